@@ -37,6 +37,12 @@ var (
 	orgNames          []string
 	managerApiAddr    string
 	trustProxyHeaders bool
+	certBinding       bool
+	maxConnections    int
+	messageRate       float64
+	messageBurst      int
+	maxMessageSize    int64
+	gatewayId         string
 	otelCollectorAddr string
 	logFormat         string
 )
@@ -117,6 +123,14 @@ var serveCmd = &cobra.Command{
 			slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 		}
 
+		if gatewayId == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return fmt.Errorf("getting hostname for gateway id: %w", err)
+			}
+			gatewayId = hostname
+		}
+
 		shutdown, err := initProvider(otelCollectorAddr)
 		if err != nil {
 			return err
@@ -145,6 +159,11 @@ var serveCmd = &cobra.Command{
 			server.WithDeviceRegistry(remoteRegistry),
 			server.WithOrgNames(orgNames),
 			server.WithTrustProxyHeaders(trustProxyHeaders),
+			server.WithCertificateBinding(certBinding),
+			server.WithMaxConnectionsPerClient(maxConnections),
+			server.WithMessageRateLimit(messageRate, messageBurst),
+			server.WithMaxMessageSize(maxMessageSize),
+			server.WithGatewayId(gatewayId),
 			server.WithOtelTracer(tracer))
 		wsServer := server.New("ws", wsAddr, nil, websocketHandler)
 		var wssServer *server.Server
@@ -227,6 +246,18 @@ func init() {
 		"The address of the CSMS manager API, e.g. http://127.0.0.1:9410")
 	serveCmd.Flags().BoolVar(&trustProxyHeaders, "trust-proxy", false,
 		"Trust proxy headers when determining the client's TLS status")
+	serveCmd.Flags().BoolVar(&certBinding, "require-cert-binding", false,
+		"Require charge stations using client certificates to present the certificate most recently issued to them")
+	serveCmd.Flags().IntVar(&maxConnections, "max-connections-per-client", 0,
+		"The maximum number of concurrent connections for each charge station, 0 for no limit")
+	serveCmd.Flags().Float64Var(&messageRate, "message-rate-limit", 0,
+		"The maximum number of requests per second that each charge station can send, 0 for no limit")
+	serveCmd.Flags().IntVar(&messageBurst, "message-rate-burst", 10,
+		"The number of requests that a charge station can send in a burst before the message rate limit applies")
+	serveCmd.Flags().Int64Var(&maxMessageSize, "max-message-size", 32768,
+		"The maximum size in bytes of a message sent by a charge station, larger messages close the connection")
+	serveCmd.Flags().StringVar(&gatewayId, "gateway-id", "",
+		"The identifier reported to the CSMS for connections to this gateway, defaults to the hostname")
 	serveCmd.Flags().StringVar(&otelCollectorAddr, "otel-collector-addr", "",
 		"The address of the open telemetry collector that will receive traces, e.g. localhost:4317")
 	serveCmd.Flags().StringVar(&logFormat, "log-format", "text",
@@ -18,6 +18,7 @@ require (
 	go.opentelemetry.io/otel/trace v1.16.0
 	go.uber.org/goleak v1.2.1
 	golang.org/x/exp v0.0.0-20230728194245-b0cb94b80691
+	golang.org/x/time v0.8.0
 	google.golang.org/grpc v1.56.3
 	nhooyr.io/websocket v1.8.7
 )
@@ -2,7 +2,10 @@
 
 package registry
 
-import "crypto/x509"
+import (
+	"crypto/x509"
+	"errors"
+)
 
 type SecurityProfile int
 
@@ -17,9 +20,23 @@ type ChargeStation struct {
 	SecurityProfile        SecurityProfile
 	Base64SHA256Password   string
 	InvalidUsernameAllowed bool
+	ClientCertificateHash  string
+	// PendingSecurityProfile is set while the charge station is being upgraded to a higher
+	// security profile: the charge station may connect using either security profile
+	PendingSecurityProfile *SecurityProfile
+	// PendingBase64SHA256Password is set while the charge station's password is being rotated:
+	// the charge station may connect using either password
+	PendingBase64SHA256Password string
 }
 
+// ErrCertificateRevoked is returned by LookupCertificate when the certificate has been revoked
+var ErrCertificateRevoked = errors.New("certificate has been revoked")
+
 type DeviceRegistry interface {
 	LookupChargeStation(clientId string) (*ChargeStation, error)
 	LookupCertificate(certHash string) (*x509.Certificate, error)
+	// ConfirmSecurityProfile reports that the charge station has connected using its pending security profile
+	ConfirmSecurityProfile(clientId string, securityProfile SecurityProfile) error
+	// ConfirmPassword reports that the charge station has connected using its pending password
+	ConfirmPassword(clientId string) error
 }
@@ -5,14 +5,22 @@ package registry
 import "crypto/x509"
 
 type MockRegistry struct {
-	ChargeStations map[string]*ChargeStation
-	Certificates   map[string]*x509.Certificate
+	ChargeStations      map[string]*ChargeStation
+	Certificates        map[string]*x509.Certificate
+	RevokedCertificates map[string]bool
+	// ConfirmedSecurityProfiles records the security profiles confirmed by ConfirmSecurityProfile
+	ConfirmedSecurityProfiles map[string]SecurityProfile
+	// ConfirmedPasswords records the charge stations confirmed by ConfirmPassword
+	ConfirmedPasswords map[string]bool
 }
 
 func NewMockRegistry() *MockRegistry {
 	return &MockRegistry{
-		ChargeStations: make(map[string]*ChargeStation),
-		Certificates:   make(map[string]*x509.Certificate),
+		ChargeStations:            make(map[string]*ChargeStation),
+		Certificates:              make(map[string]*x509.Certificate),
+		RevokedCertificates:       make(map[string]bool),
+		ConfirmedSecurityProfiles: make(map[string]SecurityProfile),
+		ConfirmedPasswords:        make(map[string]bool),
 	}
 }
 
@@ -21,5 +29,18 @@ func (m MockRegistry) LookupChargeStation(clientId string) (*ChargeStation, erro
 }
 
 func (m MockRegistry) LookupCertificate(certHash string) (*x509.Certificate, error) {
+	if m.RevokedCertificates[certHash] {
+		return nil, ErrCertificateRevoked
+	}
 	return m.Certificates[certHash], nil
 }
+
+func (m MockRegistry) ConfirmSecurityProfile(clientId string, securityProfile SecurityProfile) error {
+	m.ConfirmedSecurityProfiles[clientId] = securityProfile
+	return nil
+}
+
+func (m MockRegistry) ConfirmPassword(clientId string) error {
+	m.ConfirmedPasswords[clientId] = true
+	return nil
+}
@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"bytes"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
@@ -15,9 +16,12 @@ type RemoteRegistry struct {
 }
 
 type ChargeStationDetailsResponse struct {
-	SecurityProfile        int    `json:"security_profile"`
-	Base64SHA256Password   string `json:"base64_SHA256_password,omitempty"`
-	InvalidUsernameAllowed bool   `json:"invalid_username_allowed,omitempty"`
+	SecurityProfile             int    `json:"security_profile"`
+	Base64SHA256Password        string `json:"base64_SHA256_password,omitempty"`
+	InvalidUsernameAllowed      bool   `json:"invalid_username_allowed,omitempty"`
+	ClientCertificateHash       string `json:"client_certificate_hash,omitempty"`
+	PendingSecurityProfile      *int   `json:"pending_security_profile,omitempty"`
+	PendingBase64SHA256Password string `json:"pending_base64_SHA256_password,omitempty"`
 }
 
 func (r RemoteRegistry) LookupChargeStation(clientId string) (*ChargeStation, error) {
@@ -46,17 +50,74 @@ func (r RemoteRegistry) LookupChargeStation(clientId string) (*ChargeStation, er
 		if err != nil {
 			return nil, fmt.Errorf("unmarshaling data: %w", err)
 		}
+		var pendingSecurityProfile *SecurityProfile
+		if chargeStationDetails.PendingSecurityProfile != nil {
+			securityProfile := SecurityProfile(*chargeStationDetails.PendingSecurityProfile)
+			pendingSecurityProfile = &securityProfile
+		}
 		return &ChargeStation{
-			ClientId:               clientId,
-			SecurityProfile:        SecurityProfile(chargeStationDetails.SecurityProfile),
-			Base64SHA256Password:   chargeStationDetails.Base64SHA256Password,
-			InvalidUsernameAllowed: chargeStationDetails.InvalidUsernameAllowed,
+			ClientId:                    clientId,
+			SecurityProfile:             SecurityProfile(chargeStationDetails.SecurityProfile),
+			Base64SHA256Password:        chargeStationDetails.Base64SHA256Password,
+			InvalidUsernameAllowed:      chargeStationDetails.InvalidUsernameAllowed,
+			ClientCertificateHash:       chargeStationDetails.ClientCertificateHash,
+			PendingSecurityProfile:      pendingSecurityProfile,
+			PendingBase64SHA256Password: chargeStationDetails.PendingBase64SHA256Password,
 		}, nil
 	}
 
 	return nil, nil
 }
 
+type ConfirmSecurityProfileRequest struct {
+	SecurityProfile int `json:"security_profile"`
+}
+
+func (r RemoteRegistry) ConfirmSecurityProfile(clientId string, securityProfile SecurityProfile) error {
+	b, err := json.Marshal(ConfirmSecurityProfileRequest{SecurityProfile: int(securityProfile)})
+	if err != nil {
+		return fmt.Errorf("marshaling data: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v0/cs/%s/security-profile-upgrade/reconnect", r.ManagerApiAddr, clientId), bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	req.Header.Set("content-type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("making http request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected http status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (r RemoteRegistry) ConfirmPassword(clientId string) error {
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v0/cs/%s/password/reconnect", r.ManagerApiAddr, clientId), nil)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("making http request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected http status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
 type CertificateResponse struct {
 	Certificate string `json:"certificate"`
 }
@@ -102,6 +163,9 @@ func (r RemoteRegistry) LookupCertificate(certHash string) (*x509.Certificate, e
 			return nil, fmt.Errorf("no certificate found in PEM data")
 		}
 	}
+	if resp.StatusCode == http.StatusGone {
+		return nil, ErrCertificateRevoked
+	}
 
 	return nil, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"io"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
@@ -43,6 +44,129 @@ func TestLookupChargeStation(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestLookupChargeStationWithClientCertificateHash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"security_profile":2,"client_certificate_hash":"3I9bc6nHu0BPaJsmBqMRyUHaRI5GY7yhDkCWoRmdL4A"}`))
+	}))
+	defer server.Close()
+
+	reg := registry.RemoteRegistry{
+		ManagerApiAddr: server.URL,
+	}
+
+	want := &registry.ChargeStation{
+		ClientId:              "cs001",
+		SecurityProfile:       2,
+		ClientCertificateHash: "3I9bc6nHu0BPaJsmBqMRyUHaRI5GY7yhDkCWoRmdL4A",
+	}
+
+	got, _ := reg.LookupChargeStation("cs001")
+	require.NotNil(t, got)
+
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationWithPendingSecurityProfile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"security_profile":1,"pending_security_profile":2}`))
+	}))
+	defer server.Close()
+
+	reg := registry.RemoteRegistry{
+		ManagerApiAddr: server.URL,
+	}
+
+	pendingSecurityProfile := registry.TLSWithClientSideCertificates
+	want := &registry.ChargeStation{
+		ClientId:               "cs001",
+		SecurityProfile:        1,
+		PendingSecurityProfile: &pendingSecurityProfile,
+	}
+
+	got, _ := reg.LookupChargeStation("cs001")
+	require.NotNil(t, got)
+
+	assert.Equal(t, want, got)
+}
+
+func TestConfirmSecurityProfile(t *testing.T) {
+	var gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	reg := registry.RemoteRegistry{
+		ManagerApiAddr: server.URL,
+	}
+
+	err := reg.ConfirmSecurityProfile("cs001", registry.TLSWithClientSideCertificates)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/v0/cs/cs001/security-profile-upgrade/reconnect", gotPath)
+	assert.JSONEq(t, `{"security_profile":2}`, gotBody)
+}
+
+func TestLookupChargeStationWithPendingPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"security_profile":1,"base64_SHA256_password":"DEADBEEF","pending_base64_SHA256_password":"BEEFDEAD"}`))
+	}))
+	defer server.Close()
+
+	reg := registry.RemoteRegistry{
+		ManagerApiAddr: server.URL,
+	}
+
+	want := &registry.ChargeStation{
+		ClientId:                    "cs001",
+		SecurityProfile:             1,
+		Base64SHA256Password:        "DEADBEEF",
+		PendingBase64SHA256Password: "BEEFDEAD",
+	}
+
+	got, _ := reg.LookupChargeStation("cs001")
+	require.NotNil(t, got)
+
+	assert.Equal(t, want, got)
+}
+
+func TestConfirmPassword(t *testing.T) {
+	var gotMethod, gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	reg := registry.RemoteRegistry{
+		ManagerApiAddr: server.URL,
+	}
+
+	err := reg.ConfirmPassword("cs001")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPost, gotMethod)
+	assert.Equal(t, "/api/v0/cs/cs001/password/reconnect", gotPath)
+}
+
+func TestConfirmSecurityProfileWithNoUpgrade(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	reg := registry.RemoteRegistry{
+		ManagerApiAddr: server.URL,
+	}
+
+	err := reg.ConfirmSecurityProfile("cs001", registry.TLSWithClientSideCertificates)
+	assert.Error(t, err)
+}
+
 func TestLookupCertificate(t *testing.T) {
 	want := generateCertificate(t)
 
@@ -66,6 +190,22 @@ func TestLookupCertificate(t *testing.T) {
 	assert.Equal(t, want.Raw, got.Raw)
 }
 
+func TestLookupRevokedCertificate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+		_, _ = w.Write([]byte(`{"status":"Gone","error":"certificate has been revoked"}`))
+	}))
+	defer server.Close()
+
+	reg := registry.RemoteRegistry{
+		ManagerApiAddr: server.URL,
+	}
+
+	got, err := reg.LookupCertificate("certificate-hash")
+	assert.ErrorIs(t, err, registry.ErrCertificateRevoked)
+	assert.Nil(t, got)
+}
+
 func TestLookupCertificateWithSlashesAndPlusesInHash(t *testing.T) {
 	var want *x509.Certificate
 	var certHash [32]byte
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+)
+
+const (
+	connectionEventConnected    = "Connected"
+	connectionEventDisconnected = "Disconnected"
+	controlActionDisconnect     = "Disconnect"
+)
+
+// connectionEvent is published on <prefix>/connection/<cs-id> when a charge station connects
+// to or disconnects from the gateway so that the manager can track where each charge station
+// is connected
+type connectionEvent struct {
+	Event           string     `json:"event"`
+	ConnectionId    string     `json:"connection_id"`
+	GatewayId       string     `json:"gateway_id"`
+	RemoteAddress   string     `json:"remote_address,omitempty"`
+	Subprotocol     string     `json:"subprotocol,omitempty"`
+	SecurityProfile int        `json:"security_profile"`
+	ConnectedAt     *time.Time `json:"connected_at,omitempty"`
+}
+
+// connectionControl is received on <prefix>/control/<cs-id> when the manager wants the gateway
+// to take action on a charge station's connection
+type connectionControl struct {
+	Action       string `json:"action"`
+	ConnectionId string `json:"connection_id,omitempty"`
+}
+
+// stationConnection holds the details of a single charge station websocket connection
+type stationConnection struct {
+	id              string
+	gatewayId       string
+	remoteAddress   string
+	subprotocol     string
+	securityProfile registry.SecurityProfile
+	connectedAt     time.Time
+}
+
+func newConnectionId() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("generating connection id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (c *stationConnection) event(eventType string) ([]byte, error) {
+	event := connectionEvent{
+		Event:           eventType,
+		ConnectionId:    c.id,
+		GatewayId:       c.gatewayId,
+		RemoteAddress:   c.remoteAddress,
+		Subprotocol:     c.subprotocol,
+		SecurityProfile: int(c.securityProfile),
+	}
+	if eventType == connectionEventConnected {
+		event.ConnectedAt = &c.connectedAt
+	}
+	b, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling connection event: %w", err)
+	}
+	return b, nil
+}
+
+// shouldDisconnect returns true if the control message asks for this connection to be closed:
+// a disconnect without a connection id applies to every connection for the charge station
+func (c *stationConnection) shouldDisconnect(payload []byte) (bool, error) {
+	var control connectionControl
+	err := json.Unmarshal(payload, &control)
+	if err != nil {
+		return false, fmt.Errorf("unmarshalling connection control: %w", err)
+	}
+	return control.Action == controlActionDisconnect && (control.ConnectionId == "" || control.ConnectionId == c.id), nil
+}
+
+func publishConnectionEvent(ctx context.Context, mqttConn *autopaho.ConnectionManager, topic string, conn *stationConnection, eventType string) error {
+	payload, err := conn.event(eventType)
+	if err != nil {
+		return err
+	}
+	_, err = mqttConn.Publish(ctx, &paho.Publish{
+		Topic:   topic,
+		Payload: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("publishing connection event to %s: %w", topic, err)
+	}
+	return nil
+}
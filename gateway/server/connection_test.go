@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
+)
+
+type connectionEvent struct {
+	Event           string     `json:"event"`
+	ConnectionId    string     `json:"connection_id"`
+	GatewayId       string     `json:"gateway_id"`
+	RemoteAddress   string     `json:"remote_address"`
+	Subprotocol     string     `json:"subprotocol"`
+	SecurityProfile int        `json:"security_profile"`
+	ConnectedAt     *time.Time `json:"connected_at"`
+}
+
+func TestConnectionEventsArePublished(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	addr := startEchoManager(ctx, t)
+	client, events := listenForConnectionEvents(ctx, t, addr)
+	defer func() {
+		_ = client.Disconnect(context.Background())
+	}()
+
+	srv, dialOptions := startLimitedWebsocketServer(t, "connectionEventsCS1", addr,
+		server.WithGatewayId("gateway-1"))
+	defer srv.Close()
+
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/connectionEventsCS1", srv.URL), dialOptions)
+	require.NoError(t, err)
+
+	connected := waitForConnectionEvent(ctx, t, events)
+	assert.Equal(t, "Connected", connected.Event)
+	assert.NotEmpty(t, connected.ConnectionId)
+	assert.Equal(t, "gateway-1", connected.GatewayId)
+	assert.NotEmpty(t, connected.RemoteAddress)
+	assert.Equal(t, "ocpp2.0.1", connected.Subprotocol)
+	assert.Equal(t, 0, connected.SecurityProfile)
+	assert.NotNil(t, connected.ConnectedAt)
+
+	_ = conn.Close(websocket.StatusNormalClosure, "OK")
+
+	disconnected := waitForConnectionEvent(ctx, t, events)
+	assert.Equal(t, "Disconnected", disconnected.Event)
+	assert.Equal(t, connected.ConnectionId, disconnected.ConnectionId)
+	assert.Equal(t, "gateway-1", disconnected.GatewayId)
+}
+
+func TestConnectionIsClosedByControlMessage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	addr := startEchoManager(ctx, t)
+	client, events := listenForConnectionEvents(ctx, t, addr)
+	defer func() {
+		_ = client.Disconnect(context.Background())
+	}()
+
+	srv, dialOptions := startLimitedWebsocketServer(t, "controlDisconnectCS1", addr)
+	defer srv.Close()
+
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/controlDisconnectCS1", srv.URL), dialOptions)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	connected := waitForConnectionEvent(ctx, t, events)
+	require.Equal(t, "Connected", connected.Event)
+
+	// a request to disconnect a different connection is ignored
+	_, err = client.Publish(ctx, &paho.Publish{
+		Topic:   "cs/control/controlDisconnectCS1",
+		Payload: []byte(`{"action":"Disconnect","connection_id":"another-connection"}`),
+	})
+	require.NoError(t, err)
+
+	msg := sendEchoRequest(ctx, t, conn, "1")
+	assert.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+
+	_, err = client.Publish(ctx, &paho.Publish{
+		Topic:   "cs/control/controlDisconnectCS1",
+		Payload: []byte(fmt.Sprintf(`{"action":"Disconnect","connection_id":"%s"}`, connected.ConnectionId)),
+	})
+	require.NoError(t, err)
+
+	_, _, err = conn.Read(ctx)
+	assert.Equal(t, websocket.StatusNormalClosure, websocket.CloseStatus(err))
+
+	disconnected := waitForConnectionEvent(ctx, t, events)
+	assert.Equal(t, "Disconnected", disconnected.Event)
+	assert.Equal(t, connected.ConnectionId, disconnected.ConnectionId)
+}
+
+// listenForConnectionEvents connects a client that simulates the manager by receiving the
+// connection events published by the gateway
+func listenForConnectionEvents(ctx context.Context, t *testing.T, addr *url.URL) (*autopaho.ConnectionManager, chan *connectionEvent) {
+	events := make(chan *connectionEvent, 10)
+	client, err := autopaho.NewConnection(ctx, autopaho.ClientConfig{
+		BrokerUrls:        []*url.URL{addr},
+		KeepAlive:         10,
+		ConnectRetryDelay: 2 * time.Second,
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
+			_, err := manager.Subscribe(ctx, &paho.Subscribe{
+				Subscriptions: map[string]paho.SubscribeOptions{
+					"cs/connection/+": {},
+				},
+			})
+			require.NoError(t, err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: "connectionEvents",
+			Router: paho.NewSingleHandlerRouter(func(publish *paho.Publish) {
+				var event connectionEvent
+				err := json.Unmarshal(publish.Payload, &event)
+				require.NoError(t, err)
+				events <- &event
+			}),
+		},
+	})
+	require.NoError(t, err)
+	err = client.AwaitConnection(ctx)
+	require.NoError(t, err)
+	return client, events
+}
+
+func waitForConnectionEvent(ctx context.Context, t *testing.T, events chan *connectionEvent) *connectionEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-ctx.Done():
+		t.Fatalf("timeout waiting for connection event")
+		return nil
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+const (
+	limitMessageRate  = "message_rate"
+	limitMessageSize  = "message_size"
+	limitConnections  = "connections"
+	defaultMaxMessage = 32768
+)
+
+var limitExceededCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gateway",
+	Name:      "limit_exceeded_total",
+	Help:      "The number of times a charge station has exceeded one of the gateway limits",
+}, []string{"limit"})
+
+// stationLimiter tracks the connections and message rate for each charge station. The message
+// rate limit is shared by all the connections for a charge station and is kept after the charge
+// station disconnects, so reconnecting does not reset it. A charge station's limit is dropped once
+// it has had no connections for long enough for its message allowance to have refilled.
+type stationLimiter struct {
+	sync.Mutex
+	maxConnections    int
+	messagesPerSecond float64
+	messageBurst      int
+	idleTimeout       time.Duration
+	lastPruned        time.Time
+	stations          map[string]*stationLimit
+}
+
+type stationLimit struct {
+	connections int
+	messages    *rate.Limiter
+	// idleSince is when the last connection was closed
+	idleSince time.Time
+}
+
+// minLimitIdleTimeout is the shortest time that a charge station's limit is kept once it has
+// no connections
+const minLimitIdleTimeout = time.Minute
+
+func newStationLimiter(maxConnections int, messagesPerSecond float64, messageBurst int) *stationLimiter {
+	if messageBurst <= 0 {
+		messageBurst = 1
+	}
+	idleTimeout := minLimitIdleTimeout
+	if messagesPerSecond > 0 {
+		refill := time.Duration(float64(messageBurst) / messagesPerSecond * float64(time.Second))
+		if refill > idleTimeout {
+			idleTimeout = refill
+		}
+	}
+	return &stationLimiter{
+		maxConnections:    maxConnections,
+		messagesPerSecond: messagesPerSecond,
+		messageBurst:      messageBurst,
+		idleTimeout:       idleTimeout,
+		lastPruned:        time.Now(),
+		stations:          make(map[string]*stationLimit),
+	}
+}
+
+// acquire registers a new connection for the charge station. It returns false if the charge
+// station already has the maximum number of connections, otherwise the caller must call release
+// when the connection is closed.
+func (l *stationLimiter) acquire(clientId string) (*stationLimit, bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	now := time.Now()
+	l.prune(now)
+
+	station := l.stations[clientId]
+	if station == nil {
+		station = &stationLimit{}
+		if l.messagesPerSecond > 0 {
+			station.messages = rate.NewLimiter(rate.Limit(l.messagesPerSecond), l.messageBurst)
+		}
+		l.stations[clientId] = station
+	}
+	if l.maxConnections > 0 && station.connections >= l.maxConnections {
+		limitExceededCounter.WithLabelValues(limitConnections).Inc()
+		return nil, false
+	}
+	station.connections++
+	return station, true
+}
+
+func (l *stationLimiter) release(clientId string) {
+	l.Lock()
+	defer l.Unlock()
+
+	station := l.stations[clientId]
+	if station == nil {
+		return
+	}
+	station.connections--
+	if station.connections <= 0 {
+		station.connections = 0
+		station.idleSince = time.Now()
+	}
+}
+
+// prune drops the limits for the charge stations that have been idle for longer than the idle
+// timeout: the stations are only checked once per idle timeout
+func (l *stationLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPruned) < l.idleTimeout {
+		return
+	}
+	l.lastPruned = now
+	for clientId, station := range l.stations {
+		if station.connections == 0 && now.Sub(station.idleSince) >= l.idleTimeout {
+			delete(l.stations, clientId)
+		}
+	}
+}
+
+// allowMessage returns true if the charge station has not exceeded its message rate
+func (s *stationLimit) allowMessage() bool {
+	if s.messages == nil || s.messages.Allow() {
+		return true
+	}
+	limitExceededCounter.WithLabelValues(limitMessageRate).Inc()
+	return false
+}
@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"github.com/thoughtworks/maeve-csms/gateway/pipe"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
+)
+
+func TestMessageRateLimit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	addr := startEchoManager(ctx, t)
+	srv, dialOptions := startLimitedWebsocketServer(t, "rateLimitedCS1", addr,
+		server.WithMessageRateLimit(0.001, 1))
+	defer srv.Close()
+
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/rateLimitedCS1", srv.URL), dialOptions)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	msg := sendEchoRequest(ctx, t, conn, "1")
+	assert.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+	assert.Equal(t, "1", msg.MessageId)
+
+	msg = sendEchoRequest(ctx, t, conn, "2")
+	assert.Equal(t, ocpp.MessageTypeCallError, msg.MessageTypeId)
+	assert.Equal(t, "2", msg.MessageId)
+	assert.Equal(t, `"RpcFrameworkError"`, string(msg.Data[0]))
+
+	assert.Contains(t, readMetrics(t), `gateway_limit_exceeded_total{limit="message_rate"}`)
+}
+
+func TestMessageRateLimitIsKeptWhenChargeStationReconnects(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	addr := startEchoManager(ctx, t)
+	srv, dialOptions := startLimitedWebsocketServer(t, "rateLimitedCS2", addr,
+		server.WithMessageRateLimit(0.001, 1))
+	defer srv.Close()
+
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/rateLimitedCS2", srv.URL), dialOptions)
+	require.NoError(t, err)
+
+	msg := sendEchoRequest(ctx, t, conn, "1")
+	assert.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+	_ = conn.Close(websocket.StatusNormalClosure, "OK")
+
+	conn, _, err = websocket.Dial(ctx, fmt.Sprintf("%s/ws/rateLimitedCS2", srv.URL), dialOptions)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	msg = sendEchoRequest(ctx, t, conn, "2")
+	assert.Equal(t, ocpp.MessageTypeCallError, msg.MessageTypeId)
+	assert.Equal(t, "2", msg.MessageId)
+}
+
+func TestMaxMessageSize(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	addr := startEchoManager(ctx, t)
+	srv, dialOptions := startLimitedWebsocketServer(t, "maxMessageSizeCS1", addr,
+		server.WithMaxMessageSize(64))
+	defer srv.Close()
+
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/maxMessageSizeCS1", srv.URL), dialOptions)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	msg := sendEchoRequest(ctx, t, conn, "1")
+	assert.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+
+	err = conn.Write(ctx, websocket.MessageText, []byte(fmt.Sprintf(`[2,"2","EchoRequest","%s"]`, strings.Repeat("x", 100))))
+	require.NoError(t, err)
+
+	_, _, err = conn.Read(ctx)
+	assert.Equal(t, websocket.StatusMessageTooBig, websocket.CloseStatus(err))
+
+	assert.Contains(t, readMetrics(t), `gateway_limit_exceeded_total{limit="message_size"}`)
+}
+
+func TestMaxConnectionsPerClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	addr := startEchoManager(ctx, t)
+	srv, dialOptions := startLimitedWebsocketServer(t, "maxConnectionsCS1", addr,
+		server.WithMaxConnectionsPerClient(1))
+	defer srv.Close()
+
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/maxConnectionsCS1", srv.URL), dialOptions)
+	require.NoError(t, err)
+
+	secondConn, resp, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/maxConnectionsCS1", srv.URL), dialOptions)
+	if err == nil {
+		_ = secondConn.Close(websocket.StatusNormalClosure, "OK")
+		t.Fatalf("expected error dialing CSMS")
+	}
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Contains(t, readMetrics(t), `gateway_limit_exceeded_total{limit="connections"}`)
+
+	// the charge station can connect again once the first connection has closed
+	_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	require.Eventually(t, func() bool {
+		conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/maxConnectionsCS1", srv.URL), dialOptions)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+		return true
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+// startEchoManager starts an MQTT broker with a client that simulates the manager by responding
+// to each request with its own payload
+func startEchoManager(ctx context.Context, t *testing.T) *url.URL {
+	broker, addr := server.NewBroker(t)
+	err := broker.Serve()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = broker.Close()
+	})
+
+	client, err := autopaho.NewConnection(ctx, autopaho.ClientConfig{
+		BrokerUrls:        []*url.URL{addr},
+		KeepAlive:         10,
+		ConnectRetryDelay: 2 * time.Second,
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
+			_, err := manager.Subscribe(ctx, &paho.Subscribe{
+				Subscriptions: map[string]paho.SubscribeOptions{
+					"cs/in/ocpp2.0.1/+": {},
+				},
+			})
+			require.NoError(t, err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: "test",
+			Router: paho.NewSingleHandlerRouter(func(publish *paho.Publish) {
+				var reqMsg pipe.GatewayMessage
+				err := json.Unmarshal(publish.Payload, &reqMsg)
+				require.NoError(t, err)
+
+				b, err := json.Marshal(pipe.GatewayMessage{
+					MessageType:     ocpp.MessageTypeCallResult,
+					MessageId:       reqMsg.MessageId,
+					ResponsePayload: reqMsg.RequestPayload,
+				})
+				require.NoError(t, err)
+				err = broker.Publish(publish.Properties.ResponseTopic, b, false, 0)
+				require.NoError(t, err)
+			}),
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Disconnect(context.Background())
+	})
+	err = client.AwaitConnection(ctx)
+	require.NoError(t, err)
+
+	return addr
+}
+
+func startLimitedWebsocketServer(t *testing.T, clientId string, addr *url.URL, opts ...server.WebsocketOpt) (*httptest.Server, *websocket.DialOptions) {
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[clientId] = &registry.ChargeStation{
+		ClientId:             clientId,
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+
+	opts = append(opts,
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithMqttConnectSettings(15*time.Second, 15*time.Second, 5*time.Second))
+	srv := httptest.NewServer(server.NewWebsocketHandler(opts...))
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", clientId, "password")))
+	return srv, &websocket.DialOptions{
+		Subprotocols: []string{"ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	}
+}
+
+func sendEchoRequest(ctx context.Context, t *testing.T, conn *websocket.Conn, messageId string) *ocpp.Message {
+	err := conn.Write(ctx, websocket.MessageText, []byte(fmt.Sprintf(`[2,"%s","EchoRequest","Payload"]`, messageId)))
+	require.NoError(t, err)
+
+	_, b, err := conn.Read(ctx)
+	require.NoError(t, err)
+	var msg ocpp.Message
+	err = json.Unmarshal(b, &msg)
+	require.NoError(t, err)
+	return &msg
+}
+
+func readMetrics(t *testing.T) string {
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	server.NewStatusHandler().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	b, err := io.ReadAll(w.Result().Body)
+	require.NoError(t, err)
+	return string(b)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	"go.opentelemetry.io/otel/attribute"
@@ -42,7 +43,7 @@ func TraceRequest(tracer trace.Tracer) func(http.Handler) http.Handler {
 	}
 }
 
-func TLSOffload(registry registry.DeviceRegistry) func(http.Handler) http.Handler {
+func TLSOffload(deviceRegistry registry.DeviceRegistry) func(http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			span := trace.SpanFromContext(r.Context())
@@ -65,9 +66,12 @@ func TLSOffload(registry registry.DeviceRegistry) func(http.Handler) http.Handle
 					if err == nil && clientCertChainValid {
 						clientCertHashHeader := r.Header.Get("X-Client-Cert-Hash")
 						span.SetAttributes(attribute.String("cert.hash", clientCertHashHeader))
-						certificate, err := registry.LookupCertificate(clientCertHashHeader)
+						certificate, err := deviceRegistry.LookupCertificate(clientCertHashHeader)
 						if err == nil && certificate != nil {
 							r.TLS.PeerCertificates = []*x509.Certificate{certificate}
+						} else if errors.Is(err, registry.ErrCertificateRevoked) {
+							span.SetAttributes(attribute.String("cert.lookup.error", "Revoked"))
+							slog.Warn("certificate revoked", "clientCertHashHeader", clientCertHashHeader)
 						} else if err != nil {
 							span.SetAttributes(attribute.String("cert.lookup.error", err.Error()))
 							slog.Error("lookup certificate", "clientCertHashHeader", clientCertHashHeader, "err", err)
@@ -98,6 +98,31 @@ func TestTLSOffloadWithValidClientCertificate(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
 }
 
+func TestTLSOffloadWithRevokedClientCertificate(t *testing.T) {
+	r := chi.NewRouter()
+	reg := registry.NewMockRegistry()
+	reg.Certificates["certificate-hash"] = &x509.Certificate{}
+	reg.RevokedCertificates["certificate-hash"] = true
+	r.Use(server.TLSOffload(reg))
+	r.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && r.TLS.PeerCertificates != nil && len(r.TLS.PeerCertificates) > 0 {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Client-Cert-Present", "true")
+	req.Header.Set("X-Client-Cert-Chain-Verified", "true")
+	req.Header.Set("X-Client-Cert-Hash", "certificate-hash")
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Result().StatusCode)
+}
+
 func TestTLSOffloadWithInvalidClientCertificate(t *testing.T) {
 	r := chi.NewRouter()
 	reg := registry.NewMockRegistry()
@@ -155,6 +180,14 @@ func (e errorRegistry) LookupCertificate(certHash string) (*x509.Certificate, er
 	return nil, fmt.Errorf("expected error")
 }
 
+func (e errorRegistry) ConfirmSecurityProfile(clientId string, securityProfile registry.SecurityProfile) error {
+	return fmt.Errorf("expected error")
+}
+
+func (e errorRegistry) ConfirmPassword(clientId string) error {
+	return fmt.Errorf("expected error")
+}
+
 func TestTLSOffloadWithClientCertificateRetrievalError(t *testing.T) {
 	r := chi.NewRouter()
 	reg := errorRegistry{}
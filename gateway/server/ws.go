@@ -42,6 +42,13 @@ type WebsocketHandler struct {
 	orgNames              []string
 	pipeOptions           []pipe.Opt
 	trustProxyHeaders     bool
+	certificateBinding    bool
+	maxConnections        int
+	messagesPerSecond     float64
+	messageBurst          int
+	maxMessageSize        int64
+	limiter               *stationLimiter
+	gatewayId             string
 	tracer                trace.Tracer
 }
 
@@ -111,6 +118,47 @@ func WithTrustProxyHeaders(trustProxyHeaders bool) WebsocketOpt {
 	}
 }
 
+// WithCertificateBinding requires that a charge station using security profile 3 presents
+// the client certificate that was most recently issued to it (where the manager has recorded one)
+func WithCertificateBinding(certificateBinding bool) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.certificateBinding = certificateBinding
+	}
+}
+
+// WithMaxConnectionsPerClient limits the number of concurrent connections for each charge station:
+// further connections are rejected. Zero (the default) allows any number of connections.
+func WithMaxConnectionsPerClient(maxConnections int) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.maxConnections = maxConnections
+	}
+}
+
+// WithMessageRateLimit limits the rate at which each charge station can send requests: requests
+// that exceed the limit are rejected with an RpcFrameworkError. Zero (the default) disables the limit.
+func WithMessageRateLimit(messagesPerSecond float64, burst int) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.messagesPerSecond = messagesPerSecond
+		handler.messageBurst = burst
+	}
+}
+
+// WithMaxMessageSize limits the size of a message sent by a charge station: the connection is
+// closed if a message exceeds the limit. The default is 32KiB.
+func WithMaxMessageSize(maxMessageSize int64) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.maxMessageSize = maxMessageSize
+	}
+}
+
+// WithGatewayId sets the identifier that is reported to the CSMS for the connections made to this
+// gateway. The serve command defaults it to the hostname.
+func WithGatewayId(gatewayId string) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.gatewayId = gatewayId
+	}
+}
+
 func WithPipeOption(pipeOption pipe.Opt) WebsocketOpt {
 	return func(handler *WebsocketHandler) {
 		handler.pipeOptions = append(handler.pipeOptions, pipeOption)
@@ -176,6 +224,12 @@ func ensureDefaults(handler *WebsocketHandler) {
 	if handler.tracer == nil {
 		handler.tracer = trace.NewNoopTracerProvider().Tracer("")
 	}
+
+	if handler.maxMessageSize == 0 {
+		handler.maxMessageSize = defaultMaxMessage
+	}
+
+	handler.limiter = newStationLimiter(handler.maxConnections, handler.messagesPerSecond, handler.messageBurst)
 }
 
 func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -210,48 +264,53 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	span.SetAttributes(attribute.Int("ocpp.security_profile", int(cs.SecurityProfile)))
 
-	switch cs.SecurityProfile {
-	case registry.UnsecuredTransportWithBasicAuth:
-		if r.TLS != nil || !checkAuthorization(r.Context(), r, cs) {
-			if r.TLS != nil {
-				span.SetAttributes(attribute.String("auth.failure_reason", "tls for unsecured transport"))
-			}
-			span.SetStatus(codes.Error, "unauthorized")
-			span.SetAttributes(semconv.HTTPStatusCode(http.StatusUnauthorized))
-			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
-			return
-		}
-	case registry.TLSWithBasicAuth:
-		if r.TLS == nil || !checkAuthorization(r.Context(), r, cs) {
-			if r.TLS == nil {
-				span.SetAttributes(attribute.String("auth.failure_reason", "no tls for secured transport"))
-			}
-			span.SetStatus(codes.Error, "unauthorized")
-			span.SetAttributes(semconv.HTTPStatusCode(http.StatusUnauthorized))
-			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
-			return
-		}
-	case registry.TLSWithClientSideCertificates:
-		if r.TLS == nil || !checkCertificate(r.Context(), r, s.orgNames, cs) {
-			if r.TLS == nil {
-				span.SetAttributes(attribute.String("auth.failure_reason", "no tls for secured transport"))
+	securityProfile := cs.SecurityProfile
+	authorized := s.checkSecurityProfile(r, span, cs, cs.SecurityProfile)
+	if !authorized && cs.PendingSecurityProfile != nil {
+		// the charge station is being upgraded and may have reconnected using the new security profile
+		span.SetAttributes(attribute.Int("ocpp.pending_security_profile", int(*cs.PendingSecurityProfile)))
+		authorized = s.checkSecurityProfile(r, span, cs, *cs.PendingSecurityProfile)
+		if authorized {
+			securityProfile = *cs.PendingSecurityProfile
+			err = s.deviceRegistry.ConfirmSecurityProfile(clientId, *cs.PendingSecurityProfile)
+			if err != nil {
+				slog.Warn("unable to confirm security profile", "clientId", clientId, "err", err)
+				span.RecordError(err)
 			}
-			span.SetStatus(codes.Error, "unauthorized")
-			span.SetAttributes(semconv.HTTPStatusCode(http.StatusUnauthorized))
-			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
-			return
 		}
-	default:
+	}
+	if !authorized {
+		span.SetStatus(codes.Error, "unauthorized")
+		span.SetAttributes(semconv.HTTPStatusCode(http.StatusUnauthorized))
 		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 		return
 	}
 
+	station, ok := s.limiter.acquire(clientId)
+	if !ok {
+		span.SetStatus(codes.Error, "too many connections")
+		span.SetAttributes(semconv.HTTPStatusCode(http.StatusTooManyRequests))
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+		return
+	}
+	defer s.limiter.release(clientId)
+
+	connectionId, err := newConnectionId()
+	if err != nil {
+		span.SetStatus(codes.Error, "creating connection id")
+		span.RecordError(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
 	wsConn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{"ocpp2.0.1", "ocpp1.6"}, InsecureSkipVerify: true})
 	if err != nil {
 		span.SetAttributes(attribute.String("websocket.accept_failure_reason", err.Error()))
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
+	// allow one more byte than the limit so that read can detect messages that are too big
+	wsConn.SetReadLimit(s.maxMessageSize + 1)
 
 	protocol := wsConn.Subprotocol()
 	if protocol == "" {
@@ -260,6 +319,27 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	span.SetAttributes(attribute.String("ocpp.protocol", protocol))
 
+	conn := &stationConnection{
+		id:              connectionId,
+		gatewayId:       s.gatewayId,
+		remoteAddress:   r.RemoteAddr,
+		subprotocol:     protocol,
+		securityProfile: securityProfile,
+		connectedAt:     time.Now().UTC(),
+	}
+	span.SetAttributes(attribute.String("connection.id", conn.id))
+	connectionTopic := fmt.Sprintf("%s/connection/%s", s.mqttTopicPrefix, clientId)
+	controlTopic := fmt.Sprintf("%s/control/%s", s.mqttTopicPrefix, clientId)
+
+	// the broker publishes the disconnected event if the gateway loses its mqtt connection
+	disconnectedEvent, err := conn.event(connectionEventDisconnected)
+	if err != nil {
+		span.SetStatus(codes.Error, "creating disconnected event")
+		span.RecordError(err)
+		_ = wsConn.Close(websocket.StatusInternalError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
 	p := pipe.NewPipe(s.pipeOptions...)
 	p.Start()
 	defer p.Close()
@@ -273,8 +353,7 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	span.SetAttributes(attribute.StringSlice("mqtt.broker_urls", mqttBrokerURLStrings))
 
-	var mqttConn *autopaho.ConnectionManager
-	mqttConn, err = autopaho.NewConnection(ctx, autopaho.ClientConfig{
+	mqttConfig := autopaho.ClientConfig{
 		BrokerUrls:        s.mqttBrokerURLs,
 		KeepAlive:         s.mqttKeepAliveInterval,
 		ConnectRetryDelay: s.mqttConnectRetryDelay,
@@ -283,7 +362,8 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			span.SetAttributes(attribute.String("mqtt.topic", topicName))
 			_, err = manager.Subscribe(ctx, &paho.Subscribe{
 				Subscriptions: map[string]paho.SubscribeOptions{
-					topicName: {},
+					topicName:    {},
+					controlTopic: {},
 				},
 			})
 			if err != nil {
@@ -294,10 +374,32 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				_ = wsConn.Close(websocket.StatusProtocolError, http.StatusText(http.StatusInternalServerError))
 				return
 			}
+
+			// (re-)publish the connected event: the CSMS may have received the disconnected event
+			// if the gateway lost its mqtt connection
+			err = publishConnectionEvent(ctx, manager, connectionTopic, conn, connectionEventConnected)
+			if err != nil {
+				slog.Warn("publishing connected event", "clientId", clientId, "err", err)
+			}
 		},
 		ClientConfig: paho.ClientConfig{
 			ClientID: clientId,
 			Router: paho.NewSingleHandlerRouter(func(mqttMsg *paho.Publish) {
+				if mqttMsg.Topic == controlTopic {
+					disconnect, err := conn.shouldDisconnect(mqttMsg.Payload)
+					if err != nil {
+						slog.Error("unmarshalling CSMS control message", "err", err)
+						return
+					}
+					if disconnect {
+						slog.Info("disconnecting charge station at request of CSMS", "clientId", clientId, "connectionId", conn.id)
+						go func() {
+							_ = wsConn.Close(websocket.StatusNormalClosure, "disconnected by CSMS")
+						}()
+					}
+					return
+				}
+
 				// route requests from the CSMS
 				var msg pipe.GatewayMessage
 				err := json.Unmarshal(mqttMsg.Payload, &msg)
@@ -332,7 +434,11 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				span.SetAttributes(attribute.String("mqtt.disconnect_reason", disconnect.Properties.ReasonString))
 			},
 		},
-	})
+	}
+	mqttConfig.SetWillMessage(connectionTopic, disconnectedEvent, 1, false)
+
+	var mqttConn *autopaho.ConnectionManager
+	mqttConn, err = autopaho.NewConnection(ctx, mqttConfig)
 	if err != nil {
 		span.SetStatus(codes.Error, "connecting to mqtt")
 		span.RecordError(err)
@@ -352,6 +458,15 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		slog.Error("waiting for mqtt", "mqttBrokerURLs", s.mqttBrokerURLs, "err", err)
 		return
 	}
+	defer func() {
+		// a will message is not sent when the mqtt connection is closed normally
+		ctx, cancel := context.WithTimeout(context.Background(), s.mqttConnectTimeout)
+		defer cancel()
+		err := publishConnectionEvent(ctx, mqttConn, connectionTopic, conn, connectionEventDisconnected)
+		if err != nil {
+			slog.Warn("publishing disconnected event", "clientId", clientId, "err", err)
+		}
+	}()
 
 	// we've finished connecting... complete this span so we get to see the details in the trace
 	span.End()
@@ -366,7 +481,33 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	goWriteToChargeStation(ctx, s.tracer, p.ChargeStationTx, wsConn, protocol, clientId)
 
 	// read from the websocket and send to the CS Rx channel (CS Tx used for error)
-	readFromChargeStation(ctx, s.tracer, wsConn, p.ChargeStationRx, p.ChargeStationTx, protocol, clientId)
+	readFromChargeStation(ctx, s.tracer, wsConn, station, s.maxMessageSize, p.ChargeStationRx, p.ChargeStationTx, protocol, clientId)
+}
+
+// checkSecurityProfile returns true if the connection satisfies the requirements of the security profile
+func (s *WebsocketHandler) checkSecurityProfile(r *http.Request, span trace.Span, cs *registry.ChargeStation, securityProfile registry.SecurityProfile) bool {
+	switch securityProfile {
+	case registry.UnsecuredTransportWithBasicAuth:
+		if r.TLS != nil {
+			span.SetAttributes(attribute.String("auth.failure_reason", "tls for unsecured transport"))
+			return false
+		}
+		return checkAuthorization(r.Context(), r, s.deviceRegistry, cs)
+	case registry.TLSWithBasicAuth:
+		if r.TLS == nil {
+			span.SetAttributes(attribute.String("auth.failure_reason", "no tls for secured transport"))
+			return false
+		}
+		return checkAuthorization(r.Context(), r, s.deviceRegistry, cs)
+	case registry.TLSWithClientSideCertificates:
+		if r.TLS == nil {
+			span.SetAttributes(attribute.String("auth.failure_reason", "no tls for secured transport"))
+			return false
+		}
+		return checkCertificate(r.Context(), r, s.orgNames, s.deviceRegistry, s.certificateBinding, cs)
+	default:
+		return false
+	}
 }
 
 func getScheme(r *http.Request) string {
@@ -408,7 +549,7 @@ func heartbeat(ctx context.Context, wsConn *websocket.Conn, pingPeriod time.Dura
 	}
 }
 
-func checkAuthorization(ctx context.Context, r *http.Request, cs *registry.ChargeStation) bool {
+func checkAuthorization(ctx context.Context, r *http.Request, deviceRegistry registry.DeviceRegistry, cs *registry.ChargeStation) bool {
 	span := trace.SpanFromContext(ctx)
 
 	username, password, ok := r.BasicAuth()
@@ -425,6 +566,16 @@ func checkAuthorization(ctx context.Context, r *http.Request, cs *registry.Charg
 	sha256pw := sha256.Sum256([]byte(password))
 	b64sha256 := base64.StdEncoding.EncodeToString(sha256pw[:])
 	result := b64sha256 == cs.Base64SHA256Password
+	if !result && cs.PendingBase64SHA256Password != "" && b64sha256 == cs.PendingBase64SHA256Password {
+		// the charge station has connected using its rotated password: the previous password can be retired
+		span.SetAttributes(attribute.Bool("auth.pending_password", true))
+		result = true
+		err := deviceRegistry.ConfirmPassword(cs.ClientId)
+		if err != nil {
+			slog.Warn("unable to confirm password", "clientId", cs.ClientId, "err", err)
+			span.RecordError(err)
+		}
+	}
 
 	if !result {
 		span.SetAttributes(attribute.String("auth.failure_reason", "invalid password"))
@@ -433,7 +584,7 @@ func checkAuthorization(ctx context.Context, r *http.Request, cs *registry.Charg
 	return result
 }
 
-func checkCertificate(ctx context.Context, r *http.Request, orgNames []string, cs *registry.ChargeStation) bool {
+func checkCertificate(ctx context.Context, r *http.Request, orgNames []string, deviceRegistry registry.DeviceRegistry, certificateBinding bool, cs *registry.ChargeStation) bool {
 	span := trace.SpanFromContext(ctx)
 
 	if len(r.TLS.PeerCertificates) == 0 {
@@ -461,11 +612,29 @@ func checkCertificate(ctx context.Context, r *http.Request, orgNames []string, c
 		return false
 	}
 
-	//result := cs.ClientId == leafCertificate.Subject.CommonName
-	//
-	//if !result {
-	//	span.SetAttributes(attribute.String("auth.failure_reason", "bad common name"))
-	//}
+	hash := sha256.Sum256(leafCertificate.Raw)
+	certHash := base64.RawURLEncoding.EncodeToString(hash[:])
+
+	// certificates presented to a TLS offloading load balancer are checked for revocation
+	// when the certificate is looked up, so only check certificates presented to the gateway
+	if len(r.TLS.VerifiedChains) > 0 {
+		_, err := deviceRegistry.LookupCertificate(certHash)
+		if errors.Is(err, registry.ErrCertificateRevoked) {
+			span.SetAttributes(attribute.String("auth.failure_reason", "certificate revoked"))
+			return false
+		} else if err != nil {
+			span.SetAttributes(attribute.String("auth.failure_reason", "certificate lookup failed"))
+			slog.Error("lookup certificate", "err", err)
+			return false
+		}
+	}
+
+	// charge stations that have not (yet) had a certificate installed through the CSMS have
+	// no recorded certificate, so are allowed to use any certificate that passes the checks above
+	if certificateBinding && cs.ClientCertificateHash != "" && cs.ClientCertificateHash != certHash {
+		span.SetAttributes(attribute.String("auth.failure_reason", "certificate not issued to charge station"))
+		return false
+	}
 
 	return foundOrg
 }
@@ -562,9 +731,9 @@ func write(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, pro
 	return wsConn.Write(newCtx, websocket.MessageText, data)
 }
 
-func readFromChargeStation(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, csRx, csTx chan *pipe.GatewayMessage, protocol, clientId string) {
+func readFromChargeStation(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, station *stationLimit, maxMessageSize int64, csRx, csTx chan *pipe.GatewayMessage, protocol, clientId string) {
 	for {
-		msg, err := read(ctx, tracer, wsConn, protocol, clientId)
+		msg, err := read(ctx, tracer, wsConn, station, maxMessageSize, protocol, clientId)
 		if err != nil {
 			if msg != nil {
 				slog.Warn("sending error message to client", "err", err)
@@ -585,9 +754,14 @@ func readFromChargeStation(ctx context.Context, tracer trace.Tracer, wsConn *web
 
 var errClient = errors.New("client error")
 
-func read(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, protocol, clientId string) (*pipe.GatewayMessage, error) {
-	typ, b, err := wsConn.Read(context.Background())
-	if errors.Is(err, context.DeadlineExceeded) {
+var errMessageTooBig = errors.New("message too big")
+
+func read(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, station *stationLimit, maxMessageSize int64, protocol, clientId string) (*pipe.GatewayMessage, error) {
+	typ, b, err := readMessage(context.Background(), wsConn, maxMessageSize)
+	if errors.Is(err, errMessageTooBig) {
+		slog.Warn("closing connection: message too big", "clientId", clientId, "maxMessageSize", maxMessageSize)
+		return nil, err
+	} else if errors.Is(err, context.DeadlineExceeded) {
 		return nil, nil
 	} else if status := websocket.CloseStatus(err); status != -1 {
 		slog.Info("connection closed with status", "status", status)
@@ -645,9 +819,42 @@ func read(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, prot
 
 	span.SetAttributes(semconv.MessagingMessageConversationID(msg.MessageId))
 
+	// only requests are rate limited: responses have been solicited by the CSMS
+	if msg.MessageType == ocpp.MessageTypeCall && !station.allowMessage() {
+		span.SetStatus(codes.Error, "message rate limit exceeded")
+		return &pipe.GatewayMessage{
+			Context:          newCtx,
+			MessageType:      ocpp.MessageTypeCallError,
+			MessageId:        msg.MessageId,
+			ErrorCode:        ocpp.ErrorRpcFrameworkError,
+			ErrorDescription: "message rate limit exceeded",
+		}, errClient
+	}
+
 	return msg, nil
 }
 
+// readMessage reads a message from the websocket. If the message is larger than maxMessageSize the
+// connection is closed with StatusMessageTooBig.
+func readMessage(ctx context.Context, wsConn *websocket.Conn, maxMessageSize int64) (websocket.MessageType, []byte, error) {
+	typ, r, err := wsConn.Reader(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	b, err := io.ReadAll(io.LimitReader(r, maxMessageSize+1))
+	if err != nil {
+		return 0, nil, err
+	}
+	if int64(len(b)) > maxMessageSize {
+		limitExceededCounter.WithLabelValues(limitMessageSize).Inc()
+		// reading the remainder of the message exceeds the websocket read limit which closes the connection
+		_, _ = io.Copy(io.Discard, r)
+		_ = wsConn.Close(websocket.StatusMessageTooBig, fmt.Sprintf("message exceeds %d bytes", maxMessageSize))
+		return 0, nil, errMessageTooBig
+	}
+	return typ, b, nil
+}
+
 func marshalGatewayMessageAsOcpp(msg *pipe.GatewayMessage) ([]byte, error) {
 	var err error
 	ocppMsg := ocpp.Message{}
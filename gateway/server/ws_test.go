@@ -7,6 +7,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -245,6 +246,67 @@ func TestHttpConnectionWithBasicAuth(t *testing.T) {
 	}
 }
 
+func TestHttpConnectionWithBasicAuthPendingPassword(t *testing.T) {
+	tests := map[string]struct {
+		password      string
+		wantConfirmed bool
+	}{
+		"current password": {
+			password:      "password2",
+			wantConfirmed: false,
+		},
+		"pending password": {
+			password:      "password",
+			wantConfirmed: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cs := &registry.ChargeStation{
+				ClientId:                    "basicAuthPendingPasswordCS1",
+				SecurityProfile:             registry.UnsecuredTransportWithBasicAuth,
+				Base64SHA256Password:        "bPYV1byqx3g1Ko8fM2DSPwLzTsGC4lmJf9bOSF14cNQ=", // password2,
+				PendingBase64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password,
+			}
+
+			mockRegistry := registry.NewMockRegistry()
+			mockRegistry.ChargeStations[cs.ClientId] = cs
+
+			srv := httptest.NewServer(server.NewWebsocketHandler(server.WithDeviceRegistry(mockRegistry)))
+			defer srv.Close()
+
+			authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", cs.ClientId, tc.password)))
+			dialOptions := &websocket.DialOptions{
+				Subprotocols: []string{"ocpp1.6", "ocpp2.0.1"},
+				HTTPHeader: http.Header{
+					"authorization": []string{authHeader},
+				},
+			}
+
+			conn, resp, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), dialOptions)
+			if err != nil {
+				t.Fatalf("dialing CSMS: %v", err)
+			}
+			defer func() {
+				err := conn.Close(websocket.StatusGoingAway, "Shutdown")
+				if err != nil {
+					t.Logf("WARN: websocket close: %v", err)
+				}
+			}()
+			if resp.StatusCode != http.StatusSwitchingProtocols {
+				t.Fatalf("status code: want %d, got %d", http.StatusSwitchingProtocols, resp.StatusCode)
+			}
+			if got := mockRegistry.ConfirmedPasswords[cs.ClientId]; got != tc.wantConfirmed {
+				t.Fatalf("password confirmed: want %t, got %t", tc.wantConfirmed, got)
+			}
+		})
+	}
+}
+
 func TestHttpConnectionWithBasicAuthWrongPassword(t *testing.T) {
 	//defer goleak.VerifyNone(t)
 
@@ -401,6 +463,96 @@ func TestTlsConnectionWithBasicAuth(t *testing.T) {
 	}
 }
 
+func TestTlsConnectionWithPendingSecurityProfile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pendingSecurityProfile := registry.TLSWithBasicAuth
+	cs := &registry.ChargeStation{
+		ClientId:               "tlsWithPendingSecurityProfileCS1",
+		SecurityProfile:        registry.UnsecuredTransportWithBasicAuth,
+		PendingSecurityProfile: &pendingSecurityProfile,
+		Base64SHA256Password:   "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password,
+	}
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	srv := httptest.NewTLSServer(server.NewWebsocketHandler(server.WithDeviceRegistry(mockRegistry)))
+	defer srv.Close()
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", cs.ClientId, "password")))
+	dialOptions := &websocket.DialOptions{
+		HTTPClient:   srv.Client(),
+		Subprotocols: []string{"ocpp1.6", "ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	}
+
+	conn, resp, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), dialOptions)
+	if err != nil {
+		t.Fatalf("dialing CSMS: %v", err)
+	}
+	defer func() {
+		err := conn.Close(websocket.StatusGoingAway, "Shutdown")
+		if err != nil {
+			t.Logf("WARN: websocket close: %v", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status code: want %d, got %d", http.StatusSwitchingProtocols, resp.StatusCode)
+	}
+	if got, ok := mockRegistry.ConfirmedSecurityProfiles[cs.ClientId]; !ok || got != registry.TLSWithBasicAuth {
+		t.Fatalf("confirmed security profile: want %d, got %d (confirmed: %t)", registry.TLSWithBasicAuth, got, ok)
+	}
+}
+
+func TestHttpConnectionWithPendingSecurityProfileUsesCurrentSecurityProfile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pendingSecurityProfile := registry.TLSWithBasicAuth
+	cs := &registry.ChargeStation{
+		ClientId:               "httpWithPendingSecurityProfileCS1",
+		SecurityProfile:        registry.UnsecuredTransportWithBasicAuth,
+		PendingSecurityProfile: &pendingSecurityProfile,
+		Base64SHA256Password:   "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password,
+	}
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	srv := httptest.NewServer(server.NewWebsocketHandler(server.WithDeviceRegistry(mockRegistry)))
+	defer srv.Close()
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", cs.ClientId, "password")))
+	dialOptions := &websocket.DialOptions{
+		HTTPClient:   srv.Client(),
+		Subprotocols: []string{"ocpp1.6", "ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	}
+
+	conn, resp, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), dialOptions)
+	if err != nil {
+		t.Fatalf("dialing CSMS: %v", err)
+	}
+	defer func() {
+		err := conn.Close(websocket.StatusGoingAway, "Shutdown")
+		if err != nil {
+			t.Logf("WARN: websocket close: %v", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status code: want %d, got %d", http.StatusSwitchingProtocols, resp.StatusCode)
+	}
+	if _, ok := mockRegistry.ConfirmedSecurityProfiles[cs.ClientId]; ok {
+		t.Fatalf("expected security profile not to be confirmed")
+	}
+}
+
 func TestTlsConnectionWithBasicAuthWrongPassword(t *testing.T) {
 	//defer goleak.VerifyNone(t)
 
@@ -502,6 +654,191 @@ func TestTlsConnectionWithCertificateAuth(t *testing.T) {
 	}
 }
 
+func TestTlsConnectionWithRevokedCertificate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := &registry.ChargeStation{
+		ClientId:        "tlsWithRevokedCertificate",
+		SecurityProfile: registry.TLSWithClientSideCertificates,
+	}
+
+	caCert, _, clientCert, clientKeyPair := createTestKeyPairs(t, cs.ClientId)
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+	clientCertHash := sha256.Sum256(clientCert.Raw)
+	mockRegistry.RevokedCertificates[base64.RawURLEncoding.EncodeToString(clientCertHash[:])] = true
+
+	srv := httptest.NewUnstartedServer(server.NewWebsocketHandler(
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithOrgName("Thoughtworks")))
+	clientCAPool := x509.NewCertPool()
+	clientCAPool.AddCert(caCert)
+	srv.TLS = &tls.Config{
+		ClientCAs:  clientCAPool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	rootCAPool := x509.NewCertPool()
+	rootCAPool.AddCert(srv.Certificate())
+
+	clientTlsCert := tls.Certificate{
+		Certificate: [][]byte{clientCert.Raw, caCert.Raw},
+		PrivateKey:  clientKeyPair,
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:      rootCAPool,
+				Certificates: []tls.Certificate{clientTlsCert},
+			},
+		},
+	}
+	dialOptions := &websocket.DialOptions{
+		HTTPClient:   httpClient,
+		Subprotocols: []string{"ocpp1.6", "ocpp2.0.1"},
+	}
+
+	_, resp, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), dialOptions)
+	if err == nil {
+		t.Fatalf("expected error dialing CSMS")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected unauthorized response, got %v", resp)
+	}
+}
+
+func TestTlsConnectionWithBoundCertificate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := &registry.ChargeStation{
+		ClientId:        "tlsWithBoundCertificate",
+		SecurityProfile: registry.TLSWithClientSideCertificates,
+	}
+
+	caCert, _, clientCert, clientKeyPair := createTestKeyPairs(t, cs.ClientId)
+
+	clientCertHash := sha256.Sum256(clientCert.Raw)
+	cs.ClientCertificateHash = base64.RawURLEncoding.EncodeToString(clientCertHash[:])
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	srv := httptest.NewUnstartedServer(server.NewWebsocketHandler(
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithOrgName("Thoughtworks"),
+		server.WithCertificateBinding(true)))
+	clientCAPool := x509.NewCertPool()
+	clientCAPool.AddCert(caCert)
+	srv.TLS = &tls.Config{
+		ClientCAs:  clientCAPool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	rootCAPool := x509.NewCertPool()
+	rootCAPool.AddCert(srv.Certificate())
+
+	clientTlsCert := tls.Certificate{
+		Certificate: [][]byte{clientCert.Raw, caCert.Raw},
+		PrivateKey:  clientKeyPair,
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:      rootCAPool,
+				Certificates: []tls.Certificate{clientTlsCert},
+			},
+		},
+	}
+	dialOptions := &websocket.DialOptions{
+		HTTPClient:   httpClient,
+		Subprotocols: []string{"ocpp1.6", "ocpp2.0.1"},
+	}
+
+	conn, resp, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), dialOptions)
+	if err != nil {
+		t.Fatalf("dialing CSMS: %v", err)
+	}
+	defer func() {
+		err := conn.Close(websocket.StatusGoingAway, "Shutdown")
+		if err != nil {
+			t.Logf("WARN: websocket close: %v", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status code: want %d, got %d", http.StatusSwitchingProtocols, resp.StatusCode)
+	}
+}
+
+func TestTlsConnectionWithCertificateNotBoundToChargeStation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := &registry.ChargeStation{
+		ClientId:        "tlsWithCertificateNotBound",
+		SecurityProfile: registry.TLSWithClientSideCertificates,
+	}
+
+	caCert, _, clientCert, clientKeyPair := createTestKeyPairs(t, cs.ClientId)
+
+	// the hash of a certificate issued to a different charge station
+	otherCertHash := sha256.Sum256([]byte("another certificate"))
+	cs.ClientCertificateHash = base64.RawURLEncoding.EncodeToString(otherCertHash[:])
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	srv := httptest.NewUnstartedServer(server.NewWebsocketHandler(
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithOrgName("Thoughtworks"),
+		server.WithCertificateBinding(true)))
+	clientCAPool := x509.NewCertPool()
+	clientCAPool.AddCert(caCert)
+	srv.TLS = &tls.Config{
+		ClientCAs:  clientCAPool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	rootCAPool := x509.NewCertPool()
+	rootCAPool.AddCert(srv.Certificate())
+
+	clientTlsCert := tls.Certificate{
+		Certificate: [][]byte{clientCert.Raw, caCert.Raw},
+		PrivateKey:  clientKeyPair,
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:      rootCAPool,
+				Certificates: []tls.Certificate{clientTlsCert},
+			},
+		},
+	}
+	dialOptions := &websocket.DialOptions{
+		HTTPClient:   httpClient,
+		Subprotocols: []string{"ocpp1.6", "ocpp2.0.1"},
+	}
+
+	_, resp, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), dialOptions)
+	if err == nil {
+		t.Fatalf("expected error dialing CSMS")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected unauthorized response, got %v", resp)
+	}
+}
+
 func TestTlsConnectionWithCertificateAuthUntrustedRoot(t *testing.T) {
 	//defer goleak.VerifyNone(t)
 
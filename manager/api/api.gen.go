@@ -40,6 +40,18 @@ const (
 	N201 ChargeStationRuntimeDetailsOcppVersion = "2.0.1"
 )
 
+// Defines values for ChargeStationSecurityProfileUpgradeOcppInterface.
+const (
+	Wired0    ChargeStationSecurityProfileUpgradeOcppInterface = "Wired0"
+	Wired1    ChargeStationSecurityProfileUpgradeOcppInterface = "Wired1"
+	Wired2    ChargeStationSecurityProfileUpgradeOcppInterface = "Wired2"
+	Wired3    ChargeStationSecurityProfileUpgradeOcppInterface = "Wired3"
+	Wireless0 ChargeStationSecurityProfileUpgradeOcppInterface = "Wireless0"
+	Wireless1 ChargeStationSecurityProfileUpgradeOcppInterface = "Wireless1"
+	Wireless2 ChargeStationSecurityProfileUpgradeOcppInterface = "Wireless2"
+	Wireless3 ChargeStationSecurityProfileUpgradeOcppInterface = "Wireless3"
+)
+
 // Defines values for ChargeStationTriggerTrigger.
 const (
 	BootNotification               ChargeStationTriggerTrigger = "BootNotification"
@@ -153,11 +165,27 @@ type Certificate struct {
 	Certificate string `json:"certificate"`
 }
 
+// CertificateRevocation Identifies the certificates to revoke: either `certificate_hash` or `charge_station_id` must be provided
+type CertificateRevocation struct {
+	// CertificateHash The base64 encoded SHA-256 hash of the DER bytes of the certificate
+	CertificateHash *string `json:"certificate_hash,omitempty"`
+
+	// ChargeStationId Revoke every certificate that the CSMS has issued to the charge station that has not yet expired
+	ChargeStationId *string `json:"charge_station_id,omitempty"`
+
+	// Reason The reason for revocation: one of `unspecified` (the default), `keyCompromise`, `cACompromise`,
+	// `affiliationChanged`, `superseded`, `cessationOfOperation` or `privilegeWithdrawn`
+	Reason *string `json:"reason,omitempty"`
+}
+
 // ChargeStation Represents a charge station.
 type ChargeStation struct {
 	// Base64SHA256Password The base64 encoded, SHA-256 hash of the charge station password
 	Base64SHA256Password *string `json:"base64_SHA256_password,omitempty"`
 
+	// ClientCertificateHash The base64 (URL encoding, no padding) SHA-256 hash of the DER encoded client certificate most recently installed on the charge station. Only set once the charge station has accepted a certificate provided in a CertificateSigned request.
+	ClientCertificateHash *string `json:"client_certificate_hash,omitempty"`
+
 	// Evses List of EVSEs available at the charge station.
 	Evses *[]Evse `json:"evses"`
 
@@ -170,8 +198,132 @@ type ChargeStation struct {
 	// LocationId Identifier for the location of the charge station.
 	LocationId string `json:"location_id"`
 
+	// PendingBase64SHA256Password The base64 encoded, SHA-256 hash of a rotated charge station password that the charge station has not yet connected with: the charge station may connect using either password until it does.
+	PendingBase64SHA256Password *string `json:"pending_base64_SHA256_password,omitempty"`
+
+	// PendingSecurityProfile The security profile that the charge station is being upgraded to: the charge station may connect using either the current or the pending security profile until the upgrade is complete.
+	PendingSecurityProfile *int `json:"pending_security_profile,omitempty"`
+
 	// SecurityProfile The security profile to use for the charge station: * `0` - unsecured transport with basic auth * `1` - TLS with basic auth * `2` - TLS with client certificate
 	SecurityProfile int `json:"security_profile"`
+
+	// Tags Tags used to select the configuration templates that apply to the charge station
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// ChargeStationCertificateInventory The certificates installed on a charge station
+type ChargeStationCertificateInventory struct {
+	// AuditedAt The time at which the charge station certificates were last audited
+	AuditedAt *time.Time `json:"audited_at,omitempty"`
+
+	// Certificates The certificates installed on the charge station
+	Certificates []InventoryCertificate `json:"certificates"`
+
+	// MissingCertificates The expected root certificates that were not installed when the charge station was last audited
+	MissingCertificates []InventoryCertificate `json:"missing_certificates"`
+
+	// ReportedAt The time at which the charge station last reported its installed certificates
+	ReportedAt *time.Time `json:"reported_at,omitempty"`
+
+	// RequestedAt The time at which the installed certificates were last requested from the charge station
+	RequestedAt *time.Time `json:"requested_at,omitempty"`
+}
+
+// ChargeStationCertificateStatus defines model for ChargeStationCertificateStatus.
+type ChargeStationCertificateStatus struct {
+	// ErrorCode The OCPP error code returned by the charge station if it responded with an error
+	ErrorCode *string `json:"error_code,omitempty"`
+
+	// ErrorDescription The OCPP error description returned by the charge station if it responded with an error
+	ErrorDescription *string `json:"error_description,omitempty"`
+
+	// Id The certificate identifier
+	Id string `json:"id"`
+
+	// Status The installation status: one of `Pending`, `Accepted`, `Rejected` or `Errored`
+	Status string `json:"status"`
+
+	// Type The type of certificate: one of `V2G`, `MO`, `MF` or `CSMS`
+	Type string `json:"type"`
+}
+
+// ChargeStationCertificatesStatus The certificates sent to a charge station
+type ChargeStationCertificatesStatus struct {
+	Certificates []ChargeStationCertificateStatus `json:"certificates"`
+}
+
+// ChargeStationChangeAvailability Change the availability of a charge station
+type ChargeStationChangeAvailability struct {
+	// ConnectorId The connector to change: for OCPP 2.0.1 the `evse_id` must also be provided
+	ConnectorId *int `json:"connector_id,omitempty"`
+
+	// EvseId The EVSE to change (OCPP 2.0.1 only): the whole charge station is changed if omitted
+	EvseId *int `json:"evse_id,omitempty"`
+
+	// OperationalStatus The required availability: one of `Operative` or `Inoperative`
+	OperationalStatus string `json:"operational_status"`
+}
+
+// ChargeStationConnection The connection between a charge station and a gateway
+type ChargeStationConnection struct {
+	// ChargeStationId The charge station identifier
+	ChargeStationId string    `json:"charge_station_id"`
+	ConnectedAt     time.Time `json:"connected_at"`
+
+	// ConnectionId The identifier the gateway assigned to the websocket connection
+	ConnectionId string `json:"connection_id"`
+
+	// GatewayId The identifier of the gateway that the charge station is connected to
+	GatewayId string `json:"gateway_id"`
+
+	// RemoteAddress The network address that the charge station connected from
+	RemoteAddress *string `json:"remote_address,omitempty"`
+
+	// SecurityProfile The security profile that the charge station used to connect
+	SecurityProfile int `json:"security_profile"`
+
+	// Subprotocol The OCPP websocket subprotocol negotiated for the connection
+	Subprotocol *string `json:"subprotocol,omitempty"`
+}
+
+// ChargeStationDeviceModel The device model reported by an OCPP 2.0.1 charge station.
+type ChargeStationDeviceModel struct {
+	// UpdatedAt The time the device model was last updated
+	UpdatedAt time.Time             `json:"updated_at"`
+	Variables []DeviceModelVariable `json:"variables"`
+}
+
+// ChargeStationDisplayMessage A message on a charge station display
+type ChargeStationDisplayMessage struct {
+	Content     string     `json:"content"`
+	EndDateTime *time.Time `json:"end_date_time,omitempty"`
+
+	// ErrorCode The OCPP error code returned by the charge station if it responded with an error
+	ErrorCode *string `json:"error_code,omitempty"`
+
+	// ErrorDescription The OCPP error description returned by the charge station if it responded with an error
+	ErrorDescription *string `json:"error_description,omitempty"`
+	Format           string  `json:"format"`
+
+	// Id The message identifier (unique for the charge station)
+	Id            int        `json:"id"`
+	Language      *string    `json:"language,omitempty"`
+	Priority      string     `json:"priority"`
+	StartDateTime *time.Time `json:"start_date_time,omitempty"`
+	State         *string    `json:"state,omitempty"`
+
+	// Status The status of the message: `Pending` until the charge station responds, `Accepted` once the charge
+	// station holds the message, `NotSupportedMessageFormat`, `NotSupportedPriority`, `NotSupportedState`,
+	// `Rejected` or `UnknownTransaction` if the charge station would not show the message, `Errored` if the
+	// charge station responded with an error, `ClearPending` until the charge station clears the message or
+	// `Unmanaged` if the charge station reports a message that was not scheduled by the CSMS
+	Status        string  `json:"status"`
+	TransactionId *string `json:"transaction_id,omitempty"`
+}
+
+// ChargeStationGetVariables The variables to read from a charge station
+type ChargeStationGetVariables struct {
+	Variables []string `json:"variables"`
 }
 
 // ChargeStationInstallCertificates The set of certificates to install on the charge station. The certificates will be sent
@@ -193,18 +345,241 @@ type ChargeStationInstallCertificatesCertificatesStatus string
 // ChargeStationInstallCertificatesCertificatesType defines model for ChargeStationInstallCertificates.Certificates.Type.
 type ChargeStationInstallCertificatesCertificatesType string
 
+// ChargeStationLocalList The local authorization list held by a charge station
+type ChargeStationLocalList struct {
+	Entries []ChargeStationLocalListEntry `json:"entries"`
+
+	// ErrorCode The OCPP error code returned by the charge station if it responded with an error
+	ErrorCode *string `json:"error_code,omitempty"`
+
+	// ErrorDescription The OCPP error description returned by the charge station if it responded with an error
+	ErrorDescription *string `json:"error_description,omitempty"`
+
+	// GroupId The token group that the list is restricted to
+	GroupId *string `json:"group_id,omitempty"`
+
+	// Status The status of the list: one of `VersionUnknown` or `VersionCheckPending` while the version held by the
+	// charge station is being checked, `Pending` while an update has not been answered, `Accepted`, `Failed`,
+	// `VersionMismatch` or `NotSupported` as reported by the charge station, or `Errored` if the charge
+	// station responded with an error
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Version The version of the list that was last accepted by the charge station
+	Version int `json:"version"`
+}
+
+// ChargeStationLocalListEntry An entry in a local authorization list
+type ChargeStationLocalListEntry struct {
+	// GroupId The token group
+	GroupId *string `json:"group_id,omitempty"`
+
+	// IdToken The token identifier
+	IdToken string `json:"id_token"`
+
+	// Status The authorization status of the token, e.g. `Accepted` or `Invalid`
+	Status string `json:"status"`
+
+	// TokenType The OCPP 2.0.1 type of the token, e.g. `ISO14443` or `ISO15693`: not set for OCPP 1.6
+	TokenType *string `json:"token_type,omitempty"`
+}
+
+// ChargeStationLocalListSettings Settings for the local authorization list of a charge station
+type ChargeStationLocalListSettings struct {
+	// GroupId Restricts the list to the tokens in this token group
+	GroupId *string `json:"group_id,omitempty"`
+}
+
+// ChargeStationOperation A remote operation sent to a charge station
+type ChargeStationOperation struct {
+	// ErrorCode The OCPP error code returned by the charge station if it responded with an error
+	ErrorCode *string `json:"error_code,omitempty"`
+
+	// ErrorDescription The OCPP error description returned by the charge station if it responded with an error
+	ErrorDescription *string `json:"error_description,omitempty"`
+
+	// Operation The OCPP action used for the operation, e.g. `Reset`
+	Operation string `json:"operation"`
+
+	// Request The JSON encoded OCPP request
+	Request     string    `json:"request"`
+	RequestedAt time.Time `json:"requested_at"`
+
+	// Response The JSON encoded OCPP response
+	Response *string `json:"response,omitempty"`
+
+	// Status The status of the operation: `Pending` until the charge station responds, then the status returned by
+	// the charge station (e.g. `Accepted`, `Rejected` or `Scheduled`) or `Errored` if the charge station
+	// responded with an error
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ChargeStationReservation A reservation of an EVSE at a charge station
+type ChargeStationReservation struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// ErrorCode The OCPP error code returned by the charge station if it responded with an error
+	ErrorCode *string `json:"error_code,omitempty"`
+
+	// ErrorDescription The OCPP error description returned by the charge station if it responded with an error
+	ErrorDescription *string `json:"error_description,omitempty"`
+
+	// EvseId The reserved EVSE: not provided if any EVSE is reserved
+	EvseId     *int      `json:"evse_id,omitempty"`
+	ExpiryDate time.Time `json:"expiry_date"`
+	GroupId    *string   `json:"group_id,omitempty"`
+	IdToken    string    `json:"id_token"`
+
+	// Reference The identifier of the reservation in the system that requested it, e.g. the OCPI reservation id
+	Reference *string `json:"reference,omitempty"`
+
+	// ReservationId The reservation identifier (unique for the charge station)
+	ReservationId int `json:"reservation_id"`
+
+	// Status The status of the reservation: `Pending` until the charge station responds, `Accepted` while the
+	// reservation is active, `Faulted`, `Occupied`, `Rejected` or `Unavailable` if the charge station would
+	// not make the reservation, `Errored` if the charge station responded with an error, then `Used`,
+	// `Cancelled`, `Expired` or `Removed` once the reservation has ended
+	Status    string `json:"status"`
+	TokenType string `json:"token_type"`
+
+	// TransactionId The transaction that used the reservation
+	TransactionId *string   `json:"transaction_id,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ChargeStationReserveNow A request to reserve an EVSE at a charge station
+type ChargeStationReserveNow struct {
+	// EvseId The EVSE to reserve: any EVSE is reserved if not provided
+	EvseId *int `json:"evse_id,omitempty"`
+
+	// ExpiryDate The date and time at which the reservation expires
+	ExpiryDate time.Time `json:"expiry_date"`
+
+	// GroupId A group token: any token in the group may use the reservation
+	GroupId *string `json:"group_id,omitempty"`
+
+	// IdToken The token that the EVSE is reserved for (at most 20 characters for OCPP 1.6)
+	IdToken string `json:"id_token"`
+
+	// TokenType The OCPP 2.0.1 type of the token (defaults to `ISO14443`)
+	TokenType *string `json:"token_type,omitempty"`
+}
+
+// ChargeStationReset Reset a charge station
+type ChargeStationReset struct {
+	// EvseId The EVSE to reset (OCPP 2.0.1 only): the whole charge station is reset if omitted
+	EvseId *int `json:"evse_id,omitempty"`
+
+	// Type The type of reset: one of `Immediate` or `OnIdle`
+	Type string `json:"type"`
+}
+
 // ChargeStationRuntimeDetails Represents charge station runtime details.
 type ChargeStationRuntimeDetails struct {
+	// Model Model reported by the charge station when it last booted.
+	Model *string `json:"model,omitempty"`
+
 	// OcppVersion OCPP version used with charge station.
 	OcppVersion ChargeStationRuntimeDetailsOcppVersion `json:"ocpp_version"`
+
+	// Vendor Vendor reported by the charge station when it last booted.
+	Vendor *string `json:"vendor,omitempty"`
 }
 
 // ChargeStationRuntimeDetailsOcppVersion OCPP version used with charge station.
 type ChargeStationRuntimeDetailsOcppVersion string
 
+// ChargeStationSecurityProfileUpgrade Upgrade a charge station to a higher security profile
+type ChargeStationSecurityProfileUpgrade struct {
+	// ConfigurationSlot The network configuration slot used for the new network connection profile (OCPP 2.0.1 only), defaults to 1
+	ConfigurationSlot *int `json:"configuration_slot,omitempty"`
+
+	// CsmsRootCertificate The PEM encoded root certificate of the CSMS server certificate: required when upgrading from unsecured transport
+	CsmsRootCertificate *string `json:"csms_root_certificate,omitempty"`
+
+	// MessageTimeout The timeout (in seconds) for messages sent using the new network connection profile: required for OCPP 2.0.1
+	MessageTimeout *int `json:"message_timeout,omitempty"`
+
+	// OcppCsmsUrl The URL the charge station uses to connect with the new security profile: required for OCPP 2.0.1
+	OcppCsmsUrl *string `json:"ocpp_csms_url,omitempty"`
+
+	// OcppInterface The network interface the charge station uses for the new network connection profile: required for OCPP 2.0.1
+	OcppInterface *ChargeStationSecurityProfileUpgradeOcppInterface `json:"ocpp_interface,omitempty"`
+
+	// SecurityProfile The security profile to upgrade to (which must be higher than the current security profile): * `1` - TLS with basic auth * `2` - TLS with client certificate
+	SecurityProfile int `json:"security_profile"`
+}
+
+// ChargeStationSecurityProfileUpgradeOcppInterface The network interface the charge station uses for the new network connection profile: required for OCPP 2.0.1
+type ChargeStationSecurityProfileUpgradeOcppInterface string
+
+// ChargeStationSecurityProfileUpgradeReconnect The security profile a charge station has connected with
+type ChargeStationSecurityProfileUpgradeReconnect struct {
+	// SecurityProfile The security profile the charge station connected with
+	SecurityProfile int `json:"security_profile"`
+}
+
+// ChargeStationSecurityProfileUpgradeStatus The progress of a charge station security profile upgrade
+type ChargeStationSecurityProfileUpgradeStatus struct {
+	// ErrorDescription The reason the upgrade failed
+	ErrorDescription *string `json:"error_description,omitempty"`
+
+	// FromSecurityProfile The security profile when the upgrade was requested
+	FromSecurityProfile int       `json:"from_security_profile"`
+	RequestedAt         time.Time `json:"requested_at"`
+
+	// Step The step that the upgrade has reached: one of `InstallRootCertificate`, `SetBasicAuthPassword`,
+	// `SignCertificate`, `SetNetworkProfile`, `SetNetworkConfigurationPriority`, `AwaitReconnect`,
+	// `Completed` or `Failed`
+	Step string `json:"step"`
+
+	// ToSecurityProfile The security profile being upgraded to
+	ToSecurityProfile int       `json:"to_security_profile"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ChargeStationSettingStatus defines model for ChargeStationSettingStatus.
+type ChargeStationSettingStatus struct {
+	// ActualValue The value read back from the charge station when the setting has drifted
+	ActualValue *string `json:"actual_value,omitempty"`
+
+	// ErrorCode The OCPP error code returned by the charge station if it responded with an error
+	ErrorCode *string `json:"error_code,omitempty"`
+
+	// ErrorDescription The OCPP error description returned by the charge station if it responded with an error
+	ErrorDescription *string `json:"error_description,omitempty"`
+
+	// Status The status of the setting: one of `Pending`, `Accepted`, `Rejected`, `RebootRequired`, `NotSupported`
+	// or `Drifted`
+	Status string `json:"status"`
+
+	// Value The value supplied for the setting
+	Value string `json:"value"`
+}
+
 // ChargeStationSettings Settings for a charge station
 type ChargeStationSettings map[string]string
 
+// ChargeStationSettingsStatus The status of the settings supplied for a charge station
+type ChargeStationSettingsStatus struct {
+	// Settings The settings keyed by name
+	Settings map[string]ChargeStationSettingStatus `json:"settings"`
+}
+
+// ChargeStationStopTransaction Stop a transaction
+type ChargeStationStopTransaction struct {
+	// TransactionId The transaction identifier: for OCPP 1.6 this must be an integer
+	TransactionId string `json:"transaction_id"`
+}
+
+// ChargeStationTransactionStatus Get the status of a transaction
+type ChargeStationTransactionStatus struct {
+	// TransactionId The transaction identifier
+	TransactionId *string `json:"transaction_id,omitempty"`
+}
+
 // ChargeStationTrigger Trigger a charge station action
 type ChargeStationTrigger struct {
 	Trigger ChargeStationTriggerTrigger `json:"trigger"`
@@ -213,6 +588,74 @@ type ChargeStationTrigger struct {
 // ChargeStationTriggerTrigger defines model for ChargeStationTrigger.Trigger.
 type ChargeStationTriggerTrigger string
 
+// ChargeStationTriggerStatus defines model for ChargeStationTriggerStatus.
+type ChargeStationTriggerStatus struct {
+	// ErrorCode The OCPP error code returned by the charge station if it responded with an error
+	ErrorCode *string `json:"error_code,omitempty"`
+
+	// ErrorDescription The OCPP error description returned by the charge station if it responded with an error
+	ErrorDescription *string `json:"error_description,omitempty"`
+
+	// Status The status of the trigger: one of `Pending`, `Accepted`, `Rejected`, `NotImplemented` or `Failed`
+	Status string `json:"status"`
+
+	// Trigger The message that was triggered
+	Trigger string `json:"trigger"`
+}
+
+// ChargeStationUnlockConnector Unlock a charge station connector
+type ChargeStationUnlockConnector struct {
+	// ConnectorId The connector to unlock
+	ConnectorId int `json:"connector_id"`
+
+	// EvseId The EVSE of the connector (required for OCPP 2.0.1)
+	EvseId *int `json:"evse_id,omitempty"`
+}
+
+// ChargeStationVariable defines model for ChargeStationVariable.
+type ChargeStationVariable struct {
+	// ErrorCode The OCPP error code returned by the charge station if it responded with an error
+	ErrorCode *string `json:"error_code,omitempty"`
+
+	// ErrorDescription The OCPP error description returned by the charge station if it responded with an error
+	ErrorDescription *string `json:"error_description,omitempty"`
+
+	// Status The status of the variable: one of `Pending`, `Accepted`, `Rejected`, `UnknownComponent`,
+	// `UnknownVariable`, `NotSupportedAttributeType` or (for OCPP 1.6) `UnknownKey`
+	Status string `json:"status"`
+
+	// Value The value returned by the charge station
+	Value *string `json:"value,omitempty"`
+}
+
+// ChargeStationVariables The variables read from a charge station
+type ChargeStationVariables struct {
+	// Variables The variables keyed by name
+	Variables map[string]ChargeStationVariable `json:"variables"`
+}
+
+// ConfigurationTemplate A named set of settings that is applied to the charge stations that match all the selectors that are
+// set on the template. A template without any selectors applies to every charge station.
+type ConfigurationTemplate struct {
+	// Id The configuration template identifier
+	Id *string `json:"id,omitempty"`
+
+	// LocationId Selects the charge stations at the location
+	LocationId *string `json:"location_id,omitempty"`
+
+	// Model Selects the charge stations that report this model when they boot
+	Model *string `json:"model,omitempty"`
+
+	// Settings Settings for a charge station
+	Settings ChargeStationSettings `json:"settings"`
+
+	// Tag Selects the charge stations that have the tag
+	Tag *string `json:"tag,omitempty"`
+
+	// Vendor Selects the charge stations that report this vendor when they boot
+	Vendor *string `json:"vendor,omitempty"`
+}
+
 // Connector defines model for Connector.
 type Connector struct {
 	Format ConnectorFormat `json:"format"`
@@ -235,6 +678,71 @@ type ConnectorPowerType string
 // ConnectorStandard defines model for Connector.Standard.
 type ConnectorStandard string
 
+// DeviceModelVariable A variable of a component in the charge station's device model.
+type DeviceModelVariable struct {
+	Attributes        []DeviceModelVariableAttribute      `json:"attributes"`
+	Characteristics   *DeviceModelVariableCharacteristics `json:"characteristics,omitempty"`
+	ComponentInstance *string                             `json:"component_instance,omitempty"`
+	ComponentName     string                              `json:"component_name"`
+	ConnectorId       *int                                `json:"connector_id,omitempty"`
+	EvseId            *int                                `json:"evse_id,omitempty"`
+	VariableInstance  *string                             `json:"variable_instance,omitempty"`
+	VariableName      string                              `json:"variable_name"`
+}
+
+// DeviceModelVariableAttribute defines model for DeviceModelVariableAttribute.
+type DeviceModelVariableAttribute struct {
+	Constant bool `json:"constant"`
+
+	// Mutability The attribute mutability: one of `ReadOnly`, `WriteOnly` or `ReadWrite`
+	Mutability string `json:"mutability"`
+	Persistent bool   `json:"persistent"`
+
+	// Type The attribute type: one of `Actual`, `Target`, `MinSet` or `MaxSet`
+	Type  string  `json:"type"`
+	Value *string `json:"value,omitempty"`
+}
+
+// DeviceModelVariableCharacteristics defines model for DeviceModelVariableCharacteristics.
+type DeviceModelVariableCharacteristics struct {
+	// DataType The data type of the variable as defined by OCPP 2.0.1, e.g. `integer` or `OptionList`
+	DataType           string   `json:"data_type"`
+	MaxLimit           *float64 `json:"max_limit,omitempty"`
+	MinLimit           *float64 `json:"min_limit,omitempty"`
+	SupportsMonitoring bool     `json:"supports_monitoring"`
+	Unit               *string  `json:"unit,omitempty"`
+	ValuesList         *string  `json:"values_list,omitempty"`
+}
+
+// DisplayMessage A message to show on a charge station display
+type DisplayMessage struct {
+	Content string `json:"content"`
+
+	// EndDateTime The date and time after which the message is removed
+	EndDateTime *time.Time `json:"end_date_time,omitempty"`
+
+	// Format The format of the content: `ASCII`, `HTML`, `URI` or `UTF8` (defaults to `UTF8`)
+	Format *string `json:"format,omitempty"`
+
+	// Id The message identifier: a new identifier is allocated if not provided
+	Id *int `json:"id,omitempty"`
+
+	// Language The RFC 5646 language code of the content
+	Language *string `json:"language,omitempty"`
+
+	// Priority How the message is shown: `AlwaysFront`, `InFront` or `NormalCycle`
+	Priority string `json:"priority"`
+
+	// StartDateTime The date and time from which the message is shown: immediately if not provided
+	StartDateTime *time.Time `json:"start_date_time,omitempty"`
+
+	// State The charge station state in which the message is shown: `Charging`, `Faulted`, `Idle` or `Unavailable`
+	State *string `json:"state,omitempty"`
+
+	// TransactionId The message is shown during the transaction and removed when the transaction ends
+	TransactionId *string `json:"transaction_id,omitempty"`
+}
+
 // Evse defines model for Evse.
 type Evse struct {
 	Connectors []Connector `json:"connectors"`
@@ -260,6 +768,51 @@ type GeoLocation struct {
 	Longitude string `json:"longitude"`
 }
 
+// InventoryCertificate A certificate identified by its OCPP certificate hash data
+type InventoryCertificate struct {
+	// CertificateType The type of certificate: one of `V2G`, `MO`, `CSMS`, `MF` or `EVCC` (the V2G certificate chain)
+	CertificateType string `json:"certificate_type"`
+
+	// HashAlgorithm The hash algorithm used for the issuer name and key hashes, e.g. `SHA256`
+	HashAlgorithm string `json:"hash_algorithm"`
+
+	// IssuerKeyHash The hex encoded hash of the issuer public key
+	IssuerKeyHash string `json:"issuer_key_hash"`
+
+	// IssuerNameHash The hex encoded hash of the issuer distinguished name
+	IssuerNameHash string `json:"issuer_name_hash"`
+
+	// SerialNumber The hex encoded serial number of the certificate
+	SerialNumber string `json:"serial_number"`
+
+	// Subject The subject of the certificate (only known for missing certificates)
+	Subject *string `json:"subject,omitempty"`
+}
+
+// IssuedCertificate A certificate issued to a charge station
+type IssuedCertificate struct {
+	// CertificateId The hex encoded SHA-256 hash of the certificate DER bytes
+	CertificateId string `json:"certificate_id"`
+
+	// CertificateType The type of certificate: one of `ChargeStation` or `EVCC` (the V2G certificate)
+	CertificateType string `json:"certificate_type"`
+
+	// ChargeStationId The charge station identifier
+	ChargeStationId string `json:"charge_station_id"`
+
+	// IssuedAt The time at which the certificate was issued
+	IssuedAt time.Time `json:"issued_at"`
+
+	// NotAfter The time at which the certificate expires
+	NotAfter time.Time `json:"not_after"`
+
+	// RenewalRequestedAt The time at which the charge station was last asked to renew the certificate
+	RenewalRequestedAt *time.Time `json:"renewal_requested_at,omitempty"`
+
+	// SerialNumber The hex encoded serial number of the certificate
+	SerialNumber string `json:"serial_number"`
+}
+
 // Location A charge station location
 type Location struct {
 	Address     string               `json:"address"`
@@ -277,6 +830,15 @@ type Location struct {
 // LocationParkingType defines model for Location.ParkingType.
 type LocationParkingType string
 
+// LocationDisplayMessage A message on the displays of the charge stations at a location
+type LocationDisplayMessage struct {
+	// ChargeStationIds The charge stations at the location that the message applies to
+	ChargeStationIds []string `json:"charge_station_ids"`
+
+	// Id The message identifier (the same at each charge station)
+	Id int `json:"id"`
+}
+
 // MeterValue defines model for MeterValue.
 type MeterValue struct {
 	SampledValues []SampledValue `json:"sampled_values"`
@@ -304,6 +866,23 @@ type Registration struct {
 // endpoints.
 type RegistrationStatus string
 
+// RevokedCertificate A revoked client certificate
+type RevokedCertificate struct {
+	// CertificateHash The base64 encoded SHA-256 hash of the DER bytes of the certificate
+	CertificateHash string `json:"certificate_hash"`
+
+	// ChargeStationId The charge station that the certificate was issued to, if known
+	ChargeStationId *string `json:"charge_station_id,omitempty"`
+
+	// Issuer The issuer distinguished name, if the certificate is known to the CSMS
+	Issuer    *string   `json:"issuer,omitempty"`
+	Reason    string    `json:"reason"`
+	RevokedAt time.Time `json:"revoked_at"`
+
+	// SerialNumber The hex encoded serial number, if the certificate is known to the CSMS
+	SerialNumber *string `json:"serial_number,omitempty"`
+}
+
 // SampledValue defines model for SampledValue.
 type SampledValue struct {
 	Context       *string        `json:"context"`
@@ -396,6 +975,60 @@ type ListChargeStationsParams struct {
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// ChangeChargeStationAvailabilityParams defines parameters for ChangeChargeStationAvailability.
+type ChangeChargeStationAvailabilityParams struct {
+	// Wait The number of seconds to wait for the charge station to respond
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// ClearChargeStationCacheParams defines parameters for ClearChargeStationCache.
+type ClearChargeStationCacheParams struct {
+	// Wait The number of seconds to wait for the charge station to respond
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// LookupChargeStationDeviceModelParams defines parameters for LookupChargeStationDeviceModel.
+type LookupChargeStationDeviceModelParams struct {
+	// Component Only return the variables of the named component
+	Component *string `form:"component,omitempty" json:"component,omitempty"`
+}
+
+// ResetChargeStationParams defines parameters for ResetChargeStation.
+type ResetChargeStationParams struct {
+	// Wait The number of seconds to wait for the charge station to respond
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// StopChargeStationTransactionParams defines parameters for StopChargeStationTransaction.
+type StopChargeStationTransactionParams struct {
+	// Wait The number of seconds to wait for the charge station to respond
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// GetChargeStationTransactionStatusParams defines parameters for GetChargeStationTransactionStatus.
+type GetChargeStationTransactionStatusParams struct {
+	// Wait The number of seconds to wait for the charge station to respond
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// UnlockChargeStationConnectorParams defines parameters for UnlockChargeStationConnector.
+type UnlockChargeStationConnectorParams struct {
+	// Wait The number of seconds to wait for the charge station to respond
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// GetChargeStationVariablesParams defines parameters for GetChargeStationVariables.
+type GetChargeStationVariablesParams struct {
+	// Wait The number of seconds to wait for the charge station to respond
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// ListIssuedCertificatesParams defines parameters for ListIssuedCertificates.
+type ListIssuedCertificatesParams struct {
+	// ExpiringWithinDays Only return certificates that expire within this number of days
+	ExpiringWithinDays *int `form:"expiring_within_days,omitempty" json:"expiring_within_days,omitempty"`
+}
+
 // ListLocationsParams defines parameters for ListLocations.
 type ListLocationsParams struct {
 	// Offset The number of items to skip before starting to collect the result set.
@@ -426,6 +1059,9 @@ type ListTransactionsByChargeStationParams struct {
 // UploadCertificateJSONRequestBody defines body for UploadCertificate for application/json ContentType.
 type UploadCertificateJSONRequestBody = Certificate
 
+// SetConfigurationTemplateJSONRequestBody defines body for SetConfigurationTemplate for application/json ContentType.
+type SetConfigurationTemplateJSONRequestBody = ConfigurationTemplate
+
 // RegisterChargeStationJSONRequestBody defines body for RegisterChargeStation for application/json ContentType.
 type RegisterChargeStationJSONRequestBody = ChargeStation
 
@@ -435,21 +1071,60 @@ type UpdateChargeStationJSONRequestBody = ChargeStation
 // InstallChargeStationCertificatesJSONRequestBody defines body for InstallChargeStationCertificates for application/json ContentType.
 type InstallChargeStationCertificatesJSONRequestBody = ChargeStationInstallCertificates
 
+// ChangeChargeStationAvailabilityJSONRequestBody defines body for ChangeChargeStationAvailability for application/json ContentType.
+type ChangeChargeStationAvailabilityJSONRequestBody = ChargeStationChangeAvailability
+
+// SetChargeStationDisplayMessageJSONRequestBody defines body for SetChargeStationDisplayMessage for application/json ContentType.
+type SetChargeStationDisplayMessageJSONRequestBody = DisplayMessage
+
+// SetChargeStationLocalListJSONRequestBody defines body for SetChargeStationLocalList for application/json ContentType.
+type SetChargeStationLocalListJSONRequestBody = ChargeStationLocalListSettings
+
 // ReconfigureChargeStationJSONRequestBody defines body for ReconfigureChargeStation for application/json ContentType.
 type ReconfigureChargeStationJSONRequestBody = ChargeStationSettings
 
+// ReserveChargeStationJSONRequestBody defines body for ReserveChargeStation for application/json ContentType.
+type ReserveChargeStationJSONRequestBody = ChargeStationReserveNow
+
+// ResetChargeStationJSONRequestBody defines body for ResetChargeStation for application/json ContentType.
+type ResetChargeStationJSONRequestBody = ChargeStationReset
+
+// UpgradeChargeStationSecurityProfileJSONRequestBody defines body for UpgradeChargeStationSecurityProfile for application/json ContentType.
+type UpgradeChargeStationSecurityProfileJSONRequestBody = ChargeStationSecurityProfileUpgrade
+
+// ReconnectChargeStationSecurityProfileUpgradeJSONRequestBody defines body for ReconnectChargeStationSecurityProfileUpgrade for application/json ContentType.
+type ReconnectChargeStationSecurityProfileUpgradeJSONRequestBody = ChargeStationSecurityProfileUpgradeReconnect
+
+// StopChargeStationTransactionJSONRequestBody defines body for StopChargeStationTransaction for application/json ContentType.
+type StopChargeStationTransactionJSONRequestBody = ChargeStationStopTransaction
+
+// GetChargeStationTransactionStatusJSONRequestBody defines body for GetChargeStationTransactionStatus for application/json ContentType.
+type GetChargeStationTransactionStatusJSONRequestBody = ChargeStationTransactionStatus
+
 // TriggerChargeStationJSONRequestBody defines body for TriggerChargeStation for application/json ContentType.
 type TriggerChargeStationJSONRequestBody = ChargeStationTrigger
 
+// UnlockChargeStationConnectorJSONRequestBody defines body for UnlockChargeStationConnector for application/json ContentType.
+type UnlockChargeStationConnectorJSONRequestBody = ChargeStationUnlockConnector
+
+// GetChargeStationVariablesJSONRequestBody defines body for GetChargeStationVariables for application/json ContentType.
+type GetChargeStationVariablesJSONRequestBody = ChargeStationGetVariables
+
 // RegisterLocationJSONRequestBody defines body for RegisterLocation for application/json ContentType.
 type RegisterLocationJSONRequestBody = Location
 
 // UpdateLocationJSONRequestBody defines body for UpdateLocation for application/json ContentType.
 type UpdateLocationJSONRequestBody = Location
 
+// SetLocationDisplayMessageJSONRequestBody defines body for SetLocationDisplayMessage for application/json ContentType.
+type SetLocationDisplayMessageJSONRequestBody = DisplayMessage
+
 // RegisterPartyJSONRequestBody defines body for RegisterParty for application/json ContentType.
 type RegisterPartyJSONRequestBody = Registration
 
+// RevokeCertificatesJSONRequestBody defines body for RevokeCertificates for application/json ContentType.
+type RevokeCertificatesJSONRequestBody = CertificateRevocation
+
 // SetTokenJSONRequestBody defines body for SetToken for application/json ContentType.
 type SetTokenJSONRequestBody = Token
 
@@ -464,6 +1139,21 @@ type ServerInterface interface {
 	// Lookup a certificate
 	// (GET /certificate/{certificate_hash})
 	LookupCertificate(w http.ResponseWriter, r *http.Request, certificateHash string)
+	// List configuration templates
+	// (GET /configuration-template)
+	ListConfigurationTemplates(w http.ResponseWriter, r *http.Request)
+	// Delete a configuration template
+	// (DELETE /configuration-template/{template_id})
+	DeleteConfigurationTemplate(w http.ResponseWriter, r *http.Request, templateId string)
+	// Lookup a configuration template
+	// (GET /configuration-template/{template_id})
+	LookupConfigurationTemplate(w http.ResponseWriter, r *http.Request, templateId string)
+	// Create or update a configuration template
+	// (PUT /configuration-template/{template_id})
+	SetConfigurationTemplate(w http.ResponseWriter, r *http.Request, templateId string)
+	// List charge station connections
+	// (GET /connection)
+	ListConnections(w http.ResponseWriter, r *http.Request)
 	// List Charge Stations
 	// (GET /cs)
 	ListChargeStations(w http.ResponseWriter, r *http.Request, params ListChargeStationsParams)
@@ -479,18 +1169,111 @@ type ServerInterface interface {
 	// Update a charge station
 	// (PUT /cs/{cs_id})
 	UpdateChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	// Get the certificates installed on a charge station
+	// (GET /cs/{cs_id}/certificate-inventory)
+	LookupChargeStationCertificateInventory(w http.ResponseWriter, r *http.Request, csId string)
+	// Get the status of the certificates installed on the charge station
+	// (GET /cs/{cs_id}/certificates)
+	LookupChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string)
 	// Install certificates on the charge station
 	// (POST /cs/{cs_id}/certificates)
 	InstallChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string)
+	// Change the availability of the charge station
+	// (POST /cs/{cs_id}/change-availability)
+	ChangeChargeStationAvailability(w http.ResponseWriter, r *http.Request, csId string, params ChangeChargeStationAvailabilityParams)
+	// Clear the authorization cache
+	// (POST /cs/{cs_id}/clear-cache)
+	ClearChargeStationCache(w http.ResponseWriter, r *http.Request, csId string, params ClearChargeStationCacheParams)
+	// Disconnect a charge station
+	// (DELETE /cs/{cs_id}/connection)
+	DisconnectChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	// Get the gateway connection for a charge station
+	// (GET /cs/{cs_id}/connection)
+	LookupChargeStationConnection(w http.ResponseWriter, r *http.Request, csId string)
+	// Get Charge Station device model
+	// (GET /cs/{cs_id}/device-model)
+	LookupChargeStationDeviceModel(w http.ResponseWriter, r *http.Request, csId string, params LookupChargeStationDeviceModelParams)
+	// List the messages on the charge station display
+	// (GET /cs/{cs_id}/display-messages)
+	ListChargeStationDisplayMessages(w http.ResponseWriter, r *http.Request, csId string)
+	// Schedule a message on the charge station display
+	// (POST /cs/{cs_id}/display-messages)
+	SetChargeStationDisplayMessage(w http.ResponseWriter, r *http.Request, csId string)
+	// Clear a message from the charge station display
+	// (DELETE /cs/{cs_id}/display-messages/{message_id})
+	ClearChargeStationDisplayMessage(w http.ResponseWriter, r *http.Request, csId string, messageId int)
+	// Get the local authorization list of the charge station
+	// (GET /cs/{cs_id}/local-list)
+	LookupChargeStationLocalList(w http.ResponseWriter, r *http.Request, csId string)
+	// Manage the local authorization list of the charge station
+	// (PUT /cs/{cs_id}/local-list)
+	SetChargeStationLocalList(w http.ResponseWriter, r *http.Request, csId string)
+	// Get the remote operations sent to the charge station
+	// (GET /cs/{cs_id}/operations)
+	ListChargeStationOperations(w http.ResponseWriter, r *http.Request, csId string)
+	// Rotate the charge station basic auth password
+	// (POST /cs/{cs_id}/password)
+	RotateChargeStationPassword(w http.ResponseWriter, r *http.Request, csId string)
+	// Report that the charge station has connected using its pending password
+	// (POST /cs/{cs_id}/password/reconnect)
+	ReconnectChargeStationPassword(w http.ResponseWriter, r *http.Request, csId string)
 	// Reconfigure the charge station
 	// (POST /cs/{cs_id}/reconfigure)
 	ReconfigureChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	// List the reservations made at the charge station
+	// (GET /cs/{cs_id}/reservations)
+	ListChargeStationReservations(w http.ResponseWriter, r *http.Request, csId string)
+	// Reserve an EVSE at the charge station
+	// (POST /cs/{cs_id}/reservations)
+	ReserveChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	// Cancel a reservation made at the charge station
+	// (DELETE /cs/{cs_id}/reservations/{reservation_id})
+	CancelChargeStationReservation(w http.ResponseWriter, r *http.Request, csId string, reservationId int)
+	// Get a reservation made at the charge station
+	// (GET /cs/{cs_id}/reservations/{reservation_id})
+	LookupChargeStationReservation(w http.ResponseWriter, r *http.Request, csId string, reservationId int)
+	// Reset the charge station
+	// (POST /cs/{cs_id}/reset)
+	ResetChargeStation(w http.ResponseWriter, r *http.Request, csId string, params ResetChargeStationParams)
 	// Get Charge Station runtime details
 	// (GET /cs/{cs_id}/runtime-details)
 	LookupChargeStationRuntimeDetails(w http.ResponseWriter, r *http.Request, csId string)
+	// Get the progress of the most recent security profile upgrade
+	// (GET /cs/{cs_id}/security-profile-upgrade)
+	LookupChargeStationSecurityProfileUpgrade(w http.ResponseWriter, r *http.Request, csId string)
+	// Upgrade the charge station to a higher security profile
+	// (POST /cs/{cs_id}/security-profile-upgrade)
+	UpgradeChargeStationSecurityProfile(w http.ResponseWriter, r *http.Request, csId string)
+	// Report that the charge station has connected using a new security profile
+	// (POST /cs/{cs_id}/security-profile-upgrade/reconnect)
+	ReconnectChargeStationSecurityProfileUpgrade(w http.ResponseWriter, r *http.Request, csId string)
+	// Get the status of the charge station settings
+	// (GET /cs/{cs_id}/settings)
+	LookupChargeStationSettings(w http.ResponseWriter, r *http.Request, csId string)
+	// Stop a transaction
+	// (POST /cs/{cs_id}/stop-transaction)
+	StopChargeStationTransaction(w http.ResponseWriter, r *http.Request, csId string, params StopChargeStationTransactionParams)
+	// Get the status of a transaction
+	// (POST /cs/{cs_id}/transaction-status)
+	GetChargeStationTransactionStatus(w http.ResponseWriter, r *http.Request, csId string, params GetChargeStationTransactionStatusParams)
+	// Get the status of the most recent trigger
+	// (GET /cs/{cs_id}/trigger)
+	LookupChargeStationTrigger(w http.ResponseWriter, r *http.Request, csId string)
 
 	// (POST /cs/{cs_id}/trigger)
 	TriggerChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	// Unlock a connector
+	// (POST /cs/{cs_id}/unlock-connector)
+	UnlockChargeStationConnector(w http.ResponseWriter, r *http.Request, csId string, params UnlockChargeStationConnectorParams)
+	// Get the variables read from the charge station
+	// (GET /cs/{cs_id}/variables)
+	LookupChargeStationVariables(w http.ResponseWriter, r *http.Request, csId string)
+	// Read variables from the charge station
+	// (POST /cs/{cs_id}/variables:get)
+	GetChargeStationVariables(w http.ResponseWriter, r *http.Request, csId string, params GetChargeStationVariablesParams)
+	// List certificates issued to charge stations
+	// (GET /issued-certificate)
+	ListIssuedCertificates(w http.ResponseWriter, r *http.Request, params ListIssuedCertificatesParams)
 	// List locations
 	// (GET /location)
 	ListLocations(w http.ResponseWriter, r *http.Request, params ListLocationsParams)
@@ -506,9 +1289,24 @@ type ServerInterface interface {
 	// Update a location
 	// (PUT /location/{locationId})
 	UpdateLocation(w http.ResponseWriter, r *http.Request, locationId string)
+	// Schedule a message on the displays of the charge stations at a location
+	// (POST /location/{locationId}/display-messages)
+	SetLocationDisplayMessage(w http.ResponseWriter, r *http.Request, locationId string)
+	// Clear a message from the displays of the charge stations at a location
+	// (DELETE /location/{locationId}/display-messages/{message_id})
+	ClearLocationDisplayMessage(w http.ResponseWriter, r *http.Request, locationId string, messageId int)
 	// Registers an OCPI party with the CSMS
 	// (POST /register)
 	RegisterParty(w http.ResponseWriter, r *http.Request)
+	// List revoked client certificates
+	// (GET /revoked-certificate)
+	ListRevokedCertificates(w http.ResponseWriter, r *http.Request)
+	// Revoke client certificates
+	// (POST /revoked-certificate)
+	RevokeCertificates(w http.ResponseWriter, r *http.Request)
+	// Get the CRL for the local CA
+	// (GET /revoked-certificate/crl)
+	GetCertificateRevocationList(w http.ResponseWriter, r *http.Request)
 	// List authorization tokens
 	// (GET /token)
 	ListTokens(w http.ResponseWriter, r *http.Request, params ListTokensParams)
@@ -548,6 +1346,36 @@ func (_ Unimplemented) LookupCertificate(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List configuration templates
+// (GET /configuration-template)
+func (_ Unimplemented) ListConfigurationTemplates(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a configuration template
+// (DELETE /configuration-template/{template_id})
+func (_ Unimplemented) DeleteConfigurationTemplate(w http.ResponseWriter, r *http.Request, templateId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Lookup a configuration template
+// (GET /configuration-template/{template_id})
+func (_ Unimplemented) LookupConfigurationTemplate(w http.ResponseWriter, r *http.Request, templateId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create or update a configuration template
+// (PUT /configuration-template/{template_id})
+func (_ Unimplemented) SetConfigurationTemplate(w http.ResponseWriter, r *http.Request, templateId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List charge station connections
+// (GET /connection)
+func (_ Unimplemented) ListConnections(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List Charge Stations
 // (GET /cs)
 func (_ Unimplemented) ListChargeStations(w http.ResponseWriter, r *http.Request, params ListChargeStationsParams) {
@@ -578,37 +1406,223 @@ func (_ Unimplemented) UpdateChargeStation(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get the certificates installed on a charge station
+// (GET /cs/{cs_id}/certificate-inventory)
+func (_ Unimplemented) LookupChargeStationCertificateInventory(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the status of the certificates installed on the charge station
+// (GET /cs/{cs_id}/certificates)
+func (_ Unimplemented) LookupChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Install certificates on the charge station
 // (POST /cs/{cs_id}/certificates)
 func (_ Unimplemented) InstallChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Reconfigure the charge station
-// (POST /cs/{cs_id}/reconfigure)
-func (_ Unimplemented) ReconfigureChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+// Change the availability of the charge station
+// (POST /cs/{cs_id}/change-availability)
+func (_ Unimplemented) ChangeChargeStationAvailability(w http.ResponseWriter, r *http.Request, csId string, params ChangeChargeStationAvailabilityParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get Charge Station runtime details
-// (GET /cs/{cs_id}/runtime-details)
-func (_ Unimplemented) LookupChargeStationRuntimeDetails(w http.ResponseWriter, r *http.Request, csId string) {
+// Clear the authorization cache
+// (POST /cs/{cs_id}/clear-cache)
+func (_ Unimplemented) ClearChargeStationCache(w http.ResponseWriter, r *http.Request, csId string, params ClearChargeStationCacheParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (POST /cs/{cs_id}/trigger)
-func (_ Unimplemented) TriggerChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+// Disconnect a charge station
+// (DELETE /cs/{cs_id}/connection)
+func (_ Unimplemented) DisconnectChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List locations
-// (GET /location)
-func (_ Unimplemented) ListLocations(w http.ResponseWriter, r *http.Request, params ListLocationsParams) {
+// Get the gateway connection for a charge station
+// (GET /cs/{cs_id}/connection)
+func (_ Unimplemented) LookupChargeStationConnection(w http.ResponseWriter, r *http.Request, csId string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Registers a location with the CSMS
-// (POST /location)
+// Get Charge Station device model
+// (GET /cs/{cs_id}/device-model)
+func (_ Unimplemented) LookupChargeStationDeviceModel(w http.ResponseWriter, r *http.Request, csId string, params LookupChargeStationDeviceModelParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the messages on the charge station display
+// (GET /cs/{cs_id}/display-messages)
+func (_ Unimplemented) ListChargeStationDisplayMessages(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Schedule a message on the charge station display
+// (POST /cs/{cs_id}/display-messages)
+func (_ Unimplemented) SetChargeStationDisplayMessage(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Clear a message from the charge station display
+// (DELETE /cs/{cs_id}/display-messages/{message_id})
+func (_ Unimplemented) ClearChargeStationDisplayMessage(w http.ResponseWriter, r *http.Request, csId string, messageId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the local authorization list of the charge station
+// (GET /cs/{cs_id}/local-list)
+func (_ Unimplemented) LookupChargeStationLocalList(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Manage the local authorization list of the charge station
+// (PUT /cs/{cs_id}/local-list)
+func (_ Unimplemented) SetChargeStationLocalList(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the remote operations sent to the charge station
+// (GET /cs/{cs_id}/operations)
+func (_ Unimplemented) ListChargeStationOperations(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rotate the charge station basic auth password
+// (POST /cs/{cs_id}/password)
+func (_ Unimplemented) RotateChargeStationPassword(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Report that the charge station has connected using its pending password
+// (POST /cs/{cs_id}/password/reconnect)
+func (_ Unimplemented) ReconnectChargeStationPassword(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reconfigure the charge station
+// (POST /cs/{cs_id}/reconfigure)
+func (_ Unimplemented) ReconfigureChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the reservations made at the charge station
+// (GET /cs/{cs_id}/reservations)
+func (_ Unimplemented) ListChargeStationReservations(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reserve an EVSE at the charge station
+// (POST /cs/{cs_id}/reservations)
+func (_ Unimplemented) ReserveChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel a reservation made at the charge station
+// (DELETE /cs/{cs_id}/reservations/{reservation_id})
+func (_ Unimplemented) CancelChargeStationReservation(w http.ResponseWriter, r *http.Request, csId string, reservationId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a reservation made at the charge station
+// (GET /cs/{cs_id}/reservations/{reservation_id})
+func (_ Unimplemented) LookupChargeStationReservation(w http.ResponseWriter, r *http.Request, csId string, reservationId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset the charge station
+// (POST /cs/{cs_id}/reset)
+func (_ Unimplemented) ResetChargeStation(w http.ResponseWriter, r *http.Request, csId string, params ResetChargeStationParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get Charge Station runtime details
+// (GET /cs/{cs_id}/runtime-details)
+func (_ Unimplemented) LookupChargeStationRuntimeDetails(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the progress of the most recent security profile upgrade
+// (GET /cs/{cs_id}/security-profile-upgrade)
+func (_ Unimplemented) LookupChargeStationSecurityProfileUpgrade(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upgrade the charge station to a higher security profile
+// (POST /cs/{cs_id}/security-profile-upgrade)
+func (_ Unimplemented) UpgradeChargeStationSecurityProfile(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Report that the charge station has connected using a new security profile
+// (POST /cs/{cs_id}/security-profile-upgrade/reconnect)
+func (_ Unimplemented) ReconnectChargeStationSecurityProfileUpgrade(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the status of the charge station settings
+// (GET /cs/{cs_id}/settings)
+func (_ Unimplemented) LookupChargeStationSettings(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stop a transaction
+// (POST /cs/{cs_id}/stop-transaction)
+func (_ Unimplemented) StopChargeStationTransaction(w http.ResponseWriter, r *http.Request, csId string, params StopChargeStationTransactionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the status of a transaction
+// (POST /cs/{cs_id}/transaction-status)
+func (_ Unimplemented) GetChargeStationTransactionStatus(w http.ResponseWriter, r *http.Request, csId string, params GetChargeStationTransactionStatusParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the status of the most recent trigger
+// (GET /cs/{cs_id}/trigger)
+func (_ Unimplemented) LookupChargeStationTrigger(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /cs/{cs_id}/trigger)
+func (_ Unimplemented) TriggerChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unlock a connector
+// (POST /cs/{cs_id}/unlock-connector)
+func (_ Unimplemented) UnlockChargeStationConnector(w http.ResponseWriter, r *http.Request, csId string, params UnlockChargeStationConnectorParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the variables read from the charge station
+// (GET /cs/{cs_id}/variables)
+func (_ Unimplemented) LookupChargeStationVariables(w http.ResponseWriter, r *http.Request, csId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Read variables from the charge station
+// (POST /cs/{cs_id}/variables:get)
+func (_ Unimplemented) GetChargeStationVariables(w http.ResponseWriter, r *http.Request, csId string, params GetChargeStationVariablesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List certificates issued to charge stations
+// (GET /issued-certificate)
+func (_ Unimplemented) ListIssuedCertificates(w http.ResponseWriter, r *http.Request, params ListIssuedCertificatesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List locations
+// (GET /location)
+func (_ Unimplemented) ListLocations(w http.ResponseWriter, r *http.Request, params ListLocationsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Registers a location with the CSMS
+// (POST /location)
 func (_ Unimplemented) RegisterLocation(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
@@ -631,12 +1645,42 @@ func (_ Unimplemented) UpdateLocation(w http.ResponseWriter, r *http.Request, lo
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Schedule a message on the displays of the charge stations at a location
+// (POST /location/{locationId}/display-messages)
+func (_ Unimplemented) SetLocationDisplayMessage(w http.ResponseWriter, r *http.Request, locationId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Clear a message from the displays of the charge stations at a location
+// (DELETE /location/{locationId}/display-messages/{message_id})
+func (_ Unimplemented) ClearLocationDisplayMessage(w http.ResponseWriter, r *http.Request, locationId string, messageId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Registers an OCPI party with the CSMS
 // (POST /register)
 func (_ Unimplemented) RegisterParty(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List revoked client certificates
+// (GET /revoked-certificate)
+func (_ Unimplemented) ListRevokedCertificates(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke client certificates
+// (POST /revoked-certificate)
+func (_ Unimplemented) RevokeCertificates(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the CRL for the local CA
+// (GET /revoked-certificate/crl)
+func (_ Unimplemented) GetCertificateRevocationList(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List authorization tokens
 // (GET /token)
 func (_ Unimplemented) ListTokens(w http.ResponseWriter, r *http.Request, params ListTokensParams) {
@@ -720,17 +1764,990 @@ func (siw *ServerInterfaceWrapper) LookupCertificate(w http.ResponseWriter, r *h
 
 	var err error
 
-	// ------------- Path parameter "certificate_hash" -------------
-	var certificateHash string
+	// ------------- Path parameter "certificate_hash" -------------
+	var certificateHash string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "certificate_hash", chi.URLParam(r, "certificate_hash"), &certificateHash, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "certificate_hash", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupCertificate(w, r, certificateHash)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListConfigurationTemplates operation middleware
+func (siw *ServerInterfaceWrapper) ListConfigurationTemplates(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListConfigurationTemplates(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteConfigurationTemplate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteConfigurationTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "template_id" -------------
+	var templateId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "template_id", chi.URLParam(r, "template_id"), &templateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "template_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteConfigurationTemplate(w, r, templateId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupConfigurationTemplate operation middleware
+func (siw *ServerInterfaceWrapper) LookupConfigurationTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "template_id" -------------
+	var templateId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "template_id", chi.URLParam(r, "template_id"), &templateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "template_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupConfigurationTemplate(w, r, templateId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetConfigurationTemplate operation middleware
+func (siw *ServerInterfaceWrapper) SetConfigurationTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "template_id" -------------
+	var templateId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "template_id", chi.URLParam(r, "template_id"), &templateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "template_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetConfigurationTemplate(w, r, templateId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListConnections operation middleware
+func (siw *ServerInterfaceWrapper) ListConnections(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListConnections(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListChargeStations operation middleware
+func (siw *ServerInterfaceWrapper) ListChargeStations(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListChargeStationsParams
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChargeStations(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RegisterChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) RegisterChargeStation(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterChargeStation(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) DeleteChargeStation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteChargeStation(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStation(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChargeStation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateChargeStation(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStationCertificateInventory operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationCertificateInventory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationCertificateInventory(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStationCertificates operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationCertificates(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationCertificates(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// InstallChargeStationCertificates operation middleware
+func (siw *ServerInterfaceWrapper) InstallChargeStationCertificates(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.InstallChargeStationCertificates(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ChangeChargeStationAvailability operation middleware
+func (siw *ServerInterfaceWrapper) ChangeChargeStationAvailability(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ChangeChargeStationAvailabilityParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ChangeChargeStationAvailability(w, r, csId, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClearChargeStationCache operation middleware
+func (siw *ServerInterfaceWrapper) ClearChargeStationCache(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ClearChargeStationCacheParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClearChargeStationCache(w, r, csId, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DisconnectChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) DisconnectChargeStation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DisconnectChargeStation(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStationConnection operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationConnection(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationConnection(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStationDeviceModel operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationDeviceModel(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupChargeStationDeviceModelParams
+
+	// ------------- Optional query parameter "component" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "component", r.URL.Query(), &params.Component)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "component", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationDeviceModel(w, r, csId, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListChargeStationDisplayMessages operation middleware
+func (siw *ServerInterfaceWrapper) ListChargeStationDisplayMessages(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChargeStationDisplayMessages(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetChargeStationDisplayMessage operation middleware
+func (siw *ServerInterfaceWrapper) SetChargeStationDisplayMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetChargeStationDisplayMessage(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClearChargeStationDisplayMessage operation middleware
+func (siw *ServerInterfaceWrapper) ClearChargeStationDisplayMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClearChargeStationDisplayMessage(w, r, csId, messageId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStationLocalList operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationLocalList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationLocalList(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetChargeStationLocalList operation middleware
+func (siw *ServerInterfaceWrapper) SetChargeStationLocalList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetChargeStationLocalList(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListChargeStationOperations operation middleware
+func (siw *ServerInterfaceWrapper) ListChargeStationOperations(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChargeStationOperations(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RotateChargeStationPassword operation middleware
+func (siw *ServerInterfaceWrapper) RotateChargeStationPassword(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateChargeStationPassword(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReconnectChargeStationPassword operation middleware
+func (siw *ServerInterfaceWrapper) ReconnectChargeStationPassword(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReconnectChargeStationPassword(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReconfigureChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) ReconfigureChargeStation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReconfigureChargeStation(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListChargeStationReservations operation middleware
+func (siw *ServerInterfaceWrapper) ListChargeStationReservations(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChargeStationReservations(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReserveChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) ReserveChargeStation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReserveChargeStation(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelChargeStationReservation operation middleware
+func (siw *ServerInterfaceWrapper) CancelChargeStationReservation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "reservation_id" -------------
+	var reservationId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reservation_id", chi.URLParam(r, "reservation_id"), &reservationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reservation_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelChargeStationReservation(w, r, csId, reservationId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStationReservation operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationReservation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "reservation_id" -------------
+	var reservationId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reservation_id", chi.URLParam(r, "reservation_id"), &reservationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reservation_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationReservation(w, r, csId, reservationId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResetChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) ResetChargeStation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ResetChargeStationParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetChargeStation(w, r, csId, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStationRuntimeDetails operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationRuntimeDetails(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationRuntimeDetails(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStationSecurityProfileUpgrade operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationSecurityProfileUpgrade(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationSecurityProfileUpgrade(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpgradeChargeStationSecurityProfile operation middleware
+func (siw *ServerInterfaceWrapper) UpgradeChargeStationSecurityProfile(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpgradeChargeStationSecurityProfile(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReconnectChargeStationSecurityProfileUpgrade operation middleware
+func (siw *ServerInterfaceWrapper) ReconnectChargeStationSecurityProfileUpgrade(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReconnectChargeStationSecurityProfileUpgrade(w, r, csId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupChargeStationSettings operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationSettings(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "certificate_hash", chi.URLParam(r, "certificate_hash"), &certificateHash, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "certificate_hash", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LookupCertificate(w, r, certificateHash)
+		siw.Handler.LookupChargeStationSettings(w, r, csId)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -740,46 +2757,33 @@ func (siw *ServerInterfaceWrapper) LookupCertificate(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// ListChargeStations operation middleware
-func (siw *ServerInterfaceWrapper) ListChargeStations(w http.ResponseWriter, r *http.Request) {
+// StopChargeStationTransaction operation middleware
+func (siw *ServerInterfaceWrapper) StopChargeStationTransaction(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListChargeStationsParams
-
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Path parameter "cs_id" -------------
+	var csId string
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StopChargeStationTransactionParams
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListChargeStations(w, r, params)
-	}))
-
-	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
-		handler = siw.HandlerMiddlewares[i](handler)
-	}
-
-	handler.ServeHTTP(w, r)
-}
-
-// RegisterChargeStation operation middleware
-func (siw *ServerInterfaceWrapper) RegisterChargeStation(w http.ResponseWriter, r *http.Request) {
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RegisterChargeStation(w, r)
+		siw.Handler.StopChargeStationTransaction(w, r, csId, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -789,8 +2793,8 @@ func (siw *ServerInterfaceWrapper) RegisterChargeStation(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteChargeStation operation middleware
-func (siw *ServerInterfaceWrapper) DeleteChargeStation(w http.ResponseWriter, r *http.Request) {
+// GetChargeStationTransactionStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetChargeStationTransactionStatus(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -803,8 +2807,19 @@ func (siw *ServerInterfaceWrapper) DeleteChargeStation(w http.ResponseWriter, r
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChargeStationTransactionStatusParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteChargeStation(w, r, csId)
+		siw.Handler.GetChargeStationTransactionStatus(w, r, csId, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -814,8 +2829,8 @@ func (siw *ServerInterfaceWrapper) DeleteChargeStation(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// LookupChargeStation operation middleware
-func (siw *ServerInterfaceWrapper) LookupChargeStation(w http.ResponseWriter, r *http.Request) {
+// LookupChargeStationTrigger operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationTrigger(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -829,7 +2844,7 @@ func (siw *ServerInterfaceWrapper) LookupChargeStation(w http.ResponseWriter, r
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LookupChargeStation(w, r, csId)
+		siw.Handler.LookupChargeStationTrigger(w, r, csId)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -839,8 +2854,8 @@ func (siw *ServerInterfaceWrapper) LookupChargeStation(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateChargeStation operation middleware
-func (siw *ServerInterfaceWrapper) UpdateChargeStation(w http.ResponseWriter, r *http.Request) {
+// TriggerChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) TriggerChargeStation(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -854,7 +2869,7 @@ func (siw *ServerInterfaceWrapper) UpdateChargeStation(w http.ResponseWriter, r
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateChargeStation(w, r, csId)
+		siw.Handler.TriggerChargeStation(w, r, csId)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -864,8 +2879,8 @@ func (siw *ServerInterfaceWrapper) UpdateChargeStation(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// InstallChargeStationCertificates operation middleware
-func (siw *ServerInterfaceWrapper) InstallChargeStationCertificates(w http.ResponseWriter, r *http.Request) {
+// UnlockChargeStationConnector operation middleware
+func (siw *ServerInterfaceWrapper) UnlockChargeStationConnector(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -878,8 +2893,19 @@ func (siw *ServerInterfaceWrapper) InstallChargeStationCertificates(w http.Respo
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UnlockChargeStationConnectorParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.InstallChargeStationCertificates(w, r, csId)
+		siw.Handler.UnlockChargeStationConnector(w, r, csId, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -889,8 +2915,8 @@ func (siw *ServerInterfaceWrapper) InstallChargeStationCertificates(w http.Respo
 	handler.ServeHTTP(w, r)
 }
 
-// ReconfigureChargeStation operation middleware
-func (siw *ServerInterfaceWrapper) ReconfigureChargeStation(w http.ResponseWriter, r *http.Request) {
+// LookupChargeStationVariables operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationVariables(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -904,7 +2930,7 @@ func (siw *ServerInterfaceWrapper) ReconfigureChargeStation(w http.ResponseWrite
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReconfigureChargeStation(w, r, csId)
+		siw.Handler.LookupChargeStationVariables(w, r, csId)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -914,8 +2940,8 @@ func (siw *ServerInterfaceWrapper) ReconfigureChargeStation(w http.ResponseWrite
 	handler.ServeHTTP(w, r)
 }
 
-// LookupChargeStationRuntimeDetails operation middleware
-func (siw *ServerInterfaceWrapper) LookupChargeStationRuntimeDetails(w http.ResponseWriter, r *http.Request) {
+// GetChargeStationVariables operation middleware
+func (siw *ServerInterfaceWrapper) GetChargeStationVariables(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -928,8 +2954,19 @@ func (siw *ServerInterfaceWrapper) LookupChargeStationRuntimeDetails(w http.Resp
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChargeStationVariablesParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LookupChargeStationRuntimeDetails(w, r, csId)
+		siw.Handler.GetChargeStationVariables(w, r, csId, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -939,22 +2976,24 @@ func (siw *ServerInterfaceWrapper) LookupChargeStationRuntimeDetails(w http.Resp
 	handler.ServeHTTP(w, r)
 }
 
-// TriggerChargeStation operation middleware
-func (siw *ServerInterfaceWrapper) TriggerChargeStation(w http.ResponseWriter, r *http.Request) {
+// ListIssuedCertificates operation middleware
+func (siw *ServerInterfaceWrapper) ListIssuedCertificates(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "cs_id" -------------
-	var csId string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListIssuedCertificatesParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "cs_id", chi.URLParam(r, "cs_id"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "expiring_within_days" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expiring_within_days", r.URL.Query(), &params.ExpiringWithinDays)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cs_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expiring_within_days", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.TriggerChargeStation(w, r, csId)
+		siw.Handler.ListIssuedCertificates(w, r, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -1088,6 +3127,65 @@ func (siw *ServerInterfaceWrapper) UpdateLocation(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
+// SetLocationDisplayMessage operation middleware
+func (siw *ServerInterfaceWrapper) SetLocationDisplayMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "locationId" -------------
+	var locationId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "locationId", chi.URLParam(r, "locationId"), &locationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "locationId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetLocationDisplayMessage(w, r, locationId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClearLocationDisplayMessage operation middleware
+func (siw *ServerInterfaceWrapper) ClearLocationDisplayMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "locationId" -------------
+	var locationId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "locationId", chi.URLParam(r, "locationId"), &locationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "locationId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClearLocationDisplayMessage(w, r, locationId, messageId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // RegisterParty operation middleware
 func (siw *ServerInterfaceWrapper) RegisterParty(w http.ResponseWriter, r *http.Request) {
 
@@ -1102,6 +3200,48 @@ func (siw *ServerInterfaceWrapper) RegisterParty(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
+// ListRevokedCertificates operation middleware
+func (siw *ServerInterfaceWrapper) ListRevokedCertificates(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRevokedCertificates(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeCertificates operation middleware
+func (siw *ServerInterfaceWrapper) RevokeCertificates(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeCertificates(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCertificateRevocationList operation middleware
+func (siw *ServerInterfaceWrapper) GetCertificateRevocationList(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCertificateRevocationList(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListTokens operation middleware
 func (siw *ServerInterfaceWrapper) ListTokens(w http.ResponseWriter, r *http.Request) {
 
@@ -1376,6 +3516,21 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/certificate/{certificate_hash}", wrapper.LookupCertificate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/configuration-template", wrapper.ListConfigurationTemplates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/configuration-template/{template_id}", wrapper.DeleteConfigurationTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/configuration-template/{template_id}", wrapper.LookupConfigurationTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/configuration-template/{template_id}", wrapper.SetConfigurationTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/connection", wrapper.ListConnections)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/cs", wrapper.ListChargeStations)
 	})
@@ -1391,18 +3546,111 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/cs/{cs_id}", wrapper.UpdateChargeStation)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/certificate-inventory", wrapper.LookupChargeStationCertificateInventory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/certificates", wrapper.LookupChargeStationCertificates)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cs/{cs_id}/certificates", wrapper.InstallChargeStationCertificates)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/change-availability", wrapper.ChangeChargeStationAvailability)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/clear-cache", wrapper.ClearChargeStationCache)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/cs/{cs_id}/connection", wrapper.DisconnectChargeStation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/connection", wrapper.LookupChargeStationConnection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/device-model", wrapper.LookupChargeStationDeviceModel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/display-messages", wrapper.ListChargeStationDisplayMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/display-messages", wrapper.SetChargeStationDisplayMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/cs/{cs_id}/display-messages/{message_id}", wrapper.ClearChargeStationDisplayMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/local-list", wrapper.LookupChargeStationLocalList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/cs/{cs_id}/local-list", wrapper.SetChargeStationLocalList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/operations", wrapper.ListChargeStationOperations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/password", wrapper.RotateChargeStationPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/password/reconnect", wrapper.ReconnectChargeStationPassword)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cs/{cs_id}/reconfigure", wrapper.ReconfigureChargeStation)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/reservations", wrapper.ListChargeStationReservations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/reservations", wrapper.ReserveChargeStation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/cs/{cs_id}/reservations/{reservation_id}", wrapper.CancelChargeStationReservation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/reservations/{reservation_id}", wrapper.LookupChargeStationReservation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/reset", wrapper.ResetChargeStation)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/cs/{cs_id}/runtime-details", wrapper.LookupChargeStationRuntimeDetails)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/security-profile-upgrade", wrapper.LookupChargeStationSecurityProfileUpgrade)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/security-profile-upgrade", wrapper.UpgradeChargeStationSecurityProfile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/security-profile-upgrade/reconnect", wrapper.ReconnectChargeStationSecurityProfileUpgrade)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/settings", wrapper.LookupChargeStationSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/stop-transaction", wrapper.StopChargeStationTransaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/transaction-status", wrapper.GetChargeStationTransactionStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/trigger", wrapper.LookupChargeStationTrigger)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cs/{cs_id}/trigger", wrapper.TriggerChargeStation)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/unlock-connector", wrapper.UnlockChargeStationConnector)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{cs_id}/variables", wrapper.LookupChargeStationVariables)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{cs_id}/variables:get", wrapper.GetChargeStationVariables)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/issued-certificate", wrapper.ListIssuedCertificates)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/location", wrapper.ListLocations)
 	})
@@ -1418,9 +3666,24 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/location/{locationId}", wrapper.UpdateLocation)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/location/{locationId}/display-messages", wrapper.SetLocationDisplayMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/location/{locationId}/display-messages/{message_id}", wrapper.ClearLocationDisplayMessage)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/register", wrapper.RegisterParty)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/revoked-certificate", wrapper.ListRevokedCertificates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/revoked-certificate", wrapper.RevokeCertificates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/revoked-certificate/crl", wrapper.GetCertificateRevocationList)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/token", wrapper.ListTokens)
 	})
@@ -1443,85 +3706,238 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xdW3PbOJb+KyjuPiRbtCXLbu/GL7OKpDia2JZLUtI1M07RMAlJGJMAGwDlaFz+71MH",
-	"ICmQhC7pHnc76bx0iyQuBwffueLAefRCnqScEaakd/boyXBBEqx/9ohQdEZDrAg8RkSGgqaKcuadeV0U",
-	"xpQwhUKrle+lgqfwgugRwm0jTBcEXQ8uEWEhj0hkD4QeqFogRh5iyohEgqQxDkmE7lbo9uaG3Xq+p1Yp",
-	"8c48qQRlc+/pyfcE+SWjgkTe2T8qE38uG/O7f5JQeU++11tgMScThQ0tddLGJBVEAksQRqFui6RpfNhY",
-	"5B2W5PQkmLzvdn46DVIs5QMXkXu9pm2xZB9N3ncPOj+dogWWC8RnSC1IbT5UDuh7Cf5yQdhcLbyz05MG",
-	"C3yPLKUhqTrxBZUKBh98mgwkwktMY3wXE4SVYz5YH1Uk0eP8tyAz78z7r9YaI60cIK3BUhKYlGWxHs47",
-	"UyIjJVVYCLyC79TBio+M/pIRRCPCYJuIQDMuNhDTWCVlSxzTKMgkEQwnJMBxzB+IY5rhDEmikOIISIPx",
-	"GcIM5QOgYgD0QOMYMa5QKsgSMO3YhpAzRkIFNJQ03XEeE8yAqJiHul3gWu6wuc6ivXvTneuWJMwEVasg",
-	"FXxG4w0SVbRCeStYfSbJBgafof9Bt+1bdIAypnuSCCmBmUy5UEYK77CkIcKZWkDbI2g7vZi4vnUq35rq",
-	"4Yatl0WZInMiGoJLAeeNlVb5u1Ogh0wqHMeW/pKbmKUFw6JRAr+o6Y84c+0Ngp6VLho/dzAcUzcM4NbE",
-	"D5YrFi4EZzyT8epQ82KjstTPpRR+Ld1/oBr2PVhvtols/c1HEZnhLFaa5mvCIiNUhGUJQKAbhiRVBIAw",
-	"JrC/+mfR7rNjTvPisRzhU+fc873LEfznned7vcnlxNGxBj391d9pOqr6bbPdkbtxOs6YognpE4VpLLea",
-	"oRqahOmJItO1aZR4mKbBkgjptG+j3vU1yr+CdohykW0ooYKjR4ennu91DtuHR7sZWZl7JxMmRIFW1VTj",
-	"KKLwDsfXldU0oXRPVohKLWhahedqVJrBDtE7LpBepaZ53U4ueBZHaIGX2h6gGQfjQdkcpVgpItjZDbvJ",
-	"2u3jsDR5+pG0zNslFhSsnXmZ64KipZki1CYmjLOIgLXhqVmR1UzLKQtzkjCLEFhuRKMbJkmKBVZGyCRJ",
-	"6EHIY86kmamYfftEZavmPFgpQe8y0MWwK2j7dAn+QpMsQbF2OrQJ0Tw9OjwF5v/UbmvI4FARIY1Ks1yU",
-	"o3a77RDW6l4Wu68Hr/ta3i7sTAWdgxVpQsR8aIyIcJgPXBUXtR6ogPxbztUVz6XZ9Jlo/VV/SefsU+e8",
-	"V3GD4aWmlLJ5TqujAU/uKCNRz6lxNmmpnFKnXBkfhet1VBc44yLByl7fZNT7MJiCduy+vRg49arxZRqv",
-	"YyxVkKUR4MYtnfBJi5cgIRcResASQS+U90Kv6JxxcDbAsxIEK9Iyn16D0vmCkxT8G6/T7pwcHHUOOv87",
-	"Peqctdtn7fbfPb9cjAc9DkARuixRgr8EOEmJwHNiswB8j+OOwxUxXZY8Vvv3SPkDEUHdAHV7wVFw/b47",
-	"GXg+PByXD/2ek9MgpxE2UUMxSO99tz/QRqz3vjv66xB6jy4Hk+mwF3Tth7f2Q89+6NsPA/vhnf1wbj+8",
-	"tx8qk/7VfvhgP1x4vnf+dhp0e/mPPvwYDnrBafu4/SboBJKyeUyCo9Pae7UQZONrzfPm69OT4nXn6M1p",
-	"MD2qPQa90eXbUfVlp/boanPcrT3DIq4Gl93gp6DTLn6fBsfW75/K30dt68NR2/5yYn85MV+uu1fT0fm4",
-	"e/0+eDuaTkeXwcfr6uvp6Droj36+8nxvOphcdINx+Wvi+d7Hqw9X8HWnxshR7BvfuiIVVcRX0Gxh0qVq",
-	"dPTX0DJhoYCqDuy2MHKts56asSOYxTyi2hBmfnNaaZt/HGZCgH8ALMW5MTftC/8GQnjbL+sWwbzne6Mw",
-	"zFKa+82SiKX++Q48bf3rI8NW68I6QRPKqFzkzvV6mVaLxiqyzVF9vFrH9bIkWjuY1MRTveuRRGmMFXAM",
-	"vcIM/J7szqyai/KTfH24M9GTmYDRMNW3AehC7TnhF3kg2QRvjBVVWVTV/LOYa9lpwo2z+d7Na0SXM9nD",
-	"uOi1iW2k36qOTREgN1wbHEWCSOm05CFVK/cHzkVEWRGKbhNgm6e6Z8aU2DSq/hZAoOlsYFBl+ZCd/3Ow",
-	"HrzZvVRCisU9ZfOmeb4YXZ0Hl6PpaPxz929a644/DK/Og/PuuHs+sF5cjMBDGl0F/fHw08A0Hl0Fk+l4",
-	"oH2nj1f9wfh8PPp41S86f/b3Ikytgg3uVcohii+ZtGMwV/6k2PJ8g9ebUtuC6j5bZLmweEkUEZ9wnDn0",
-	"vtQ6IwqW8Hl/3T8x3cygDvUPOlQqnKQOPtXWXaPA7utazJjMqVRig3D1yUwnPEBZUUYV1dGbyf9xVgTd",
-	"ZUpt1LseImGNiFLBQ7MBNT7tTI4Uir4yHCyUSHWIhsVH/QwxWILFPYkQluh2PDgfTqaD8aB/a1Kd0FTx",
-	"e8LKBFWeKUWK37A7YiJ/xREOgVr4igiLUk514nvJaQSBsY6dCYl2r3c7gTfs9npw1R9enbvp4yxeVYks",
-	"CIOGty0eprSVpxXkrV+86Rx2bnVsu35uhYJoE4RjeXvDyjWZELXQAjkxYC5LzrnTS0Cje9MM+VaKNeRJ",
-	"kjEdHbK5yaoA9eRyco1e9caD/uBqOuxeTILp6MPgKuhqE7crr5+J2D39x/FFARg9Q8Gdchv1jqSCL2kE",
-	"HAXjO7mcGH7jUMG2mLQJi4gohipHKXBnOzeZoDvtm2GYS+4qEu9yHxX5ovZz9yzTuLNxQrDMBGb7eZLp",
-	"Asv9DEzGqAr4LDDjk1367iOjajS7zBs/+d6y4EM+MsuSO0dS3DRz8nODQnk/nV6j0iuqcpkIwYUbT/pT",
-	"od9+XXYX2R92ASUfzrWyqVvoukwfN3BB/2VUj8FaI+ONwwUJktyE1k5jWFTk7RdYIZ0HA+RrUYaOILlU",
-	"FnrI9rYvfu7+DWKv7sXF6OdBf/0rGL17dzG8Gugo79Ng7NQjAG+BQ+U8IzKJRNMADfvoFbnsDvuvEZaS",
-	"h1SHJ6U2MaS+0s+O9GKe1ONCvtZGXec1vTPv1T+6B3/HB//6/Nh5ev3q4C+v1y+Oqy/aB28+P75pvnv9",
-	"F8/f7da5FqZbIGhRaBkqZQacBsVV1YEd30sos54aM84Fz9INbKQS0QjpFvr0lmdpvN5gfeaQ4HuC1ANH",
-	"XKCEC1J8euDiHlQiZ6RK0fGpKzsmZebKPQ7zhcGGYLbyUcLN+aveOnBLGmnrvClKBWXKxJ/wevxu2Ech",
-	"FpGvTycZAWuIBY1Xpcp3hiaYzTM8J1s2JBVkRgSEukXjwogVx0BYouFkhE6P3xwcrRvlbuNXbdZzB+b7",
-	"xd22y+3gB3wF3OwE53FlvcdbTqMc3oKtamy90g/ej3rBx8lgDCrl+rr4OZq+1/8HIDhVSrZpQZk5YTea",
-	"gkZ7wFmfibvQjBTIlBnJNHIdgC+pzHAc5BbMSZNp0hIER+YQQ7dtFdmCsPAlSxnAbC0Cu0s+qpFNud9+",
-	"cbBnUgW2Ei5luFi9bxsOp1USmMn8DKHpvuiIPMgj8k3hHbhaUSDJLwHjVgMrp0yjoHQ5Ha6MIuJrAy0r",
-	"dnOEWXw2iymz/RBrX6XCQm0lV9NaxthNcVizbBNLcjHPZwn0Trrmqu94g9+N2SxmVgit8bG2zNombSBw",
-	"zTgXUqq+XgMrSRYrmsZGVJo8Bcdyd9CrW/nWWE1CnnTBzIwX7jUOzSFQgmnsnXkJJktyoAhO/l8teDZf",
-	"KLCB8jDkiVckWrxLPPhEEDRqnt8NGTgXOEbd66EpQVBEuzCls2J6Q9jhI/Ilb22KQ2RxHAuGB6JKiDRi",
-	"GhKW+99m/m4KQok6h22TFFDxmip9tO975Um31z5sm3Y8JQyn1DvzjvUr7QktNPNbtYKIlEvlSGemMceR",
-	"9iEapSwoL/KA6c1ZqY6rQqyP041Nr7YGrxX0nCmEcRSIZBIMTpKpDMemiqaIkeGhTAlLhAVBdwQaA/44",
-	"jvJYGcHvgzscYxYSYWLdstswKldUPYjMY7y3PFqVIZiRPpymca6UW/+URuEZhbIzp2/N8FQFLQRS+oVM",
-	"Ocur1Trtoyb3e9rMRwZxulzkP0ZeHjRpyuoZbPIl1RUnJhTSEiezJMFiVfIPAFFhocJz2Sg6hJ42zlqP",
-	"1kOwwHLxZBYdE1dVTl+/3wQ+iFsWWKI7QhjK0jUIygjfoAnXCg4r9YY3LHd2+oMxulspIl2YMYRUMQPh",
-	"hdafsOxHjwLBIFxrlVFfq1fHgG/t1fb0x9PnBlxOmvy64qjAxpPvnZgmz4yWK67QjGfsZYHUbNieIPW9",
-	"OXGovgvO77P0jwefoeNlga/9fGqypgEtphcpmT85tte43FcB601zYnxMlKBkCZIS5wXSVYssjQ+T4jll",
-	"ZUFcDZ9UqkpBkmwCtBkHmbgHJtTOO4iOvKcpuiMzLvT0QmePFei0OCahyoNjmcUKSaITshr3v2REn+rk",
-	"wOezmSTgEVrwpowmEGW2XaW3m6mTFfIEUZlgm6aNaUJrs5rCMV0D5q9pOHLQ8FsFbL8ig0q5f7OIs4HD",
-	"7iZMvCxxABrN2pAFwFIcKvGR1vZuT9echsGmY8TIg7PgWa6kIkl+ciBlluSWoOnJ3jCwDowrtCLKWAl9",
-	"AgEOOon0UY0eRRcTu0qlKdPubGoqfvVrcsMkR1RpD1sPGXI2o3NdsK4dZar0KQYs4Y5zBfOXOQWXaSnW",
-	"XIXGM/nDVfh9Rx5xwUUncLZB0Sjn1mMoAxrt4QkjjGRKQtjSOlzuVogqiYb9w03Oa22LdyroOh7L6xuF",
-	"Aqz5G9KkGvZyMhwVDft5uL0qUYZdL9L1rGok2J9hf7ta2m6gi/N2Pvt1KMi9yD8cBb+rb9lUOTvg9Ds7",
-	"mB/ZPeMPrK4yXhKcz4n6FVhOM2cuSZ9xNMrRtYc5p0vC0DByZmyg37ehwF6E3XwREvSCElZO2O1vmFv1",
-	"S2mFA1mFaXHhzmZZ5fbdnxSzrouI+8O4dmfrw4uCVr606h1E54XJr0CbIKVbvzkvP8lgtUQajzNvnxeD",
-	"QESSnyncEaTZss5NNcMaKhHVocoNg4iU5YmsojQOQzQxJ4wIHDeuZRchjc7ak3CBGZWJD3EIjJqPdsNm",
-	"XCDOzME/jMHmxBRWwfajKBM60ifSXFvrzsCZXrNBz+U7g6yipk8QiHdIhCTPS/XqXIGYSeF7gshsRkKF",
-	"6EzfDBOZ3lzF3eFRuRM/rI99S/E7EV9rf3+jyJqbqAfR+hLrdm++dnX1P+nV1+7T/ql8/Nrad/srtX34",
-	"4flv9/zr7NpfQqy7pW7vKb+s+kPPWjd6v2k1uxUZduHyVk0prZMJ8LSKjjKvvSfCrgnVlQiuIzQqVXE/",
-	"58fpxIs8nbCvT+1/MFHC4eUdScQW3gpZKGG/3zFE+fdxdgK86HWxvgD3HFpqvUnfYfp+M8fdO2jrsdZj",
-	"8WsY7VnSUnp75ZxrP29jCYq1v7tLANYk/UZv7cRVHBE2UvAnWxuyl10oYu9CJbtZkdmdtuord9X478+x",
-	"q1992tJ+Ji2xETd1j/ubwk1ZhLEfbrbkw38FakzH300X/NF2pL0FHcWVim8URmVu2rq5vsHUFP7u5pyg",
-	"ZciYuZ9qbnRUvQfUJ0VRQZGprCTK3BclbxihakFEfhVY01y5/VpOYubMb8kWBMQxesBUlbdnzXtdKp0P",
-	"d8M2DbjL57mGsZ7J4alckf5OnZ7NWLHAyMOU5kAsr2W4qyapVOYWdXHVbIGLipX1Xev89uL6VGZD0KYv",
-	"QP6I2F5kxGYup+4RrhV/etUA4uVFao67tHbQll/k3hixGfGWoPKy0qLnd+J/LfYnxED/mdRavnXfkT7r",
-	"2RciQae5L0jX97TUZ61HczEqywuxtleE/8bdNeMUG7zbeStJ29fjd9xtfFaP38JTLVPT3IUfZdzVCOKr",
-	"oLq+2FcpG9y3tlubZWsMCDOaf+/UYYatPm9XLz5H7/9wEF6Eg2DdE/6qrK4N0cOX5y9slyBbdC0GbBbg",
-	"1mP1vu5eEl1mDKy+TVrQsK9Lze1Gm4+R7f36VqTaXtkuChrXol/GCXZFTFxiYa/RXGk+/P2Mp0XdC82h",
-	"vKMsAp+sgt4NIqj/BQGx3AzqmIc4RhFZkpiniflDMtDey/8AlbdQKj1r6cR/vOBSnb05OWq3cEpby7bn",
-	"xmjEw3si9hg0wQzPiagO+fnp3wEAAP//Vlz8FJ5kAAA=",
+	"H4sIAAAAAAAC/+y9aXPbOJco/FdQet+qsW/Ja9KZac+HuWrZSTztrSwnXTOjLgkmIQmPKUAPANrRpPLf",
+	"b2ElSIKLvEXu1pfEIkGs5xyc/XzvRHS+oAQRwTtH3zs8mqE5VH/2ERN4giMokPwZIx4xvBCYks5Rpwei",
+	"BCMiQOS16nYWjC7kA6R6iOp6uJkhcHVyDhCJaIxivyPwgMUMEPSQYII4YGiRwAjF4HYJxsMhGXe6HbFc",
+	"oM5RhwuGybTz40e3w9A/U8xQ3Dn6n9zAf7rG9PYfKBKdH11/adfonkZQz6k4xdMYEdkOcSBmyJ8hB4IC",
+	"hu7pHToCCIsZYmDsvR/NIJ+NAZVPZ5BN0YgLNcgIx2MwT7kAtwgsGL3HMYrrNk71FN69W8jRh/duAwef",
+	"ezuHv3wA8gNAJ2rKxyfX4HYp52se5I9rDr+dITIVs87Rh/elTe12SnMvT+RabQJA94gtc2coZlCoIfuD",
+	"84GcFMCcpyiWO6dmovoGpm/dXLYiVIAlEgB9W6jjzM3y8N8Cs2QIckrCe6TfgQllgLmTPgKUILkj45Tw",
+	"BYrkEcdjsCWnFaMJTBOx3QXjO7Ts0/mC0TnmaNwF46jn/x6SMZxMcIJVn/0ZJFMUy2Y8XSDGUax/RYhz",
+	"1eJycrlATP2pIWPB8D1O0BT9gcUsZvCBjIckCNtlAFabNxAVgHuNFgxxidMAFjZ6twRsGoxGg8+9w18+",
+	"jBaQ8wfK4jYg1w3CXOFgXYctwE2RlNFK0L/15fpMzweTaRcQChYwln9vVyKEozglCgbmlAvAUISISJYA",
+	"Ey5gkqAYUBJY2i64JMkScCQAJREKLV4CNIwitBAoBjA3lEV+gAmAwKNIAzwlKAaSniEudhVIMARjOVjn",
+	"SLAUBbYO3XPEyxt1hrmQSz/5OjjhAN5DnMDbBAEoArOVoIEFmqt+/n+GJp2jzv+3l90Pe+Zy2Du550gO",
+	"StJEdVeYFWQMLuX7EL34QvA/UwSwJa1M4WZ4MqVVYnIPExyPUo4YgXM0gklCH1BgmNOJOhdJa1iqjoYA",
+	"SIDpANgOwANOEkVyFgzdS2gIHGJECUGRkHNwc7qlNEGQyEklNKomj6flddr2YXwJrnuBiATp0XOiKgSM",
+	"CijhsgJhMwoeAGpLpc3eoFjd2Ueh5nO4tM1AyjGZ2gvTDZQSgROABYgp4hrgC6SiEf7tDnEUpQyL5WjB",
+	"6AQnFXyHbQVMq8qlYg5ukZxyupgyGKvba7VFqrYpYxK2DACYuZZnofdBNjHDyfEl/iVIoFpCgIlAU8Tk",
+	"Tjx2B6hEigpkPAL/B4z3x2AHpER9KTeCQcIXlAnNrd1CjiMAUzGTbQ9k25uzQejdYe5dmQj7d6C3LgGn",
+	"AQJ3A6dcTlyxFRwlcv/V/CmZ4GnKDHOB5otEs23yoOFikSzDfIhPAktAlqdvBZ4Tx53A5ufJw59NV7l3",
+	"DZwSSY8oW4YPMMeM5i4qWF5S/sKHaYwFikdQhLsWeK5uiIcZjmZBguiP/YAYAgnkAph+O93OhLK57L0T",
+	"Q4F2ZH8hquZ3s+oi60+u7vJy++rtdflwu5055hKPR82zRN8WmgQySkV+zgrc1A5Jgpmt4GGGQmsAD5AX",
+	"9/JZF8WQxNinnL2ane0GYOGfS26r2kKB4XNWmlN4SA8UXadgwug8DC1tplctV/JOBYisguPySaoON4+h",
+	"iDHKRvLiDu/IZf/qCqhGQDYCDImUES0hh26xCcByT/iCkthc1ZIbUj2EzkSPnxu3YRrey2efDY4bqYPH",
+	"T4Z64G6fy70YWNJz0w0z+fBKX9VSjusZNl7+fY3+oTBey3EncuIoHodG1g+CUL1cqDG8VWTjfj38JMc5",
+	"v1T/ftTjSEG6Wfeh3nbNdaTXvQpI8kHNXuWwjSt2mTbfN0UK2oqiNaBM0228Ikoq4b2nxSOcYBG4dHUb",
+	"BdDQa6hZ6aYd0JwhZaNKWLYt5I5GaqgjxYwpHDvc3d89UEOPpaCXKZFgwmlBk1TmnMwn4YGlZJiNCba8",
+	"8ShJltua232Y0STEHOuvYonTdI6FqJgBtYoPmIzqcNGeYG6HM6ww+pN7pPHhlFD3oBEtAlNoBgsj+FFS",
+	"e2hyL26ReECozHwBSKTgP4UCPcBlGTCaFWw3AQpaS+ycSGYu1JbMmFtL5SyyYRVMmDUByLnWWBiG+gHd",
+	"chrdZcKhxojSiOb7NsMZSdmOWCOtucUDQcPcxpwKNIJxzBCvgEKCxANld8A0qhwuG0syGcF753mlUSvn",
+	"mIGDqMbT2wWjgkY0qbm0szPy2gOCplRgpRNwcmDNERZJbgmWi1CVO/OgsJSD3Ub8PEb3OELnNEYVa41V",
+	"AzCXLTKm9XYp2Q2P0DVpSNNFDM2cqhlUURzP8fLm89Yc8T1kWKrV2l+W3kZ8NR833pDeovwhmzcd80UC",
+	"l+eIczgNWobm+lVIFgWx/jp0QwpERFDsRiQeybmO1Ia1pmgbJtqfjd2z7+35a3uOHiHeSrX6Nqwm2g5S",
+	"pASSaWpApTT2gmHKDL8VYtuZeMzRy/mgqi4ruQ/9zl42ZvVHmRDg6ecKp2HOgftiQtEmMCS29YwmMffH",
+	"6ILxBRWDdKEJlEGtj2qp48LLK7NhxecSO7VRKi+cfCF3hD6QG6mtg5E2PuGgoeaBpkms9BR8Rh8K87My",
+	"jvl2SMI7UITELhj3EwRZ8xZGslluVwBlQzL+QuaQwGk2dHnv5QZwAN13WulilNSSRMZpkiGSlKKGQYZE",
+	"ZHtkmJL6Cw9rw60BYIdiXUfM2gtgn5D46lP9MnA6Cq0tz9BoNhpFj/BlUu5fWUMM6NtvdsHHsgSiGvKZ",
+	"ApYZvNcgPqHSECOV2gsoBGLkaEiG6f7+u8jdVuon2tNP7Qj64S7Q3LRpqYeIlLkmStIYSXCiC828e82U",
+	"5C6RTLWXjLaUdACOh4SjBWTQXPYczfFORBNKuB7Jjl4/kGtVHgcKwfBtKnXVSoyvGu4o01aRyBvuFoEE",
+	"TQRA84VYdgHane6CsZTE/v3fD/au6ANiY7X3Q6I2/2D3Q7b1mAcU3HdoWbKbHOzv7wegfI7JqQaDgwYO",
+	"YQWm4FQrUfqNulJlLJ2UXCmMEqbKzlrSPSiz3S1SOoghCXsUQL4k0YxRQlOemO1pqZhYdd4/0fOlzZXW",
+	"tV4Nas6GFne6HUTSuTxoe2d1uh17eXS6Hdvuzxq1lu3h6+GnTrdzfin/+djpdiSRDXwYVlU1ees8o6Ln",
+	"jEYwkUbx8HZJW02iDFWU4f81Cm/MBZihRMsNTeQWEcHwY9VcbnYnRLBlSH+/4WlzGgRG00Wl/kDQO0SA",
+	"apNJ1Oo0scQs2U2NoqA9oyi79DS3iHFMiWG7NA9mnvVnKLpznNDDTIv6CNzr1w7IQvyVM0RHshMUd8G4",
+	"0BMkRtB09vlbpZAi/AEx9YGvwf4IcSL/GhI7u3PM51BExmvNZy3HAPKc9Fw+u25OEZ7n1DLGt+Jow9xY",
+	"XuhuKTnrpVRwUPqlf2wZq6iNbmaDwmsMCDfFC9MM76Cn6+hBbj3tyZQmBGUZmwDZ8VL7DlVRrRJxao0v",
+	"YSvMSLWo+/jxVpj87PMIpvq2XJInXykVsPLnCZte5GejagOMz9caW0xptNPB5cH79+/fmdEGlwe/fPj1",
+	"3fhIixZIZCr6g90PjRoyt4XtBQMHCQMkBCYhFwj7JudgFLzF2pgqqmHk2pBM7qEPzbaMS2AUM8zrAanR",
+	"m9E5SYZUS1p9C5wyv70RanNz+rOh1ZvsZgGjTOlsQct9ZxHkGnEkxjV2/fAA/zm4vHD8rxrNNm/hIdDW",
+	"r0DuBkftJ2DaP4kZcBu0ot5IWIcQ05134EMS+HKrQA1LRumB1XuMt2vuZtvfkLzw3VxljfPvygwEcie+",
+	"2tUpAZLd1xAQ91qRQ6INoFC0MOIytDJDsqE6RSflSvZDnwyK1YEcGZ9c6yItT2qp3hjmXTUNW7tl3MBS",
+	"aY3bn5N/69VyPqJMZiaIIRKhtkZMHwKxwfklF2iumVEH+gALQ2SFPqHT/KdxBdGzLRo2umhJfpRqvz1d",
+	"9AZ9vEbdiUxD4vUnIULeVfdIyTVpYujhZRSlCxyijV+I84SvVYYPiYTCObxDxTV0mwhqtUJcUfrxF26k",
+	"rz4kEUoSPc0THfOiZ3mN5vQ+Z0jwFy2FPCT7r9Bl55jfFqru8gF6bTRoavNzfh4vcjsUoLjrM87ewvK4",
+	"7l0kHqV+zOWBLuhD+O5QqKkV8KrhSvdHKzcc0/FRkN5JUPPponJBJHiezn1lbjUtLA8r3yiNdtnH0gc2",
+	"1c8KPp3VQkTPKmTkMepVqj8tKdRvpTd9ylEA2Dz99rsPj5RSnTKotL+S9m1BocOADvfVicJIIMZzgt52",
+	"i4k8RfoEW76+NhNCt1eRMP2zbwX4IijyoeeEbLGqf5n+qMG7rNnHUnWT6ehO53MUY2kvVYT2kpzGCWrp",
+	"Wdm8lfJOm6NjJCBOeG1sXvHK0F+CWH9a9kOZhz1dzkvuLaELTV47WGhF1y2lAsXBUCMaLRajSj2aOj3z",
+	"Vt8HOoij5EBjDQNaLaLOO2hIuEckpqw80Ff1/HkWVZQ9/BU2HufAOCldaR+lLzowJxDXpl+UkEUrJ2Z4",
+	"OkOs5OkVcoPJrHojnlBR76KWaw9k+7y8TtCD39Z6K5rhy9iYtxQdBNEt4nM+YpTmAjWbrVzF4AhL8VR4",
+	"sKK/zH99lDmDqlPWEVFSA67s3oE4pDAjZJwClAMJTWscuGgqwBYm8owk47mt9tB8bZyddWBX8756c8+7",
+	"8Ab3U8Gj2tSUVfixyRjXsD8g9/wBNS7a6RVhrcWkClRAzpFNYJVoY9fvmlVOsR001k3QkpM/5Ov9Tlf/",
+	"cWD/OLR/vDN/JIjzfe/vA+/vQ+/vd0Gi9IQQOkMFBAVbmpWysf6GAogZJLmYwGIv20cvEj9XoIGlFT6S",
+	"Dl4jc44ttwiGYknzMaQlmvhoJ9oah10z0CvtUl1IxYLRqfIyDijny4sy8FWh3W7U85hsCH6E6UQZAoMe",
+	"g4zOHxtP6+Lb7DAPkFv5qYKLe5yWlwu0qJiTQJ7d185jpuYBpXLU4we1F8c1pcLzYJEC+QCJ3yTy9VIx",
+	"uzLhykpsl0H65bYXmriZ088/7PtXte/F13uAWDhE0koBE/NrNAHGWFsl6T/2kEqRzcFzebI0H4aj8MTN",
+	"gT5JA2zsYVWxdTASKUxG9zBJUZWrXZIi7WN3C6O7qhDCDMi5HlEBV8zwRIQRaqMMfpxNxWxv+6g89bcU",
+	"B64NGBadZcdDIvHqWJ9VBWI1QghPF4sEe9y2mWgjRuie2xuBfdsvjGOsHRSvcmBdnuQdWlpnQd+500xy",
+	"49v5RN9OPdwcfpP6N5AoXVBOSyQ3/5d9X4nU1kWzxr4PK51SGmBnsCq68Tx8NyqBeAsgbe0Jl6fhP7qh",
+	"Sdtp3qGlPhx5hOX9KPF0ZprNWCfownObDzheCLoA0NeTlzZlVT17Zo85ysOScqywsoQCan1BN+qs8hNo",
+	"XLS34CqI+YSEb6lWfOvL7MEjvEduGJ7KfSmPol8EAjSrZu06ssLnb5SKC2pYPv2N3qPiQzwlXw8/9XOp",
+	"1hTDKEc2YJ2ParYN6PwWExT3gx6xlUesZ/pny73ZJB14XvbE7P9K7MkFFaeSxZ8jUuTyw5EpVVDthcs4",
+	"X0bTOsSEVoBOe1bkC0lodNe30eqh/F6yQRnNXID7U4PjUzXAIwPdzZFlHW5V6Jy2m7UDuXk3bpyLy9zg",
+	"3TPhneXhVkI845fdt/yHErfNQ3tERWmhZznCm+VCm4y2ctZA1+vvaPlYWaJ+GxsRuS36tg4ye2yE2ZM5",
+	"Pz9+uW6Cq3F9DaFMvmrmxuQKC9mu5VixjQVyDKgivJgDaNjlYDiSaaZc7IEMddKsdqIoiHkJmXSWl71r",
+	"vYJNW7YLeu5vhUHSViFN59n3emxlDTCpWPMmuUD0Uw21DaROy7Nljfn4apMiDtS0eXCXbJwGjSr9SyrM",
+	"oHW9Gtcqic+GldYh+kaDs1TGwyA58qSaVaUXbtLWPWKqTviWn69gNl1pD3QnjZuwkgDlMwZ5cMsC0C0/",
+	"Pbjs/35y0+l2+r3fzk6ClpgKjzxp7x3ZtArVji3afSSiLC4lYwBbeEoo08nktL/Qnn61rXwnoOTOOked",
+	"w/3D9zsHhzuH/3pzcHi0v3+0v//frd1g5vDbCM4XiJnod/cVJuLdYZCFkZ/c00S0/2Ihw0VHxYC8Xn90",
+	"MLr63BucdLryxzv347gftnkJSGLIYr+T/ufe8YkK6ut/7l3+56n8+vL8ZHBz2h/1/B+/+T/6/o9j/8eJ",
+	"/+Oj/+OT/+Oz/yM36H/6P373f5x1up1Pv92Men3zx7H84/SkP/qw/27/19HhSNpuEzQ6+FB4LmYMVT5+",
+	"dxh8/OG9fXx48OuH0c1B4eeof3n+22X+4WHhZ6jNu17ht1zExcl5b/TL6HDf/v1h9M77+xf398G+9+Jg",
+	"33/z3n/zXr+56l3cXH667l19Hv12eXNzeT76cpV/fHN5NTq+/OOi0+3cnAzOeqNr99eg0+18ufj9Qr5t",
+	"lFBdYDyOOwWsyEN8Dpo9mAyRmlDOk8CV7TR92r7mKRgDRPJfeC6FS9kDxykHn5SbxTGUoRBPpy7EXODo",
+	"Md33Cz3ITu2HI6vwDNLVrJniqsJN8tJarRRWfmmPo34erlXFNEqSWG7axe+7/rG1BKXsiEoXWUTV1P1c",
+	"Kl426HkqKlPIqUA32zHIWmYSzLXhq6QI8gfDAqkfxh0YxurROJwhmnHMBaqaVrWPXDYh2SabSk/ZyeRE",
+	"biSKqEQk51jyNno+5/DboCL0x0k7rcK/vR3LraOb7XTLQ+uXUSd/dDEUsMYnU77OeWM64iENe2iCjYCW",
+	"qQlsGJSBcONPqPqUQXvjKq4gwXNcsKfS9DbxuAiSzm8NS4DJSu25llv5aE4JFlQNG4SJlGBRgX5Jivgo",
+	"wTz0vnCK2Z6Ghw6eXescUoLqbDSPzCXl2Vp+OTjstsgt1egjPRGIeW7SdqLKWVU57LdmDzOOuDyofufp",
+	"q+SKjsC4N+ifnkpU/HxzfqYUGtenJqjh5uO/jQuew+rZ9io5Vcs5n44AVI5b2RMl6yZKQqv3Sd9vSgdV",
+	"Hv/6Yx/88uH9B2CbaZ1Xfh/yRrRQSRA/rVR+kM/51EZyLRLAZFRKL3mAS/6RUaKI3SnRf5p4eDaHSX8Z",
+	"JagqCUcxVVUTJCntShCQzISwdVNOloFdXjETVmNSSdUSYFI7pbE1YYzzsTbKgboUW9MuyVINEJpxQZwy",
+	"63npfa+20iBd5ozhN0Ak5o2++gV65iV0suAWImGqBkaIN9Dc0QoZQOwnIWbQ46Uqim28OaG4TsHrCiVk",
+	"yVoLSl+pxff9y3sW2Drdjg34UmlsXISggVIpr2Sg2TF6Ue2t8RETzGfmssqW6bUorSKtrm0isTVfOEpO",
+	"WuntjNDRv7rkQGrV5I6BLUikV0N6q1dNmXvFt5u92VM/0XPXB8AQ1H5C9Mwrd5UH3gQKLNI4r3iYJBQG",
+	"NWMJJdPWzQuTdiP53YTmG8xrHypGFkoFrng1LLjm1/wmqgaKZFxqK289R+5ulbPby+F98rXfN5Wmvh5+",
+	"yk0qmkFMgpe1nO4IJlNJl2bz8JTUklybfBSAKrzFMhcX6Rkk2yNu+VddUSZIsPXHozu0rCnGNEPfnKO/",
+	"X27JjLxIbxMcyXFrRpDTe/wQMeZSLZliPkNxwSjgq3MZhsnIMMuN4+jmQDcPl1ArD5Fq6A12bl4GugJb",
+	"MgADKDuSDj3QxQX8Nnx7lQoFlikvQE9gv8uHXNypIG7Kb+JVENPVf1slZXwll+AfVLAOmTe0q4PXUIfk",
+	"sTifU/83YXoQx18iE7je8FVqffiZ6FzFvtZXO6FipOSjx4y3aowpQwQ9wGT0mPohlVVX+J0GUNV5ANtb",
+	"sjgvTWXaZN4uE4ICUhXn6Z+fDzsh3D+rrJnZK26uZ8kr6FKzHOxlbKhKCRxRymJMbMbGOsba53XUl6lN",
+	"phXoVb1zThgV4nJTIUqrrWxk1ReQ3cnCMSWrzdnlxafR+eXN5fUfvf9Syvjr308vPo0+9a57n068B2eX",
+	"N5LjvRgdX59+PdGNLy9Gg5vrE2VS+3JxfHL96fryy8Wx/fjPbquJiWVVHowFlWESbpMaOgsly7VHbg44",
+	"O5TCEeTP2ZtWHSyulJhcopjRH/FwIT6ug/orwbeEdLwNxS4ZuLMQFTu7zJS/QjGy1bJ4y+E41FQRwWjW",
+	"ItlH6DgDWxA6oHMkEPtqdcL5XeRK2Ip1OEZ7oXmgP9OdBjZDUmYu4HzRrL8szMD/NrSYazTFXFQlzzpW",
+	"SmJu0v5igZVTu4t0NJHdjjE3WV2yHsGC0UhjSGGfVsi04nVni4iCU/tS/QbSAwIyedlBDsbXJ59OBzcn",
+	"1yfH4ywRlc5EYBPtmkKbQNAhuUWuEgWM5GzlW4BIvKBYlZy9pzjOImVR3Lze+gkOyfjq5OL49OJTeH6K",
+	"c85N0k5MNhzv0WiB90zENx937ZPD3cOxkoey33sRQwpPYMLHQ+LWpP1nLJk2k5F6Brdz4TS5jakosqqL",
+	"EZ3PU6K8iMk0C+VF54MrsNW/Pjk+ubg57Z0NRjeXv59cjHpKN9BUUbc2ophOshHs7rhjVCfiEjC5QG21",
+	"3zCSrJJ6yBGJM6bF9WLhzmeZUoabHVLVhoXxTtaZbhI3dEnueMXi5C9aY/uZOP2sJkyQRweCdqWSWMmP",
+	"1UJ2eKhKEbrrkhvlxDg9ivVzk3DRqS3LHXiljmm1SM8nMNVPWEeNfG0kZbPO3KpCEJy7s0KaY4G+iXaa",
+	"Xo/7bmw8R5CnDJJ2SuTFDPJ2PKy0Ho7oZKT7R0039heCxeXk3DQOGImtATMYKxfcz4or8fPNzRVwCtGA",
+	"r3UYeKxrs7qhH+mZ7L94gsvuTfja6JFCUleb6qcASTCaodE86E9+SmKbQV/RE2vrlh0B+aG8e4x57wHF",
+	"3p3XO/uj918SP3pnZ5d/nBxnf40uP348O704Uf5FX0+ugzehBG8GI1Hn468agNNjsIXOe6fH2wByTiNd",
+	"j8rdhyZBkvodiBs00XqUKV2ZCVjsHHW2/qe3899w53///H74Y3tr5z+2swfv8g/2d3798/uv5Wfb/xEu",
+	"m5aXHEMLUy1ydkxJcOVOy6s3f4sfKvOp92ulpOeYAxzrHFqq8j9NF0l2wMomrLPZPVAgVYyUueS9uuIZ",
+	"B5SgNmm2Km6TU7MweSCQLLs6k5ZZtWKsS/GopilYMExEVtz3+uPpMYggi7vK8EmQ5Ocgw8nSMS1Bq4Qx",
+	"G9ccyEKlbGQozmzMhg2zdwfk4HRwCT68+3XnIG+IXvWwXtom1+7q9KX6wH7ItxJuGoHzXW697x5V7tTS",
+	"LEdXjkefL/ujL4MT6VzYu7qyf17efFb/S0AIkpS0akEmkaVNSd4CnFUS8RA0+wmtdaNuwJHmHvO0gTXR",
+	"TfYYgrGOTlZt96xQHllpyOEAJBkKNHMleeWJO2/zXddYCX0i7HDYrr7rXxzBWykfC9uikGXI2wbFI47+",
+	"OSI07B9Ym2t1jgRiq6oKPO1DQFFAJ5MEExR2kNJOHXXTXTnNZmWyTD3KSJ1kaKwWKt/CaNVpM3P7WFhm",
+	"4ZAqJphtXAhS8rxeCVbmaSLwItGoUt7TCre0ot1btup6fZUnIj/BZEItew21VQ7NIU46R505RPdoRyA4",
+	"/78yomY6U2la+G6kanlqXW7nHJ58RUA2KgcknRKBmGQ/elenuhiQQIqFccyK/loKFl2AvpnWWjblNs9C",
+	"yrVeRMrKCY6QSVluxu8tJFJKZ0Ot1hJJNisjsLhkfZ393X3dji4QgQvcOeq8U48UJzRTm79XSNomlbqh",
+	"bHYJhbHiIUqStC8v6SQI8i+VasHm7Cy0llwrIsJkpwpmCZMXzjyVHqc6tZXV8sgfzhuEA8hs2hoJf9Tk",
+	"rVFqWhjv3MIEkggxra1xn53GbkX5kGujpfiNxsuC36DSwWqivPcPI8VqgtLozuON8CMPtIKlyEtMr47j",
+	"cP8gUGpa56/VEKfc+Z5tejajwo8SNH8h6NtCZ8fSUtIPZcyezyFbuv2TAJHbQgGnvCAXd/6UX/pwtve9",
+	"KDb/0ItOUEiNc6yeVwGfDo/iurpNusiAwOmoNDTBOt3NkBSVNyGY0RPJw4wULxT9lMv+3sFywhK5MpIR",
+	"VBH4MND1zqpegffjzxK4vC/v1wV1jpE/up33uskLQ8sFFWBCU7JeQKoPrCWQdjvTUM7bM0rv0sUrAx/o",
+	"5wrLuRBANY5VakKGlCxkY4WPckWxlWaWqVDnIclsDtzl7pat5yEw1yteLzDffzmCXKC12Wun/Pk5WPT+",
+	"YP8VhiyUUsyg2UDZWuFzhottLx0/dnlHeKHcYVzHXHAXjR0OfOaAshgx400YBxEIcxGMIeedJwJ2Ww/i",
+	"8tCBwoml3T8zRaEqFr5eoCDnWjVRDyT8FiPbohY69r7bv0Y4bsuYBCeyCwa5nACKhMOEIRgvNZL9M0Wp",
+	"sYcW/AKGxJJ3OJmobajhSIIHXiLajwvtD5B6b39enJnph6eoj2RduY3gnNtAZQUPco0Ew+i+BtSsa3MF",
+	"QdI3+l8FTJ6RGQiTygBbED7SV+MMKtCArCXbnV3TT0CERSqqJGF5BRt9uMQInYWligTfeIlT/oVnWVog",
+	"Qz75VVlSSmVWsxwtxlKRpV1xfif2CVad65yRU4h1PljZLYC6C0ympQFkig1evCdCxavNrTGDeuaa7+dI",
+	"Omb8IafC5UgwGZKMTzG5ZcqeA2ip+rD5aTDRLI1Sx2fI3QVcaVKgQMw9HxJ6jxjDsdp5s5mZX4gsiUwA",
+	"gizB3kchkjRA4i3ToxdQFFWTor+IykjPKcPdJxEIw78ZqbaBo69MfwOZCzJLll4+eKVDNJJ0DYdvRn8l",
+	"tt6PKcgGb8PY31Tmvldb4Qk01fEE68f8Vy7Ih5+cXcTCDa+EF8tpScJnpaEC4Cht/gJOMXHVbQLA4R8W",
+	"b0PUMnd/BQ0q0v0OL8AtmlCmhmeK1qr6HkmCImHLYaWJAByJXUv1/pkitszIHp1MOBKdHIWricj+0a2e",
+	"Hc9NT+udqobVKQoKdNXUJpNZjusqlT2Z51sdodqgUa8KJtYPOfTagAeAlRjRrbD5aM9meeg64L+QQQ7c",
+	"ZJUqtRco5+kcVXEwQ2LL0C+RKUWvuAZpqkKx4ppUL4ukonIMJoooL3ROR/VYZsejAAtla1Jd2uvCBoBj",
+	"oTxS5RIkpyXHd9b1EF23a86Dxgtd+Hnw+wtd9HYXg4DTTJz3vke8nepFsqALFMkjLYKLkYdPj3erlCaF",
+	"I27mOmtC7UL6cL4CnxkIH2qpHslPao3VInmKJM/n9LieLNVf0DZ2gk4eBwVGJ/LToeBV1R1lktMATq9s",
+	"ADGJc4skY53AWaa7Xx2Wg9qML04MKsSfSg5ziu8RAadx0HdBfvc2CNha3JtrgUFr5LoRBLv2F7Pvy7GD",
+	"bUqOOpkqZYQXPYGqlV26KJep9Am5LsqSJCjuOi9YyPNdqVTJrp1iJ81d6ELJvPYApjFWQWWISPfLOAsj",
+	"cztXrE/p52SWbGw2GA2ljtQatoRTZ56vUcznJPtsSJft5G2wJy+EZcENaankyD4FGZy+2m12EwZtrbu1",
+	"4E2WOShbu7uuhLU5wH8eItJCHxMmHplTjCrIGsy0DrY4klGfFUOPt4GgUyRmiA2J9dTEzC4zS4aW8tUx",
+	"mG8w12xEjWmrEmdf1fMmD11tAGstUTUf/F2NuOGiEs06ojz0m0qZbw/8X5gttfuSg+XWnGqhxvrvawVo",
+	"Zml50FoZoIp3wQySKdox+fhcuuQqxaQ6vUr+sQsgMeV9GIDWMECZTiIQI+tAfq9sUZi4n14NxIPdD8Yz",
+	"3f86n0pu7Oe+HgOYUCJNnQGYVoXaFEnJTExQgARBLgAlEQKc6tW49HS2qL6NStb6VgO4Q4K5CRAzzK78",
+	"zqC+VoYCZm5OU7Yld/9lHvRjxR6PZcnZsRzLWnLV4om1MCgIrax7irmtqRgDnP8mN2ugJj1Juez+YVZR",
+	"lDnjkGyNIEN8FwzdY5q6ZAJZmU0VPWiXpIvxpIshERT8AuaYpDJTWuju7iuoyyFuz4fANSRe3XrTkSlW",
+	"L7dMHqnbosI0BbXbW2G8eYBVtpsPrUw3L0xe9cHlzupn6gEuLVRVCQEO7LoGU2w2lEbc2lJmDHkc8hN7",
+	"nPazbXkzGLPAz1xW5jVczy29338tl2aP5GCiAhqB2bts0lgTGm7relXU2lLz/vVni4ttiSHNDq3b+WX/",
+	"8OfMu6rAm5rTTxK9Yxz7e6muKjxH6+UiowibOkifFwpnJFuFv0oQZDsqoPfxfBVQvXDFa+QTT6iON6zP",
+	"W2J95FHm71QFHBuW57Esz4bR2DAaG0Zjw2i8EUZD0n/NZ5Rv8lUYi5wLbpW3To/f8Vxwqrrw6QPh4aJr",
+	"Wa/K3TGhHAEsSjcqNEV9h0QxEv4Ajk64FM66F0+lgyk5Kvz2qvZkDv6myyHRNpt6s2WMueNzggFb7v0b",
+	"9D86DOeyWW3Pf6r5zVBid0Tr5SjlYGMls1q3heXMc5YKI5wptJJHvMwFHtzkztDY2x0b7pgKJG2sHoaY",
+	"77mu+MBnGquqisGruJ4Mg1rb2zLY+ltb2zwUa7aybfCxtUnN0jcPOSbasvBYcVwXFd1xhavbIHBWhlS5",
+	"PBKv6mDQNTv3hbwsOUfz28TD1iG5kO7Qy2t1r9nE4NX3m/akxiQTJhSN+ITEb5Aj0wtl8oH+MSTmsm6J",
+	"yF4Jx7VwxSxJnrL6pvHnydWDdITVhiIaIKwQNf33a+AI6m97G4+2DK42jqH1jqG5vVqBPOhCBDsWI1tE",
+	"t9mmQC4jThMvyXq4SqavIGjC/ALriwWY0STm4DYV+skDMiGp2ei3S5cDpzKALgeEuWoNf0m/mdWDkvKb",
+	"0iZEqR88bHfC6xeolAPesCdlVtd15RimgYFHDqAdRV5at8gUksxX/mh3sfrVKLUSKpjDzkMApaZWkdue",
+	"e874SkcyjUFKBE5CCzcKBL4LXK2SzEVN636xcoWFBKBvOlu7mx5DKpSKO8HZ7fIMJRUqKB0PO0PRnRSq",
+	"dWlbLHSQulqE9FbMUwusE2AllOtoWrUlKv69Kuq7BsD/Pt5CRcxuHf31Atd/YCrVZWMyBawl9T9b37pW",
+	"NM0SHI/ePJqoNXAFe9/NX02xcm3teh4p1jICFtzOcxcUgcCjZUMyVvrEVSjaeZO0YbWoWslnFQ5aN+dV",
+	"Qm5n3Fp/OhO0chWu78ZZZADRRs6pM2Ad1hePsmWAFOT4CqAKKH91OaGwc2uoe4d5XHsWEiELgSQ7tk5/",
+	"s1bBWtrl1Y6IfOEkWdVVwTiQYG4Zflch0ajg8/zEkGRmV+1an+VAzjsoq3oADEWI2Az3LXUFsspbIjnI",
+	"v7XOL9uFZllAn6gCjldV+WXjWnXfHBI4rZRQ11IHWIkOK3vmVMSCnhCtSNJ7M0fEdV05dDUfr2/rxKRI",
+	"FBBrQ9uQqBICtviHzm2lKqUps19FZ11XV0UpvySQ2xw5rgCLUgtCwDGZJjKlt3ym647oqVhCY5dUP30J",
+	"J0YKUaTJ+eNYILpDC2H9XFQso86yG+PJBDFdri3LD8btFLWTOdiCYJImtoET4/AkaNNTig44JK5vu5bt",
+	"/Mr8KavsX6qOoio1GxIOddqvL3aODAG+SLAWJidYw1zm42Jpc6AnTYA5sCNa/nhrPEAkdvTh3BKsMaBs",
+	"SMbqRS8VM/myL1jCdk+leuIKMcMkjbeNxOcc4t32q9QuGgLyHXmjmCm3EQLXn5S/sDuz2wCbBu6vEity",
+	"rojZMxPQAs+TOfS143l8jsP3aTHYM6ci51Gn6+FfI47EeFvTzBofpCrGJ/NR3AXO28mPYcwlxoGEP9iE",
+	"XEE/g3s0JEFdUitVazaBjZa14H62soLVg751ZFuK8Mzr/Oba49wCcv5AWVztS/wJEcT03aZyAN1CjiOF",
+	"/sB+XGWgkLeOMTVWzPTI3PeKpRkPUJQyLJb6FvtNDiTvoyszzNhZ6dSAnnLXDD/u+UTpd7QcF9IS3qGl",
+	"/HRI7EWob365LLcWbNSqkLsMVYZzKTlEZ5ZkswyEFaFQbbUfxpCYudm+3Dhas2KUrgxlPtVuR0rfdLNa",
+	"96qSa34gfwUMCYmyu6BHlpnjX2kOmFvlcqx3ItQixjyCyjlEM1alzKcxRdxX8GBlO878EItLw7qODibg",
+	"X0EMl0HO4pqKYmIUCwZvg9TVJ/jamFptgjF1zkHGukxnHkHX9hz41ZRE4tkF7dwaqUnsYHKFhDwEy3Cd",
+	"oVhg9iaqooRityiic1ThP+laWRqUIXMA3UOIhEJeim8Ll9akMk5gMxzp0wUBSoe7XrjmAXSF02sdSD8C",
+	"Cxmy929NsNIglVuBuM4wmLuv1VRNNbXbLM11kJWQFxjmRjElMwgJZL3fbVl7ZeuYKn4mKa7epbDUllep",
+	"YcB83gXYGKh0b0MiOQ9KdMlTo4fgwMI6iFMJxEAgZcHdBT1leM22wahhQo4ZVhHPkFQpoFhHU6HArkSQ",
+	"ACGLvSJVT0Jey5hwwVKjzKkkA/okNtnGMCV/NfncO9+nCQQMccTuS2J4gyB67X+1EUXzO/IIYTR3Cmvp",
+	"6ePPUOfigGJFyOs+NnpVj424yw6yRVlXkkFC9W/MbR5RJIUmslSPt42zr1IiD0lm2kbfFpgtlf7Z+Mc/",
+	"Y+TrkJjQVz8jiRpWTzV2emvIQSFPSWwDZdxeVzsf+QuqtNWfCqDkcZtSGXN9MwoKVFpyuTtZrdmufB9B",
+	"EiGdsk/tE+JKpvXia8KGi+AlpM5tcwE5+oAu6MNPdRnKkakqP5089K150OaaXcvqlB2hguL5bue9796v",
+	"p3sOKTRXJFUi/33u3DUZytv7vde2YI40eY37ll6Ms8C7CkuXyA4w6PyjeqoE17fi+pPbqIYZ5E/0hVx/",
+	"DEVfW4zOwRWhIKFkipgBy9fXnrE8J7c+zkfqGAHM7dhjObEwm1921tkg4CoIuP9TLu1qWWKDPL5Z7Tkw",
+	"J3A/iyfk5VGCTN645XIgbmueW9YHyssSkIDxqfWjHUvfWo6EL1VAMP4Mpf1MvzHOIONLchonaAxC7Qd0",
+	"IsybTTagN5QNSLk3rL2Atcl9aMm42KQ73GQh2mQh2mQheuNZiBQteyLvlBK5rB1baKXRB9B88BL1o651",
+	"18dmKn+ralKFtbcQMPLnsPFvqU8lUNyu9hjCjX/czoLRCU7QTrqYMhij1d1lbU/A9ARMT5YqVycdkCU4",
+	"hmTcZlpePQ4/aAgtcvHGDKmIhZbBQtZF8EoP9cVswN85cii8Ja2rdFSBwqtW7KiER8efNQHmWjrtLhid",
+	"MsSDMXKV+/4Ii+k5vQ/HlAgKIJjhqUTB4oBakFVZDYoVsjT3aWuMKFZ+SPT8FDcvef6UqA5RrC2GkhPd",
+	"7tb4CBsbgeoMZH0JCm7OBpo+qM+GRH633a0Qy11GOo6nKv9YghHJT75uCN18SLz2fDsfmlQeU8sGxhen",
+	"uI3WhXk8QOICiQfK7gwmjj1f47yfcgFlK9yUQd5LueQbWJyJUqFQmdhI+yXFwCVTDJZlC7kch1aY9yK2",
+	"gIBy3sPB2oaqWR2t+vt6HwVvsWeoHLxhvFxpRA2kKxLFZ+DGXsrlWAuk1VclJtl9M4N8SNrj983M64ar",
+	"rGeq4mJF5INHe4bETgPbsMwV3JDfGDf3U6iC27l25GFNfKXreDrMS3rBkGbXLfttu1DDIM6tRGeMq2or",
+	"Kc+2LlVVTI3zdI1cl7eoeM7b4+0hKYlymLlAyEFuUJVDLpxOIpeeaoEYpjGOTDg6jMEtjO6GpEKlfKQc",
+	"+cz6wMOMcgTuYZJqIcGyPCX/uGOGJ0J6oyibkjbqmBh6/bVLg+GmsAv+YFigHcVL2Q3tAp5GM+ukF+Bw",
+	"u2pRuqCsGqW9TGsO+O8txepNWEFutR7cr0rTDKC3Rqy3UFqyYmNXIFGCLnY8x9EnWMBlVzzvhgq2NCU1",
+	"3w4EXdx4bwuxoJDEQ3KtomUrWx7sftjemLXfkFlbnmSOXHjHujFwr6uBu4B/G1P3xtS9MXVvTN1v3NQt",
+	"qVqeQVmBU/K+2tFcQCteyWfZCrwRZZW1QPx2NkXhP1OUIh0rNSR+g1xR5G0TTZtDRC0OOUzM810bduoN",
+	"sVOfCj6C3iVt0GLDU60pT1U+qg1XteGqNlzVhqt621xVWTX1eBYLTyU9XtkpynxYg8kBLbn5qOTr5OUJ",
+	"bKcFvjHT/jsrgc0etNYB26N+TRWwhZKWhH/Ndb8B+G/ngJSHZ3Nwm8h+D5HfdGaZtsQ2JQmN7nZczoon",
+	"aP51VxzALAOGF+imlfvKewrdc1kYYazFKr2/G/HzDYmfX9RJh0peUraRPNdV8jSn5g5qI3du5M6N3LmR",
+	"O9+23Kmpms90rCBquqqxLYv02OZF5ywvuCFMlXbB18C3KtGlyzWvq9nW5poH7VPNlwVUN4O/tYia7UKz",
+	"eJqBx2sKqBmUrQBgaymqZitRrnlVU38Evh5NW6bpcPnVtathoTx0NsFKvM1fjUNSo1yCfEmiGaOEpjxZ",
+	"Wq+nT0hkuF/2dZLv+370yJC4RtLNyblwM8TTRLQSU9yyJGkYkqCgAjw5xV38NYvzCv3ZKQ2JG6iJeEGG",
+	"GmSdgOQ3JE8U/VpYztaaKG7kFvmtj0A/VWipvTpuCvSuFh3ayyiVgUIbRvXNZViALS6cpssQc56ieMcL",
+	"f2xRkj5Q1uXr4Sc/5tLT6qmA0hnkQA8FBC18z+X2OjFc5oyEYICnpJ9157JCgn7hU3kRuBBQhlRy9NI0",
+	"ZKMooap3k9xXqtuGZKx+YDId6QIkI1l+JHCt6SDKUr/qa2SLl6hHGTWVXUlvnCHJGHSYSMZlaT6Mt9XM",
+	"LG5XVXg6Vfvm7Ubj3XJJkqXptsWsMS/MuoKsh/YqT+YtZd/vPn8yvFYZt0tb1SbVtsR/A5u5zZJKXVOq",
+	"a4154zNVCtKfdyWe+bQg+8AQgoTqeTdKrhxAV94NJgmwH8pLaoq5UHvm0jtI7K8C7DP7ZRteKQNRBQly",
+	"efwOL8AtmlCGdLZqE9wd0SRBkfC4XCDT1lWANZ1MOBLtAbmGieK56WkErBpWVVoMc0kH+63YpJdGJns8",
+	"bXCo50DCgcP6YUlCoxIi2Ge16ec1WCvAN82bAdx+5XbxZTjb7JCeHiu+RqxN046HT9CnY3vf7V+n9Zmw",
+	"j9Vz7qeKcmNmSaICJ6w/9M63QMMCElw2pSemegpE9dqJAL1QP/q/oiGhUsZLyXodvt5W/+Rvl+D0uApn",
+	"G++qFU9VKzpf4lR/osYyTyUq4aaYrutNwY0+uNZwEyzV7ao2rwo1+sNXowU/+x7Zr4EOXXz7zZIffZIe",
+	"GK141ezFmC8SuNwxARY18RyDaIbiNFHwZlqb6mR8JrOpUK3XNB2qauH3iC1L4ndWxz5TMdv+pIp5iu8R",
+	"yTw0sFJ8qqLIhZ4yDajVCkcJgszqhCW3r9Xdc+fcWFEF3B7ysZ67KTzehs93+NakDX1Gqvv86FRY9ytX",
+	"dKnY/QrZ14JK5kFg4DLelG7xw7zMrnjImkfQivh5LrHt+cjJ3nfzV7nCS6FaikTdN4OHQanaUj5HzBqm",
+	"ku3Ms1RK+Ym4aIs/5uivCCmqNeitVyESOWkPTdzknwlRrJ6pzmDrBEgibXmnYAGZWBakdnCMbC1Tg8n5",
+	"tHu+Dlh9oY5lSExVb0ywwJpX0DNiBUFVj0mZ90Oda864pp8LmnU3JFUdNukarmRfL6RouPZm9FdVNlTD",
+	"igeMNFpgB4j39K7SelJWeF7r9gVV/str8srjttWLmxVWK8az52AG+Wz9lH5uBaW8oNX68GpNoOyLh7OM",
+	"ZsHS1kh7Czn68B4gElFJRQafezuHv3xQ+6QVxRwcn1yD26VAvCvphGSwdUH9tpY0GPQvsRkEGfoHioRL",
+	"QaZorOot5QhAWRimdLrKmBc8dTOoWZskyQno90qeGHJV/euzMJmS3Zag/wXM/dkQcsyVNaTrjH3rwJO/",
+	"mutaDgAz77UM/tfccU0f/Uq0p+Je2YtYUmeaSxnhACp6YulN//qsq7Iil7G2q8lA4iz6QYx3hEd9NiT9",
+	"nkd9VO1YmwQC3kOcSD+ErMSjHqpYVDLr3nJXzNacrfKuCuGypOyr3ZyLO/xtx+xhdugTyuZQdI46t5hA",
+	"ZZ0ryiVBrOhfn70eGgTcU4LbGFOkPdXV+cg58rV04uxfnznm18JjLTao8swNXinq5pTtDNQa9ZWtZQxT",
+	"MaMM/y9yF2aFSfpG9bGxR6+jPVqdTZur88yYojVArB9LaqHRulMakLMYoB7UcKFaiOJARddZe4X66PGw",
+	"P0Aa9F+IITNH9xeSGvWc9lJjLSChQw2cqaNne9/Vf6PUaPHCtM2atJ54urofe8DNpik3tbYavXcfXtee",
+	"6cFTwQ+lfAqvX4boYq3toyuBapZww0UDNIc25f3D/D7KvnS74WvY++a35VsvX7lhEF6JQfBTi67is+aD",
+	"6O768Qv1GOSjbtawBoH3vntP22K084fwvi3PBZwe62Aer1F1hb23mLPXX1nTDPLbvDbF/UoZeIto4a+R",
+	"3koV4u4rirve4OvpIfIRk7gyGVUBBeWHiN3Xm1kTEKN7lNDFXNcBk+073U7Kks5RZybE4mhPGYeTGeXi",
+	"6Nf3B/t7cIH37vc7FWZTGt0h1qLTOSRwili+yz9//L8BACQV1VkqiAEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
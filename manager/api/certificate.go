@@ -33,6 +33,18 @@ func (s *Server) DeleteCertificate(w http.ResponseWriter, r *http.Request, certi
 }
 
 func (s *Server) LookupCertificate(w http.ResponseWriter, r *http.Request, certificateHash string) {
+	certificateHash = normalizeCertificateHash(certificateHash)
+
+	revoked, err := s.store.LookupRevokedCertificate(r.Context(), certificateHash)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if revoked != nil {
+		_ = render.Render(w, r, ErrRevoked)
+		return
+	}
+
 	cert, err := s.store.LookupCertificate(r.Context(), certificateHash)
 	if err != nil {
 		_ = render.Render(w, r, ErrInternalError(err))
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Server) LookupChargeStationCertificateInventory(w http.ResponseWriter, r *http.Request, csId string) {
+	inventory, err := s.store.LookupChargeStationCertificateInventory(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if inventory == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, ChargeStationCertificateInventory{
+		Certificates:        newInventoryCertificates(inventory.Certificates),
+		ReportedAt:          inventory.ReportedAt,
+		RequestedAt:         inventory.RequestedAt,
+		MissingCertificates: newInventoryCertificates(inventory.MissingCertificates),
+		AuditedAt:           inventory.AuditedAt,
+	})
+}
+
+func newInventoryCertificates(certificates []*store.InventoryCertificate) []InventoryCertificate {
+	result := make([]InventoryCertificate, 0, len(certificates))
+	for _, cert := range certificates {
+		result = append(result, InventoryCertificate{
+			CertificateType: string(cert.CertificateType),
+			HashAlgorithm:   cert.HashAlgorithm,
+			IssuerNameHash:  cert.IssuerNameHash,
+			IssuerKeyHash:   cert.IssuerKeyHash,
+			SerialNumber:    cert.SerialNumber,
+			Subject:         stringOrNil(cert.Subject),
+		})
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestLookupChargeStationCertificateInventory(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	now := clock.Now().Truncate(time.Second)
+	err := engine.SetChargeStationCertificateInventory(context.Background(), "cs001", &store.ChargeStationCertificateInventory{
+		Certificates: []*store.InventoryCertificate{
+			{
+				CertificateType: store.CertificateTypeV2G,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "name",
+				IssuerKeyHash:   "key",
+				SerialNumber:    "1234",
+			},
+		},
+		ReportedAt: &now,
+		MissingCertificates: []*store.InventoryCertificate{
+			{
+				CertificateType: store.CertificateTypeMO,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "mo-name",
+				IssuerKeyHash:   "mo-key",
+				SerialNumber:    "5678",
+				Subject:         "CN=MO Root CA",
+			},
+		},
+		AuditedAt: &now,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/certificate-inventory", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationCertificateInventory
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	subject := "CN=MO Root CA"
+	assert.Equal(t, api.ChargeStationCertificateInventory{
+		Certificates: []api.InventoryCertificate{
+			{CertificateType: "V2G", HashAlgorithm: "SHA256", IssuerNameHash: "name", IssuerKeyHash: "key", SerialNumber: "1234"},
+		},
+		ReportedAt: &now,
+		MissingCertificates: []api.InventoryCertificate{
+			{CertificateType: "MO", HashAlgorithm: "SHA256", IssuerNameHash: "mo-name", IssuerKeyHash: "mo-key", SerialNumber: "5678", Subject: &subject},
+		},
+		AuditedAt: &now,
+	}, got)
+}
+
+func TestLookupChargeStationCertificateInventoryNotFound(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/certificate-inventory", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
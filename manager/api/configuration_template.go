@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Server) ListConfigurationTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := s.store.ListConfigurationTemplates(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, len(templates))
+	for i, template := range templates {
+		resp[i] = newConfigurationTemplate(template)
+	}
+
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) SetConfigurationTemplate(w http.ResponseWriter, r *http.Request, templateId string) {
+	req := new(ConfigurationTemplate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	template := &store.ConfigurationTemplate{
+		Id:         templateId,
+		LocationId: req.LocationId,
+		Vendor:     req.Vendor,
+		Model:      req.Model,
+		Tag:        req.Tag,
+		Settings:   req.Settings,
+	}
+	err := s.store.SetConfigurationTemplate(r.Context(), template)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	err = s.applyConfigurationTemplate(r.Context(), template)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	req.Id = &templateId
+	w.WriteHeader(http.StatusCreated)
+	_ = render.Render(w, r, req)
+}
+
+func (s *Server) LookupConfigurationTemplate(w http.ResponseWriter, r *http.Request, templateId string) {
+	template, err := s.store.LookupConfigurationTemplate(r.Context(), templateId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if template == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, newConfigurationTemplate(template))
+}
+
+func (s *Server) DeleteConfigurationTemplate(w http.ResponseWriter, r *http.Request, templateId string) {
+	err := s.store.DeleteConfigurationTemplate(r.Context(), templateId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyConfigurationTemplate queues the settings for every charge station that matches the
+// template. All the templates are applied to each matching charge station so that the
+// template ordering is respected.
+func (s *Server) applyConfigurationTemplate(ctx context.Context, template *store.ConfigurationTemplate) error {
+	templates, err := s.store.ListConfigurationTemplates(ctx)
+	if err != nil {
+		return fmt.Errorf("list configuration templates: %w", err)
+	}
+
+	const pageSize = 50
+	for offset := 0; ; offset += pageSize {
+		chargeStations, err := s.store.ListChargeStations(ctx, offset, pageSize)
+		if err != nil {
+			return fmt.Errorf("list charge stations: %w", err)
+		}
+		for _, cs := range chargeStations {
+			details, err := s.store.LookupChargeStationRuntimeDetails(ctx, cs.Id)
+			if err != nil {
+				return fmt.Errorf("lookup charge station runtime details %s: %w", cs.Id, err)
+			}
+			if !template.Matches(cs, details) {
+				continue
+			}
+			_, err = handlers.ApplyConfigurationTemplates(ctx, s.store, templates, cs, details)
+			if err != nil {
+				return fmt.Errorf("apply configuration templates to %s: %w", cs.Id, err)
+			}
+		}
+		if len(chargeStations) < pageSize {
+			return nil
+		}
+	}
+}
+
+func newConfigurationTemplate(template *store.ConfigurationTemplate) ConfigurationTemplate {
+	return ConfigurationTemplate{
+		Id:         &template.Id,
+		LocationId: template.LocationId,
+		Vendor:     template.Vendor,
+		Model:      template.Model,
+		Tag:        template.Tag,
+		Settings:   template.Settings,
+	}
+}
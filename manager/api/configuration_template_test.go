@@ -0,0 +1,164 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestSetConfigurationTemplate(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	for _, cs := range []*store.ChargeStation{
+		{Id: "cs001", LocationId: "loc001"},
+		{Id: "cs002", LocationId: "loc001", Tags: []string{"depot"}},
+		{Id: "cs003", LocationId: "loc002", Tags: []string{"depot"}},
+	} {
+		require.NoError(t, engine.CreateChargeStation(ctx, cs))
+	}
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs002", &store.ChargeStationRuntimeDetails{
+		OcppVersion: store.OcppVersion201,
+		Vendor:      "VendorX",
+		Model:       "ModelY",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/configuration-template/depot", strings.NewReader(`{
+  "location_id": "loc001",
+  "tag": "depot",
+  "settings": {
+    "OCPPCommCtrlr/HeartbeatInterval": "60"
+  }
+}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	got, err := engine.LookupConfigurationTemplate(ctx, "depot")
+	require.NoError(t, err)
+	location, tag := "loc001", "depot"
+	assert.Equal(t, &store.ConfigurationTemplate{
+		Id:         "depot",
+		LocationId: &location,
+		Tag:        &tag,
+		Settings:   map[string]string{"OCPPCommCtrlr/HeartbeatInterval": "60"},
+	}, got)
+
+	for csId, want := range map[string]bool{"cs001": false, "cs002": true, "cs003": false} {
+		settings, err := engine.LookupChargeStationSettings(ctx, csId)
+		require.NoError(t, err)
+		if !want {
+			assert.Nil(t, settings, csId)
+			continue
+		}
+		require.NotNil(t, settings, csId)
+		setting := settings.Settings["OCPPCommCtrlr/HeartbeatInterval"]
+		require.NotNil(t, setting)
+		assert.Equal(t, "60", setting.Value)
+		assert.Equal(t, store.ChargeStationSettingStatusPending, setting.Status)
+	}
+}
+
+func TestLookupConfigurationTemplate(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	model := "ModelY"
+	err := engine.SetConfigurationTemplate(context.Background(), &store.ConfigurationTemplate{
+		Id:       "model-y",
+		Model:    &model,
+		Settings: map[string]string{"HeartbeatInterval": "60"},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/configuration-template/model-y", nil)
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var res api.ConfigurationTemplate
+	err = json.NewDecoder(rr.Body).Decode(&res)
+	require.NoError(t, err)
+
+	id := "model-y"
+	assert.Equal(t, api.ConfigurationTemplate{
+		Id:       &id,
+		Model:    &model,
+		Settings: api.ChargeStationSettings{"HeartbeatInterval": "60"},
+	}, res)
+}
+
+func TestLookupConfigurationTemplateThatDoesNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/configuration-template/unknown", nil)
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestListConfigurationTemplates(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	for _, id := range []string{"b", "a"} {
+		err := engine.SetConfigurationTemplate(context.Background(), &store.ConfigurationTemplate{
+			Id:       id,
+			Settings: map[string]string{"HeartbeatInterval": "60"},
+		})
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/configuration-template", nil)
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var res []api.ConfigurationTemplate
+	err := json.NewDecoder(rr.Body).Decode(&res)
+	require.NoError(t, err)
+
+	require.Len(t, res, 2)
+	assert.Equal(t, "a", *res[0].Id)
+	assert.Equal(t, "b", *res[1].Id)
+}
+
+func TestDeleteConfigurationTemplate(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetConfigurationTemplate(context.Background(), &store.ConfigurationTemplate{
+		Id:       "a",
+		Settings: map[string]string{"HeartbeatInterval": "60"},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodDelete, "/configuration-template/a", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	got, err := engine.LookupConfigurationTemplate(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
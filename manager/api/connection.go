@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Server) ListConnections(w http.ResponseWriter, r *http.Request) {
+	var resp = make([]render.Renderer, 0)
+	var previousCsId string
+	for {
+		connections, err := s.store.ListChargeStationConnections(r.Context(), 50, previousCsId)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		for _, connection := range connections {
+			resp = append(resp, newChargeStationConnection(connection))
+		}
+		if len(connections) < 50 {
+			break
+		}
+		previousCsId = connections[len(connections)-1].ChargeStationId
+	}
+
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) LookupChargeStationConnection(w http.ResponseWriter, r *http.Request, csId string) {
+	connection, err := s.store.LookupChargeStationConnection(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if connection == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	resp := newChargeStationConnection(connection)
+	_ = render.Render(w, r, resp)
+}
+
+func (s *Server) DisconnectChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	if s.controller == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	connection, err := s.store.LookupChargeStationConnection(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if connection == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	err = s.controller.Disconnect(r.Context(), csId, connection.ConnectionId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func newChargeStationConnection(connection *store.ChargeStationConnection) ChargeStationConnection {
+	return ChargeStationConnection{
+		ChargeStationId: connection.ChargeStationId,
+		ConnectionId:    connection.ConnectionId,
+		GatewayId:       connection.GatewayId,
+		RemoteAddress:   stringOrNil(connection.RemoteAddress),
+		Subprotocol:     stringOrNil(connection.Subprotocol),
+		SecurityProfile: int(connection.SecurityProfile),
+		ConnectedAt:     connection.ConnectedAt,
+	}
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// fakeConnectionController records the connections that it has been asked to disconnect
+type fakeConnectionController struct {
+	disconnected map[string]string
+}
+
+func (f *fakeConnectionController) Disconnect(_ context.Context, chargeStationId, connectionId string) error {
+	f.disconnected[chargeStationId] = connectionId
+	return nil
+}
+
+func TestListConnections(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	connectedAt := time.Now().UTC().Truncate(time.Second)
+	for _, csId := range []string{"cs002", "cs001"} {
+		err := engine.SetChargeStationConnection(context.Background(), csId, &store.ChargeStationConnection{
+			ConnectionId:    "conn-" + csId,
+			GatewayId:       "gateway-1",
+			Subprotocol:     "ocpp1.6",
+			SecurityProfile: store.TLSWithClientSideCertificates,
+			ConnectedAt:     connectedAt,
+		})
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/connection", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got []api.ChargeStationConnection
+	err := json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+	subprotocol := "ocpp1.6"
+	want := []api.ChargeStationConnection{
+		{
+			ChargeStationId: "cs001",
+			ConnectionId:    "conn-cs001",
+			GatewayId:       "gateway-1",
+			Subprotocol:     &subprotocol,
+			SecurityProfile: 2,
+			ConnectedAt:     connectedAt,
+		},
+		{
+			ChargeStationId: "cs002",
+			ConnectionId:    "conn-cs002",
+			GatewayId:       "gateway-1",
+			Subprotocol:     &subprotocol,
+			SecurityProfile: 2,
+			ConnectedAt:     connectedAt,
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationConnection(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	connectedAt := time.Now().UTC().Truncate(time.Second)
+	err := engine.SetChargeStationConnection(context.Background(), "cs001", &store.ChargeStationConnection{
+		ConnectionId:    "conn-1",
+		GatewayId:       "gateway-1",
+		RemoteAddress:   "10.0.0.1:4321",
+		Subprotocol:     "ocpp2.0.1",
+		SecurityProfile: store.TLSWithBasicAuth,
+		ConnectedAt:     connectedAt,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/connection", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationConnection
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+	remoteAddress := "10.0.0.1:4321"
+	subprotocol := "ocpp2.0.1"
+	want := api.ChargeStationConnection{
+		ChargeStationId: "cs001",
+		ConnectionId:    "conn-1",
+		GatewayId:       "gateway-1",
+		RemoteAddress:   &remoteAddress,
+		Subprotocol:     &subprotocol,
+		SecurityProfile: 1,
+		ConnectedAt:     connectedAt,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationConnectionWhenNotConnected(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/connection", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestDisconnectChargeStation(t *testing.T) {
+	controller := &fakeConnectionController{disconnected: make(map[string]string)}
+	server, r, engine, _ := setupServerWithConnectionController(t, nil, nil, controller)
+	defer server.Close()
+
+	err := engine.SetChargeStationConnection(context.Background(), "cs001", &store.ChargeStationConnection{
+		ConnectionId: "conn-1",
+		GatewayId:    "gateway-1",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodDelete, "/cs/cs001/connection", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+
+	assert.Equal(t, map[string]string{"cs001": "conn-1"}, controller.disconnected)
+
+	// the connection is only removed once the gateway reports the disconnect
+	got, err := engine.LookupChargeStationConnection(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestDisconnectChargeStationWhenNotConnected(t *testing.T) {
+	controller := &fakeConnectionController{disconnected: make(map[string]string)}
+	server, r, _, _ := setupServerWithConnectionController(t, nil, nil, controller)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodDelete, "/cs/cs001/connection", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+	assert.Empty(t, controller.disconnected)
+}
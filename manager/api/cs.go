@@ -1,13 +1,19 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/go-chi/render"
-	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	handlers16 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 )
 
@@ -60,13 +66,22 @@ func (s *Server) ListChargeStations(w http.ResponseWriter, r *http.Request, para
 				}
 			}
 		}
+		pendingSecurityProfile, err := s.pendingSecurityProfile(r, cs.Id)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
 		resp[i] = ChargeStation{
-			Id:                     cs.Id,
-			LocationId:             cs.LocationId,
-			InvalidUsernameAllowed: &cs.InvalidUsernameAllowed,
-			Base64SHA256Password:   &cs.Base64SHA256Password,
-			SecurityProfile:        int(cs.SecurityProfile),
-			Evses:                  &outputEvses,
+			Id:                          cs.Id,
+			LocationId:                  cs.LocationId,
+			InvalidUsernameAllowed:      &cs.InvalidUsernameAllowed,
+			Base64SHA256Password:        &cs.Base64SHA256Password,
+			PendingBase64SHA256Password: stringOrNil(cs.PendingPassword(s.clock.Now())),
+			SecurityProfile:             int(cs.SecurityProfile),
+			Evses:                       &outputEvses,
+			Tags:                        tagsOrNil(cs.Tags),
+			ClientCertificateHash:       stringOrNil(cs.ClientCertificateHash),
+			PendingSecurityProfile:      pendingSecurityProfile,
 		}
 	}
 
@@ -123,13 +138,23 @@ func (s *Server) LookupChargeStation(w http.ResponseWriter, r *http.Request, csI
 		}
 	}
 
+	pendingSecurityProfile, err := s.pendingSecurityProfile(r, csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
 	resp := ChargeStation{
-		Id:                     cs.Id,
-		LocationId:             cs.LocationId,
-		InvalidUsernameAllowed: &cs.InvalidUsernameAllowed,
-		Base64SHA256Password:   &cs.Base64SHA256Password,
-		SecurityProfile:        int(cs.SecurityProfile),
-		Evses:                  &outputEvses,
+		Id:                          cs.Id,
+		LocationId:                  cs.LocationId,
+		InvalidUsernameAllowed:      &cs.InvalidUsernameAllowed,
+		Base64SHA256Password:        &cs.Base64SHA256Password,
+		PendingBase64SHA256Password: stringOrNil(cs.PendingPassword(s.clock.Now())),
+		SecurityProfile:             int(cs.SecurityProfile),
+		Evses:                       &outputEvses,
+		Tags:                        tagsOrNil(cs.Tags),
+		ClientCertificateHash:       stringOrNil(cs.ClientCertificateHash),
+		PendingSecurityProfile:      pendingSecurityProfile,
 	}
 
 	_ = render.Render(w, r, resp)
@@ -168,6 +193,10 @@ func (s *Server) RegisterChargeStation(w http.ResponseWriter, r *http.Request) {
 	if req.InvalidUsernameAllowed != nil {
 		invalidUsernameAllowed = *req.InvalidUsernameAllowed
 	}
+	var tags []string
+	if req.Tags != nil {
+		tags = *req.Tags
+	}
 
 	// Store charge station locally
 	now := s.clock.Now()
@@ -206,6 +235,7 @@ func (s *Server) RegisterChargeStation(w http.ResponseWriter, r *http.Request) {
 		Base64SHA256Password:   pwd,
 		InvalidUsernameAllowed: invalidUsernameAllowed,
 		Evses:                  &storeEvses,
+		Tags:                   tags,
 	})
 	if err != nil {
 		_ = render.Render(w, r, ErrInternalError(err))
@@ -293,6 +323,47 @@ func (s *Server) ReconfigureChargeStation(w http.ResponseWriter, r *http.Request
 	}
 }
 
+func (s *Server) LookupChargeStationSettings(w http.ResponseWriter, r *http.Request, csId string) {
+	settings, err := s.store.LookupChargeStationSettings(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if settings == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	variables, err := s.store.LookupChargeStationVariables(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	resp := ChargeStationSettingsStatus{
+		Settings: make(map[string]ChargeStationSettingStatus, len(settings.Settings)),
+	}
+	for name, setting := range settings.Settings {
+		if store.IsWriteOnlySetting(name) {
+			continue
+		}
+		status := ChargeStationSettingStatus{
+			Value:            setting.Value,
+			Status:           string(setting.Status),
+			ErrorCode:        stringOrNil(setting.ErrorCode),
+			ErrorDescription: stringOrNil(setting.ErrorDescription),
+		}
+		if setting.Status == store.ChargeStationSettingStatusDrifted && variables != nil {
+			if variable, ok := variables.Variables[name]; ok {
+				status.ActualValue = variable.Value
+			}
+		}
+		resp.Settings[name] = status
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
 func (s *Server) InstallChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string) {
 	req := new(ChargeStationInstallCertificates)
 	if err := render.Bind(r, req); err != nil {
@@ -302,7 +373,7 @@ func (s *Server) InstallChargeStationCertificates(w http.ResponseWriter, r *http
 
 	var certs []*store.ChargeStationInstallCertificate
 	for _, cert := range req.Certificates {
-		certId, err := handlers.GetCertificateId(cert.Certificate)
+		certId, err := handlers201.GetCertificateId(cert.Certificate)
 		if err != nil {
 			_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid certificate: %w", err)))
 			return
@@ -331,6 +402,33 @@ func (s *Server) InstallChargeStationCertificates(w http.ResponseWriter, r *http
 	}
 }
 
+func (s *Server) LookupChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string) {
+	certificates, err := s.store.LookupChargeStationInstallCertificates(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if certificates == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	resp := ChargeStationCertificatesStatus{
+		Certificates: make([]ChargeStationCertificateStatus, 0, len(certificates.Certificates)),
+	}
+	for _, cert := range certificates.Certificates {
+		resp.Certificates = append(resp.Certificates, ChargeStationCertificateStatus{
+			Type:             string(cert.CertificateType),
+			Id:               cert.CertificateId,
+			Status:           string(cert.CertificateInstallationStatus),
+			ErrorCode:        stringOrNil(cert.ErrorCode),
+			ErrorDescription: stringOrNil(cert.ErrorDescription),
+		})
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
 func (s *Server) TriggerChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
 	req := new(ChargeStationTrigger)
 	if err := render.Bind(r, req); err != nil {
@@ -350,6 +448,175 @@ func (s *Server) TriggerChargeStation(w http.ResponseWriter, r *http.Request, cs
 	w.WriteHeader(http.StatusCreated)
 }
 
+func (s *Server) LookupChargeStationTrigger(w http.ResponseWriter, r *http.Request, csId string) {
+	trigger, err := s.store.LookupChargeStationTriggerMessage(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if trigger == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, ChargeStationTriggerStatus{
+		Trigger:          string(trigger.TriggerMessage),
+		Status:           string(trigger.TriggerStatus),
+		ErrorCode:        stringOrNil(trigger.ErrorCode),
+		ErrorDescription: stringOrNil(trigger.ErrorDescription),
+	})
+}
+
+func (s *Server) GetChargeStationVariables(w http.ResponseWriter, r *http.Request, csId string, params GetChargeStationVariablesParams) {
+	req := new(ChargeStationGetVariables)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	if params.Wait != nil {
+		s.getChargeStationVariablesAndWait(w, r, csId, req.Variables, time.Duration(*params.Wait)*time.Second)
+		return
+	}
+
+	variables := make(map[string]*store.ChargeStationVariable, len(req.Variables))
+	for _, name := range req.Variables {
+		variables[name] = &store.ChargeStationVariable{
+			Status: store.ChargeStationVariableStatusPending,
+		}
+	}
+
+	err := s.store.UpdateChargeStationVariables(r.Context(), csId, &store.ChargeStationVariables{
+		Variables: variables,
+	})
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// getChargeStationVariablesAndWait sends the request for the variables to the charge station
+// and waits for the response rather than leaving the request to be sent by the sync process
+func (s *Server) getChargeStationVariablesAndWait(w http.ResponseWriter, r *http.Request, csId string, names []string, wait time.Duration) {
+	details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if details == nil {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("charge station %s has not connected", csId)))
+		return
+	}
+
+	var callMaker handlers.SyncCallMaker
+	var req ocpp.Request
+	var resp ocpp.Response
+	var resultVariables func() map[string]*store.ChargeStationVariable
+	switch details.OcppVersion {
+	case "1.6":
+		callMaker = s.v16CallMaker
+		getConfigurationReq := &ocpp16.GetConfigurationJson{Key: names}
+		getConfigurationResp := new(ocpp16.GetConfigurationResponseJson)
+		req, resp = getConfigurationReq, getConfigurationResp
+		resultVariables = func() map[string]*store.ChargeStationVariable {
+			return handlers16.GetConfigurationResultVariables(getConfigurationResp)
+		}
+	case "2.0.1":
+		callMaker = s.v201CallMaker
+		getVariablesReq := new(ocpp201.GetVariablesRequestJson)
+		for _, name := range names {
+			component, variable, attributeType, err := handlers201.ParseVariableName(name)
+			if err != nil {
+				_ = render.Render(w, r, ErrInvalidRequest(err))
+				return
+			}
+			getVariablesReq.GetVariableData = append(getVariablesReq.GetVariableData, ocpp201.GetVariableDataType{
+				Component:     component,
+				Variable:      variable,
+				AttributeType: attributeType,
+			})
+		}
+		getVariablesResp := new(ocpp201.GetVariablesResponseJson)
+		req, resp = getVariablesReq, getVariablesResp
+		resultVariables = func() map[string]*store.ChargeStationVariable {
+			return handlers201.GetVariablesResultVariables(getVariablesReq, getVariablesResp)
+		}
+	default:
+		_ = render.Render(w, r, ErrInternalError(fmt.Errorf("unsupported ocpp version: %s", details.OcppVersion)))
+		return
+	}
+	if callMaker == nil {
+		_ = render.Render(w, r, ErrInternalError(fmt.Errorf("unable to send calls to ocpp %s charge stations", details.OcppVersion)))
+		return
+	}
+
+	// the variables are recorded as pending (so the results can be stored by whichever manager instance
+	// receives them) but will not be requested by the sync process while we are waiting for the response
+	pending := make(map[string]*store.ChargeStationVariable, len(names))
+	for _, name := range names {
+		pending[name] = &store.ChargeStationVariable{
+			Status:    store.ChargeStationVariableStatusPending,
+			SendAfter: s.clock.Now().Add(wait),
+		}
+	}
+	err = s.store.UpdateChargeStationVariables(r.Context(), csId, &store.ChargeStationVariables{
+		Variables: pending,
+	})
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), wait)
+	defer cancel()
+	err = callMaker.SendAndWait(ctx, csId, req, resp)
+	if err != nil {
+		_ = render.Render(w, r, ErrCallFailed(err))
+		return
+	}
+
+	variables := resultVariables()
+	result := ChargeStationVariables{
+		Variables: make(map[string]ChargeStationVariable, len(variables)),
+	}
+	for name, v := range variables {
+		result.Variables[name] = ChargeStationVariable{
+			Value:  v.Value,
+			Status: string(v.Status),
+		}
+	}
+
+	_ = render.Render(w, r, result)
+}
+
+func (s *Server) LookupChargeStationVariables(w http.ResponseWriter, r *http.Request, csId string) {
+	variables, err := s.store.LookupChargeStationVariables(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if variables == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	resp := ChargeStationVariables{
+		Variables: make(map[string]ChargeStationVariable, len(variables.Variables)),
+	}
+	for name, v := range variables.Variables {
+		resp.Variables[name] = ChargeStationVariable{
+			Value:            v.Value,
+			Status:           string(v.Status),
+			ErrorCode:        stringOrNil(v.ErrorCode),
+			ErrorDescription: stringOrNil(v.ErrorDescription),
+		}
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
 func (s *Server) LookupChargeStationRuntimeDetails(w http.ResponseWriter, r *http.Request, csId string) {
 	csDetails, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
 	if err != nil {
@@ -364,6 +631,81 @@ func (s *Server) LookupChargeStationRuntimeDetails(w http.ResponseWriter, r *htt
 	resp := ChargeStationRuntimeDetails{
 		OcppVersion: ChargeStationRuntimeDetailsOcppVersion(csDetails.OcppVersion),
 	}
+	if csDetails.Vendor != "" {
+		resp.Vendor = &csDetails.Vendor
+	}
+	if csDetails.Model != "" {
+		resp.Model = &csDetails.Model
+	}
 
 	_ = render.Render(w, r, resp)
 }
+
+func (s *Server) LookupChargeStationDeviceModel(w http.ResponseWriter, r *http.Request, csId string, params LookupChargeStationDeviceModelParams) {
+	deviceModel, err := s.store.LookupChargeStationDeviceModel(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if deviceModel == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	resp := ChargeStationDeviceModel{
+		UpdatedAt: deviceModel.UpdatedAt,
+		Variables: make([]DeviceModelVariable, 0, len(deviceModel.Variables)),
+	}
+	for _, v := range deviceModel.Variables {
+		if params.Component != nil && v.ComponentName != *params.Component {
+			continue
+		}
+		attrs := make([]DeviceModelVariableAttribute, len(v.Attributes))
+		for i, a := range v.Attributes {
+			attrs[i] = DeviceModelVariableAttribute{
+				Type:       a.Type,
+				Value:      a.Value,
+				Mutability: a.Mutability,
+				Persistent: a.Persistent,
+				Constant:   a.Constant,
+			}
+		}
+		var chars *DeviceModelVariableCharacteristics
+		if v.Characteristics != nil {
+			chars = &DeviceModelVariableCharacteristics{
+				DataType:           v.Characteristics.DataType,
+				Unit:               v.Characteristics.Unit,
+				MinLimit:           v.Characteristics.MinLimit,
+				MaxLimit:           v.Characteristics.MaxLimit,
+				ValuesList:         v.Characteristics.ValuesList,
+				SupportsMonitoring: v.Characteristics.SupportsMonitoring,
+			}
+		}
+		resp.Variables = append(resp.Variables, DeviceModelVariable{
+			ComponentName:     v.ComponentName,
+			ComponentInstance: v.ComponentInstance,
+			EvseId:            v.EvseId,
+			ConnectorId:       v.ConnectorId,
+			VariableName:      v.VariableName,
+			VariableInstance:  v.VariableInstance,
+			Attributes:        attrs,
+			Characteristics:   chars,
+		})
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
+func tagsOrNil(tags []string) *[]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	return &tags
+}
+
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -3,16 +3,22 @@ package api_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 )
 
 func TestRegisterChargeStation(t *testing.T) {
@@ -80,9 +86,11 @@ func TestLookupChargeStation(t *testing.T) {
 	require.NoError(t, err)
 
 	err = engine.CreateChargeStation(context.Background(), &store.ChargeStation{
-		Id:              "cs001",
-		SecurityProfile: 1,
-		LocationId:      "loc001",
+		Id:                    "cs001",
+		SecurityProfile:       2,
+		LocationId:            "loc001",
+		Tags:                  []string{"depot"},
+		ClientCertificateHash: "3I9bc6nHu0BPaJsmBqMRyUHaRI5GY7yhDkCWoRmdL4A",
 	})
 	require.NoError(t, err)
 
@@ -96,10 +104,12 @@ func TestLookupChargeStation(t *testing.T) {
 	require.NoError(t, err)
 
 	want := &store.ChargeStation{
-		Id:              res.Id,
-		SecurityProfile: 1,
-		LocationId:      "loc001",
-		Evses:           &[]store.Evse{},
+		Id:                    res.Id,
+		SecurityProfile:       2,
+		LocationId:            "loc001",
+		Evses:                 &[]store.Evse{},
+		Tags:                  []string{"depot"},
+		ClientCertificateHash: "3I9bc6nHu0BPaJsmBqMRyUHaRI5GY7yhDkCWoRmdL4A",
 	}
 
 	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
@@ -182,6 +192,8 @@ func TestLookupChargeStationRuntimeDetails(t *testing.T) {
 
 	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
 		OcppVersion: "2.0.1",
+		Vendor:      "VendorX",
+		Model:       "ModelY",
 	})
 	require.NoError(t, err)
 
@@ -195,13 +207,447 @@ func TestLookupChargeStationRuntimeDetails(t *testing.T) {
 	require.NoError(t, err)
 
 	want := &store.ChargeStationRuntimeDetails{
-		OcppVersion: res.OcppVersion,
+		OcppVersion: "2.0.1",
+		Vendor:      "VendorX",
+		Model:       "ModelY",
 	}
 
 	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
 	assert.Equal(t, *want, res)
 }
 
+func TestLookupChargeStationDeviceModel(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	updatedAt := time.Date(2024, 3, 18, 17, 10, 0, 0, time.UTC)
+	value := "60"
+	err := engine.UpdateChargeStationDeviceModel(context.Background(), "cs001", []*store.DeviceModelVariable{
+		{
+			ComponentName: "OCPPCommCtrlr",
+			VariableName:  "HeartbeatInterval",
+			Attributes: []*store.DeviceModelVariableAttribute{
+				{Type: "Actual", Value: &value, Mutability: "ReadWrite", Persistent: true},
+			},
+			Characteristics: &store.DeviceModelVariableCharacteristics{
+				DataType: "integer",
+			},
+		},
+		{
+			ComponentName: "SecurityCtrlr",
+			VariableName:  "SecurityProfile",
+		},
+	}, updatedAt)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/device-model?component=OCPPCommCtrlr", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationDeviceModel
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	want := api.ChargeStationDeviceModel{
+		UpdatedAt: updatedAt,
+		Variables: []api.DeviceModelVariable{
+			{
+				ComponentName: "OCPPCommCtrlr",
+				VariableName:  "HeartbeatInterval",
+				Attributes: []api.DeviceModelVariableAttribute{
+					{Type: "Actual", Value: &value, Mutability: "ReadWrite", Persistent: true},
+				},
+				Characteristics: &api.DeviceModelVariableCharacteristics{
+					DataType: "integer",
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationDeviceModelThatDoesNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/device-model", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestLookupChargeStationSettings(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.UpdateChargeStationSettings(context.Background(), "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"HeartbeatInterval":        {Value: "60", Status: store.ChargeStationSettingStatusDrifted},
+			"MeterValueSampleInterval": {Value: "30", Status: store.ChargeStationSettingStatusAccepted},
+			"AuthorizationKey":         {Value: "secret", Status: store.ChargeStationSettingStatusPending},
+		},
+	})
+	require.NoError(t, err)
+	actual := "300"
+	err = engine.UpdateChargeStationVariables(context.Background(), "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"HeartbeatInterval":        {Value: &actual, Status: store.ChargeStationVariableStatusAccepted},
+			"MeterValueSampleInterval": {Value: makePtr("30"), Status: store.ChargeStationVariableStatusAccepted},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/settings", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationSettingsStatus
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	want := api.ChargeStationSettingsStatus{
+		Settings: map[string]api.ChargeStationSettingStatus{
+			"HeartbeatInterval":        {Value: "60", Status: "Drifted", ActualValue: &actual},
+			"MeterValueSampleInterval": {Value: "30", Status: "Accepted"},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationSettingsThatDoNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/settings", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestGetChargeStationVariables(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/variables:get",
+		strings.NewReader(`{"variables":["OCPPCommCtrlr/HeartbeatInterval","SecurityCtrlr/SecurityProfile"]}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	got, err := engine.LookupChargeStationVariables(context.Background(), "cs001")
+	require.NoError(t, err)
+
+	want := &store.ChargeStationVariables{
+		ChargeStationId: "cs001",
+		Variables: map[string]*store.ChargeStationVariable{
+			"OCPPCommCtrlr/HeartbeatInterval": {Status: store.ChargeStationVariableStatusPending},
+			"SecurityCtrlr/SecurityProfile":   {Status: store.ChargeStationVariableStatusPending},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestGetChargeStationVariablesAndWaitForOcpp16(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		response: `{"configurationKey":[{"key":"HeartbeatInterval","readonly":false,"value":"60"}],"unknownKey":["Unknown"]}`,
+	}
+	server, r, engine, _ := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/variables:get?wait=5",
+		strings.NewReader(`{"variables":["HeartbeatInterval","Unknown"]}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationVariables
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	value := "60"
+	assert.Equal(t, api.ChargeStationVariables{
+		Variables: map[string]api.ChargeStationVariable{
+			"HeartbeatInterval": {Value: &value, Status: "Accepted"},
+			"Unknown":           {Status: "UnknownKey"},
+		},
+	}, got)
+	assert.Equal(t, &ocpp16.GetConfigurationJson{Key: []string{"HeartbeatInterval", "Unknown"}}, callMaker.request)
+
+	stored, err := engine.LookupChargeStationVariables(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationVariableStatusPending, stored.Variables["HeartbeatInterval"].Status)
+}
+
+func TestGetChargeStationVariablesAndWaitForOcpp201(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		response: `{"getVariableResult":[{"attributeStatus":"Accepted","attributeValue":"22000","component":{"name":"EVSE","evse":{"id":1}},"variable":{"name":"Power"}}]}`,
+	}
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, callMaker)
+	defer server.Close()
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/variables:get?wait=5",
+		strings.NewReader(`{"variables":["EVSE;;1/Power"]}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationVariables
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	value := "22000"
+	assert.Equal(t, api.ChargeStationVariables{
+		Variables: map[string]api.ChargeStationVariable{
+			"EVSE;;1/Power": {Value: &value, Status: "Accepted"},
+		},
+	}, got)
+	assert.Equal(t, &ocpp201.GetVariablesRequestJson{
+		GetVariableData: []ocpp201.GetVariableDataType{
+			{
+				Component: ocpp201.ComponentType{Name: "EVSE", Evse: &ocpp201.EVSEType{Id: 1}},
+				Variable:  ocpp201.VariableType{Name: "Power"},
+			},
+		},
+	}, callMaker.request)
+}
+
+func TestGetChargeStationVariablesAndWaitWhenChargeStationRespondsWithError(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		err: transport.NewError(transport.ErrorNotSupported, errors.New("not supported")),
+	}
+	server, r, engine, _ := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/variables:get?wait=5",
+		strings.NewReader(`{"variables":["HeartbeatInterval"]}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadGateway, rr.Result().StatusCode)
+}
+
+func TestGetChargeStationVariablesAndWaitWhenChargeStationDoesNotRespond(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		err: context.DeadlineExceeded,
+	}
+	server, r, engine, _ := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/variables:get?wait=5",
+		strings.NewReader(`{"variables":["HeartbeatInterval"]}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusGatewayTimeout, rr.Result().StatusCode)
+}
+
+func TestGetChargeStationVariablesAndWaitWhenChargeStationHasNotConnected(t *testing.T) {
+	server, r, _, _ := setupServerWithCallMakers(t, &fakeSyncCallMaker{}, nil)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/variables:get?wait=5",
+		strings.NewReader(`{"variables":["HeartbeatInterval"]}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestLookupChargeStationVariables(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	value := "60"
+	err := engine.UpdateChargeStationVariables(context.Background(), "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"HeartbeatInterval": {Value: &value, Status: store.ChargeStationVariableStatusAccepted},
+			"UnknownKey":        {Status: store.ChargeStationVariableStatusUnknownKey},
+			"MeterValuesSampledData": {
+				Status:           store.ChargeStationVariableStatusRejected,
+				ErrorCode:        "InternalError",
+				ErrorDescription: "failed",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/variables", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationVariables
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	errorCode, errorDescription := "InternalError", "failed"
+
+	want := api.ChargeStationVariables{
+		Variables: map[string]api.ChargeStationVariable{
+			"HeartbeatInterval": {Value: &value, Status: "Accepted"},
+			"UnknownKey":        {Status: "UnknownKey"},
+			"MeterValuesSampledData": {
+				Status:           "Rejected",
+				ErrorCode:        &errorCode,
+				ErrorDescription: &errorDescription,
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationVariablesThatDoNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/variables", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestLookupChargeStationTrigger(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationTriggerMessage(context.Background(), "cs001", &store.ChargeStationTriggerMessage{
+		TriggerMessage:   store.TriggerMessageSignV2GCertificate,
+		TriggerStatus:    store.TriggerStatusFailed,
+		ErrorCode:        "NotSupported",
+		ErrorDescription: "not supported",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/trigger", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationTriggerStatus
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	errorCode, errorDescription := "NotSupported", "not supported"
+	want := api.ChargeStationTriggerStatus{
+		Trigger:          "SignV2GCertificate",
+		Status:           "Failed",
+		ErrorCode:        &errorCode,
+		ErrorDescription: &errorDescription,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationTriggerThatDoesNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/trigger", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestLookupChargeStationCertificates(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.UpdateChargeStationInstallCertificates(context.Background(), "cs001", &store.ChargeStationInstallCertificates{
+		Certificates: []*store.ChargeStationInstallCertificate{
+			{
+				CertificateType:               store.CertificateTypeV2G,
+				CertificateId:                 "v2g001",
+				CertificateData:               "v2g-pem",
+				CertificateInstallationStatus: store.CertificateInstallationAccepted,
+			},
+			{
+				CertificateType:               store.CertificateTypeMO,
+				CertificateId:                 "mo001",
+				CertificateData:               "mo-pem",
+				CertificateInstallationStatus: store.CertificateInstallationErrored,
+				ErrorCode:                     "SecurityError",
+				ErrorDescription:              "untrusted",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/certificates", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationCertificatesStatus
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	errorCode, errorDescription := "SecurityError", "untrusted"
+	assert.ElementsMatch(t, []api.ChargeStationCertificateStatus{
+		{Type: "V2G", Id: "v2g001", Status: "Accepted"},
+		{Type: "MO", Id: "mo001", Status: "Errored", ErrorCode: &errorCode, ErrorDescription: &errorDescription},
+	}, got.Certificates)
+}
+
+func TestLookupChargeStationCertificatesThatDoNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/certificates", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
 func TestLookupChargeStationRuntimeDetailsThatDoesNotExist(t *testing.T) {
 	server, r, _, _ := setupServer(t)
 	defer server.Close()
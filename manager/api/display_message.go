@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	displayMessagePriorities = []string{"AlwaysFront", "InFront", "NormalCycle"}
+	displayMessageStates     = []string{"Charging", "Faulted", "Idle", "Unavailable"}
+	displayMessageFormats    = []string{"ASCII", "HTML", "URI", "UTF8"}
+)
+
+func (s *Server) SetChargeStationDisplayMessage(w http.ResponseWriter, r *http.Request, csId string) {
+	req := new(DisplayMessage)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	message, err := newStoreDisplayMessage(req)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	// messages may be scheduled before the charge station first connects
+	if details != nil && details.OcppVersion != store.OcppVersion201 {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("display messages are not supported by ocpp %s charge stations", details.OcppVersion)))
+		return
+	}
+
+	err = s.setDisplayMessage(r.Context(), csId, message)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.Render(w, r, newChargeStationDisplayMessage(message))
+}
+
+func (s *Server) ListChargeStationDisplayMessages(w http.ResponseWriter, r *http.Request, csId string) {
+	messages, err := s.store.LookupChargeStationDisplayMessages(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, 0)
+	if messages != nil {
+		ids := maps.Keys(messages.Messages)
+		slices.Sort(ids)
+		for _, id := range ids {
+			resp = append(resp, newChargeStationDisplayMessage(messages.Messages[id]))
+		}
+	}
+
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) ClearChargeStationDisplayMessage(w http.ResponseWriter, r *http.Request, csId string, messageId int) {
+	found, err := s.clearDisplayMessage(r.Context(), csId, messageId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if !found {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) SetLocationDisplayMessage(w http.ResponseWriter, r *http.Request, locationId string) {
+	req := new(DisplayMessage)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	message, err := newStoreDisplayMessage(req)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	resp := LocationDisplayMessage{
+		Id:               message.Id,
+		ChargeStationIds: make([]string, 0),
+	}
+	err = s.forEachLocationChargeStation(r.Context(), locationId, func(csId string) error {
+		details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
+		if err != nil {
+			return fmt.Errorf("lookup charge station runtime details %s: %w", csId, err)
+		}
+		if details != nil && details.OcppVersion != store.OcppVersion201 {
+			return nil
+		}
+		// each charge station has its own copy of the message
+		csMessage := *message
+		err = s.setDisplayMessage(r.Context(), csId, &csMessage)
+		if err != nil {
+			return err
+		}
+		resp.ChargeStationIds = append(resp.ChargeStationIds, csId)
+		return nil
+	})
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.Render(w, r, resp)
+}
+
+func (s *Server) ClearLocationDisplayMessage(w http.ResponseWriter, r *http.Request, locationId string, messageId int) {
+	resp := LocationDisplayMessage{
+		Id:               messageId,
+		ChargeStationIds: make([]string, 0),
+	}
+	err := s.forEachLocationChargeStation(r.Context(), locationId, func(csId string) error {
+		found, err := s.clearDisplayMessage(r.Context(), csId, messageId)
+		if err != nil {
+			return err
+		}
+		if found {
+			resp.ChargeStationIds = append(resp.ChargeStationIds, csId)
+		}
+		return nil
+	})
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	_ = render.Render(w, r, resp)
+}
+
+// setDisplayMessage adds the message to the charge station's display messages (replacing any
+// message with the same id) so that it is sent to the charge station by the sync loop
+func (s *Server) setDisplayMessage(ctx context.Context, csId string, message *store.DisplayMessage) error {
+	messages, err := s.store.LookupChargeStationDisplayMessages(ctx, csId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station display messages %s: %w", csId, err)
+	}
+	if messages == nil {
+		messages = &store.ChargeStationDisplayMessages{
+			Messages: make(map[int]*store.DisplayMessage),
+		}
+	}
+	messages.Messages[message.Id] = message
+	messages.UpdatedAt = s.clock.Now()
+
+	err = s.store.SetChargeStationDisplayMessages(ctx, csId, messages)
+	if err != nil {
+		return fmt.Errorf("set charge station display messages %s: %w", csId, err)
+	}
+	return nil
+}
+
+// clearDisplayMessage marks the message to be cleared from the charge station by the sync loop.
+// Messages that the charge station did not accept are removed immediately. It returns false if
+// the message is not known.
+func (s *Server) clearDisplayMessage(ctx context.Context, csId string, messageId int) (bool, error) {
+	messages, err := s.store.LookupChargeStationDisplayMessages(ctx, csId)
+	if err != nil {
+		return false, fmt.Errorf("lookup charge station display messages %s: %w", csId, err)
+	}
+	if messages == nil || messages.Messages[messageId] == nil {
+		return false, nil
+	}
+
+	message := messages.Messages[messageId]
+	switch message.Status {
+	case store.DisplayMessageStatusClearPending:
+		return true, nil
+	case store.DisplayMessageStatusPending, store.DisplayMessageStatusAccepted, store.DisplayMessageStatusUnmanaged:
+		// a pending message may already have been received by the charge station
+		message.Status = store.DisplayMessageStatusClearPending
+		message.SendAfter = s.clock.Now()
+	default:
+		delete(messages.Messages, messageId)
+	}
+	messages.UpdatedAt = s.clock.Now()
+
+	err = s.store.SetChargeStationDisplayMessages(ctx, csId, messages)
+	if err != nil {
+		return false, fmt.Errorf("set charge station display messages %s: %w", csId, err)
+	}
+	return true, nil
+}
+
+// forEachLocationChargeStation calls fn with the id of each charge station at the location
+func (s *Server) forEachLocationChargeStation(ctx context.Context, locationId string, fn func(csId string) error) error {
+	const pageSize = 50
+	for offset := 0; ; offset += pageSize {
+		chargeStations, err := s.store.ListChargeStations(ctx, offset, pageSize)
+		if err != nil {
+			return fmt.Errorf("list charge stations: %w", err)
+		}
+		for _, cs := range chargeStations {
+			if cs.LocationId != locationId {
+				continue
+			}
+			if err = fn(cs.Id); err != nil {
+				return err
+			}
+		}
+		if len(chargeStations) < pageSize {
+			return nil
+		}
+	}
+}
+
+// newStoreDisplayMessage validates the requested message and returns it as a pending message
+func newStoreDisplayMessage(req *DisplayMessage) (*store.DisplayMessage, error) {
+	if !slices.Contains(displayMessagePriorities, req.Priority) {
+		return nil, fmt.Errorf("priority must be one of %v", displayMessagePriorities)
+	}
+	if req.State != nil && !slices.Contains(displayMessageStates, *req.State) {
+		return nil, fmt.Errorf("state must be one of %v", displayMessageStates)
+	}
+	format := "UTF8"
+	if req.Format != nil {
+		format = *req.Format
+	}
+	if !slices.Contains(displayMessageFormats, format) {
+		return nil, fmt.Errorf("format must be one of %v", displayMessageFormats)
+	}
+	if req.Content == "" {
+		return nil, errors.New("content must be provided")
+	}
+	if utf8.RuneCountInString(req.Content) > 512 {
+		return nil, errors.New("content must be at most 512 characters")
+	}
+	if req.StartDateTime != nil && req.EndDateTime != nil && !req.EndDateTime.After(*req.StartDateTime) {
+		return nil, errors.New("end_date_time must be after start_date_time")
+	}
+
+	id := int(rand.Int31()) //#nosec G404 - message id does not require secure random number generator
+	if req.Id != nil {
+		if *req.Id < 0 {
+			return nil, errors.New("id must not be negative")
+		}
+		id = *req.Id
+	}
+
+	return &store.DisplayMessage{
+		Id:            id,
+		Priority:      req.Priority,
+		State:         req.State,
+		StartDateTime: req.StartDateTime,
+		EndDateTime:   req.EndDateTime,
+		TransactionId: req.TransactionId,
+		Format:        format,
+		Language:      req.Language,
+		Content:       req.Content,
+		Status:        store.DisplayMessageStatusPending,
+	}, nil
+}
+
+func newChargeStationDisplayMessage(message *store.DisplayMessage) ChargeStationDisplayMessage {
+	return ChargeStationDisplayMessage{
+		Id:               message.Id,
+		Priority:         message.Priority,
+		State:            message.State,
+		StartDateTime:    message.StartDateTime,
+		EndDateTime:      message.EndDateTime,
+		TransactionId:    message.TransactionId,
+		Format:           message.Format,
+		Language:         message.Language,
+		Content:          message.Content,
+		Status:           string(message.Status),
+		ErrorCode:        stringOrNil(message.ErrorCode),
+		ErrorDescription: stringOrNil(message.ErrorDescription),
+	}
+}
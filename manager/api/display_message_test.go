@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestSetChargeStationDisplayMessage(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	endDateTime := clock.Now().Add(time.Hour).Truncate(time.Second)
+	rr := postOperation(r, "/cs/cs001/display-messages",
+		fmt.Sprintf(`{"id":7,"priority":"InFront","state":"Idle","end_date_time":"%s","language":"en","content":"Welcome"}`,
+			endDateTime.Format(time.RFC3339)))
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	var got api.ChargeStationDisplayMessage
+	err := json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	state := "Idle"
+	language := "en"
+	assert.Equal(t, api.ChargeStationDisplayMessage{
+		Id:          7,
+		Priority:    "InFront",
+		State:       &state,
+		EndDateTime: &endDateTime,
+		Format:      "UTF8",
+		Language:    &language,
+		Content:     "Welcome",
+		Status:      "Pending",
+	}, got)
+
+	messages, err := engine.LookupChargeStationDisplayMessages(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, messages)
+	require.Contains(t, messages.Messages, 7)
+	assert.Equal(t, store.DisplayMessageStatusPending, messages.Messages[7].Status)
+}
+
+func TestSetChargeStationDisplayMessageRejectsInvalidMessages(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	start := clock.Now().Add(time.Hour).Format(time.RFC3339)
+	end := clock.Now().Format(time.RFC3339)
+	for name, body := range map[string]string{
+		"priority": `{"priority":"Sometimes","content":"Welcome"}`,
+		"state":    `{"priority":"InFront","state":"Sleeping","content":"Welcome"}`,
+		"format":   `{"priority":"InFront","format":"PDF","content":"Welcome"}`,
+		"content":  `{"priority":"InFront","content":""}`,
+		"window":   fmt.Sprintf(`{"priority":"InFront","content":"Welcome","start_date_time":"%s","end_date_time":"%s"}`, start, end),
+	} {
+		t.Run(name, func(t *testing.T) {
+			rr := postOperation(r, "/cs/cs001/display-messages", body)
+			assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+		})
+	}
+
+	messages, err := engine.LookupChargeStationDisplayMessages(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, messages)
+}
+
+func TestSetChargeStationDisplayMessageRejectsOcpp16ChargeStation(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	rr := postOperation(r, "/cs/cs001/display-messages", `{"priority":"InFront","content":"Welcome"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestListChargeStationDisplayMessages(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationDisplayMessages(context.Background(), "cs001", &store.ChargeStationDisplayMessages{
+		Messages: map[int]*store.DisplayMessage{
+			2: {Id: 2, Priority: "NormalCycle", Format: "ASCII", Content: "Reported", Status: store.DisplayMessageStatusUnmanaged},
+			1: {Id: 1, Priority: "InFront", Format: "UTF8", Content: "Welcome", Status: store.DisplayMessageStatusErrored,
+				ErrorCode: "InternalError", ErrorDescription: "display failed"},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/display-messages", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got []api.ChargeStationDisplayMessage
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	errorCode := "InternalError"
+	errorDescription := "display failed"
+	assert.Equal(t, []api.ChargeStationDisplayMessage{
+		{Id: 1, Priority: "InFront", Format: "UTF8", Content: "Welcome", Status: "Errored",
+			ErrorCode: &errorCode, ErrorDescription: &errorDescription},
+		{Id: 2, Priority: "NormalCycle", Format: "ASCII", Content: "Reported", Status: "Unmanaged"},
+	}, got)
+}
+
+func TestClearChargeStationDisplayMessage(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	err := engine.SetChargeStationDisplayMessages(ctx, "cs001", &store.ChargeStationDisplayMessages{
+		Messages: map[int]*store.DisplayMessage{
+			1: {Id: 1, Priority: "InFront", Format: "UTF8", Content: "Welcome", Status: store.DisplayMessageStatusAccepted},
+			2: {Id: 2, Priority: "InFront", Format: "UTF8", Content: "Unsupported", Status: store.DisplayMessageStatusNotSupportedPriority},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, id := range []int{1, 2} {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/cs/cs001/display-messages/%d", id), nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/cs/cs001/display-messages/3", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	messages, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	require.Len(t, messages.Messages, 1)
+	assert.Equal(t, store.DisplayMessageStatusClearPending, messages.Messages[1].Status)
+}
+
+func TestSetAndClearLocationDisplayMessage(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	for _, cs := range []*store.ChargeStation{
+		{Id: "cs001", LocationId: "loc001"},
+		{Id: "cs002", LocationId: "loc001"},
+		{Id: "cs003", LocationId: "loc001"},
+		{Id: "cs004", LocationId: "loc002"},
+	} {
+		require.NoError(t, engine.CreateChargeStation(ctx, cs))
+	}
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs002", &store.ChargeStationRuntimeDetails{OcppVersion: store.OcppVersion16})
+	require.NoError(t, err)
+
+	rr := postOperation(r, "/location/loc001/display-messages", `{"priority":"AlwaysFront","content":"Closing at 10pm"}`)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	var got api.LocationDisplayMessage
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cs001", "cs003"}, got.ChargeStationIds)
+
+	for _, csId := range []string{"cs001", "cs003"} {
+		messages, err := engine.LookupChargeStationDisplayMessages(ctx, csId)
+		require.NoError(t, err)
+		require.NotNil(t, messages)
+		require.Contains(t, messages.Messages, got.Id)
+		assert.Equal(t, "Closing at 10pm", messages.Messages[got.Id].Content)
+	}
+	for _, csId := range []string{"cs002", "cs004"} {
+		messages, err := engine.LookupChargeStationDisplayMessages(ctx, csId)
+		require.NoError(t, err)
+		assert.Nil(t, messages)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/location/loc001/display-messages/%d", got.Id), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+
+	var cleared api.LocationDisplayMessage
+	err = json.NewDecoder(rr.Body).Decode(&cleared)
+	require.NoError(t, err)
+	assert.Equal(t, api.LocationDisplayMessage{Id: got.Id, ChargeStationIds: []string{"cs001", "cs003"}}, cleared)
+
+	messages, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageStatusClearPending, messages.Messages[got.Id].Status)
+}
@@ -3,6 +3,8 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"github.com/go-chi/render"
 	"net/http"
 )
@@ -39,7 +41,39 @@ func ErrInternalError(err error) render.Renderer {
 	}
 }
 
+// ErrConflict is used when the request cannot be made until an earlier request has completed
+func ErrConflict(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusConflict,
+		StatusText:     http.StatusText(http.StatusConflict),
+		ErrorText:      err.Error(),
+	}
+}
+
 var ErrNotFound = &ErrResponse{
 	HTTPStatusCode: http.StatusNotFound,
 	StatusText:     http.StatusText(http.StatusNotFound),
 }
+
+// ErrRevoked is used when a certificate has been revoked
+var ErrRevoked = &ErrResponse{
+	HTTPStatusCode: http.StatusGone,
+	StatusText:     http.StatusText(http.StatusGone),
+	ErrorText:      "certificate has been revoked",
+}
+
+// ErrCallFailed is used when a call sent to a charge station (waiting for the response) fails: either
+// because the charge station responded with an error or because it did not respond in time
+func ErrCallFailed(err error) render.Renderer {
+	status := http.StatusBadGateway
+	if errors.Is(err, context.DeadlineExceeded) {
+		status = http.StatusGatewayTimeout
+	}
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: status,
+		StatusText:     http.StatusText(status),
+		ErrorText:      err.Error(),
+	}
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Server) ListIssuedCertificates(w http.ResponseWriter, r *http.Request, params ListIssuedCertificatesParams) {
+	var expiresBefore *time.Time
+	if params.ExpiringWithinDays != nil {
+		t := s.clock.Now().Add(time.Duration(*params.ExpiringWithinDays) * 24 * time.Hour)
+		expiresBefore = &t
+	}
+
+	var resp = make([]render.Renderer, 0)
+	var previousChargeStationId string
+	for {
+		issuedCertificates, err := s.store.ListChargeStationIssuedCertificates(r.Context(), 50, previousChargeStationId)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		for _, certificates := range issuedCertificates {
+			for _, certificateType := range []store.CertificateType{store.CertificateTypeChargeStation, store.CertificateTypeEVCC} {
+				certificate := certificates.Certificates[certificateType]
+				if certificate == nil || (expiresBefore != nil && certificate.NotAfter.After(*expiresBefore)) {
+					continue
+				}
+				resp = append(resp, IssuedCertificate{
+					ChargeStationId:    certificates.ChargeStationId,
+					CertificateType:    string(certificate.CertificateType),
+					CertificateId:      certificate.CertificateId,
+					SerialNumber:       certificate.SerialNumber,
+					NotAfter:           certificate.NotAfter,
+					IssuedAt:           certificate.IssuedAt,
+					RenewalRequestedAt: certificate.RenewalRequestedAt,
+				})
+			}
+		}
+		if len(issuedCertificates) < 50 {
+			break
+		}
+		previousChargeStationId = issuedCertificates[len(issuedCertificates)-1].ChargeStationId
+	}
+
+	_ = render.RenderList(w, r, resp)
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestListIssuedCertificates(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	now := clock.Now().Truncate(time.Second)
+	expiring := now.Add(10 * 24 * time.Hour)
+	notExpiring := now.Add(300 * 24 * time.Hour)
+	ctx := context.Background()
+	err := engine.SetChargeStationIssuedCertificates(ctx, "cs001", &store.ChargeStationIssuedCertificates{
+		Certificates: map[store.CertificateType]*store.IssuedCertificate{
+			store.CertificateTypeChargeStation: {
+				CertificateType:    store.CertificateTypeChargeStation,
+				CertificateId:      "abc123",
+				SerialNumber:       "1234",
+				NotAfter:           expiring,
+				IssuedAt:           now,
+				RenewalRequestedAt: &now,
+			},
+			store.CertificateTypeEVCC: {
+				CertificateType: store.CertificateTypeEVCC,
+				CertificateId:   "def456",
+				SerialNumber:    "5678",
+				NotAfter:        notExpiring,
+				IssuedAt:        now,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/issued-certificate", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got []api.IssuedCertificate
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	want := []api.IssuedCertificate{
+		{
+			ChargeStationId:    "cs001",
+			CertificateType:    "ChargeStation",
+			CertificateId:      "abc123",
+			SerialNumber:       "1234",
+			NotAfter:           expiring,
+			IssuedAt:           now,
+			RenewalRequestedAt: &now,
+		},
+		{
+			ChargeStationId: "cs001",
+			CertificateType: "EVCC",
+			CertificateId:   "def456",
+			SerialNumber:    "5678",
+			NotAfter:        notExpiring,
+			IssuedAt:        now,
+		},
+	}
+	assert.Equal(t, want, got)
+
+	req = httptest.NewRequest(http.MethodGet, "/issued-certificate?expiring_within_days=30", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	got = nil
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+	assert.Equal(t, want[:1], got)
+}
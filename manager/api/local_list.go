@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slices"
+)
+
+func (s *Server) SetChargeStationLocalList(w http.ResponseWriter, r *http.Request, csId string) {
+	req := new(ChargeStationLocalListSettings)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	localList, err := s.store.LookupChargeStationLocalList(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if localList == nil {
+		// the version held by the charge station is checked before the list is sent
+		localList = &store.ChargeStationLocalList{
+			Entries: make(map[string]*store.LocalListEntry),
+			Status:  store.LocalListStatusVersionUnknown,
+		}
+	}
+	localList.GroupId = req.GroupId
+	localList.UpdatedAt = s.clock.Now()
+
+	err = s.store.SetChargeStationLocalList(r.Context(), csId, localList)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+}
+
+func (s *Server) LookupChargeStationLocalList(w http.ResponseWriter, r *http.Request, csId string) {
+	localList, err := s.store.LookupChargeStationLocalList(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if localList == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	resp := ChargeStationLocalList{
+		GroupId:          localList.GroupId,
+		Version:          localList.Version,
+		Status:           string(localList.Status),
+		ErrorCode:        stringOrNil(localList.ErrorCode),
+		ErrorDescription: stringOrNil(localList.ErrorDescription),
+		Entries:          make([]ChargeStationLocalListEntry, 0, len(localList.Entries)),
+		UpdatedAt:        localList.UpdatedAt,
+	}
+	for _, entry := range localList.Entries {
+		resp.Entries = append(resp.Entries, ChargeStationLocalListEntry{
+			IdToken:   entry.IdToken,
+			TokenType: stringOrNil(entry.IdTokenType),
+			Status:    entry.Status,
+			GroupId:   entry.GroupId,
+		})
+	}
+	slices.SortFunc(resp.Entries, func(a, b ChargeStationLocalListEntry) int {
+		return strings.Compare(a.IdToken, b.IdToken)
+	})
+
+	_ = render.Render(w, r, resp)
+}
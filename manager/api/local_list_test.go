@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestSetChargeStationLocalList(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/cs/cs001/local-list", strings.NewReader(`{"group_id":"group001"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	got, err := engine.LookupChargeStationLocalList(context.Background(), "cs001")
+	require.NoError(t, err)
+
+	groupId := "group001"
+	want := &store.ChargeStationLocalList{
+		ChargeStationId: "cs001",
+		GroupId:         &groupId,
+		Entries:         map[string]*store.LocalListEntry{},
+		Status:          store.LocalListStatusVersionUnknown,
+		UpdatedAt:       clock.Now(),
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestSetChargeStationLocalListKeepsExistingList(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	groupId := "group001"
+	err := engine.SetChargeStationLocalList(context.Background(), "cs001", &store.ChargeStationLocalList{
+		GroupId: &groupId,
+		Version: 3,
+		Entries: map[string]*store.LocalListEntry{
+			"ABC": {IdToken: "ABC", Status: "Accepted", GroupId: &groupId},
+		},
+		Status: store.LocalListStatusAccepted,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/cs/cs001/local-list", strings.NewReader(`{}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	got, err := engine.LookupChargeStationLocalList(context.Background(), "cs001")
+	require.NoError(t, err)
+
+	want := &store.ChargeStationLocalList{
+		ChargeStationId: "cs001",
+		Version:         3,
+		Entries: map[string]*store.LocalListEntry{
+			"ABC": {IdToken: "ABC", Status: "Accepted", GroupId: &groupId},
+		},
+		Status:    store.LocalListStatusAccepted,
+		UpdatedAt: clock.Now(),
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationLocalList(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationLocalList(context.Background(), "cs001", &store.ChargeStationLocalList{
+		Version: 2,
+		Entries: map[string]*store.LocalListEntry{
+			"DEF": {IdToken: "DEF", Status: "Invalid"},
+			"ABC": {IdToken: "ABC", IdTokenType: "ISO14443", Status: "Accepted"},
+		},
+		Status:           store.LocalListStatusErrored,
+		ErrorCode:        "InternalError",
+		ErrorDescription: "list is full",
+		UpdatedAt:        clock.Now(),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/local-list", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationLocalList
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	want := api.ChargeStationLocalList{
+		Version:          2,
+		Status:           "Errored",
+		ErrorCode:        makePtr("InternalError"),
+		ErrorDescription: makePtr("list is full"),
+		Entries: []api.ChargeStationLocalListEntry{
+			{IdToken: "ABC", TokenType: makePtr("ISO14443"), Status: "Accepted"},
+			{IdToken: "DEF", Status: "Invalid"},
+		},
+		UpdatedAt: clock.Now(),
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationLocalListThatDoesNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/local-list", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
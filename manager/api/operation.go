@@ -0,0 +1,367 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// chargeStationOperation is the OCPP call used to perform a remote operation on a charge
+// station for a specific OCPP version
+type chargeStationOperation struct {
+	action   string
+	request  ocpp.Request
+	response ocpp.Response
+	status   func() string // the status from the response
+}
+
+// operationBuilder creates the OCPP call for a remote operation: it returns an error if the
+// operation cannot be performed with the parameters provided
+type operationBuilder func() (*chargeStationOperation, error)
+
+func (s *Server) ResetChargeStation(w http.ResponseWriter, r *http.Request, csId string, params ResetChargeStationParams) {
+	req := new(ChargeStationReset)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	v16 := func() (*chargeStationOperation, error) {
+		if req.EvseId != nil {
+			return nil, errors.New("evse_id is not supported for ocpp 1.6")
+		}
+		var resetType ocpp16.ResetJsonType
+		switch req.Type {
+		case "Immediate":
+			resetType = ocpp16.ResetJsonTypeHard
+		case "OnIdle":
+			resetType = ocpp16.ResetJsonTypeSoft
+		default:
+			return nil, fmt.Errorf("unknown reset type: %s", req.Type)
+		}
+		resp := new(ocpp16.ResetResponseJson)
+		return &chargeStationOperation{
+			action:   "Reset",
+			request:  &ocpp16.ResetJson{Type: resetType},
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	v201 := func() (*chargeStationOperation, error) {
+		resetType := ocpp201.ResetEnumType(req.Type)
+		if resetType != ocpp201.ResetEnumTypeImmediate && resetType != ocpp201.ResetEnumTypeOnIdle {
+			return nil, fmt.Errorf("unknown reset type: %s", req.Type)
+		}
+		resp := new(ocpp201.ResetResponseJson)
+		return &chargeStationOperation{
+			action:   "Reset",
+			request:  &ocpp201.ResetRequestJson{Type: resetType, EvseId: req.EvseId},
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	s.sendChargeStationOperation(w, r, csId, params.Wait, v16, v201)
+}
+
+func (s *Server) ChangeChargeStationAvailability(w http.ResponseWriter, r *http.Request, csId string, params ChangeChargeStationAvailabilityParams) {
+	req := new(ChargeStationChangeAvailability)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	v16 := func() (*chargeStationOperation, error) {
+		if req.EvseId != nil {
+			return nil, errors.New("evse_id is not supported for ocpp 1.6")
+		}
+		availabilityType := ocpp16.ChangeAvailabilityJsonType(req.OperationalStatus)
+		if availabilityType != ocpp16.ChangeAvailabilityJsonTypeOperative && availabilityType != ocpp16.ChangeAvailabilityJsonTypeInoperative {
+			return nil, fmt.Errorf("unknown operational status: %s", req.OperationalStatus)
+		}
+		// connector 0 is the whole charge station
+		connectorId := 0
+		if req.ConnectorId != nil {
+			connectorId = *req.ConnectorId
+		}
+		resp := new(ocpp16.ChangeAvailabilityResponseJson)
+		return &chargeStationOperation{
+			action:   "ChangeAvailability",
+			request:  &ocpp16.ChangeAvailabilityJson{ConnectorId: connectorId, Type: availabilityType},
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	v201 := func() (*chargeStationOperation, error) {
+		operationalStatus := ocpp201.OperationalStatusEnumType(req.OperationalStatus)
+		if operationalStatus != ocpp201.OperationalStatusEnumTypeOperative && operationalStatus != ocpp201.OperationalStatusEnumTypeInoperative {
+			return nil, fmt.Errorf("unknown operational status: %s", req.OperationalStatus)
+		}
+		changeAvailabilityReq := &ocpp201.ChangeAvailabilityRequestJson{OperationalStatus: operationalStatus}
+		if req.EvseId != nil {
+			changeAvailabilityReq.Evse = &ocpp201.EVSEType{Id: *req.EvseId, ConnectorId: req.ConnectorId}
+		} else if req.ConnectorId != nil {
+			return nil, errors.New("evse_id is required with connector_id for ocpp 2.0.1")
+		}
+		resp := new(ocpp201.ChangeAvailabilityResponseJson)
+		return &chargeStationOperation{
+			action:   "ChangeAvailability",
+			request:  changeAvailabilityReq,
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	s.sendChargeStationOperation(w, r, csId, params.Wait, v16, v201)
+}
+
+func (s *Server) UnlockChargeStationConnector(w http.ResponseWriter, r *http.Request, csId string, params UnlockChargeStationConnectorParams) {
+	req := new(ChargeStationUnlockConnector)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	v16 := func() (*chargeStationOperation, error) {
+		if req.EvseId != nil {
+			return nil, errors.New("evse_id is not supported for ocpp 1.6")
+		}
+		resp := new(ocpp16.UnlockConnectorResponseJson)
+		return &chargeStationOperation{
+			action:   "UnlockConnector",
+			request:  &ocpp16.UnlockConnectorJson{ConnectorId: req.ConnectorId},
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	v201 := func() (*chargeStationOperation, error) {
+		if req.EvseId == nil {
+			return nil, errors.New("evse_id is required for ocpp 2.0.1")
+		}
+		resp := new(ocpp201.UnlockConnectorResponseJson)
+		return &chargeStationOperation{
+			action:   "UnlockConnector",
+			request:  &ocpp201.UnlockConnectorRequestJson{EvseId: *req.EvseId, ConnectorId: req.ConnectorId},
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	s.sendChargeStationOperation(w, r, csId, params.Wait, v16, v201)
+}
+
+func (s *Server) ClearChargeStationCache(w http.ResponseWriter, r *http.Request, csId string, params ClearChargeStationCacheParams) {
+	v16 := func() (*chargeStationOperation, error) {
+		resp := new(ocpp16.ClearCacheResponseJson)
+		return &chargeStationOperation{
+			action:   "ClearCache",
+			request:  &ocpp16.ClearCacheJson{},
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	v201 := func() (*chargeStationOperation, error) {
+		resp := new(ocpp201.ClearCacheResponseJson)
+		return &chargeStationOperation{
+			action:   "ClearCache",
+			request:  &ocpp201.ClearCacheRequestJson{},
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	s.sendChargeStationOperation(w, r, csId, params.Wait, v16, v201)
+}
+
+func (s *Server) StopChargeStationTransaction(w http.ResponseWriter, r *http.Request, csId string, params StopChargeStationTransactionParams) {
+	req := new(ChargeStationStopTransaction)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	v16 := func() (*chargeStationOperation, error) {
+		transactionId, err := strconv.Atoi(req.TransactionId)
+		if err != nil {
+			return nil, fmt.Errorf("transaction_id must be an integer for ocpp 1.6: %w", err)
+		}
+		resp := new(ocpp16.RemoteStopTransactionResponseJson)
+		return &chargeStationOperation{
+			action:   "RemoteStopTransaction",
+			request:  &ocpp16.RemoteStopTransactionJson{TransactionId: transactionId},
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	v201 := func() (*chargeStationOperation, error) {
+		resp := new(ocpp201.RequestStopTransactionResponseJson)
+		return &chargeStationOperation{
+			action:   "RequestStopTransaction",
+			request:  &ocpp201.RequestStopTransactionRequestJson{TransactionId: req.TransactionId},
+			response: resp,
+			status:   func() string { return string(resp.Status) },
+		}, nil
+	}
+
+	s.sendChargeStationOperation(w, r, csId, params.Wait, v16, v201)
+}
+
+func (s *Server) GetChargeStationTransactionStatus(w http.ResponseWriter, r *http.Request, csId string, params GetChargeStationTransactionStatusParams) {
+	req := new(ChargeStationTransactionStatus)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	v201 := func() (*chargeStationOperation, error) {
+		resp := new(ocpp201.GetTransactionStatusResponseJson)
+		return &chargeStationOperation{
+			action:   "GetTransactionStatus",
+			request:  &ocpp201.GetTransactionStatusRequestJson{TransactionId: req.TransactionId},
+			response: resp,
+			// the response does not have a status: receiving it means the request was accepted
+			status: func() string { return "Accepted" },
+		}, nil
+	}
+
+	s.sendChargeStationOperation(w, r, csId, params.Wait, nil, v201)
+}
+
+func (s *Server) ListChargeStationOperations(w http.ResponseWriter, r *http.Request, csId string) {
+	operations, err := s.store.ListChargeStationOperations(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, len(operations))
+	for i, operation := range operations {
+		resp[i] = newChargeStationOperation(operation)
+	}
+
+	_ = render.RenderList(w, r, resp)
+}
+
+// sendChargeStationOperation records the remote operation as pending and sends it to the charge
+// station using the builder for the charge station's OCPP version (a nil builder means that the
+// operation is not supported for that version). If wait is provided then the response from the
+// charge station is included in the response.
+func (s *Server) sendChargeStationOperation(w http.ResponseWriter, r *http.Request, csId string, wait *int, v16, v201 operationBuilder) {
+	details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if details == nil {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("charge station %s has not connected", csId)))
+		return
+	}
+
+	var callMaker handlers.SyncCallMaker
+	var build operationBuilder
+	switch details.OcppVersion {
+	case "1.6":
+		callMaker, build = s.v16CallMaker, v16
+	case "2.0.1":
+		callMaker, build = s.v201CallMaker, v201
+	default:
+		_ = render.Render(w, r, ErrInternalError(fmt.Errorf("unsupported ocpp version: %s", details.OcppVersion)))
+		return
+	}
+	if build == nil {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("operation is not supported for ocpp %s charge stations", details.OcppVersion)))
+		return
+	}
+	op, err := build()
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	if callMaker == nil {
+		_ = render.Render(w, r, ErrInternalError(fmt.Errorf("unable to send calls to ocpp %s charge stations", details.OcppVersion)))
+		return
+	}
+
+	operation, err := handlers.StartOperation(r.Context(), s.store, s.clock, csId, op.action, op.request)
+	if err != nil {
+		if errors.Is(err, handlers.ErrOperationPending) {
+			_ = render.Render(w, r, ErrConflict(err))
+		} else {
+			_ = render.Render(w, r, ErrInternalError(err))
+		}
+		return
+	}
+
+	if wait == nil {
+		err = callMaker.Send(r.Context(), csId, op.request)
+		if err != nil {
+			// mark the operation as errored so that it doesn't block further requests
+			err = errors.Join(err, handlers.RecordOperationFailure(r.Context(), s.store, s.clock, csId, op.action, err))
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		_ = render.Render(w, r, newChargeStationOperation(operation))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(*wait)*time.Second)
+	defer cancel()
+	err = callMaker.SendAndWait(ctx, csId, op.request, op.response)
+	if err != nil {
+		err = errors.Join(err, handlers.RecordOperationFailure(r.Context(), s.store, s.clock, csId, op.action, err))
+		_ = render.Render(w, r, ErrCallFailed(err))
+		return
+	}
+
+	// the result is also recorded by whichever manager instance handles the response: only the first
+	// update is applied
+	err = handlers.RecordOperationResult(r.Context(), s.store, s.clock, csId, op.action, op.status(), op.response)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	operation, err = s.store.LookupChargeStationOperation(r.Context(), csId, op.action)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if operation == nil {
+		_ = render.Render(w, r, ErrInternalError(fmt.Errorf("operation %s not found", op.action)))
+		return
+	}
+
+	_ = render.Render(w, r, newChargeStationOperation(operation))
+}
+
+func newChargeStationOperation(operation *store.ChargeStationOperation) ChargeStationOperation {
+	return ChargeStationOperation{
+		Operation:        operation.Operation,
+		Status:           string(operation.Status),
+		Request:          operation.Request,
+		Response:         stringOrNil(operation.Response),
+		ErrorCode:        stringOrNil(operation.ErrorCode),
+		ErrorDescription: stringOrNil(operation.ErrorDescription),
+		RequestedAt:      operation.RequestedAt,
+		UpdatedAt:        operation.UpdatedAt,
+	}
+}
@@ -0,0 +1,370 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+)
+
+func setChargeStationOcppVersion(t *testing.T, engine store.Engine, ocppVersion string) {
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: store.OcppVersion(ocppVersion),
+	})
+	require.NoError(t, err)
+}
+
+func postOperation(r *chi.Mux, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestResetChargeStation(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, clock := setupServerWithCallMakers(t, nil, callMaker)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	rr := postOperation(r, "/cs/cs001/reset", `{"type":"OnIdle","evse_id":1}`)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	var got api.ChargeStationOperation
+	err := json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	assert.Equal(t, api.ChargeStationOperation{
+		Operation:   "Reset",
+		Status:      "Pending",
+		Request:     `{"evseId":1,"type":"OnIdle"}`,
+		RequestedAt: clock.Now(),
+		UpdatedAt:   clock.Now(),
+	}, got)
+
+	evseId := 1
+	assert.Equal(t, &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeOnIdle, EvseId: &evseId}, callMaker.request)
+
+	stored, err := engine.LookupChargeStationOperation(context.Background(), "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationOperation{
+		ChargeStationId: "cs001",
+		Operation:       "Reset",
+		Request:         `{"evseId":1,"type":"OnIdle"}`,
+		Status:          store.ChargeStationOperationStatusPending,
+		RequestedAt:     clock.Now(),
+		UpdatedAt:       clock.Now(),
+	}, stored)
+}
+
+func TestResetChargeStationAndWaitForOcpp16(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		response: `{"status":"Accepted"}`,
+	}
+	server, r, engine, clock := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	rr := postOperation(r, "/cs/cs001/reset?wait=5", `{"type":"Immediate"}`)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationOperation
+	err := json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	response := `{"status":"Accepted"}`
+	assert.Equal(t, api.ChargeStationOperation{
+		Operation:   "Reset",
+		Status:      "Accepted",
+		Request:     `{"type":"Hard"}`,
+		Response:    &response,
+		RequestedAt: clock.Now(),
+		UpdatedAt:   clock.Now(),
+	}, got)
+	assert.Equal(t, &ocpp16.ResetJson{Type: ocpp16.ResetJsonTypeHard}, callMaker.request)
+}
+
+func TestResetChargeStationWithUnknownType(t *testing.T) {
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, &fakeSyncCallMaker{})
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	rr := postOperation(r, "/cs/cs001/reset", `{"type":"Hard"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+	stored, err := engine.LookupChargeStationOperation(context.Background(), "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestResetChargeStationWhileResetIsPending(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, callMaker)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	rr := postOperation(r, "/cs/cs001/reset", `{"type":"OnIdle"}`)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	rr = postOperation(r, "/cs/cs001/reset", `{"type":"Immediate"}`)
+	assert.Equal(t, http.StatusConflict, rr.Result().StatusCode)
+	assert.Equal(t, &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeOnIdle}, callMaker.request)
+
+	stored, err := engine.LookupChargeStationOperation(context.Background(), "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"OnIdle"}`, stored.Request)
+}
+
+func TestResetChargeStationThatCannotBeSent(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{err: errors.New("broker unavailable")}
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, callMaker)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	rr := postOperation(r, "/cs/cs001/reset", `{"type":"OnIdle"}`)
+	require.Equal(t, http.StatusInternalServerError, rr.Result().StatusCode)
+
+	stored, err := engine.LookupChargeStationOperation(context.Background(), "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationOperationStatusErrored, stored.Status)
+	assert.Equal(t, "broker unavailable", stored.ErrorDescription)
+
+	// the failed request doesn't block a retry
+	callMaker.err = nil
+	rr = postOperation(r, "/cs/cs001/reset", `{"type":"OnIdle"}`)
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+}
+
+func TestResetChargeStationThatHasNotConnected(t *testing.T) {
+	server, r, _, _ := setupServerWithCallMakers(t, &fakeSyncCallMaker{}, &fakeSyncCallMaker{})
+	defer server.Close()
+
+	rr := postOperation(r, "/cs/cs001/reset", `{"type":"Immediate"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestChangeChargeStationAvailability(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, callMaker)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	rr := postOperation(r, "/cs/cs001/change-availability", `{"operational_status":"Inoperative","evse_id":1,"connector_id":2}`)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	connectorId := 2
+	assert.Equal(t, &ocpp201.ChangeAvailabilityRequestJson{
+		OperationalStatus: ocpp201.OperationalStatusEnumTypeInoperative,
+		Evse:              &ocpp201.EVSEType{Id: 1, ConnectorId: &connectorId},
+	}, callMaker.request)
+}
+
+func TestChangeChargeStationAvailabilityForOcpp16(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, _ := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	rr := postOperation(r, "/cs/cs001/change-availability", `{"operational_status":"Operative"}`)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	assert.Equal(t, &ocpp16.ChangeAvailabilityJson{
+		ConnectorId: 0,
+		Type:        ocpp16.ChangeAvailabilityJsonTypeOperative,
+	}, callMaker.request)
+}
+
+func TestChangeChargeStationAvailabilityWithConnectorButNoEvse(t *testing.T) {
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, &fakeSyncCallMaker{})
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	rr := postOperation(r, "/cs/cs001/change-availability", `{"operational_status":"Operative","connector_id":1}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestUnlockChargeStationConnectorAndWaitForOcpp16(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		response: `{"status":"UnlockFailed"}`,
+	}
+	server, r, engine, _ := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	rr := postOperation(r, "/cs/cs001/unlock-connector?wait=5", `{"connector_id":1}`)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationOperation
+	err := json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	assert.Equal(t, "UnlockConnector", got.Operation)
+	assert.Equal(t, "UnlockFailed", got.Status)
+	assert.Equal(t, &ocpp16.UnlockConnectorJson{ConnectorId: 1}, callMaker.request)
+}
+
+func TestUnlockChargeStationConnectorWithoutEvseForOcpp201(t *testing.T) {
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, &fakeSyncCallMaker{})
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	rr := postOperation(r, "/cs/cs001/unlock-connector", `{"connector_id":1}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestClearChargeStationCacheAndWaitWhenChargeStationRespondsWithError(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		err: transport.NewError(transport.ErrorNotSupported, errors.New("not supported")),
+	}
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, callMaker)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/clear-cache?wait=5", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadGateway, rr.Result().StatusCode)
+	assert.Equal(t, &ocpp201.ClearCacheRequestJson{}, callMaker.request)
+}
+
+func TestStopChargeStationTransactionForOcpp16(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, _ := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	rr := postOperation(r, "/cs/cs001/stop-transaction", `{"transaction_id":"1234"}`)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	assert.Equal(t, &ocpp16.RemoteStopTransactionJson{TransactionId: 1234}, callMaker.request)
+
+	stored, err := engine.LookupChargeStationOperation(context.Background(), "cs001", "RemoteStopTransaction")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationOperationStatusPending, stored.Status)
+}
+
+func TestStopChargeStationTransactionWithNonIntegerIdForOcpp16(t *testing.T) {
+	server, r, engine, _ := setupServerWithCallMakers(t, &fakeSyncCallMaker{}, nil)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	rr := postOperation(r, "/cs/cs001/stop-transaction", `{"transaction_id":"abc"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestStopChargeStationTransactionForOcpp201(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, callMaker)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	rr := postOperation(r, "/cs/cs001/stop-transaction", `{"transaction_id":"abc"}`)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	assert.Equal(t, &ocpp201.RequestStopTransactionRequestJson{TransactionId: "abc"}, callMaker.request)
+}
+
+func TestGetChargeStationTransactionStatusAndWait(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		response: `{"messagesInQueue":false,"ongoingIndicator":true}`,
+	}
+	server, r, engine, _ := setupServerWithCallMakers(t, nil, callMaker)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	rr := postOperation(r, "/cs/cs001/transaction-status?wait=5", `{"transaction_id":"abc"}`)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationOperation
+	err := json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Accepted", got.Status)
+	require.NotNil(t, got.Response)
+	assert.JSONEq(t, `{"messagesInQueue":false,"ongoingIndicator":true}`, *got.Response)
+}
+
+func TestGetChargeStationTransactionStatusForOcpp16(t *testing.T) {
+	server, r, engine, _ := setupServerWithCallMakers(t, &fakeSyncCallMaker{}, nil)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	rr := postOperation(r, "/cs/cs001/transaction-status", `{}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestListChargeStationOperations(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	requestedAt := clock.Now().Add(-time.Minute)
+	err := engine.SetChargeStationOperation(context.Background(), "cs001", &store.ChargeStationOperation{
+		Operation:        "UnlockConnector",
+		Request:          `{"evseId":1,"connectorId":1}`,
+		Status:           store.ChargeStationOperationStatusErrored,
+		ErrorCode:        "NotSupported",
+		ErrorDescription: "not supported",
+		RequestedAt:      requestedAt,
+		UpdatedAt:        clock.Now(),
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationOperation(context.Background(), "cs001", &store.ChargeStationOperation{
+		Operation:   "Reset",
+		Request:     `{"type":"Immediate"}`,
+		Response:    `{"status":"Accepted"}`,
+		Status:      "Accepted",
+		RequestedAt: requestedAt,
+		UpdatedAt:   clock.Now(),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/operations", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got []api.ChargeStationOperation
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	response := `{"status":"Accepted"}`
+	errorCode := "NotSupported"
+	errorDescription := "not supported"
+	assert.Equal(t, []api.ChargeStationOperation{
+		{
+			Operation:   "Reset",
+			Status:      "Accepted",
+			Request:     `{"type":"Immediate"}`,
+			Response:    &response,
+			RequestedAt: requestedAt,
+			UpdatedAt:   clock.Now(),
+		},
+		{
+			Operation:        "UnlockConnector",
+			Status:           "Errored",
+			Request:          `{"evseId":1,"connectorId":1}`,
+			ErrorCode:        &errorCode,
+			ErrorDescription: &errorDescription,
+			RequestedAt:      requestedAt,
+			UpdatedAt:        clock.Now(),
+		},
+	}, got)
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Server) RotateChargeStationPassword(w http.ResponseWriter, r *http.Request, csId string) {
+	cs, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if cs == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+	if cs.SecurityProfile == store.TLSWithClientSideCertificates {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("charge station does not use basic auth")))
+		return
+	}
+	details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if details == nil {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("charge station has not connected: OCPP version is unknown")))
+		return
+	}
+
+	_, err = handlers.RotatePassword(r.Context(), s.store, s.clock, csId, details.OcppVersion)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) ReconnectChargeStationPassword(w http.ResponseWriter, r *http.Request, csId string) {
+	cs, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if cs == nil || cs.PendingPassword(s.clock.Now()) == "" {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	cs.Base64SHA256Password = cs.PendingBase64SHA256Password
+	cs.PendingBase64SHA256Password = ""
+	cs.PendingPasswordExpiresAt = nil
+	err = s.store.UpdateChargeStation(r.Context(), csId, cs)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestRotateChargeStationPassword(t *testing.T) {
+	tests := map[string]struct {
+		ocppVersion store.OcppVersion
+		key         string
+	}{
+		"ocpp 1.6": {
+			ocppVersion: "1.6",
+			key:         "AuthorizationKey",
+		},
+		"ocpp 2.0.1": {
+			ocppVersion: "2.0.1",
+			key:         "SecurityCtrlr/BasicAuthPassword",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			ctx := context.Background()
+			err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+				Id:                   "cs001",
+				SecurityProfile:      store.TLSWithBasicAuth,
+				Base64SHA256Password: "DEADBEEF",
+			})
+			require.NoError(t, err)
+			err = engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{OcppVersion: tc.ocppVersion})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/cs/cs001/password", nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+			settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+			require.NoError(t, err)
+			require.NotNil(t, settings)
+			setting := settings.Settings[tc.key]
+			require.NotNil(t, setting)
+			assert.Equal(t, store.ChargeStationSettingStatusPending, setting.Status)
+			assert.Len(t, setting.Value, 40)
+
+			cs, err := engine.LookupChargeStation(ctx, "cs001")
+			require.NoError(t, err)
+			hash := sha256.Sum256([]byte(setting.Value))
+			assert.Equal(t, "DEADBEEF", cs.Base64SHA256Password)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(hash[:]), cs.PendingBase64SHA256Password)
+			require.NotNil(t, cs.PendingPasswordExpiresAt)
+
+			req = httptest.NewRequest(http.MethodGet, "/cs/cs001", nil)
+			rr = httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+			var got api.ChargeStation
+			err = json.NewDecoder(rr.Body).Decode(&got)
+			require.NoError(t, err)
+			require.NotNil(t, got.PendingBase64SHA256Password)
+			assert.Equal(t, cs.PendingBase64SHA256Password, *got.PendingBase64SHA256Password)
+		})
+	}
+}
+
+func TestRotateChargeStationPasswordValidation(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:              "cs001",
+		SecurityProfile: store.TLSWithBasicAuth,
+	})
+	require.NoError(t, err)
+	err = engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:              "cs002",
+		SecurityProfile: store.TLSWithClientSideCertificates,
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationRuntimeDetails(ctx, "cs002", &store.ChargeStationRuntimeDetails{OcppVersion: "2.0.1"})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		csId string
+		want int
+	}{
+		"unknown charge station": {
+			csId: "unknown",
+			want: http.StatusNotFound,
+		},
+		"no runtime details": {
+			csId: "cs001",
+			want: http.StatusBadRequest,
+		},
+		"client certificate auth": {
+			csId: "cs002",
+			want: http.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/cs/"+tc.csId+"/password", nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, tc.want, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestReconnectChargeStationPassword(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:                          "cs001",
+		SecurityProfile:             store.TLSWithBasicAuth,
+		Base64SHA256Password:        "DEADBEEF",
+		PendingBase64SHA256Password: "BEEFDEAD",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/password/reconnect", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	cs, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "BEEFDEAD", cs.Base64SHA256Password)
+	assert.Empty(t, cs.PendingBase64SHA256Password)
+
+	// there is no longer a pending password
+	req = httptest.NewRequest(http.MethodPost, "/cs/cs001/password/reconnect", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestExpiredPendingPasswordIsNotReturned(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	expiresAt := clock.Now().Add(-time.Minute)
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:                          "cs001",
+		SecurityProfile:             store.TLSWithBasicAuth,
+		Base64SHA256Password:        "DEADBEEF",
+		PendingBase64SHA256Password: "CAFEBABE",
+		PendingPasswordExpiresAt:    &expiresAt,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStation
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+	assert.Nil(t, got.PendingBase64SHA256Password)
+
+	req = httptest.NewRequest(http.MethodPost, "/cs/cs001/password/reconnect", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
@@ -12,6 +12,10 @@ func (c ChargeStation) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func (c ChargeStationDeviceModel) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
 func (c ChargeStationSettings) Bind(r *http.Request) error {
 	return nil
 }
@@ -20,10 +24,30 @@ func (c ChargeStationInstallCertificates) Bind(r *http.Request) error {
 	return nil
 }
 
+func (c ChargeStationSettingsStatus) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
 func (c ChargeStationTrigger) Bind(r *http.Request) error {
 	return nil
 }
 
+func (c ChargeStationGetVariables) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationCertificatesStatus) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationTriggerStatus) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationVariables) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
 func (t Token) Bind(r *http.Request) error {
 	return nil
 }
@@ -59,3 +83,95 @@ func (t Transaction) Render(w http.ResponseWriter, r *http.Request) error {
 func (t ChargeStationRuntimeDetails) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
+
+func (t ConfigurationTemplate) Bind(r *http.Request) error {
+	return nil
+}
+
+func (t ConfigurationTemplate) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationReset) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationChangeAvailability) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationUnlockConnector) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationStopTransaction) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationTransactionStatus) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationOperation) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationLocalListSettings) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationLocalList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationReserveNow) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationReservation) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (d DisplayMessage) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationDisplayMessage) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (l LocationDisplayMessage) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (i IssuedCertificate) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationCertificateInventory) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c CertificateRevocation) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c RevokedCertificate) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationSecurityProfileUpgrade) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationSecurityProfileUpgradeStatus) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationSecurityProfileUpgradeReconnect) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationConnection) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Server) ReserveChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	req := new(ChargeStationReserveNow)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	now := s.clock.Now()
+	if !req.ExpiryDate.After(now) {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("expiry_date must be in the future")))
+		return
+	}
+
+	ocppVersion, callMaker, errResp := s.lookupChargeStationCallMaker(r, csId)
+	if errResp != nil {
+		_ = render.Render(w, r, errResp)
+		return
+	}
+
+	tokenType := "ISO14443"
+	if req.TokenType != nil {
+		tokenType = *req.TokenType
+	}
+	reservationId, err := handlers.NewReservationId(r.Context(), s.store, csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	reservation := &store.Reservation{
+		ChargeStationId: csId,
+		ReservationId:   reservationId,
+		EvseId:          req.EvseId,
+		IdToken:         req.IdToken,
+		TokenType:       tokenType,
+		GroupId:         req.GroupId,
+		ExpiryDate:      req.ExpiryDate,
+		Status:          store.ReservationStatusPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	ocppReq, err := handlers.NewReserveNowRequest(ocppVersion, reservation)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	err = s.store.SetReservation(r.Context(), reservation)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	err = callMaker.Send(r.Context(), csId, ocppReq)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.Render(w, r, newChargeStationReservation(reservation))
+}
+
+func (s *Server) ListChargeStationReservations(w http.ResponseWriter, r *http.Request, csId string) {
+	reservations, err := s.store.ListReservations(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, len(reservations))
+	for i, reservation := range reservations {
+		resp[i] = newChargeStationReservation(reservation)
+	}
+
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) LookupChargeStationReservation(w http.ResponseWriter, r *http.Request, csId string, reservationId int) {
+	reservation, err := s.store.LookupReservation(r.Context(), csId, reservationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if reservation == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, newChargeStationReservation(reservation))
+}
+
+func (s *Server) CancelChargeStationReservation(w http.ResponseWriter, r *http.Request, csId string, reservationId int) {
+	reservation, err := s.store.LookupReservation(r.Context(), csId, reservationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if reservation == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+	if !reservation.Status.IsActive() {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("reservation %d is %s", reservationId, reservation.Status)))
+		return
+	}
+
+	ocppVersion, callMaker, errResp := s.lookupChargeStationCallMaker(r, csId)
+	if errResp != nil {
+		_ = render.Render(w, r, errResp)
+		return
+	}
+	ocppReq, err := handlers.NewCancelReservationRequest(ocppVersion, reservationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	// the reservation is marked as cancelled when the charge station accepts the request
+	err = callMaker.Send(r.Context(), csId, ocppReq)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// lookupChargeStationCallMaker returns the OCPP version of the charge station and the call maker
+// used to send it calls, or the error to render if calls cannot be sent to the charge station
+func (s *Server) lookupChargeStationCallMaker(r *http.Request, csId string) (store.OcppVersion, handlers.SyncCallMaker, render.Renderer) {
+	details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
+	if err != nil {
+		return "", nil, ErrInternalError(err)
+	}
+	if details == nil {
+		return "", nil, ErrInvalidRequest(fmt.Errorf("charge station %s has not connected", csId))
+	}
+
+	var callMaker handlers.SyncCallMaker
+	switch details.OcppVersion {
+	case store.OcppVersion16:
+		callMaker = s.v16CallMaker
+	case store.OcppVersion201:
+		callMaker = s.v201CallMaker
+	default:
+		return "", nil, ErrInternalError(fmt.Errorf("unsupported ocpp version: %s", details.OcppVersion))
+	}
+	if callMaker == nil {
+		return "", nil, ErrInternalError(fmt.Errorf("unable to send calls to ocpp %s charge stations", details.OcppVersion))
+	}
+	return details.OcppVersion, callMaker, nil
+}
+
+func newChargeStationReservation(reservation *store.Reservation) ChargeStationReservation {
+	return ChargeStationReservation{
+		ReservationId:    reservation.ReservationId,
+		Reference:        reservation.Reference,
+		EvseId:           reservation.EvseId,
+		IdToken:          reservation.IdToken,
+		TokenType:        reservation.TokenType,
+		GroupId:          reservation.GroupId,
+		ExpiryDate:       reservation.ExpiryDate,
+		Status:           string(reservation.Status),
+		ErrorCode:        stringOrNil(reservation.ErrorCode),
+		ErrorDescription: stringOrNil(reservation.ErrorDescription),
+		TransactionId:    reservation.TransactionId,
+		CreatedAt:        reservation.CreatedAt,
+		UpdatedAt:        reservation.UpdatedAt,
+	}
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestReserveChargeStation(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, clock := setupServerWithCallMakers(t, nil, callMaker)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "2.0.1")
+
+	expiryDate := clock.Now().Add(time.Hour).Truncate(time.Second)
+	rr := postOperation(r, "/cs/cs001/reservations",
+		fmt.Sprintf(`{"evse_id":1,"id_token":"ABCD1234","group_id":"GROUP","expiry_date":"%s"}`, expiryDate.Format(time.RFC3339)))
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	var got api.ChargeStationReservation
+	err := json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+
+	evseId := 1
+	groupId := "GROUP"
+	assert.Equal(t, api.ChargeStationReservation{
+		ReservationId: got.ReservationId,
+		EvseId:        &evseId,
+		IdToken:       "ABCD1234",
+		TokenType:     "ISO14443",
+		GroupId:       &groupId,
+		ExpiryDate:    expiryDate,
+		Status:        "Pending",
+		CreatedAt:     clock.Now(),
+		UpdatedAt:     clock.Now(),
+	}, got)
+
+	assert.Equal(t, &ocpp201.ReserveNowRequestJson{
+		EvseId:         &evseId,
+		ExpiryDateTime: expiryDate.Format(time.RFC3339),
+		Id:             got.ReservationId,
+		IdToken:        ocpp201.IdTokenType{IdToken: "ABCD1234", Type: ocpp201.IdTokenEnumTypeISO14443},
+		GroupIdToken:   &ocpp201.IdTokenType{IdToken: "GROUP", Type: ocpp201.IdTokenEnumTypeCentral},
+	}, callMaker.request)
+
+	stored, err := engine.LookupReservation(context.Background(), "cs001", got.ReservationId)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, store.ReservationStatusPending, stored.Status)
+}
+
+func TestReserveChargeStationRejectsLongTokenForOcpp16(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, clock := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	expiryDate := clock.Now().Add(time.Hour)
+	rr := postOperation(r, "/cs/cs001/reservations",
+		fmt.Sprintf(`{"id_token":"ABCDEFGHIJKLMNOPQRSTUVWXYZ","expiry_date":"%s"}`, expiryDate.Format(time.RFC3339)))
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+	assert.Nil(t, callMaker.request)
+
+	reservations, err := engine.ListReservations(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Empty(t, reservations)
+}
+
+func TestReserveChargeStationRejectsPastExpiryDate(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, clock := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	expiryDate := clock.Now().Add(-time.Minute)
+	rr := postOperation(r, "/cs/cs001/reservations",
+		fmt.Sprintf(`{"id_token":"ABCD1234","expiry_date":"%s"}`, expiryDate.Format(time.RFC3339)))
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+	assert.Nil(t, callMaker.request)
+}
+
+func TestListAndLookupChargeStationReservations(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	now := clock.Now().Truncate(time.Second)
+	for _, id := range []int{2, 1} {
+		err := engine.SetReservation(context.Background(), &store.Reservation{
+			ChargeStationId: "cs001",
+			ReservationId:   id,
+			IdToken:         "ABCD1234",
+			TokenType:       "ISO14443",
+			ExpiryDate:      now.Add(time.Hour),
+			Status:          store.ReservationStatusAccepted,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/reservations", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var list []api.ChargeStationReservation
+	err := json.NewDecoder(rr.Body).Decode(&list)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, 1, list[0].ReservationId)
+	assert.Equal(t, 2, list[1].ReservationId)
+
+	req = httptest.NewRequest(http.MethodGet, "/cs/cs001/reservations/2", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStationReservation
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+	assert.Equal(t, api.ChargeStationReservation{
+		ReservationId: 2,
+		IdToken:       "ABCD1234",
+		TokenType:     "ISO14443",
+		ExpiryDate:    now.Add(time.Hour),
+		Status:        "Accepted",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, got)
+
+	req = httptest.NewRequest(http.MethodGet, "/cs/cs001/reservations/3", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestCancelChargeStationReservation(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	server, r, engine, clock := setupServerWithCallMakers(t, callMaker, nil)
+	defer server.Close()
+	setChargeStationOcppVersion(t, engine, "1.6")
+
+	for id, status := range map[int]store.ReservationStatus{
+		1: store.ReservationStatusAccepted,
+		2: store.ReservationStatusUsed,
+	} {
+		err := engine.SetReservation(context.Background(), &store.Reservation{
+			ChargeStationId: "cs001",
+			ReservationId:   id,
+			IdToken:         "ABCD1234",
+			TokenType:       "ISO14443",
+			ExpiryDate:      clock.Now().Add(time.Hour),
+			Status:          status,
+		})
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/cs/cs001/reservations/1", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+	assert.Equal(t, &ocpp16.CancelReservationJson{ReservationId: 1}, callMaker.request)
+
+	// the reservation remains active until the charge station accepts the request
+	stored, err := engine.LookupReservation(context.Background(), "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusAccepted, stored.Status)
+
+	callMaker.request = nil
+	req = httptest.NewRequest(http.MethodDelete, "/cs/cs001/reservations/2", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+	assert.Nil(t, callMaker.request)
+
+	req = httptest.NewRequest(http.MethodDelete, "/cs/cs001/reservations/3", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Server) RevokeCertificates(w http.ResponseWriter, r *http.Request) {
+	req := new(CertificateRevocation)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	if (req.CertificateHash == nil) == (req.ChargeStationId == nil) {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("one of certificate_hash or charge_station_id must be provided")))
+		return
+	}
+	reason := "unspecified"
+	if req.Reason != nil {
+		if _, err := services.RevocationReasonCode(*req.Reason); err != nil {
+			_ = render.Render(w, r, ErrInvalidRequest(err))
+			return
+		}
+		reason = *req.Reason
+	}
+
+	var certificates []*store.RevokedCertificate
+	if req.CertificateHash != nil {
+		certificates = append(certificates, &store.RevokedCertificate{
+			CertificateHash: normalizeCertificateHash(*req.CertificateHash),
+		})
+	} else {
+		issued, err := s.store.LookupChargeStationIssuedCertificates(r.Context(), *req.ChargeStationId)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		if issued == nil || len(issued.Certificates)+len(issued.Superseded) == 0 {
+			_ = render.Render(w, r, ErrNotFound)
+			return
+		}
+		// every certificate that has been issued to the charge station (and has not expired) is revoked
+		var issuedCertificates []*store.IssuedCertificate
+		for _, certificateType := range []store.CertificateType{store.CertificateTypeChargeStation, store.CertificateTypeEVCC} {
+			if certificate := issued.Certificates[certificateType]; certificate != nil {
+				issuedCertificates = append(issuedCertificates, certificate)
+			}
+		}
+		issuedCertificates = append(issuedCertificates, issued.Superseded...)
+		now := s.clock.Now()
+		for _, certificate := range issuedCertificates {
+			if !certificate.NotAfter.After(now) {
+				continue
+			}
+			// the certificate id is the hex encoded SHA-256 hash of the DER bytes
+			hash, err := hex.DecodeString(certificate.CertificateId)
+			if err != nil {
+				_ = render.Render(w, r, ErrInternalError(fmt.Errorf("decoding certificate id %s: %w", certificate.CertificateId, err)))
+				return
+			}
+			certificates = append(certificates, &store.RevokedCertificate{
+				CertificateHash: base64.RawURLEncoding.EncodeToString(hash),
+				ChargeStationId: *req.ChargeStationId,
+				SerialNumber:    certificate.SerialNumber,
+			})
+		}
+	}
+
+	resp := make([]render.Renderer, 0, len(certificates))
+	for _, certificate := range certificates {
+		revoked, err := s.revokeCertificate(r.Context(), certificate, reason)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		resp = append(resp, newRevokedCertificate(revoked))
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.RenderList(w, r, resp)
+}
+
+// revokeCertificate records the certificate as revoked, adding the serial number and issuer if
+// the certificate is in the certificate store. Certificates that have already been revoked are
+// left unchanged.
+func (s *Server) revokeCertificate(ctx context.Context, certificate *store.RevokedCertificate, reason string) (*store.RevokedCertificate, error) {
+	existing, err := s.store.LookupRevokedCertificate(ctx, certificate.CertificateHash)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	pemCertificate, err := s.store.LookupCertificate(ctx, certificate.CertificateHash)
+	if err != nil {
+		return nil, err
+	}
+	if pemCertificate != "" {
+		block, _ := pem.Decode([]byte(pemCertificate))
+		if block != nil && block.Type == "CERTIFICATE" {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parsing certificate %s: %w", certificate.CertificateHash, err)
+			}
+			certificate.SerialNumber = cert.SerialNumber.Text(16)
+			certificate.Issuer = cert.Issuer.String()
+		}
+	}
+
+	certificate.Reason = reason
+	certificate.RevokedAt = s.clock.Now()
+	err = s.store.SetRevokedCertificate(ctx, certificate)
+	if err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+func (s *Server) ListRevokedCertificates(w http.ResponseWriter, r *http.Request) {
+	var resp = make([]render.Renderer, 0)
+	var previousCertificateHash string
+	for {
+		revokedCertificates, err := s.store.ListRevokedCertificates(r.Context(), 50, previousCertificateHash)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		for _, certificate := range revokedCertificates {
+			resp = append(resp, newRevokedCertificate(certificate))
+		}
+		if len(revokedCertificates) < 50 {
+			break
+		}
+		previousCertificateHash = revokedCertificates[len(revokedCertificates)-1].CertificateHash
+	}
+
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) GetCertificateRevocationList(w http.ResponseWriter, r *http.Request) {
+	if s.crlProvider == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	crl, err := s.crlProvider.ProvideCertificateRevocationList(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.Header().Set("content-type", "application/pkix-crl")
+	_, _ = w.Write(crl)
+}
+
+// normalizeCertificateHash converts a base64 encoded hash to the URL encoding without padding
+// that is used to identify certificates in the store
+func normalizeCertificateHash(certificateHash string) string {
+	certificateHash = strings.ReplaceAll(certificateHash, "+", "-")
+	certificateHash = strings.ReplaceAll(certificateHash, "/", "_")
+	return strings.TrimRight(certificateHash, "=")
+}
+
+func newRevokedCertificate(certificate *store.RevokedCertificate) RevokedCertificate {
+	return RevokedCertificate{
+		CertificateHash: certificate.CertificateHash,
+		ChargeStationId: stringOrNil(certificate.ChargeStationId),
+		SerialNumber:    stringOrNil(certificate.SerialNumber),
+		Issuer:          stringOrNil(certificate.Issuer),
+		Reason:          certificate.Reason,
+		RevokedAt:       certificate.RevokedAt,
+	}
+}
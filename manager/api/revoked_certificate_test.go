@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+func TestRevokeCertificateByHash(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	cert := generateCertificate(t)
+	err := engine.SetCertificate(context.Background(), string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+	require.NoError(t, err)
+	b64Hash := getCertificateHash(cert)
+
+	req := httptest.NewRequest(http.MethodPost, "/revoked-certificate",
+		strings.NewReader(fmt.Sprintf(`{"certificate_hash":"%s","reason":"keyCompromise"}`, b64Hash)))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	var got []api.RevokedCertificate
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	require.NoError(t, err)
+	serialNumber := cert.SerialNumber.Text(16)
+	issuer := cert.Issuer.String()
+	want := []api.RevokedCertificate{
+		{
+			CertificateHash: b64Hash,
+			SerialNumber:    &serialNumber,
+			Issuer:          &issuer,
+			Reason:          "keyCompromise",
+			RevokedAt:       clock.Now(),
+		},
+	}
+	assert.Equal(t, want, got)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/certificate/%s", b64Hash), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusGone, rr.Result().StatusCode)
+}
+
+func TestRevokeUnknownCertificateByHash(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	// the hash may be provided using the standard base64 encoding
+	req := httptest.NewRequest(http.MethodPost, "/revoked-certificate",
+		strings.NewReader(`{"certificate_hash":"ab+/cd=="}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	got, err := engine.LookupRevokedCertificate(context.Background(), "ab-_cd")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "unspecified", got.Reason)
+	assert.Equal(t, "", got.SerialNumber)
+}
+
+func TestRevokeCertificatesByChargeStation(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	cert := generateCertificate(t)
+	err := engine.SetCertificate(context.Background(), string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+	require.NoError(t, err)
+	hash := sha256.Sum256(cert.Raw)
+	supersededCert := generateCertificate(t)
+	supersededHash := sha256.Sum256(supersededCert.Raw)
+	expiredCert := generateCertificate(t)
+	expiredHash := sha256.Sum256(expiredCert.Raw)
+	err = engine.SetChargeStationIssuedCertificates(context.Background(), "cs001", &store.ChargeStationIssuedCertificates{
+		Certificates: map[store.CertificateType]*store.IssuedCertificate{
+			store.CertificateTypeChargeStation: {
+				CertificateType: store.CertificateTypeChargeStation,
+				CertificateId:   hex.EncodeToString(hash[:]),
+				SerialNumber:    cert.SerialNumber.Text(16),
+				NotAfter:        clock.Now().Add(365 * 24 * time.Hour),
+			},
+		},
+		Superseded: []*store.IssuedCertificate{
+			{
+				CertificateType: store.CertificateTypeChargeStation,
+				CertificateId:   hex.EncodeToString(supersededHash[:]),
+				SerialNumber:    supersededCert.SerialNumber.Text(16),
+				NotAfter:        clock.Now().Add(30 * 24 * time.Hour),
+			},
+			{
+				CertificateType: store.CertificateTypeChargeStation,
+				CertificateId:   hex.EncodeToString(expiredHash[:]),
+				SerialNumber:    expiredCert.SerialNumber.Text(16),
+				NotAfter:        clock.Now().Add(-time.Hour),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/revoked-certificate", strings.NewReader(`{"charge_station_id":"cs001"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	got, err := engine.LookupRevokedCertificate(context.Background(), getCertificateHash(cert))
+	require.NoError(t, err)
+	want := &store.RevokedCertificate{
+		CertificateHash: getCertificateHash(cert),
+		ChargeStationId: "cs001",
+		SerialNumber:    cert.SerialNumber.Text(16),
+		Issuer:          cert.Issuer.String(),
+		Reason:          "unspecified",
+		RevokedAt:       clock.Now(),
+	}
+	assert.Equal(t, want, got)
+
+	// the superseded certificate is not in the certificate store: so the issuer is unknown
+	got, err = engine.LookupRevokedCertificate(context.Background(), getCertificateHash(supersededCert))
+	require.NoError(t, err)
+	want = &store.RevokedCertificate{
+		CertificateHash: getCertificateHash(supersededCert),
+		ChargeStationId: "cs001",
+		SerialNumber:    supersededCert.SerialNumber.Text(16),
+		Reason:          "unspecified",
+		RevokedAt:       clock.Now(),
+	}
+	assert.Equal(t, want, got)
+
+	// the expired certificate can't be used to connect so it is not revoked
+	got, err = engine.LookupRevokedCertificate(context.Background(), getCertificateHash(expiredCert))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	req = httptest.NewRequest(http.MethodGet, "/revoked-certificate", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var list []api.RevokedCertificate
+	err = json.NewDecoder(rr.Body).Decode(&list)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "cs001", *list[0].ChargeStationId)
+	assert.Equal(t, "cs001", *list[1].ChargeStationId)
+}
+
+func TestRevokeCertificatesForUnknownChargeStation(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/revoked-certificate", strings.NewReader(`{"charge_station_id":"cs001"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestRevokeCertificatesWithInvalidRequest(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	for _, body := range []string{
+		`{}`,
+		`{"certificate_hash":"abc","charge_station_id":"cs001"}`,
+		`{"certificate_hash":"abc","reason":"bored"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/revoked-certificate", strings.NewReader(body))
+		req.Header.Set("content-type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode, body)
+	}
+}
+
+type fakeCertificateRevocationListProvider struct{}
+
+func (fakeCertificateRevocationListProvider) ProvideCertificateRevocationList(context.Context) ([]byte, error) {
+	return []byte("crl"), nil
+}
+
+func TestGetCertificateRevocationList(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	srv, err := api.NewServer(engine, clockTest.NewFakePassiveClock(time.Now()), nil, nil, nil, fakeCertificateRevocationListProvider{}, nil)
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Mount("/", api.Handler(srv))
+
+	req := httptest.NewRequest(http.MethodGet, "/revoked-certificate/crl", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, "application/pkix-crl", rr.Result().Header.Get("content-type"))
+	assert.Equal(t, "crl", rr.Body.String())
+}
+
+func TestGetCertificateRevocationListWithoutProvider(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/revoked-certificate/crl", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
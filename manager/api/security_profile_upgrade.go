@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Server) UpgradeChargeStationSecurityProfile(w http.ResponseWriter, r *http.Request, csId string) {
+	req := new(ChargeStationSecurityProfileUpgrade)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	cs, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if cs == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+	details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if details == nil {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("charge station has not connected: OCPP version is unknown")))
+		return
+	}
+
+	toSecurityProfile := store.SecurityProfile(req.SecurityProfile)
+	if toSecurityProfile <= cs.SecurityProfile || toSecurityProfile > store.TLSWithClientSideCertificates {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("cannot upgrade from security profile %d to %d", cs.SecurityProfile, toSecurityProfile)))
+		return
+	}
+
+	now := s.clock.Now()
+	upgrade := &store.ChargeStationSecurityProfileUpgrade{
+		ChargeStationId:       csId,
+		FromSecurityProfile:   cs.SecurityProfile,
+		ToSecurityProfile:     toSecurityProfile,
+		Step:                  store.SecurityProfileUpgradeStepSignCertificate,
+		ClientCertificateHash: cs.ClientCertificateHash,
+		ConfigurationSlot:     1,
+		SendAfter:             now,
+		RequestedAt:           now,
+		UpdatedAt:             now,
+	}
+
+	if cs.SecurityProfile == store.UnsecuredTransportWithBasicAuth {
+		if req.CsmsRootCertificate == nil {
+			_ = render.Render(w, r, ErrInvalidRequest(errors.New("csms_root_certificate is required when upgrading from unsecured transport")))
+			return
+		}
+		if block, _ := pem.Decode([]byte(*req.CsmsRootCertificate)); block == nil || block.Type != "CERTIFICATE" {
+			_ = render.Render(w, r, ErrInvalidRequest(errors.New("csms_root_certificate must be a PEM encoded certificate")))
+			return
+		}
+		upgrade.CsmsRootCertificate = *req.CsmsRootCertificate
+		upgrade.Step = store.SecurityProfileUpgradeStepInstallRootCertificate
+	}
+
+	if details.OcppVersion == store.OcppVersion201 {
+		if req.OcppCsmsUrl == nil {
+			_ = render.Render(w, r, ErrInvalidRequest(errors.New("ocpp_csms_url is required for OCPP 2.0.1 charge stations")))
+			return
+		}
+		if req.OcppInterface == nil {
+			_ = render.Render(w, r, ErrInvalidRequest(errors.New("ocpp_interface is required for OCPP 2.0.1 charge stations")))
+			return
+		}
+		if req.MessageTimeout == nil || *req.MessageTimeout <= 0 {
+			_ = render.Render(w, r, ErrInvalidRequest(errors.New("a positive message_timeout is required for OCPP 2.0.1 charge stations")))
+			return
+		}
+		upgrade.OcppCsmsUrl = *req.OcppCsmsUrl
+		upgrade.OcppInterface = string(*req.OcppInterface)
+		upgrade.MessageTimeout = *req.MessageTimeout
+		if req.ConfigurationSlot != nil {
+			upgrade.ConfigurationSlot = *req.ConfigurationSlot
+		}
+	}
+
+	err = s.store.SetChargeStationSecurityProfileUpgrade(r.Context(), csId, upgrade)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) LookupChargeStationSecurityProfileUpgrade(w http.ResponseWriter, r *http.Request, csId string) {
+	upgrade, err := s.store.LookupChargeStationSecurityProfileUpgrade(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if upgrade == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, ChargeStationSecurityProfileUpgradeStatus{
+		FromSecurityProfile: int(upgrade.FromSecurityProfile),
+		ToSecurityProfile:   int(upgrade.ToSecurityProfile),
+		Step:                string(upgrade.Step),
+		ErrorDescription:    stringOrNil(upgrade.ErrorDescription),
+		RequestedAt:         upgrade.RequestedAt,
+		UpdatedAt:           upgrade.UpdatedAt,
+	})
+}
+
+func (s *Server) ReconnectChargeStationSecurityProfileUpgrade(w http.ResponseWriter, r *http.Request, csId string) {
+	req := new(ChargeStationSecurityProfileUpgradeReconnect)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	upgrade, err := s.store.LookupChargeStationSecurityProfileUpgrade(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if upgrade == nil || !awaitingSecurityProfileUpgradeReconnect(upgrade) {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+	if store.SecurityProfile(req.SecurityProfile) != upgrade.ToSecurityProfile {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("charge station is being upgraded to security profile %d", upgrade.ToSecurityProfile)))
+		return
+	}
+
+	cs, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if cs == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+	cs.SecurityProfile = upgrade.ToSecurityProfile
+	err = s.store.UpdateChargeStation(r.Context(), csId, cs)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	upgrade.Step = store.SecurityProfileUpgradeStepCompleted
+	upgrade.UpdatedAt = s.clock.Now()
+	err = s.store.SetChargeStationSecurityProfileUpgrade(r.Context(), csId, upgrade)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pendingSecurityProfile returns the security profile that the charge station is being upgraded
+// to, or nil if there is no upgrade in progress
+func (s *Server) pendingSecurityProfile(r *http.Request, csId string) (*int, error) {
+	upgrade, err := s.store.LookupChargeStationSecurityProfileUpgrade(r.Context(), csId)
+	if err != nil {
+		return nil, err
+	}
+	if upgrade == nil || upgrade.Step == store.SecurityProfileUpgradeStepCompleted || upgrade.Step == store.SecurityProfileUpgradeStepFailed {
+		return nil, nil
+	}
+	securityProfile := int(upgrade.ToSecurityProfile)
+	return &securityProfile, nil
+}
+
+// awaitingSecurityProfileUpgradeReconnect returns true once the charge station has been sent the
+// new security profile: it may reconnect using the new security profile from that point
+func awaitingSecurityProfileUpgradeReconnect(upgrade *store.ChargeStationSecurityProfileUpgrade) bool {
+	switch upgrade.Step {
+	case store.SecurityProfileUpgradeStepSetNetworkProfile,
+		store.SecurityProfileUpgradeStepSetNetworkConfigurationPriority,
+		store.SecurityProfileUpgradeStepAwaitReconnect:
+		return true
+	}
+	return false
+}
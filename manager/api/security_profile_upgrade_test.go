@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestUpgradeChargeStationSecurityProfileFromUnsecuredTransport(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:                   "cs001",
+		SecurityProfile:      store.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "DEADBEEF",
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{OcppVersion: "1.6"})
+	require.NoError(t, err)
+
+	rootCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: generateCertificate(t).Raw}))
+	body, err := json.Marshal(map[string]any{
+		"security_profile":      1,
+		"csms_root_certificate": rootCert,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/security-profile-upgrade", strings.NewReader(string(body)))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	got, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, store.UnsecuredTransportWithBasicAuth, got.FromSecurityProfile)
+	assert.Equal(t, store.TLSWithBasicAuth, got.ToSecurityProfile)
+	assert.Equal(t, store.SecurityProfileUpgradeStepInstallRootCertificate, got.Step)
+	assert.Equal(t, rootCert, got.CsmsRootCertificate)
+	assert.Empty(t, got.Base64SHA256Password)
+	assert.Equal(t, clock.Now(), got.RequestedAt)
+
+	req = httptest.NewRequest(http.MethodGet, "/cs/cs001/security-profile-upgrade", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var status api.ChargeStationSecurityProfileUpgradeStatus
+	err = json.NewDecoder(rr.Body).Decode(&status)
+	require.NoError(t, err)
+	assert.Equal(t, api.ChargeStationSecurityProfileUpgradeStatus{
+		FromSecurityProfile: 0,
+		ToSecurityProfile:   1,
+		Step:                "InstallRootCertificate",
+		RequestedAt:         clock.Now(),
+		UpdatedAt:           clock.Now(),
+	}, status)
+
+	req = httptest.NewRequest(http.MethodGet, "/cs/cs001", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var cs api.ChargeStation
+	err = json.NewDecoder(rr.Body).Decode(&cs)
+	require.NoError(t, err)
+	require.NotNil(t, cs.PendingSecurityProfile)
+	assert.Equal(t, 1, *cs.PendingSecurityProfile)
+}
+
+func TestUpgradeChargeStationSecurityProfileValidation(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:              "cs001",
+		SecurityProfile: store.UnsecuredTransportWithBasicAuth,
+	})
+	require.NoError(t, err)
+	err = engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:              "cs002",
+		SecurityProfile: store.TLSWithBasicAuth,
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationRuntimeDetails(ctx, "cs002", &store.ChargeStationRuntimeDetails{OcppVersion: "2.0.1"})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		csId string
+		body string
+		want int
+	}{
+		"unknown charge station": {
+			csId: "unknown",
+			body: `{"security_profile":2}`,
+			want: http.StatusNotFound,
+		},
+		"no runtime details": {
+			csId: "cs001",
+			body: `{"security_profile":2}`,
+			want: http.StatusBadRequest,
+		},
+		"not an upgrade": {
+			csId: "cs002",
+			body: `{"security_profile":1,"ocpp_csms_url":"wss://csms.example.com/ws"}`,
+			want: http.StatusBadRequest,
+		},
+		"unknown security profile": {
+			csId: "cs002",
+			body: `{"security_profile":3,"ocpp_csms_url":"wss://csms.example.com/ws"}`,
+			want: http.StatusBadRequest,
+		},
+		"missing csms url": {
+			csId: "cs002",
+			body: `{"security_profile":2,"ocpp_interface":"Wired0","message_timeout":30}`,
+			want: http.StatusBadRequest,
+		},
+		"missing ocpp interface": {
+			csId: "cs002",
+			body: `{"security_profile":2,"ocpp_csms_url":"wss://csms.example.com/ws","message_timeout":30}`,
+			want: http.StatusBadRequest,
+		},
+		"missing message timeout": {
+			csId: "cs002",
+			body: `{"security_profile":2,"ocpp_csms_url":"wss://csms.example.com/ws","ocpp_interface":"Wired0"}`,
+			want: http.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/cs/%s/security-profile-upgrade", tc.csId), strings.NewReader(tc.body))
+			req.Header.Set("content-type", "application/json")
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, tc.want, rr.Result().StatusCode)
+		})
+	}
+
+	got, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestUpgradeChargeStationSecurityProfileToClientCertificates(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:                    "cs001",
+		SecurityProfile:       store.TLSWithBasicAuth,
+		ClientCertificateHash: "previous-hash",
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{OcppVersion: "2.0.1"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/security-profile-upgrade",
+		strings.NewReader(`{"security_profile":2,"ocpp_csms_url":"wss://csms.example.com/ws","configuration_slot":2,"ocpp_interface":"Wireless0","message_timeout":45}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	got, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, store.SecurityProfileUpgradeStepSignCertificate, got.Step)
+	assert.Equal(t, "previous-hash", got.ClientCertificateHash)
+	assert.Equal(t, "wss://csms.example.com/ws", got.OcppCsmsUrl)
+	assert.Equal(t, 2, got.ConfigurationSlot)
+	assert.Equal(t, "Wireless0", got.OcppInterface)
+	assert.Equal(t, 45, got.MessageTimeout)
+	assert.Empty(t, got.Base64SHA256Password)
+	assert.Empty(t, got.CsmsRootCertificate)
+}
+
+func TestReconnectChargeStationSecurityProfileUpgrade(t *testing.T) {
+	server, r, engine, clock := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:              "cs001",
+		SecurityProfile: store.TLSWithBasicAuth,
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", &store.ChargeStationSecurityProfileUpgrade{
+		ChargeStationId:     "cs001",
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepAwaitReconnect,
+	})
+	require.NoError(t, err)
+
+	// reconnecting with a different security profile is rejected
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/security-profile-upgrade/reconnect", strings.NewReader(`{"security_profile":1}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodPost, "/cs/cs001/security-profile-upgrade/reconnect", strings.NewReader(`{"security_profile":2}`))
+	req.Header.Set("content-type", "application/json")
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	cs, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.TLSWithClientSideCertificates, cs.SecurityProfile)
+
+	upgrade, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepCompleted, upgrade.Step)
+	assert.Equal(t, clock.Now(), upgrade.UpdatedAt)
+
+	// the upgrade is no longer waiting for the charge station to reconnect
+	req = httptest.NewRequest(http.MethodPost, "/cs/cs001/security-profile-upgrade/reconnect", strings.NewReader(`{"security_profile":2}`))
+	req.Header.Set("content-type", "application/json")
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
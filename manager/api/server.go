@@ -10,27 +10,42 @@ import (
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"k8s.io/utils/clock"
 )
 
 type Server struct {
-	store   store.Engine
-	clock   clock.PassiveClock
-	swagger *openapi3.T
-	ocpi    ocpi.Api
+	store         store.Engine
+	clock         clock.PassiveClock
+	swagger       *openapi3.T
+	ocpi          ocpi.Api
+	v16CallMaker  handlers.SyncCallMaker
+	v201CallMaker handlers.SyncCallMaker
+	crlProvider   services.CertificateRevocationListProvider
+	controller    transport.ConnectionController
 }
 
-func NewServer(engine store.Engine, clock clock.PassiveClock, ocpi ocpi.Api) (*Server, error) {
+// NewServer creates the API server. The call makers are used by operations that wait for
+// the charge station to respond: they may be nil in which case those operations will fail.
+// The CRL provider is nil if the charge station certificate provider does not issue CRLs and the
+// connection controller is nil if charge stations cannot be disconnected.
+func NewServer(engine store.Engine, clock clock.PassiveClock, ocpi ocpi.Api, v16CallMaker, v201CallMaker handlers.SyncCallMaker, crlProvider services.CertificateRevocationListProvider, controller transport.ConnectionController) (*Server, error) {
 	swagger, err := GetSwagger()
 	if err != nil {
 		return nil, err
 	}
 	return &Server{
-		store:   engine,
-		clock:   clock,
-		ocpi:    ocpi,
-		swagger: swagger,
+		store:         engine,
+		clock:         clock,
+		ocpi:          ocpi,
+		swagger:       swagger,
+		v16CallMaker:  v16CallMaker,
+		v201CallMaker: v201CallMaker,
+		crlProvider:   crlProvider,
+		controller:    controller,
 	}, nil
 }
 
@@ -1,6 +1,8 @@
 package api_test
 
 import (
+	"context"
+	"encoding/json"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -8,20 +10,31 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"k8s.io/utils/clock"
 	clockTest "k8s.io/utils/clock/testing"
 )
 
 func setupServer(t *testing.T) (*httptest.Server, *chi.Mux, store.Engine, clock.PassiveClock) {
+	return setupServerWithCallMakers(t, nil, nil)
+}
+
+func setupServerWithCallMakers(t *testing.T, v16CallMaker, v201CallMaker handlers.SyncCallMaker) (*httptest.Server, *chi.Mux, store.Engine, clock.PassiveClock) {
+	return setupServerWithConnectionController(t, v16CallMaker, v201CallMaker, nil)
+}
+
+func setupServerWithConnectionController(t *testing.T, v16CallMaker, v201CallMaker handlers.SyncCallMaker, controller transport.ConnectionController) (*httptest.Server, *chi.Mux, store.Engine, clock.PassiveClock) {
 	engine := inmemory.NewStore(clock.RealClock{})
 	ocpiApi := ocpi.NewOCPI(engine, nil, "GB", "TWK")
 
 	now := time.Now().UTC()
 	c := clockTest.NewFakePassiveClock(now)
-	srv, err := api.NewServer(engine, c, ocpiApi)
+	srv, err := api.NewServer(engine, c, ocpiApi, v16CallMaker, v201CallMaker, nil, controller)
 	require.NoError(t, err)
 
 	r := chi.NewRouter()
@@ -30,3 +43,23 @@ func setupServer(t *testing.T) (*httptest.Server, *chi.Mux, store.Engine, clock.
 
 	return server, r, engine, c
 }
+
+// fakeSyncCallMaker records the request and responds with the configured response or error
+type fakeSyncCallMaker struct {
+	request  ocpp.Request
+	response string
+	err      error
+}
+
+func (f *fakeSyncCallMaker) Send(_ context.Context, _ string, request ocpp.Request) error {
+	f.request = request
+	return f.err
+}
+
+func (f *fakeSyncCallMaker) SendAndWait(_ context.Context, _ string, request ocpp.Request, response ocpp.Response) error {
+	f.request = request
+	if f.err != nil {
+		return f.err
+	}
+	return json.Unmarshal([]byte(f.response), response)
+}
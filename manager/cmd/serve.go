@@ -6,6 +6,7 @@ import (
 	"context"
 	"github.com/spf13/cobra"
 	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/server"
 	"github.com/thoughtworks/maeve-csms/manager/sync"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
@@ -44,9 +45,12 @@ the gateway and send appropriate responses.`,
 		}()
 
 		apiServer := server.New("api", cfg.Api.Addr, nil,
-			server.NewApiHandler(settings.Api, settings.Storage, settings.OcpiApi, settings.ChargeStationCertProviderService))
+			server.NewApiHandler(settings.Api, settings.Storage, settings.OcpiApi, settings.ChargeStationCertProviderService,
+				settings.Ocpp16CallMaker, settings.Ocpp201CallMaker, settings.ConnectionController))
 
-		sync.Sync(settings.Storage, clock.RealClock{}, settings.Tracer, settings.MsgEmitter)
+		sync.Sync(settings.Storage, clock.RealClock{}, settings.Tracer, settings.MsgEmitter,
+			settings.Sync.DriftCheckInterval, settings.Sync.ReapplyDriftedSettings, settings.Sync.CertRenewalPeriod,
+			settings.Sync.CertificateAuditInterval, settings.Sync.ExpectedRootCertificates)
 
 		errCh := make(chan error, 1)
 		apiServer.Start(errCh)
@@ -66,6 +70,31 @@ the gateway and send appropriate responses.`,
 			}
 		}
 
+		var correlationConnections []transport.Connection
+		for version, enabled := range map[transport.OcppVersion]bool{
+			transport.OcppVersion16:  settings.Ocpp16Handler != nil,
+			transport.OcppVersion201: settings.Ocpp201Handler != nil,
+		} {
+			if !enabled {
+				continue
+			}
+			conn, err := settings.MsgCorrelationListener.Connect(context.Background(), version, nil, settings.CallCorrelator)
+			if err != nil {
+				errCh <- err
+				continue
+			}
+			correlationConnections = append(correlationConnections, conn)
+		}
+
+		var connectionEventsConnection transport.Connection
+		if settings.ConnectionEventListener != nil {
+			connectionEventsConnection, err = settings.ConnectionEventListener.ConnectConnectionEvents(context.Background(),
+				handlers.ConnectionEventHandler{Store: settings.Storage, Clock: clock.RealClock{}})
+			if err != nil {
+				errCh <- err
+			}
+		}
+
 		if settings.OcpiApi != nil {
 			ocpiServer := server.New("ocpi", cfg.Ocpi.Addr, nil, server.NewOcpiHandler(settings.Storage, clock.RealClock{}, settings.OcpiApi, settings.MsgEmitter))
 			ocpiServer.Start(errCh)
@@ -86,6 +115,20 @@ the gateway and send appropriate responses.`,
 			}
 		}
 
+		for _, conn := range correlationConnections {
+			err := conn.Disconnect(context.Background())
+			if err != nil {
+				slog.Warn("disconnecting from broker", "err", err)
+			}
+		}
+
+		if connectionEventsConnection != nil {
+			err := connectionEventsConnection.Disconnect(context.Background())
+			if err != nil {
+				slog.Warn("disconnecting from broker", "err", err)
+			}
+		}
+
 		return err
 	},
 }
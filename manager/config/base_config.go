@@ -23,6 +23,7 @@ type BaseConfig struct {
 	ChargeStationCertProvider ChargeStationCertProviderConfig `mapstructure:"charge_station_cert_provider" toml:"charge_station_cert_provider" validate:"required"`
 	TariffService             TariffServiceConfig             `mapstructure:"tariff_service" toml:"tariff_service" validate:"required"`
 	Ocpi                      *OcpiConfig                     `mapstructure:"ocpi,omitempty" toml:"ocpi,omitempty"`
+	CertificateAudit          *CertificateAuditConfig         `mapstructure:"certificate_audit,omitempty" toml:"certificate_audit,omitempty"`
 }
 
 // DefaultConfig provides the default configuration. The configuration
@@ -47,9 +48,12 @@ var DefaultConfig = BaseConfig{
 		},
 	},
 	Ocpp: OcppSettingsConfig{
-		HeartbeatInterval: "5m",
-		Ocpp16Enabled:     true,
-		Ocpp201Enabled:    true,
+		HeartbeatInterval:  "5m",
+		Ocpp16Enabled:      true,
+		Ocpp201Enabled:     true,
+		DriftCheckInterval: "1h",
+		CertRenewalPeriod:  "720h",
+		CostUpdateInterval: "30s",
 	},
 	Observability: ObservabilitySettingsConfig{
 		LogFormat: "text",
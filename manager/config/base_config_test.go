@@ -35,9 +35,12 @@ func TestParseConfig(t *testing.T) {
 			},
 		},
 		Ocpp: config.OcppSettingsConfig{
-			HeartbeatInterval: "10m",
-			Ocpp16Enabled:     false,
-			Ocpp201Enabled:    true,
+			HeartbeatInterval:  "10m",
+			Ocpp16Enabled:      false,
+			Ocpp201Enabled:     true,
+			DriftCheckInterval: "1h",
+			CertRenewalPeriod:  "720h",
+			CostUpdateInterval: "30s",
 		},
 		Observability: config.ObservabilitySettingsConfig{
 			LogFormat:         "text",
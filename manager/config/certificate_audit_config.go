@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type CertificateAuditConfig struct {
+	Interval      string                  `mapstructure:"interval" toml:"interval" validate:"required"`
+	V2GRootCerts  *RootCertProviderConfig `mapstructure:"v2g_root_certs,omitempty" toml:"v2g_root_certs,omitempty"`
+	MORootCerts   *RootCertProviderConfig `mapstructure:"mo_root_certs,omitempty" toml:"mo_root_certs,omitempty"`
+	CSMSRootCerts *RootCertProviderConfig `mapstructure:"csms_root_certs,omitempty" toml:"csms_root_certs,omitempty"`
+}
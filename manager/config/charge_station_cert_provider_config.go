@@ -15,7 +15,8 @@ type LocalSourceConfig struct {
 
 type LocalChargeStationCertProviderConfig struct {
 	CertificateSource *LocalSourceConfig `mapstructure:"cert" toml:"cert" validate:"required"`
-	PrivateKeySource  *LocalSourceConfig `mapstructure:"key" toml:"key" validate:"required"`
+	PrivateKeySource  *LocalSourceConfig `mapstructure:"key,omitempty" toml:"key,omitempty" validate:"required_without=Signer"`
+	Signer            *SignerConfig      `mapstructure:"signer,omitempty" toml:"signer,omitempty"`
 }
 
 type DelegatingChargeStationCertProviderConfig struct {
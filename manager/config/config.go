@@ -4,14 +4,18 @@ package config
 
 import (
 	"context"
+	"crypto"
 	"crypto/tls"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/subnova/slog-exporter/slogtrace"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
@@ -46,13 +50,29 @@ type ApiSettings struct {
 	OrgName string
 }
 
+type SyncSettings struct {
+	DriftCheckInterval     time.Duration
+	ReapplyDriftedSettings bool
+	CertRenewalPeriod      time.Duration
+	// CertificateAuditInterval is zero if the certificate audit is disabled
+	CertificateAuditInterval time.Duration
+	ExpectedRootCertificates map[store.CertificateType]services.RootCertificateProviderService
+}
+
 type Config struct {
 	Api                              ApiSettings
+	Sync                             SyncSettings
 	Tracer                           oteltrace.Tracer
 	TracerProvider                   *trace.TracerProvider
 	Storage                          store.Engine
 	MsgEmitter                       transport.Emitter
 	MsgListener                      transport.Listener
+	MsgCorrelationListener           transport.Listener
+	CallCorrelator                   *handlers.CallCorrelator
+	ConnectionEventListener          transport.ConnectionEventListener
+	ConnectionController             transport.ConnectionController
+	Ocpp16CallMaker                  handlers.SyncCallMaker
+	Ocpp201CallMaker                 handlers.SyncCallMaker
 	Ocpp16Handler                    transport.MessageHandler
 	Ocpp201Handler                   transport.MessageHandler
 	ContractCertValidationService    services.CertificateValidationService
@@ -73,6 +93,30 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, fmt.Errorf("failed to parse heartbeat interval: %s", err)
 	}
 
+	var driftCheckInterval time.Duration
+	if cfg.Ocpp.DriftCheckInterval != "" {
+		driftCheckInterval, err = time.ParseDuration(cfg.Ocpp.DriftCheckInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse drift check interval: %s", err)
+		}
+	}
+
+	var certRenewalPeriod time.Duration
+	if cfg.Ocpp.CertRenewalPeriod != "" {
+		certRenewalPeriod, err = time.ParseDuration(cfg.Ocpp.CertRenewalPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cert renewal period: %s", err)
+		}
+	}
+
+	var costUpdateInterval time.Duration
+	if cfg.Ocpp.CostUpdateInterval != "" {
+		costUpdateInterval, err = time.ParseDuration(cfg.Ocpp.CostUpdateInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cost update interval: %s", err)
+		}
+	}
+
 	c = &Config{
 		Api: ApiSettings{
 			Addr:    cfg.Api.Addr,
@@ -81,6 +125,11 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 			WssPort: cfg.Api.WssPort,
 			OrgName: cfg.Api.OrgName,
 		},
+		Sync: SyncSettings{
+			DriftCheckInterval:     driftCheckInterval,
+			ReapplyDriftedSettings: cfg.Ocpp.ReapplyDriftedSettings,
+			CertRenewalPeriod:      certRenewalPeriod,
+		},
 	}
 
 	switch cfg.Observability.LogFormat {
@@ -109,12 +158,12 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
-	c.ContractCertValidationService, err = getContractCertValidator(&cfg.ContractCertValidator, httpClient)
+	c.ContractCertValidationService, err = getContractCertValidator(&cfg.ContractCertValidator, c.Storage, httpClient)
 	if err != nil {
 		return nil, err
 	}
 
-	c.ContractCertProviderService, err = getContractCertProvider(&cfg.ContractCertProvider, httpClient)
+	c.ContractCertProviderService, err = getContractCertProvider(ctx, &cfg.ContractCertProvider, c.Storage, httpClient)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +188,21 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
+	c.MsgCorrelationListener, err = getMsgCorrelationListener(&cfg.Transport, c.Tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	c.CallCorrelator = handlers.NewCallCorrelator()
+
+	// the connection events and forced disconnects are only supported by some transports
+	if listener, ok := c.MsgListener.(transport.ConnectionEventListener); ok {
+		c.ConnectionEventListener = listener
+	}
+	if controller, ok := c.MsgEmitter.(transport.ConnectionController); ok {
+		c.ConnectionController = controller
+	}
+
 	if cfg.Ocpp.Ocpp16Enabled {
 		c.Ocpp16Handler = ocpp16.NewRouter(c.MsgEmitter,
 			clock.RealClock{},
@@ -148,6 +212,9 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 			c.ContractCertProviderService,
 			heartbeatInterval,
 			schemas.OcppSchemas)
+		callMaker := ocpp16.NewCallMaker(c.MsgEmitter)
+		callMaker.Correlator = c.CallCorrelator
+		c.Ocpp16CallMaker = callMaker
 	}
 	if cfg.Ocpp.Ocpp201Enabled {
 		c.Ocpp201Handler = ocpp201.NewRouter(c.MsgEmitter,
@@ -158,7 +225,11 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 			c.ChargeStationCertProviderService,
 			c.ContractCertProviderService,
 			heartbeatInterval,
+			costUpdateInterval,
 			schemas.OcppSchemas)
+		callMaker := ocpp201.NewCallMaker(c.MsgEmitter)
+		callMaker.Correlator = c.CallCorrelator
+		c.Ocpp201CallMaker = callMaker
 	}
 
 	if cfg.Ocpi != nil {
@@ -168,6 +239,17 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		}
 	}
 
+	if cfg.CertificateAudit != nil {
+		c.Sync.CertificateAuditInterval, err = time.ParseDuration(cfg.CertificateAudit.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate audit interval: %s", err)
+		}
+		c.Sync.ExpectedRootCertificates, err = getExpectedRootCertificates(cfg.CertificateAudit, httpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return
 }
 
@@ -219,7 +301,7 @@ func getStorage(ctx context.Context, cfg *StorageConfig) (engine store.Engine, e
 	return
 }
 
-func getContractCertValidator(cfg *ContractCertValidatorConfig, httpClient *http.Client) (contractCertValidator services.CertificateValidationService, err error) {
+func getContractCertValidator(cfg *ContractCertValidatorConfig, engine store.Engine, httpClient *http.Client) (contractCertValidator services.CertificateValidationService, err error) {
 	switch cfg.Type {
 	case "ocsp":
 		var rootCertificateProvider services.RootCertificateProviderService
@@ -228,11 +310,51 @@ func getContractCertValidator(cfg *ContractCertValidatorConfig, httpClient *http
 			return nil, fmt.Errorf("create root certificate provider: %w", err)
 		}
 
-		contractCertValidator, err = &services.OnlineCertificateValidationService{
+		validationService := &services.OnlineCertificateValidationService{
 			RootCertificateProvider: rootCertificateProvider,
 			MaxOCSPAttempts:         cfg.Ocsp.MaxAttempts,
 			HttpClient:              httpClient,
-		}, nil
+			Clock:                   clock.RealClock{},
+		}
+
+		if cfg.Ocsp.CacheTtl != "" {
+			validationService.OcspResponseCacheTTL, err = time.ParseDuration(cfg.Ocsp.CacheTtl)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse ocsp cache TTL: %w", err)
+			}
+			if validationService.OcspResponseCacheTTL > 0 {
+				validationService.OcspResponseCache = engine
+			}
+		}
+
+		contractCertValidator = validationService
+	case "crl":
+		var rootCertificateProvider services.RootCertificateProviderService
+		rootCertificateProvider, err = getRootCertProvider(&cfg.Crl.RootCertProvider, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("create root certificate provider: %w", err)
+		}
+
+		var refreshInterval time.Duration
+		refreshInterval, err = time.ParseDuration(cfg.Crl.RefreshInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse crl refresh interval: %w", err)
+		}
+
+		contractCertValidator = services.NewCRLCertificateValidationService(rootCertificateProvider, httpClient, refreshInterval, clock.RealClock{})
+	case "composite":
+		validators := make([]services.CertificateValidationService, len(cfg.Composite.Validators))
+		for index, validatorCfg := range cfg.Composite.Validators {
+			validatorCfg := validatorCfg
+			validators[index], err = getContractCertValidator(&validatorCfg, engine, httpClient)
+			if err != nil {
+				return nil, fmt.Errorf("creating composite contract certificate validator %d: %v", index, err)
+			}
+		}
+
+		contractCertValidator = services.CompositeCertificateValidationService{
+			Validators: validators,
+		}
 	default:
 		return nil, fmt.Errorf("unknown contract certificate validator type: %s", cfg.Type)
 	}
@@ -288,7 +410,26 @@ func getRootCertProvider(cfg *RootCertProviderConfig, httpClient *http.Client) (
 	return
 }
 
-func getContractCertProvider(cfg *ContractCertProviderConfig, httpClient *http.Client) (evCertificateProvider services.ContractCertificateProvider, err error) {
+func getExpectedRootCertificates(cfg *CertificateAuditConfig, httpClient *http.Client) (map[store.CertificateType]services.RootCertificateProviderService, error) {
+	providers := make(map[store.CertificateType]services.RootCertificateProviderService)
+	for certificateType, providerCfg := range map[store.CertificateType]*RootCertProviderConfig{
+		store.CertificateTypeV2G:  cfg.V2GRootCerts,
+		store.CertificateTypeMO:   cfg.MORootCerts,
+		store.CertificateTypeCSMS: cfg.CSMSRootCerts,
+	} {
+		if providerCfg == nil {
+			continue
+		}
+		provider, err := getRootCertProvider(providerCfg, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("create %s root certificate provider: %w", certificateType, err)
+		}
+		providers[certificateType] = provider
+	}
+	return providers, nil
+}
+
+func getContractCertProvider(ctx context.Context, cfg *ContractCertProviderConfig, engine store.Engine, httpClient *http.Client) (evCertificateProvider services.ContractCertificateProvider, err error) {
 	switch cfg.Type {
 	case "opcp":
 		httpTokenService, err := getHttpTokenService(&cfg.Opcp.HttpAuth, httpClient)
@@ -301,6 +442,36 @@ func getContractCertProvider(cfg *ContractCertProviderConfig, httpClient *http.C
 			HttpTokenService: httpTokenService,
 			HttpClient:       httpClient,
 		}
+	case "local":
+		certificateSource, err := getLocalSource(cfg.Local.CertificateSource)
+		if err != nil {
+			return nil, fmt.Errorf("create local source: %w", err)
+		}
+		privateKeySource, signer, err := getPrivateKey(ctx, cfg.Local.PrivateKeySource, cfg.Local.Signer, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		provisioningCertificateSource, err := getLocalSource(cfg.Local.ProvisioningCertificateSource)
+		if err != nil {
+			return nil, fmt.Errorf("create provisioning certificate source: %w", err)
+		}
+		provisioningPrivateKeySource, provisioningSigner, err := getPrivateKey(ctx, cfg.Local.ProvisioningPrivateKeySource, cfg.Local.ProvisioningSigner, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("provisioning: %w", err)
+		}
+
+		evCertificateProvider = &services.LocalContractCertificateProvider{
+			CertificateReader:             certificateSource,
+			PrivateKeyReader:              privateKeySource,
+			Signer:                        signer,
+			ProvisioningCertificateReader: provisioningCertificateSource,
+			ProvisioningPrivateKeyReader:  provisioningPrivateKeySource,
+			ProvisioningSigner:            provisioningSigner,
+			EmaidPrefix:                   cfg.Local.EmaidPrefix,
+			Store:                         engine,
+			TokenStore:                    engine,
+			Clock:                         clock.RealClock{},
+		}
 	case "default":
 		evCertificateProvider = &services.DefaultContractCertificateProvider{}
 	default:
@@ -329,15 +500,17 @@ func getChargeStationCertProvider(ctx context.Context, cfg *ChargeStationCertPro
 		if err != nil {
 			return nil, fmt.Errorf("create local source: %w", err)
 		}
-		privateKeySource, err := getLocalSource(cfg.Local.PrivateKeySource)
+		privateKeySource, signer, err := getPrivateKey(ctx, cfg.Local.PrivateKeySource, cfg.Local.Signer, httpClient)
 		if err != nil {
-			return nil, fmt.Errorf("create private key source: %w", err)
+			return nil, err
 		}
 
 		chargeStationCertProvider = &services.LocalChargeStationCertificateProvider{
 			Store:             engine,
+			RevocationStore:   engine,
 			CertificateReader: certificateSource,
 			PrivateKeyReader:  privateKeySource,
+			Signer:            signer,
 		}
 	case "delegating":
 		var v2gChargeStationCertProvider services.ChargeStationCertificateProvider
@@ -397,6 +570,56 @@ func getHttpTokenService(cfg *HttpAuthConfig, httpClient *http.Client) (httpToke
 	return
 }
 
+// getPrivateKey returns the signer when one is configured, otherwise the source of the private key
+func getPrivateKey(ctx context.Context, privateKeyCfg *LocalSourceConfig, signerCfg *SignerConfig, httpClient *http.Client) (privateKeySource services.LocalSource, signer crypto.Signer, err error) {
+	if signerCfg != nil {
+		signer, err = getSigner(ctx, signerCfg, httpClient)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create signer: %w", err)
+		}
+		return nil, signer, nil
+	}
+	privateKeySource, err = getLocalSource(privateKeyCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create private key source: %w", err)
+	}
+	return privateKeySource, nil, nil
+}
+
+func getSigner(ctx context.Context, cfg *SignerConfig, httpClient *http.Client) (signer crypto.Signer, err error) {
+	switch cfg.Type {
+	case "pkcs11":
+		pinSource, err := getLocalSource(cfg.Pkcs11.PinSource)
+		if err != nil {
+			return nil, fmt.Errorf("create pin source: %w", err)
+		}
+		pin, err := pinSource.GetData(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("read pin: %w", err)
+		}
+		signer, err = services.NewPKCS11Signer(cfg.Pkcs11.Module, cfg.Pkcs11.TokenLabel, strings.TrimSpace(pin), cfg.Pkcs11.KeyLabel)
+		if err != nil {
+			return nil, err
+		}
+	case "remote":
+		var httpTokenService services.HttpTokenService
+		if cfg.Remote.HttpAuth != nil {
+			httpTokenService, err = getHttpTokenService(cfg.Remote.HttpAuth, httpClient)
+			if err != nil {
+				return nil, fmt.Errorf("create http auth service: %w", err)
+			}
+		}
+		signer, err = services.NewRemoteSigner(ctx, cfg.Remote.Url, cfg.Remote.KeyId, httpTokenService, httpClient)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown signer type: %s", cfg.Type)
+	}
+
+	return
+}
+
 func getLocalSource(cfg *LocalSourceConfig) (source services.LocalSource, err error) {
 	switch cfg.Type {
 	case "file":
@@ -505,6 +728,20 @@ func getMsgListener(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.Li
 	}
 }
 
+// getMsgCorrelationListener returns a listener that is used to receive the responses to calls made by
+// this manager instance: it uses a group that is unique to this instance so that it receives every
+// message rather than sharing them with the other instances
+func getMsgCorrelationListener(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.Listener, error) {
+	switch cfg.Type {
+	case "mqtt":
+		mqttCfg := *cfg.Mqtt
+		mqttCfg.Group = fmt.Sprintf("%s-%s", cfg.Mqtt.Group, uuid.New().String())
+		return getMsgListener(&TransportConfig{Type: cfg.Type, Mqtt: &mqttCfg}, tracer)
+	default:
+		return nil, fmt.Errorf("unknown transport type: %s", cfg.Type)
+	}
+}
+
 func attributeFilter(_ attribute.KeyValue) bool {
 	return true
 }
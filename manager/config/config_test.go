@@ -4,12 +4,23 @@ package config_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"github.com/huandu/go-clone/generic"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestConfigure(t *testing.T) {
@@ -33,6 +44,8 @@ func TestConfigure(t *testing.T) {
 	assert.NotNil(t, settings.Storage)
 	assert.NotNil(t, settings.MsgEmitter)
 	assert.NotNil(t, settings.MsgListener)
+	assert.NotNil(t, settings.MsgCorrelationListener)
+	assert.NotNil(t, settings.CallCorrelator)
 	assert.NotNil(t, settings.Ocpp16Handler)
 	assert.NotNil(t, settings.Ocpp201Handler)
 	assert.NotNil(t, settings.ContractCertValidationService)
@@ -75,6 +88,39 @@ func TestConfigureOcspContractCertValidator(t *testing.T) {
 	require.NotNil(t, settings.ContractCertValidationService)
 }
 
+func TestConfigureCompositeContractCertValidatorWithCrlFallback(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertValidator = config.ContractCertValidatorConfig{
+		Type: "composite",
+		Composite: &config.CompositeContractCertValidatorConfig{
+			Validators: []config.ContractCertValidatorConfig{
+				cfg.ContractCertValidator,
+				{
+					Type: "crl",
+					Crl: &config.CrlContractCertValidatorConfig{
+						RootCertProvider: config.RootCertProviderConfig{
+							Type: "file",
+							File: &config.FileRootCertProviderConfig{
+								FileNames: []string{"testdata/root_ca.pem"},
+							},
+						},
+						RefreshInterval: "1h",
+					},
+				},
+			},
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	validator, ok := settings.ContractCertValidationService.(services.CompositeCertificateValidationService)
+	require.True(t, ok)
+	require.Len(t, validator.Validators, 2)
+	assert.IsType(t, &services.OnlineCertificateValidationService{}, validator.Validators[0])
+	assert.IsType(t, &services.CRLCertificateValidationService{}, validator.Validators[1])
+}
+
 func TestConfigureOpcpContractCertProvider(t *testing.T) {
 	_ = os.Setenv("TEST_OPCP_TOKEN", "test-token")
 	defer func() {
@@ -134,6 +180,35 @@ func TestConfigureDefaultContractCertProvider(t *testing.T) {
 	require.NotNil(t, settings.ContractCertProviderService)
 }
 
+func TestConfigureLocalContractCertProvider(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertProvider.Type = "local"
+	cfg.ContractCertProvider.Local = &config.LocalContractCertProviderConfig{
+		CertificateSource: &config.LocalSourceConfig{
+			Type: "file",
+			File: "testdata/ca.pem",
+		},
+		PrivateKeySource: &config.LocalSourceConfig{
+			Type: "file",
+			File: "testdata/ca.key",
+		},
+		ProvisioningCertificateSource: &config.LocalSourceConfig{
+			Type: "file",
+			File: "testdata/ca.pem",
+		},
+		ProvisioningPrivateKeySource: &config.LocalSourceConfig{
+			Type: "file",
+			File: "testdata/ca.key",
+		},
+		EmaidPrefix: "GBTWK",
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ContractCertProviderService)
+}
+
 func TestConfigureOpcpChargeStationCertProvider(t *testing.T) {
 	_ = os.Setenv("TEST_OPCP_TOKEN", "test-token")
 	defer func() {
@@ -177,6 +252,50 @@ func TestConfigureLocalChargeStationCertProviderWithFile(t *testing.T) {
 	require.NotNil(t, settings.ChargeStationCertProviderService)
 }
 
+func TestConfigureLocalChargeStationCertProviderWithRemoteSigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/keys/ca-key" || r.Header.Get("authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(services.RemotePublicKeyResponse{
+			PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+		})
+	}))
+	defer server.Close()
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ChargeStationCertProvider.Type = "local"
+	cfg.ChargeStationCertProvider.Local = &config.LocalChargeStationCertProviderConfig{
+		CertificateSource: &config.LocalSourceConfig{
+			Type: "file",
+			File: "testdata/ca.pem",
+		},
+		Signer: &config.SignerConfig{
+			Type: "remote",
+			Remote: &config.RemoteSignerConfig{
+				Url:   server.URL,
+				KeyId: "ca-key",
+				HttpAuth: &config.HttpAuthConfig{
+					Type: "fixed_token",
+					FixedToken: &config.FixedHttpTokenConfig{
+						Token: "test-token",
+					},
+				},
+			},
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ChargeStationCertProviderService)
+}
+
 func TestConfigureLocalChargeStationCertProviderWithGoogleCloudSecret(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
 	cfg.ChargeStationCertProvider.Type = "local"
@@ -256,3 +375,23 @@ func TestConfigureKwHTariffService(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, settings.ContractCertProviderService)
 }
+
+func TestConfigureCertificateAudit(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.CertificateAudit = &config.CertificateAuditConfig{
+		Interval: "24h",
+		V2GRootCerts: &config.RootCertProviderConfig{
+			Type: "file",
+			File: &config.FileRootCertProviderConfig{
+				FileNames: []string{"testdata/root_ca.pem"},
+			},
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, settings.Sync.CertificateAuditInterval)
+	assert.Len(t, settings.Sync.ExpectedRootCertificates, 1)
+	assert.NotNil(t, settings.Sync.ExpectedRootCertificates[store.CertificateTypeV2G])
+}
@@ -7,7 +7,18 @@ type OpcpContractCertProviderConfig struct {
 	HttpAuth HttpAuthConfig `mapstructure:"auth" toml:"auth" validate:"required"`
 }
 
+type LocalContractCertProviderConfig struct {
+	CertificateSource             *LocalSourceConfig `mapstructure:"cert" toml:"cert" validate:"required"`
+	PrivateKeySource              *LocalSourceConfig `mapstructure:"key,omitempty" toml:"key,omitempty" validate:"required_without=Signer"`
+	Signer                        *SignerConfig      `mapstructure:"signer,omitempty" toml:"signer,omitempty"`
+	ProvisioningCertificateSource *LocalSourceConfig `mapstructure:"provisioning_cert" toml:"provisioning_cert" validate:"required"`
+	ProvisioningPrivateKeySource  *LocalSourceConfig `mapstructure:"provisioning_key,omitempty" toml:"provisioning_key,omitempty" validate:"required_without=ProvisioningSigner"`
+	ProvisioningSigner            *SignerConfig      `mapstructure:"provisioning_signer,omitempty" toml:"provisioning_signer,omitempty"`
+	EmaidPrefix                   string             `mapstructure:"emaid_prefix" toml:"emaid_prefix" validate:"required,len=5,alphanum"`
+}
+
 type ContractCertProviderConfig struct {
-	Type string                          `mapstructure:"type" toml:"type" validate:"required,oneof=default opcp"`
-	Opcp *OpcpContractCertProviderConfig `mapstructure:"opcp,omitempty" toml:"opcp,omitempty" validate:"required_if=Type opcp"`
+	Type  string                           `mapstructure:"type" toml:"type" validate:"required,oneof=default opcp local"`
+	Opcp  *OpcpContractCertProviderConfig  `mapstructure:"opcp,omitempty" toml:"opcp,omitempty" validate:"required_if=Type opcp"`
+	Local *LocalContractCertProviderConfig `mapstructure:"local,omitempty" toml:"local,omitempty" validate:"required_if=Type local"`
 }
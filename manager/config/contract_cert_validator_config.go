@@ -5,9 +5,21 @@ package config
 type OcspContractCertValidatorConfig struct {
 	RootCertProvider RootCertProviderConfig `mapstructure:"root_certs" toml:"root_certs" validate:"required"`
 	MaxAttempts      int                    `mapstructure:"max_attempts" toml:"max_attempts" validate:"required"`
+	CacheTtl         string                 `mapstructure:"cache_ttl" toml:"cache_ttl,omitempty"`
+}
+
+type CrlContractCertValidatorConfig struct {
+	RootCertProvider RootCertProviderConfig `mapstructure:"root_certs" toml:"root_certs" validate:"required"`
+	RefreshInterval  string                 `mapstructure:"refresh_interval" toml:"refresh_interval" validate:"required"`
+}
+
+type CompositeContractCertValidatorConfig struct {
+	Validators []ContractCertValidatorConfig `mapstructure:"validators" toml:"validators" validate:"required,dive,required"`
 }
 
 type ContractCertValidatorConfig struct {
-	Type string                           `mapstructure:"type" toml:"type" validate:"required,oneof=ocsp"`
-	Ocsp *OcspContractCertValidatorConfig `mapstructure:"ocsp,omitempty" toml:"ocsp,omitempty" validate:"required_if=Type ocsp"`
+	Type      string                                `mapstructure:"type" toml:"type" validate:"required,oneof=ocsp crl composite"`
+	Ocsp      *OcspContractCertValidatorConfig      `mapstructure:"ocsp,omitempty" toml:"ocsp,omitempty" validate:"required_if=Type ocsp"`
+	Crl       *CrlContractCertValidatorConfig       `mapstructure:"crl,omitempty" toml:"crl,omitempty" validate:"required_if=Type crl"`
+	Composite *CompositeContractCertValidatorConfig `mapstructure:"composite,omitempty" toml:"composite,omitempty" validate:"required_if=Type composite"`
 }
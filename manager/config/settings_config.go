@@ -14,6 +14,16 @@ type OcppSettingsConfig struct {
 	HeartbeatInterval string `mapstructure:"heartbeat_interval" toml:"heartbeat_interval" validate:"required"`
 	Ocpp16Enabled     bool   `mapstructure:"ocpp16_enabled" toml:"ocpp16_enabled" validate:"required_without=Ocpp201Enabled"`
 	Ocpp201Enabled    bool   `mapstructure:"ocpp201_enabled" toml:"ocpp201_enabled" validate:"required_without=Ocpp16Enabled"`
+	// DriftCheckInterval is the frequency to read back accepted settings from charge stations
+	// to detect configuration drift, a value of "0" disables drift detection
+	DriftCheckInterval     string `mapstructure:"drift_check_interval,omitempty" toml:"drift_check_interval,omitempty"`
+	ReapplyDriftedSettings bool   `mapstructure:"reapply_drifted_settings,omitempty" toml:"reapply_drifted_settings,omitempty"`
+	// CertRenewalPeriod is how long before an issued certificate expires that the charge station
+	// is asked to renew it, a value of "0" disables certificate renewal
+	CertRenewalPeriod string `mapstructure:"cert_renewal_period,omitempty" toml:"cert_renewal_period,omitempty"`
+	// CostUpdateInterval is the minimum time between the running cost updates sent to a charge
+	// station for a transaction
+	CostUpdateInterval string `mapstructure:"cost_update_interval,omitempty" toml:"cost_update_interval,omitempty"`
 }
 
 type ObservabilitySettingsConfig struct {
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type Pkcs11SignerConfig struct {
+	Module     string             `mapstructure:"module" toml:"module" validate:"required"`
+	TokenLabel string             `mapstructure:"token_label" toml:"token_label" validate:"required"`
+	PinSource  *LocalSourceConfig `mapstructure:"pin" toml:"pin" validate:"required"`
+	KeyLabel   string             `mapstructure:"key_label" toml:"key_label" validate:"required"`
+}
+
+type RemoteSignerConfig struct {
+	Url      string          `mapstructure:"url" toml:"url" validate:"required"`
+	KeyId    string          `mapstructure:"key_id" toml:"key_id" validate:"required"`
+	HttpAuth *HttpAuthConfig `mapstructure:"auth,omitempty" toml:"auth,omitempty"`
+}
+
+type SignerConfig struct {
+	Type   string              `mapstructure:"type" toml:"type" validate:"required,oneof=pkcs11 remote"`
+	Pkcs11 *Pkcs11SignerConfig `mapstructure:"pkcs11,omitempty" toml:"pkcs11,omitempty" validate:"required_if=Type pkcs11"`
+	Remote *RemoteSignerConfig `mapstructure:"remote,omitempty" toml:"remote,omitempty" validate:"required_if=Type remote"`
+}
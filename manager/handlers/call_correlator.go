@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"sync"
+)
+
+// CallCorrelator matches the CallResult and CallError messages received from charge stations
+// with the calls that are waiting for them (see OcppCallMaker.SendAndWait). It is a
+// transport.MessageHandler: the response to a call can be received by any manager instance
+// so each instance must connect its correlator using a subscription that is not shared with
+// the other instances.
+type CallCorrelator struct {
+	sync.Mutex
+	waiting map[string]chan *transport.Message
+}
+
+func NewCallCorrelator() *CallCorrelator {
+	return &CallCorrelator{
+		waiting: make(map[string]chan *transport.Message),
+	}
+}
+
+// Expect registers interest in the response to the call with the given message id. The response
+// will be delivered on the returned channel. Forget must be called once the response is no longer
+// required.
+func (c *CallCorrelator) Expect(messageId string) <-chan *transport.Message {
+	c.Lock()
+	defer c.Unlock()
+	ch := make(chan *transport.Message, 1)
+	c.waiting[messageId] = ch
+	return ch
+}
+
+// Forget removes interest in the response to the call with the given message id.
+func (c *CallCorrelator) Forget(messageId string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.waiting, messageId)
+}
+
+func (c *CallCorrelator) Handle(_ context.Context, _ string, msg *transport.Message) {
+	if msg.MessageType != transport.MessageTypeCallResult && msg.MessageType != transport.MessageTypeCallError {
+		return
+	}
+
+	c.Lock()
+	ch, ok := c.waiting[msg.MessageId]
+	delete(c.waiting, msg.MessageId)
+	c.Unlock()
+
+	if ok {
+		ch <- msg
+	}
+}
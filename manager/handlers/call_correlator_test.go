@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"testing"
+)
+
+func TestCallCorrelatorDeliversResponse(t *testing.T) {
+	correlator := handlers.NewCallCorrelator()
+
+	ch := correlator.Expect("msg001")
+	defer correlator.Forget("msg001")
+
+	msg := &transport.Message{
+		MessageType: transport.MessageTypeCallResult,
+		Action:      "Reset",
+		MessageId:   "msg001",
+	}
+	correlator.Handle(context.Background(), "cs001", msg)
+
+	select {
+	case got := <-ch:
+		assert.Equal(t, msg, got)
+	default:
+		t.Fatal("expected response to be delivered")
+	}
+}
+
+func TestCallCorrelatorIgnoresUnexpectedMessages(t *testing.T) {
+	correlator := handlers.NewCallCorrelator()
+
+	ch := correlator.Expect("msg001")
+	defer correlator.Forget("msg001")
+
+	correlator.Handle(context.Background(), "cs001", &transport.Message{
+		MessageType: transport.MessageTypeCallResult,
+		Action:      "Reset",
+		MessageId:   "msg002",
+	})
+	correlator.Handle(context.Background(), "cs001", &transport.Message{
+		MessageType: transport.MessageTypeCall,
+		Action:      "Heartbeat",
+		MessageId:   "msg001",
+	})
+
+	select {
+	case got := <-ch:
+		t.Fatalf("unexpected response delivered: %v", got)
+	default:
+	}
+}
+
+func TestCallCorrelatorDropsResponseAfterForget(t *testing.T) {
+	correlator := handlers.NewCallCorrelator()
+
+	ch := correlator.Expect("msg001")
+	correlator.Forget("msg001")
+
+	correlator.Handle(context.Background(), "cs001", &transport.Message{
+		MessageType: transport.MessageTypeCallError,
+		Action:      "Reset",
+		MessageId:   "msg001",
+	})
+
+	select {
+	case got := <-ch:
+		t.Fatalf("unexpected response delivered: %v", got)
+	default:
+	}
+}
@@ -5,6 +5,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
@@ -14,30 +15,77 @@ import (
 )
 
 // OcppCallMaker is an implementation of the CallMaker interface for a specific set of OCPP messages.
+// If a Correlator is provided then it also implements the SyncCallMaker interface.
 type OcppCallMaker struct {
 	Emitter     transport.Emitter       // used to send the message to the charge station
 	OcppVersion transport.OcppVersion   // identifies the OCPP version that the messages are for
 	Actions     map[reflect.Type]string // the OCPP Action associated with a specific ocpp.Request object
+	Correlator  *CallCorrelator         // used to receive the response to a message (optional)
 }
 
 func (b OcppCallMaker) Send(ctx context.Context, chargeStationId string, request ocpp.Request) error {
+	msg, err := b.newCall(request)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("sending message", "action", msg.Action, "chargeStationId", chargeStationId)
+	return b.Emitter.Emit(ctx, b.OcppVersion, chargeStationId, msg)
+}
+
+func (b OcppCallMaker) SendAndWait(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response) error {
+	if b.Correlator == nil {
+		return errors.New("no call correlator configured")
+	}
+
+	msg, err := b.newCall(request)
+	if err != nil {
+		return err
+	}
+
+	responseCh := b.Correlator.Expect(msg.MessageId)
+	defer b.Correlator.Forget(msg.MessageId)
+
+	slog.Info("sending message and waiting for response", "action", msg.Action, "chargeStationId", chargeStationId)
+	err = b.Emitter.Emit(ctx, b.OcppVersion, chargeStationId, msg)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for %s response: %w", msg.Action, ctx.Err())
+	case resp := <-responseCh:
+		if resp.MessageType == transport.MessageTypeCallError {
+			var err error
+			if resp.ErrorDescription != "" {
+				err = errors.New(resp.ErrorDescription)
+			}
+			return transport.NewError(resp.ErrorCode, err)
+		}
+		err = json.Unmarshal(resp.ResponsePayload, response)
+		if err != nil {
+			return fmt.Errorf("unmarshalling %s response: %w", msg.Action, err)
+		}
+		return nil
+	}
+}
+
+func (b OcppCallMaker) newCall(request ocpp.Request) (*transport.Message, error) {
 	action, ok := b.Actions[reflect.TypeOf(request)]
 	if !ok {
-		return fmt.Errorf("unknown request type: %T", request)
+		return nil, fmt.Errorf("unknown request type: %T", request)
 	}
 
 	requestBytes, err := json.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	msg := &transport.Message{
+	return &transport.Message{
 		MessageType:    transport.MessageTypeCall,
 		MessageId:      uuid.New().String(),
 		Action:         action,
 		RequestPayload: requestBytes,
-	}
-
-	slog.Info("sending message", "action", msg.Action, "chargeStationId", chargeStationId)
-	return b.Emitter.Emit(ctx, b.OcppVersion, chargeStationId, msg)
+	}, nil
 }
@@ -5,12 +5,14 @@ package handlers_test
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 )
 
 type FakeEmitter struct {
@@ -67,3 +69,104 @@ func TestCallMakerWithUnknownMessageType(t *testing.T) {
 	assert.ErrorContains(t, err, "unknown request type")
 	assert.Nil(t, emitter.msg)
 }
+
+func TestCallMakerSendAndWait(t *testing.T) {
+	correlator := handlers.NewCallCorrelator()
+	emitter := transport.EmitterFunc(func(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+		go correlator.Handle(ctx, chargeStationId, &transport.Message{
+			MessageType:     transport.MessageTypeCallResult,
+			Action:          message.Action,
+			MessageId:       message.MessageId,
+			RequestPayload:  message.RequestPayload,
+			ResponsePayload: []byte(`{"status":"Accepted"}`),
+		})
+		return nil
+	})
+	callMaker := &handlers.OcppCallMaker{
+		Emitter:     emitter,
+		OcppVersion: transport.OcppVersion201,
+		Actions: map[reflect.Type]string{
+			reflect.TypeOf(&ocpp201.ResetRequestJson{}): "Reset",
+		},
+		Correlator: correlator,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp := new(ocpp201.ResetResponseJson)
+	err := callMaker.SendAndWait(ctx, "cs001", &ocpp201.ResetRequestJson{
+		Type: ocpp201.ResetEnumTypeImmediate,
+	}, resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, ocpp201.ResetStatusEnumTypeAccepted, resp.Status)
+}
+
+func TestCallMakerSendAndWaitWithCallError(t *testing.T) {
+	correlator := handlers.NewCallCorrelator()
+	emitter := transport.EmitterFunc(func(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+		go correlator.Handle(ctx, chargeStationId, &transport.Message{
+			MessageType:      transport.MessageTypeCallError,
+			Action:           message.Action,
+			MessageId:        message.MessageId,
+			ErrorCode:        transport.ErrorNotSupported,
+			ErrorDescription: "reset not supported",
+		})
+		return nil
+	})
+	callMaker := &handlers.OcppCallMaker{
+		Emitter:     emitter,
+		OcppVersion: transport.OcppVersion201,
+		Actions: map[reflect.Type]string{
+			reflect.TypeOf(&ocpp201.ResetRequestJson{}): "Reset",
+		},
+		Correlator: correlator,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := callMaker.SendAndWait(ctx, "cs001", &ocpp201.ResetRequestJson{
+		Type: ocpp201.ResetEnumTypeImmediate,
+	}, new(ocpp201.ResetResponseJson))
+
+	var transportErr *transport.Error
+	require.ErrorAs(t, err, &transportErr)
+	assert.Equal(t, transport.ErrorNotSupported, transportErr.ErrorCode)
+	assert.ErrorContains(t, err, "reset not supported")
+}
+
+func TestCallMakerSendAndWaitTimesOut(t *testing.T) {
+	callMaker := &handlers.OcppCallMaker{
+		Emitter:     &FakeEmitter{},
+		OcppVersion: transport.OcppVersion201,
+		Actions: map[reflect.Type]string{
+			reflect.TypeOf(&ocpp201.ResetRequestJson{}): "Reset",
+		},
+		Correlator: handlers.NewCallCorrelator(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := callMaker.SendAndWait(ctx, "cs001", &ocpp201.ResetRequestJson{
+		Type: ocpp201.ResetEnumTypeImmediate,
+	}, new(ocpp201.ResetResponseJson))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCallMakerSendAndWaitWithoutCorrelator(t *testing.T) {
+	callMaker := &handlers.OcppCallMaker{
+		Emitter:     &FakeEmitter{},
+		OcppVersion: transport.OcppVersion201,
+		Actions: map[reflect.Type]string{
+			reflect.TypeOf(&ocpp201.ResetRequestJson{}): "Reset",
+		},
+	}
+
+	err := callMaker.SendAndWait(context.Background(), "cs001", &ocpp201.ResetRequestJson{
+		Type: ocpp201.ResetEnumTypeImmediate,
+	}, new(ocpp201.ResetResponseJson))
+	assert.ErrorContains(t, err, "no call correlator configured")
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slices"
+	"k8s.io/utils/clock"
+)
+
+// NewInventoryCertificate returns the SHA256 hash data that identifies a root certificate. The issuer
+// of a root certificate is the certificate itself so the issuer key hash is the hash of its own key.
+func NewInventoryCertificate(certificateType store.CertificateType, cert *x509.Certificate) (*store.InventoryCertificate, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(cert.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	issuerNameHash := sha256.Sum256(cert.RawIssuer)
+	issuerKeyHash := sha256.Sum256(spki.PublicKey.RightAlign())
+
+	return &store.InventoryCertificate{
+		CertificateType: certificateType,
+		HashAlgorithm:   "SHA256",
+		IssuerNameHash:  hex.EncodeToString(issuerNameHash[:]),
+		IssuerKeyHash:   hex.EncodeToString(issuerKeyHash[:]),
+		SerialNumber:    cert.SerialNumber.Text(16),
+	}, nil
+}
+
+// ReplaceInventoryCertificates records the certificates that the charge station reported in response to a
+// GetInstalledCertificateIds request. Only the certificates of the requested types are replaced: if no
+// types are given then all the certificates are replaced.
+func ReplaceInventoryCertificates(ctx context.Context, inventoryStore store.CertificateInventoryStore, clock clock.PassiveClock, chargeStationId string, certificateTypes []store.CertificateType, certificates []*store.InventoryCertificate) error {
+	return updateInventory(ctx, inventoryStore, clock, chargeStationId, func(inventory *store.ChargeStationCertificateInventory) {
+		now := clock.Now()
+		var kept []*store.InventoryCertificate
+		if len(certificateTypes) > 0 {
+			for _, cert := range inventory.Certificates {
+				if !slices.Contains(certificateTypes, cert.CertificateType) {
+					kept = append(kept, cert)
+				}
+			}
+		}
+		inventory.Certificates = append(kept, certificates...)
+		inventory.ReportedAt = &now
+	})
+}
+
+// AddInventoryCertificate records that a certificate has been installed on the charge station
+func AddInventoryCertificate(ctx context.Context, inventoryStore store.CertificateInventoryStore, clock clock.PassiveClock, chargeStationId string, certificate *store.InventoryCertificate) error {
+	return updateInventory(ctx, inventoryStore, clock, chargeStationId, func(inventory *store.ChargeStationCertificateInventory) {
+		inventory.Certificates = slices.DeleteFunc(inventory.Certificates, certificate.Matches)
+		inventory.Certificates = append(inventory.Certificates, certificate)
+	})
+}
+
+// RemoveInventoryCertificate records that a certificate has been deleted from the charge station
+func RemoveInventoryCertificate(ctx context.Context, inventoryStore store.CertificateInventoryStore, clock clock.PassiveClock, chargeStationId string, certificate *store.InventoryCertificate) error {
+	return updateInventory(ctx, inventoryStore, clock, chargeStationId, func(inventory *store.ChargeStationCertificateInventory) {
+		inventory.Certificates = slices.DeleteFunc(inventory.Certificates, certificate.Matches)
+	})
+}
+
+func updateInventory(ctx context.Context, inventoryStore store.CertificateInventoryStore, clock clock.PassiveClock, chargeStationId string, update func(*store.ChargeStationCertificateInventory)) error {
+	inventory, err := inventoryStore.LookupChargeStationCertificateInventory(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station certificate inventory: %w", err)
+	}
+	if inventory == nil {
+		inventory = &store.ChargeStationCertificateInventory{}
+	}
+	update(inventory)
+	inventory.UpdatedAt = clock.Now()
+
+	err = inventoryStore.SetChargeStationCertificateInventory(ctx, chargeStationId, inventory)
+	if err != nil {
+		return fmt.Errorf("set charge station certificate inventory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"golang.org/x/crypto/ocsp"
+	"k8s.io/utils/clock"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func newRootCertificate(t *testing.T) *x509.Certificate {
+	keyPair, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(0x0abc),
+		Subject:               pkix.Name{CommonName: "V2G Root CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &keyPair.PublicKey, keyPair)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(derBytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestNewInventoryCertificate(t *testing.T) {
+	cert := newRootCertificate(t)
+
+	got, err := handlers.NewInventoryCertificate(store.CertificateTypeV2G, cert)
+	require.NoError(t, err)
+
+	// the hash data is the same as that used in an OCSP request
+	reqBytes, err := ocsp.CreateRequest(cert, cert, &ocsp.RequestOptions{Hash: crypto.SHA256})
+	require.NoError(t, err)
+	req, err := ocsp.ParseRequest(reqBytes)
+	require.NoError(t, err)
+
+	assert.Equal(t, &store.InventoryCertificate{
+		CertificateType: store.CertificateTypeV2G,
+		HashAlgorithm:   "SHA256",
+		IssuerNameHash:  hex.EncodeToString(req.IssuerNameHash),
+		IssuerKeyHash:   hex.EncodeToString(req.IssuerKeyHash),
+		SerialNumber:    "abc",
+	}, got)
+}
+
+func TestAddAndRemoveInventoryCertificate(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	certificate, err := handlers.NewInventoryCertificate(store.CertificateTypeV2G, newRootCertificate(t))
+	require.NoError(t, err)
+
+	// adding the same certificate twice only records it once
+	require.NoError(t, handlers.AddInventoryCertificate(ctx, engine, clock.RealClock{}, "cs001", certificate))
+	require.NoError(t, handlers.AddInventoryCertificate(ctx, engine, clock.RealClock{}, "cs001", certificate))
+
+	inventory, err := engine.LookupChargeStationCertificateInventory(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.InventoryCertificate{certificate}, inventory.Certificates)
+
+	// the charge station may report the serial number with a leading zero
+	reported := *certificate
+	reported.SerialNumber = "0ABC"
+	require.NoError(t, handlers.RemoveInventoryCertificate(ctx, engine, clock.RealClock{}, "cs001", &reported))
+
+	inventory, err = engine.LookupChargeStationCertificateInventory(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Empty(t, inventory.Certificates)
+}
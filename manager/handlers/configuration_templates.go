@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// ApplyConfigurationTemplates queues the settings from every template that matches the charge
+// station into the charge station settings. The templates are applied in order so a later
+// template overrides any setting also provided by an earlier template. Settings that already
+// have the template value are left alone: this means a setting is not resent every time the
+// charge station boots. It returns the names of the settings that have been queued.
+func ApplyConfigurationTemplates(ctx context.Context, settingsStore store.ChargeStationSettingsStore, templates []*store.ConfigurationTemplate, cs *store.ChargeStation, details *store.ChargeStationRuntimeDetails) ([]string, error) {
+	values := make(map[string]string)
+	for _, template := range templates {
+		if template.Matches(cs, details) {
+			for name, value := range template.Settings {
+				values[name] = value
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	settings, err := settingsStore.LookupChargeStationSettings(ctx, cs.Id)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station settings: %w", err)
+	}
+
+	var queued []string
+	updated := make(map[string]*store.ChargeStationSetting)
+	for name, value := range values {
+		if settings != nil {
+			if setting := settings.Settings[name]; setting != nil && setting.Value == value {
+				continue
+			}
+		}
+		queued = append(queued, name)
+		updated[name] = &store.ChargeStationSetting{Value: value, Status: store.ChargeStationSettingStatusPending}
+	}
+
+	sort.Strings(queued)
+
+	if len(updated) > 0 {
+		err = settingsStore.UpdateChargeStationSettings(ctx, cs.Id, &store.ChargeStationSettings{
+			ChargeStationId: cs.Id,
+			Settings:        updated,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("update charge station settings: %w", err)
+		}
+	}
+
+	return queued, nil
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestApplyConfigurationTemplates(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"HeartbeatInterval":     {Value: "60", Status: store.ChargeStationSettingStatusAccepted},
+			"WebSocketPingInterval": {Value: "10", Status: store.ChargeStationSettingStatusAccepted},
+		},
+	})
+	require.NoError(t, err)
+
+	templates := []*store.ConfigurationTemplate{
+		{
+			Id:         "a-location",
+			LocationId: makePtr("loc001"),
+			Settings: map[string]string{
+				"HeartbeatInterval":        "60",
+				"MeterValueSampleInterval": "30",
+			},
+		},
+		{
+			Id:     "b-model",
+			Vendor: makePtr("VendorX"),
+			Model:  makePtr("ModelY"),
+			Settings: map[string]string{
+				"MeterValueSampleInterval": "15",
+				"WebSocketPingInterval":    "20",
+			},
+		},
+		{
+			Id:       "c-tag",
+			Tag:      makePtr("slow"),
+			Settings: map[string]string{"ConnectionTimeOut": "90"},
+		},
+		{
+			Id:         "d-other-location",
+			LocationId: makePtr("loc002"),
+			Settings:   map[string]string{"ConnectionTimeOut": "60"},
+		},
+	}
+	cs := &store.ChargeStation{Id: "cs001", LocationId: "loc001", Tags: []string{"fast"}}
+	details := &store.ChargeStationRuntimeDetails{OcppVersion: store.OcppVersion16, Vendor: "VendorX", Model: "ModelY"}
+
+	queued, err := handlers.ApplyConfigurationTemplates(ctx, engine, templates, cs, details)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MeterValueSampleInterval", "WebSocketPingInterval"}, queued)
+
+	got, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+
+	statuses := make(map[string]store.ChargeStationSetting)
+	for name, setting := range got.Settings {
+		statuses[name] = store.ChargeStationSetting{Value: setting.Value, Status: setting.Status}
+	}
+	assert.Equal(t, map[string]store.ChargeStationSetting{
+		"HeartbeatInterval":        {Value: "60", Status: store.ChargeStationSettingStatusAccepted},
+		"MeterValueSampleInterval": {Value: "15", Status: store.ChargeStationSettingStatusPending},
+		"WebSocketPingInterval":    {Value: "20", Status: store.ChargeStationSettingStatusPending},
+	}, statuses)
+}
+
+func TestApplyConfigurationTemplatesWithoutRuntimeDetails(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	templates := []*store.ConfigurationTemplate{
+		{
+			Id:       "model",
+			Model:    makePtr("ModelY"),
+			Settings: map[string]string{"HeartbeatInterval": "60"},
+		},
+	}
+	cs := &store.ChargeStation{Id: "cs001", LocationId: "loc001"}
+
+	queued, err := handlers.ApplyConfigurationTemplates(ctx, engine, templates, cs, nil)
+	require.NoError(t, err)
+	assert.Empty(t, queued)
+
+	got, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
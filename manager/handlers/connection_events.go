@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// ConnectionEventHandler maintains the table of charge station connections from the connection
+// events published by the gateways. A Disconnected event is ignored unless it is for the
+// connection that is currently recorded: a charge station that reconnects to a different
+// gateway may be reported as connected before its old connection is reported as disconnected.
+// Similarly a Connected event is ignored if the recorded connection was made later.
+type ConnectionEventHandler struct {
+	Store store.ChargeStationConnectionStore
+	Clock clock.PassiveClock
+}
+
+func (h ConnectionEventHandler) Handle(ctx context.Context, chargeStationId string, event *transport.ConnectionEvent) {
+	span := trace.SpanFromContext(ctx)
+
+	err := h.handle(ctx, chargeStationId, event)
+	if err != nil {
+		slog.Error("unable to handle connection event", slog.String("chargeStationId", chargeStationId), slog.String("event", string(event.Event)), "err", err)
+		span.SetStatus(codes.Error, "handling connection event failed")
+		span.RecordError(err)
+	} else {
+		span.SetStatus(codes.Ok, "ok")
+	}
+}
+
+func (h ConnectionEventHandler) handle(ctx context.Context, chargeStationId string, event *transport.ConnectionEvent) error {
+	switch event.Event {
+	case transport.ConnectionEventConnected:
+		connectedAt := h.Clock.Now().UTC()
+		if event.ConnectedAt != nil {
+			connectedAt = event.ConnectedAt.UTC()
+		}
+		connection, err := h.Store.LookupChargeStationConnection(ctx, chargeStationId)
+		if err != nil {
+			return fmt.Errorf("lookup charge station connection: %w", err)
+		}
+		if connection != nil && connection.ConnectionId != event.ConnectionId && connection.ConnectedAt.After(connectedAt) {
+			// the event for an older connection has been delivered after the current connection
+			return nil
+		}
+		err = h.Store.SetChargeStationConnection(ctx, chargeStationId, &store.ChargeStationConnection{
+			ChargeStationId: chargeStationId,
+			ConnectionId:    event.ConnectionId,
+			GatewayId:       event.GatewayId,
+			RemoteAddress:   event.RemoteAddress,
+			Subprotocol:     event.Subprotocol,
+			SecurityProfile: store.SecurityProfile(event.SecurityProfile),
+			ConnectedAt:     connectedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("set charge station connection: %w", err)
+		}
+	case transport.ConnectionEventDisconnected:
+		connection, err := h.Store.LookupChargeStationConnection(ctx, chargeStationId)
+		if err != nil {
+			return fmt.Errorf("lookup charge station connection: %w", err)
+		}
+		if connection == nil || connection.ConnectionId != event.ConnectionId {
+			return nil
+		}
+		err = h.Store.DeleteChargeStationConnection(ctx, chargeStationId)
+		if err != nil {
+			return fmt.Errorf("delete charge station connection: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown connection event: %s", event.Event)
+	}
+	return nil
+}
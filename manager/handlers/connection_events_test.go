@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestConnectionEventHandlerRecordsConnection(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	handler := handlers.ConnectionEventHandler{Store: engine, Clock: clockTest.NewFakePassiveClock(now)}
+
+	handler.Handle(ctx, "cs001", &transport.ConnectionEvent{
+		Event:           transport.ConnectionEventConnected,
+		ConnectionId:    "conn-1",
+		GatewayId:       "gateway-1",
+		RemoteAddress:   "10.0.0.1:4321",
+		Subprotocol:     "ocpp2.0.1",
+		SecurityProfile: 1,
+	})
+
+	got, err := engine.LookupChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationConnection{
+		ChargeStationId: "cs001",
+		ConnectionId:    "conn-1",
+		GatewayId:       "gateway-1",
+		RemoteAddress:   "10.0.0.1:4321",
+		Subprotocol:     "ocpp2.0.1",
+		SecurityProfile: store.TLSWithBasicAuth,
+		ConnectedAt:     now,
+	}, got)
+
+	handler.Handle(ctx, "cs001", &transport.ConnectionEvent{
+		Event:        transport.ConnectionEventDisconnected,
+		ConnectionId: "conn-1",
+		GatewayId:    "gateway-1",
+	})
+
+	got, err = engine.LookupChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestConnectionEventHandlerIgnoresDisconnectForReplacedConnection(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	handler := handlers.ConnectionEventHandler{Store: engine, Clock: clockTest.NewFakePassiveClock(now)}
+
+	firstConnectedAt := now.Add(-2 * time.Minute)
+	connectedAt := now.Add(-time.Minute)
+	handler.Handle(ctx, "cs001", &transport.ConnectionEvent{
+		Event:        transport.ConnectionEventConnected,
+		ConnectionId: "conn-1",
+		GatewayId:    "gateway-1",
+		ConnectedAt:  &firstConnectedAt,
+	})
+	handler.Handle(ctx, "cs001", &transport.ConnectionEvent{
+		Event:        transport.ConnectionEventConnected,
+		ConnectionId: "conn-2",
+		GatewayId:    "gateway-2",
+		ConnectedAt:  &connectedAt,
+	})
+	handler.Handle(ctx, "cs001", &transport.ConnectionEvent{
+		Event:        transport.ConnectionEventDisconnected,
+		ConnectionId: "conn-1",
+		GatewayId:    "gateway-1",
+	})
+
+	got, err := engine.LookupChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "conn-2", got.ConnectionId)
+	assert.Equal(t, "gateway-2", got.GatewayId)
+	assert.Equal(t, connectedAt, got.ConnectedAt)
+}
+
+func TestConnectionEventHandlerIgnoresConnectForOlderConnection(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	handler := handlers.ConnectionEventHandler{Store: engine, Clock: clockTest.NewFakePassiveClock(now)}
+
+	// the events for the two connections are delivered out of order
+	firstConnectedAt := now.Add(-2 * time.Minute)
+	connectedAt := now.Add(-time.Minute)
+	handler.Handle(ctx, "cs001", &transport.ConnectionEvent{
+		Event:        transport.ConnectionEventConnected,
+		ConnectionId: "conn-2",
+		GatewayId:    "gateway-2",
+		ConnectedAt:  &connectedAt,
+	})
+	handler.Handle(ctx, "cs001", &transport.ConnectionEvent{
+		Event:        transport.ConnectionEventConnected,
+		ConnectionId: "conn-1",
+		GatewayId:    "gateway-1",
+		ConnectedAt:  &firstConnectedAt,
+	})
+
+	got, err := engine.LookupChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "conn-2", got.ConnectionId)
+	assert.Equal(t, connectedAt, got.ConnectedAt)
+}
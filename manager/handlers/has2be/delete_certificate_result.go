@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	typesHasToBe "github.com/thoughtworks/maeve-csms/manager/ocpp/has2be"
+	types201 "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+)
+
+type DeleteCertificateResultHandler struct {
+	Handler201 handlers.CallResultHandler
+}
+
+func (d DeleteCertificateResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*typesHasToBe.DeleteCertificateRequestJson)
+	resp := response.(*typesHasToBe.DeleteCertificateResponseJson)
+
+	req201 := &types201.DeleteCertificateRequestJson{
+		CertificateHashData: newCertificateHashData201(req.CertificateHashData),
+	}
+	resp201 := &types201.DeleteCertificateResponseJson{
+		Status: types201.DeleteCertificateStatusEnumType(resp.Status),
+	}
+
+	return d.Handler201.HandleCallResult(ctx, chargeStationId, req201, resp201, state)
+}
+
+func newCertificateHashData201(hashData typesHasToBe.CertificateHashDataType) types201.CertificateHashDataType {
+	return types201.CertificateHashDataType{
+		HashAlgorithm:  types201.HashAlgorithmEnumType(hashData.HashAlgorithm),
+		IssuerKeyHash:  hashData.IssuerKeyHash,
+		IssuerNameHash: hashData.IssuerNameHash,
+		SerialNumber:   hashData.SerialNumber,
+	}
+}
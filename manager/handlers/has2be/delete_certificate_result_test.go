@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlersHasToBe "github.com/thoughtworks/maeve-csms/manager/handlers/has2be"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	typesHasToBe "github.com/thoughtworks/maeve-csms/manager/ocpp/has2be"
+	types201 "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"testing"
+)
+
+// recordingCallResultHandler records the OCPP 2.0.1 messages passed to it
+type recordingCallResultHandler struct {
+	request  ocpp.Request
+	response ocpp.Response
+}
+
+func (r *recordingCallResultHandler) HandleCallResult(_ context.Context, _ string, request ocpp.Request, response ocpp.Response, _ any) error {
+	r.request = request
+	r.response = response
+	return nil
+}
+
+func TestDeleteCertificateResult(t *testing.T) {
+	handler201 := &recordingCallResultHandler{}
+	h := handlersHasToBe.DeleteCertificateResultHandler{Handler201: handler201}
+
+	req := &typesHasToBe.DeleteCertificateRequestJson{
+		CertificateHashData: typesHasToBe.CertificateHashDataType{
+			HashAlgorithm:  typesHasToBe.HashAlgorithmEnumTypeSHA256,
+			IssuerKeyHash:  "key-hash",
+			IssuerNameHash: "name-hash",
+			SerialNumber:   "serial-number",
+		},
+	}
+	resp := &typesHasToBe.DeleteCertificateResponseJson{
+		Status: typesHasToBe.DeleteCertificateStatusEnumTypeNotFound,
+	}
+
+	err := h.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, &types201.DeleteCertificateRequestJson{
+		CertificateHashData: types201.CertificateHashDataType{
+			HashAlgorithm:  types201.HashAlgorithmEnumTypeSHA256,
+			IssuerKeyHash:  "key-hash",
+			IssuerNameHash: "name-hash",
+			SerialNumber:   "serial-number",
+		},
+	}, handler201.request)
+	assert.Equal(t, &types201.DeleteCertificateResponseJson{
+		Status: types201.DeleteCertificateStatusEnumTypeNotFound,
+	}, handler201.response)
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	typesHasToBe "github.com/thoughtworks/maeve-csms/manager/ocpp/has2be"
+	types201 "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+)
+
+type GetInstalledCertificateIdsResultHandler struct {
+	Handler201 handlers.CallResultHandler
+}
+
+func (g GetInstalledCertificateIdsResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*typesHasToBe.GetInstalledCertificateIdsRequestJson)
+	resp := response.(*typesHasToBe.GetInstalledCertificateIdsResponseJson)
+
+	req201 := &types201.GetInstalledCertificateIdsRequestJson{}
+	for _, certType := range req.TypeOfCertificate {
+		req201.CertificateType = append(req201.CertificateType, types201.GetCertificateIdUseEnumType(certType))
+	}
+
+	// the has2be response does not include the type of each certificate: it is only known
+	// if a single type was requested
+	var certType types201.GetCertificateIdUseEnumType
+	if len(req.TypeOfCertificate) == 1 {
+		certType = types201.GetCertificateIdUseEnumType(req.TypeOfCertificate[0])
+	}
+	resp201 := &types201.GetInstalledCertificateIdsResponseJson{
+		Status: types201.GetInstalledCertificateStatusEnumType(resp.Status),
+	}
+	for _, hashData := range resp.CertificateHashDataChain {
+		resp201.CertificateHashDataChain = append(resp201.CertificateHashDataChain, types201.CertificateHashDataChainType{
+			CertificateHashData: newCertificateHashData201(hashData),
+			CertificateType:     certType,
+		})
+	}
+
+	return g.Handler201.HandleCallResult(ctx, chargeStationId, req201, resp201, state)
+}
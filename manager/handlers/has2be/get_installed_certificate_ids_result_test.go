@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlersHasToBe "github.com/thoughtworks/maeve-csms/manager/handlers/has2be"
+	typesHasToBe "github.com/thoughtworks/maeve-csms/manager/ocpp/has2be"
+	types201 "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"testing"
+)
+
+func TestGetInstalledCertificateIdsResult(t *testing.T) {
+	handler201 := &recordingCallResultHandler{}
+	h := handlersHasToBe.GetInstalledCertificateIdsResultHandler{Handler201: handler201}
+
+	req := &typesHasToBe.GetInstalledCertificateIdsRequestJson{
+		TypeOfCertificate: []typesHasToBe.GetCertificateIdUseEnumType{typesHasToBe.GetCertificateIdUseEnumTypeV2GRootCertificate},
+	}
+	resp := &typesHasToBe.GetInstalledCertificateIdsResponseJson{
+		Status: typesHasToBe.GetInstalledCertificateStatusEnumTypeAccepted,
+		CertificateHashDataChain: []typesHasToBe.CertificateHashDataType{
+			{
+				HashAlgorithm:  typesHasToBe.HashAlgorithmEnumTypeSHA256,
+				IssuerKeyHash:  "key-hash",
+				IssuerNameHash: "name-hash",
+				SerialNumber:   "serial-number",
+			},
+		},
+	}
+
+	err := h.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, &types201.GetInstalledCertificateIdsRequestJson{
+		CertificateType: []types201.GetCertificateIdUseEnumType{types201.GetCertificateIdUseEnumTypeV2GRootCertificate},
+	}, handler201.request)
+	assert.Equal(t, &types201.GetInstalledCertificateIdsResponseJson{
+		Status: types201.GetInstalledCertificateStatusEnumTypeAccepted,
+		CertificateHashDataChain: []types201.CertificateHashDataChainType{
+			{
+				CertificateType: types201.GetCertificateIdUseEnumTypeV2GRootCertificate,
+				CertificateHashData: types201.CertificateHashDataType{
+					HashAlgorithm:  types201.HashAlgorithmEnumTypeSHA256,
+					IssuerKeyHash:  "key-hash",
+					IssuerNameHash: "name-hash",
+					SerialNumber:   "serial-number",
+				},
+			},
+		},
+	}, handler201.response)
+}
+
+func TestGetInstalledCertificateIdsResultWithAllTypes(t *testing.T) {
+	handler201 := &recordingCallResultHandler{}
+	h := handlersHasToBe.GetInstalledCertificateIdsResultHandler{Handler201: handler201}
+
+	req := &typesHasToBe.GetInstalledCertificateIdsRequestJson{}
+	resp := &typesHasToBe.GetInstalledCertificateIdsResponseJson{
+		Status: typesHasToBe.GetInstalledCertificateStatusEnumTypeAccepted,
+		CertificateHashDataChain: []typesHasToBe.CertificateHashDataType{
+			{
+				HashAlgorithm:  typesHasToBe.HashAlgorithmEnumTypeSHA256,
+				IssuerKeyHash:  "key-hash",
+				IssuerNameHash: "name-hash",
+				SerialNumber:   "serial-number",
+			},
+		},
+	}
+
+	err := h.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, &types201.GetInstalledCertificateIdsRequestJson{}, handler201.request)
+	got := handler201.response.(*types201.GetInstalledCertificateIdsResponseJson)
+	require.Len(t, got.CertificateHashDataChain, 1)
+	assert.Equal(t, types201.GetCertificateIdUseEnumType(""), got.CertificateHashDataChain[0].CertificateType)
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	typesHasToBe "github.com/thoughtworks/maeve-csms/manager/ocpp/has2be"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+)
+
+type InstallCertificateErrorHandler struct {
+	Handler201 handlers.CallErrorHandler
+}
+
+func (i InstallCertificateErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*typesHasToBe.InstallCertificateRequestJson)
+
+	req201, err := newInstallCertificateRequest201(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return i.Handler201.HandleCallError(ctx, chargeStationId, req201, errorCode, errorDescription, state)
+}
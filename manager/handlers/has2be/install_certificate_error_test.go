@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be_test
+
+import (
+	"context"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlersHasToBe "github.com/thoughtworks/maeve-csms/manager/handlers/has2be"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	typesHasToBe "github.com/thoughtworks/maeve-csms/manager/ocpp/has2be"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestInstallCertificateError(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	h := handlersHasToBe.InstallCertificateErrorHandler{
+		Handler201: handlers201.InstallCertificateErrorHandler{Store: engine},
+	}
+
+	req := &typesHasToBe.InstallCertificateRequestJson{
+		Certificate:     hex.EncodeToString([]byte("test")),
+		CertificateType: typesHasToBe.InstallCertificateUseEnumTypeCSMSRootCertificate,
+	}
+
+	err := h.HandleCallError(context.Background(), "cs001", req, transport.ErrorNotImplemented, "not implemented", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationInstallCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Len(t, got.Certificates, 1)
+	assert.Equal(t, store.CertificateTypeCSMS, got.Certificates[0].CertificateType)
+	assert.Equal(t, store.CertificateInstallationErrored, got.Certificates[0].CertificateInstallationStatus)
+	assert.Equal(t, string(transport.ErrorNotImplemented), got.Certificates[0].ErrorCode)
+}
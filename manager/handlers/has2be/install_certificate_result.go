@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	typesHasToBe "github.com/thoughtworks/maeve-csms/manager/ocpp/has2be"
+	types201 "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type InstallCertificateResultHandler struct {
+	Handler201 handlers.CallResultHandler
+}
+
+func (i InstallCertificateResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*typesHasToBe.InstallCertificateRequestJson)
+	resp := response.(*typesHasToBe.InstallCertificateResponseJson)
+
+	req201, err := newInstallCertificateRequest201(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return i.Handler201.HandleCallResult(ctx, chargeStationId, req201, &types201.InstallCertificateResponseJson{
+		Status: types201.InstallCertificateStatusEnumType(resp.Status),
+	}, state)
+}
+
+func newInstallCertificateRequest201(ctx context.Context, req *typesHasToBe.InstallCertificateRequestJson) (*types201.InstallCertificateRequestJson, error) {
+	certificate, inputFormat, err := normalizeCertificateEncoding(req.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("install_certificate.input_format", inputFormat))
+
+	return &types201.InstallCertificateRequestJson{
+		Certificate:     certificate,
+		CertificateType: types201.InstallCertificateUseEnumType(req.CertificateType),
+	}, nil
+}
+
+// normalizeCertificateEncoding returns the certificate PEM encoded: the has2be extension
+// specifies a hex encoded DER certificate, but some charge stations expect PEM (as in OCPP 2.0.1)
+func normalizeCertificateEncoding(certificate string) (string, string, error) {
+	if pemDecoded, _ := pem.Decode([]byte(certificate)); pemDecoded == nil {
+		der, err := hex.DecodeString(certificate)
+		if err != nil {
+			return "", "", fmt.Errorf("decoding hex certificate: %w", err)
+		}
+		pemBlock := pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: der,
+		}
+		return string(pem.EncodeToMemory(&pemBlock)), "hex", nil
+	}
+
+	return certificate, "pem", nil
+}
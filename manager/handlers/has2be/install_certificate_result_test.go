@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlersHasToBe "github.com/thoughtworks/maeve-csms/manager/handlers/has2be"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	typesHasToBe "github.com/thoughtworks/maeve-csms/manager/ocpp/has2be"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestInstallCertificateResultWithHexCertificate(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	h := handlersHasToBe.InstallCertificateResultHandler{
+		Handler201: handlers201.InstallCertificateResultHandler{Store: engine},
+	}
+
+	der := []byte("test")
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	certId, err := handlers201.GetCertificateId(pemData)
+	require.NoError(t, err)
+
+	req := &typesHasToBe.InstallCertificateRequestJson{
+		Certificate:     hex.EncodeToString(der),
+		CertificateType: typesHasToBe.InstallCertificateUseEnumTypeV2GRootCertificate,
+	}
+	resp := &typesHasToBe.InstallCertificateResponseJson{
+		Status: typesHasToBe.InstallCertificateStatusEnumTypeAccepted,
+	}
+
+	err = h.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationInstallCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Len(t, got.Certificates, 1)
+	assert.Equal(t, &store.ChargeStationInstallCertificate{
+		CertificateType:               store.CertificateTypeV2G,
+		CertificateId:                 certId,
+		CertificateData:               pemData,
+		CertificateInstallationStatus: store.CertificateInstallationAccepted,
+	}, got.Certificates[0])
+}
+
+func TestInstallCertificateResultWithPemCertificate(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	h := handlersHasToBe.InstallCertificateResultHandler{
+		Handler201: handlers201.InstallCertificateResultHandler{Store: engine},
+	}
+
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("test")}))
+	req := &typesHasToBe.InstallCertificateRequestJson{
+		Certificate:     pemData,
+		CertificateType: typesHasToBe.InstallCertificateUseEnumTypeMORootCertificate,
+	}
+	resp := &typesHasToBe.InstallCertificateResponseJson{
+		Status: typesHasToBe.InstallCertificateStatusEnumTypeRejected,
+	}
+
+	err := h.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationInstallCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Len(t, got.Certificates, 1)
+	assert.Equal(t, store.CertificateTypeMO, got.Certificates[0].CertificateType)
+	assert.Equal(t, pemData, got.Certificates[0].CertificateData)
+	assert.Equal(t, store.CertificateInstallationRejected, got.Certificates[0].CertificateInstallationStatus)
+}
+
+func TestInstallCertificateResultWithInvalidCertificate(t *testing.T) {
+	h := handlersHasToBe.InstallCertificateResultHandler{
+		Handler201: handlers201.InstallCertificateResultHandler{Store: inmemory.NewStore(clock.RealClock{})},
+	}
+
+	req := &typesHasToBe.InstallCertificateRequestJson{
+		Certificate:     "not-hex",
+		CertificateType: typesHasToBe.InstallCertificateUseEnumTypeV2GRootCertificate,
+	}
+	resp := &typesHasToBe.InstallCertificateResponseJson{
+		Status: typesHasToBe.InstallCertificateStatusEnumTypeAccepted,
+	}
+
+	err := h.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	assert.ErrorContains(t, err, "decoding hex certificate")
+}
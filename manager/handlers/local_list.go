@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+// LocalListUpdate is a SendLocalList request independent of the OCPP version. Entries
+// without a status are removed from the list.
+type LocalListUpdate struct {
+	Full    bool
+	Version int
+	Entries []*store.LocalListEntry
+}
+
+// RecordLocalListUpdateResult records the charge station's response to a SendLocalList request.
+// If the update was accepted then the version and entries of the list held by the charge station
+// are updated.
+func RecordLocalListUpdateResult(ctx context.Context, localListStore store.LocalListStore, clock clock.PassiveClock, chargeStationId string, update LocalListUpdate, status store.LocalListStatus) error {
+	localList, err := localListStore.LookupChargeStationLocalList(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station local list: %w", err)
+	}
+	if localList == nil {
+		// the list is no longer managed
+		return nil
+	}
+
+	if status == store.LocalListStatusAccepted {
+		if update.Version <= localList.Version {
+			// the response to an update that has already been applied
+			return nil
+		}
+		if update.Full || localList.Entries == nil {
+			localList.Entries = make(map[string]*store.LocalListEntry, len(update.Entries))
+		}
+		for _, entry := range update.Entries {
+			if entry.Status == "" {
+				delete(localList.Entries, entry.IdToken)
+			} else {
+				localList.Entries[entry.IdToken] = entry
+			}
+		}
+		localList.Version = update.Version
+		// any remaining changes that did not fit in the update are sent straight away
+		localList.SendAfter = time.Time{}
+	}
+
+	localList.Status = status
+	localList.ErrorCode = ""
+	localList.ErrorDescription = ""
+	localList.UpdatedAt = clock.Now()
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("local_list.status", string(status)))
+
+	err = localListStore.SetChargeStationLocalList(ctx, chargeStationId, localList)
+	if err != nil {
+		return fmt.Errorf("set charge station local list: %w", err)
+	}
+	return nil
+}
+
+// RecordLocalListVersion records the version of the list held by the charge station in response
+// to a GetLocalListVersion request. If it differs from the version that was last accepted by the
+// charge station then the status is set to VersionMismatch so that the full list will be sent.
+func RecordLocalListVersion(ctx context.Context, localListStore store.LocalListStore, clock clock.PassiveClock, chargeStationId string, version int) error {
+	localList, err := localListStore.LookupChargeStationLocalList(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station local list: %w", err)
+	}
+	if localList == nil {
+		return nil
+	}
+	if localList.Status != store.LocalListStatusVersionUnknown && localList.Status != store.LocalListStatusVersionCheckPending {
+		// an update has been sent since the version was requested
+		return nil
+	}
+
+	switch {
+	case version < 0:
+		// OCPP 1.6 uses -1 to indicate that the local list is disabled
+		localList.Status = store.LocalListStatusNotSupported
+	case version == localList.Version:
+		localList.Status = store.LocalListStatusAccepted
+	default:
+		localList.Status = store.LocalListStatusVersionMismatch
+	}
+	localList.ErrorCode = ""
+	localList.ErrorDescription = ""
+	localList.UpdatedAt = clock.Now()
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("local_list.status", string(localList.Status)))
+
+	err = localListStore.SetChargeStationLocalList(ctx, chargeStationId, localList)
+	if err != nil {
+		return fmt.Errorf("set charge station local list: %w", err)
+	}
+	return nil
+}
+
+// LocalListErrorHandler records a CallError received in response to a SendLocalList or
+// GetLocalListVersion request for any OCPP version
+type LocalListErrorHandler struct {
+	Store store.LocalListStore
+	Clock clock.PassiveClock
+}
+
+func (h LocalListErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, _ ocpp.Request, errorCode transport.ErrorCode, errorDescription string, _ any) error {
+	localList, err := h.Store.LookupChargeStationLocalList(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station local list: %w", err)
+	}
+	if localList == nil {
+		return nil
+	}
+
+	localList.Status = store.LocalListStatusErrored
+	if errorCode == transport.ErrorNotImplemented || errorCode == transport.ErrorNotSupported {
+		// the charge station will not manage a local list
+		localList.Status = store.LocalListStatusNotSupported
+	}
+	localList.ErrorCode = string(errorCode)
+	localList.ErrorDescription = errorDescription
+	localList.UpdatedAt = h.Clock.Now()
+
+	err = h.Store.SetChargeStationLocalList(ctx, chargeStationId, localList)
+	if err != nil {
+		return fmt.Errorf("set charge station local list: %w", err)
+	}
+	return nil
+}
+
+// MarkLocalListVersionUnknown is used when a charge station boots: the charge station may have
+// lost (or been given) a different list so the version that it holds must be checked before any
+// further differential updates are sent.
+func MarkLocalListVersionUnknown(ctx context.Context, localListStore store.LocalListStore, clock clock.PassiveClock, chargeStationId string) error {
+	localList, err := localListStore.LookupChargeStationLocalList(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station local list: %w", err)
+	}
+	if localList == nil {
+		return nil
+	}
+
+	localList.Status = store.LocalListStatusVersionUnknown
+	localList.SendAfter = time.Time{}
+	localList.UpdatedAt = clock.Now()
+
+	err = localListStore.SetChargeStationLocalList(ctx, chargeStationId, localList)
+	if err != nil {
+		return fmt.Errorf("set charge station local list: %w", err)
+	}
+	return nil
+}
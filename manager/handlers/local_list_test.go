@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestLocalListErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	ctx := context.Background()
+
+	require.NoError(t, engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Version: 1,
+		Entries: map[string]*store.LocalListEntry{},
+		Status:  store.LocalListStatusPending,
+	}))
+
+	handler := handlers.LocalListErrorHandler{
+		Store: engine,
+		Clock: clk,
+	}
+	err := handler.HandleCallError(ctx, "cs001", &ocpp201.SendLocalListRequestJson{}, transport.ErrorInternalError, "internal error", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationLocalList{
+		ChargeStationId:  "cs001",
+		Version:          1,
+		Entries:          map[string]*store.LocalListEntry{},
+		Status:           store.LocalListStatusErrored,
+		ErrorCode:        "InternalError",
+		ErrorDescription: "internal error",
+		UpdatedAt:        clk.Now(),
+	}, got)
+}
+
+func TestLocalListErrorHandlerWhenNotImplemented(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	require.NoError(t, engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Entries: map[string]*store.LocalListEntry{},
+		Status:  store.LocalListStatusVersionCheckPending,
+	}))
+
+	handler := handlers.LocalListErrorHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+	err := handler.HandleCallError(ctx, "cs001", &ocpp201.GetLocalListVersionRequestJson{}, transport.ErrorNotImplemented, "not implemented", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.LocalListStatusNotSupported, got.Status)
+}
@@ -4,6 +4,8 @@ package ocpp16
 
 import (
 	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -18,6 +20,9 @@ type BootNotificationHandler struct {
 	Clock               clock.PassiveClock
 	RuntimeDetailsStore store.ChargeStationRuntimeDetailsStore
 	SettingsStore       store.ChargeStationSettingsStore
+	ChargeStationStore  store.ChargeStationStore
+	TemplateStore       store.ConfigurationTemplateStore
+	LocalListStore      store.LocalListStore
 	HeartbeatInterval   int
 }
 
@@ -38,9 +43,12 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 		span.SetAttributes(attribute.String("boot.firmware", *req.FirmwareVersion))
 	}
 
-	err := b.RuntimeDetailsStore.SetChargeStationRuntimeDetails(ctx, chargeStationId, &store.ChargeStationRuntimeDetails{
+	details := &store.ChargeStationRuntimeDetails{
 		OcppVersion: "1.6",
-	})
+		Vendor:      req.ChargePointVendor,
+		Model:       req.ChargePointModel,
+	}
+	err := b.RuntimeDetailsStore.SetChargeStationRuntimeDetails(ctx, chargeStationId, details)
 	if err != nil {
 		return nil, err
 	}
@@ -68,9 +76,47 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 		}
 	}
 
+	err = b.applyConfigurationTemplates(ctx, chargeStationId, details)
+	if err != nil {
+		return nil, err
+	}
+
+	err = handlers.MarkLocalListVersionUnknown(ctx, b.LocalListStore, b.Clock, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.BootNotificationResponseJson{
 		CurrentTime: b.Clock.Now().Format(time.RFC3339),
 		Interval:    b.HeartbeatInterval,
 		Status:      types.BootNotificationResponseJsonStatusAccepted,
 	}, nil
 }
+
+func (b BootNotificationHandler) applyConfigurationTemplates(ctx context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
+	templates, err := b.TemplateStore.ListConfigurationTemplates(ctx)
+	if err != nil {
+		return fmt.Errorf("list configuration templates: %w", err)
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
+	cs, err := b.ChargeStationStore.LookupChargeStation(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station: %w", err)
+	}
+	if cs == nil {
+		// an unregistered charge station can still match templates by vendor and model
+		cs = &store.ChargeStation{Id: chargeStationId}
+	}
+
+	queued, err := handlers.ApplyConfigurationTemplates(ctx, b.SettingsStore, templates, cs, details)
+	if err != nil {
+		return err
+	}
+	if len(queued) > 0 {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.StringSlice("boot.queued_settings", queued))
+	}
+	return nil
+}
@@ -34,6 +34,9 @@ func TestBootNotificationHandler(t *testing.T) {
 		Clock:               clockTest.NewFakePassiveClock(now),
 		RuntimeDetailsStore: engine,
 		SettingsStore:       engine,
+		ChargeStationStore:  engine,
+		TemplateStore:       engine,
+		LocalListStore:      engine,
 		HeartbeatInterval:   10,
 	}
 
@@ -65,3 +68,96 @@ func TestBootNotificationHandler(t *testing.T) {
 		assert.NotEqual(t, store.ChargeStationSettingStatusRebootRequired, v.Status)
 	}
 }
+
+func TestBootNotificationHandlerAppliesConfigurationTemplates(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{Id: "cs001", LocationId: "loc001", Tags: []string{"depot"}})
+	require.NoError(t, err)
+	err = engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"HeartbeatInterval": {Value: "60", Status: store.ChargeStationSettingStatusRebootRequired},
+		},
+	})
+	require.NoError(t, err)
+	tag := "depot"
+	err = engine.SetConfigurationTemplate(ctx, &store.ConfigurationTemplate{
+		Id:  "depot",
+		Tag: &tag,
+		Settings: map[string]string{
+			"HeartbeatInterval":        "60",
+			"MeterValueSampleInterval": "30",
+		},
+	})
+	require.NoError(t, err)
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clock.RealClock{},
+		RuntimeDetailsStore: engine,
+		SettingsStore:       engine,
+		ChargeStationStore:  engine,
+		TemplateStore:       engine,
+		LocalListStore:      engine,
+		HeartbeatInterval:   10,
+	}
+
+	req := &types.BootNotificationJson{
+		ChargePointVendor: "VendorX",
+		ChargePointModel:  "ModelY",
+	}
+
+	_, err = handler.HandleCall(ctx, "cs001", req)
+	require.NoError(t, err)
+
+	details, err := engine.LookupChargeStationRuntimeDetails(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+		Vendor:      "VendorX",
+		Model:       "ModelY",
+	}, *details)
+
+	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	statuses := make(map[string]store.ChargeStationSettingStatus)
+	for name, setting := range settings.Settings {
+		statuses[name] = setting.Status
+	}
+	assert.Equal(t, map[string]store.ChargeStationSettingStatus{
+		"HeartbeatInterval":        store.ChargeStationSettingStatusAccepted,
+		"MeterValueSampleInterval": store.ChargeStationSettingStatusPending,
+	}, statuses)
+}
+
+func TestBootNotificationHandlerMarksLocalListVersionUnknown(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Version:   3,
+		Entries:   map[string]*store.LocalListEntry{},
+		Status:    store.LocalListStatusPending,
+		SendAfter: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clock.RealClock{},
+		RuntimeDetailsStore: engine,
+		SettingsStore:       engine,
+		ChargeStationStore:  engine,
+		TemplateStore:       engine,
+		LocalListStore:      engine,
+		HeartbeatInterval:   10,
+	}
+
+	_, err = handler.HandleCall(ctx, "cs001", &types.BootNotificationJson{})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.LocalListStatusVersionUnknown, got.Status)
+	assert.Equal(t, 3, got.Version)
+	assert.True(t, got.SendAfter.IsZero())
+}
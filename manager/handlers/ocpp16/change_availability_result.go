@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type ChangeAvailabilityResultHandler struct {
+	Store store.ChargeStationOperationStore
+	Clock clock.PassiveClock
+}
+
+func (h ChangeAvailabilityResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*ocpp16.ChangeAvailabilityJson)
+	resp := response.(*ocpp16.ChangeAvailabilityResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("change_availability.connector_id", req.ConnectorId),
+		attribute.String("change_availability.type", string(req.Type)),
+		attribute.String("change_availability.status", string(resp.Status)))
+
+	return handlers.RecordOperationResult(ctx, h.Store, h.Clock, chargeStationId, "ChangeAvailability", string(resp.Status), resp)
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ChangeConfigurationErrorHandler struct {
+	SettingsStore      store.ChargeStationSettingsStore
+	ChargeStationStore store.ChargeStationStore
+}
+
+func (c ChangeConfigurationErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*ocpp16.ChangeConfigurationJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("setting.key", req.Key),
+		attribute.String("setting.value", store.RetainedSettingValue(req.Key, req.Value)))
+
+	err := c.SettingsStore.UpdateChargeStationSettings(ctx, chargeStationId, &store.ChargeStationSettings{
+		ChargeStationId: chargeStationId,
+		Settings: map[string]*store.ChargeStationSetting{
+			req.Key: {
+				Value:            store.RetainedSettingValue(req.Key, req.Value),
+				Status:           store.ChargeStationSettingStatusRejected,
+				ErrorCode:        string(errorCode),
+				ErrorDescription: errorDescription,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("update charge station settings: %w", err)
+	}
+
+	if req.Key == store.Ocpp16BasicAuthPasswordKey {
+		return handlers.ClearPendingPassword(ctx, c.ChargeStationStore, chargeStationId)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+func TestChangeConfigurationErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	err := engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"HeartbeatInterval": {Value: "60", Status: store.ChargeStationSettingStatusPending},
+		},
+	})
+	require.NoError(t, err)
+
+	handler := ocpp16.ChangeConfigurationErrorHandler{SettingsStore: engine}
+
+	tracer, exporter := testutil.GetTracer()
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		err = handler.HandleCallError(ctx, "cs001", &types.ChangeConfigurationJson{
+			Key:   "HeartbeatInterval",
+			Value: "60",
+		}, transport.ErrorPropertyConstraintViolation, "value out of range", nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"setting.key":   "HeartbeatInterval",
+		"setting.value": "60",
+	})
+
+	got, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	setting := got.Settings["HeartbeatInterval"]
+	assert.Equal(t, store.ChargeStationSettingStatusRejected, setting.Status)
+	assert.Equal(t, "PropertyConstraintViolation", setting.ErrorCode)
+	assert.Equal(t, "value out of range", setting.ErrorDescription)
+}
+
+func TestChangeConfigurationErrorHandlerClearsPendingPassword(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:                          "cs001",
+		Base64SHA256Password:        "DEADBEEF",
+		PendingBase64SHA256Password: "CAFEBABE",
+	})
+	require.NoError(t, err)
+
+	handler := ocpp16.ChangeConfigurationErrorHandler{SettingsStore: engine, ChargeStationStore: engine}
+
+	tracer, exporter := testutil.GetTracer()
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		err = handler.HandleCallError(ctx, "cs001", &types.ChangeConfigurationJson{
+			Key:   "AuthorizationKey",
+			Value: "secret",
+		}, transport.ErrorInternalError, "failed", nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"setting.key":   "AuthorizationKey",
+		"setting.value": "",
+	})
+
+	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "", settings.Settings["AuthorizationKey"].Value)
+
+	cs, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "DEADBEEF", cs.Base64SHA256Password)
+	assert.Equal(t, "", cs.PendingBase64SHA256Password)
+}
@@ -14,8 +14,9 @@ import (
 )
 
 type ChangeConfigurationResultHandler struct {
-	SettingsStore store.ChargeStationSettingsStore
-	CallMaker     handlers.CallMaker
+	SettingsStore      store.ChargeStationSettingsStore
+	ChargeStationStore store.ChargeStationStore
+	CallMaker          handlers.CallMaker
 }
 
 func (c ChangeConfigurationResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
@@ -25,14 +26,14 @@ func (c ChangeConfigurationResultHandler) HandleCallResult(ctx context.Context,
 	span := trace.SpanFromContext(ctx)
 	span.SetAttributes(
 		attribute.String("setting.key", req.Key),
-		attribute.String("setting.value", req.Value),
+		attribute.String("setting.value", store.RetainedSettingValue(req.Key, req.Value)),
 		attribute.String("setting.status", string(resp.Status)))
 
 	err := c.SettingsStore.UpdateChargeStationSettings(ctx, chargeStationId, &store.ChargeStationSettings{
 		ChargeStationId: chargeStationId,
 		Settings: map[string]*store.ChargeStationSetting{
 			req.Key: {
-				Value:  req.Value,
+				Value:  store.RetainedSettingValue(req.Key, req.Value),
 				Status: store.ChargeStationSettingStatus(resp.Status),
 			},
 		},
@@ -41,6 +42,15 @@ func (c ChangeConfigurationResultHandler) HandleCallResult(ctx context.Context,
 		return fmt.Errorf("update charge station settings: %w", err)
 	}
 
+	if req.Key == store.Ocpp16BasicAuthPasswordKey &&
+		resp.Status != ocpp16.ChangeConfigurationResponseJsonStatusAccepted &&
+		resp.Status != ocpp16.ChangeConfigurationResponseJsonStatusRebootRequired {
+		err = handlers.ClearPendingPassword(ctx, c.ChargeStationStore, chargeStationId)
+		if err != nil {
+			return err
+		}
+	}
+
 	settings, err := c.SettingsStore.LookupChargeStationSettings(ctx, chargeStationId)
 	if err != nil {
 		return fmt.Errorf("lookup charge station settings: %w", err)
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type ClearCacheResultHandler struct {
+	Store store.ChargeStationOperationStore
+	Clock clock.PassiveClock
+}
+
+func (h ClearCacheResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	resp := response.(*ocpp16.ClearCacheResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("clear_cache.status", string(resp.Status)))
+
+	return handlers.RecordOperationResult(ctx, h.Store, h.Clock, chargeStationId, "ClearCache", string(resp.Status), resp)
+}
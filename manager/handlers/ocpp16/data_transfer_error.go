@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+)
+
+type DataTransferErrorHandler struct {
+	SchemaFS        fs.FS
+	CallErrorRoutes map[string]map[string]handlers.CallErrorRoute
+}
+
+func (d DataTransferErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*types.DataTransferJson)
+
+	messageId := ""
+	if req.MessageId != nil {
+		messageId = *req.MessageId
+	}
+	slog.Info("data transfer error",
+		slog.String("vendorId", req.VendorId), slog.String("messageId", messageId),
+		slog.String("errorCode", string(errorCode)))
+
+	vendorMap, ok := d.CallErrorRoutes[req.VendorId]
+	if !ok {
+		return fmt.Errorf("unknown data transfer error vendor: %s", req.VendorId)
+	}
+	route, ok := vendorMap[messageId]
+	if !ok {
+		return fmt.Errorf("unknown data transfer error message id: %s", messageId)
+	}
+
+	var dataTransferRequest ocpp.Request
+	if req.Data != nil {
+		data := []byte(*req.Data)
+		err := schemas.Validate(data, d.SchemaFS, route.RequestSchema)
+		if err != nil {
+			return fmt.Errorf("validating %s:%s data transfer error request data: %w", req.VendorId, messageId, err)
+		}
+		dataTransferRequest = route.NewRequest()
+		err = json.Unmarshal(data, &dataTransferRequest)
+		if err != nil {
+			return fmt.Errorf("unmarshalling %s:%s data transfer request data: %w", req.VendorId, messageId, err)
+		}
+	}
+
+	return route.Handler.HandleCallError(ctx, chargeStationId, dataTransferRequest, errorCode, errorDescription, state)
+}
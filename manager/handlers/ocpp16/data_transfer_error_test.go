@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+func TestDataTransferErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	handler := ocpp16.DataTransferErrorHandler{
+		SchemaFS: schemas.OcppSchemas,
+		CallErrorRoutes: map[string]map[string]handlers.CallErrorRoute{
+			"org.openchargealliance.iso15118pnc": {
+				"InstallCertificate": {
+					NewRequest:    func() ocpp.Request { return new(ocpp201.InstallCertificateRequestJson) },
+					RequestSchema: "ocpp201/InstallCertificateRequest.json",
+					Handler:       handlers201.InstallCertificateErrorHandler{Store: engine},
+				},
+			},
+		},
+	}
+
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("test")})
+	certId, err := handlers201.GetCertificateId(string(pemBytes))
+	require.NoError(t, err)
+
+	messageId := "InstallCertificate"
+	reqData, err := json.Marshal(&ocpp201.InstallCertificateRequestJson{
+		CertificateType: ocpp201.InstallCertificateUseEnumTypeV2GRootCertificate,
+		Certificate:     string(pemBytes),
+	})
+	require.NoError(t, err)
+	data := string(reqData)
+	err = handler.HandleCallError(ctx, "cs001", &types.DataTransferJson{
+		VendorId:  "org.openchargealliance.iso15118pnc",
+		MessageId: &messageId,
+		Data:      &data,
+	}, transport.ErrorGenericError, "failed", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationInstallCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	require.Len(t, got.Certificates, 1)
+	assert.Equal(t, certId, got.Certificates[0].CertificateId)
+	assert.Equal(t, store.CertificateInstallationErrored, got.Certificates[0].CertificateInstallationStatus)
+	assert.Equal(t, "GenericError", got.Certificates[0].ErrorCode)
+	assert.Equal(t, "failed", got.Certificates[0].ErrorDescription)
+}
+
+func TestDataTransferErrorHandlerWithUnknownVendor(t *testing.T) {
+	handler := ocpp16.DataTransferErrorHandler{
+		SchemaFS:        schemas.OcppSchemas,
+		CallErrorRoutes: map[string]map[string]handlers.CallErrorRoute{},
+	}
+
+	err := handler.HandleCallError(context.Background(), "cs001", &types.DataTransferJson{
+		VendorId: "unknown",
+	}, transport.ErrorGenericError, "failed", nil)
+	assert.ErrorContains(t, err, "unknown data transfer error vendor: unknown")
+}
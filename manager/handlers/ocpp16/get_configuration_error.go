@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type GetConfigurationErrorHandler struct {
+	VariablesStore store.ChargeStationVariablesStore
+}
+
+func (h GetConfigurationErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*ocpp16.GetConfigurationJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.StringSlice("get_configuration.keys", req.Key))
+
+	if len(req.Key) == 0 {
+		return nil
+	}
+
+	variables := make(map[string]*store.ChargeStationVariable)
+	for _, key := range req.Key {
+		variables[key] = &store.ChargeStationVariable{
+			Status:           store.ChargeStationVariableStatusRejected,
+			ErrorCode:        string(errorCode),
+			ErrorDescription: errorDescription,
+		}
+	}
+
+	err := h.VariablesStore.UpdateChargeStationVariables(ctx, chargeStationId, &store.ChargeStationVariables{
+		ChargeStationId: chargeStationId,
+		Variables:       variables,
+	})
+	if err != nil {
+		return fmt.Errorf("update charge station variables: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+func TestGetConfigurationErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	handler := ocpp16.GetConfigurationErrorHandler{VariablesStore: engine}
+
+	err := handler.HandleCallError(ctx, "cs001", &types.GetConfigurationJson{
+		Key: []string{"HeartbeatInterval"},
+	}, transport.ErrorInternalError, "oops", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationVariables(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*store.ChargeStationVariable{
+		"HeartbeatInterval": {
+			Status:           store.ChargeStationVariableStatusRejected,
+			ErrorCode:        "InternalError",
+			ErrorDescription: "oops",
+		},
+	}, got.Variables)
+}
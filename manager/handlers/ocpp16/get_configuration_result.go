@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+type GetConfigurationResultHandler struct {
+	VariablesStore store.ChargeStationVariablesStore
+	SettingsStore  store.ChargeStationSettingsStore
+}
+
+func (h GetConfigurationResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*ocpp16.GetConfigurationJson)
+	resp := response.(*ocpp16.GetConfigurationResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	var knownKeys []string
+	for _, key := range resp.ConfigurationKey {
+		knownKeys = append(knownKeys, key.Key)
+	}
+	variables := GetConfigurationResultVariables(resp)
+
+	span.SetAttributes(
+		attribute.String("get_configuration.keys", strings.Join(req.Key, ",")),
+		attribute.String("get_configuration.known_keys", strings.Join(knownKeys, ",")),
+		attribute.String("get_configuration.unknown_keys", strings.Join(resp.UnknownKey, ",")))
+
+	if len(variables) == 0 {
+		return nil
+	}
+
+	err := h.VariablesStore.UpdateChargeStationVariables(ctx, chargeStationId, &store.ChargeStationVariables{
+		ChargeStationId: chargeStationId,
+		Variables:       variables,
+	})
+	if err != nil {
+		return fmt.Errorf("update charge station variables: %w", err)
+	}
+
+	drifted, err := handlers.DetectSettingsDrift(ctx, h.SettingsStore, chargeStationId, variables)
+	if err != nil {
+		return err
+	}
+	if len(drifted) > 0 {
+		span.SetAttributes(attribute.String("get_configuration.drifted", strings.Join(drifted, ",")))
+	}
+
+	return nil
+}
+
+// GetConfigurationResultVariables returns the configuration keys in the GetConfiguration response
+// keyed by name
+func GetConfigurationResultVariables(resp *ocpp16.GetConfigurationResponseJson) map[string]*store.ChargeStationVariable {
+	variables := make(map[string]*store.ChargeStationVariable)
+	for _, key := range resp.ConfigurationKey {
+		variables[key.Key] = &store.ChargeStationVariable{
+			Value:  key.Value,
+			Status: store.ChargeStationVariableStatusAccepted,
+		}
+	}
+	for _, key := range resp.UnknownKey {
+		variables[key] = &store.ChargeStationVariable{
+			Status: store.ChargeStationVariableStatusUnknownKey,
+		}
+	}
+	return variables
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers16 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestGetConfigurationResultHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := handlers16.GetConfigurationResultHandler{
+		VariablesStore: engine,
+		SettingsStore:  engine,
+	}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	value := "60"
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &ocpp16.GetConfigurationJson{
+			Key: []string{"HeartbeatInterval", "AuthorizeRemoteTxRequests", "Unknown"},
+		}
+		resp := &ocpp16.GetConfigurationResponseJson{
+			ConfigurationKey: []ocpp16.GetConfigurationResponseJsonConfigurationKeyElem{
+				{Key: "HeartbeatInterval", Value: &value},
+				{Key: "AuthorizeRemoteTxRequests", Readonly: true},
+			},
+			UnknownKey: []string{"Unknown"},
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"get_configuration.keys":         "HeartbeatInterval,AuthorizeRemoteTxRequests,Unknown",
+		"get_configuration.known_keys":   "HeartbeatInterval,AuthorizeRemoteTxRequests",
+		"get_configuration.unknown_keys": "Unknown",
+	})
+
+	got, err := engine.LookupChargeStationVariables(ctx, "cs001")
+	require.NoError(t, err)
+
+	want := &store.ChargeStationVariables{
+		ChargeStationId: "cs001",
+		Variables: map[string]*store.ChargeStationVariable{
+			"HeartbeatInterval":         {Value: &value, Status: store.ChargeStationVariableStatusAccepted},
+			"AuthorizeRemoteTxRequests": {Status: store.ChargeStationVariableStatusAccepted},
+			"Unknown":                   {Status: store.ChargeStationVariableStatusUnknownKey},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestGetConfigurationResultHandlerDetectsDrift(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := handlers16.GetConfigurationResultHandler{
+		VariablesStore: engine,
+		SettingsStore:  engine,
+	}
+
+	ctx := context.Background()
+
+	err := engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"HeartbeatInterval": {Value: "60", Status: store.ChargeStationSettingStatusAccepted},
+		},
+	})
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+
+	value := "300"
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &ocpp16.GetConfigurationJson{
+			Key: []string{"HeartbeatInterval"},
+		}
+		resp := &ocpp16.GetConfigurationResponseJson{
+			ConfigurationKey: []ocpp16.GetConfigurationResponseJsonConfigurationKeyElem{
+				{Key: "HeartbeatInterval", Value: &value},
+			},
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"get_configuration.keys":         "HeartbeatInterval",
+		"get_configuration.known_keys":   "HeartbeatInterval",
+		"get_configuration.unknown_keys": "",
+		"get_configuration.drifted":      "HeartbeatInterval",
+	})
+
+	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationSettingStatusDrifted, settings.Settings["HeartbeatInterval"].Status)
+}
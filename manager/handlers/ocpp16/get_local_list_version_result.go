@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type GetLocalListVersionResultHandler struct {
+	Store store.LocalListStore
+	Clock clock.PassiveClock
+}
+
+func (h GetLocalListVersionResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	resp := response.(*ocpp16.GetLocalListVersionResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(
+		attribute.Int("get_local_list_version.version_number", resp.ListVersion))
+
+	return handlers.RecordLocalListVersion(ctx, h.Store, h.Clock, chargeStationId, resp.ListVersion)
+}
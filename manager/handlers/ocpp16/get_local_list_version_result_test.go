@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestGetLocalListVersionResultHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp16.GetLocalListVersionResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+
+	err := engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Version: 2,
+		Entries: map[string]*store.LocalListEntry{},
+		Status:  store.LocalListStatusVersionCheckPending,
+	})
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		err := handler.HandleCallResult(ctx, "cs001", &types.GetLocalListVersionJson{},
+			&types.GetLocalListVersionResponseJson{ListVersion: 2}, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"get_local_list_version.version_number": 2,
+		"local_list.status":                     "Accepted",
+	})
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.LocalListStatusAccepted, got.Status)
+}
+
+func TestGetLocalListVersionResultHandlerWhenListIsDisabled(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp16.GetLocalListVersionResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+
+	err := engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Entries: map[string]*store.LocalListEntry{},
+		Status:  store.LocalListStatusVersionUnknown,
+	})
+	require.NoError(t, err)
+
+	err = handler.HandleCallResult(ctx, "cs001", &types.GetLocalListVersionJson{},
+		&types.GetLocalListVersionResponseJson{ListVersion: -1}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.LocalListStatusNotSupported, got.Status)
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestOperationResultHandlers(t *testing.T) {
+	tests := map[string]struct {
+		handler    func(store.Engine, clock.PassiveClock) handlers.CallResultHandler
+		request    ocpp.Request
+		response   ocpp.Response
+		wantStatus string
+		wantAttrs  map[string]any
+	}{
+		"Reset": {
+			handler: func(engine store.Engine, clk clock.PassiveClock) handlers.CallResultHandler {
+				return ocpp16.ResetResultHandler{Store: engine, Clock: clk}
+			},
+			request:    &types.ResetJson{Type: types.ResetJsonTypeSoft},
+			response:   &types.ResetResponseJson{Status: types.ResetResponseJsonStatusAccepted},
+			wantStatus: "Accepted",
+			wantAttrs: map[string]any{
+				"reset.type":   "Soft",
+				"reset.status": "Accepted",
+			},
+		},
+		"UnlockConnector": {
+			handler: func(engine store.Engine, clk clock.PassiveClock) handlers.CallResultHandler {
+				return ocpp16.UnlockConnectorResultHandler{Store: engine, Clock: clk}
+			},
+			request:    &types.UnlockConnectorJson{ConnectorId: 2},
+			response:   &types.UnlockConnectorResponseJson{Status: types.UnlockConnectorResponseJsonStatusUnlocked},
+			wantStatus: "Unlocked",
+			wantAttrs: map[string]any{
+				"unlock_connector.connector_id": 2,
+				"unlock_connector.status":       "Unlocked",
+			},
+		},
+		"ClearCache": {
+			handler: func(engine store.Engine, clk clock.PassiveClock) handlers.CallResultHandler {
+				return ocpp16.ClearCacheResultHandler{Store: engine, Clock: clk}
+			},
+			request:    &types.ClearCacheJson{},
+			response:   &types.ClearCacheResponseJson{Status: types.ClearCacheResponseJsonStatusRejected},
+			wantStatus: "Rejected",
+			wantAttrs: map[string]any{
+				"clear_cache.status": "Rejected",
+			},
+		},
+		"ChangeAvailability": {
+			handler: func(engine store.Engine, clk clock.PassiveClock) handlers.CallResultHandler {
+				return ocpp16.ChangeAvailabilityResultHandler{Store: engine, Clock: clk}
+			},
+			request:    &types.ChangeAvailabilityJson{ConnectorId: 1, Type: types.ChangeAvailabilityJsonTypeInoperative},
+			response:   &types.ChangeAvailabilityResponseJson{Status: types.ChangeAvailabilityResponseJsonStatusScheduled},
+			wantStatus: "Scheduled",
+			wantAttrs: map[string]any{
+				"change_availability.connector_id": 1,
+				"change_availability.type":         "Inoperative",
+				"change_availability.status":       "Scheduled",
+			},
+		},
+		"RemoteStopTransaction": {
+			handler: func(engine store.Engine, clk clock.PassiveClock) handlers.CallResultHandler {
+				return ocpp16.RemoteStopTransactionResultHandler{Store: engine, Clock: clk}
+			},
+			request:    &types.RemoteStopTransactionJson{TransactionId: 42},
+			response:   &types.RemoteStopTransactionResponseJson{Status: types.RemoteStopTransactionResponseJsonStatusAccepted},
+			wantStatus: "Accepted",
+			wantAttrs: map[string]any{
+				"remote_stop.transaction_id": 42,
+				"remote_stop.status":         "Accepted",
+			},
+		},
+	}
+
+	for operation, tc := range tests {
+		t.Run(operation, func(t *testing.T) {
+			engine := inmemory.NewStore(clock.RealClock{})
+			clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+			ctx := context.Background()
+
+			request, err := json.Marshal(tc.request)
+			require.NoError(t, err)
+			response, err := json.Marshal(tc.response)
+			require.NoError(t, err)
+
+			requestedAt := clk.Now().Add(-time.Minute)
+			err = engine.SetChargeStationOperation(ctx, "cs001", &store.ChargeStationOperation{
+				Operation:   operation,
+				Request:     string(request),
+				Status:      store.ChargeStationOperationStatusPending,
+				RequestedAt: requestedAt,
+				UpdatedAt:   requestedAt,
+			})
+			require.NoError(t, err)
+
+			tracer, exporter := testutil.GetTracer()
+
+			func() {
+				ctx, span := tracer.Start(ctx, `test`)
+				defer span.End()
+
+				err := tc.handler(engine, clk).HandleCallResult(ctx, "cs001", tc.request, tc.response, nil)
+				require.NoError(t, err)
+			}()
+
+			wantAttrs := map[string]any{"operation.status": tc.wantStatus}
+			for k, v := range tc.wantAttrs {
+				wantAttrs[k] = v
+			}
+			testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", wantAttrs)
+
+			got, err := engine.LookupChargeStationOperation(ctx, "cs001", operation)
+			require.NoError(t, err)
+			assert.Equal(t, &store.ChargeStationOperation{
+				ChargeStationId: "cs001",
+				Operation:       operation,
+				Request:         string(request),
+				Response:        string(response),
+				Status:          store.ChargeStationOperationStatus(tc.wantStatus),
+				RequestedAt:     requestedAt,
+				UpdatedAt:       clk.Now(),
+			}, got)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type ReserveNowResultHandler struct {
+	Store store.ReservationStore
+	Clock clock.PassiveClock
+}
+
+func (h ReserveNowResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*ocpp16.ReserveNowJson)
+	resp := response.(*ocpp16.ReserveNowResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("reserve_now.reservation_id", req.ReservationId),
+		attribute.String("reserve_now.status", string(resp.Status)))
+
+	return handlers.RecordReservationResult(ctx, h.Store, h.Clock, chargeStationId, req.ReservationId, string(resp.Status))
+}
+
+type ReserveNowErrorHandler struct {
+	Store store.ReservationStore
+	Clock clock.PassiveClock
+}
+
+func (h ReserveNowErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, _ any) error {
+	req := request.(*ocpp16.ReserveNowJson)
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("reserve_now.reservation_id", req.ReservationId))
+
+	return handlers.RecordReservationError(ctx, h.Store, h.Clock, chargeStationId, req.ReservationId, errorCode, errorDescription)
+}
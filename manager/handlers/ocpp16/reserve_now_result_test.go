@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func setPendingReservation(t *testing.T, engine store.ReservationStore, createdAt time.Time) *store.Reservation {
+	reservation := &store.Reservation{
+		ChargeStationId: "cs001",
+		ReservationId:   42,
+		IdToken:         "ABCD1234",
+		TokenType:       "ISO14443",
+		ExpiryDate:      createdAt.Add(time.Hour),
+		Status:          store.ReservationStatusPending,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+	}
+	err := engine.SetReservation(context.Background(), reservation)
+	require.NoError(t, err)
+	return reservation
+}
+
+func TestReserveNowResultHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp16.ReserveNowResultHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+	want := setPendingReservation(t, engine, clk.Now().Add(-time.Minute))
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		req := &types.ReserveNowJson{
+			ConnectorId:   0,
+			ExpiryDate:    want.ExpiryDate.Format(time.RFC3339),
+			IdTag:         "ABCD1234",
+			ReservationId: 42,
+		}
+		err := handler.HandleCallResult(ctx, "cs001", req, &types.ReserveNowResponseJson{Status: types.ReserveNowResponseJsonStatusOccupied}, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"reserve_now.reservation_id": 42,
+		"reserve_now.status":         "Occupied",
+		"reservation.status":         "Occupied",
+	})
+
+	got, err := engine.LookupReservation(ctx, "cs001", 42)
+	require.NoError(t, err)
+	want.Status = store.ReservationStatusOccupied
+	want.UpdatedAt = clk.Now()
+	assert.Equal(t, want, got)
+}
+
+func TestReserveNowErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp16.ReserveNowErrorHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+	want := setPendingReservation(t, engine, clk.Now().Add(-time.Minute))
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		req := &types.ReserveNowJson{
+			ConnectorId:   0,
+			ExpiryDate:    want.ExpiryDate.Format(time.RFC3339),
+			IdTag:         "ABCD1234",
+			ReservationId: 42,
+		}
+		err := handler.HandleCallError(ctx, "cs001", req, transport.ErrorNotSupported, "reservations not supported", nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"reserve_now.reservation_id": 42,
+		"reservation.status":         "Errored",
+	})
+
+	got, err := engine.LookupReservation(ctx, "cs001", 42)
+	require.NoError(t, err)
+	want.Status = store.ReservationStatusErrored
+	want.ErrorCode = "NotSupported"
+	want.ErrorDescription = "reservations not supported"
+	want.UpdatedAt = clk.Now()
+	assert.Equal(t, want, got)
+}
@@ -47,6 +47,9 @@ func NewRouter(emitter transport.Emitter,
 					Clock:               clk,
 					RuntimeDetailsStore: engine,
 					SettingsStore:       engine,
+					ChargeStationStore:  engine,
+					TemplateStore:       engine,
+					LocalListStore:      engine,
 					HeartbeatInterval:   int(heartbeatInterval.Seconds()),
 				},
 			},
@@ -80,6 +83,7 @@ func NewRouter(emitter transport.Emitter,
 					Clock:            clk,
 					TokenStore:       engine,
 					TransactionStore: engine,
+					ReservationStore: engine,
 				},
 			},
 			"StopTransaction": {
@@ -141,6 +145,7 @@ func NewRouter(emitter transport.Emitter,
 								Handler: handlers201.SignCertificateHandler{
 									ChargeStationCertificateProvider: chargeStationCertProvider,
 									Store:                            engine,
+									Clock:                            clk,
 								},
 							},
 							"Get15118EVCertificate": {
@@ -195,6 +200,7 @@ func NewRouter(emitter transport.Emitter,
 									Handler201: handlers201.SignCertificateHandler{
 										ChargeStationCertificateProvider: chargeStationCertProvider,
 										Store:                            engine,
+										Clock:                            clk,
 									},
 								},
 							},
@@ -222,6 +228,26 @@ func NewRouter(emitter transport.Emitter,
 									Store: engine,
 								},
 							},
+							"DeleteCertificate": {
+								NewRequest:     func() ocpp.Request { return new(ocpp201.DeleteCertificateRequestJson) },
+								NewResponse:    func() ocpp.Response { return new(ocpp201.DeleteCertificateResponseJson) },
+								RequestSchema:  "ocpp201/DeleteCertificateRequest.json",
+								ResponseSchema: "ocpp201/DeleteCertificateResponse.json",
+								Handler: handlers201.DeleteCertificateResultHandler{
+									Store: engine,
+									Clock: clk,
+								},
+							},
+							"GetInstalledCertificateIds": {
+								NewRequest:     func() ocpp.Request { return new(ocpp201.GetInstalledCertificateIdsRequestJson) },
+								NewResponse:    func() ocpp.Response { return new(ocpp201.GetInstalledCertificateIdsResponseJson) },
+								RequestSchema:  "ocpp201/GetInstalledCertificateIdsRequest.json",
+								ResponseSchema: "ocpp201/GetInstalledCertificateIdsResponse.json",
+								Handler: handlers201.GetInstalledCertificateIdsResultHandler{
+									Store: engine,
+									Clock: clk,
+								},
+							},
 							"InstallCertificate": {
 								NewRequest:     func() ocpp.Request { return new(ocpp201.InstallCertificateRequestJson) },
 								NewResponse:    func() ocpp.Response { return new(ocpp201.InstallCertificateResponseJson) },
@@ -229,6 +255,7 @@ func NewRouter(emitter transport.Emitter,
 								ResponseSchema: "ocpp201/InstallCertificateResponse.json",
 								Handler: handlers201.InstallCertificateResultHandler{
 									Store: engine,
+									Clock: clk,
 								},
 							},
 							"TriggerMessage": {
@@ -249,18 +276,145 @@ func NewRouter(emitter transport.Emitter,
 								ResponseSchema: "has2be/CertificateSignedResponse.json",
 								Handler:        handlersHasToBe.CertificateSignedResultHandler{},
 							},
+							"DeleteCertificate": {
+								NewRequest:     func() ocpp.Request { return new(has2be.DeleteCertificateRequestJson) },
+								NewResponse:    func() ocpp.Response { return new(has2be.DeleteCertificateResponseJson) },
+								RequestSchema:  "has2be/DeleteCertificateRequest.json",
+								ResponseSchema: "has2be/DeleteCertificateResponse.json",
+								Handler: handlersHasToBe.DeleteCertificateResultHandler{
+									Handler201: handlers201.DeleteCertificateResultHandler{
+										Store: engine,
+										Clock: clk,
+									},
+								},
+							},
+							"GetInstalledCertificateIds": {
+								NewRequest:     func() ocpp.Request { return new(has2be.GetInstalledCertificateIdsRequestJson) },
+								NewResponse:    func() ocpp.Response { return new(has2be.GetInstalledCertificateIdsResponseJson) },
+								RequestSchema:  "has2be/GetInstalledCertificateIdsRequest.json",
+								ResponseSchema: "has2be/GetInstalledCertificateIdsResponse.json",
+								Handler: handlersHasToBe.GetInstalledCertificateIdsResultHandler{
+									Handler201: handlers201.GetInstalledCertificateIdsResultHandler{
+										Store: engine,
+										Clock: clk,
+									},
+								},
+							},
+							"InstallCertificate": {
+								NewRequest:     func() ocpp.Request { return new(has2be.InstallCertificateRequestJson) },
+								NewResponse:    func() ocpp.Response { return new(has2be.InstallCertificateResponseJson) },
+								RequestSchema:  "has2be/InstallCertificateRequest.json",
+								ResponseSchema: "has2be/InstallCertificateResponse.json",
+								Handler: handlersHasToBe.InstallCertificateResultHandler{
+									Handler201: handlers201.InstallCertificateResultHandler{
+										Store: engine,
+										Clock: clk,
+									},
+								},
+							},
 						},
 					},
 				},
 			},
+			"CancelReservation": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.CancelReservationJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.CancelReservationResponseJson) },
+				RequestSchema:  "ocpp16/CancelReservation.json",
+				ResponseSchema: "ocpp16/CancelReservationResponse.json",
+				Handler: CancelReservationResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"ChangeAvailability": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.ChangeAvailabilityJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.ChangeAvailabilityResponseJson) },
+				RequestSchema:  "ocpp16/ChangeAvailability.json",
+				ResponseSchema: "ocpp16/ChangeAvailabilityResponse.json",
+				Handler: ChangeAvailabilityResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
 			"ChangeConfiguration": {
 				NewRequest:     func() ocpp.Request { return new(ocpp16.ChangeConfigurationJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp16.ChangeConfigurationResponseJson) },
 				RequestSchema:  "ocpp16/ChangeConfiguration.json",
 				ResponseSchema: "ocpp16/ChangeConfigurationResponse.json",
 				Handler: ChangeConfigurationResultHandler{
-					SettingsStore: engine,
-					CallMaker:     standardCallMaker,
+					SettingsStore:      engine,
+					ChargeStationStore: engine,
+					CallMaker:          standardCallMaker,
+				},
+			},
+			"ClearCache": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.ClearCacheJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.ClearCacheResponseJson) },
+				RequestSchema:  "ocpp16/ClearCache.json",
+				ResponseSchema: "ocpp16/ClearCacheResponse.json",
+				Handler: ClearCacheResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"GetConfiguration": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.GetConfigurationJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.GetConfigurationResponseJson) },
+				RequestSchema:  "ocpp16/GetConfiguration.json",
+				ResponseSchema: "ocpp16/GetConfigurationResponse.json",
+				Handler: GetConfigurationResultHandler{
+					VariablesStore: engine,
+					SettingsStore:  engine,
+				},
+			},
+			"GetLocalListVersion": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.GetLocalListVersionJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.GetLocalListVersionResponseJson) },
+				RequestSchema:  "ocpp16/GetLocalListVersion.json",
+				ResponseSchema: "ocpp16/GetLocalListVersionResponse.json",
+				Handler: GetLocalListVersionResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"RemoteStopTransaction": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.RemoteStopTransactionJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.RemoteStopTransactionResponseJson) },
+				RequestSchema:  "ocpp16/RemoteStopTransaction.json",
+				ResponseSchema: "ocpp16/RemoteStopTransactionResponse.json",
+				Handler: RemoteStopTransactionResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"ReserveNow": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.ReserveNowJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.ReserveNowResponseJson) },
+				RequestSchema:  "ocpp16/ReserveNow.json",
+				ResponseSchema: "ocpp16/ReserveNowResponse.json",
+				Handler: ReserveNowResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"Reset": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.ResetJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.ResetResponseJson) },
+				RequestSchema:  "ocpp16/Reset.json",
+				ResponseSchema: "ocpp16/ResetResponse.json",
+				Handler: ResetResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"SendLocalList": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.SendLocalListJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.SendLocalListResponseJson) },
+				RequestSchema:  "ocpp16/SendLocalList.json",
+				ResponseSchema: "ocpp16/SendLocalListResponse.json",
+				Handler: SendLocalListResultHandler{
+					Store: engine,
+					Clock: clk,
 				},
 			},
 			"TriggerMessage": {
@@ -270,6 +424,145 @@ func NewRouter(emitter transport.Emitter,
 				ResponseSchema: "ocpp16/TriggerMessageResponse.json",
 				Handler:        TriggerMessageResultHandler{},
 			},
+			"UnlockConnector": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.UnlockConnectorJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.UnlockConnectorResponseJson) },
+				RequestSchema:  "ocpp16/UnlockConnector.json",
+				ResponseSchema: "ocpp16/UnlockConnectorResponse.json",
+				Handler: UnlockConnectorResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+		},
+		CallErrorRoutes: map[string]handlers.CallErrorRoute{
+			"DataTransfer": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.DataTransferJson) },
+				RequestSchema: "ocpp16/DataTransfer.json",
+				Handler: DataTransferErrorHandler{
+					SchemaFS: schemaFS,
+					CallErrorRoutes: map[string]map[string]handlers.CallErrorRoute{
+						"org.openchargealliance.iso15118pnc": {
+							"InstallCertificate": {
+								NewRequest:    func() ocpp.Request { return new(ocpp201.InstallCertificateRequestJson) },
+								RequestSchema: "ocpp201/InstallCertificateRequest.json",
+								Handler: handlers201.InstallCertificateErrorHandler{
+									Store: engine,
+								},
+							},
+							"TriggerMessage": {
+								NewRequest:    func() ocpp.Request { return new(ocpp201.TriggerMessageRequestJson) },
+								RequestSchema: "ocpp201/TriggerMessageRequest.json",
+								Handler: handlers201.TriggerMessageErrorHandler{
+									Store: engine,
+								},
+							},
+						},
+						"iso15118": { // has2be extensions
+							"InstallCertificate": {
+								NewRequest:    func() ocpp.Request { return new(has2be.InstallCertificateRequestJson) },
+								RequestSchema: "has2be/InstallCertificateRequest.json",
+								Handler: handlersHasToBe.InstallCertificateErrorHandler{
+									Handler201: handlers201.InstallCertificateErrorHandler{
+										Store: engine,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"ChangeAvailability": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.ChangeAvailabilityJson) },
+				RequestSchema: "ocpp16/ChangeAvailability.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "ChangeAvailability",
+				},
+			},
+			"ChangeConfiguration": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.ChangeConfigurationJson) },
+				RequestSchema: "ocpp16/ChangeConfiguration.json",
+				Handler: ChangeConfigurationErrorHandler{
+					SettingsStore:      engine,
+					ChargeStationStore: engine,
+				},
+			},
+			"ClearCache": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.ClearCacheJson) },
+				RequestSchema: "ocpp16/ClearCache.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "ClearCache",
+				},
+			},
+			"GetConfiguration": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.GetConfigurationJson) },
+				RequestSchema: "ocpp16/GetConfiguration.json",
+				Handler: GetConfigurationErrorHandler{
+					VariablesStore: engine,
+				},
+			},
+			"GetLocalListVersion": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.GetLocalListVersionJson) },
+				RequestSchema: "ocpp16/GetLocalListVersion.json",
+				Handler: handlers.LocalListErrorHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"RemoteStopTransaction": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.RemoteStopTransactionJson) },
+				RequestSchema: "ocpp16/RemoteStopTransaction.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "RemoteStopTransaction",
+				},
+			},
+			"ReserveNow": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.ReserveNowJson) },
+				RequestSchema: "ocpp16/ReserveNow.json",
+				Handler: ReserveNowErrorHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"Reset": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.ResetJson) },
+				RequestSchema: "ocpp16/Reset.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "Reset",
+				},
+			},
+			"SendLocalList": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.SendLocalListJson) },
+				RequestSchema: "ocpp16/SendLocalList.json",
+				Handler: handlers.LocalListErrorHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"TriggerMessage": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.TriggerMessageJson) },
+				RequestSchema: "ocpp16/TriggerMessage.json",
+				Handler: TriggerMessageErrorHandler{
+					TriggerStore: engine,
+				},
+			},
+			"UnlockConnector": {
+				NewRequest:    func() ocpp.Request { return new(ocpp16.UnlockConnectorJson) },
+				RequestSchema: "ocpp16/UnlockConnector.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "UnlockConnector",
+				},
+			},
 		},
 	}
 }
@@ -280,8 +573,18 @@ func NewCallMaker(e transport.Emitter) *handlers.OcppCallMaker {
 		OcppVersion: transport.OcppVersion16,
 		Actions: map[reflect.Type]string{
 			reflect.TypeOf(&ocpp16.ChangeConfigurationJson{}):    "ChangeConfiguration",
+			reflect.TypeOf(&ocpp16.GetConfigurationJson{}):       "GetConfiguration",
 			reflect.TypeOf(&ocpp16.TriggerMessageJson{}):         "TriggerMessage",
 			reflect.TypeOf(&ocpp16.RemoteStartTransactionJson{}): "RemoteStartTransaction",
+			reflect.TypeOf(&ocpp16.RemoteStopTransactionJson{}):  "RemoteStopTransaction",
+			reflect.TypeOf(&ocpp16.ResetJson{}):                  "Reset",
+			reflect.TypeOf(&ocpp16.ChangeAvailabilityJson{}):     "ChangeAvailability",
+			reflect.TypeOf(&ocpp16.UnlockConnectorJson{}):        "UnlockConnector",
+			reflect.TypeOf(&ocpp16.ClearCacheJson{}):             "ClearCache",
+			reflect.TypeOf(&ocpp16.SendLocalListJson{}):          "SendLocalList",
+			reflect.TypeOf(&ocpp16.GetLocalListVersionJson{}):    "GetLocalListVersion",
+			reflect.TypeOf(&ocpp16.ReserveNowJson{}):             "ReserveNow",
+			reflect.TypeOf(&ocpp16.CancelReservationJson{}):      "CancelReservation",
 		},
 	}
 }
@@ -304,6 +607,14 @@ func NewDataTransferCallMaker(e transport.Emitter) *DataTransferCallMaker {
 				VendorId:  "org.openchargealliance.iso15118pnc",
 				MessageId: "CertificateSigned",
 			},
+			reflect.TypeOf(&ocpp201.DeleteCertificateRequestJson{}): {
+				VendorId:  "org.openchargealliance.iso15118pnc",
+				MessageId: "DeleteCertificate",
+			},
+			reflect.TypeOf(&ocpp201.GetInstalledCertificateIdsRequestJson{}): {
+				VendorId:  "org.openchargealliance.iso15118pnc",
+				MessageId: "GetInstalledCertificateIds",
+			},
 			reflect.TypeOf(&ocpp201.InstallCertificateRequestJson{}): {
 				VendorId:  "org.openchargealliance.iso15118pnc",
 				MessageId: "InstallCertificate",
@@ -312,6 +623,18 @@ func NewDataTransferCallMaker(e transport.Emitter) *DataTransferCallMaker {
 				VendorId:  "org.openchargealliance.iso15118pnc",
 				MessageId: "TriggerMessage",
 			},
+			reflect.TypeOf(&has2be.DeleteCertificateRequestJson{}): {
+				VendorId:  "iso15118",
+				MessageId: "DeleteCertificate",
+			},
+			reflect.TypeOf(&has2be.GetInstalledCertificateIdsRequestJson{}): {
+				VendorId:  "iso15118",
+				MessageId: "GetInstalledCertificateIds",
+			},
+			reflect.TypeOf(&has2be.InstallCertificateRequestJson{}): {
+				VendorId:  "iso15118",
+				MessageId: "InstallCertificate",
+			},
 		},
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/has2be"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"regexp"
@@ -41,6 +42,19 @@ func TestDataTransferCallMaker(t *testing.T) {
 	assert.JSONEq(t, `{"vendorId":"org.openchargealliance.iso15118pnc","messageId":"CertificateSigned","data":"{\"certificateChain\":\"pemData\",\"certificateType\":\"V2GCertificate\"}"}`, string(emitter.got.RequestPayload))
 }
 
+func TestDataTransferCallMakerWithHasToBeMessage(t *testing.T) {
+	emitter := &FakeEmitter{}
+	callMaker := ocpp16.NewDataTransferCallMaker(emitter)
+
+	err := callMaker.Send(context.Background(), "cs001", &has2be.GetInstalledCertificateIdsRequestJson{
+		TypeOfCertificate: []has2be.GetCertificateIdUseEnumType{has2be.GetCertificateIdUseEnumTypeV2GRootCertificate},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "DataTransfer", emitter.got.Action)
+	assert.JSONEq(t, `{"vendorId":"iso15118","messageId":"GetInstalledCertificateIds","data":"{\"typeOfCertificate\":[\"V2GRootCertificate\"]}"}`, string(emitter.got.RequestPayload))
+}
+
 func TestDataTransferCallMakerWithUnknownMessageType(t *testing.T) {
 	emitter := &FakeEmitter{}
 	callMaker := ocpp16.NewDataTransferCallMaker(emitter)
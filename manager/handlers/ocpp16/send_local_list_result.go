@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type SendLocalListResultHandler struct {
+	Store store.LocalListStore
+	Clock clock.PassiveClock
+}
+
+func (h SendLocalListResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*ocpp16.SendLocalListJson)
+	resp := response.(*ocpp16.SendLocalListResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(
+		attribute.String("send_local_list.update_type", string(req.UpdateType)),
+		attribute.Int("send_local_list.version_number", req.ListVersion),
+		attribute.String("send_local_list.status", string(resp.Status)))
+
+	update := handlers.LocalListUpdate{
+		Full:    req.UpdateType == ocpp16.SendLocalListJsonUpdateTypeFull,
+		Version: req.ListVersion,
+	}
+	for _, data := range req.LocalAuthorizationList {
+		entry := &store.LocalListEntry{
+			IdToken: data.IdTag,
+		}
+		if data.IdTagInfo != nil {
+			entry.Status = string(data.IdTagInfo.Status)
+			entry.GroupId = data.IdTagInfo.ParentIdTag
+		}
+		update.Entries = append(update.Entries, entry)
+	}
+
+	return handlers.RecordLocalListUpdateResult(ctx, h.Store, h.Clock, chargeStationId, update, store.LocalListStatus(resp.Status))
+}
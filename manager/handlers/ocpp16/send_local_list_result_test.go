@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestSendLocalListResultHandlerAppliesAcceptedFullUpdate(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp16.SendLocalListResultHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+
+	err := engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Version: 4,
+		Entries: map[string]*store.LocalListEntry{
+			"OLD": {IdToken: "OLD", Status: "Accepted"},
+		},
+		Status: store.LocalListStatusPending,
+	})
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+
+	groupId := "group001"
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		req := &types.SendLocalListJson{
+			ListVersion: 5,
+			LocalAuthorizationList: []types.SendLocalListJsonLocalAuthorizationListElem{
+				{
+					IdTag: "ABCD1234",
+					IdTagInfo: &types.SendLocalListJsonLocalAuthorizationListElemIdTagInfo{
+						ParentIdTag: &groupId,
+						Status:      types.SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatusAccepted,
+					},
+				},
+			},
+			UpdateType: types.SendLocalListJsonUpdateTypeFull,
+		}
+		resp := &types.SendLocalListResponseJson{
+			Status: types.SendLocalListResponseJsonStatusAccepted,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"send_local_list.update_type":    "Full",
+		"send_local_list.version_number": 5,
+		"send_local_list.status":         "Accepted",
+		"local_list.status":              "Accepted",
+	})
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationLocalList{
+		ChargeStationId: "cs001",
+		Version:         5,
+		Entries: map[string]*store.LocalListEntry{
+			"ABCD1234": {IdToken: "ABCD1234", Status: "Accepted", GroupId: &groupId},
+		},
+		Status:    store.LocalListStatusAccepted,
+		UpdatedAt: clk.Now(),
+	}, got)
+}
+
+func TestSendLocalListResultHandlerRecordsNotSupported(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp16.SendLocalListResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+
+	err := engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Entries: map[string]*store.LocalListEntry{},
+		Status:  store.LocalListStatusPending,
+	})
+	require.NoError(t, err)
+
+	err = handler.HandleCallResult(ctx, "cs001", &types.SendLocalListJson{
+		ListVersion: 1,
+		UpdateType:  types.SendLocalListJsonUpdateTypeDifferential,
+	}, &types.SendLocalListResponseJson{
+		Status: types.SendLocalListResponseJsonStatusNotSupported,
+	}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.LocalListStatusNotSupported, got.Status)
+	assert.Equal(t, 0, got.Version)
+}
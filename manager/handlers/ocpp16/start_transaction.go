@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/store"
@@ -19,6 +20,7 @@ type StartTransactionHandler struct {
 	Clock            clock.PassiveClock
 	TokenStore       store.TokenStore
 	TransactionStore store.TransactionStore
+	ReservationStore store.ReservationStore
 }
 
 func (t StartTransactionHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
@@ -62,6 +64,14 @@ func (t StartTransactionHandler) HandleCall(ctx context.Context, chargeStationId
 		return nil, err
 	}
 
+	if req.ReservationId != nil {
+		// the reservation terminates as a result of the transaction
+		err = handlers.RecordReservationUsed(ctx, t.ReservationStore, t.Clock, chargeStationId, *req.ReservationId, transactionUuid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &types.StartTransactionResponseJson{
 		IdTagInfo: types.StartTransactionResponseJsonIdTagInfo{
 			Status: status,
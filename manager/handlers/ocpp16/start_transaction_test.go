@@ -151,3 +151,48 @@ func TestStartTransactionWithInvalidRFID(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestStartTransactionWithReservation(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetToken(context.Background(), &store.Token{
+		Type:      "RFID",
+		Uid:       "ABCD1234",
+		Valid:     true,
+		CacheMode: "NEVER",
+	})
+	require.NoError(t, err)
+
+	now, err := time.Parse(time.RFC3339, "2023-06-15T15:05:00+01:00")
+	require.NoError(t, err)
+	reservation := setPendingReservation(t, engine, now.Add(-time.Minute))
+
+	handler := handlers.StartTransactionHandler{
+		Clock:            clockTest.NewFakePassiveClock(now),
+		TokenStore:       engine,
+		TransactionStore: engine,
+		ReservationStore: engine,
+	}
+
+	reservationId := 42
+	req := &types.StartTransactionJson{
+		ConnectorId:   1,
+		IdTag:         "ABCD1234",
+		MeterStart:    100,
+		ReservationId: &reservationId,
+		Timestamp:     now.Format(time.RFC3339),
+	}
+
+	ctx := context.Background()
+	resp, err := handler.HandleCall(ctx, "cs001", req)
+	require.NoError(t, err)
+	got := resp.(*types.StartTransactionResponseJson)
+
+	transactionId := handlers.ConvertToUUID(got.TransactionId)
+	found, err := engine.LookupReservation(ctx, "cs001", 42)
+	require.NoError(t, err)
+	reservation.Status = store.ReservationStatusUsed
+	reservation.TransactionId = &transactionId
+	reservation.UpdatedAt = now
+	assert.Equal(t, reservation, found)
+}
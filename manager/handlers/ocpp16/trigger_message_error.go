@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TriggerMessageErrorHandler struct {
+	TriggerStore store.ChargeStationTriggerMessageStore
+}
+
+func (t TriggerMessageErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*ocpp16.TriggerMessageJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("trigger.requested_message", string(req.RequestedMessage)))
+
+	err := t.TriggerStore.SetChargeStationTriggerMessage(ctx, chargeStationId, &store.ChargeStationTriggerMessage{
+		TriggerMessage:   store.TriggerMessage(req.RequestedMessage),
+		TriggerStatus:    store.TriggerStatusFailed,
+		ErrorCode:        string(errorCode),
+		ErrorDescription: errorDescription,
+	})
+	if err != nil {
+		return fmt.Errorf("set charge station trigger message: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+func TestTriggerMessageErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	handler := ocpp16.TriggerMessageErrorHandler{TriggerStore: engine}
+
+	err := handler.HandleCallError(ctx, "cs001", &types.TriggerMessageJson{
+		RequestedMessage: types.TriggerMessageJsonRequestedMessageBootNotification,
+	}, transport.ErrorNotImplemented, "not implemented", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationTriggerMessage(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationTriggerMessage{
+		TriggerMessage:   store.TriggerMessageBootNotification,
+		TriggerStatus:    store.TriggerStatusFailed,
+		ErrorCode:        "NotImplemented",
+		ErrorDescription: "not implemented",
+	}, got)
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type UnlockConnectorResultHandler struct {
+	Store store.ChargeStationOperationStore
+	Clock clock.PassiveClock
+}
+
+func (h UnlockConnectorResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*ocpp16.UnlockConnectorJson)
+	resp := response.(*ocpp16.UnlockConnectorResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("unlock_connector.connector_id", req.ConnectorId),
+		attribute.String("unlock_connector.status", string(resp.Status)))
+
+	return handlers.RecordOperationResult(ctx, h.Store, h.Clock, chargeStationId, "UnlockConnector", string(resp.Status), resp)
+}
@@ -4,6 +4,8 @@ package ocpp201
 
 import (
 	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -17,6 +19,11 @@ import (
 type BootNotificationHandler struct {
 	Clock               clock.PassiveClock
 	RuntimeDetailsStore store.ChargeStationRuntimeDetailsStore
+	SettingsStore       store.ChargeStationSettingsStore
+	ChargeStationStore  store.ChargeStationStore
+	TemplateStore       store.ConfigurationTemplateStore
+	LocalListStore      store.LocalListStore
+	DisplayMessageStore store.DisplayMessageStore
 	HeartbeatInterval   int
 }
 
@@ -38,9 +45,27 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 		span.SetAttributes(attribute.String("boot.firmware", *req.ChargingStation.FirmwareVersion))
 	}
 
-	err := b.RuntimeDetailsStore.SetChargeStationRuntimeDetails(ctx, chargeStationId, &store.ChargeStationRuntimeDetails{
+	details := &store.ChargeStationRuntimeDetails{
 		OcppVersion: "2.0.1",
-	})
+		Vendor:      req.ChargingStation.VendorName,
+		Model:       req.ChargingStation.Model,
+	}
+	err := b.RuntimeDetailsStore.SetChargeStationRuntimeDetails(ctx, chargeStationId, details)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.applyConfigurationTemplates(ctx, chargeStationId, details)
+	if err != nil {
+		return nil, err
+	}
+
+	err = handlers.MarkLocalListVersionUnknown(ctx, b.LocalListStore, b.Clock, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = markDisplayMessagesForReconcile(ctx, b.DisplayMessageStore, b.Clock, chargeStationId)
 	if err != nil {
 		return nil, err
 	}
@@ -51,3 +76,31 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 		Status:      types.RegistrationStatusEnumTypeAccepted,
 	}, nil
 }
+
+func (b BootNotificationHandler) applyConfigurationTemplates(ctx context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
+	templates, err := b.TemplateStore.ListConfigurationTemplates(ctx)
+	if err != nil {
+		return fmt.Errorf("list configuration templates: %w", err)
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
+	cs, err := b.ChargeStationStore.LookupChargeStation(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station: %w", err)
+	}
+	if cs == nil {
+		// an unregistered charge station can still match templates by vendor and model
+		cs = &store.ChargeStation{Id: chargeStationId}
+	}
+
+	queued, err := handlers.ApplyConfigurationTemplates(ctx, b.SettingsStore, templates, cs, details)
+	if err != nil {
+		return err
+	}
+	if len(queued) > 0 {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.StringSlice("boot.queued_settings", queued))
+	}
+	return nil
+}
@@ -29,6 +29,11 @@ func TestBootNotificationHandler(t *testing.T) {
 	handler := handlers.BootNotificationHandler{
 		Clock:               clockTest.NewFakePassiveClock(now),
 		RuntimeDetailsStore: engine,
+		SettingsStore:       engine,
+		ChargeStationStore:  engine,
+		TemplateStore:       engine,
+		LocalListStore:      engine,
+		DisplayMessageStore: engine,
 		HeartbeatInterval:   10,
 	}
 
@@ -55,5 +60,82 @@ func TestBootNotificationHandler(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, store.ChargeStationRuntimeDetails{
 		OcppVersion: "2.0.1",
+		Model:       "testy",
 	}, *details)
 }
+
+func TestBootNotificationHandlerAppliesConfigurationTemplates(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{Id: "cs001", LocationId: "loc001"})
+	require.NoError(t, err)
+	err = engine.SetConfigurationTemplate(ctx, &store.ConfigurationTemplate{
+		Id:       "testy",
+		Vendor:   makePtr("VendorX"),
+		Model:    makePtr("testy"),
+		Settings: map[string]string{"OCPPCommCtrlr/HeartbeatInterval": "60"},
+	})
+	require.NoError(t, err)
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clock.RealClock{},
+		RuntimeDetailsStore: engine,
+		SettingsStore:       engine,
+		ChargeStationStore:  engine,
+		TemplateStore:       engine,
+		LocalListStore:      engine,
+		DisplayMessageStore: engine,
+		HeartbeatInterval:   10,
+	}
+
+	req := &types.BootNotificationRequestJson{
+		ChargingStation: types.ChargingStationType{
+			VendorName: "VendorX",
+			Model:      "testy",
+		},
+		Reason: types.BootReasonEnumTypePowerUp,
+	}
+
+	_, err = handler.HandleCall(ctx, "cs001", req)
+	require.NoError(t, err)
+
+	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, settings)
+	setting := settings.Settings["OCPPCommCtrlr/HeartbeatInterval"]
+	require.NotNil(t, setting)
+	assert.Equal(t, "60", setting.Value)
+	assert.Equal(t, store.ChargeStationSettingStatusPending, setting.Status)
+}
+
+func TestBootNotificationHandlerMarksDisplayMessagesForReconcile(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationDisplayMessages(ctx, "cs001", &store.ChargeStationDisplayMessages{
+		Messages: map[int]*store.DisplayMessage{
+			1: {Id: 1, Priority: "NormalCycle", Format: "UTF8", Content: "Welcome", Status: store.DisplayMessageStatusAccepted},
+		},
+	})
+	require.NoError(t, err)
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clock.RealClock{},
+		RuntimeDetailsStore: engine,
+		SettingsStore:       engine,
+		ChargeStationStore:  engine,
+		TemplateStore:       engine,
+		LocalListStore:      engine,
+		DisplayMessageStore: engine,
+		HeartbeatInterval:   10,
+	}
+
+	_, err = handler.HandleCall(ctx, "cs001", &types.BootNotificationRequestJson{})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageReconcileStatusRequired, got.ReconcileStatus)
+	assert.Equal(t, store.DisplayMessageStatusAccepted, got.Messages[1].Status)
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type CancelReservationResultHandler struct {
+	Store store.ReservationStore
+	Clock clock.PassiveClock
+}
+
+func (h CancelReservationResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.CancelReservationRequestJson)
+	resp := response.(*types.CancelReservationResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("cancel_reservation.reservation_id", req.ReservationId),
+		attribute.String("cancel_reservation.status", string(resp.Status)))
+
+	if resp.Status != types.CancelReservationStatusEnumTypeAccepted {
+		return nil
+	}
+	return handlers.RecordReservationCancelled(ctx, h.Store, h.Clock, chargeStationId, req.ReservationId)
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestCancelReservationResultHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp201.CancelReservationResultHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+	want := setActiveReservation(t, engine, store.ReservationStatusAccepted, clk.Now().Add(-time.Minute))
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		err := handler.HandleCallResult(ctx, "cs001", &types.CancelReservationRequestJson{ReservationId: 42},
+			&types.CancelReservationResponseJson{Status: types.CancelReservationStatusEnumTypeAccepted}, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"cancel_reservation.reservation_id": 42,
+		"cancel_reservation.status":         "Accepted",
+		"reservation.status":                "Cancelled",
+	})
+
+	got, err := engine.LookupReservation(ctx, "cs001", 42)
+	require.NoError(t, err)
+	want.Status = store.ReservationStatusCancelled
+	want.UpdatedAt = clk.Now()
+	assert.Equal(t, want, got)
+}
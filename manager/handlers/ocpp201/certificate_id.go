@@ -4,6 +4,7 @@ package ocpp201
 
 import (
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
 	"fmt"
@@ -23,3 +24,18 @@ func GetCertificateId(pemData string) (string, error) {
 
 	return certificateId, nil
 }
+
+// GetCertificateHash returns the base64 (URL encoding, no padding) SHA-256 hash
+// of the first certificate in the PEM data: this is the form used to key
+// certificates in the store and by the gateway when presenting client certificates
+func GetCertificateHash(pemData string) (string, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return "", fmt.Errorf("failed to decode certificate chain")
+	}
+	if block.Type != "CERTIFICATE" {
+		return "", fmt.Errorf("expected certificate, got %s", block.Type)
+	}
+	hash := sha256.Sum256(block.Bytes)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
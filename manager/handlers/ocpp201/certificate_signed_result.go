@@ -68,5 +68,33 @@ func (c CertificateSignedResultHandler) HandleCallResult(ctx context.Context, ch
 		return err
 	}
 
+	if storeType == store.CertificateTypeChargeStation && installStatus == store.CertificateInstallationAccepted {
+		err = c.recordClientCertificate(ctx, chargeStationId, req.CertificateChain)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// recordClientCertificate binds the newly installed charge station certificate to the
+// charge station so that the gateway can reject the certificate if it is presented by
+// another charge station
+func (c CertificateSignedResultHandler) recordClientCertificate(ctx context.Context, chargeStationId, certificateChain string) error {
+	certHash, err := GetCertificateHash(certificateChain)
+	if err != nil {
+		return err
+	}
+
+	cs, err := c.Store.LookupChargeStation(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	if cs == nil {
+		return nil
+	}
+
+	cs.ClientCertificateHash = certHash
+	return c.Store.UpdateChargeStation(ctx, chargeStationId, cs)
+}
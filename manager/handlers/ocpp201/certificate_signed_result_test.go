@@ -149,3 +149,58 @@ func TestCertificateSignedResultHandler(t *testing.T) {
 	}
 
 }
+
+func TestCertificateSignedResultHandlerRecordsClientCertificateHash(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := handlers201.CertificateSignedResultHandler{Store: engine}
+
+	ctx := context.Background()
+	err := engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:              "cs001",
+		SecurityProfile: store.TLSWithClientSideCertificates,
+	})
+	require.NoError(t, err)
+
+	pemBytes := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: []byte("test"),
+	})
+	hash, err := handlers201.GetCertificateHash(string(pemBytes))
+	require.NoError(t, err)
+
+	typChargingStation := ocpp201.CertificateSigningUseEnumTypeChargingStationCertificate
+	typV2G := ocpp201.CertificateSigningUseEnumTypeV2GCertificate
+
+	// neither a rejected certificate nor a V2G certificate is bound to the charge station
+	err = handler.HandleCallResult(ctx, "cs001", &ocpp201.CertificateSignedRequestJson{
+		CertificateChain: string(pemBytes),
+		CertificateType:  &typChargingStation,
+	}, &ocpp201.CertificateSignedResponseJson{
+		Status: ocpp201.CertificateSignedStatusEnumTypeRejected,
+	}, nil)
+	require.NoError(t, err)
+	err = handler.HandleCallResult(ctx, "cs001", &ocpp201.CertificateSignedRequestJson{
+		CertificateChain: string(pemBytes),
+		CertificateType:  &typV2G,
+	}, &ocpp201.CertificateSignedResponseJson{
+		Status: ocpp201.CertificateSignedStatusEnumTypeAccepted,
+	}, nil)
+	require.NoError(t, err)
+
+	cs, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Empty(t, cs.ClientCertificateHash)
+
+	err = handler.HandleCallResult(ctx, "cs001", &ocpp201.CertificateSignedRequestJson{
+		CertificateChain: string(pemBytes),
+		CertificateType:  &typChargingStation,
+	}, &ocpp201.CertificateSignedResponseJson{
+		Status: ocpp201.CertificateSignedStatusEnumTypeAccepted,
+	}, nil)
+	require.NoError(t, err)
+
+	cs, err = engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, hash, cs.ClientCertificateHash)
+	assert.Equal(t, store.TLSWithClientSideCertificates, cs.SecurityProfile)
+}
@@ -4,13 +4,19 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type ChangeAvailabilityResultHandler struct{}
+type ChangeAvailabilityResultHandler struct {
+	Store store.ChargeStationOperationStore
+	Clock clock.PassiveClock
+}
 
 func (h ChangeAvailabilityResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.ChangeAvailabilityRequestJson)
@@ -32,5 +38,5 @@ func (h ChangeAvailabilityResultHandler) HandleCallResult(ctx context.Context, c
 		}
 	}
 
-	return nil
+	return handlers.RecordOperationResult(ctx, h.Store, h.Clock, chargeStationId, "ChangeAvailability", string(resp.Status), resp)
 }
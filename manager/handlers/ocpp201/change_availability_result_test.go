@@ -7,12 +7,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
 	"testing"
 )
 
 func TestChangeAvailabilityResultHandler(t *testing.T) {
-	handler := ocpp201.ChangeAvailabilityResultHandler{}
+	handler := ocpp201.ChangeAvailabilityResultHandler{
+		Store: inmemory.NewStore(clock.RealClock{}),
+		Clock: clock.RealClock{},
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -40,7 +45,10 @@ func TestChangeAvailabilityResultHandler(t *testing.T) {
 }
 
 func TestChangeAvailabilityResultHandlerWithEvseId(t *testing.T) {
-	handler := ocpp201.ChangeAvailabilityResultHandler{}
+	handler := ocpp201.ChangeAvailabilityResultHandler{
+		Store: inmemory.NewStore(clock.RealClock{}),
+		Clock: clock.RealClock{},
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -72,7 +80,10 @@ func TestChangeAvailabilityResultHandlerWithEvseId(t *testing.T) {
 }
 
 func TestChangeAvailabilityResultHandlerWithConnectorId(t *testing.T) {
-	handler := ocpp201.ChangeAvailabilityResultHandler{}
+	handler := ocpp201.ChangeAvailabilityResultHandler{
+		Store: inmemory.NewStore(clock.RealClock{}),
+		Clock: clock.RealClock{},
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
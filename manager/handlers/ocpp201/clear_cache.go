@@ -4,13 +4,19 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type ClearCacheResultHandler struct{}
+type ClearCacheResultHandler struct {
+	Store store.ChargeStationOperationStore
+	Clock clock.PassiveClock
+}
 
 func (h ClearCacheResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	resp := response.(*types.ClearCacheResponseJson)
@@ -20,5 +26,5 @@ func (h ClearCacheResultHandler) HandleCallResult(ctx context.Context, chargeSta
 	span.SetAttributes(
 		attribute.String("clear_cache.status", string(resp.Status)))
 
-	return nil
+	return handlers.RecordOperationResult(ctx, h.Store, h.Clock, chargeStationId, "ClearCache", string(resp.Status), resp)
 }
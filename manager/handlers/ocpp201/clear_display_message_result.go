@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type ClearDisplayMessageResultHandler struct {
+	Store store.DisplayMessageStore
+	Clock clock.PassiveClock
+}
+
+func (h ClearDisplayMessageResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.ClearDisplayMessageRequestJson)
+	resp := response.(*types.ClearDisplayMessageResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("clear_display_message.id", req.Id),
+		attribute.String("clear_display_message.status", string(resp.Status)))
+
+	// the message is forgotten if the charge station has removed it or never held it (Unknown)
+	return updateDisplayMessages(ctx, h.Store, h.Clock, chargeStationId, func(messages *store.ChargeStationDisplayMessages) bool {
+		message, ok := messages.Messages[req.Id]
+		if !ok || message.Status != store.DisplayMessageStatusClearPending {
+			return false
+		}
+		delete(messages.Messages, req.Id)
+		return true
+	})
+}
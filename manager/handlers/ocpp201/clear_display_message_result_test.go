@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestClearDisplayMessageResultHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.ClearDisplayMessageResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+	setDisplayMessages(t, engine,
+		newDisplayMessage(1, store.DisplayMessageStatusClearPending),
+		newDisplayMessage(2, store.DisplayMessageStatusClearPending),
+		newDisplayMessage(3, store.DisplayMessageStatusAccepted))
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		err := handler.HandleCallResult(ctx, "cs001", &types.ClearDisplayMessageRequestJson{Id: 1},
+			&types.ClearDisplayMessageResponseJson{Status: types.ClearMessageStatusEnumTypeAccepted}, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"clear_display_message.id":     1,
+		"clear_display_message.status": "Accepted",
+	})
+
+	// the charge station does not hold the message
+	err := handler.HandleCallResult(ctx, "cs001", &types.ClearDisplayMessageRequestJson{Id: 2},
+		&types.ClearDisplayMessageResponseJson{Status: types.ClearMessageStatusEnumTypeUnknown}, nil)
+	require.NoError(t, err)
+
+	// the message has been scheduled again since the request was sent
+	err = handler.HandleCallResult(ctx, "cs001", &types.ClearDisplayMessageRequestJson{Id: 3},
+		&types.ClearDisplayMessageResponseJson{Status: types.ClearMessageStatusEnumTypeAccepted}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	require.Len(t, got.Messages, 1)
+	assert.Equal(t, store.DisplayMessageStatusAccepted, got.Messages[3].Status)
+}
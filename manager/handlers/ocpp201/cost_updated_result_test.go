@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"testing"
+)
+
+func TestCostUpdatedResultHandler(t *testing.T) {
+	handler := ocpp201.CostUpdatedResultHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		req := &types.CostUpdatedRequestJson{
+			TotalCost:     1.25,
+			TransactionId: "5555",
+		}
+		resp := &types.CostUpdatedResponseJson{}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"cost_updated.transaction_id": "5555",
+		"cost_updated.total_cost":     1.25,
+	})
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// costUpdateQueueSize is the number of CostUpdated requests that can be waiting to be sent: further
+// updates are dropped until the queue has space
+const costUpdateQueueSize = 1000
+
+// costUpdateIdleTimeout is how long the last running cost of a transaction is kept without any
+// updates: it is normally removed when the transaction ends, but the end may never be reported
+const costUpdateIdleTimeout = 24 * time.Hour
+
+type costUpdate struct {
+	ctx             context.Context
+	chargeStationId string
+	request         *types.CostUpdatedRequestJson
+}
+
+type sentCost struct {
+	cost   float64
+	sentAt time.Time
+}
+
+// CostUpdater sends the running cost of transactions to charge stations using CostUpdated. The
+// running cost is only sent when it has changed since the previous update for the transaction and
+// at least the minimum interval after it. Updates are queued and sent by a separate goroutine so that
+// the TransactionEvent call is not held up by sending the update.
+type CostUpdater struct {
+	sync.Mutex
+	callMaker   handlers.CallMaker
+	clock       clock.PassiveClock
+	minInterval time.Duration
+	queue       chan costUpdate
+	sent        map[string]sentCost
+	lastPrune   time.Time
+}
+
+// NewCostUpdater creates a CostUpdater and starts the goroutine that sends the updates
+func NewCostUpdater(callMaker handlers.CallMaker, clock clock.PassiveClock, minInterval time.Duration) *CostUpdater {
+	c := &CostUpdater{
+		callMaker:   callMaker,
+		clock:       clock,
+		minInterval: minInterval,
+		queue:       make(chan costUpdate, costUpdateQueueSize),
+		sent:        make(map[string]sentCost),
+		lastPrune:   clock.Now(),
+	}
+	go c.run()
+	return c
+}
+
+// Update queues a CostUpdated request for the transaction if the running cost has changed and the
+// previous update was sent at least the minimum interval ago
+func (c *CostUpdater) Update(ctx context.Context, chargeStationId, transactionId string, cost float64) {
+	key := chargeStationId + "/" + transactionId
+
+	c.Lock()
+	now := c.clock.Now()
+	c.prune(now)
+	previous, ok := c.sent[key]
+	if ok && (previous.cost == cost || now.Before(previous.sentAt.Add(c.minInterval))) {
+		c.Unlock()
+		return
+	}
+	c.sent[key] = sentCost{cost: cost, sentAt: now}
+	c.Unlock()
+
+	select {
+	case c.queue <- costUpdate{
+		// the update is sent after the call has been handled
+		ctx:             context.WithoutCancel(ctx),
+		chargeStationId: chargeStationId,
+		request: &types.CostUpdatedRequestJson{
+			TotalCost:     cost,
+			TransactionId: transactionId,
+		},
+	}:
+	default:
+		slog.Warn("cost update queue is full: dropping running cost", slog.String("chargeStationId", chargeStationId),
+			slog.String("transactionId", transactionId))
+		c.Lock()
+		delete(c.sent, key)
+		c.Unlock()
+	}
+}
+
+// End forgets the running cost of the transaction
+func (c *CostUpdater) End(chargeStationId, transactionId string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.sent, chargeStationId+"/"+transactionId)
+}
+
+// prune removes the running cost of transactions that have not been updated for costUpdateIdleTimeout,
+// it must be called with the lock held
+func (c *CostUpdater) prune(now time.Time) {
+	if now.Before(c.lastPrune.Add(costUpdateIdleTimeout)) {
+		return
+	}
+	c.lastPrune = now
+	for key, sent := range c.sent {
+		if !now.Before(sent.sentAt.Add(costUpdateIdleTimeout)) {
+			delete(c.sent, key)
+		}
+	}
+}
+
+func (c *CostUpdater) run() {
+	for update := range c.queue {
+		err := c.callMaker.Send(update.ctx, update.chargeStationId, update.request)
+		if err != nil {
+			slog.Error("unable to send running cost", slog.String("chargeStationId", update.chargeStationId),
+				slog.String("transactionId", update.request.TransactionId), "err", err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+func TestCostUpdaterOnlySendsChangedCostAfterMinInterval(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	clk := clockTest.NewFakePassiveClock(now)
+	callMaker := &recordingCallMaker{}
+
+	updater := handlers.NewCostUpdater(callMaker, clk, time.Minute)
+
+	updater.Update(ctx, "cs001", "tx001", 1.0)
+	// the cost has changed, but the previous update was sent too recently
+	clk.SetTime(now.Add(30 * time.Second))
+	updater.Update(ctx, "cs001", "tx001", 1.5)
+	// other transactions are not affected
+	updater.Update(ctx, "cs001", "tx002", 0.5)
+	clk.SetTime(now.Add(2 * time.Minute))
+	updater.Update(ctx, "cs001", "tx001", 1.5)
+	// the cost is unchanged
+	clk.SetTime(now.Add(4 * time.Minute))
+	updater.Update(ctx, "cs001", "tx001", 1.5)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, []ocpp.Request{
+			&types.CostUpdatedRequestJson{TotalCost: 1.0, TransactionId: "tx001"},
+			&types.CostUpdatedRequestJson{TotalCost: 0.5, TransactionId: "tx002"},
+			&types.CostUpdatedRequestJson{TotalCost: 1.5, TransactionId: "tx001"},
+		}, callMaker.sent())
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCostUpdaterForgetsTransactionThatHasEnded(t *testing.T) {
+	ctx := context.Background()
+	clk := clockTest.NewFakePassiveClock(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	callMaker := &recordingCallMaker{}
+
+	updater := handlers.NewCostUpdater(callMaker, clk, time.Minute)
+
+	updater.Update(ctx, "cs001", "tx001", 1.0)
+	updater.End("cs001", "tx001")
+	updater.Update(ctx, "cs001", "tx001", 2.0)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, callMaker.sent(), 2)
+	}, time.Second, 10*time.Millisecond)
+}
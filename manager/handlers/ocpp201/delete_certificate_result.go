@@ -4,13 +4,19 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type DeleteCertificateResultHandler struct{}
+type DeleteCertificateResultHandler struct {
+	Store store.CertificateInventoryStore
+	Clock clock.PassiveClock
+}
 
 func (h DeleteCertificateResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.DeleteCertificateRequestJson)
@@ -22,5 +28,10 @@ func (h DeleteCertificateResultHandler) HandleCallResult(ctx context.Context, ch
 		attribute.String("delete_certificate.serial_number", req.CertificateHashData.SerialNumber),
 		attribute.String("delete_certificate.status", string(resp.Status)))
 
-	return nil
+	// NotFound means that the certificate is not installed either
+	if resp.Status == types.DeleteCertificateStatusEnumTypeFailed {
+		return nil
+	}
+
+	return handlers.RemoveInventoryCertificate(ctx, h.Store, h.Clock, chargeStationId, newInventoryCertificate("", req.CertificateHashData))
 }
@@ -4,20 +4,46 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
 	"testing"
 )
 
 func TestDeleteCertificateResultHandler(t *testing.T) {
-	handler := ocpp201.DeleteCertificateResultHandler{}
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.DeleteCertificateResultHandler{Store: engine, Clock: clock.RealClock{}}
 
 	tracer, exporter := testutil.GetTracer()
 
 	ctx := context.Background()
 
+	remaining := &store.InventoryCertificate{
+		CertificateType: store.CertificateTypeMO,
+		HashAlgorithm:   "SHA256",
+		IssuerKeyHash:   "DEF456",
+		IssuerNameHash:  "FEDCBA",
+		SerialNumber:    "87654321",
+	}
+	err := engine.SetChargeStationCertificateInventory(ctx, "cs001", &store.ChargeStationCertificateInventory{
+		Certificates: []*store.InventoryCertificate{
+			{
+				CertificateType: store.CertificateTypeCSMS,
+				HashAlgorithm:   "SHA256",
+				IssuerKeyHash:   "abc123",
+				IssuerNameHash:  "abcdef",
+				SerialNumber:    "012345678",
+			},
+			remaining,
+		},
+	})
+	require.NoError(t, err)
+
 	func() {
 		ctx, span := tracer.Start(ctx, `test`)
 		defer span.End()
@@ -42,4 +68,8 @@ func TestDeleteCertificateResultHandler(t *testing.T) {
 		"delete_certificate.serial_number": "12345678",
 		"delete_certificate.status":        "Accepted",
 	})
+
+	inventory, err := engine.LookupChargeStationCertificateInventory(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.InventoryCertificate{remaining}, inventory.Certificates)
 }
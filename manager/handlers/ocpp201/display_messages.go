@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// updateDisplayMessages applies the update to the charge station's display messages and stores
+// them if the update returns true. Charge stations without managed display messages are ignored.
+func updateDisplayMessages(ctx context.Context, displayMessageStore store.DisplayMessageStore, clock clock.PassiveClock, chargeStationId string, update func(*store.ChargeStationDisplayMessages) bool) error {
+	messages, err := displayMessageStore.LookupChargeStationDisplayMessages(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station display messages: %w", err)
+	}
+	if messages == nil {
+		slog.Warn("no display messages for charge station", slog.String("chargeStationId", chargeStationId))
+		return nil
+	}
+	if !update(messages) {
+		return nil
+	}
+	messages.UpdatedAt = clock.Now()
+
+	err = displayMessageStore.SetChargeStationDisplayMessages(ctx, chargeStationId, messages)
+	if err != nil {
+		return fmt.Errorf("set charge station display messages: %w", err)
+	}
+	return nil
+}
+
+// markDisplayMessagesForReconcile is used when a charge station boots: the charge station may
+// not have retained its display messages so the messages that it holds must be checked
+func markDisplayMessagesForReconcile(ctx context.Context, displayMessageStore store.DisplayMessageStore, clock clock.PassiveClock, chargeStationId string) error {
+	messages, err := displayMessageStore.LookupChargeStationDisplayMessages(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station display messages: %w", err)
+	}
+	if messages == nil {
+		return nil
+	}
+
+	messages.ReconcileStatus = store.DisplayMessageReconcileStatusRequired
+	messages.ReconcileAfter = time.Time{}
+	messages.UpdatedAt = clock.Now()
+
+	err = displayMessageStore.SetChargeStationDisplayMessages(ctx, chargeStationId, messages)
+	if err != nil {
+		return fmt.Errorf("set charge station display messages: %w", err)
+	}
+	return nil
+}
+
+// completeDisplayMessagesReconcile is used once the charge station has reported all the messages
+// that it holds for the request: accepted messages that were not reported are sent again and
+// messages that the charge station no longer holds are forgotten
+func completeDisplayMessagesReconcile(messages *store.ChargeStationDisplayMessages, requestId int) {
+	for id, message := range messages.Messages {
+		if message.ReportedRequestId == requestId {
+			continue
+		}
+		switch message.Status {
+		case store.DisplayMessageStatusAccepted:
+			message.Status = store.DisplayMessageStatusPending
+			message.SendAfter = time.Time{}
+		case store.DisplayMessageStatusUnmanaged, store.DisplayMessageStatusClearPending:
+			delete(messages.Messages, id)
+		}
+	}
+	messages.ReconcileStatus = ""
+	messages.ReconcileAfter = time.Time{}
+}
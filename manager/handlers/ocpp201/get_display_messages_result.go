@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type GetDisplayMessagesResultHandler struct {
+	Store store.DisplayMessageStore
+	Clock clock.PassiveClock
+}
+
+func (h GetDisplayMessagesResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.GetDisplayMessagesRequestJson)
+	resp := response.(*types.GetDisplayMessagesResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("get_display_messages.request_id", req.RequestId),
+		attribute.String("get_display_messages.status", string(resp.Status)))
+
+	if resp.Status == types.GetDisplayMessagesStatusEnumTypeAccepted {
+		// the messages will be reported using NotifyDisplayMessages
+		return nil
+	}
+
+	// the charge station does not hold any messages
+	return updateDisplayMessages(ctx, h.Store, h.Clock, chargeStationId, func(messages *store.ChargeStationDisplayMessages) bool {
+		if messages.ReconcileStatus != store.DisplayMessageReconcileStatusPending || messages.ReconcileRequestId != req.RequestId {
+			return false
+		}
+		completeDisplayMessagesReconcile(messages, req.RequestId)
+		return true
+	})
+}
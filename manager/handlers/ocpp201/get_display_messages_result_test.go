@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func setDisplayMessagesReconcilePending(t *testing.T, engine store.DisplayMessageStore, requestId int) {
+	ctx := context.Background()
+	messages, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	messages.ReconcileStatus = store.DisplayMessageReconcileStatusPending
+	messages.ReconcileRequestId = requestId
+	err = engine.SetChargeStationDisplayMessages(ctx, "cs001", messages)
+	require.NoError(t, err)
+}
+
+func TestGetDisplayMessagesResultHandlerWithNoMessages(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.GetDisplayMessagesResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+	setDisplayMessages(t, engine,
+		newDisplayMessage(1, store.DisplayMessageStatusAccepted),
+		newDisplayMessage(2, store.DisplayMessageStatusClearPending),
+		newDisplayMessage(3, store.DisplayMessageStatusRejected))
+	setDisplayMessagesReconcilePending(t, engine, 7)
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		err := handler.HandleCallResult(ctx, "cs001", &types.GetDisplayMessagesRequestJson{RequestId: 7},
+			&types.GetDisplayMessagesResponseJson{Status: types.GetDisplayMessagesStatusEnumTypeUnknown}, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"get_display_messages.request_id": 7,
+		"get_display_messages.status":     "Unknown",
+	})
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageReconcileStatus(""), got.ReconcileStatus)
+	require.Len(t, got.Messages, 2)
+	assert.Equal(t, store.DisplayMessageStatusPending, got.Messages[1].Status)
+	assert.Equal(t, store.DisplayMessageStatusRejected, got.Messages[3].Status)
+}
+
+func TestGetDisplayMessagesResultHandlerWaitsForReport(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.GetDisplayMessagesResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+	setDisplayMessages(t, engine, newDisplayMessage(1, store.DisplayMessageStatusAccepted))
+	setDisplayMessagesReconcilePending(t, engine, 7)
+
+	err := handler.HandleCallResult(ctx, "cs001", &types.GetDisplayMessagesRequestJson{RequestId: 7},
+		&types.GetDisplayMessagesResponseJson{Status: types.GetDisplayMessagesStatusEnumTypeAccepted}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageReconcileStatusPending, got.ReconcileStatus)
+	assert.Equal(t, store.DisplayMessageStatusAccepted, got.Messages[1].Status)
+}
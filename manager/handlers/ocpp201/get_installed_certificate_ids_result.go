@@ -4,14 +4,20 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 	"strings"
 )
 
-type GetInstalledCertificateIdsResultHandler struct{}
+type GetInstalledCertificateIdsResultHandler struct {
+	Store store.CertificateInventoryStore
+	Clock clock.PassiveClock
+}
 
 func (h GetInstalledCertificateIdsResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.GetInstalledCertificateIdsRequestJson)
@@ -20,9 +26,11 @@ func (h GetInstalledCertificateIdsResultHandler) HandleCallResult(ctx context.Co
 	span := trace.SpanFromContext(ctx)
 
 	var certTypes []string
+	var storeTypes []store.CertificateType
 	if req.CertificateType != nil {
 		for _, ct := range req.CertificateType {
 			certTypes = append(certTypes, string(ct))
+			storeTypes = append(storeTypes, getInventoryCertificateType(ct))
 		}
 	}
 
@@ -30,5 +38,43 @@ func (h GetInstalledCertificateIdsResultHandler) HandleCallResult(ctx context.Co
 		attribute.String("get_installed_certificate.types", strings.Join(certTypes, ",")),
 		attribute.String("get_installed_certificate.status", string(resp.Status)))
 
-	return nil
+	// NotFound means that there are no certificates of the requested types
+	var certificates []*store.InventoryCertificate
+	switch resp.Status {
+	case types.GetInstalledCertificateStatusEnumTypeAccepted:
+		for _, chain := range resp.CertificateHashDataChain {
+			certificates = append(certificates, newInventoryCertificate(getInventoryCertificateType(chain.CertificateType), chain.CertificateHashData))
+		}
+	case types.GetInstalledCertificateStatusEnumTypeNotFound:
+	default:
+		return nil
+	}
+
+	return handlers.ReplaceInventoryCertificates(ctx, h.Store, h.Clock, chargeStationId, storeTypes, certificates)
+}
+
+func getInventoryCertificateType(certificateType types.GetCertificateIdUseEnumType) store.CertificateType {
+	switch certificateType {
+	case types.GetCertificateIdUseEnumTypeV2GRootCertificate:
+		return store.CertificateTypeV2G
+	case types.GetCertificateIdUseEnumTypeMORootCertificate:
+		return store.CertificateTypeMO
+	case types.GetCertificateIdUseEnumTypeCSMSRootCertificate:
+		return store.CertificateTypeCSMS
+	case types.GetCertificateIdUseEnumTypeManufacturerRootCertificate:
+		return store.CertificateTypeMF
+	case types.GetCertificateIdUseEnumTypeV2GCertificateChain:
+		return store.CertificateTypeEVCC
+	}
+	return ""
+}
+
+func newInventoryCertificate(certificateType store.CertificateType, hashData types.CertificateHashDataType) *store.InventoryCertificate {
+	return &store.InventoryCertificate{
+		CertificateType: certificateType,
+		HashAlgorithm:   string(hashData.HashAlgorithm),
+		IssuerNameHash:  hashData.IssuerNameHash,
+		IssuerKeyHash:   hashData.IssuerKeyHash,
+		SerialNumber:    hashData.SerialNumber,
+	}
 }
@@ -4,20 +4,50 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	clockTest "k8s.io/utils/clock/testing"
 	"testing"
+	"time"
 )
 
 func TestGetInstalledCertificateIdsResultHandler(t *testing.T) {
-	handler := ocpp201.GetInstalledCertificateIdsResultHandler{}
+	now := time.Now().UTC()
+	clock := clockTest.NewFakePassiveClock(now)
+	engine := inmemory.NewStore(clock)
+	handler := ocpp201.GetInstalledCertificateIdsResultHandler{Store: engine, Clock: clock}
 
 	tracer, exporter := testutil.GetTracer()
 
 	ctx := context.Background()
 
+	// certificates of types that were not requested are kept
+	v2gRoot := &store.InventoryCertificate{
+		CertificateType: store.CertificateTypeV2G,
+		HashAlgorithm:   "SHA256",
+		IssuerKeyHash:   "DEF456",
+		IssuerNameHash:  "FEDCBA",
+		SerialNumber:    "87654321",
+	}
+	err := engine.SetChargeStationCertificateInventory(ctx, "cs001", &store.ChargeStationCertificateInventory{
+		Certificates: []*store.InventoryCertificate{
+			v2gRoot,
+			{
+				CertificateType: store.CertificateTypeMO,
+				HashAlgorithm:   "SHA256",
+				IssuerKeyHash:   "123456",
+				IssuerNameHash:  "654321",
+				SerialNumber:    "01",
+			},
+		},
+	})
+	require.NoError(t, err)
+
 	func() {
 		ctx, span := tracer.Start(ctx, `test`)
 		defer span.End()
@@ -52,4 +82,22 @@ func TestGetInstalledCertificateIdsResultHandler(t *testing.T) {
 		"get_installed_certificate.types":  "CSMSRootCertificate,MORootCertificate",
 		"get_installed_certificate.status": "Accepted",
 	})
+
+	inventory, err := engine.LookupChargeStationCertificateInventory(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationCertificateInventory{
+		ChargeStationId: "cs001",
+		Certificates: []*store.InventoryCertificate{
+			v2gRoot,
+			{
+				CertificateType: store.CertificateTypeCSMS,
+				HashAlgorithm:   "SHA256",
+				IssuerKeyHash:   "ABC123",
+				IssuerNameHash:  "ABCDEF",
+				SerialNumber:    "12345678",
+			},
+		},
+		ReportedAt: &now,
+		UpdatedAt:  now,
+	}, inventory)
 }
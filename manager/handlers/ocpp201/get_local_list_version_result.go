@@ -4,13 +4,19 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type GetLocalListVersionResultHandler struct{}
+type GetLocalListVersionResultHandler struct {
+	Store store.LocalListStore
+	Clock clock.PassiveClock
+}
 
 func (h GetLocalListVersionResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	resp := response.(*types.GetLocalListVersionResponseJson)
@@ -20,5 +26,5 @@ func (h GetLocalListVersionResultHandler) HandleCallResult(ctx context.Context,
 	span.SetAttributes(
 		attribute.Int("get_local_list_version.version_number", resp.VersionNumber))
 
-	return nil
+	return handlers.RecordLocalListVersion(ctx, h.Store, h.Clock, chargeStationId, resp.VersionNumber)
 }
@@ -4,15 +4,24 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 	"testing"
+	"time"
 )
 
 func TestGetLocalListVersionResultHandler(t *testing.T) {
-	handler := ocpp201.GetLocalListVersionResultHandler{}
+	handler := ocpp201.GetLocalListVersionResultHandler{
+		Store: inmemory.NewStore(clock.RealClock{}),
+		Clock: clock.RealClock{},
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -35,3 +44,60 @@ func TestGetLocalListVersionResultHandler(t *testing.T) {
 		"get_local_list_version.version_number": 42,
 	})
 }
+
+func TestGetLocalListVersionResultHandlerWithDifferentVersion(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp201.GetLocalListVersionResultHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+
+	err := engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Version: 3,
+		Entries: map[string]*store.LocalListEntry{},
+		Status:  store.LocalListStatusVersionCheckPending,
+	})
+	require.NoError(t, err)
+
+	err = handler.HandleCallResult(ctx, "cs001", &types.GetLocalListVersionRequestJson{},
+		&types.GetLocalListVersionResponseJson{VersionNumber: 0}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationLocalList{
+		ChargeStationId: "cs001",
+		Version:         3,
+		Entries:         map[string]*store.LocalListEntry{},
+		Status:          store.LocalListStatusVersionMismatch,
+		UpdatedAt:       clk.Now(),
+	}, got)
+}
+
+func TestGetLocalListVersionResultHandlerWithSameVersion(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.GetLocalListVersionResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+
+	err := engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Version: 3,
+		Entries: map[string]*store.LocalListEntry{},
+		Status:  store.LocalListStatusVersionCheckPending,
+	})
+	require.NoError(t, err)
+
+	err = handler.HandleCallResult(ctx, "cs001", &types.GetLocalListVersionRequestJson{},
+		&types.GetLocalListVersionResponseJson{VersionNumber: 3}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.LocalListStatusAccepted, got.Status)
+}
@@ -4,13 +4,19 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type GetTransactionStatusResultHandler struct{}
+type GetTransactionStatusResultHandler struct {
+	Store store.ChargeStationOperationStore
+	Clock clock.PassiveClock
+}
 
 func (h GetTransactionStatusResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.GetTransactionStatusRequestJson)
@@ -30,5 +36,5 @@ func (h GetTransactionStatusResultHandler) HandleCallResult(ctx context.Context,
 			attribute.Bool("get_transaction_status.ongoing", *resp.OngoingIndicator))
 	}
 
-	return nil
+	return handlers.RecordOperationResult(ctx, h.Store, h.Clock, chargeStationId, "GetTransactionStatus", "Accepted", resp)
 }
@@ -7,12 +7,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
 	"testing"
 )
 
 func TestTransactionStatusResultHandlerWithTransactionId(t *testing.T) {
-	handler := ocpp201.GetTransactionStatusResultHandler{}
+	handler := ocpp201.GetTransactionStatusResultHandler{
+		Store: inmemory.NewStore(clock.RealClock{}),
+		Clock: clock.RealClock{},
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -40,7 +45,10 @@ func TestTransactionStatusResultHandlerWithTransactionId(t *testing.T) {
 }
 
 func TestTransactionStatusResultHandlerWithOngoingIndicator(t *testing.T) {
-	handler := ocpp201.GetTransactionStatusResultHandler{}
+	handler := ocpp201.GetTransactionStatusResultHandler{
+		Store: inmemory.NewStore(clock.RealClock{}),
+		Clock: clock.RealClock{},
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type GetVariablesErrorHandler struct {
+	VariablesStore store.ChargeStationVariablesStore
+}
+
+func (h GetVariablesErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*types.GetVariablesRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("get_variables.count", len(req.GetVariableData)))
+
+	variables := make(map[string]*store.ChargeStationVariable)
+	for _, data := range req.GetVariableData {
+		name := getVariableName(data.Component, data.Variable, data.AttributeType)
+		variables[name] = &store.ChargeStationVariable{
+			Status:           store.ChargeStationVariableStatusRejected,
+			ErrorCode:        string(errorCode),
+			ErrorDescription: errorDescription,
+		}
+	}
+
+	err := h.VariablesStore.UpdateChargeStationVariables(ctx, chargeStationId, &store.ChargeStationVariables{
+		ChargeStationId: chargeStationId,
+		Variables:       variables,
+	})
+	if err != nil {
+		return fmt.Errorf("update charge station variables: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+func TestGetVariablesErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	handler := handlers201.GetVariablesErrorHandler{VariablesStore: engine}
+
+	err := handler.HandleCallError(ctx, "cs001", &ocpp201.GetVariablesRequestJson{
+		GetVariableData: []ocpp201.GetVariableDataType{
+			{
+				Component: ocpp201.ComponentType{Name: "EVSE", Evse: &ocpp201.EVSEType{Id: 1}},
+				Variable:  ocpp201.VariableType{Name: "Power"},
+			},
+		},
+	}, transport.ErrorInternalError, "oops", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationVariables(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*store.ChargeStationVariable{
+		"EVSE;;1/Power": {
+			Status:           store.ChargeStationVariableStatusRejected,
+			ErrorCode:        "InternalError",
+			ErrorDescription: "oops",
+		},
+	}, got.Variables)
+}
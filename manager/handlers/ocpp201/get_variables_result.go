@@ -3,16 +3,22 @@ package ocpp201
 import (
 	"context"
 	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"strings"
 )
 
-type GetVariablesResultHandler struct{}
+type GetVariablesResultHandler struct {
+	VariablesStore store.ChargeStationVariablesStore
+	SettingsStore  store.ChargeStationSettingsStore
+}
 
 func (h GetVariablesResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.GetVariablesRequestJson)
 	resp := response.(*types.GetVariablesResponseJson)
 
 	span := trace.SpanFromContext(ctx)
@@ -23,14 +29,111 @@ func (h GetVariablesResultHandler) HandleCallResult(ctx context.Context, chargeS
 		variableNames = append(variableNames, fmt.Sprintf("%s/%s", getComponentId(v.Component), getVariableId(v.Variable)))
 		variableValues = append(variableValues, fmt.Sprintf("%s/%s:%s", getAttributeTypeName(v.AttributeType), getAttributeValue(v.AttributeValue), v.AttributeStatus))
 	}
+	variables := GetVariablesResultVariables(req, resp)
 
 	span.SetAttributes(
 		attribute.String("get_variables.names", strings.Join(variableNames, ",")),
 		attribute.String("get_variables.values", strings.Join(variableValues, ",")))
 
+	if len(variables) == 0 {
+		return nil
+	}
+
+	err := h.VariablesStore.UpdateChargeStationVariables(ctx, chargeStationId, &store.ChargeStationVariables{
+		ChargeStationId: chargeStationId,
+		Variables:       variables,
+	})
+	if err != nil {
+		return fmt.Errorf("update charge station variables: %w", err)
+	}
+
+	drifted, err := handlers.DetectSettingsDrift(ctx, h.SettingsStore, chargeStationId, variables)
+	if err != nil {
+		return err
+	}
+	if len(drifted) > 0 {
+		span.SetAttributes(attribute.String("get_variables.drifted", strings.Join(drifted, ",")))
+	}
+
 	return nil
 }
 
+// GetVariablesResultVariables returns the variables in the GetVariables response keyed by the
+// name that was used to request them
+func GetVariablesResultVariables(req *types.GetVariablesRequestJson, resp *types.GetVariablesResponseJson) map[string]*store.ChargeStationVariable {
+	variables := make(map[string]*store.ChargeStationVariable)
+	for _, v := range resp.GetVariableResult {
+		variables[getRequestedVariableName(req, v)] = &store.ChargeStationVariable{
+			Value:  v.AttributeValue,
+			Status: store.ChargeStationVariableStatus(v.AttributeStatus),
+		}
+	}
+	return variables
+}
+
+type requestedVariable struct {
+	component     types.ComponentType
+	variable      types.VariableType
+	attributeType *types.AttributeEnumType
+}
+
+// findRequestedVariable returns the index of the requested variable that corresponds to the result
+// or -1 if there is no match. The charge station may omit the attribute type from the result (or
+// include it when it was omitted from the request) so the match treats a missing attribute type
+// as Actual.
+func findRequestedVariable(requested []requestedVariable, component types.ComponentType, variable types.VariableType, attributeType *types.AttributeEnumType) int {
+	resultName := getVariableName(component, variable, nil)
+	for i, r := range requested {
+		if getVariableName(r.component, r.variable, nil) == resultName &&
+			getAttributeTypeName(r.attributeType) == getAttributeTypeName(attributeType) {
+			return i
+		}
+	}
+	return -1
+}
+
+// getRequestedVariableName returns the name that was used to request the variable so the result
+// is stored against the same key
+func getRequestedVariableName(req *types.GetVariablesRequestJson, result types.GetVariableResultType) string {
+	requested := make([]requestedVariable, len(req.GetVariableData))
+	for i, data := range req.GetVariableData {
+		requested[i] = requestedVariable{component: data.Component, variable: data.Variable, attributeType: data.AttributeType}
+	}
+	if i := findRequestedVariable(requested, result.Component, result.Variable, result.AttributeType); i >= 0 {
+		return getVariableName(requested[i].component, requested[i].variable, requested[i].attributeType)
+	}
+	return getVariableName(result.Component, result.Variable, result.AttributeType)
+}
+
+// getVariableName returns the name of the variable using the syntax accepted by the API:
+// <component>[;<instance>[;<evse id>]]/<variable>[;<instance>[;<attribute type>]] where the
+// instance is left empty when only the EVSE id or attribute type is present
+func getVariableName(component types.ComponentType, variable types.VariableType, attributeType *types.AttributeEnumType) string {
+	var b strings.Builder
+	b.WriteString(component.Name)
+	if component.Instance != nil || component.Evse != nil {
+		b.WriteString(";")
+		if component.Instance != nil {
+			b.WriteString(*component.Instance)
+		}
+		if component.Evse != nil {
+			_, _ = fmt.Fprintf(&b, ";%d", component.Evse.Id)
+		}
+	}
+	b.WriteString("/")
+	b.WriteString(variable.Name)
+	if variable.Instance != nil || attributeType != nil {
+		b.WriteString(";")
+		if variable.Instance != nil {
+			b.WriteString(*variable.Instance)
+		}
+		if attributeType != nil {
+			_, _ = fmt.Fprintf(&b, ";%s", *attributeType)
+		}
+	}
+	return b.String()
+}
+
 func getComponentId(component types.ComponentType) string {
 	instance := ""
 	evseId := ""
@@ -2,15 +2,23 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
 	"testing"
 )
 
 func TestGetVariablesResult(t *testing.T) {
-	handler := ocpp201.GetVariablesResultHandler{}
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.GetVariablesResultHandler{
+		VariablesStore: engine,
+		SettingsStore:  engine,
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -133,4 +141,70 @@ func TestGetVariablesResult(t *testing.T) {
 		"get_variables.names":  "SomeCtrlr:::/MyVar:,SomeOtherCtrlr:SomeInstance::/MyOtherVar:SomeVarInstance,SomeOtherCtrlr:SomeInstance:1:/MyOtherVar:SomeVarInstance,SomeCtrlr::1:2/AnotherVar:",
 		"get_variables.values": "MaxSet/12:Accepted,Actual/Example:Accepted,Actual/<null>:NotSupportedAttributeType,Actual/Hello:Accepted",
 	})
+
+	got, err := engine.LookupChargeStationVariables(ctx, "cs001")
+	require.NoError(t, err)
+
+	want := &store.ChargeStationVariables{
+		ChargeStationId: "cs001",
+		Variables: map[string]*store.ChargeStationVariable{
+			"SomeCtrlr/MyVar;;MaxSet": {
+				Value:  makePtr("12"),
+				Status: store.ChargeStationVariableStatusAccepted,
+			},
+			"SomeOtherCtrlr;SomeInstance/MyOtherVar;SomeVarInstance": {
+				Value:  makePtr("Example"),
+				Status: store.ChargeStationVariableStatusAccepted,
+			},
+			"SomeOtherCtrlr;SomeInstance;1/MyOtherVar;SomeVarInstance": {
+				Status: store.ChargeStationVariableStatusNotSupportedAttributeType,
+			},
+			"SomeCtrlr;;1/AnotherVar": {
+				Value:  makePtr("Hello"),
+				Status: store.ChargeStationVariableStatusAccepted,
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestGetVariablesResultMatchesRequestedName(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.GetVariablesResultHandler{
+		VariablesStore: engine,
+		SettingsStore:  engine,
+	}
+
+	req := &types.GetVariablesRequestJson{
+		GetVariableData: []types.GetVariableDataType{
+			{
+				Component: types.ComponentType{Name: "OCPPCommCtrlr"},
+				Variable:  types.VariableType{Name: "HeartbeatInterval"},
+			},
+		},
+	}
+	resp := &types.GetVariablesResponseJson{
+		GetVariableResult: []types.GetVariableResultType{
+			{
+				Component:       types.ComponentType{Name: "OCPPCommCtrlr"},
+				Variable:        types.VariableType{Name: "HeartbeatInterval"},
+				AttributeType:   makePtr(types.AttributeEnumTypeActual),
+				AttributeValue:  makePtr("60"),
+				AttributeStatus: types.GetVariableStatusEnumTypeAccepted,
+			},
+		},
+	}
+
+	err := handler.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationVariables(context.Background(), "cs001")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]*store.ChargeStationVariable{
+		"OCPPCommCtrlr/HeartbeatInterval": {
+			Value:  makePtr("60"),
+			Status: store.ChargeStationVariableStatusAccepted,
+		},
+	}, got.Variables)
 }
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type InstallCertificateErrorHandler struct {
+	Store store.ChargeStationInstallCertificatesStore
+}
+
+func (i InstallCertificateErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*ocpp201.InstallCertificateRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("install_certificate.type", string(req.CertificateType)))
+
+	certId, err := GetCertificateId(req.Certificate)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.String("install_certificate.id", certId))
+
+	return i.Store.UpdateChargeStationInstallCertificates(ctx, chargeStationId, &store.ChargeStationInstallCertificates{
+		Certificates: []*store.ChargeStationInstallCertificate{
+			{
+				CertificateType:               getCertificateType(req.CertificateType),
+				CertificateId:                 certId,
+				CertificateData:               req.Certificate,
+				CertificateInstallationStatus: store.CertificateInstallationErrored,
+				ErrorCode:                     string(errorCode),
+				ErrorDescription:              errorDescription,
+			},
+		},
+	})
+}
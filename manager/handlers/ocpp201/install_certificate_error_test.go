@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+func TestInstallCertificateErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	handler := handlers201.InstallCertificateErrorHandler{Store: engine}
+
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("test")})
+	certId, err := handlers201.GetCertificateId(string(pemBytes))
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		err = handler.HandleCallError(ctx, "cs001", &ocpp201.InstallCertificateRequestJson{
+			CertificateType: ocpp201.InstallCertificateUseEnumTypeMORootCertificate,
+			Certificate:     string(pemBytes),
+		}, transport.ErrorSecurityError, "untrusted", nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"install_certificate.type": "MORootCertificate",
+		"install_certificate.id":   certId,
+	})
+
+	got, err := engine.LookupChargeStationInstallCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationInstallCertificate{
+		{
+			CertificateType:               store.CertificateTypeMO,
+			CertificateId:                 certId,
+			CertificateData:               string(pemBytes),
+			CertificateInstallationStatus: store.CertificateInstallationErrored,
+			ErrorCode:                     "SecurityError",
+			ErrorDescription:              "untrusted",
+		},
+	}, got.Certificates)
+}
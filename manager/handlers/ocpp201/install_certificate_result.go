@@ -4,15 +4,21 @@ package ocpp201
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
 )
 
 type InstallCertificateResultHandler struct {
 	Store store.Engine
+	Clock clock.PassiveClock
 }
 
 func (i InstallCertificateResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
@@ -31,18 +37,6 @@ func (i InstallCertificateResultHandler) HandleCallResult(ctx context.Context, c
 	}
 	span.SetAttributes(attribute.String("install_certificate.id", certId))
 
-	var storeType store.CertificateType
-	switch req.CertificateType {
-	case ocpp201.InstallCertificateUseEnumTypeV2GRootCertificate:
-		storeType = store.CertificateTypeV2G
-	case ocpp201.InstallCertificateUseEnumTypeMORootCertificate:
-		storeType = store.CertificateTypeMO
-	case ocpp201.InstallCertificateUseEnumTypeCSMSRootCertificate:
-		storeType = store.CertificateTypeCSMS
-	case ocpp201.InstallCertificateUseEnumTypeManufacturerRootCertificate:
-		storeType = store.CertificateTypeMF
-	}
-
 	var installStatus store.CertificateInstallationStatus
 	switch resp.Status {
 	case ocpp201.InstallCertificateStatusEnumTypeAccepted:
@@ -56,7 +50,7 @@ func (i InstallCertificateResultHandler) HandleCallResult(ctx context.Context, c
 	err = i.Store.UpdateChargeStationInstallCertificates(ctx, chargeStationId, &store.ChargeStationInstallCertificates{
 		Certificates: []*store.ChargeStationInstallCertificate{
 			{
-				CertificateType:               storeType,
+				CertificateType:               getCertificateType(req.CertificateType),
 				CertificateId:                 certId,
 				CertificateData:               req.Certificate,
 				CertificateInstallationStatus: installStatus,
@@ -67,5 +61,42 @@ func (i InstallCertificateResultHandler) HandleCallResult(ctx context.Context, c
 		return err
 	}
 
+	if resp.Status == ocpp201.InstallCertificateStatusEnumTypeAccepted {
+		return i.addInventoryCertificate(ctx, chargeStationId, getCertificateType(req.CertificateType), req.Certificate)
+	}
+
 	return nil
 }
+
+// addInventoryCertificate adds the installed certificate to the charge station's certificate inventory.
+// The certificate is not added if it cannot be parsed: it will be found by the next certificate audit.
+func (i InstallCertificateResultHandler) addInventoryCertificate(ctx context.Context, chargeStationId string, certificateType store.CertificateType, pemData string) error {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		slog.Warn("unable to parse installed certificate", slog.String("chargeStationId", chargeStationId), slog.String("err", err.Error()))
+		return nil
+	}
+	certificate, err := handlers.NewInventoryCertificate(certificateType, cert)
+	if err != nil {
+		return err
+	}
+	return handlers.AddInventoryCertificate(ctx, i.Store, i.Clock, chargeStationId, certificate)
+}
+
+func getCertificateType(certificateType ocpp201.InstallCertificateUseEnumType) store.CertificateType {
+	switch certificateType {
+	case ocpp201.InstallCertificateUseEnumTypeV2GRootCertificate:
+		return store.CertificateTypeV2G
+	case ocpp201.InstallCertificateUseEnumTypeMORootCertificate:
+		return store.CertificateTypeMO
+	case ocpp201.InstallCertificateUseEnumTypeCSMSRootCertificate:
+		return store.CertificateTypeCSMS
+	case ocpp201.InstallCertificateUseEnumTypeManufacturerRootCertificate:
+		return store.CertificateTypeMF
+	}
+	return ""
+}
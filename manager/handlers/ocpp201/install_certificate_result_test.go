@@ -9,11 +9,13 @@ import (
 	"github.com/stretchr/testify/require"
 	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
 	"k8s.io/utils/clock"
 	"testing"
+	"time"
 )
 
 func TestInstallCertificateResultHandler(t *testing.T) {
@@ -164,3 +166,29 @@ func TestInstallCertificateResultHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestInstallCertificateResultHandlerAddsInventoryCertificate(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := handlers201.InstallCertificateResultHandler{Store: engine, Clock: clock.RealClock{}}
+
+	ctx := context.Background()
+	pemData, err := x509CertificateProvider{t: t, notAfter: time.Now().Add(time.Hour)}.ProvideCertificate(ctx, services.CertificateTypeV2G, "", "")
+	require.NoError(t, err)
+
+	req := &ocpp201.InstallCertificateRequestJson{
+		Certificate:     pemData,
+		CertificateType: ocpp201.InstallCertificateUseEnumTypeMORootCertificate,
+	}
+	resp := &ocpp201.InstallCertificateResponseJson{
+		Status: ocpp201.InstallCertificateStatusEnumTypeAccepted,
+	}
+	err = handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	inventory, err := engine.LookupChargeStationCertificateInventory(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, inventory)
+	require.Len(t, inventory.Certificates, 1)
+	assert.Equal(t, store.CertificateTypeMO, inventory.Certificates[0].CertificateType)
+	assert.Equal(t, "1234", inventory.Certificates[0].SerialNumber)
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type NotifyDisplayMessagesHandler struct {
+	Store store.DisplayMessageStore
+	Clock clock.PassiveClock
+}
+
+func (h NotifyDisplayMessagesHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.NotifyDisplayMessagesRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("notify_display_messages.request_id", req.RequestId),
+		attribute.Int("notify_display_messages.count", len(req.MessageInfo)),
+		attribute.Bool("notify_display_messages.tbc", req.Tbc))
+
+	err := updateDisplayMessages(ctx, h.Store, h.Clock, chargeStationId, func(messages *store.ChargeStationDisplayMessages) bool {
+		if messages.ReconcileStatus != store.DisplayMessageReconcileStatusPending || messages.ReconcileRequestId != req.RequestId {
+			// a report that was not requested by the CSMS
+			return false
+		}
+		if messages.Messages == nil {
+			messages.Messages = make(map[int]*store.DisplayMessage)
+		}
+		for _, info := range req.MessageInfo {
+			message, ok := messages.Messages[info.Id]
+			if !ok {
+				message = newUnmanagedDisplayMessage(info)
+				messages.Messages[info.Id] = message
+			}
+			message.ReportedRequestId = req.RequestId
+		}
+		if !req.Tbc {
+			completeDisplayMessagesReconcile(messages, req.RequestId)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.NotifyDisplayMessagesResponseJson{}, nil
+}
+
+func newUnmanagedDisplayMessage(info types.MessageInfoType) *store.DisplayMessage {
+	return &store.DisplayMessage{
+		Id:            info.Id,
+		Priority:      string(info.Priority),
+		State:         (*string)(info.State),
+		StartDateTime: parseDisplayMessageTime(info.StartDateTime),
+		EndDateTime:   parseDisplayMessageTime(info.EndDateTime),
+		TransactionId: info.TransactionId,
+		Format:        string(info.Message.Format),
+		Language:      info.Message.Language,
+		Content:       info.Message.Content,
+		Status:        store.DisplayMessageStatusUnmanaged,
+	}
+}
+
+func parseDisplayMessageTime(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
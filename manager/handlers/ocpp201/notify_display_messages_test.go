@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestNotifyDisplayMessagesHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.NotifyDisplayMessagesHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+	setDisplayMessages(t, engine,
+		newDisplayMessage(1, store.DisplayMessageStatusAccepted),
+		newDisplayMessage(2, store.DisplayMessageStatusAccepted))
+	setDisplayMessagesReconcilePending(t, engine, 7)
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		got, err := handler.HandleCall(ctx, "cs001", &types.NotifyDisplayMessagesRequestJson{
+			RequestId:   7,
+			MessageInfo: []types.MessageInfoType{newSetDisplayMessageRequest(1).Message},
+			Tbc:         true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &types.NotifyDisplayMessagesResponseJson{}, got)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"notify_display_messages.request_id": 7,
+		"notify_display_messages.count":      1,
+		"notify_display_messages.tbc":        true,
+	})
+
+	unmanaged := newSetDisplayMessageRequest(3).Message
+	unmanaged.Message.Content = "Installed locally"
+	_, err := handler.HandleCall(ctx, "cs001", &types.NotifyDisplayMessagesRequestJson{
+		RequestId:   7,
+		MessageInfo: []types.MessageInfoType{unmanaged},
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageReconcileStatus(""), got.ReconcileStatus)
+	require.Len(t, got.Messages, 3)
+	assert.Equal(t, store.DisplayMessageStatusAccepted, got.Messages[1].Status)
+	// the charge station no longer holds message 2 so it is sent again
+	assert.Equal(t, store.DisplayMessageStatusPending, got.Messages[2].Status)
+	assert.Equal(t, &store.DisplayMessage{
+		Id:                3,
+		Priority:          "NormalCycle",
+		Format:            "UTF8",
+		Content:           "Installed locally",
+		Status:            store.DisplayMessageStatusUnmanaged,
+		ReportedRequestId: 7,
+	}, got.Messages[3])
+}
+
+func TestNotifyDisplayMessagesHandlerIgnoresUnrequestedReport(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.NotifyDisplayMessagesHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+	setDisplayMessages(t, engine, newDisplayMessage(1, store.DisplayMessageStatusAccepted))
+
+	_, err := handler.HandleCall(ctx, "cs001", &types.NotifyDisplayMessagesRequestJson{
+		RequestId: 7,
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageStatusAccepted, got.Messages[1].Status)
+}
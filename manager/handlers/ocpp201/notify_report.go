@@ -4,13 +4,20 @@ package ocpp201
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type NotifyReportHandler struct{}
+type NotifyReportHandler struct {
+	Clock            clock.PassiveClock
+	DeviceModelStore store.ChargeStationDeviceModelStore
+}
 
 func (h NotifyReportHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (response ocpp.Response, err error) {
 	req := request.(*ocpp201.NotifyReportRequestJson)
@@ -23,5 +30,111 @@ func (h NotifyReportHandler) HandleCall(ctx context.Context, chargeStationId str
 		attribute.Int("notify_report.seq_no", req.SeqNo),
 		attribute.Bool("notify_report.tbc", req.Tbc))
 
+	var variables []*store.DeviceModelVariable
+	for _, reportData := range req.ReportData {
+		variables = append(variables, newDeviceModelVariable(reportData))
+	}
+
+	// single part report: no need to assemble the parts
+	if req.SeqNo == 0 && !req.Tbc {
+		err = h.DeviceModelStore.UpdateChargeStationDeviceModel(ctx, chargeStationId, variables, h.Clock.Now())
+		if err != nil {
+			return nil, fmt.Errorf("updating device model: %w", err)
+		}
+		span.SetAttributes(attribute.Bool("notify_report.complete", true))
+		return &ocpp201.NotifyReportResponseJson{}, nil
+	}
+
+	err = h.DeviceModelStore.SetDeviceModelReportPart(ctx, chargeStationId, req.RequestId, &store.DeviceModelReportPart{
+		SeqNo:     req.SeqNo,
+		Tbc:       req.Tbc,
+		Variables: variables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("setting device model report part: %w", err)
+	}
+
+	// the parts may be received out of order (and by different manager instances), so the
+	// report is only complete once the final part and all preceding parts have been received
+	parts, err := h.DeviceModelStore.LookupDeviceModelReportParts(ctx, chargeStationId, req.RequestId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup device model report parts: %w", err)
+	}
+	if !isReportComplete(parts) {
+		span.SetAttributes(attribute.Bool("notify_report.complete", false))
+		return &ocpp201.NotifyReportResponseJson{}, nil
+	}
+
+	variables = nil
+	for _, part := range parts {
+		variables = append(variables, part.Variables...)
+	}
+	err = h.DeviceModelStore.UpdateChargeStationDeviceModel(ctx, chargeStationId, variables, h.Clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("updating device model: %w", err)
+	}
+	err = h.DeviceModelStore.DeleteDeviceModelReportParts(ctx, chargeStationId, req.RequestId)
+	if err != nil {
+		return nil, fmt.Errorf("deleting device model report parts: %w", err)
+	}
+	span.SetAttributes(attribute.Bool("notify_report.complete", true))
+
 	return &ocpp201.NotifyReportResponseJson{}, nil
 }
+
+// isReportComplete expects the parts to be ordered by seq no
+func isReportComplete(parts []*store.DeviceModelReportPart) bool {
+	for i, part := range parts {
+		if part.SeqNo != i {
+			return false
+		}
+		if !part.Tbc {
+			return true
+		}
+	}
+	return false
+}
+
+func newDeviceModelVariable(reportData ocpp201.ReportDataType) *store.DeviceModelVariable {
+	variable := &store.DeviceModelVariable{
+		ComponentName:     reportData.Component.Name,
+		ComponentInstance: reportData.Component.Instance,
+		VariableName:      reportData.Variable.Name,
+		VariableInstance:  reportData.Variable.Instance,
+	}
+	if reportData.Component.Evse != nil {
+		evseId := reportData.Component.Evse.Id
+		variable.EvseId = &evseId
+		variable.ConnectorId = reportData.Component.Evse.ConnectorId
+	}
+	for _, attr := range reportData.VariableAttribute {
+		// defaults as defined by the OCPP 2.0.1 specification
+		attrType := ocpp201.AttributeEnumTypeActual
+		if attr.Type != nil {
+			attrType = *attr.Type
+		}
+		mutability := ocpp201.MutabilityEnumTypeReadWrite
+		if attr.Mutability != nil {
+			mutability = *attr.Mutability
+		}
+		variable.Attributes = append(variable.Attributes, &store.DeviceModelVariableAttribute{
+			Type:       string(attrType),
+			Value:      attr.Value,
+			Mutability: string(mutability),
+			Persistent: attr.Persistent,
+			Constant:   attr.Constant,
+		})
+	}
+	if reportData.VariableCharacteristics != nil {
+		chars := reportData.VariableCharacteristics
+		variable.Characteristics = &store.DeviceModelVariableCharacteristics{
+			DataType:           string(chars.DataType),
+			Unit:               chars.Unit,
+			MinLimit:           chars.MinLimit,
+			MaxLimit:           chars.MaxLimit,
+			ValuesList:         chars.ValuesList,
+			SupportsMonitoring: chars.SupportsMonitoring,
+		}
+	}
+	return variable
+}
@@ -8,12 +8,21 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	clockTest "k8s.io/utils/clock/testing"
 	"testing"
+	"time"
 )
 
 func TestNotifyReport(t *testing.T) {
-	handler := ocpp201.NotifyReportHandler{}
+	now := time.Now()
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(now))
+	handler := ocpp201.NotifyReportHandler{
+		Clock:            clockTest.NewFakePassiveClock(now),
+		DeviceModelStore: engine,
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -29,6 +38,10 @@ func TestNotifyReport(t *testing.T) {
 				{
 					Component: types.ComponentType{
 						Name: "SomeCtrlr",
+						Evse: &types.EVSEType{
+							Id:          1,
+							ConnectorId: makePtr(2),
+						},
 					},
 					Variable: types.VariableType{
 						Name: "SomeVar",
@@ -42,11 +55,15 @@ func TestNotifyReport(t *testing.T) {
 							Value:      makePtr("19"),
 						},
 					},
-					VariableCharacteristics: nil,
+					VariableCharacteristics: &types.VariableCharacteristicsType{
+						DataType:           types.DataEnumTypeInteger,
+						MaxLimit:           makePtr(100.0),
+						SupportsMonitoring: true,
+					},
 				},
 			},
 			RequestId: 42,
-			SeqNo:     1,
+			SeqNo:     0,
 			Tbc:       false,
 		}
 
@@ -59,7 +76,100 @@ func TestNotifyReport(t *testing.T) {
 	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
 		"notify_report.generated_at": "2024-03-18T17:10:00.000Z",
 		"notify_report.request_id":   42,
-		"notify_report.seq_no":       1,
+		"notify_report.seq_no":       0,
 		"notify_report.tbc":          false,
+		"notify_report.complete":     true,
 	})
+
+	got, err := engine.LookupChargeStationDeviceModel(ctx, "cs001")
+	require.NoError(t, err)
+
+	want := &store.ChargeStationDeviceModel{
+		ChargeStationId: "cs001",
+		Variables: []*store.DeviceModelVariable{
+			{
+				ComponentName: "SomeCtrlr",
+				EvseId:        makePtr(1),
+				ConnectorId:   makePtr(2),
+				VariableName:  "SomeVar",
+				Attributes: []*store.DeviceModelVariableAttribute{
+					{
+						Type:       "Actual",
+						Value:      makePtr("19"),
+						Mutability: "ReadOnly",
+						Persistent: true,
+					},
+				},
+				Characteristics: &store.DeviceModelVariableCharacteristics{
+					DataType:           "integer",
+					MaxLimit:           makePtr(100.0),
+					SupportsMonitoring: true,
+				},
+			},
+		},
+		UpdatedAt: now,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestNotifyReportAssemblesMultiPartReport(t *testing.T) {
+	now := time.Now()
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(now))
+	handler := ocpp201.NotifyReportHandler{
+		Clock:            clockTest.NewFakePassiveClock(now),
+		DeviceModelStore: engine,
+	}
+
+	ctx := context.Background()
+
+	newReq := func(seqNo int, tbc bool, component string) *types.NotifyReportRequestJson {
+		return &types.NotifyReportRequestJson{
+			GeneratedAt: "2024-03-18T17:10:00.000Z",
+			ReportData: []types.ReportDataType{
+				{
+					Component: types.ComponentType{Name: component},
+					Variable:  types.VariableType{Name: "Enabled"},
+					VariableAttribute: []types.VariableAttributeType{
+						{Value: makePtr("true")},
+					},
+				},
+			},
+			RequestId: 42,
+			SeqNo:     seqNo,
+			Tbc:       tbc,
+		}
+	}
+
+	// final part received before the second part
+	for _, req := range []*types.NotifyReportRequestJson{
+		newReq(0, true, "ACtrlr"),
+		newReq(2, false, "CCtrlr"),
+	} {
+		_, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		got, err := engine.LookupChargeStationDeviceModel(ctx, "cs001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	}
+
+	_, err := handler.HandleCall(ctx, "cs001", newReq(1, true, "BCtrlr"))
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationDeviceModel(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+
+	var components []string
+	for _, v := range got.Variables {
+		components = append(components, v.ComponentName)
+		assert.Equal(t, []*store.DeviceModelVariableAttribute{
+			{Type: "Actual", Value: makePtr("true"), Mutability: "ReadWrite"},
+		}, v.Attributes)
+	}
+	assert.Equal(t, []string{"ACtrlr", "BCtrlr", "CCtrlr"}, components)
+
+	parts, err := engine.LookupDeviceModelReportParts(ctx, "cs001", 42)
+	require.NoError(t, err)
+	assert.Empty(t, parts)
 }
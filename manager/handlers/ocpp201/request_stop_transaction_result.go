@@ -4,13 +4,19 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type RequestStopTransactionResultHandler struct{}
+type RequestStopTransactionResultHandler struct {
+	Store store.ChargeStationOperationStore
+	Clock clock.PassiveClock
+}
 
 func (h RequestStopTransactionResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.RequestStopTransactionRequestJson)
@@ -22,5 +28,5 @@ func (h RequestStopTransactionResultHandler) HandleCallResult(ctx context.Contex
 		attribute.String("request_stop.transaction_id", req.TransactionId),
 		attribute.String("request_stop.status", string(resp.Status)))
 
-	return nil
+	return handlers.RecordOperationResult(ctx, h.Store, h.Clock, chargeStationId, "RequestStopTransaction", string(resp.Status), resp)
 }
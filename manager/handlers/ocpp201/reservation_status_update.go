@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type ReservationStatusUpdateHandler struct {
+	Store store.ReservationStore
+	Clock clock.PassiveClock
+}
+
+func (h ReservationStatusUpdateHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.ReservationStatusUpdateRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("reservation_status_update.reservation_id", req.ReservationId),
+		attribute.String("reservation_status_update.status", string(req.ReservationUpdateStatus)))
+
+	status := store.ReservationStatusExpired
+	if req.ReservationUpdateStatus == types.ReservationUpdateStatusEnumTypeRemoved {
+		status = store.ReservationStatusRemoved
+	}
+	err := handlers.EndReservation(ctx, h.Store, h.Clock, chargeStationId, req.ReservationId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ReservationStatusUpdateResponseJson{}, nil
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestReservationStatusUpdateHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp201.ReservationStatusUpdateHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+	want := setActiveReservation(t, engine, store.ReservationStatusAccepted, clk.Now().Add(-time.Minute))
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		resp, err := handler.HandleCall(ctx, "cs001", &types.ReservationStatusUpdateRequestJson{
+			ReservationId:           42,
+			ReservationUpdateStatus: types.ReservationUpdateStatusEnumTypeRemoved,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &types.ReservationStatusUpdateResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"reservation_status_update.reservation_id": 42,
+		"reservation_status_update.status":         "Removed",
+		"reservation.status":                       "Removed",
+	})
+
+	got, err := engine.LookupReservation(ctx, "cs001", 42)
+	require.NoError(t, err)
+	want.Status = store.ReservationStatusRemoved
+	want.UpdatedAt = clk.Now()
+	assert.Equal(t, want, got)
+}
+
+func TestReservationStatusUpdateHandlerWithUnknownReservation(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.ReservationStatusUpdateHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	resp, err := handler.HandleCall(context.Background(), "cs001", &types.ReservationStatusUpdateRequestJson{
+		ReservationId:           42,
+		ReservationUpdateStatus: types.ReservationUpdateStatusEnumTypeExpired,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &types.ReservationStatusUpdateResponseJson{}, resp)
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type ReserveNowResultHandler struct {
+	Store store.ReservationStore
+	Clock clock.PassiveClock
+}
+
+func (h ReserveNowResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.ReserveNowRequestJson)
+	resp := response.(*types.ReserveNowResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("reserve_now.reservation_id", req.Id),
+		attribute.String("reserve_now.status", string(resp.Status)))
+
+	return handlers.RecordReservationResult(ctx, h.Store, h.Clock, chargeStationId, req.Id, string(resp.Status))
+}
+
+type ReserveNowErrorHandler struct {
+	Store store.ReservationStore
+	Clock clock.PassiveClock
+}
+
+func (h ReserveNowErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, _ any) error {
+	req := request.(*types.ReserveNowRequestJson)
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("reserve_now.reservation_id", req.Id))
+
+	return handlers.RecordReservationError(ctx, h.Store, h.Clock, chargeStationId, req.Id, errorCode, errorDescription)
+}
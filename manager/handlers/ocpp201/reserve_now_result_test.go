@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func setActiveReservation(t *testing.T, engine store.ReservationStore, status store.ReservationStatus, createdAt time.Time) *store.Reservation {
+	evseId := 1
+	reservation := &store.Reservation{
+		ChargeStationId: "cs001",
+		ReservationId:   42,
+		EvseId:          &evseId,
+		IdToken:         "ABCD1234",
+		TokenType:       "ISO14443",
+		ExpiryDate:      createdAt.Add(time.Hour),
+		Status:          status,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+	}
+	err := engine.SetReservation(context.Background(), reservation)
+	require.NoError(t, err)
+	return reservation
+}
+
+func newReserveNowRequest(reservation *store.Reservation) *types.ReserveNowRequestJson {
+	return &types.ReserveNowRequestJson{
+		EvseId:         reservation.EvseId,
+		ExpiryDateTime: reservation.ExpiryDate.Format(time.RFC3339),
+		Id:             reservation.ReservationId,
+		IdToken: types.IdTokenType{
+			IdToken: reservation.IdToken,
+			Type:    types.IdTokenEnumType(reservation.TokenType),
+		},
+	}
+}
+
+func TestReserveNowResultHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp201.ReserveNowResultHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+	want := setActiveReservation(t, engine, store.ReservationStatusPending, clk.Now().Add(-time.Minute))
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		err := handler.HandleCallResult(ctx, "cs001", newReserveNowRequest(want),
+			&types.ReserveNowResponseJson{Status: types.ReserveNowStatusEnumTypeAccepted}, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"reserve_now.reservation_id": 42,
+		"reserve_now.status":         "Accepted",
+		"reservation.status":         "Accepted",
+	})
+
+	got, err := engine.LookupReservation(ctx, "cs001", 42)
+	require.NoError(t, err)
+	want.Status = store.ReservationStatusAccepted
+	want.UpdatedAt = clk.Now()
+	assert.Equal(t, want, got)
+}
+
+func TestReserveNowResultHandlerIgnoresReservationThatHasEnded(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp201.ReserveNowResultHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+	want := setActiveReservation(t, engine, store.ReservationStatusExpired, clk.Now().Add(-time.Minute))
+
+	err := handler.HandleCallResult(ctx, "cs001", newReserveNowRequest(want),
+		&types.ReserveNowResponseJson{Status: types.ReserveNowStatusEnumTypeAccepted}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupReservation(ctx, "cs001", 42)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestReserveNowErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp201.ReserveNowErrorHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+	want := setActiveReservation(t, engine, store.ReservationStatusPending, clk.Now().Add(-time.Minute))
+
+	err := handler.HandleCallError(ctx, "cs001", newReserveNowRequest(want), transport.ErrorInternalError, "failed", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupReservation(ctx, "cs001", 42)
+	require.NoError(t, err)
+	want.Status = store.ReservationStatusErrored
+	want.ErrorCode = "InternalError"
+	want.ErrorDescription = "failed"
+	want.UpdatedAt = clk.Now()
+	assert.Equal(t, want, got)
+}
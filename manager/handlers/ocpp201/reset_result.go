@@ -4,13 +4,19 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type ResetResultHandler struct{}
+type ResetResultHandler struct {
+	Store store.ChargeStationOperationStore
+	Clock clock.PassiveClock
+}
 
 func (h ResetResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.ResetRequestJson)
@@ -22,5 +28,5 @@ func (h ResetResultHandler) HandleCallResult(ctx context.Context, chargeStationI
 		attribute.String("reset.type", string(req.Type)),
 		attribute.String("reset.status", string(resp.Status)))
 
-	return nil
+	return handlers.RecordOperationResult(ctx, h.Store, h.Clock, chargeStationId, "Reset", string(resp.Status), resp)
 }
@@ -4,15 +4,24 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 	"testing"
+	"time"
 )
 
 func TestResetResultHandler(t *testing.T) {
-	handler := ocpp201.ResetResultHandler{}
+	handler := ocpp201.ResetResultHandler{
+		Store: inmemory.NewStore(clock.RealClock{}),
+		Clock: clock.RealClock{},
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -38,3 +47,59 @@ func TestResetResultHandler(t *testing.T) {
 		"reset.status": "Accepted",
 	})
 }
+
+func TestResetResultHandlerRecordsOperationResult(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp201.ResetResultHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+
+	requestedAt := clk.Now().Add(-time.Minute)
+	err := engine.SetChargeStationOperation(ctx, "cs001", &store.ChargeStationOperation{
+		Operation:   "Reset",
+		Request:     `{"type":"Immediate"}`,
+		Status:      store.ChargeStationOperationStatusPending,
+		RequestedAt: requestedAt,
+		UpdatedAt:   requestedAt,
+	})
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		req := &types.ResetRequestJson{
+			Type: types.ResetEnumTypeImmediate,
+		}
+		resp := &types.ResetResponseJson{
+			Status: types.ResetStatusEnumTypeScheduled,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"reset.type":       "Immediate",
+		"reset.status":     "Scheduled",
+		"operation.status": "Scheduled",
+	})
+
+	got, err := engine.LookupChargeStationOperation(ctx, "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationOperation{
+		ChargeStationId: "cs001",
+		Operation:       "Reset",
+		Request:         `{"type":"Immediate"}`,
+		Response:        `{"status":"Scheduled"}`,
+		Status:          "Scheduled",
+		RequestedAt:     requestedAt,
+		UpdatedAt:       clk.Now(),
+	}, got)
+}
@@ -23,6 +23,7 @@ func NewRouter(emitter transport.Emitter,
 	chargeStationCertProvider services.ChargeStationCertificateProvider,
 	contractCertProvider services.ContractCertificateProvider,
 	heartbeatInterval time.Duration,
+	costUpdateInterval time.Duration,
 	schemaFS fs.FS) transport.MessageHandler {
 
 	return &handlers.Router{
@@ -51,6 +52,11 @@ func NewRouter(emitter transport.Emitter,
 					Clock:               clk,
 					HeartbeatInterval:   int(heartbeatInterval.Seconds()),
 					RuntimeDetailsStore: engine,
+					SettingsStore:       engine,
+					ChargeStationStore:  engine,
+					TemplateStore:       engine,
+					LocalListStore:      engine,
+					DisplayMessageStore: engine,
 				},
 			},
 			"FirmwareStatusNotification": {
@@ -95,11 +101,32 @@ func NewRouter(emitter transport.Emitter,
 				ResponseSchema: "ocpp201/MeterValuesResponse.json",
 				Handler:        MeterValuesHandler{},
 			},
+			"NotifyDisplayMessages": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.NotifyDisplayMessagesRequestJson) },
+				RequestSchema:  "ocpp201/NotifyDisplayMessagesRequest.json",
+				ResponseSchema: "ocpp201/NotifyDisplayMessagesResponse.json",
+				Handler: NotifyDisplayMessagesHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
 			"NotifyReport": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.NotifyReportRequestJson) },
 				RequestSchema:  "ocpp201/NotifyReportRequest.json",
 				ResponseSchema: "ocpp201/NotifyReportResponse.json",
-				Handler:        NotifyReportHandler{},
+				Handler: NotifyReportHandler{
+					Clock:            clk,
+					DeviceModelStore: engine,
+				},
+			},
+			"ReservationStatusUpdate": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.ReservationStatusUpdateRequestJson) },
+				RequestSchema:  "ocpp201/ReservationStatusUpdateRequest.json",
+				ResponseSchema: "ocpp201/ReservationStatusUpdateResponse.json",
+				Handler: ReservationStatusUpdateHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"StatusNotification": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.StatusNotificationRequestJson) },
@@ -114,6 +141,7 @@ func NewRouter(emitter transport.Emitter,
 				Handler: SignCertificateHandler{
 					ChargeStationCertificateProvider: chargeStationCertProvider,
 					Store:                            engine,
+					Clock:                            clk,
 				},
 			},
 			"SecurityEventNotification": {
@@ -133,10 +161,22 @@ func NewRouter(emitter transport.Emitter,
 						TokenStore: engine,
 					},
 					TariffService: tariffService,
+					Clock:         clk,
+					CostUpdater:   NewCostUpdater(NewCallMaker(emitter), clk, costUpdateInterval),
 				},
 			},
 		},
 		CallResultRoutes: map[string]handlers.CallResultRoute{
+			"CancelReservation": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.CancelReservationRequestJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp201.CancelReservationResponseJson) },
+				RequestSchema:  "ocpp201/CancelReservationRequest.json",
+				ResponseSchema: "ocpp201/CancelReservationResponse.json",
+				Handler: CancelReservationResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
 			"CertificateSigned": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.CertificateSignedRequestJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp201.CertificateSignedResponseJson) },
@@ -151,21 +191,47 @@ func NewRouter(emitter transport.Emitter,
 				NewResponse:    func() ocpp.Response { return new(ocpp201.ChangeAvailabilityResponseJson) },
 				RequestSchema:  "ocpp201/ChangeAvailabilityRequest.json",
 				ResponseSchema: "ocpp201/ChangeAvailabilityResponse.json",
-				Handler:        ChangeAvailabilityResultHandler{},
+				Handler: ChangeAvailabilityResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"ClearCache": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.ClearCacheRequestJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp201.ClearCacheResponseJson) },
 				RequestSchema:  "ocpp201/ClearCacheRequest.json",
 				ResponseSchema: "ocpp201/ClearCacheResponse.json",
-				Handler:        ClearCacheResultHandler{},
+				Handler: ClearCacheResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"ClearDisplayMessage": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.ClearDisplayMessageRequestJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp201.ClearDisplayMessageResponseJson) },
+				RequestSchema:  "ocpp201/ClearDisplayMessageRequest.json",
+				ResponseSchema: "ocpp201/ClearDisplayMessageResponse.json",
+				Handler: ClearDisplayMessageResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"CostUpdated": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.CostUpdatedRequestJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp201.CostUpdatedResponseJson) },
+				RequestSchema:  "ocpp201/CostUpdatedRequest.json",
+				ResponseSchema: "ocpp201/CostUpdatedResponse.json",
+				Handler:        CostUpdatedResultHandler{},
 			},
 			"DeleteCertificate": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.DeleteCertificateRequestJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp201.DeleteCertificateResponseJson) },
 				RequestSchema:  "ocpp201/DeleteCertificateRequest.json",
 				ResponseSchema: "ocpp201/DeleteCertificateResponse.json",
-				Handler:        DeleteCertificateResultHandler{},
+				Handler: DeleteCertificateResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"GetBaseReport": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.GetBaseReportRequestJson) },
@@ -174,19 +240,35 @@ func NewRouter(emitter transport.Emitter,
 				ResponseSchema: "ocpp201/GetBaseReportResponse.json",
 				Handler:        GetBaseReportResultHandler{},
 			},
+			"GetDisplayMessages": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.GetDisplayMessagesRequestJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp201.GetDisplayMessagesResponseJson) },
+				RequestSchema:  "ocpp201/GetDisplayMessagesRequest.json",
+				ResponseSchema: "ocpp201/GetDisplayMessagesResponse.json",
+				Handler: GetDisplayMessagesResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
 			"GetInstalledCertificateIds": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.GetInstalledCertificateIdsRequestJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp201.GetInstalledCertificateIdsResponseJson) },
 				RequestSchema:  "ocpp201/GetInstalledCertificateIdsRequest.json",
 				ResponseSchema: "ocpp201/GetInstalledCertificateIdsResponse.json",
-				Handler:        GetInstalledCertificateIdsResultHandler{},
+				Handler: GetInstalledCertificateIdsResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"GetLocalListVersion": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.GetLocalListVersionRequestJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp201.GetLocalListVersionResponseJson) },
 				RequestSchema:  "ocpp201/GetLocalListVersionRequest.json",
 				ResponseSchema: "ocpp201/GetLocalListVersionResponse.json",
-				Handler:        GetLocalListVersionResultHandler{},
+				Handler: GetLocalListVersionResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"GetReport": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.GetReportRequestJson) },
@@ -200,14 +282,20 @@ func NewRouter(emitter transport.Emitter,
 				NewResponse:    func() ocpp.Response { return new(ocpp201.GetTransactionStatusResponseJson) },
 				RequestSchema:  "ocpp201/GetTransactionStatusRequest.json",
 				ResponseSchema: "ocpp201/GetTransactionStatusResponse.json",
-				Handler:        GetTransactionStatusResultHandler{},
+				Handler: GetTransactionStatusResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"GetVariables": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.GetVariablesRequestJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp201.GetVariablesResponseJson) },
 				RequestSchema:  "ocpp201/GetVariablesRequest.json",
 				ResponseSchema: "ocpp201/GetVariablesResponse.json",
-				Handler:        GetVariablesResultHandler{},
+				Handler: GetVariablesResultHandler{
+					VariablesStore: engine,
+					SettingsStore:  engine,
+				},
 			},
 			"InstallCertificate": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.InstallCertificateRequestJson) },
@@ -216,6 +304,7 @@ func NewRouter(emitter transport.Emitter,
 				ResponseSchema: "ocpp201/InstallCertificateResponse.json",
 				Handler: InstallCertificateResultHandler{
 					Store: engine,
+					Clock: clk,
 				},
 			},
 			"RequestStartTransaction": {
@@ -230,28 +319,60 @@ func NewRouter(emitter transport.Emitter,
 				NewResponse:    func() ocpp.Response { return new(ocpp201.RequestStopTransactionResponseJson) },
 				RequestSchema:  "ocpp201/RequestStopTransactionRequest.json",
 				ResponseSchema: "ocpp201/RequestStopTransactionResponse.json",
-				Handler:        RequestStopTransactionResultHandler{},
+				Handler: RequestStopTransactionResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"ReserveNow": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.ReserveNowRequestJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp201.ReserveNowResponseJson) },
+				RequestSchema:  "ocpp201/ReserveNowRequest.json",
+				ResponseSchema: "ocpp201/ReserveNowResponse.json",
+				Handler: ReserveNowResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"Reset": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.ResetRequestJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp201.ResetResponseJson) },
 				RequestSchema:  "ocpp201/ResetRequest.json",
 				ResponseSchema: "ocpp201/ResetResponse.json",
-				Handler:        ResetResultHandler{},
+				Handler: ResetResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"SendLocalList": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.SendLocalListRequestJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp201.SendLocalListResponseJson) },
 				RequestSchema:  "ocpp201/SendLocalListRequest.json",
 				ResponseSchema: "ocpp201/SendLocalListResponse.json",
-				Handler:        SendLocalListResultHandler{},
+				Handler: SendLocalListResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"SetDisplayMessage": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.SetDisplayMessageRequestJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp201.SetDisplayMessageResponseJson) },
+				RequestSchema:  "ocpp201/SetDisplayMessageRequest.json",
+				ResponseSchema: "ocpp201/SetDisplayMessageResponse.json",
+				Handler: SetDisplayMessageResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"SetNetworkProfile": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.SetNetworkProfileRequestJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp201.SetNetworkProfileResponseJson) },
 				RequestSchema:  "ocpp201/SetNetworkProfileRequest.json",
 				ResponseSchema: "ocpp201/SetNetworkProfileResponse.json",
-				Handler:        SetNetworkProfileResultHandler{},
+				Handler: SetNetworkProfileResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
 			},
 			"SetVariables": {
 				NewRequest:     func() ocpp.Request { return new(ocpp201.SetVariablesRequestJson) },
@@ -276,7 +397,135 @@ func NewRouter(emitter transport.Emitter,
 				NewResponse:    func() ocpp.Response { return new(ocpp201.UnlockConnectorResponseJson) },
 				RequestSchema:  "ocpp201/UnlockConnectorRequest.json",
 				ResponseSchema: "ocpp201/UnlockConnectorResponse.json",
-				Handler:        UnlockConnectorResultHandler{},
+				Handler: UnlockConnectorResultHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+		},
+		CallErrorRoutes: map[string]handlers.CallErrorRoute{
+			"ChangeAvailability": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.ChangeAvailabilityRequestJson) },
+				RequestSchema: "ocpp201/ChangeAvailabilityRequest.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "ChangeAvailability",
+				},
+			},
+			"ClearCache": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.ClearCacheRequestJson) },
+				RequestSchema: "ocpp201/ClearCacheRequest.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "ClearCache",
+				},
+			},
+			"GetLocalListVersion": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.GetLocalListVersionRequestJson) },
+				RequestSchema: "ocpp201/GetLocalListVersionRequest.json",
+				Handler: handlers.LocalListErrorHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"GetTransactionStatus": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.GetTransactionStatusRequestJson) },
+				RequestSchema: "ocpp201/GetTransactionStatusRequest.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "GetTransactionStatus",
+				},
+			},
+			"GetVariables": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.GetVariablesRequestJson) },
+				RequestSchema: "ocpp201/GetVariablesRequest.json",
+				Handler: GetVariablesErrorHandler{
+					VariablesStore: engine,
+				},
+			},
+			"InstallCertificate": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.InstallCertificateRequestJson) },
+				RequestSchema: "ocpp201/InstallCertificateRequest.json",
+				Handler: InstallCertificateErrorHandler{
+					Store: engine,
+				},
+			},
+			"RequestStopTransaction": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.RequestStopTransactionRequestJson) },
+				RequestSchema: "ocpp201/RequestStopTransactionRequest.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "RequestStopTransaction",
+				},
+			},
+			"ReserveNow": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.ReserveNowRequestJson) },
+				RequestSchema: "ocpp201/ReserveNowRequest.json",
+				Handler: ReserveNowErrorHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"Reset": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.ResetRequestJson) },
+				RequestSchema: "ocpp201/ResetRequest.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "Reset",
+				},
+			},
+			"SendLocalList": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.SendLocalListRequestJson) },
+				RequestSchema: "ocpp201/SendLocalListRequest.json",
+				Handler: handlers.LocalListErrorHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"SetDisplayMessage": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.SetDisplayMessageRequestJson) },
+				RequestSchema: "ocpp201/SetDisplayMessageRequest.json",
+				Handler: SetDisplayMessageErrorHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"SetNetworkProfile": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.SetNetworkProfileRequestJson) },
+				RequestSchema: "ocpp201/SetNetworkProfileRequest.json",
+				Handler: SetNetworkProfileErrorHandler{
+					Store: engine,
+					Clock: clk,
+				},
+			},
+			"SetVariables": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.SetVariablesRequestJson) },
+				RequestSchema: "ocpp201/SetVariablesRequest.json",
+				Handler: SetVariablesErrorHandler{
+					Store:              engine,
+					ChargeStationStore: engine,
+				},
+			},
+			"TriggerMessage": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.TriggerMessageRequestJson) },
+				RequestSchema: "ocpp201/TriggerMessageRequest.json",
+				Handler: TriggerMessageErrorHandler{
+					Store: engine,
+				},
+			},
+			"UnlockConnector": {
+				NewRequest:    func() ocpp.Request { return new(ocpp201.UnlockConnectorRequestJson) },
+				RequestSchema: "ocpp201/UnlockConnectorRequest.json",
+				Handler: handlers.OperationErrorHandler{
+					Store:     engine,
+					Clock:     clk,
+					Operation: "UnlockConnector",
+				},
 			},
 		},
 	}
@@ -287,11 +536,15 @@ func NewCallMaker(e transport.Emitter) *handlers.OcppCallMaker {
 		Emitter:     e,
 		OcppVersion: transport.OcppVersion201,
 		Actions: map[reflect.Type]string{
+			reflect.TypeOf(&ocpp201.CancelReservationRequestJson{}):          "CancelReservation",
 			reflect.TypeOf(&ocpp201.CertificateSignedRequestJson{}):          "CertificateSigned",
 			reflect.TypeOf(&ocpp201.ChangeAvailabilityRequestJson{}):         "ChangeAvailability",
 			reflect.TypeOf(&ocpp201.ClearCacheRequestJson{}):                 "ClearCache",
+			reflect.TypeOf(&ocpp201.ClearDisplayMessageRequestJson{}):        "ClearDisplayMessage",
+			reflect.TypeOf(&ocpp201.CostUpdatedRequestJson{}):                "CostUpdated",
 			reflect.TypeOf(&ocpp201.DeleteCertificateRequestJson{}):          "DeleteCertificate",
 			reflect.TypeOf(&ocpp201.GetBaseReportRequestJson{}):              "GetBaseReport",
+			reflect.TypeOf(&ocpp201.GetDisplayMessagesRequestJson{}):         "GetDisplayMessages",
 			reflect.TypeOf(&ocpp201.GetInstalledCertificateIdsRequestJson{}): "GetInstalledCertificateIds",
 			reflect.TypeOf(&ocpp201.GetLocalListVersionRequestJson{}):        "GetLocalListVersion",
 			reflect.TypeOf(&ocpp201.GetReportRequestJson{}):                  "GetReport",
@@ -300,8 +553,10 @@ func NewCallMaker(e transport.Emitter) *handlers.OcppCallMaker {
 			reflect.TypeOf(&ocpp201.InstallCertificateRequestJson{}):         "InstallCertificate",
 			reflect.TypeOf(&ocpp201.RequestStartTransactionRequestJson{}):    "RequestStartTransaction",
 			reflect.TypeOf(&ocpp201.RequestStopTransactionRequestJson{}):     "RequestStopTransaction",
+			reflect.TypeOf(&ocpp201.ReserveNowRequestJson{}):                 "ReserveNow",
 			reflect.TypeOf(&ocpp201.ResetRequestJson{}):                      "Reset",
 			reflect.TypeOf(&ocpp201.SendLocalListRequestJson{}):              "SendLocalList",
+			reflect.TypeOf(&ocpp201.SetDisplayMessageRequestJson{}):          "SetDisplayMessage",
 			reflect.TypeOf(&ocpp201.SetNetworkProfileRequestJson{}):          "SetNetworkProfile",
 			reflect.TypeOf(&ocpp201.SetVariablesRequestJson{}):               "SetVariables",
 			reflect.TypeOf(&ocpp201.TriggerMessageRequestJson{}):             "TriggerMessage",
@@ -45,6 +45,14 @@ func (f fakeTariffService) CalculateCost(transaction *store.Transaction) (float6
 	return 42.0, nil
 }
 
+func (f fakeTariffService) CalculateRunningCost(transaction *store.Transaction) (float64, error) {
+	return 21.0, nil
+}
+
+func (f fakeTariffService) TariffText(chargeStationId, idToken string) string {
+	return ""
+}
+
 type fakeCertValidationService struct{}
 
 func (f fakeCertValidationService) ValidatePEMCertificateChain(ctx context.Context, pemChain []byte, eMAID string) (*string, error) {
@@ -85,6 +93,7 @@ func TestRoutingCalls(t *testing.T) {
 		&fakeChargeStationCertProvider{},
 		&fakeContractCertProvider{},
 		5*time.Minute,
+		30*time.Second,
 		schemas.OcppSchemas,
 	)
 
@@ -234,6 +243,7 @@ func TestRoutingCallResults(t *testing.T) {
 		&fakeChargeStationCertProvider{},
 		&fakeContractCertProvider{},
 		5*time.Minute,
+		30*time.Second,
 		schemas.OcppSchemas,
 	)
 
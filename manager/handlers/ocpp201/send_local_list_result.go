@@ -4,13 +4,19 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type SendLocalListResultHandler struct{}
+type SendLocalListResultHandler struct {
+	Store store.LocalListStore
+	Clock clock.PassiveClock
+}
 
 func (h SendLocalListResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.SendLocalListRequestJson)
@@ -23,5 +29,23 @@ func (h SendLocalListResultHandler) HandleCallResult(ctx context.Context, charge
 		attribute.Int("send_local_list.version_number", req.VersionNumber),
 		attribute.String("send_local_list.status", string(resp.Status)))
 
-	return nil
+	update := handlers.LocalListUpdate{
+		Full:    req.UpdateType == types.UpdateEnumTypeFull,
+		Version: req.VersionNumber,
+	}
+	for _, data := range req.LocalAuthorizationList {
+		entry := &store.LocalListEntry{
+			IdToken:     data.IdToken.IdToken,
+			IdTokenType: string(data.IdToken.Type),
+		}
+		if data.IdTokenInfo != nil {
+			entry.Status = string(data.IdTokenInfo.Status)
+			if data.IdTokenInfo.GroupIdToken != nil {
+				entry.GroupId = &data.IdTokenInfo.GroupIdToken.IdToken
+			}
+		}
+		update.Entries = append(update.Entries, entry)
+	}
+
+	return handlers.RecordLocalListUpdateResult(ctx, h.Store, h.Clock, chargeStationId, update, store.LocalListStatus(resp.Status))
 }
@@ -4,15 +4,24 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 	"testing"
+	"time"
 )
 
 func TestSendLocalListResultHandler(t *testing.T) {
-	handler := ocpp201.SendLocalListResultHandler{}
+	handler := ocpp201.SendLocalListResultHandler{
+		Store: inmemory.NewStore(clock.RealClock{}),
+		Clock: clock.RealClock{},
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -48,3 +57,113 @@ func TestSendLocalListResultHandler(t *testing.T) {
 		"send_local_list.status":         "Accepted",
 	})
 }
+
+func TestSendLocalListResultHandlerAppliesAcceptedDifferentialUpdate(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp201.SendLocalListResultHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+
+	err := engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Version: 1,
+		Entries: map[string]*store.LocalListEntry{
+			"ABCD1234": {IdToken: "ABCD1234", Status: "Accepted"},
+			"EFGH5678": {IdToken: "EFGH5678", Status: "Accepted"},
+		},
+		Status: store.LocalListStatusPending,
+	})
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		req := &types.SendLocalListRequestJson{
+			LocalAuthorizationList: []types.AuthorizationData{
+				{
+					IdToken: types.IdTokenType{
+						Type:    types.IdTokenEnumTypeISO14443,
+						IdToken: "ABCD1234",
+					},
+					IdTokenInfo: &types.IdTokenInfoType{
+						Status: types.AuthorizationStatusEnumTypeInvalid,
+						GroupIdToken: &types.IdTokenType{
+							Type:    types.IdTokenEnumTypeCentral,
+							IdToken: "group001",
+						},
+					},
+				},
+				{
+					IdToken: types.IdTokenType{
+						Type:    types.IdTokenEnumTypeISO14443,
+						IdToken: "EFGH5678",
+					},
+				},
+			},
+			UpdateType:    types.UpdateEnumTypeDifferential,
+			VersionNumber: 2,
+		}
+		resp := &types.SendLocalListResponseJson{
+			Status: types.SendLocalListStatusEnumTypeAccepted,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"send_local_list.update_type":    "Differential",
+		"send_local_list.version_number": 2,
+		"send_local_list.status":         "Accepted",
+		"local_list.status":              "Accepted",
+	})
+
+	groupId := "group001"
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationLocalList{
+		ChargeStationId: "cs001",
+		Version:         2,
+		Entries: map[string]*store.LocalListEntry{
+			"ABCD1234": {IdToken: "ABCD1234", IdTokenType: "ISO14443", Status: "Invalid", GroupId: &groupId},
+		},
+		Status:    store.LocalListStatusAccepted,
+		UpdatedAt: clk.Now(),
+	}, got)
+}
+
+func TestSendLocalListResultHandlerRecordsVersionMismatch(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.SendLocalListResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+
+	err := engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Version: 1,
+		Entries: map[string]*store.LocalListEntry{},
+		Status:  store.LocalListStatusPending,
+	})
+	require.NoError(t, err)
+
+	err = handler.HandleCallResult(ctx, "cs001", &types.SendLocalListRequestJson{
+		UpdateType:    types.UpdateEnumTypeDifferential,
+		VersionNumber: 2,
+	}, &types.SendLocalListResponseJson{
+		Status: types.SendLocalListStatusEnumTypeVersionMismatch,
+	}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.LocalListStatusVersionMismatch, got.Status)
+	assert.Equal(t, 1, got.Version)
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type SetDisplayMessageResultHandler struct {
+	Store store.DisplayMessageStore
+	Clock clock.PassiveClock
+}
+
+func (h SetDisplayMessageResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.SetDisplayMessageRequestJson)
+	resp := response.(*types.SetDisplayMessageResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("set_display_message.id", req.Message.Id),
+		attribute.String("set_display_message.status", string(resp.Status)))
+
+	return updateDisplayMessages(ctx, h.Store, h.Clock, chargeStationId, func(messages *store.ChargeStationDisplayMessages) bool {
+		message, ok := messages.Messages[req.Message.Id]
+		if !ok || message.Status != store.DisplayMessageStatusPending {
+			// the message has been cleared or updated since the request was sent
+			return false
+		}
+		message.Status = store.DisplayMessageStatus(resp.Status)
+		message.ErrorCode = ""
+		message.ErrorDescription = ""
+		return true
+	})
+}
+
+type SetDisplayMessageErrorHandler struct {
+	Store store.DisplayMessageStore
+	Clock clock.PassiveClock
+}
+
+func (h SetDisplayMessageErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, _ any) error {
+	req := request.(*types.SetDisplayMessageRequestJson)
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("set_display_message.id", req.Message.Id))
+
+	return updateDisplayMessages(ctx, h.Store, h.Clock, chargeStationId, func(messages *store.ChargeStationDisplayMessages) bool {
+		message, ok := messages.Messages[req.Message.Id]
+		if !ok || message.Status != store.DisplayMessageStatusPending {
+			return false
+		}
+		message.Status = store.DisplayMessageStatusErrored
+		message.ErrorCode = string(errorCode)
+		message.ErrorDescription = errorDescription
+		return true
+	})
+}
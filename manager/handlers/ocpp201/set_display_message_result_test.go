@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func setDisplayMessages(t *testing.T, engine store.DisplayMessageStore, messages ...*store.DisplayMessage) {
+	displayMessages := &store.ChargeStationDisplayMessages{
+		Messages: make(map[int]*store.DisplayMessage),
+	}
+	for _, message := range messages {
+		displayMessages.Messages[message.Id] = message
+	}
+	err := engine.SetChargeStationDisplayMessages(context.Background(), "cs001", displayMessages)
+	require.NoError(t, err)
+}
+
+func newDisplayMessage(id int, status store.DisplayMessageStatus) *store.DisplayMessage {
+	return &store.DisplayMessage{
+		Id:       id,
+		Priority: "NormalCycle",
+		Format:   "UTF8",
+		Content:  "Welcome",
+		Status:   status,
+	}
+}
+
+func newSetDisplayMessageRequest(id int) *types.SetDisplayMessageRequestJson {
+	return &types.SetDisplayMessageRequestJson{
+		Message: types.MessageInfoType{
+			Id:       id,
+			Priority: types.MessagePriorityEnumTypeNormalCycle,
+			Message: types.MessageContentType{
+				Format:  types.MessageFormatEnumTypeUTF8,
+				Content: "Welcome",
+			},
+		},
+	}
+}
+
+func TestSetDisplayMessageResultHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	handler := ocpp201.SetDisplayMessageResultHandler{
+		Store: engine,
+		Clock: clk,
+	}
+
+	ctx := context.Background()
+	setDisplayMessages(t, engine,
+		newDisplayMessage(1, store.DisplayMessageStatusPending),
+		newDisplayMessage(2, store.DisplayMessageStatusPending))
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		err := handler.HandleCallResult(ctx, "cs001", newSetDisplayMessageRequest(1),
+			&types.SetDisplayMessageResponseJson{Status: types.DisplayMessageStatusEnumTypeAccepted}, nil)
+		require.NoError(t, err)
+		err = handler.HandleCallResult(ctx, "cs001", newSetDisplayMessageRequest(2),
+			&types.SetDisplayMessageResponseJson{Status: types.DisplayMessageStatusEnumTypeNotSupportedPriority}, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"set_display_message.id":     2,
+		"set_display_message.status": "NotSupportedPriority",
+	})
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageStatusAccepted, got.Messages[1].Status)
+	assert.Equal(t, store.DisplayMessageStatusNotSupportedPriority, got.Messages[2].Status)
+	assert.Equal(t, clk.Now(), got.UpdatedAt)
+}
+
+func TestSetDisplayMessageResultHandlerIgnoresClearedMessage(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.SetDisplayMessageResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+	setDisplayMessages(t, engine, newDisplayMessage(1, store.DisplayMessageStatusClearPending))
+
+	err := handler.HandleCallResult(ctx, "cs001", newSetDisplayMessageRequest(1),
+		&types.SetDisplayMessageResponseJson{Status: types.DisplayMessageStatusEnumTypeAccepted}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageStatusClearPending, got.Messages[1].Status)
+}
+
+func TestSetDisplayMessageErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.SetDisplayMessageErrorHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+	setDisplayMessages(t, engine, newDisplayMessage(1, store.DisplayMessageStatusPending))
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		err := handler.HandleCallError(ctx, "cs001", newSetDisplayMessageRequest(1), transport.ErrorNotImplemented, "not implemented", nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"set_display_message.id": 1,
+	})
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageStatusErrored, got.Messages[1].Status)
+	assert.Equal(t, string(transport.ErrorNotImplemented), got.Messages[1].ErrorCode)
+	assert.Equal(t, "not implemented", got.Messages[1].ErrorDescription)
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type SetNetworkProfileErrorHandler struct {
+	Store store.ChargeStationSecurityProfileUpgradeStore
+	Clock clock.PassiveClock
+}
+
+func (h SetNetworkProfileErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*types.SetNetworkProfileRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("set_network_profile.config_slot", req.ConfigurationSlot))
+
+	return updateSecurityProfileUpgradeNetworkProfile(ctx, h.Store, h.Clock, chargeStationId, req,
+		fmt.Sprintf("charge station responded to SetNetworkProfile with error %s: %s", errorCode, errorDescription))
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+func TestSetNetworkProfileErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Now().UTC()
+	handler := ocpp201.SetNetworkProfileErrorHandler{
+		Store: engine,
+		Clock: clockTest.NewFakePassiveClock(now),
+	}
+
+	ctx := context.Background()
+	err := engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", &store.ChargeStationSecurityProfileUpgrade{
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepSetNetworkProfile,
+		ConfigurationSlot:   1,
+	})
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		err := handler.HandleCallError(ctx, "cs001", &types.SetNetworkProfileRequestJson{
+			ConfigurationSlot: 1,
+		}, transport.ErrorNotImplemented, "not implemented", nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"set_network_profile.config_slot": 1,
+		"security_profile_upgrade.step":   "Failed",
+	})
+
+	got, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepFailed, got.Step)
+	assert.Equal(t, "charge station responded to SetNetworkProfile with error NotImplemented: not implemented", got.ErrorDescription)
+}
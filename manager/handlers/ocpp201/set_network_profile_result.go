@@ -4,13 +4,20 @@ package ocpp201
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type SetNetworkProfileResultHandler struct{}
+type SetNetworkProfileResultHandler struct {
+	Store store.ChargeStationSecurityProfileUpgradeStore
+	Clock clock.PassiveClock
+}
 
 func (h SetNetworkProfileResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.SetNetworkProfileRequestJson)
@@ -22,5 +29,37 @@ func (h SetNetworkProfileResultHandler) HandleCallResult(ctx context.Context, ch
 		attribute.Int("set_network_profile.config_slot", req.ConfigurationSlot),
 		attribute.String("set_network_profile.status", string(resp.Status)))
 
-	return nil
+	var errorDescription string
+	if resp.Status != types.SetNetworkProfileStatusEnumTypeAccepted {
+		errorDescription = fmt.Sprintf("charge station responded to SetNetworkProfile with %s", resp.Status)
+	}
+	return updateSecurityProfileUpgradeNetworkProfile(ctx, h.Store, h.Clock, chargeStationId, req, errorDescription)
+}
+
+// updateSecurityProfileUpgradeNetworkProfile moves a security profile upgrade that is waiting for
+// the charge station to respond to the SetNetworkProfile request on to its next step: the upgrade
+// has failed if there is an error description
+func updateSecurityProfileUpgradeNetworkProfile(ctx context.Context, upgradeStore store.ChargeStationSecurityProfileUpgradeStore, clock clock.PassiveClock,
+	chargeStationId string, req *types.SetNetworkProfileRequestJson, errorDescription string) error {
+	upgrade, err := upgradeStore.LookupChargeStationSecurityProfileUpgrade(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	if upgrade == nil ||
+		upgrade.Step != store.SecurityProfileUpgradeStepSetNetworkProfile ||
+		upgrade.ConfigurationSlot != req.ConfigurationSlot {
+		return nil
+	}
+
+	if errorDescription == "" {
+		upgrade.Step = store.SecurityProfileUpgradeStepSetNetworkConfigurationPriority
+	} else {
+		upgrade.Step = store.SecurityProfileUpgradeStepFailed
+		upgrade.ErrorDescription = errorDescription
+	}
+	upgrade.SendAfter = clock.Now()
+	upgrade.UpdatedAt = clock.Now()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("security_profile_upgrade.step", string(upgrade.Step)))
+
+	return upgradeStore.SetChargeStationSecurityProfileUpgrade(ctx, chargeStationId, upgrade)
 }
@@ -4,15 +4,26 @@ package ocpp201_test
 
 import (
 	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
-	"testing"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 )
 
 func TestSetNetworkProfileResultHandler(t *testing.T) {
-	handler := ocpp201.SetNetworkProfileResultHandler{}
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.SetNetworkProfileResultHandler{
+		Store: engine,
+		Clock: clockTest.NewFakePassiveClock(time.Now()),
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -46,3 +57,100 @@ func TestSetNetworkProfileResultHandler(t *testing.T) {
 		"set_network_profile.status":      "Accepted",
 	})
 }
+
+func TestSetNetworkProfileResultHandlerUpdatesSecurityProfileUpgrade(t *testing.T) {
+	now := time.Now().UTC()
+	clk := clockTest.NewFakePassiveClock(now)
+
+	req := &types.SetNetworkProfileRequestJson{
+		ConfigurationSlot: 2,
+		ConnectionData: types.NetworkConnectionProfileType{
+			MessageTimeout:  30,
+			OcppCsmsUrl:     "wss://cs.example.com/ws",
+			OcppInterface:   types.OCPPInterfaceEnumTypeWired0,
+			OcppTransport:   types.OCPPTransportEnumTypeJSON,
+			OcppVersion:     types.OCPPVersionEnumTypeOCPP20,
+			SecurityProfile: 2,
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		status           types.SetNetworkProfileStatusEnumType
+		step             store.SecurityProfileUpgradeStep
+		errorDescription string
+	}{
+		{
+			name:   "accepted",
+			status: types.SetNetworkProfileStatusEnumTypeAccepted,
+			step:   store.SecurityProfileUpgradeStepSetNetworkConfigurationPriority,
+		},
+		{
+			name:             "rejected",
+			status:           types.SetNetworkProfileStatusEnumTypeRejected,
+			step:             store.SecurityProfileUpgradeStepFailed,
+			errorDescription: "charge station responded to SetNetworkProfile with Rejected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine := inmemory.NewStore(clock.RealClock{})
+			handler := ocpp201.SetNetworkProfileResultHandler{
+				Store: engine,
+				Clock: clk,
+			}
+
+			ctx := context.Background()
+			err := engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", &store.ChargeStationSecurityProfileUpgrade{
+				FromSecurityProfile: store.TLSWithBasicAuth,
+				ToSecurityProfile:   store.TLSWithClientSideCertificates,
+				Step:                store.SecurityProfileUpgradeStepSetNetworkProfile,
+				OcppCsmsUrl:         "wss://cs.example.com/ws",
+				ConfigurationSlot:   2,
+				SendAfter:           now.Add(time.Minute),
+			})
+			require.NoError(t, err)
+
+			err = handler.HandleCallResult(ctx, "cs001", req, &types.SetNetworkProfileResponseJson{Status: tc.status}, nil)
+			require.NoError(t, err)
+
+			got, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+			require.NoError(t, err)
+			assert.Equal(t, tc.step, got.Step)
+			assert.Equal(t, tc.errorDescription, got.ErrorDescription)
+			assert.Equal(t, now, got.SendAfter)
+			assert.Equal(t, now, got.UpdatedAt)
+		})
+	}
+}
+
+func TestSetNetworkProfileResultHandlerIgnoresOtherConfigurationSlot(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.SetNetworkProfileResultHandler{
+		Store: engine,
+		Clock: clock.RealClock{},
+	}
+
+	ctx := context.Background()
+	upgrade := &store.ChargeStationSecurityProfileUpgrade{
+		ChargeStationId:     "cs001",
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepSetNetworkProfile,
+		ConfigurationSlot:   2,
+	}
+	err := engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", upgrade)
+	require.NoError(t, err)
+
+	err = handler.HandleCallResult(ctx, "cs001", &types.SetNetworkProfileRequestJson{
+		ConfigurationSlot: 1,
+	}, &types.SetNetworkProfileResponseJson{
+		Status: types.SetNetworkProfileStatusEnumTypeRejected,
+	}, nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, upgrade, got)
+}
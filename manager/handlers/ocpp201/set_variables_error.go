@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type SetVariablesErrorHandler struct {
+	Store              store.ChargeStationSettingsStore
+	ChargeStationStore store.ChargeStationStore
+}
+
+func (i SetVariablesErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*ocpp201.SetVariablesRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("set_variables.count", len(req.SetVariableData)))
+
+	settings := make(map[string]*store.ChargeStationSetting)
+	passwordRefused := false
+	for _, data := range req.SetVariableData {
+		name := getVariableName(data.Component, data.Variable, data.AttributeType)
+		if name == store.Ocpp201BasicAuthPasswordKey {
+			passwordRefused = true
+		}
+		settings[name] = &store.ChargeStationSetting{
+			Value:            store.RetainedSettingValue(name, data.AttributeValue),
+			Status:           store.ChargeStationSettingStatusRejected,
+			ErrorCode:        string(errorCode),
+			ErrorDescription: errorDescription,
+		}
+	}
+
+	err := i.Store.UpdateChargeStationSettings(ctx, chargeStationId, &store.ChargeStationSettings{
+		ChargeStationId: chargeStationId,
+		Settings:        settings,
+	})
+	if err != nil {
+		return fmt.Errorf("update charge station settings: %w", err)
+	}
+
+	if passwordRefused {
+		return handlers.ClearPendingPassword(ctx, i.ChargeStationStore, chargeStationId)
+	}
+
+	return nil
+}
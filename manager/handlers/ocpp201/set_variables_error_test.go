@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+func TestSetVariablesErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	handler := handlers201.SetVariablesErrorHandler{Store: engine}
+
+	tracer, exporter := testutil.GetTracer()
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		err := handler.HandleCallError(ctx, "cs001", &ocpp201.SetVariablesRequestJson{
+			SetVariableData: []ocpp201.SetVariableDataType{
+				{
+					Component:      ocpp201.ComponentType{Name: "OCPPCommCtrlr"},
+					Variable:       ocpp201.VariableType{Name: "HeartbeatInterval"},
+					AttributeValue: "60",
+				},
+			},
+		}, transport.ErrorFormatViolation, "bad request", nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"set_variables.count": 1,
+	})
+
+	got, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	setting := got.Settings["OCPPCommCtrlr/HeartbeatInterval"]
+	require.NotNil(t, setting)
+	assert.Equal(t, "60", setting.Value)
+	assert.Equal(t, store.ChargeStationSettingStatusRejected, setting.Status)
+	assert.Equal(t, "FormatViolation", setting.ErrorCode)
+	assert.Equal(t, "bad request", setting.ErrorDescription)
+}
@@ -5,12 +5,12 @@ package ocpp201
 import (
 	"context"
 	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
-	"golang.org/x/exp/slog"
 )
 
 type SetVariablesResultHandler struct {
@@ -20,20 +20,69 @@ type SetVariablesResultHandler struct {
 func (i SetVariablesResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	span := trace.SpanFromContext(ctx)
 	if response != nil {
+		req := request.(*ocpp201.SetVariablesRequestJson)
 		resp := response.(*ocpp201.SetVariablesResponseJson)
 
+		requested := make([]requestedVariable, len(req.SetVariableData))
+		for i, data := range req.SetVariableData {
+			requested[i] = requestedVariable{component: data.Component, variable: data.Variable, attributeType: data.AttributeType}
+		}
+
+		settings := make(map[string]*store.ChargeStationSetting)
+		passwordRefused := false
 		for _, variable := range resp.SetVariableResult {
 			span.SetAttributes(
 				attribute.String(fmt.Sprintf("set_variables.%s_%s.result", variable.Component.Name, variable.Variable.Name),
 					string(variable.AttributeStatus)))
+
+			// record the status against the setting that was requested so that the
+			// accepted value can later be compared with the value read back
+			idx := findRequestedVariable(requested, variable.Component, variable.Variable, variable.AttributeType)
+			if idx < 0 {
+				continue
+			}
+			name := getVariableName(requested[idx].component, requested[idx].variable, requested[idx].attributeType)
+			settings[name] = &store.ChargeStationSetting{
+				Value:  store.RetainedSettingValue(name, req.SetVariableData[idx].AttributeValue),
+				Status: getSettingStatus(variable.AttributeStatus),
+			}
+			if name == store.Ocpp201BasicAuthPasswordKey &&
+				variable.AttributeStatus != ocpp201.SetVariableStatusEnumTypeAccepted &&
+				variable.AttributeStatus != ocpp201.SetVariableStatusEnumTypeRebootRequired {
+				passwordRefused = true
+			}
+		}
+
+		if len(settings) > 0 {
+			err := i.Store.UpdateChargeStationSettings(ctx, chargeStationId, &store.ChargeStationSettings{
+				ChargeStationId: chargeStationId,
+				Settings:        settings,
+			})
+			if err != nil {
+				return fmt.Errorf("update charge station settings: %w", err)
+			}
 		}
 
-		err := i.Store.DeleteChargeStationSettings(ctx, chargeStationId)
-		if err != nil {
-			slog.Error("failed to delete charge station settings", "err", err)
-			span.AddEvent("failed to delete charge station settings", trace.WithAttributes(attribute.String("err", err.Error())))
+		if passwordRefused {
+			err := handlers.ClearPendingPassword(ctx, i.Store, chargeStationId)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
+
+func getSettingStatus(status ocpp201.SetVariableStatusEnumType) store.ChargeStationSettingStatus {
+	switch status {
+	case ocpp201.SetVariableStatusEnumTypeAccepted:
+		return store.ChargeStationSettingStatusAccepted
+	case ocpp201.SetVariableStatusEnumTypeRebootRequired:
+		return store.ChargeStationSettingStatusRebootRequired
+	case ocpp201.SetVariableStatusEnumTypeRejected:
+		return store.ChargeStationSettingStatusRejected
+	default:
+		return store.ChargeStationSettingStatusNotSupported
+	}
+}
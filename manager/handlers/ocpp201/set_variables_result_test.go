@@ -4,9 +4,11 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"k8s.io/utils/clock"
 	"testing"
@@ -66,4 +68,101 @@ func TestSetVariablesResultHandler(t *testing.T) {
 
 	err := handler.HandleCallResult(context.TODO(), "cs001", &request, &response, nil)
 	require.NoError(t, err)
+
+	settings, err := engine.LookupChargeStationSettings(context.TODO(), "cs001")
+	require.NoError(t, err)
+
+	want := map[string]*store.ChargeStationSetting{
+		"MyCtrlr/MyVariable":      {Value: "20", Status: store.ChargeStationSettingStatusAccepted},
+		"MyCtrlr/MyOtherVariable": {Value: "something", Status: store.ChargeStationSettingStatusRejected},
+	}
+	assert.Equal(t, want, settings.Settings)
+}
+
+func TestSetVariablesResultHandlerDoesNotRetainPassword(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := handlers201.SetVariablesResultHandler{
+		Store: engine,
+	}
+
+	request := ocpp201.SetVariablesRequestJson{
+		SetVariableData: []ocpp201.SetVariableDataType{
+			{
+				AttributeValue: "secret",
+				Component: ocpp201.ComponentType{
+					Name: "SecurityCtrlr",
+				},
+				Variable: ocpp201.VariableType{
+					Name: "BasicAuthPassword",
+				},
+			},
+		},
+	}
+
+	response := ocpp201.SetVariablesResponseJson{
+		SetVariableResult: []ocpp201.SetVariableResultType{
+			{
+				Component: ocpp201.ComponentType{
+					Name: "SecurityCtrlr",
+				},
+				Variable: ocpp201.VariableType{
+					Name: "BasicAuthPassword",
+				},
+				AttributeStatus: ocpp201.SetVariableStatusEnumTypeAccepted,
+			},
+		},
+	}
+
+	err := handler.HandleCallResult(context.TODO(), "cs001", &request, &response, nil)
+	require.NoError(t, err)
+
+	settings, err := engine.LookupChargeStationSettings(context.TODO(), "cs001")
+	require.NoError(t, err)
+
+	want := map[string]*store.ChargeStationSetting{
+		"SecurityCtrlr/BasicAuthPassword": {Value: "", Status: store.ChargeStationSettingStatusAccepted},
+	}
+	assert.Equal(t, want, settings.Settings)
+}
+
+func TestSetVariablesResultHandlerClearsPendingPasswordWhenRejected(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := handlers201.SetVariablesResultHandler{
+		Store: engine,
+	}
+
+	err := engine.CreateChargeStation(context.TODO(), &store.ChargeStation{
+		Id:                          "cs001",
+		Base64SHA256Password:        "DEADBEEF",
+		PendingBase64SHA256Password: "CAFEBABE",
+	})
+	require.NoError(t, err)
+
+	request := ocpp201.SetVariablesRequestJson{
+		SetVariableData: []ocpp201.SetVariableDataType{
+			{
+				AttributeValue: "secret",
+				Component:      ocpp201.ComponentType{Name: "SecurityCtrlr"},
+				Variable:       ocpp201.VariableType{Name: "BasicAuthPassword"},
+			},
+		},
+	}
+
+	response := ocpp201.SetVariablesResponseJson{
+		SetVariableResult: []ocpp201.SetVariableResultType{
+			{
+				Component:       ocpp201.ComponentType{Name: "SecurityCtrlr"},
+				Variable:        ocpp201.VariableType{Name: "BasicAuthPassword"},
+				AttributeStatus: ocpp201.SetVariableStatusEnumTypeRejected,
+			},
+		},
+	}
+
+	err = handler.HandleCallResult(context.TODO(), "cs001", &request, &response, nil)
+	require.NoError(t, err)
+
+	cs, err := engine.LookupChargeStation(context.TODO(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "DEADBEEF", cs.Base64SHA256Password)
+	assert.Equal(t, "", cs.PendingBase64SHA256Password)
 }
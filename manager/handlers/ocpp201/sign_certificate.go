@@ -4,9 +4,13 @@ package ocpp201
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
@@ -17,6 +21,7 @@ import (
 type SignCertificateHandler struct {
 	ChargeStationCertificateProvider services.ChargeStationCertificateProvider
 	Store                            store.Engine
+	Clock                            clock.PassiveClock
 }
 
 func (s SignCertificateHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
@@ -70,6 +75,12 @@ func (s SignCertificateHandler) HandleCall(ctx context.Context, chargeStationId
 				} else {
 					status = types.GenericStatusEnumTypeAccepted
 				}
+
+				err = s.recordIssuedCertificate(ctx, chargeStationId, storeType, certId, pemChain)
+				if err != nil {
+					slog.Error("failed to record issued certificate", "err", err)
+					span.AddEvent("failed to record issued certificate", trace.WithAttributes(attribute.String("err", err.Error())))
+				}
 			}
 		}
 	}
@@ -80,3 +91,54 @@ func (s SignCertificateHandler) HandleCall(ctx context.Context, chargeStationId
 		Status: status,
 	}, nil
 }
+
+// recordIssuedCertificate records the expiry of the leaf certificate in the chain so that the
+// charge station can be asked to renew the certificate before it expires. The certificate that it
+// replaces is kept (until it expires) so that it can be revoked.
+func (s SignCertificateHandler) recordIssuedCertificate(ctx context.Context, chargeStationId string, certificateType store.CertificateType, certId, pemChain string) error {
+	block, _ := pem.Decode([]byte(pemChain))
+	if block == nil {
+		return fmt.Errorf("failed to decode certificate chain")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("parsing certificate: %w", err)
+	}
+
+	issued, err := s.Store.LookupChargeStationIssuedCertificates(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station issued certificates: %w", err)
+	}
+	if issued == nil {
+		issued = &store.ChargeStationIssuedCertificates{
+			Certificates: make(map[store.CertificateType]*store.IssuedCertificate),
+		}
+	}
+
+	now := s.Clock.Now()
+	var superseded []*store.IssuedCertificate
+	if previous := issued.Certificates[certificateType]; previous != nil && previous.CertificateId != certId {
+		superseded = append(superseded, previous)
+	}
+	superseded = append(superseded, issued.Superseded...)
+	issued.Superseded = nil
+	for _, certificate := range superseded {
+		if certificate.NotAfter.After(now) {
+			issued.Superseded = append(issued.Superseded, certificate)
+		}
+	}
+	issued.Certificates[certificateType] = &store.IssuedCertificate{
+		CertificateType: certificateType,
+		CertificateId:   certId,
+		SerialNumber:    cert.SerialNumber.Text(16),
+		NotAfter:        cert.NotAfter.UTC(),
+		IssuedAt:        now,
+	}
+	issued.UpdatedAt = now
+
+	err = s.Store.SetChargeStationIssuedCertificates(ctx, chargeStationId, issued)
+	if err != nil {
+		return fmt.Errorf("set charge station issued certificates: %w", err)
+	}
+	return nil
+}
@@ -4,7 +4,13 @@ package ocpp201_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
@@ -13,7 +19,10 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
 	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"math/big"
 	"testing"
+	"time"
 )
 
 type mockCertificateProvider struct{}
@@ -26,6 +35,26 @@ func (m mockCertificateProvider) ProvideCertificate(context.Context, services.Ce
 	return string(pem.EncodeToMemory(&block)), nil
 }
 
+// x509CertificateProvider returns a self-signed certificate that expires at notAfter
+type x509CertificateProvider struct {
+	t        *testing.T
+	notAfter time.Time
+}
+
+func (p x509CertificateProvider) ProvideCertificate(context.Context, services.CertificateType, string, string) (pemEncodedCertificateChain string, err error) {
+	keyPair, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(p.t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(0x1234),
+		Subject:      pkix.Name{CommonName: "cs001"},
+		NotBefore:    p.notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     p.notAfter,
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &keyPair.PublicKey, keyPair)
+	require.NoError(p.t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})), nil
+}
+
 func TestSignCertificateHandler(t *testing.T) {
 	engine := inmemory.NewStore(clock.RealClock{})
 
@@ -65,3 +94,88 @@ func TestSignCertificateHandler(t *testing.T) {
 	require.Equal(t, store.CertificateTypeEVCC, certs.Certificates[0].CertificateType)
 	require.Equal(t, store.CertificateInstallationPending, certs.Certificates[0].CertificateInstallationStatus)
 }
+
+func TestSignCertificateHandlerRecordsIssuedCertificate(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	typ := ocpp201.CertificateSigningUseEnumTypeChargingStationCertificate
+	req := &ocpp201.SignCertificateRequestJson{
+		CertificateType: &typ,
+		Csr:             "test",
+	}
+
+	notAfter := now.Add(90 * 24 * time.Hour)
+	handler := handlers201.SignCertificateHandler{
+		ChargeStationCertificateProvider: x509CertificateProvider{t: t, notAfter: notAfter},
+		Store:                            engine,
+		Clock:                            clockTest.NewFakePassiveClock(now),
+	}
+
+	response, err := handler.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	require.Equal(t, ocpp201.GenericStatusEnumTypeAccepted, response.(*ocpp201.SignCertificateResponseJson).Status)
+
+	installCerts, err := engine.LookupChargeStationInstallCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.Len(t, installCerts.Certificates, 1)
+
+	issued, err := engine.LookupChargeStationIssuedCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, issued)
+	assert.Equal(t, &store.IssuedCertificate{
+		CertificateType: store.CertificateTypeChargeStation,
+		CertificateId:   installCerts.Certificates[0].CertificateId,
+		SerialNumber:    "1234",
+		NotAfter:        notAfter,
+		IssuedAt:        now,
+	}, issued.Certificates[store.CertificateTypeChargeStation])
+}
+
+func TestSignCertificateHandlerKeepsSupersededCertificatesUntilTheyExpire(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	previous := &store.IssuedCertificate{
+		CertificateType: store.CertificateTypeChargeStation,
+		CertificateId:   "previous",
+		SerialNumber:    "1",
+		NotAfter:        now.Add(24 * time.Hour),
+		IssuedAt:        now.Add(-30 * 24 * time.Hour),
+	}
+	err := engine.SetChargeStationIssuedCertificates(context.Background(), "cs001", &store.ChargeStationIssuedCertificates{
+		Certificates: map[store.CertificateType]*store.IssuedCertificate{
+			store.CertificateTypeChargeStation: previous,
+		},
+		Superseded: []*store.IssuedCertificate{
+			{
+				CertificateType: store.CertificateTypeChargeStation,
+				CertificateId:   "expired",
+				SerialNumber:    "0",
+				NotAfter:        now.Add(-time.Hour),
+				IssuedAt:        now.Add(-365 * 24 * time.Hour),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	typ := ocpp201.CertificateSigningUseEnumTypeChargingStationCertificate
+	handler := handlers201.SignCertificateHandler{
+		ChargeStationCertificateProvider: x509CertificateProvider{t: t, notAfter: now.Add(90 * 24 * time.Hour)},
+		Store:                            engine,
+		Clock:                            clockTest.NewFakePassiveClock(now),
+	}
+
+	response, err := handler.HandleCall(context.Background(), "cs001", &ocpp201.SignCertificateRequestJson{
+		CertificateType: &typ,
+		Csr:             "test",
+	})
+	require.NoError(t, err)
+	require.Equal(t, ocpp201.GenericStatusEnumTypeAccepted, response.(*ocpp201.SignCertificateResponseJson).Status)
+
+	issued, err := engine.LookupChargeStationIssuedCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, issued)
+	assert.Equal(t, "1234", issued.Certificates[store.CertificateTypeChargeStation].SerialNumber)
+	assert.Equal(t, []*store.IssuedCertificate{previous}, issued.Superseded)
+}
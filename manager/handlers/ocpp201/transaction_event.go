@@ -5,17 +5,21 @@ package ocpp201
 import (
 	"context"
 
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
 )
 
 type TransactionEventHandler struct {
 	Store            store.Engine
 	TokenAuthService services.TokenAuthService
 	TariffService    services.TariffService
+	Clock            clock.PassiveClock
+	CostUpdater      *CostUpdater
 }
 
 func (t TransactionEventHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
@@ -69,11 +73,53 @@ func (t TransactionEventHandler) HandleCall(ctx context.Context, chargeStationId
 		return nil, err
 	}
 
-	if req.EventType == types.TransactionEventEnumTypeEnded {
-		transaction, err := t.Store.LookupTransaction(ctx, chargeStationId, req.TransactionInfo.TransactionId)
+	if req.ReservationId != nil {
+		// the reservation terminates as a result of the transaction
+		err = handlers.RecordReservationUsed(ctx, t.Store, t.Clock, chargeStationId, *req.ReservationId, req.TransactionInfo.TransactionId)
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	switch req.EventType {
+	case types.TransactionEventEnumTypeStarted:
+		// stations with a display show the personal message to the driver
+		if response.IdTokenInfo != nil && response.IdTokenInfo.Status == types.AuthorizationStatusEnumTypeAccepted &&
+			response.IdTokenInfo.PersonalMessage == nil {
+			tariffText := t.TariffService.TariffText(chargeStationId, idToken)
+			if tariffText != "" {
+				response.IdTokenInfo.PersonalMessage = &types.MessageContentType{
+					Format:  types.MessageFormatEnumTypeUTF8,
+					Content: tariffText,
+				}
+			}
+		}
+	case types.TransactionEventEnumTypeUpdated:
+		// the total cost in the response is the final cost of the transaction: stations with a display
+		// are sent the running cost to show to the driver using CostUpdated
+		if t.CostUpdater == nil {
+			break
+		}
+		transaction, err := t.Store.LookupTransaction(ctx, chargeStationId, req.TransactionInfo.TransactionId)
+		if err != nil {
+			slog.Error("unable to lookup transaction for running cost", "err", err)
+			break
+		}
+		cost, err := t.TariffService.CalculateRunningCost(transaction)
+		if err != nil {
+			slog.Warn("unable to calculate running cost", "err", err)
+			break
+		}
+		t.CostUpdater.Update(ctx, chargeStationId, req.TransactionInfo.TransactionId, cost)
+	case types.TransactionEventEnumTypeEnded:
+		if t.CostUpdater != nil {
+			t.CostUpdater.End(chargeStationId, req.TransactionInfo.TransactionId)
+		}
+		transaction, err := t.Store.LookupTransaction(ctx, chargeStationId, req.TransactionInfo.TransactionId)
+		if err != nil {
+			slog.Error("unable to lookup transaction for total cost", "err", err)
+			break
+		}
 		cost, err := t.TariffService.CalculateCost(transaction)
 		if err != nil {
 			slog.Error("error calculating tariff", "err", err)
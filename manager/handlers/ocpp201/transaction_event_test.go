@@ -4,9 +4,13 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +20,24 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 )
 
+type recordingCallMaker struct {
+	sync.Mutex
+	requests []ocpp.Request
+}
+
+func (r *recordingCallMaker) Send(_ context.Context, _ string, request ocpp.Request) error {
+	r.Lock()
+	defer r.Unlock()
+	r.requests = append(r.requests, request)
+	return nil
+}
+
+func (r *recordingCallMaker) sent() []ocpp.Request {
+	r.Lock()
+	defer r.Unlock()
+	return append([]ocpp.Request(nil), r.requests...)
+}
+
 func TestTransactionEventHandlerWithStartedEvent(t *testing.T) {
 	ctx := context.Background()
 	engine := inmemory.NewStore(clock.RealClock{})
@@ -71,6 +93,10 @@ func TestTransactionEventHandlerWithStartedEvent(t *testing.T) {
 	want := &types.TransactionEventResponseJson{
 		IdTokenInfo: &types.IdTokenInfoType{
 			Status: types.AuthorizationStatusEnumTypeAccepted,
+			PersonalMessage: &types.MessageContentType{
+				Format:  types.MessageFormatEnumTypeUTF8,
+				Content: "0.55 EUR per kWh",
+			},
 		},
 	}
 	assert.Equal(t, want, got)
@@ -80,6 +106,54 @@ func TestTransactionEventHandlerWithStartedEvent(t *testing.T) {
 	assert.NotNil(t, transaction)
 }
 
+func TestTransactionEventHandlerWithReservation(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+
+	err := engine.SetToken(ctx, &store.Token{
+		Uid:   "ABCD1234",
+		Valid: true,
+	})
+	require.NoError(t, err)
+	reservation := setActiveReservation(t, engine, store.ReservationStatusAccepted, clk.Now().Add(-time.Minute))
+
+	handler := handlers.TransactionEventHandler{
+		Store: engine,
+		TokenAuthService: &services.OcppTokenAuthService{
+			Clock:      clk,
+			TokenStore: engine,
+		},
+		TariffService: services.BasicKwhTariffService{},
+		Clock:         clk,
+	}
+
+	req := &types.TransactionEventRequestJson{
+		EventType:     types.TransactionEventEnumTypeStarted,
+		TriggerReason: types.TriggerReasonEnumTypeAuthorized,
+		Timestamp:     "2023-05-05T12:00:00+01:00",
+		IdToken: &types.IdTokenType{
+			Type:    types.IdTokenEnumTypeISO14443,
+			IdToken: "ABCD1234",
+		},
+		ReservationId: makePtr(42),
+		SeqNo:         0,
+		TransactionInfo: types.TransactionType{
+			TransactionId: "5555",
+		},
+	}
+
+	_, err = handler.HandleCall(ctx, "cs001", req)
+	require.NoError(t, err)
+
+	got, err := engine.LookupReservation(ctx, "cs001", 42)
+	require.NoError(t, err)
+	reservation.Status = store.ReservationStatusUsed
+	reservation.TransactionId = makePtr("5555")
+	reservation.UpdatedAt = clk.Now()
+	assert.Equal(t, reservation, got)
+}
+
 func TestTransactionEventHandlerWithStartedEventWithInvalidToken(t *testing.T) {
 	ctx := context.Background()
 	engine := inmemory.NewStore(clock.RealClock{})
@@ -148,10 +222,13 @@ func TestTransactionEventHandlerWithUpdatedEvent(t *testing.T) {
 		TokenStore: engine,
 	}
 
+	callMaker := &recordingCallMaker{}
+
 	handler := handlers.TransactionEventHandler{
 		Store:            engine,
 		TokenAuthService: tokenAuthService,
 		TariffService:    tariffService,
+		CostUpdater:      handlers.NewCostUpdater(callMaker, clock.RealClock{}, time.Minute),
 	}
 
 	req := &types.TransactionEventRequestJson{
@@ -180,8 +257,16 @@ func TestTransactionEventHandlerWithUpdatedEvent(t *testing.T) {
 	got, err := handler.HandleCall(ctx, "cs001", req)
 	assert.NoError(t, err)
 
-	want := &types.TransactionEventResponseJson{}
-	assert.Equal(t, want, got)
+	// the running cost is sent separately as the total cost in the response is the final cost
+	assert.Equal(t, &types.TransactionEventResponseJson{}, got)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, []ocpp.Request{
+			&types.CostUpdatedRequestJson{
+				TotalCost:     0.055,
+				TransactionId: "5555",
+			},
+		}, callMaker.sent())
+	}, time.Second, 10*time.Millisecond)
 
 	transaction, err := engine.LookupTransaction(ctx, "cs001", "5555")
 	require.NoError(t, err)
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TriggerMessageErrorHandler struct {
+	Store store.ChargeStationTriggerMessageStore
+}
+
+func (t TriggerMessageErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	req := request.(*ocpp201.TriggerMessageRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("trigger_message.trigger", string(req.RequestedMessage)))
+
+	return t.Store.SetChargeStationTriggerMessage(ctx, chargeStationId, &store.ChargeStationTriggerMessage{
+		TriggerMessage:   store.TriggerMessage(req.RequestedMessage),
+		TriggerStatus:    store.TriggerStatusFailed,
+		ErrorCode:        string(errorCode),
+		ErrorDescription: errorDescription,
+	})
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+func TestTriggerMessageErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	handler := handlers201.TriggerMessageErrorHandler{Store: engine}
+
+	err := handler.HandleCallError(ctx, "cs001", &ocpp201.TriggerMessageRequestJson{
+		RequestedMessage: ocpp201.MessageTriggerEnumTypeSignChargingStationCertificate,
+	}, transport.ErrorNotSupported, "not supported", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationTriggerMessage(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationTriggerMessage{
+		TriggerMessage:   store.TriggerMessageSignChargingStationCertificate,
+		TriggerStatus:    store.TriggerStatusFailed,
+		ErrorCode:        "NotSupported",
+		ErrorDescription: "not supported",
+	}, got)
+}
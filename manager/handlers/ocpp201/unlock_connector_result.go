@@ -4,13 +4,19 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
 )
 
-type UnlockConnectorResultHandler struct{}
+type UnlockConnectorResultHandler struct {
+	Store store.ChargeStationOperationStore
+	Clock clock.PassiveClock
+}
 
 func (h UnlockConnectorResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*ocpp201.UnlockConnectorRequestJson)
@@ -21,5 +27,5 @@ func (h UnlockConnectorResultHandler) HandleCallResult(ctx context.Context, char
 		attribute.Int("unlock_connector.connector_id", req.ConnectorId),
 		attribute.String("unlock_connector.status", string(resp.Status)))
 
-	return nil
+	return handlers.RecordOperationResult(ctx, h.Store, h.Clock, chargeStationId, "UnlockConnector", string(resp.Status), resp)
 }
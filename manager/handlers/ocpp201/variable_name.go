@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"fmt"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"regexp"
+	"strconv"
+)
+
+// variableNamePattern is a regexp that matches the following:
+// - Component name - mandatory (first component)
+// - Component instance - optional (first component following a ';')
+// - EVSE id - optional (second component following a ';')
+// - Variable name - mandatory (first component following a '/')
+// - Variable instance - optional (first component following a ';')
+// - Attribute type - optional (second component following a ';')
+// The instances may be left empty so that an EVSE id or attribute type can be given without
+// an instance, e.g. EVSE;;1/Power or OCPPCommCtrlr/HeartbeatInterval;;Target
+var variableNamePattern = regexp.MustCompile(`^([A-Za-z0-9*\-_=:+|@.]+)(?:;([A-Za-z0-9*\-_=:+|@.]*))?(?:;(\d+))?/([A-Za-z0-9*\-_=:+|@.]+)(?:;([A-Za-z0-9*\-_=:+|@.]*))?(?:;(Actual|Target|MinSet|MaxSet))?$`)
+
+// ParseVariableName parses a variable name using the syntax accepted by the API (the inverse
+// of getVariableName) into the component, variable and (optional) attribute type.
+func ParseVariableName(name string) (types.ComponentType, types.VariableType, *types.AttributeEnumType, error) {
+	matches := variableNamePattern.FindStringSubmatch(name)
+	if len(matches) != 7 {
+		return types.ComponentType{}, types.VariableType{}, nil, fmt.Errorf("invalid ocpp 2.0.1 name: %s", name)
+	}
+
+	component := types.ComponentType{
+		Name: matches[1],
+	}
+	if matches[2] != "" {
+		component.Instance = &matches[2]
+	}
+	if matches[3] != "" {
+		evseId, err := strconv.Atoi(matches[3])
+		if err != nil {
+			return types.ComponentType{}, types.VariableType{}, nil, fmt.Errorf("invalid ocpp 2.0.1 name (EVSE id is not an integer): %s", name)
+		}
+		component.Evse = &types.EVSEType{
+			Id: evseId,
+		}
+	}
+
+	variable := types.VariableType{
+		Name: matches[4],
+	}
+	if matches[5] != "" {
+		variable.Instance = &matches[5]
+	}
+
+	var attributeType *types.AttributeEnumType
+	if matches[6] != "" {
+		attributeType = (*types.AttributeEnumType)(&matches[6])
+	}
+
+	return component, variable, attributeType, nil
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+	"sync"
+	"time"
+)
+
+// PendingOperationTimeout is how long a request for an operation blocks further requests for the
+// same operation while the charge station has not responded to it
+const PendingOperationTimeout = 5 * time.Minute
+
+// ErrOperationPending is returned by StartOperation when the charge station has not responded to
+// the previous request for the operation
+var ErrOperationPending = errors.New("operation is already pending")
+
+// operationLocks serializes StartOperation for each charge station so that the check for a pending
+// request and the recording of the new request can't interleave
+var operationLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: make(map[string]*sync.Mutex)}
+
+func lockOperations(chargeStationId string) func() {
+	operationLocks.Lock()
+	lock, ok := operationLocks.locks[chargeStationId]
+	if !ok {
+		lock = new(sync.Mutex)
+		operationLocks.locks[chargeStationId] = lock
+	}
+	operationLocks.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// StartOperation records a new request for the operation as pending. Only the most recent request
+// for each operation is recorded (see store.ChargeStationOperation) so ErrOperationPending is returned
+// while the charge station has not responded to the previous request: otherwise its response would
+// be recorded against the new request. A request that has been pending for longer than
+// PendingOperationTimeout is assumed to have been lost.
+func StartOperation(ctx context.Context, operationStore store.ChargeStationOperationStore, clock clock.PassiveClock,
+	chargeStationId, operation string, request ocpp.Request) (*store.ChargeStationOperation, error) {
+	defer lockOperations(chargeStationId)()
+
+	previous, err := lookupPendingOperation(ctx, operationStore, chargeStationId, operation)
+	if err != nil {
+		return nil, err
+	}
+	now := clock.Now()
+	if previous != nil && now.Before(previous.RequestedAt.Add(PendingOperationTimeout)) {
+		return nil, fmt.Errorf("%s requested at %s: %w", operation, previous.RequestedAt.Format(time.RFC3339), ErrOperationPending)
+	}
+
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling %s request: %w", operation, err)
+	}
+
+	op := &store.ChargeStationOperation{
+		ChargeStationId: chargeStationId,
+		Operation:       operation,
+		Request:         string(requestBytes),
+		Status:          store.ChargeStationOperationStatusPending,
+		RequestedAt:     now,
+		UpdatedAt:       now,
+	}
+	err = operationStore.SetChargeStationOperation(ctx, chargeStationId, op)
+	if err != nil {
+		return nil, fmt.Errorf("setting charge station operation %s: %w", operation, err)
+	}
+	return op, nil
+}
+
+// RecordOperationResult records the response from the charge station against the most recent request
+// for the operation (see store.ChargeStationOperation). The response is ignored if there is no pending
+// request for the operation: the call may have been made by another part of the system.
+func RecordOperationResult(ctx context.Context, operationStore store.ChargeStationOperationStore, clock clock.PassiveClock,
+	chargeStationId, operation, status string, response ocpp.Response) error {
+	op, err := lookupPendingOperation(ctx, operationStore, chargeStationId, operation)
+	if err != nil || op == nil {
+		return err
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("marshalling %s response: %w", operation, err)
+	}
+
+	op.Status = store.ChargeStationOperationStatus(status)
+	op.Response = string(responseBytes)
+	op.UpdatedAt = clock.Now()
+	err = operationStore.SetChargeStationOperation(ctx, chargeStationId, op)
+	if err != nil {
+		return fmt.Errorf("setting charge station operation %s: %w", operation, err)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("operation.status", status))
+
+	return nil
+}
+
+// RecordOperationFailure records that the most recent request for the operation could not be sent
+// to the charge station, so that it no longer blocks further requests for the operation
+func RecordOperationFailure(ctx context.Context, operationStore store.ChargeStationOperationStore, clock clock.PassiveClock,
+	chargeStationId, operation string, failure error) error {
+	op, err := lookupPendingOperation(ctx, operationStore, chargeStationId, operation)
+	if err != nil || op == nil {
+		return err
+	}
+
+	op.Status = store.ChargeStationOperationStatusErrored
+	op.ErrorCode = string(transport.ErrorInternalError)
+	op.ErrorDescription = failure.Error()
+	op.UpdatedAt = clock.Now()
+	err = operationStore.SetChargeStationOperation(ctx, chargeStationId, op)
+	if err != nil {
+		return fmt.Errorf("setting charge station operation %s: %w", operation, err)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("operation.status", string(op.Status)))
+
+	return nil
+}
+
+// OperationErrorHandler records a CallError against the most recent request for the operation
+type OperationErrorHandler struct {
+	Store     store.ChargeStationOperationStore
+	Clock     clock.PassiveClock
+	Operation string
+}
+
+func (h OperationErrorHandler) HandleCallError(ctx context.Context, chargeStationId string, _ ocpp.Request, errorCode transport.ErrorCode, errorDescription string, _ any) error {
+	op, err := lookupPendingOperation(ctx, h.Store, chargeStationId, h.Operation)
+	if err != nil || op == nil {
+		return err
+	}
+
+	op.Status = store.ChargeStationOperationStatusErrored
+	op.ErrorCode = string(errorCode)
+	op.ErrorDescription = errorDescription
+	op.UpdatedAt = h.Clock.Now()
+	err = h.Store.SetChargeStationOperation(ctx, chargeStationId, op)
+	if err != nil {
+		return fmt.Errorf("setting charge station operation %s: %w", h.Operation, err)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("operation.status", string(op.Status)))
+
+	return nil
+}
+
+func lookupPendingOperation(ctx context.Context, operationStore store.ChargeStationOperationStore, chargeStationId, operation string) (*store.ChargeStationOperation, error) {
+	op, err := operationStore.LookupChargeStationOperation(ctx, chargeStationId, operation)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station operation %s: %w", operation, err)
+	}
+	if op == nil || op.Status != store.ChargeStationOperationStatusPending {
+		return nil, nil
+	}
+	return op, nil
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecordOperationResultIgnoresOperationThatIsNotPending(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	accepted := &store.ChargeStationOperation{
+		ChargeStationId: "cs001",
+		Operation:       "Reset",
+		Request:         `{"type":"Immediate"}`,
+		Response:        `{"status":"Accepted"}`,
+		Status:          "Accepted",
+	}
+	require.NoError(t, engine.SetChargeStationOperation(ctx, "cs001", accepted))
+
+	err := handlers.RecordOperationResult(ctx, engine, clock.RealClock{}, "cs001", "Reset", "Rejected",
+		&ocpp201.ResetResponseJson{Status: ocpp201.ResetStatusEnumTypeRejected})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationOperation(ctx, "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Equal(t, accepted, got)
+}
+
+func TestRecordOperationResultIgnoresUnknownOperation(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	err := handlers.RecordOperationResult(ctx, engine, clock.RealClock{}, "cs001", "Reset", "Accepted",
+		&ocpp201.ResetResponseJson{Status: ocpp201.ResetStatusEnumTypeAccepted})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationOperation(ctx, "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestOperationErrorHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+	ctx := context.Background()
+
+	requestedAt := clk.Now().Add(-time.Minute)
+	require.NoError(t, engine.SetChargeStationOperation(ctx, "cs001", &store.ChargeStationOperation{
+		Operation:   "ClearCache",
+		Request:     `{}`,
+		Status:      store.ChargeStationOperationStatusPending,
+		RequestedAt: requestedAt,
+		UpdatedAt:   requestedAt,
+	}))
+
+	handler := handlers.OperationErrorHandler{
+		Store:     engine,
+		Clock:     clk,
+		Operation: "ClearCache",
+	}
+	err := handler.HandleCallError(ctx, "cs001", &ocpp201.ClearCacheRequestJson{}, transport.ErrorNotSupported, "not supported", nil)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationOperation(ctx, "cs001", "ClearCache")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationOperation{
+		ChargeStationId:  "cs001",
+		Operation:        "ClearCache",
+		Request:          `{}`,
+		Status:           store.ChargeStationOperationStatusErrored,
+		ErrorCode:        "NotSupported",
+		ErrorDescription: "not supported",
+		RequestedAt:      requestedAt,
+		UpdatedAt:        clk.Now(),
+	}, got)
+}
+
+func TestStartOperationRejectsOperationThatIsPending(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fakeClock := clockTest.NewFakePassiveClock(now)
+
+	op, err := handlers.StartOperation(ctx, engine, fakeClock, "cs001", "Reset", &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeImmediate})
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationOperation{
+		ChargeStationId: "cs001",
+		Operation:       "Reset",
+		Request:         `{"type":"Immediate"}`,
+		Status:          store.ChargeStationOperationStatusPending,
+		RequestedAt:     now,
+		UpdatedAt:       now,
+	}, op)
+
+	_, err = handlers.StartOperation(ctx, engine, fakeClock, "cs001", "Reset", &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeOnIdle})
+	assert.ErrorIs(t, err, handlers.ErrOperationPending)
+
+	// other operations are not affected
+	_, err = handlers.StartOperation(ctx, engine, fakeClock, "cs001", "ClearCache", &ocpp201.ClearCacheRequestJson{})
+	require.NoError(t, err)
+
+	// the pending request is assumed to have been lost after the timeout
+	fakeClock.SetTime(now.Add(handlers.PendingOperationTimeout + time.Second))
+	op, err = handlers.StartOperation(ctx, engine, fakeClock, "cs001", "Reset", &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeOnIdle})
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"OnIdle"}`, op.Request)
+}
+
+func TestStartOperationAllowsOnlyOneConcurrentRequest(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+	fakeClock := clockTest.NewFakePassiveClock(time.Now())
+
+	var wg sync.WaitGroup
+	results := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := handlers.StartOperation(ctx, engine, fakeClock, "cs001", "Reset", &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeImmediate})
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	started := 0
+	for err := range results {
+		if err == nil {
+			started++
+		} else {
+			assert.ErrorIs(t, err, handlers.ErrOperationPending)
+		}
+	}
+	assert.Equal(t, 1, started)
+}
+
+func TestRecordOperationFailure(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fakeClock := clockTest.NewFakePassiveClock(now)
+
+	_, err := handlers.StartOperation(ctx, engine, fakeClock, "cs001", "Reset", &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeImmediate})
+	require.NoError(t, err)
+
+	err = handlers.RecordOperationFailure(ctx, engine, fakeClock, "cs001", "Reset", errors.New("broker unavailable"))
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationOperation(ctx, "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationOperationStatusErrored, got.Status)
+	assert.Equal(t, "InternalError", got.ErrorCode)
+	assert.Equal(t, "broker unavailable", got.ErrorDescription)
+
+	// the failed request no longer blocks the operation
+	_, err = handlers.StartOperation(ctx, engine, fakeClock, "cs001", "Reset", &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeImmediate})
+	require.NoError(t, err)
+}
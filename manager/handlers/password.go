@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// PendingPasswordValidity is how long a charge station has to connect using a new password: the
+// pending password is no longer accepted after this time
+const PendingPasswordValidity = 7 * 24 * time.Hour
+
+// RotatePassword generates a new basic auth password for the charge station. The password is held
+// as the charge station's pending password, so the charge station may connect using either its
+// current or the new password, and is queued as a setting to be sent to the charge station. The
+// password itself is not kept once the charge station has responded to the setting. It returns
+// the base64 encoded SHA-256 hash of the new password.
+func RotatePassword(ctx context.Context, engine store.Engine, clock clock.PassiveClock, chargeStationId string, ocppVersion store.OcppVersion) (string, error) {
+	cs, err := engine.LookupChargeStation(ctx, chargeStationId)
+	if err != nil {
+		return "", fmt.Errorf("lookup charge station: %w", err)
+	}
+	if cs == nil {
+		return "", fmt.Errorf("charge station %s not found", chargeStationId)
+	}
+
+	password, base64SHA256Password, err := generateBasicAuthPassword()
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := clock.Now().Add(PendingPasswordValidity)
+	cs.PendingBase64SHA256Password = base64SHA256Password
+	cs.PendingPasswordExpiresAt = &expiresAt
+	err = engine.UpdateChargeStation(ctx, chargeStationId, cs)
+	if err != nil {
+		return "", fmt.Errorf("update charge station: %w", err)
+	}
+
+	err = engine.UpdateChargeStationSettings(ctx, chargeStationId, &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			store.BasicAuthPasswordKey(ocppVersion): {Value: password, Status: store.ChargeStationSettingStatusPending, SendAfter: clock.Now()},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("update charge station settings: %w", err)
+	}
+
+	return base64SHA256Password, nil
+}
+
+// ClearPendingPassword removes the charge station's pending basic auth password. It is used when
+// the charge station does not accept the setting that changes its password: the charge station
+// continues to use its current password.
+func ClearPendingPassword(ctx context.Context, csStore store.ChargeStationStore, chargeStationId string) error {
+	cs, err := csStore.LookupChargeStation(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station: %w", err)
+	}
+	if cs == nil || cs.PendingBase64SHA256Password == "" {
+		return nil
+	}
+
+	slog.Info("clearing pending password", slog.String("chargeStationId", chargeStationId))
+	cs.PendingBase64SHA256Password = ""
+	cs.PendingPasswordExpiresAt = nil
+	err = csStore.UpdateChargeStation(ctx, chargeStationId, cs)
+	if err != nil {
+		return fmt.Errorf("update charge station: %w", err)
+	}
+	return nil
+}
+
+// generateBasicAuthPassword returns a random password together with the base64 encoded SHA-256
+// hash of the password that is stored with the charge station
+func generateBasicAuthPassword() (string, string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generating password: %w", err)
+	}
+	password := hex.EncodeToString(b)
+	hash := sha256.Sum256([]byte(password))
+	return password, base64.StdEncoding.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// RecordReservationResult records the charge station's response to a ReserveNow request. The
+// status is either Accepted or the reason that the charge station would not make the reservation.
+func RecordReservationResult(ctx context.Context, reservationStore store.ReservationStore, clock clock.PassiveClock, chargeStationId string, reservationId int, status string) error {
+	return updateReservation(ctx, reservationStore, clock, chargeStationId, reservationId, func(reservation *store.Reservation) bool {
+		if reservation.Status != store.ReservationStatusPending {
+			return false
+		}
+		reservation.Status = store.ReservationStatus(status)
+		return true
+	})
+}
+
+// RecordReservationError records a CallError received in response to a ReserveNow request
+func RecordReservationError(ctx context.Context, reservationStore store.ReservationStore, clock clock.PassiveClock, chargeStationId string, reservationId int, errorCode transport.ErrorCode, errorDescription string) error {
+	return updateReservation(ctx, reservationStore, clock, chargeStationId, reservationId, func(reservation *store.Reservation) bool {
+		if reservation.Status != store.ReservationStatusPending {
+			return false
+		}
+		reservation.Status = store.ReservationStatusErrored
+		reservation.ErrorCode = string(errorCode)
+		reservation.ErrorDescription = errorDescription
+		return true
+	})
+}
+
+// RecordReservationCancelled records that the charge station accepted a CancelReservation request
+func RecordReservationCancelled(ctx context.Context, reservationStore store.ReservationStore, clock clock.PassiveClock, chargeStationId string, reservationId int) error {
+	return EndReservation(ctx, reservationStore, clock, chargeStationId, reservationId, store.ReservationStatusCancelled)
+}
+
+// EndReservation records that an active reservation has ended without being used, e.g. because
+// the charge station reports that it has expired or been removed
+func EndReservation(ctx context.Context, reservationStore store.ReservationStore, clock clock.PassiveClock, chargeStationId string, reservationId int, status store.ReservationStatus) error {
+	return updateReservation(ctx, reservationStore, clock, chargeStationId, reservationId, func(reservation *store.Reservation) bool {
+		if !reservation.Status.IsActive() {
+			return false
+		}
+		reservation.Status = status
+		return true
+	})
+}
+
+// RecordReservationUsed links the reservation to the transaction that was started using it
+func RecordReservationUsed(ctx context.Context, reservationStore store.ReservationStore, clock clock.PassiveClock, chargeStationId string, reservationId int, transactionId string) error {
+	return updateReservation(ctx, reservationStore, clock, chargeStationId, reservationId, func(reservation *store.Reservation) bool {
+		reservation.Status = store.ReservationStatusUsed
+		reservation.TransactionId = &transactionId
+		return true
+	})
+}
+
+// updateReservation applies the update to the reservation and stores it if the update returns true.
+// Reservations that are not known are ignored: they may have been made by another system.
+func updateReservation(ctx context.Context, reservationStore store.ReservationStore, clock clock.PassiveClock, chargeStationId string, reservationId int, update func(*store.Reservation) bool) error {
+	reservation, err := reservationStore.LookupReservation(ctx, chargeStationId, reservationId)
+	if err != nil {
+		return fmt.Errorf("lookup reservation %d: %w", reservationId, err)
+	}
+	if reservation == nil {
+		slog.Warn("unknown reservation", slog.String("chargeStationId", chargeStationId), slog.Int("reservationId", reservationId))
+		return nil
+	}
+	if !update(reservation) {
+		return nil
+	}
+	reservation.UpdatedAt = clock.Now()
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("reservation.status", string(reservation.Status)))
+
+	err = reservationStore.SetReservation(ctx, reservation)
+	if err != nil {
+		return fmt.Errorf("set reservation %d: %w", reservationId, err)
+	}
+	return nil
+}
+
+// newReservationIdAttempts is the number of random reservation ids that are tried before giving up
+const newReservationIdAttempts = 10
+
+// NewReservationId returns a reservation id that is not used by any of the charge station's
+// reservations
+func NewReservationId(ctx context.Context, reservationStore store.ReservationStore, chargeStationId string) (int, error) {
+	for i := 0; i < newReservationIdAttempts; i++ {
+		reservationId := int(rand.Int31()) //#nosec G404 - reservation id does not require secure random number generator
+		existing, err := reservationStore.LookupReservation(ctx, chargeStationId, reservationId)
+		if err != nil {
+			return 0, fmt.Errorf("lookup reservation %d: %w", reservationId, err)
+		}
+		if existing == nil {
+			return reservationId, nil
+		}
+	}
+	return 0, errors.New("unable to find an unused reservation id")
+}
+
+// NewReserveNowRequest returns the ReserveNow request for the reservation using the charge
+// station's OCPP version. OCPP 1.6 reserves a connector rather than an EVSE: the EVSE id is used
+// as the connector id (or 0 if any EVSE may be used).
+func NewReserveNowRequest(ocppVersion store.OcppVersion, reservation *store.Reservation) (ocpp.Request, error) {
+	expiryDate := reservation.ExpiryDate.UTC().Format(time.RFC3339)
+	switch ocppVersion {
+	case store.OcppVersion16:
+		if len(reservation.IdToken) > ocpp16.MaxIdTagLength {
+			return nil, fmt.Errorf("id token %s is too long for ocpp 1.6", reservation.IdToken)
+		}
+		if reservation.GroupId != nil && len(*reservation.GroupId) > ocpp16.MaxIdTagLength {
+			return nil, fmt.Errorf("group id %s is too long for ocpp 1.6", *reservation.GroupId)
+		}
+		req := &ocpp16.ReserveNowJson{
+			ExpiryDate:    expiryDate,
+			IdTag:         reservation.IdToken,
+			ParentIdTag:   reservation.GroupId,
+			ReservationId: reservation.ReservationId,
+		}
+		if reservation.EvseId != nil {
+			req.ConnectorId = *reservation.EvseId
+		}
+		return req, nil
+	case store.OcppVersion201:
+		req := &ocpp201.ReserveNowRequestJson{
+			EvseId:         reservation.EvseId,
+			ExpiryDateTime: expiryDate,
+			Id:             reservation.ReservationId,
+			IdToken: ocpp201.IdTokenType{
+				IdToken: reservation.IdToken,
+				Type:    ocpp201.IdTokenEnumType(reservation.TokenType),
+			},
+		}
+		if reservation.GroupId != nil {
+			req.GroupIdToken = &ocpp201.IdTokenType{
+				IdToken: *reservation.GroupId,
+				Type:    ocpp201.IdTokenEnumTypeCentral,
+			}
+		}
+		return req, nil
+	default:
+		return nil, fmt.Errorf("unsupported ocpp version: %s", ocppVersion)
+	}
+}
+
+// NewCancelReservationRequest returns the CancelReservation request for the reservation using the
+// charge station's OCPP version
+func NewCancelReservationRequest(ocppVersion store.OcppVersion, reservationId int) (ocpp.Request, error) {
+	switch ocppVersion {
+	case store.OcppVersion16:
+		return &ocpp16.CancelReservationJson{ReservationId: reservationId}, nil
+	case store.OcppVersion201:
+		return &ocpp201.CancelReservationRequestJson{ReservationId: reservationId}, nil
+	default:
+		return nil, fmt.Errorf("unsupported ocpp version: %s", ocppVersion)
+	}
+}
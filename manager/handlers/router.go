@@ -10,6 +10,7 @@ import (
 	"github.com/santhosh-tekuri/jsonschema"
 	"github.com/thoughtworks/maeve-csms/manager/schemas"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/slog"
@@ -23,6 +24,7 @@ type Router struct {
 	OcppVersion      transport.OcppVersion      // the OCPP version that this router supports
 	CallRoutes       map[string]CallRoute       // the set of routes for incoming calls (indexed by action)
 	CallResultRoutes map[string]CallResultRoute // the set of routes for call results (indexed by action)
+	CallErrorRoutes  map[string]CallErrorRoute  // the set of routes for call errors (indexed by action)
 }
 
 func (r Router) Handle(ctx context.Context, chargeStationId string, msg *transport.Message) {
@@ -131,8 +133,26 @@ func (r Router) route(ctx context.Context, chargeStationId string, message *tran
 			return err
 		}
 	case transport.MessageTypeCallError:
-		// TODO: what do we want to do with errors?
-		return errors.New("we shouldn't get here at the moment")
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.String("call_error.code", string(message.ErrorCode)),
+			attribute.String("call_error.description", message.ErrorDescription))
+		route, ok := r.CallErrorRoutes[message.Action]
+		if !ok {
+			return fmt.Errorf("routing request: %w", transport.NewError(transport.ErrorNotImplemented, fmt.Errorf("%s error not implemented", message.Action)))
+		}
+		err := schemas.Validate(message.RequestPayload, r.SchemaFS, route.RequestSchema)
+		if err != nil {
+			return fmt.Errorf("validating %s request: %w", message.Action, err)
+		}
+		req := route.NewRequest()
+		err = json.Unmarshal(message.RequestPayload, &req)
+		if err != nil {
+			return fmt.Errorf("unmarshalling %s request payload: %w", message.Action, err)
+		}
+		err = route.Handler.HandleCallError(ctx, chargeStationId, req, message.ErrorCode, message.ErrorDescription, message.State)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -552,6 +552,142 @@ func TestRouterErrorWhenCallResultHandlerErrors(t *testing.T) {
 	})
 }
 
+var errorMsg = transport.Message{
+	Action:           "Result",
+	MessageType:      transport.MessageTypeCallError,
+	RequestPayload:   []byte("{}"),
+	ErrorCode:        transport.ErrorNotSupported,
+	ErrorDescription: "not supported",
+}
+
+func TestRouterHandlesCallError(t *testing.T) {
+	tracer, exporter := testutil.GetTracer()
+
+	emitter := new(FakeEmitter)
+
+	var gotCode transport.ErrorCode
+	var gotDescription string
+	handler := func(ctx context.Context,
+		chargeStationId string,
+		request ocpp.Request,
+		errorCode transport.ErrorCode,
+		errorDescription string,
+		state any) error {
+		gotCode = errorCode
+		gotDescription = errorDescription
+		return nil
+	}
+
+	router := handlers.Router{
+		Emitter:  emitter,
+		SchemaFS: os.DirFS("testdata"),
+		CallErrorRoutes: map[string]handlers.CallErrorRoute{
+			"Result": {
+				NewRequest:    func() ocpp.Request { return new(fakeRequest) },
+				RequestSchema: "schemas/EmptySchema.json",
+				Handler:       handlers.CallErrorHandlerFunc(handler),
+			},
+		},
+	}
+
+	func() {
+		ctx, span := tracer.Start(context.Background(), "test")
+		defer span.End()
+		router.Handle(ctx, "id", &errorMsg)
+	}()
+
+	// for a call error the emitter should never be called
+	assert.False(t, emitter.called)
+
+	assert.Equal(t, transport.ErrorNotSupported, gotCode)
+	assert.Equal(t, "not supported", gotDescription)
+
+	// check that no error was produced using telemetry
+	require.Greater(t, len(exporter.GetSpans()), 0)
+	assert.Equal(t, codes.Ok, exporter.GetSpans()[0].Status.Code)
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"call_error.code":        "NotSupported",
+		"call_error.description": "not supported",
+	})
+}
+
+func TestRouterErrorWhenNoCallErrorRoute(t *testing.T) {
+	tracer, exporter := testutil.GetTracer()
+
+	emitter := new(FakeEmitter)
+
+	router := handlers.Router{
+		Emitter:         emitter,
+		SchemaFS:        os.DirFS("testdata"),
+		CallErrorRoutes: map[string]handlers.CallErrorRoute{},
+	}
+
+	func() {
+		ctx, span := tracer.Start(context.Background(), "test")
+		defer span.End()
+		router.Handle(ctx, "id", &errorMsg)
+	}()
+
+	// for a call error the emitter should never be called
+	assert.False(t, emitter.called)
+
+	// check that an error was produced using telemetry
+	require.Greater(t, len(exporter.GetSpans()), 0)
+	assert.Equal(t, codes.Error, exporter.GetSpans()[0].Status.Code)
+	require.Greater(t, len(exporter.GetSpans()[0].Events), 0)
+	testutil.AssertAttributes(t, exporter.GetSpans()[0].Events[0].Attributes, map[string]any{
+		"exception.type":    "*fmt.wrapError",
+		"exception.message": "routing request: NotImplemented: Result error not implemented",
+	})
+}
+
+func TestRouterErrorWhenCallErrorHandlerErrors(t *testing.T) {
+	tracer, exporter := testutil.GetTracer()
+
+	emitter := new(FakeEmitter)
+
+	handler := func(ctx context.Context,
+		chargeStationId string,
+		request ocpp.Request,
+		errorCode transport.ErrorCode,
+		errorDescription string,
+		state any) error {
+		return errors.New("handler error")
+	}
+
+	router := handlers.Router{
+		Emitter:  emitter,
+		SchemaFS: os.DirFS("testdata"),
+		CallErrorRoutes: map[string]handlers.CallErrorRoute{
+			"Result": {
+				NewRequest:    func() ocpp.Request { return new(fakeRequest) },
+				RequestSchema: "schemas/EmptySchema.json",
+				Handler:       handlers.CallErrorHandlerFunc(handler),
+			},
+		},
+	}
+
+	func() {
+		ctx, span := tracer.Start(context.Background(), "test")
+		defer span.End()
+		router.Handle(ctx, "id", &errorMsg)
+	}()
+
+	// for a call error the emitter should never be called
+	assert.False(t, emitter.called)
+
+	// check that an error was produced using telemetry
+	require.Greater(t, len(exporter.GetSpans()), 0)
+	assert.Equal(t, codes.Error, exporter.GetSpans()[0].Status.Code)
+	require.Greater(t, len(exporter.GetSpans()[0].Events), 0)
+	testutil.AssertAttributes(t, exporter.GetSpans()[0].Events[0].Attributes, map[string]any{
+		"exception.type": "*errors.errorString",
+		"exception.message": func(val attribute.Value) bool {
+			return strings.Contains(val.AsString(), "handler error")
+		},
+	})
+}
+
 type fakeRequest struct{}
 
 func (*fakeRequest) IsRequest() {}
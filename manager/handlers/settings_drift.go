@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"sort"
+)
+
+// DetectSettingsDrift compares the variables that have been read back from a charge station with
+// the settings the charge station previously accepted. Accepted settings with a different value
+// are marked as Drifted and drifted settings that once again have the expected value are marked
+// as Accepted. Write-only settings are ignored as their values are not kept. It returns the names
+// of the settings that have drifted.
+func DetectSettingsDrift(ctx context.Context, settingsStore store.ChargeStationSettingsStore, chargeStationId string, variables map[string]*store.ChargeStationVariable) ([]string, error) {
+	settings, err := settingsStore.LookupChargeStationSettings(ctx, chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station settings: %w", err)
+	}
+	if settings == nil {
+		return nil, nil
+	}
+
+	var drifted []string
+	updated := make(map[string]*store.ChargeStationSetting)
+	for name, variable := range variables {
+		setting := settings.Settings[name]
+		if setting == nil || store.IsWriteOnlySetting(name) || variable.Status != store.ChargeStationVariableStatusAccepted || variable.Value == nil {
+			continue
+		}
+		switch {
+		case setting.Status == store.ChargeStationSettingStatusAccepted && *variable.Value != setting.Value:
+			drifted = append(drifted, name)
+			updated[name] = &store.ChargeStationSetting{Value: setting.Value, Status: store.ChargeStationSettingStatusDrifted}
+		case setting.Status == store.ChargeStationSettingStatusDrifted && *variable.Value == setting.Value:
+			updated[name] = &store.ChargeStationSetting{Value: setting.Value, Status: store.ChargeStationSettingStatusAccepted}
+		}
+	}
+
+	sort.Strings(drifted)
+
+	if len(updated) > 0 {
+		err = settingsStore.UpdateChargeStationSettings(ctx, chargeStationId, &store.ChargeStationSettings{
+			ChargeStationId: chargeStationId,
+			Settings:        updated,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("update charge station settings: %w", err)
+		}
+	}
+
+	return drifted, nil
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func makePtr[T any](t T) *T {
+	p := t
+	return &p
+}
+
+func TestDetectSettingsDrift(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"HeartbeatInterval":        {Value: "60", Status: store.ChargeStationSettingStatusAccepted},
+			"MeterValueSampleInterval": {Value: "30", Status: store.ChargeStationSettingStatusAccepted},
+			"WebSocketPingInterval":    {Value: "10", Status: store.ChargeStationSettingStatusDrifted},
+			"ConnectionTimeOut":        {Value: "90", Status: store.ChargeStationSettingStatusPending},
+			"AuthorizationKey":         {Status: store.ChargeStationSettingStatusAccepted},
+		},
+	})
+	require.NoError(t, err)
+
+	drifted, err := handlers.DetectSettingsDrift(ctx, engine, "cs001", map[string]*store.ChargeStationVariable{
+		"HeartbeatInterval":        {Value: makePtr("300"), Status: store.ChargeStationVariableStatusAccepted},
+		"MeterValueSampleInterval": {Value: makePtr("30"), Status: store.ChargeStationVariableStatusAccepted},
+		"WebSocketPingInterval":    {Value: makePtr("10"), Status: store.ChargeStationVariableStatusAccepted},
+		"ConnectionTimeOut":        {Value: makePtr("60"), Status: store.ChargeStationVariableStatusAccepted},
+		"UnknownSetting":           {Status: store.ChargeStationVariableStatusUnknownKey},
+		"AuthorizationKey":         {Value: makePtr("********"), Status: store.ChargeStationVariableStatusAccepted},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HeartbeatInterval"}, drifted)
+
+	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationSettingStatusDrifted, settings.Settings["HeartbeatInterval"].Status)
+	assert.Equal(t, "60", settings.Settings["HeartbeatInterval"].Value)
+	assert.Equal(t, store.ChargeStationSettingStatusAccepted, settings.Settings["MeterValueSampleInterval"].Status)
+	assert.Equal(t, store.ChargeStationSettingStatusAccepted, settings.Settings["WebSocketPingInterval"].Status)
+	assert.Equal(t, store.ChargeStationSettingStatusPending, settings.Settings["ConnectionTimeOut"].Status)
+	assert.Equal(t, store.ChargeStationSettingStatusAccepted, settings.Settings["AuthorizationKey"].Status)
+}
+
+func TestDetectSettingsDriftWithNoSettings(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	drifted, err := handlers.DetectSettingsDrift(context.Background(), engine, "cs001", map[string]*store.ChargeStationVariable{
+		"HeartbeatInterval": {Value: makePtr("300"), Status: store.ChargeStationVariableStatusAccepted},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, drifted)
+}
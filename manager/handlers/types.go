@@ -5,6 +5,7 @@ package handlers
 import (
 	"context"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 )
 
 // CallHandler is the interface implemented by handlers that are designed to process an OCPP Call.
@@ -54,9 +55,42 @@ type CallResultRoute struct {
 	Handler        CallResultHandler    // Function to process a call result
 }
 
+// CallErrorHandler is the interface implemented by the handlers that are designed to process an OCPP CallError
+// sent by the charge station in response to a call made by the CSMS.
+type CallErrorHandler interface {
+	// HandleCallError receives the charge station id, OCPP Request message and the error code and description
+	// returned by the charge station along with any cached state. It may return an error.
+	HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error
+}
+
+// CallErrorHandlerFunc allows a plain function to be used as a CallErrorHandler
+type CallErrorHandlerFunc func(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error
+
+func (ceh CallErrorHandlerFunc) HandleCallError(ctx context.Context, chargeStationId string, request ocpp.Request, errorCode transport.ErrorCode, errorDescription string, state any) error {
+	return ceh(ctx, chargeStationId, request, errorCode, errorDescription, state)
+}
+
+// CallErrorRoute is the configuration that is used by the Router for processing an OCPP CallError.
+// In the Router this is indexed by the OCPP Action (of the corresponding Call).
+type CallErrorRoute struct {
+	NewRequest    func() ocpp.Request // Function used for creating an empty request
+	RequestSchema string              // JSON schema file that corresponds to the request data structure
+	Handler       CallErrorHandler    // Function to process a call error
+}
+
 // CallMaker is the interface used by handlers (and other parts of the system) that want to initiate
 // an OCPP call from the CSMS.
 type CallMaker interface {
 	// Send receives the charge station id and the request to send. It may return an error.
 	Send(ctx context.Context, chargeStationId string, request ocpp.Request) error
 }
+
+// SyncCallMaker is the interface used by parts of the system that want to initiate an OCPP call from the
+// CSMS and wait for the charge station to respond.
+type SyncCallMaker interface {
+	CallMaker
+	// SendAndWait receives the charge station id, the request to send and an empty response which is populated
+	// with the response from the charge station. It waits until the charge station responds or the context is
+	// done. If the charge station responds with a CallError then a *transport.Error is returned.
+	SendAndWait(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response) error
+}
@@ -0,0 +1,794 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package iso15118
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Event codes and counts for the document and fragment grammars. Global elements
+// are ordered by local name and then by namespace.
+const (
+	documentEventCount = 81 // 80 global elements and SE(*)
+	documentV2GMessage = 76
+
+	fragmentEventCount                        = 245 // 243 elements, SE(*) and ED
+	fragmentEnd                               = 244
+	fragmentContractSignatureCertChain        = 33
+	fragmentContractSignatureEncryptedPrivKey = 34
+	fragmentDHpublickey                       = 45
+	fragmentSignedInfo                        = 208
+	fragmentEMAID                             = 236
+)
+
+// Event codes and counts for the BodyElement substitution group, which is ordered by
+// local name. The count includes the end of an empty Body.
+const (
+	bodyEventCount                 = 36
+	bodyCertificateInstallationReq = 5
+	bodyCertificateInstallationRes = 6
+)
+
+const (
+	maxRootCertificateIDs = 20
+	maxSubCertificates    = 4
+)
+
+// EncodeV2GMessage encodes a message as an EXI stream using the ISO 15118-2:2013 schema
+func EncodeV2GMessage(msg *V2GMessage) ([]byte, error) {
+	w := newBitWriter()
+	w.writeDocumentEvent(documentV2GMessage, documentEventCount)
+	w.writeEvent(0, 1) // SE(Header)
+	encodeHeader(w, &msg.Header)
+	w.writeEvent(0, 1) // SE(Body)
+	err := encodeBody(w, &msg.Body)
+	if err != nil {
+		return nil, err
+	}
+	w.writeEvent(0, 1) // EE(V2G_Message)
+	return w.bytes(), nil
+}
+
+// DecodeV2GMessage decodes an EXI stream that was encoded using the ISO 15118-2:2013 schema
+func DecodeV2GMessage(data []byte) (*V2GMessage, error) {
+	r, err := newBitReader(data)
+	if err != nil {
+		return nil, err
+	}
+	code, err := r.readDocumentEvent(documentEventCount)
+	if err != nil {
+		return nil, fmt.Errorf("reading document: %w", err)
+	}
+	if code != documentV2GMessage {
+		return nil, fmt.Errorf("reading document: unexpected event code %d", code)
+	}
+
+	msg := new(V2GMessage)
+	if err = r.expectEvent(0, 1, "Header"); err != nil {
+		return nil, err
+	}
+	if err = decodeHeader(r, &msg.Header); err != nil {
+		return nil, err
+	}
+	if err = r.expectEvent(0, 1, "Body"); err != nil {
+		return nil, err
+	}
+	if err = decodeBody(r, &msg.Body); err != nil {
+		return nil, err
+	}
+	if err = r.expectEvent(0, 1, "end of V2G_Message"); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// encodeFragment encodes a single element as an EXI fragment, which is the canonical form
+// used when calculating the digests of the element and of the signed info
+func encodeFragment(element int, encode func(w *bitWriter)) []byte {
+	w := newBitWriter()
+	w.writeDocumentEvent(element, fragmentEventCount)
+	encode(w)
+	w.writeDocumentEvent(fragmentEnd, fragmentEventCount)
+	return w.bytes()
+}
+
+// writeRepeated writes between 1 and max (or unbounded if max is 0) occurrences of an
+// element followed by the end of the enclosing element
+func (w *bitWriter) writeRepeated(count, max int, encode func(i int)) {
+	for i := 0; i < count; i++ {
+		if i == 0 {
+			w.writeEvent(0, 1)
+		} else {
+			w.writeEvent(0, 2)
+		}
+		encode(i)
+	}
+	if max != 0 && count == max {
+		w.writeEvent(0, 1)
+	} else {
+		w.writeEvent(1, 2)
+	}
+}
+
+func (r *bitReader) readRepeated(max int, element string, decode func() error) error {
+	if err := r.expectEvent(0, 1, element); err != nil {
+		return err
+	}
+	for count := 1; ; count++ {
+		if err := decode(); err != nil {
+			return err
+		}
+		if max != 0 && count == max {
+			return r.expectEvent(0, 1, "end of "+element)
+		}
+		code, err := r.readEvent(2)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", element, err)
+		}
+		if code == 1 {
+			return nil
+		}
+	}
+}
+
+func (w *bitWriter) writeEnum(value, count int) {
+	w.writeBits(codeLength(count-1), uint64(value))
+}
+
+func (r *bitReader) readEnum(count int) (int, error) {
+	value, err := r.readBits(codeLength(count - 1))
+	if err != nil {
+		return 0, err
+	}
+	if int(value) >= count {
+		return 0, fmt.Errorf("invalid enumeration value %d", value)
+	}
+	return int(value), nil
+}
+
+func encodeHeader(w *bitWriter, h *MessageHeader) {
+	w.writeEvent(0, 1) // SE(SessionID)
+	w.writeEvent(0, 1) // CH
+	w.writeBinary(h.SessionID)
+	w.writeEvent(0, 1) // EE
+
+	switch {
+	case h.Notification != nil:
+		w.writeEvent(0, 3)
+		encodeNotification(w, h.Notification)
+		if h.Signature != nil {
+			w.writeEvent(0, 2)
+			encodeSignature(w, h.Signature)
+			w.writeEvent(0, 1)
+		} else {
+			w.writeEvent(1, 2)
+		}
+	case h.Signature != nil:
+		w.writeEvent(1, 3)
+		encodeSignature(w, h.Signature)
+		w.writeEvent(0, 1)
+	default:
+		w.writeEvent(2, 3)
+	}
+}
+
+func decodeHeader(r *bitReader, h *MessageHeader) error {
+	var err error
+	if err = r.expectEvent(0, 1, "SessionID"); err != nil {
+		return err
+	}
+	if h.SessionID, err = readBinaryContent(r, "SessionID"); err != nil {
+		return err
+	}
+
+	code, err := r.readEvent(3)
+	if err != nil {
+		return fmt.Errorf("reading Header: %w", err)
+	}
+	if code == 0 {
+		h.Notification = new(Notification)
+		if err = decodeNotification(r, h.Notification); err != nil {
+			return err
+		}
+		code, err = r.readEvent(2)
+		if err != nil {
+			return fmt.Errorf("reading Header: %w", err)
+		}
+		code++
+	}
+	if code == 1 {
+		h.Signature = new(Signature)
+		if err = decodeSignature(r, h.Signature); err != nil {
+			return err
+		}
+		return r.expectEvent(0, 1, "end of Header")
+	}
+	return nil
+}
+
+func encodeNotification(w *bitWriter, n *Notification) {
+	w.writeEvent(0, 1) // SE(FaultCode)
+	w.writeEvent(0, 1) // CH
+	w.writeEnum(int(n.FaultCode), faultCodeCount)
+	w.writeEvent(0, 1) // EE
+	if n.FaultMsg != nil {
+		w.writeEvent(0, 2)
+		writeStringContent(w, *n.FaultMsg)
+		w.writeEvent(0, 1)
+	} else {
+		w.writeEvent(1, 2)
+	}
+}
+
+func decodeNotification(r *bitReader, n *Notification) error {
+	if err := r.expectEvent(0, 1, "FaultCode"); err != nil {
+		return err
+	}
+	if err := r.expectEvent(0, 1, "FaultCode content"); err != nil {
+		return err
+	}
+	faultCode, err := r.readEnum(faultCodeCount)
+	if err != nil {
+		return fmt.Errorf("reading FaultCode: %w", err)
+	}
+	n.FaultCode = FaultCode(faultCode)
+	if err = r.expectEvent(0, 1, "end of FaultCode"); err != nil {
+		return err
+	}
+	code, err := r.readEvent(2)
+	if err != nil {
+		return fmt.Errorf("reading Notification: %w", err)
+	}
+	if code == 0 {
+		faultMsg, err := readStringContent(r, "FaultMsg")
+		if err != nil {
+			return err
+		}
+		n.FaultMsg = &faultMsg
+		return r.expectEvent(0, 1, "end of Notification")
+	}
+	return nil
+}
+
+func encodeBody(w *bitWriter, b *Body) error {
+	switch {
+	case b.CertificateInstallationReq != nil:
+		w.writeEvent(bodyCertificateInstallationReq, bodyEventCount)
+		if err := encodeCertificateInstallationReq(w, b.CertificateInstallationReq); err != nil {
+			return err
+		}
+	case b.CertificateInstallationRes != nil:
+		w.writeEvent(bodyCertificateInstallationRes, bodyEventCount)
+		if err := encodeCertificateInstallationRes(w, b.CertificateInstallationRes); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported message body")
+	}
+	w.writeEvent(0, 1) // EE(Body)
+	return nil
+}
+
+func decodeBody(r *bitReader, b *Body) error {
+	code, err := r.readEvent(bodyEventCount)
+	if err != nil {
+		return fmt.Errorf("reading Body: %w", err)
+	}
+	switch code {
+	case bodyCertificateInstallationReq:
+		b.CertificateInstallationReq = new(CertificateInstallationReq)
+		err = decodeCertificateInstallationReq(r, b.CertificateInstallationReq)
+	case bodyCertificateInstallationRes:
+		b.CertificateInstallationRes = new(CertificateInstallationRes)
+		err = decodeCertificateInstallationRes(r, b.CertificateInstallationRes)
+	default:
+		return fmt.Errorf("unsupported message body: event code %d", code)
+	}
+	if err != nil {
+		return err
+	}
+	return r.expectEvent(0, 1, "end of Body")
+}
+
+func encodeCertificateInstallationReq(w *bitWriter, req *CertificateInstallationReq) error {
+	if len(req.ListOfRootCertificateIDs) == 0 || len(req.ListOfRootCertificateIDs) > maxRootCertificateIDs {
+		return fmt.Errorf("certificate installation request must have between 1 and %d root certificate ids", maxRootCertificateIDs)
+	}
+	w.writeEvent(0, 1) // AT(Id)
+	w.writeString(req.Id)
+	w.writeEvent(0, 1) // SE(OEMProvisioningCert)
+	writeBinaryContent(w, req.OEMProvisioningCert)
+	w.writeEvent(0, 1) // SE(ListOfRootCertificateIDs)
+	w.writeRepeated(len(req.ListOfRootCertificateIDs), maxRootCertificateIDs, func(i int) {
+		encodeX509IssuerSerial(w, &req.ListOfRootCertificateIDs[i])
+	})
+	w.writeEvent(0, 1) // EE
+	return nil
+}
+
+func decodeCertificateInstallationReq(r *bitReader, req *CertificateInstallationReq) error {
+	var err error
+	if err = r.expectEvent(0, 1, "CertificateInstallationReq Id"); err != nil {
+		return err
+	}
+	if req.Id, err = r.readString(); err != nil {
+		return fmt.Errorf("reading CertificateInstallationReq Id: %w", err)
+	}
+	if err = r.expectEvent(0, 1, "OEMProvisioningCert"); err != nil {
+		return err
+	}
+	if req.OEMProvisioningCert, err = readBinaryContent(r, "OEMProvisioningCert"); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "ListOfRootCertificateIDs"); err != nil {
+		return err
+	}
+	err = r.readRepeated(maxRootCertificateIDs, "RootCertificateID", func() error {
+		var id X509IssuerSerial
+		if err := decodeX509IssuerSerial(r, &id); err != nil {
+			return err
+		}
+		req.ListOfRootCertificateIDs = append(req.ListOfRootCertificateIDs, id)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return r.expectEvent(0, 1, "end of CertificateInstallationReq")
+}
+
+func encodeX509IssuerSerial(w *bitWriter, id *X509IssuerSerial) {
+	w.writeEvent(0, 1) // SE(X509IssuerName)
+	writeStringContent(w, id.X509IssuerName)
+	w.writeEvent(0, 1) // SE(X509SerialNumber)
+	w.writeEvent(0, 1) // CH
+	w.writeInteger(id.X509SerialNumber)
+	w.writeEvent(0, 1) // EE
+	w.writeEvent(0, 1) // EE
+}
+
+func decodeX509IssuerSerial(r *bitReader, id *X509IssuerSerial) error {
+	var err error
+	if err = r.expectEvent(0, 1, "X509IssuerName"); err != nil {
+		return err
+	}
+	if id.X509IssuerName, err = readStringContent(r, "X509IssuerName"); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "X509SerialNumber"); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "X509SerialNumber content"); err != nil {
+		return err
+	}
+	if id.X509SerialNumber, err = r.readInteger(); err != nil {
+		return fmt.Errorf("reading X509SerialNumber: %w", err)
+	}
+	if err = r.expectEvent(0, 1, "end of X509SerialNumber"); err != nil {
+		return err
+	}
+	return r.expectEvent(0, 1, "end of RootCertificateID")
+}
+
+func encodeCertificateInstallationRes(w *bitWriter, res *CertificateInstallationRes) error {
+	w.writeEvent(0, 1) // SE(ResponseCode)
+	w.writeEvent(0, 1) // CH
+	w.writeEnum(int(res.ResponseCode), responseCodeCount)
+	w.writeEvent(0, 1) // EE
+	w.writeEvent(0, 1) // SE(SAProvisioningCertificateChain)
+	if err := encodeCertificateChain(w, &res.SAProvisioningCertificateChain); err != nil {
+		return err
+	}
+	w.writeEvent(0, 1) // SE(ContractSignatureCertChain)
+	if err := encodeCertificateChain(w, &res.ContractSignatureCertChain); err != nil {
+		return err
+	}
+	w.writeEvent(0, 1) // SE(ContractSignatureEncryptedPrivateKey)
+	encodeIdentifiedBinary(w, &res.ContractSignatureEncryptedPrivateKey)
+	w.writeEvent(0, 1) // SE(DHpublickey)
+	encodeIdentifiedBinary(w, &res.DHpublickey)
+	w.writeEvent(0, 1) // SE(eMAID)
+	encodeIdentifiedString(w, &res.EMAID)
+	w.writeEvent(0, 1) // EE
+	return nil
+}
+
+func decodeCertificateInstallationRes(r *bitReader, res *CertificateInstallationRes) error {
+	if err := r.expectEvent(0, 1, "ResponseCode"); err != nil {
+		return err
+	}
+	if err := r.expectEvent(0, 1, "ResponseCode content"); err != nil {
+		return err
+	}
+	responseCode, err := r.readEnum(responseCodeCount)
+	if err != nil {
+		return fmt.Errorf("reading ResponseCode: %w", err)
+	}
+	res.ResponseCode = ResponseCode(responseCode)
+	if err = r.expectEvent(0, 1, "end of ResponseCode"); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "SAProvisioningCertificateChain"); err != nil {
+		return err
+	}
+	if err = decodeCertificateChain(r, &res.SAProvisioningCertificateChain); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "ContractSignatureCertChain"); err != nil {
+		return err
+	}
+	if err = decodeCertificateChain(r, &res.ContractSignatureCertChain); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "ContractSignatureEncryptedPrivateKey"); err != nil {
+		return err
+	}
+	if err = decodeIdentifiedBinary(r, &res.ContractSignatureEncryptedPrivateKey, "ContractSignatureEncryptedPrivateKey"); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "DHpublickey"); err != nil {
+		return err
+	}
+	if err = decodeIdentifiedBinary(r, &res.DHpublickey, "DHpublickey"); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "eMAID"); err != nil {
+		return err
+	}
+	if err = decodeIdentifiedString(r, &res.EMAID, "eMAID"); err != nil {
+		return err
+	}
+	return r.expectEvent(0, 1, "end of CertificateInstallationRes")
+}
+
+func encodeCertificateChain(w *bitWriter, chain *CertificateChain) error {
+	if len(chain.SubCertificates) > maxSubCertificates {
+		return fmt.Errorf("certificate chain must have at most %d sub certificates", maxSubCertificates)
+	}
+	if chain.Id != nil {
+		w.writeEvent(0, 2) // AT(Id)
+		w.writeString(*chain.Id)
+		w.writeEvent(0, 1) // SE(Certificate)
+	} else {
+		w.writeEvent(1, 2) // SE(Certificate)
+	}
+	writeBinaryContent(w, chain.Certificate)
+	if len(chain.SubCertificates) > 0 {
+		w.writeEvent(0, 2) // SE(SubCertificates)
+		w.writeRepeated(len(chain.SubCertificates), maxSubCertificates, func(i int) {
+			writeBinaryContent(w, chain.SubCertificates[i])
+		})
+		w.writeEvent(0, 1) // EE
+	} else {
+		w.writeEvent(1, 2) // EE
+	}
+	return nil
+}
+
+func decodeCertificateChain(r *bitReader, chain *CertificateChain) error {
+	code, err := r.readEvent(2)
+	if err != nil {
+		return fmt.Errorf("reading certificate chain: %w", err)
+	}
+	if code == 0 {
+		id, err := r.readString()
+		if err != nil {
+			return fmt.Errorf("reading certificate chain Id: %w", err)
+		}
+		chain.Id = &id
+		if err = r.expectEvent(0, 1, "Certificate"); err != nil {
+			return err
+		}
+	}
+	if chain.Certificate, err = readBinaryContent(r, "Certificate"); err != nil {
+		return err
+	}
+	code, err = r.readEvent(2)
+	if err != nil {
+		return fmt.Errorf("reading certificate chain: %w", err)
+	}
+	if code == 0 {
+		err = r.readRepeated(maxSubCertificates, "SubCertificates Certificate", func() error {
+			certificate, err := readBinaryContent(r, "SubCertificates Certificate")
+			if err != nil {
+				return err
+			}
+			chain.SubCertificates = append(chain.SubCertificates, certificate)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return r.expectEvent(0, 1, "end of certificate chain")
+	}
+	return nil
+}
+
+func encodeIdentifiedBinary(w *bitWriter, value *IdentifiedValue[[]byte]) {
+	w.writeEvent(0, 1) // AT(Id)
+	w.writeString(value.Id)
+	writeBinaryContent(w, value.Value)
+}
+
+func decodeIdentifiedBinary(r *bitReader, value *IdentifiedValue[[]byte], element string) error {
+	var err error
+	if err = r.expectEvent(0, 1, element+" Id"); err != nil {
+		return err
+	}
+	if value.Id, err = r.readString(); err != nil {
+		return fmt.Errorf("reading %s Id: %w", element, err)
+	}
+	value.Value, err = readBinaryContent(r, element)
+	return err
+}
+
+func encodeIdentifiedString(w *bitWriter, value *IdentifiedValue[string]) {
+	w.writeEvent(0, 1) // AT(Id)
+	w.writeString(value.Id)
+	writeStringContent(w, value.Value)
+}
+
+func decodeIdentifiedString(r *bitReader, value *IdentifiedValue[string], element string) error {
+	var err error
+	if err = r.expectEvent(0, 1, element+" Id"); err != nil {
+		return err
+	}
+	if value.Id, err = r.readString(); err != nil {
+		return fmt.Errorf("reading %s Id: %w", element, err)
+	}
+	value.Value, err = readStringContent(r, element)
+	return err
+}
+
+func encodeSignature(w *bitWriter, sig *Signature) {
+	if sig.Id != nil {
+		w.writeEvent(0, 2) // AT(Id)
+		w.writeString(*sig.Id)
+		w.writeEvent(0, 1) // SE(SignedInfo)
+	} else {
+		w.writeEvent(1, 2) // SE(SignedInfo)
+	}
+	encodeSignedInfo(w, &sig.SignedInfo)
+	w.writeEvent(0, 1) // SE(SignatureValue)
+	w.writeEvent(1, 2) // CH
+	w.writeBinary(sig.SignatureValue)
+	w.writeEvent(0, 1) // EE
+	w.writeEvent(2, 3) // EE
+}
+
+func decodeSignature(r *bitReader, sig *Signature) error {
+	code, err := r.readEvent(2)
+	if err != nil {
+		return fmt.Errorf("reading Signature: %w", err)
+	}
+	if code == 0 {
+		id, err := r.readString()
+		if err != nil {
+			return fmt.Errorf("reading Signature Id: %w", err)
+		}
+		sig.Id = &id
+		if err = r.expectEvent(0, 1, "SignedInfo"); err != nil {
+			return err
+		}
+	}
+	if err = decodeSignedInfo(r, &sig.SignedInfo); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "SignatureValue"); err != nil {
+		return err
+	}
+	code, err = r.readEvent(2)
+	if err != nil {
+		return fmt.Errorf("reading SignatureValue: %w", err)
+	}
+	if code == 0 {
+		// the Id of the signature value is never referenced, so it is not kept
+		if _, err = r.readString(); err != nil {
+			return fmt.Errorf("reading SignatureValue Id: %w", err)
+		}
+		if err = r.expectEvent(0, 1, "SignatureValue content"); err != nil {
+			return err
+		}
+	}
+	if sig.SignatureValue, err = r.readBinary(); err != nil {
+		return fmt.Errorf("reading SignatureValue: %w", err)
+	}
+	if err = r.expectEvent(0, 1, "end of SignatureValue"); err != nil {
+		return err
+	}
+	code, err = r.readEvent(3)
+	if err != nil {
+		return fmt.Errorf("reading Signature: %w", err)
+	}
+	if code != 2 {
+		return errors.New("reading Signature: KeyInfo and Object are not supported")
+	}
+	return nil
+}
+
+func encodeSignedInfo(w *bitWriter, info *SignedInfo) {
+	if info.Id != nil {
+		w.writeEvent(0, 2) // AT(Id)
+		w.writeString(*info.Id)
+		w.writeEvent(0, 1) // SE(CanonicalizationMethod)
+	} else {
+		w.writeEvent(1, 2) // SE(CanonicalizationMethod)
+	}
+	encodeAlgorithm(w, info.CanonicalizationMethod, 1, 3)
+	w.writeEvent(0, 1) // SE(SignatureMethod)
+	encodeAlgorithm(w, info.SignatureMethod, 2, 4)
+	w.writeRepeated(len(info.References), 0, func(i int) {
+		encodeReference(w, &info.References[i])
+	})
+}
+
+func decodeSignedInfo(r *bitReader, info *SignedInfo) error {
+	code, err := r.readEvent(2)
+	if err != nil {
+		return fmt.Errorf("reading SignedInfo: %w", err)
+	}
+	if code == 0 {
+		id, err := r.readString()
+		if err != nil {
+			return fmt.Errorf("reading SignedInfo Id: %w", err)
+		}
+		info.Id = &id
+		if err = r.expectEvent(0, 1, "CanonicalizationMethod"); err != nil {
+			return err
+		}
+	}
+	if info.CanonicalizationMethod, err = decodeAlgorithm(r, 1, 3, "CanonicalizationMethod"); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "SignatureMethod"); err != nil {
+		return err
+	}
+	if info.SignatureMethod, err = decodeAlgorithm(r, 2, 4, "SignatureMethod"); err != nil {
+		return err
+	}
+	return r.readRepeated(0, "Reference", func() error {
+		var ref Reference
+		if err := decodeReference(r, &ref); err != nil {
+			return err
+		}
+		info.References = append(info.References, ref)
+		return nil
+	})
+}
+
+// encodeAlgorithm encodes an element with an Algorithm attribute and no content. These elements
+// allow any content, so the end of the element is one of several events.
+func encodeAlgorithm(w *bitWriter, algorithm string, endCode, count int) {
+	w.writeEvent(0, 1) // AT(Algorithm)
+	w.writeString(algorithm)
+	w.writeEvent(endCode, count)
+}
+
+func decodeAlgorithm(r *bitReader, endCode, count int, element string) (string, error) {
+	if err := r.expectEvent(0, 1, element+" Algorithm"); err != nil {
+		return "", err
+	}
+	algorithm, err := r.readString()
+	if err != nil {
+		return "", fmt.Errorf("reading %s Algorithm: %w", element, err)
+	}
+	code, err := r.readEvent(count)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", element, err)
+	}
+	if code != endCode {
+		return "", fmt.Errorf("reading %s: content is not supported", element)
+	}
+	return algorithm, nil
+}
+
+func encodeReference(w *bitWriter, ref *Reference) {
+	// the optional attributes Id, Type and URI are followed by Transforms and DigestMethod
+	next := 0
+	for i, attr := range []*string{ref.Id, ref.Type, ref.URI} {
+		if attr != nil {
+			w.writeEvent(i-next, 5-next)
+			w.writeString(*attr)
+			next = i + 1
+		}
+	}
+	if len(ref.Transforms) > 0 {
+		w.writeEvent(3-next, 5-next) // SE(Transforms)
+		w.writeRepeated(len(ref.Transforms), 0, func(i int) {
+			encodeAlgorithm(w, ref.Transforms[i], 2, 4)
+		})
+		w.writeEvent(0, 1) // SE(DigestMethod)
+	} else {
+		w.writeEvent(4-next, 5-next) // SE(DigestMethod)
+	}
+	encodeAlgorithm(w, ref.DigestMethod, 1, 3)
+	w.writeEvent(0, 1) // SE(DigestValue)
+	writeBinaryContent(w, ref.DigestValue)
+	w.writeEvent(0, 1) // EE
+}
+
+func decodeReference(r *bitReader, ref *Reference) error {
+	attrs := []**string{&ref.Id, &ref.Type, &ref.URI}
+	next := 0
+	for {
+		code, err := r.readEvent(5 - next)
+		if err != nil {
+			return fmt.Errorf("reading Reference: %w", err)
+		}
+		index := next + code
+		if index < len(attrs) {
+			value, err := r.readString()
+			if err != nil {
+				return fmt.Errorf("reading Reference attribute: %w", err)
+			}
+			*attrs[index] = &value
+			next = index + 1
+			continue
+		}
+		if index == 3 {
+			err = r.readRepeated(0, "Transform", func() error {
+				algorithm, err := decodeAlgorithm(r, 2, 4, "Transform")
+				if err != nil {
+					return err
+				}
+				ref.Transforms = append(ref.Transforms, algorithm)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if err = r.expectEvent(0, 1, "DigestMethod"); err != nil {
+				return err
+			}
+		}
+		break
+	}
+	var err error
+	if ref.DigestMethod, err = decodeAlgorithm(r, 1, 3, "DigestMethod"); err != nil {
+		return err
+	}
+	if err = r.expectEvent(0, 1, "DigestValue"); err != nil {
+		return err
+	}
+	if ref.DigestValue, err = readBinaryContent(r, "DigestValue"); err != nil {
+		return err
+	}
+	return r.expectEvent(0, 1, "end of Reference")
+}
+
+// writeBinaryContent writes the content and end of an element with a binary value
+func writeBinaryContent(w *bitWriter, value []byte) {
+	w.writeEvent(0, 1) // CH
+	w.writeBinary(value)
+	w.writeEvent(0, 1) // EE
+}
+
+func readBinaryContent(r *bitReader, element string) ([]byte, error) {
+	if err := r.expectEvent(0, 1, element+" content"); err != nil {
+		return nil, err
+	}
+	value, err := r.readBinary()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", element, err)
+	}
+	return value, r.expectEvent(0, 1, "end of "+element)
+}
+
+// writeStringContent writes the content and end of an element with a string value
+func writeStringContent(w *bitWriter, value string) {
+	w.writeEvent(0, 1) // CH
+	w.writeString(value)
+	w.writeEvent(0, 1) // EE
+}
+
+func readStringContent(r *bitReader, element string) (string, error) {
+	if err := r.expectEvent(0, 1, element+" content"); err != nil {
+		return "", err
+	}
+	value, err := r.readString()
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", element, err)
+	}
+	return value, r.expectEvent(0, 1, "end of "+element)
+}
@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package iso15118_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/iso15118"
+)
+
+func makeStringPtr(s string) *string {
+	return &s
+}
+
+func TestEncodeDecodeCertificateInstallationReq(t *testing.T) {
+	msg := &iso15118.V2GMessage{
+		Header: iso15118.MessageHeader{
+			SessionID: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		},
+		Body: iso15118.Body{
+			CertificateInstallationReq: &iso15118.CertificateInstallationReq{
+				Id:                  "id1",
+				OEMProvisioningCert: []byte{0x30, 0x82, 0x01, 0x02},
+				ListOfRootCertificateIDs: []iso15118.X509IssuerSerial{
+					{
+						X509IssuerName:   "CN=V2G Root CA,O=Thoughtworks,C=GB",
+						X509SerialNumber: big.NewInt(12345678901),
+					},
+					{
+						X509IssuerName:   "CN=MO Root CA,O=Thoughtworks,C=GB",
+						X509SerialNumber: big.NewInt(-2),
+					},
+				},
+			},
+		},
+	}
+
+	data, err := iso15118.EncodeV2GMessage(msg)
+	require.NoError(t, err)
+
+	// EXI header, SE(V2G_Message), SE(Header), SE(SessionID), CH and the session id length
+	assert.Equal(t, []byte{0x80, 0x98, 0x02}, data[:3])
+
+	decoded, err := iso15118.DecodeV2GMessage(data)
+	require.NoError(t, err)
+	assert.Equal(t, msg, decoded)
+}
+
+func TestEncodeDecodeCertificateInstallationReqWithMaximumRootCertificateIDs(t *testing.T) {
+	var ids []iso15118.X509IssuerSerial
+	for i := 0; i < 20; i++ {
+		ids = append(ids, iso15118.X509IssuerSerial{
+			X509IssuerName:   "CN=Root CA",
+			X509SerialNumber: big.NewInt(int64(i)),
+		})
+	}
+	msg := &iso15118.V2GMessage{
+		Header: iso15118.MessageHeader{
+			SessionID: []byte{0x01},
+		},
+		Body: iso15118.Body{
+			CertificateInstallationReq: &iso15118.CertificateInstallationReq{
+				Id:                       "id1",
+				OEMProvisioningCert:      []byte{0x30},
+				ListOfRootCertificateIDs: ids,
+			},
+		},
+	}
+
+	data, err := iso15118.EncodeV2GMessage(msg)
+	require.NoError(t, err)
+
+	decoded, err := iso15118.DecodeV2GMessage(data)
+	require.NoError(t, err)
+	assert.Equal(t, msg, decoded)
+
+	msg.Body.CertificateInstallationReq.ListOfRootCertificateIDs = append(ids, ids[0])
+	_, err = iso15118.EncodeV2GMessage(msg)
+	assert.Error(t, err)
+}
+
+func createCertificateInstallationRes() *iso15118.V2GMessage {
+	return &iso15118.V2GMessage{
+		Header: iso15118.MessageHeader{
+			SessionID: []byte{0xAB, 0xCD},
+		},
+		Body: iso15118.Body{
+			CertificateInstallationRes: &iso15118.CertificateInstallationRes{
+				ResponseCode: iso15118.ResponseCodeOK,
+				SAProvisioningCertificateChain: iso15118.CertificateChain{
+					Certificate:     []byte("cps-leaf"),
+					SubCertificates: [][]byte{[]byte("cps-sub-ca-2"), []byte("cps-sub-ca-1")},
+				},
+				ContractSignatureCertChain: iso15118.CertificateChain{
+					Id:              makeStringPtr("id1"),
+					Certificate:     []byte("contract-leaf"),
+					SubCertificates: [][]byte{[]byte("mo-sub-ca-2"), []byte("mo-sub-ca-1")},
+				},
+				ContractSignatureEncryptedPrivateKey: iso15118.IdentifiedValue[[]byte]{
+					Id:    "id2",
+					Value: []byte("encrypted-private-key"),
+				},
+				DHpublickey: iso15118.IdentifiedValue[[]byte]{
+					Id:    "id3",
+					Value: []byte("dh-public-key"),
+				},
+				EMAID: iso15118.IdentifiedValue[string]{
+					Id:    "id4",
+					Value: "GBTWKC123456789",
+				},
+			},
+		},
+	}
+}
+
+func TestEncodeDecodeCertificateInstallationRes(t *testing.T) {
+	msg := createCertificateInstallationRes()
+	msg.Header.Notification = &iso15118.Notification{
+		FaultCode: iso15118.FaultCodeUnknownError,
+		FaultMsg:  makeStringPtr("something went wrong"),
+	}
+	msg.Header.Signature = &iso15118.Signature{
+		Id: makeStringPtr("sig"),
+		SignedInfo: iso15118.SignedInfo{
+			CanonicalizationMethod: iso15118.CanonicalizationMethodEXI,
+			SignatureMethod:        iso15118.SignatureMethodECDSASHA256,
+			References: []iso15118.Reference{
+				{
+					URI:          makeStringPtr("#id1"),
+					Transforms:   []string{iso15118.CanonicalizationMethodEXI},
+					DigestMethod: iso15118.DigestMethodSHA256,
+					DigestValue:  []byte{0x01, 0x02},
+				},
+				{
+					Id:           makeStringPtr("ref"),
+					Type:         makeStringPtr("type"),
+					DigestMethod: iso15118.DigestMethodSHA256,
+					DigestValue:  []byte{0x03, 0x04},
+				},
+			},
+		},
+		SignatureValue: []byte{0x05, 0x06},
+	}
+
+	data, err := iso15118.EncodeV2GMessage(msg)
+	require.NoError(t, err)
+
+	decoded, err := iso15118.DecodeV2GMessage(data)
+	require.NoError(t, err)
+	assert.Equal(t, msg, decoded)
+}
+
+func TestSignAndVerifyCertificateInstallationRes(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	msg := createCertificateInstallationRes()
+	err = iso15118.SignCertificateInstallationRes(msg, key)
+	require.NoError(t, err)
+
+	require.NotNil(t, msg.Header.Signature)
+	assert.Len(t, msg.Header.Signature.SignatureValue, 64)
+	require.Len(t, msg.Header.Signature.SignedInfo.References, 4)
+	assert.Equal(t, "#id1", *msg.Header.Signature.SignedInfo.References[0].URI)
+	assert.Equal(t, "#id4", *msg.Header.Signature.SignedInfo.References[3].URI)
+
+	data, err := iso15118.EncodeV2GMessage(msg)
+	require.NoError(t, err)
+	decoded, err := iso15118.DecodeV2GMessage(data)
+	require.NoError(t, err)
+
+	err = iso15118.VerifyCertificateInstallationRes(decoded, &key.PublicKey)
+	assert.NoError(t, err)
+}
+
+func TestVerifyCertificateInstallationResWithModifiedElement(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	msg := createCertificateInstallationRes()
+	err = iso15118.SignCertificateInstallationRes(msg, key)
+	require.NoError(t, err)
+
+	msg.Body.CertificateInstallationRes.EMAID.Value = "GBTWKC987654321"
+
+	err = iso15118.VerifyCertificateInstallationRes(msg, &key.PublicKey)
+	assert.ErrorContains(t, err, "digest of id4 does not match")
+}
+
+func TestVerifyCertificateInstallationResWithWrongKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	msg := createCertificateInstallationRes()
+	err = iso15118.SignCertificateInstallationRes(msg, key)
+	require.NoError(t, err)
+
+	err = iso15118.VerifyCertificateInstallationRes(msg, &otherKey.PublicKey)
+	assert.ErrorContains(t, err, "invalid signature")
+}
+
+func TestDecodeV2GMessageWithInvalidHeader(t *testing.T) {
+	_, err := iso15118.DecodeV2GMessage([]byte{0x00, 0x98, 0x02})
+	assert.ErrorContains(t, err, "unsupported exi header")
+}
+
+func TestDecodeV2GMessageWithTruncatedStream(t *testing.T) {
+	data, err := iso15118.EncodeV2GMessage(createCertificateInstallationRes())
+	require.NoError(t, err)
+
+	_, err = iso15118.DecodeV2GMessage(data[:len(data)/2])
+	assert.ErrorContains(t, err, "unexpected end of exi stream")
+}
+
+// TestDecodeReferenceVectors decodes messages encoded by a reference ISO 15118-2 stack, see
+// testdata/reference/README.md, and checks that they are re-encoded to the same bytes
+func TestDecodeReferenceVectors(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "reference", "*.exi"))
+	require.NoError(t, err)
+	if len(files) == 0 {
+		t.Skip("no reference vectors in testdata/reference")
+	}
+
+	for _, file := range files {
+		name := filepath.Base(file)
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			msg, err := iso15118.DecodeV2GMessage(data)
+			require.NoError(t, err)
+			switch {
+			case strings.HasPrefix(name, "CertificateInstallationReq"):
+				assert.NotNil(t, msg.Body.CertificateInstallationReq)
+			case strings.HasPrefix(name, "CertificateInstallationRes"):
+				assert.NotNil(t, msg.Body.CertificateInstallationRes)
+			default:
+				t.Fatalf("unexpected reference vector %s", name)
+			}
+
+			encoded, err := iso15118.EncodeV2GMessage(msg)
+			require.NoError(t, err)
+			assert.Equal(t, data, encoded)
+		})
+	}
+}
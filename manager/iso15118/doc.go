@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package iso15118 encodes and decodes the ISO 15118-2 messages that are exchanged with
+// an EV through the charge station, such as the certificate installation request and
+// response. Messages are encoded as schema-informed, bit-packed EXI streams without
+// using the string table.
+package iso15118
@@ -0,0 +1,289 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package iso15118
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"unicode/utf8"
+)
+
+// exiHeader is the EXI header used by ISO 15118-2: distinguishing bits, no options and
+// version 1 of the final EXI format
+const exiHeader = 0x80
+
+var ErrUnsupportedEvent = errors.New("unsupported exi event")
+
+// codeLength is the number of bits used for an event code in a state with count
+// first level events. Every element grammar state used by ISO 15118-2 has at least
+// one second level event (the grammars are not strict), so the event code has count+1
+// possible values.
+func codeLength(count int) int {
+	return bits.Len(uint(count))
+}
+
+type bitWriter struct {
+	buf  []byte
+	used int // bits used in the last byte of buf
+}
+
+func newBitWriter() *bitWriter {
+	w := &bitWriter{}
+	w.writeBits(8, exiHeader)
+	return w
+}
+
+func (w *bitWriter) writeBits(n int, value uint64) {
+	for i := n - 1; i >= 0; i-- {
+		if w.used == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if value&(1<<uint(i)) != 0 {
+			w.buf[len(w.buf)-1] |= 1 << uint(7-w.used)
+		}
+		w.used = (w.used + 1) % 8
+	}
+}
+
+// writeEvent writes the event code for a state with count first level events
+func (w *bitWriter) writeEvent(code, count int) {
+	w.writeBits(codeLength(count), uint64(code))
+}
+
+// writeDocumentEvent writes an event code for a document or fragment grammar state, which
+// have no second level events when comments and processing instructions are not preserved
+func (w *bitWriter) writeDocumentEvent(code, count int) {
+	w.writeBits(bits.Len(uint(count-1)), uint64(code))
+}
+
+func (w *bitWriter) writeBool(value bool) {
+	if value {
+		w.writeBits(1, 1)
+	} else {
+		w.writeBits(1, 0)
+	}
+}
+
+func (w *bitWriter) writeUnsigned(value uint64) {
+	for {
+		b := value & 0x7f
+		value >>= 7
+		if value != 0 {
+			w.writeBits(8, b|0x80)
+		} else {
+			w.writeBits(8, b)
+			return
+		}
+	}
+}
+
+func (w *bitWriter) writeUnsignedBig(value *big.Int) {
+	v := new(big.Int).Set(value)
+	mask := big.NewInt(0x7f)
+	for {
+		b := new(big.Int).And(v, mask).Uint64()
+		v.Rsh(v, 7)
+		if v.Sign() != 0 {
+			w.writeBits(8, b|0x80)
+		} else {
+			w.writeBits(8, b)
+			return
+		}
+	}
+}
+
+func (w *bitWriter) writeInteger(value *big.Int) {
+	if value.Sign() < 0 {
+		w.writeBool(true)
+		magnitude := new(big.Int).Neg(value)
+		w.writeUnsignedBig(magnitude.Sub(magnitude, big.NewInt(1)))
+	} else {
+		w.writeBool(false)
+		w.writeUnsignedBig(value)
+	}
+}
+
+func (w *bitWriter) writeBinary(value []byte) {
+	w.writeUnsigned(uint64(len(value)))
+	for _, b := range value {
+		w.writeBits(8, uint64(b))
+	}
+}
+
+// writeString writes a string value as a string table miss. ISO 15118-2 codecs do not
+// add values to the string table, so a value is never written as a string table hit.
+func (w *bitWriter) writeString(value string) {
+	w.writeUnsigned(uint64(utf8.RuneCountInString(value)) + 2)
+	for _, r := range value {
+		w.writeUnsigned(uint64(r))
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	return w.buf
+}
+
+type bitReader struct {
+	buf []byte
+	pos int // in bits
+}
+
+func newBitReader(data []byte) (*bitReader, error) {
+	r := &bitReader{buf: data}
+	header, err := r.readBits(8)
+	if err != nil {
+		return nil, err
+	}
+	if header != exiHeader {
+		return nil, fmt.Errorf("unsupported exi header: %#x", header)
+	}
+	return r, nil
+}
+
+func (r *bitReader) readBits(n int) (uint64, error) {
+	if r.pos+n > len(r.buf)*8 {
+		return 0, errors.New("unexpected end of exi stream")
+	}
+	var value uint64
+	for i := 0; i < n; i++ {
+		b := r.buf[r.pos/8]
+		value <<= 1
+		if b&(1<<uint(7-r.pos%8)) != 0 {
+			value |= 1
+		}
+		r.pos++
+	}
+	return value, nil
+}
+
+// readEvent reads the event code for a state with count first level events. Second level
+// events, which are used to represent content that deviates from the schema, are not supported.
+func (r *bitReader) readEvent(count int) (int, error) {
+	code, err := r.readBits(codeLength(count))
+	if err != nil {
+		return 0, err
+	}
+	if int(code) >= count {
+		return 0, ErrUnsupportedEvent
+	}
+	return int(code), nil
+}
+
+// expectEvent reads an event code and checks that it is the expected one
+func (r *bitReader) expectEvent(code, count int, event string) error {
+	got, err := r.readEvent(count)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", event, err)
+	}
+	if got != code {
+		return fmt.Errorf("reading %s: unexpected event code %d", event, got)
+	}
+	return nil
+}
+
+func (r *bitReader) readDocumentEvent(count int) (int, error) {
+	code, err := r.readBits(bits.Len(uint(count - 1)))
+	if err != nil {
+		return 0, err
+	}
+	if int(code) >= count {
+		return 0, ErrUnsupportedEvent
+	}
+	return int(code), nil
+}
+
+func (r *bitReader) readBool() (bool, error) {
+	b, err := r.readBits(1)
+	return b == 1, err
+}
+
+func (r *bitReader) readUnsigned() (uint64, error) {
+	var value uint64
+	for shift := 0; ; shift += 7 {
+		if shift > 63 {
+			return 0, errors.New("exi unsigned integer too large")
+		}
+		b, err := r.readBits(8)
+		if err != nil {
+			return 0, err
+		}
+		value |= (b & 0x7f) << uint(shift)
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+}
+
+func (r *bitReader) readUnsignedBig() (*big.Int, error) {
+	value := new(big.Int)
+	for shift := uint(0); ; shift += 7 {
+		b, err := r.readBits(8)
+		if err != nil {
+			return nil, err
+		}
+		value.Or(value, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+}
+
+func (r *bitReader) readInteger() (*big.Int, error) {
+	negative, err := r.readBool()
+	if err != nil {
+		return nil, err
+	}
+	value, err := r.readUnsignedBig()
+	if err != nil {
+		return nil, err
+	}
+	if negative {
+		value.Add(value, big.NewInt(1))
+		value.Neg(value)
+	}
+	return value, nil
+}
+
+func (r *bitReader) readBinary() ([]byte, error) {
+	length, err := r.readUnsigned()
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(len(r.buf)) {
+		return nil, fmt.Errorf("exi binary value too long: %d", length)
+	}
+	value := make([]byte, length)
+	for i := range value {
+		b, err := r.readBits(8)
+		if err != nil {
+			return nil, err
+		}
+		value[i] = byte(b)
+	}
+	return value, nil
+}
+
+func (r *bitReader) readString() (string, error) {
+	length, err := r.readUnsigned()
+	if err != nil {
+		return "", err
+	}
+	if length < 2 {
+		return "", errors.New("exi string table hits are not supported")
+	}
+	length -= 2
+	if length > uint64(len(r.buf)) {
+		return "", fmt.Errorf("exi string value too long: %d", length)
+	}
+	runes := make([]rune, length)
+	for i := range runes {
+		c, err := r.readUnsigned()
+		if err != nil {
+			return "", err
+		}
+		runes[i] = rune(c)
+	}
+	return string(runes), nil
+}
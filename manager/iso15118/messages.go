@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package iso15118
+
+import "math/big"
+
+// V2GMessage is an ISO 15118-2 message. Only the message bodies that the CSMS
+// handles on behalf of the charge station are supported.
+type V2GMessage struct {
+	Header MessageHeader
+	Body   Body
+}
+
+type MessageHeader struct {
+	SessionID    []byte
+	Notification *Notification
+	Signature    *Signature
+}
+
+type FaultCode int
+
+const (
+	FaultCodeParsingError FaultCode = iota
+	FaultCodeNoTLSRootCertificateAvailable
+	FaultCodeUnknownError
+)
+
+const faultCodeCount = 3
+
+type Notification struct {
+	FaultCode FaultCode
+	FaultMsg  *string
+}
+
+type Body struct {
+	CertificateInstallationReq *CertificateInstallationReq
+	CertificateInstallationRes *CertificateInstallationRes
+}
+
+type CertificateInstallationReq struct {
+	Id                       string
+	OEMProvisioningCert      []byte
+	ListOfRootCertificateIDs []X509IssuerSerial
+}
+
+type X509IssuerSerial struct {
+	X509IssuerName   string
+	X509SerialNumber *big.Int
+}
+
+type ResponseCode int
+
+// The response codes in the order they are declared in the schema
+const (
+	ResponseCodeOK ResponseCode = iota
+	ResponseCodeOKNewSessionEstablished
+	ResponseCodeOKOldSessionJoined
+	ResponseCodeOKCertificateExpiresSoon
+	ResponseCodeFailed
+	ResponseCodeFailedSequenceError
+	ResponseCodeFailedServiceIDInvalid
+	ResponseCodeFailedUnknownSession
+	ResponseCodeFailedServiceSelectionInvalid
+	ResponseCodeFailedPaymentSelectionInvalid
+	ResponseCodeFailedCertificateExpired
+	ResponseCodeFailedSignatureError
+	ResponseCodeFailedNoCertificateAvailable
+	ResponseCodeFailedCertChainError
+	ResponseCodeFailedChallengeInvalid
+	ResponseCodeFailedContractCanceled
+	ResponseCodeFailedWrongChargeParameter
+	ResponseCodeFailedPowerDeliveryNotApplied
+	ResponseCodeFailedTariffSelectionInvalid
+	ResponseCodeFailedChargingProfileInvalid
+	ResponseCodeFailedMeteringSignatureNotValid
+	ResponseCodeFailedNoChargeServiceSelected
+	ResponseCodeFailedWrongEnergyTransferMode
+	ResponseCodeFailedContactorError
+	ResponseCodeFailedCertificateNotAllowedAtThisEVSE
+	ResponseCodeFailedCertificateRevoked
+)
+
+const responseCodeCount = 26
+
+type CertificateInstallationRes struct {
+	ResponseCode                         ResponseCode
+	SAProvisioningCertificateChain       CertificateChain
+	ContractSignatureCertChain           CertificateChain
+	ContractSignatureEncryptedPrivateKey IdentifiedValue[[]byte]
+	DHpublickey                          IdentifiedValue[[]byte]
+	EMAID                                IdentifiedValue[string]
+}
+
+type CertificateChain struct {
+	Id              *string
+	Certificate     []byte
+	SubCertificates [][]byte
+}
+
+// IdentifiedValue is a simple value with an Id attribute, so it can be referenced from a signature
+type IdentifiedValue[T any] struct {
+	Id    string
+	Value T
+}
+
+// Signature is an XML signature that is encoded as EXI
+type Signature struct {
+	Id             *string
+	SignedInfo     SignedInfo
+	SignatureValue []byte
+}
+
+type SignedInfo struct {
+	Id                     *string
+	CanonicalizationMethod string
+	SignatureMethod        string
+	References             []Reference
+}
+
+type Reference struct {
+	Id           *string
+	Type         *string
+	URI          *string
+	Transforms   []string
+	DigestMethod string
+	DigestValue  []byte
+}
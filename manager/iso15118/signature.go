@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package iso15118
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	CanonicalizationMethodEXI  = "http://www.w3.org/TR/canonical-exi/"
+	SignatureMethodECDSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	DigestMethodSHA256         = "http://www.w3.org/2001/04/xmlenc#sha256"
+)
+
+type signedElement struct {
+	id       string
+	fragment []byte
+}
+
+// certificateInstallationResSignedElements returns the elements of the response that are signed by
+// the contract certificate pool, encoded as EXI fragments
+func certificateInstallationResSignedElements(res *CertificateInstallationRes) ([]signedElement, error) {
+	if res.ContractSignatureCertChain.Id == nil {
+		return nil, errors.New("contract signature certificate chain must have an id")
+	}
+
+	var encodeErr error
+	chain := encodeFragment(fragmentContractSignatureCertChain, func(w *bitWriter) {
+		encodeErr = encodeCertificateChain(w, &res.ContractSignatureCertChain)
+	})
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+	privateKey := encodeFragment(fragmentContractSignatureEncryptedPrivKey, func(w *bitWriter) {
+		encodeIdentifiedBinary(w, &res.ContractSignatureEncryptedPrivateKey)
+	})
+	dhPublicKey := encodeFragment(fragmentDHpublickey, func(w *bitWriter) {
+		encodeIdentifiedBinary(w, &res.DHpublickey)
+	})
+	// eMAID is declared with more than one type, so the fragment uses the relaxed element
+	// fragment grammar: AT(Id), AT(*), SE(*), EE, CH and then SE(*), EE, CH
+	eMAID := encodeFragment(fragmentEMAID, func(w *bitWriter) {
+		w.writeEvent(0, 5) // AT(Id)
+		w.writeString(res.EMAID.Id)
+		w.writeEvent(4, 5) // CH
+		w.writeString(res.EMAID.Value)
+		w.writeEvent(1, 3) // EE
+	})
+
+	return []signedElement{
+		{id: *res.ContractSignatureCertChain.Id, fragment: chain},
+		{id: res.ContractSignatureEncryptedPrivateKey.Id, fragment: privateKey},
+		{id: res.DHpublickey.Id, fragment: dhPublicKey},
+		{id: res.EMAID.Id, fragment: eMAID},
+	}, nil
+}
+
+func signedInfoDigest(info *SignedInfo) []byte {
+	fragment := encodeFragment(fragmentSignedInfo, func(w *bitWriter) {
+		encodeSignedInfo(w, info)
+	})
+	digest := sha256.Sum256(fragment)
+	return digest[:]
+}
+
+// SignCertificateInstallationRes adds a signature to the message header that covers the contract
+// certificate chain, the encrypted private key, the Diffie-Hellman public key and the eMAID
+func SignCertificateInstallationRes(msg *V2GMessage, signer crypto.Signer) error {
+	res := msg.Body.CertificateInstallationRes
+	if res == nil {
+		return errors.New("message is not a certificate installation response")
+	}
+	publicKey, ok := signer.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("signing key must be an ecdsa key")
+	}
+
+	elements, err := certificateInstallationResSignedElements(res)
+	if err != nil {
+		return err
+	}
+
+	signedInfo := SignedInfo{
+		CanonicalizationMethod: CanonicalizationMethodEXI,
+		SignatureMethod:        SignatureMethodECDSASHA256,
+	}
+	for _, element := range elements {
+		uri := "#" + element.id
+		digest := sha256.Sum256(element.fragment)
+		signedInfo.References = append(signedInfo.References, Reference{
+			URI:          &uri,
+			Transforms:   []string{CanonicalizationMethodEXI},
+			DigestMethod: DigestMethodSHA256,
+			DigestValue:  digest[:],
+		})
+	}
+
+	derSignature, err := signer.Sign(rand.Reader, signedInfoDigest(&signedInfo), crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("signing certificate installation response: %w", err)
+	}
+	var ecdsaSignature struct {
+		R, S *big.Int
+	}
+	if _, err = asn1.Unmarshal(derSignature, &ecdsaSignature); err != nil {
+		return fmt.Errorf("parsing signature: %w", err)
+	}
+
+	// the signature value is the concatenation of r and s, each the size of the curve order
+	size := (publicKey.Curve.Params().N.BitLen() + 7) / 8
+	signatureValue := make([]byte, 2*size)
+	ecdsaSignature.R.FillBytes(signatureValue[:size])
+	ecdsaSignature.S.FillBytes(signatureValue[size:])
+
+	msg.Header.Signature = &Signature{
+		SignedInfo:     signedInfo,
+		SignatureValue: signatureValue,
+	}
+
+	return nil
+}
+
+// VerifyCertificateInstallationRes checks that the signature in the message header covers the
+// signed elements of the certificate installation response and was created using the public key
+func VerifyCertificateInstallationRes(msg *V2GMessage, publicKey *ecdsa.PublicKey) error {
+	res := msg.Body.CertificateInstallationRes
+	if res == nil {
+		return errors.New("message is not a certificate installation response")
+	}
+	sig := msg.Header.Signature
+	if sig == nil {
+		return errors.New("message is not signed")
+	}
+
+	elements, err := certificateInstallationResSignedElements(res)
+	if err != nil {
+		return err
+	}
+	if len(sig.SignedInfo.References) != len(elements) {
+		return fmt.Errorf("expected %d references, got %d", len(elements), len(sig.SignedInfo.References))
+	}
+	for _, element := range elements {
+		var ref *Reference
+		for i := range sig.SignedInfo.References {
+			uri := sig.SignedInfo.References[i].URI
+			if uri != nil && strings.TrimPrefix(*uri, "#") == element.id {
+				ref = &sig.SignedInfo.References[i]
+				break
+			}
+		}
+		if ref == nil {
+			return fmt.Errorf("no reference to %s", element.id)
+		}
+		digest := sha256.Sum256(element.fragment)
+		if !bytes.Equal(digest[:], ref.DigestValue) {
+			return fmt.Errorf("digest of %s does not match", element.id)
+		}
+	}
+
+	size := (publicKey.Curve.Params().N.BitLen() + 7) / 8
+	if len(sig.SignatureValue) != 2*size {
+		return fmt.Errorf("signature value has length %d, expected %d", len(sig.SignatureValue), 2*size)
+	}
+	r := new(big.Int).SetBytes(sig.SignatureValue[:size])
+	s := new(big.Int).SetBytes(sig.SignatureValue[size:])
+	if !ecdsa.Verify(publicKey, signedInfoDigest(&sig.SignedInfo), r, s) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
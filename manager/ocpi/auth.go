@@ -4,6 +4,7 @@ package ocpi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/thoughtworks/maeve-csms/manager/store"
@@ -15,41 +16,74 @@ var authzHeaderRegexp = regexp.MustCompile(`(?i)^Token (.*)$`)
 
 func NewTokenAuthenticationFunc(engine store.Engine) openapi3filter.AuthenticationFunc {
 	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
-		authzHeader := input.RequestValidationInput.Request.Header.Get("Authorization")
-		matches := authzHeaderRegexp.FindStringSubmatch(authzHeader)
-		if len(matches) != 2 {
-			return input.NewError(nil)
-		}
-
-		reg, err := engine.GetRegistrationDetails(ctx, matches[1])
+		err := authenticateRequest(ctx, engine, input.RequestValidationInput.Request)
 		if err != nil {
+			if errors.Is(err, errMissingToken) {
+				return input.NewError(nil)
+			}
 			return input.NewError(err)
 		}
-		if reg == nil {
-			return input.NewError(fmt.Errorf("unknown token"))
-		}
-		if reg.Status != store.OcpiRegistrationStatusRegistered {
-			allowed := false
-			switch input.RequestValidationInput.Request.Method {
-			case http.MethodGet:
-				switch input.RequestValidationInput.Request.URL.Path {
-				case "/ocpi/versions":
-					allowed = true
-				case "/ocpi/2.2":
-					allowed = true
-				}
-			case http.MethodPost:
-				switch input.RequestValidationInput.Request.URL.Path {
-				case "/ocpi/2.2/credentials":
-					allowed = true
-				}
+		return nil
+	}
+}
+
+// NewTokenAuthenticationMiddleware applies the same token authentication as
+// NewTokenAuthenticationFunc to routes that are not described by the OCPI 2.2
+// OpenAPI specification (i.e. the OCPI 2.1.1 routes).
+func NewTokenAuthenticationMiddleware(engine store.Engine) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := authenticateRequest(r.Context(), engine, r)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
 			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-			if !allowed {
-				return input.NewError(fmt.Errorf("unregistered token"))
+var errMissingToken = errors.New("missing token")
+
+func authenticateRequest(ctx context.Context, engine store.Engine, req *http.Request) error {
+	authzHeader := req.Header.Get("Authorization")
+	matches := authzHeaderRegexp.FindStringSubmatch(authzHeader)
+	if len(matches) != 2 {
+		return errMissingToken
+	}
+
+	reg, err := engine.GetRegistrationDetails(ctx, matches[1])
+	if err != nil {
+		return err
+	}
+	if reg == nil {
+		return fmt.Errorf("unknown token")
+	}
+	if reg.Status != store.OcpiRegistrationStatusRegistered {
+		allowed := false
+		switch req.Method {
+		case http.MethodGet:
+			switch req.URL.Path {
+			case "/ocpi/versions":
+				allowed = true
+			case "/ocpi/2.2":
+				allowed = true
+			case "/ocpi/2.1.1":
+				allowed = true
+			}
+		case http.MethodPost:
+			switch req.URL.Path {
+			case "/ocpi/2.2/credentials":
+				allowed = true
+			case "/ocpi/2.1.1/credentials":
+				allowed = true
 			}
 		}
 
-		return nil
+		if !allowed {
+			return fmt.Errorf("unregistered token")
+		}
 	}
+
+	return nil
 }
@@ -38,6 +38,10 @@ func TestAuthenticationWithPendingToken(t *testing.T) {
 		{Path: "/ocpi/2.2/credentials", Method: http.MethodGet, Success: false},
 		{Path: "/ocpi/2.2/credentials", Method: http.MethodPut, Success: false},
 		{Path: "/ocpi/2.2/credentials", Method: http.MethodDelete, Success: false},
+		{Path: "/ocpi/2.1.1", Method: http.MethodGet, Success: true},
+		{Path: "/ocpi/2.1.1/credentials", Method: http.MethodPost, Success: true},
+		{Path: "/ocpi/2.1.1/credentials", Method: http.MethodGet, Success: false},
+		{Path: "/ocpi/2.1.1/tokens/GB/TWK/DEADBEEF", Method: http.MethodGet, Success: false},
 	}
 
 	for _, endpoint := range endpoints {
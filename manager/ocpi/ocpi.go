@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"net/http"
 )
@@ -20,11 +21,19 @@ type Api interface {
 	RegisterNewParty(ctx context.Context, url, token string) error
 	GetVersions(ctx context.Context) ([]Version, error)
 	GetVersion(ctx context.Context) (VersionDetail, error)
+	GetVersionV211(ctx context.Context) (VersionDetailV211, error)
 	SetCredentials(ctx context.Context, token string, credentials Credentials) error
+	SetCredentialsV211(ctx context.Context, token string, credentials CredentialsV211) error
 	SetToken(ctx context.Context, token Token) error
 	GetToken(ctx context.Context, countryCode string, partyID string, tokenUID string) (*Token, error)
 	PushLocation(ctx context.Context, location Location) error
+	PushSession(ctx context.Context, session Session, location Location) error
+	PushCdr(ctx context.Context, cdr CDR) error
 	GetChargeStationOcppVersion(ctx context.Context, csId string) (store.OcppVersion, error)
+	LookupEvseId(ctx context.Context, csId, evseUid string) (int, error)
+	NewReservationId(ctx context.Context, csId string) (int, error)
+	SetReservation(ctx context.Context, reservation *store.Reservation) error
+	LookupReservation(ctx context.Context, countryCode, partyId, reservationId string) (*store.Reservation, error)
 }
 
 type OCPI struct {
@@ -52,7 +61,11 @@ func (o *OCPI) GetVersions(context.Context) ([]Version, error) {
 	return []Version{
 		{
 			Url:     fmt.Sprintf("%s/ocpi/2.2", o.externalUrl),
-			Version: "2.2",
+			Version: Version22,
+		},
+		{
+			Url:     fmt.Sprintf("%s/ocpi/2.1.1", o.externalUrl),
+			Version: Version211,
 		},
 	}, nil
 }
@@ -76,32 +89,46 @@ func (o *OCPI) GetVersion(context.Context) (VersionDetail, error) {
 				Url:        fmt.Sprintf("%s/ocpi/receiver/2.2/tokens/", o.externalUrl),
 			},
 		},
-		Version: "2.2",
+		Version: Version22,
+	}, nil
+}
+
+func (o *OCPI) GetVersionV211(context.Context) (VersionDetailV211, error) {
+	return VersionDetailV211{
+		Endpoints: []EndpointV211{
+			{
+				Identifier: "credentials",
+				Url:        fmt.Sprintf("%s/ocpi/2.1.1/credentials", o.externalUrl),
+			},
+			{
+				Identifier: "tokens",
+				Url:        fmt.Sprintf("%s/ocpi/2.1.1/tokens/", o.externalUrl),
+			},
+		},
+		Version: Version211,
 	}, nil
 }
 
 func (o *OCPI) SetCredentials(ctx context.Context, token string, credentials Credentials) error {
-	for _, role := range credentials.Roles {
-		err := o.store.SetPartyDetails(ctx, &store.OcpiParty{
-			Role:        string(role.Role),
-			CountryCode: role.CountryCode,
-			PartyId:     role.PartyId,
-			Url:         credentials.Url,
-			Token:       credentials.Token,
-		})
-		if err != nil {
-			return err
-		}
-	}
+	return o.setCredentials(ctx, token, Version22, credentials)
+}
 
+// SetCredentialsV211 stores the credentials of a party that registered using
+// OCPI 2.1.1. Only eMSPs are expected to talk to the CPO using 2.1.1.
+func (o *OCPI) SetCredentialsV211(ctx context.Context, token string, credentials CredentialsV211) error {
+	return o.setCredentials(ctx, token, Version211, credentials.ToCredentials(CredentialsRoleRoleEMSP))
+}
+
+func (o *OCPI) setCredentials(ctx context.Context, token, version string, credentials Credentials) error {
 	reg, err := o.store.GetRegistrationDetails(ctx, token)
 	if err != nil {
 		return err
 	}
 
 	if reg != nil && reg.Status == store.OcpiRegistrationStatusPending {
-		// register new party
-		err = o.RegisterNewParty(ctx, credentials.Url, credentials.Token)
+		// register new party: the party is then used with the version negotiated during
+		// registration rather than the version of the credentials endpoint that it called
+		version, _, err = o.registerNewParty(ctx, credentials.Url, credentials.Token)
 		if err != nil {
 			return err
 		}
@@ -112,6 +139,11 @@ func (o *OCPI) SetCredentials(ctx context.Context, token string, credentials Cre
 		}
 	}
 
+	err = o.setPartyDetails(ctx, version, credentials)
+	if err != nil {
+		return err
+	}
+
 	// store new token
 	err = o.store.SetRegistrationDetails(ctx, credentials.Token, &store.OcpiRegistration{
 		Status: store.OcpiRegistrationStatusRegistered,
@@ -123,6 +155,23 @@ func (o *OCPI) SetCredentials(ctx context.Context, token string, credentials Cre
 	return nil
 }
 
+func (o *OCPI) setPartyDetails(ctx context.Context, version string, credentials Credentials) error {
+	for _, role := range credentials.Roles {
+		err := o.store.SetPartyDetails(ctx, &store.OcpiParty{
+			Role:        string(role.Role),
+			CountryCode: role.CountryCode,
+			PartyId:     role.PartyId,
+			Url:         credentials.Url,
+			Token:       credentials.Token,
+			Version:     version,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (o *OCPI) GetToken(ctx context.Context, countryCode string, partyID string, tokenUID string) (*Token, error) {
 	tok, err := o.store.LookupToken(ctx, tokenUID)
 	if err != nil {
@@ -181,55 +230,139 @@ func (o *OCPI) PushLocation(ctx context.Context, location Location) error {
 }
 
 func (o *OCPI) pushLocationToParty(ctx context.Context, party *store.OcpiParty, location Location) error {
-	// TODO: retrieve endpoints from store, not via OCPI exchange
-	versions, err := o.getVersions(ctx, party.Url, party.Token)
+	version, endpoints, err := o.getPartyEndpoints(ctx, party)
+	if err != nil {
+		return err
+	}
+
+	locationsUrl, err := o.getReceiverUrl(endpoints, "locations")
 	if err != nil {
 		return err
 	}
 
-	endpointUrl, err := getEndpointUrl(versions)
+	var body any = location
+	if version.Version == Version211 {
+		body = NewLocationV211(location)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", locationsUrl, o.countryCode, o.partyId, location.Id)
+	return o.sendToParty(ctx, http.MethodPut, url, party, body)
+}
+
+// PushSession sends the session to all eMSPs. Parties that use OCPI 2.1.1 embed
+// the location in the session, so the location of the session must be provided.
+func (o *OCPI) PushSession(ctx context.Context, session Session, location Location) error {
+	parties, err := o.store.ListPartyDetailsForRole(ctx, "EMSP")
 	if err != nil {
 		return err
 	}
+	for _, party := range parties {
+		err = o.pushSessionToParty(ctx, party, session, location)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-	endpoints, err := o.getEndpoints(ctx, endpointUrl, party.Token)
+func (o *OCPI) pushSessionToParty(ctx context.Context, party *store.OcpiParty, session Session, location Location) error {
+	version, endpoints, err := o.getPartyEndpoints(ctx, party)
 	if err != nil {
 		return err
 	}
 
-	locationsUrl, err := o.getLocationsUrl(endpoints)
+	sessionsUrl, err := o.getReceiverUrl(endpoints, "sessions")
 	if err != nil {
 		return err
 	}
 
-	err = o.putLocation(ctx, locationsUrl, party.CountryCode, party.PartyId, party.Token, location)
+	var body any = session
+	if version.Version == Version211 {
+		body = NewSessionV211(session, location)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sessionsUrl, o.countryCode, o.partyId, session.Id)
+	return o.sendToParty(ctx, http.MethodPut, url, party, body)
+}
+
+// PushCdr sends the CDR to all eMSPs
+func (o *OCPI) PushCdr(ctx context.Context, cdr CDR) error {
+	parties, err := o.store.ListPartyDetailsForRole(ctx, "EMSP")
 	if err != nil {
 		return err
 	}
+	for _, party := range parties {
+		err = o.pushCdrToParty(ctx, party, cdr)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-func (o *OCPI) getLocationsUrl(endpoints []Endpoint) (string, error) {
+func (o *OCPI) pushCdrToParty(ctx context.Context, party *store.OcpiParty, cdr CDR) error {
+	version, endpoints, err := o.getPartyEndpoints(ctx, party)
+	if err != nil {
+		return err
+	}
+
+	cdrsUrl, err := o.getReceiverUrl(endpoints, "cdrs")
+	if err != nil {
+		return err
+	}
+
+	var body any = cdr
+	if version.Version == Version211 {
+		body = NewCdrV211(cdr)
+	}
+
+	return o.sendToParty(ctx, http.MethodPost, cdrsUrl, party, body)
+}
+
+// getPartyEndpoints returns the version negotiated with the party and the endpoints
+// that the party provides for that version
+func (o *OCPI) getPartyEndpoints(ctx context.Context, party *store.OcpiParty) (Version, []Endpoint, error) {
+	// TODO: retrieve endpoints from store, not via OCPI exchange
+	versions, err := o.getVersions(ctx, party.Url, party.Token)
+	if err != nil {
+		return Version{}, nil, err
+	}
+
+	version, err := negotiateVersion(versions, party.Version)
+	if err != nil {
+		return Version{}, nil, err
+	}
+
+	endpoints, err := o.getEndpoints(ctx, version.Url, party.Token)
+	if err != nil {
+		return Version{}, nil, err
+	}
+
+	return version, endpoints, nil
+}
+
+func (o *OCPI) getReceiverUrl(endpoints []Endpoint, identifier string) (string, error) {
 	for _, endpoint := range endpoints {
-		if endpoint.Identifier == "locations" && endpoint.Role == RECEIVER {
-			return fmt.Sprintf("%s/%s/%s", endpoint.Url, o.countryCode, o.partyId), nil
+		if isReceiverEndpoint(endpoint, identifier) {
+			return endpoint.Url, nil
 		}
 	}
-	return "", errors.New("no locations endpoint for receiver found")
+	return "", fmt.Errorf("no %s endpoint for receiver found", identifier)
 }
 
-func (o *OCPI) putLocation(ctx context.Context, url string, toCountryCode string, toPartyId string, token string, location Location) error {
-	b, err := json.Marshal(location)
+func (o *OCPI) sendToParty(ctx context.Context, method, url string, party *store.OcpiParty, body any) error {
+	b, err := json.Marshal(body)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/%s", url, location.Id), bytes.NewReader(b))
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(b))
 	if err != nil {
 		return err
 	}
-	o.setRequestHeaders(ctx, req, token, toCountryCode, toPartyId)
+	o.setRequestHeaders(ctx, req, party.Token, party.CountryCode, party.PartyId)
 
 	resp, err := o.httpClient.Do(req)
 	if err != nil {
@@ -239,7 +372,7 @@ func (o *OCPI) putLocation(ctx context.Context, url string, toCountryCode string
 		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("status code: %d", resp.StatusCode)
 	}
 
@@ -272,3 +405,41 @@ func (o *OCPI) GetChargeStationOcppVersion(ctx context.Context, csId string) (st
 
 	return csDetails.OcppVersion, nil
 }
+
+// LookupEvseId returns the OCPP EVSE id of the EVSE with the OCPI uid: EVSEs are numbered from 1
+// in the order that they were registered for the charge station
+func (o *OCPI) LookupEvseId(ctx context.Context, csId, evseUid string) (int, error) {
+	cs, err := o.store.LookupChargeStation(ctx, csId)
+	if err != nil {
+		return 0, err
+	}
+	if cs != nil && cs.Evses != nil {
+		for i, evse := range *cs.Evses {
+			if evse.Uid == evseUid {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("evse %s not found for charge station %s", evseUid, csId)
+}
+
+func (o *OCPI) NewReservationId(ctx context.Context, csId string) (int, error) {
+	return handlers.NewReservationId(ctx, o.store, csId)
+}
+
+func (o *OCPI) SetReservation(ctx context.Context, reservation *store.Reservation) error {
+	return o.store.SetReservation(ctx, reservation)
+}
+
+// LookupReservation returns the reservation made by the party for the OCPI reservation id
+// (see ReservationReference)
+func (o *OCPI) LookupReservation(ctx context.Context, countryCode, partyId, reservationId string) (*store.Reservation, error) {
+	return o.store.LookupReservationByReference(ctx, ReservationReference(countryCode, partyId, reservationId))
+}
+
+// ReservationReference returns the reference of the reservation made for the OCPI reservation id.
+// Reservation ids are only unique for the party that made the reservation, so the reference includes
+// the party's country code and party id.
+func ReservationReference(countryCode, partyId, reservationId string) string {
+	return fmt.Sprintf("%s/%s/%s", countryCode, partyId, reservationId)
+}
@@ -4,6 +4,7 @@ package ocpi_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,6 +26,10 @@ func TestGetVersions(t *testing.T) {
 			Version: "2.2",
 			Url:     "/ocpi/2.2",
 		},
+		{
+			Version: "2.1.1",
+			Url:     "/ocpi/2.1.1",
+		},
 	}
 
 	got, err := ocpiApi.GetVersions(context.Background())
@@ -178,6 +183,105 @@ func TestPushLocation(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPushLocationToV211Party(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+
+	mux := http.NewServeMux()
+	receiverServer := httptest.NewServer(mux)
+	defer receiverServer.Close()
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":[{"version":"2.1.1","url":"%[1]s/ocpi/2.1.1"},{"version":"2.2","url":"%[1]s/ocpi/2.2"}], "status_code":1000}`, receiverServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/2.1.1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{
+				"version":"2.1.1",
+				"endpoints":[{"identifier":"locations","url":"%s/ocpi/emsp/2.1.1/locations"}]},
+				"status_code":1000}`,
+			receiverServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/emsp/2.1.1/locations/GB/TWK/loc001", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var got map[string]any
+		err := json.NewDecoder(r.Body).Decode(&got)
+		require.NoError(t, err)
+		assert.Equal(t, "loc001", got["id"])
+		assert.Equal(t, "UNKNOWN", got["type"])
+		assert.NotContains(t, got, "publish")
+		w.WriteHeader(http.StatusOK)
+	})
+	err := ocpiApi.SetCredentialsV211(context.Background(), "some-token-123", ocpi.CredentialsV211{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Token:       "some-token-456",
+		Url:         receiverServer.URL + "/ocpi/versions",
+	})
+	require.NoError(t, err)
+
+	party, err := engine.GetPartyDetails(context.Background(), "EMSP", "GB", "TWK")
+	require.NoError(t, err)
+	assert.Equal(t, "2.1.1", party.Version)
+
+	err = ocpiApi.PushLocation(context.Background(), ocpi.Location{Id: "loc001"})
+	require.NoError(t, err)
+}
+
+func TestPushSessionToV211Party(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+
+	mux := http.NewServeMux()
+	receiverServer := httptest.NewServer(mux)
+	defer receiverServer.Close()
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":[{"version":"2.1.1","url":"%[1]s/ocpi/2.1.1"},{"version":"2.2","url":"%[1]s/ocpi/2.2"}], "status_code":1000}`, receiverServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/2.1.1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{
+				"version":"2.1.1",
+				"endpoints":[{"identifier":"sessions","url":"%s/ocpi/emsp/2.1.1/sessions"}]},
+				"status_code":1000}`,
+			receiverServer.URL)))
+	})
+	received := false
+	mux.HandleFunc("/ocpi/emsp/2.1.1/sessions/GB/TWK/s001", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var got map[string]any
+		err := json.NewDecoder(r.Body).Decode(&got)
+		require.NoError(t, err)
+		assert.Equal(t, "s001", got["id"])
+		assert.Equal(t, "GBTWKTWTW000018", got["auth_id"])
+		assert.Equal(t, "AUTH_REQUEST", got["auth_method"])
+		assert.Equal(t, "loc001", got["location"].(map[string]any)["id"])
+		assert.NotContains(t, got, "cdr_token")
+		received = true
+		w.WriteHeader(http.StatusOK)
+	})
+	err := ocpiApi.SetCredentialsV211(context.Background(), "some-token-123", ocpi.CredentialsV211{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Token:       "some-token-456",
+		Url:         receiverServer.URL + "/ocpi/versions",
+	})
+	require.NoError(t, err)
+
+	err = ocpiApi.PushSession(context.Background(), ocpi.Session{
+		Id:          "s001",
+		AuthMethod:  ocpi.SessionAuthMethodCOMMAND,
+		CdrToken:    ocpi.CdrToken{ContractId: "GBTWKTWTW000018"},
+		LocationId:  "loc001",
+		EvseUid:     "evse1",
+		ConnectorId: "1",
+		Status:      ocpi.SessionStatusACTIVE,
+	}, ocpi.Location{Id: "loc001"})
+	require.NoError(t, err)
+	assert.True(t, received)
+}
+
 func TestGetChargeStationOcppVersion(t *testing.T) {
 	engine := inmemory.NewStore(clock.RealClock{})
 	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
@@ -15,52 +15,76 @@ import (
 	"net/http"
 )
 
+const (
+	Version22  = "2.2"
+	Version211 = "2.1.1"
+)
+
+// supportedVersions lists the OCPI versions supported by the CSMS in order of
+// preference
+var supportedVersions = []string{Version22, Version211}
+
+// RegisterNewParty registers with the party using the credentials token that the party provided.
+// If the party responds with its own credentials then they are stored with the negotiated version.
 func (o *OCPI) RegisterNewParty(ctx context.Context, url, token string) error {
-	reg, err := o.store.GetRegistrationDetails(ctx, token)
+	version, credentials, err := o.registerNewParty(ctx, url, token)
 	if err != nil {
 		return err
 	}
+	if credentials != nil {
+		return o.setPartyDetails(ctx, version, *credentials)
+	}
+	return nil
+}
+
+// registerNewParty returns the negotiated version and the credentials the party responded with
+// (nil if the party did not respond with credentials)
+func (o *OCPI) registerNewParty(ctx context.Context, url, token string) (string, *Credentials, error) {
+	reg, err := o.store.GetRegistrationDetails(ctx, token)
+	if err != nil {
+		return "", nil, err
+	}
 	if reg != nil && reg.Status == store.OcpiRegistrationStatusRegistered {
-		return errors.New("already registered")
+		return "", nil, errors.New("already registered")
 	}
 
 	versions, err := o.getVersions(ctx, url, token)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
-	endpointUrl, err := getEndpointUrl(versions)
+	version, err := negotiateVersion(versions, "")
 	if err != nil {
-		return err
+		return "", nil, err
 	}
-	endpoints, err := o.getEndpoints(ctx, endpointUrl, token)
+	endpoints, err := o.getEndpoints(ctx, version.Url, token)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	newToken, err := generateRandomString()
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	err = o.store.SetRegistrationDetails(ctx, newToken, &store.OcpiRegistration{
 		Status: store.OcpiRegistrationStatusRegistered,
 	})
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	credentialsUrl, err := getCredentialsUrl(endpoints)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
-	err = o.postCredentials(ctx, credentialsUrl, token, newToken)
+	credentials, err := o.postCredentials(ctx, version.Version, credentialsUrl, token, newToken)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
-	return nil
+	return version.Version, credentials, nil
 }
 
 func (o *OCPI) getVersions(ctx context.Context, url, token string) ([]Version, error) {
@@ -99,21 +123,28 @@ func (o *OCPI) getVersions(ctx context.Context, url, token string) ([]Version, e
 	return *versionList.Data, nil
 }
 
-func getEndpointUrl(versions []Version) (string, error) {
-	var endpointUrl string
-
-	for _, version := range versions {
-		if version.Version == "2.2" {
-			endpointUrl = version.Url
-			break
+// negotiateVersion returns the most preferred version supported by both
+// parties. If a version has already been agreed with the party then only that
+// version is acceptable.
+func negotiateVersion(versions []Version, agreedVersion string) (Version, error) {
+	if agreedVersion != "" {
+		for _, version := range versions {
+			if version.Version == agreedVersion {
+				return version, nil
+			}
 		}
+		return Version{}, fmt.Errorf("no version %s endpoint found", agreedVersion)
 	}
 
-	if endpointUrl == "" {
-		return "", fmt.Errorf("no version 2.2 endpoint found")
+	for _, supportedVersion := range supportedVersions {
+		for _, version := range versions {
+			if version.Version == supportedVersion {
+				return version, nil
+			}
+		}
 	}
 
-	return endpointUrl, nil
+	return Version{}, fmt.Errorf("no supported version endpoint found")
 }
 
 func (o *OCPI) getEndpoints(ctx context.Context, url, token string) ([]Endpoint, error) {
@@ -169,14 +200,22 @@ func generateRandomString() (string, error) {
 
 func getCredentialsUrl(endpoints []Endpoint) (string, error) {
 	for _, endpoint := range endpoints {
-		if endpoint.Identifier == "credentials" && endpoint.Role == RECEIVER {
+		if isReceiverEndpoint(endpoint, "credentials") {
 			return endpoint.Url, nil
 		}
 	}
 	return "", errors.New("no credentials endpoint for receiver found")
 }
 
-func (o *OCPI) postCredentials(ctx context.Context, url, token, newToken string) error {
+// isReceiverEndpoint checks if the endpoint is the receiver interface for the
+// module: 2.1.1 endpoints do not have a role.
+func isReceiverEndpoint(endpoint Endpoint, identifier string) bool {
+	return endpoint.Identifier == identifier && (endpoint.Role == RECEIVER || endpoint.Role == "")
+}
+
+// postCredentials returns the credentials that the party responded with: nil if the response
+// does not include credentials
+func (o *OCPI) postCredentials(ctx context.Context, version, url, token, newToken string) (*Credentials, error) {
 	creds := Credentials{
 		Roles: []CredentialsRole{
 			{
@@ -189,29 +228,57 @@ func (o *OCPI) postCredentials(ctx context.Context, url, token, newToken string)
 		Url:   o.externalUrl + "/ocpi/versions",
 	}
 
-	b, err := json.Marshal(creds)
+	var body any = creds
+	if version == Version211 {
+		body = NewCredentialsV211(creds)
+	}
+
+	b, err := json.Marshal(body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Token %s", token))
 
 	resp, err := o.httpClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("status code: %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
 	}
 
-	return nil
+	b, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil, nil
+	}
+
+	if version == Version211 {
+		var credentialsResp OcpiResponseCredentialsV211
+		err = json.Unmarshal(b, &credentialsResp)
+		if err != nil || credentialsResp.Data == nil {
+			return nil, err
+		}
+		credentials := credentialsResp.Data.ToCredentials(CredentialsRoleRoleEMSP)
+		return &credentials, nil
+	}
+
+	var credentialsResp OcpiResponseCredentials
+	err = json.Unmarshal(b, &credentialsResp)
+	if err != nil {
+		return nil, err
+	}
+	return credentialsResp.Data, nil
 }
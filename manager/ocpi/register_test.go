@@ -4,6 +4,7 @@ package ocpi_test
 
 import (
 	"context"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
@@ -52,6 +53,7 @@ func TestRegistration(t *testing.T) {
 	assert.Equal(t, "TWK", senderPartyDetails.PartyId)
 	assert.Equal(t, senderServer.URL+"/ocpi/versions", senderPartyDetails.Url)
 	assert.Len(t, senderPartyDetails.Token, 64)
+	assert.Equal(t, "2.2", senderPartyDetails.Version)
 
 	receiverPartyDetails, err := senderStore.GetPartyDetails(context.Background(), "CPO", "GB", "TWS")
 	require.NoError(t, err)
@@ -61,6 +63,7 @@ func TestRegistration(t *testing.T) {
 	assert.Equal(t, "TWS", receiverPartyDetails.PartyId)
 	assert.Equal(t, receiverServer.URL+"/ocpi/versions", receiverPartyDetails.Url)
 	assert.Len(t, receiverPartyDetails.Token, 64)
+	assert.Equal(t, "2.2", receiverPartyDetails.Version)
 
 	// check initial registration details have been removed
 	senderTokenAReg, err := senderStore.GetRegistrationDetails(context.Background(), tokenA)
@@ -82,3 +85,47 @@ func TestRegistration(t *testing.T) {
 	require.NotNil(t, senderTokenCReg)
 	assert.Equal(t, store.OcpiRegistrationStatusRegistered, senderTokenCReg.Status)
 }
+
+func TestRegistrationWithV211Party(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	ocpiApi.SetExternalUrl("http://csms.example.com")
+
+	mux := http.NewServeMux()
+	partyServer := httptest.NewServer(mux)
+	defer partyServer.Close()
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":[{"version":"2.1.1","url":"%s/ocpi/2.1.1"}],"status_code":1000}`, partyServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/2.1.1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{
+				"version":"2.1.1",
+				"endpoints":[{"identifier":"credentials","url":"%s/ocpi/emsp/2.1.1/credentials"}]},
+				"status_code":1000}`,
+			partyServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/emsp/2.1.1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{
+				"token":"token-c",
+				"url":"%s/ocpi/versions",
+				"business_details":{"name":"eMSP"},
+				"country_code":"GB",
+				"party_id":"EMS"},
+				"status_code":1000}`,
+			partyServer.URL)))
+	})
+
+	err := ocpiApi.RegisterNewParty(context.Background(), partyServer.URL+"/ocpi/versions", "token-a")
+	require.NoError(t, err)
+
+	party, err := engine.GetPartyDetails(context.Background(), "EMSP", "GB", "EMS")
+	require.NoError(t, err)
+	require.NotNil(t, party)
+	assert.Equal(t, "token-c", party.Token)
+	assert.Equal(t, partyServer.URL+"/ocpi/versions", party.Url)
+	assert.Equal(t, "2.1.1", party.Version)
+}
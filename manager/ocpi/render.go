@@ -31,3 +31,27 @@ func (Token) Bind(r *http.Request) error {
 func (StartSession) Bind(r *http.Request) error {
 	return nil
 }
+
+func (ReserveNow) Bind(r *http.Request) error {
+	return nil
+}
+
+func (CancelReservation) Bind(r *http.Request) error {
+	return nil
+}
+
+func (OcpiResponseVersionDetailV211) Render(http.ResponseWriter, *http.Request) error {
+	return nil
+}
+
+func (OcpiResponseTokenV211) Render(http.ResponseWriter, *http.Request) error {
+	return nil
+}
+
+func (CredentialsV211) Bind(r *http.Request) error {
+	return nil
+}
+
+func (TokenV211) Bind(r *http.Request) error {
+	return nil
+}
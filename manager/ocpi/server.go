@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-chi/render"
 	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/services"
@@ -167,6 +168,20 @@ func (s *Server) PatchClientOwnedToken(w http.ResponseWriter, r *http.Request, c
 		return
 	}
 
+	err = applyTokenPatch(tok, patch)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	err = s.ocpi.SetToken(r.Context(), *tok)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+}
+
+func applyTokenPatch(tok *Token, patch map[string]any) error {
 	for k, v := range patch {
 		switch k {
 		case "contract_id":
@@ -194,15 +209,10 @@ func (s *Server) PatchClientOwnedToken(w http.ResponseWriter, r *http.Request, c
 			whitelist := v.(string)
 			tok.Whitelist = TokenWhitelist(whitelist)
 		default:
-			_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("unknown field %s", k)))
+			return fmt.Errorf("unknown field %s", k)
 		}
 	}
-
-	err = s.ocpi.SetToken(r.Context(), *tok)
-	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
-		return
-	}
+	return nil
 }
 
 func (s *Server) DeleteCredentials(w http.ResponseWriter, r *http.Request, params DeleteCredentialsParams) {
@@ -246,11 +256,153 @@ func (s *Server) GetClientOwnedCdr(w http.ResponseWriter, r *http.Request, cdrID
 }
 
 func (s *Server) PostCancelReservation(w http.ResponseWriter, r *http.Request, params PostCancelReservationParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	cancelReservation := new(CancelReservation)
+	if err := render.Bind(r, cancelReservation); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	reservation, err := s.ocpi.LookupReservation(r.Context(), params.OCPIFromCountryCode, params.OCPIFromPartyId, cancelReservation.ReservationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	if reservation == nil || !reservation.Status.IsActive() {
+		s.renderCommandResponse(w, r, CommandResponseResultREJECTED)
+		return
+	}
+
+	ocppVersion, err := s.ocpi.GetChargeStationOcppVersion(r.Context(), reservation.ChargeStationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	req, err := handlers.NewCancelReservationRequest(ocppVersion, reservation.ReservationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	// the reservation is marked as cancelled when the charge station accepts the request
+	s.renderCommandResponse(w, r, s.sendCommand(reservation.ChargeStationId, ocppVersion, req))
 }
 
 func (s *Server) PostReserveNow(w http.ResponseWriter, r *http.Request, params PostReserveNowParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	reserveNow := new(ReserveNow)
+	if err := render.Bind(r, reserveNow); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	if reserveNow.EvseUid == nil {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("CSMS does not support reserve now commands without evse_uid")))
+		return
+	}
+
+	chargeStationId, err := s.evseUIDService.GetChargeStationId(*reserveNow.EvseUid)
+	if err != nil {
+		slog.Error("error extracting charge station id", "err", err)
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	evseId, err := s.ocpi.LookupEvseId(r.Context(), chargeStationId, *reserveNow.EvseUid)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	expiryDate, err := time.Parse(time.RFC3339, reserveNow.ExpiryDate)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	ocppVersion, err := s.ocpi.GetChargeStationOcppVersion(r.Context(), chargeStationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	// the token is stored so that it can be authorized when the reservation is used
+	err = s.ocpi.SetToken(r.Context(), reserveNow.Token)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	now := s.clock.Now()
+	reference := ReservationReference(params.OCPIFromCountryCode, params.OCPIFromPartyId, reserveNow.ReservationId)
+	reservation := &store.Reservation{
+		ChargeStationId: chargeStationId,
+		Reference:       &reference,
+		EvseId:          &evseId,
+		IdToken:         reserveNow.Token.Uid,
+		TokenType:       string(ocpp201.IdTokenEnumTypeCentral),
+		GroupId:         reserveNow.Token.GroupId,
+		ExpiryDate:      expiryDate,
+		Status:          store.ReservationStatusPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if reserveNow.Token.Type == TokenTypeRFID {
+		reservation.TokenType = string(ocpp201.IdTokenEnumTypeISO14443)
+	}
+
+	// a reserve now command with the id of an active reservation replaces that reservation
+	existing, err := s.ocpi.LookupReservation(r.Context(), params.OCPIFromCountryCode, params.OCPIFromPartyId, reserveNow.ReservationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	if existing != nil && existing.ChargeStationId == chargeStationId && existing.Status.IsActive() {
+		reservation.ReservationId = existing.ReservationId
+		reservation.CreatedAt = existing.CreatedAt
+	} else {
+		reservation.ReservationId, err = s.ocpi.NewReservationId(r.Context(), chargeStationId)
+		if err != nil {
+			_ = render.Render(w, r, ErrInvalidRequest(err))
+			return
+		}
+	}
+
+	req, err := handlers.NewReserveNowRequest(ocppVersion, reservation)
+	if err != nil {
+		slog.Error("error creating reserve now request", "err", err)
+		s.renderCommandResponse(w, r, CommandResponseResultREJECTED)
+		return
+	}
+
+	err = s.ocpi.SetReservation(r.Context(), reservation)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	s.renderCommandResponse(w, r, s.sendCommand(chargeStationId, ocppVersion, req))
+}
+
+// sendCommand sends the OCPP request used to implement an OCPI command to the charge station
+// and returns the result of the command
+func (s *Server) sendCommand(chargeStationId string, ocppVersion store.OcppVersion, req ocpp.Request) CommandResponseResult {
+	callMaker := s.v201CallMaker
+	if ocppVersion == store.OcppVersion16 {
+		callMaker = s.v16CallMaker
+	}
+	err := callMaker.Send(context.Background(), chargeStationId, req)
+	if err != nil {
+		slog.Error("error sending mqtt message", "err", err)
+		return CommandResponseResultREJECTED
+	}
+	return CommandResponseResultACCEPTED
+}
+
+func (s *Server) renderCommandResponse(w http.ResponseWriter, r *http.Request, result CommandResponseResult) {
+	_ = render.Render(w, r, OcpiResponseCommandResponse{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          &CommandResponse{Result: result},
+	})
 }
 
 func (s *Server) PostStartSession(w http.ResponseWriter, r *http.Request, params PostStartSessionParams) {
@@ -65,6 +65,10 @@ func TestServerGetVersions(t *testing.T) {
 				Version: "2.2",
 				Url:     "/ocpi/2.2",
 			},
+			{
+				Version: "2.1.1",
+				Url:     "/ocpi/2.1.1",
+			},
 		},
 		StatusCode:    ocpi.StatusSuccess,
 		StatusMessage: &ocpi.StatusSuccessMessage,
@@ -404,6 +408,212 @@ func TestPostStartSession201(t *testing.T) {
 	assert.Equal(t, ocpi.CommandResponseResultACCEPTED, ocpiResponseCommandResponse.Data.Result)
 }
 
+func postCommand(t *testing.T, handler http.Handler, command, body string) ocpi.CommandResponseResult {
+	req := httptest.NewRequest(http.MethodPost, "/ocpi/receiver/2.2/commands/"+command, strings.NewReader(body))
+	req.Header.Set("Authorization", "Token 123")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "123")
+	req.Header.Set("X-Correlation-ID", "123")
+	req.Header.Set("OCPI-from-country-code", "GB")
+	req.Header.Set("OCPI-from-party-id", "TWK")
+	req.Header.Set("OCPI-to-country-code", "GB")
+	req.Header.Set("OCPI-to-party-id", "TWK")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var ocpiResponseCommandResponse ocpi.OcpiResponseCommandResponse
+	err := json.NewDecoder(resp.Body).Decode(&ocpiResponseCommandResponse)
+	require.NoError(t, err)
+	assert.Equal(t, ocpi.StatusSuccess, ocpiResponseCommandResponse.StatusCode)
+	require.NotNil(t, ocpiResponseCommandResponse.Data)
+	return ocpiResponseCommandResponse.Data.Result
+}
+
+const reserveNowCommand = `{
+	"response_url": "https://example.com/ocpi/receiver/2.2/commands/RESERVE_NOW/12345",
+	"evse_uid": "DE*GCE*E00188*001",
+	"token": {
+		"type": "RFID",
+		"uid": "DEADBEEF",
+		"whitelist": "ALWAYS",
+		"country_code": "GB",
+		"party_id": "TWK",
+		"contract_id": "GBTWKTWTW000018",
+		"issuer": "Thoughtworks",
+		"valid": true
+	},
+	"expiry_date": "2030-01-02T03:04:05Z",
+	"reservation_id": "res001",
+	"location_id": "loc001"
+}`
+
+func createChargeStationWithEvses(t *testing.T, engine store.Engine, csId string, evseUids ...string) {
+	evses := make([]store.Evse, len(evseUids))
+	for i, evseUid := range evseUids {
+		evses[i] = store.Evse{Uid: evseUid}
+	}
+	err := engine.CreateChargeStation(context.Background(), &store.ChargeStation{
+		Id:    csId,
+		Evses: &evses,
+	})
+	require.NoError(t, err)
+}
+
+func TestPostReserveNow(t *testing.T) {
+	handler, engine, now := setupHandler(t)
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "00188", &store.ChargeStationRuntimeDetails{
+		OcppVersion: store.OcppVersion16,
+	})
+	require.NoError(t, err)
+	createChargeStationWithEvses(t, engine, "00188", "DE*GCE*E00188*000", "DE*GCE*E00188*001")
+
+	result := postCommand(t, handler, "RESERVE_NOW", reserveNowCommand)
+	assert.Equal(t, ocpi.CommandResponseResultACCEPTED, result)
+
+	reservation, err := engine.LookupReservationByReference(context.Background(), "GB/TWK/res001")
+	require.NoError(t, err)
+	require.NotNil(t, reservation)
+	reference := "GB/TWK/res001"
+	evseId := 2
+	assert.Equal(t, &store.Reservation{
+		ChargeStationId: "00188",
+		ReservationId:   reservation.ReservationId,
+		Reference:       &reference,
+		EvseId:          &evseId,
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ExpiryDate:      time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+		Status:          store.ReservationStatusPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, reservation)
+
+	token, err := engine.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+	assert.NotNil(t, token)
+
+	// a second command with the same reservation id replaces the active reservation
+	result = postCommand(t, handler, "RESERVE_NOW", reserveNowCommand)
+	assert.Equal(t, ocpi.CommandResponseResultACCEPTED, result)
+
+	reservations, err := engine.ListReservations(context.Background(), "00188")
+	require.NoError(t, err)
+	require.Len(t, reservations, 1)
+	assert.Equal(t, reservation.ReservationId, reservations[0].ReservationId)
+}
+
+func TestPostReserveNowFromAnotherPartyWithSameReservationId(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "00188", &store.ChargeStationRuntimeDetails{
+		OcppVersion: store.OcppVersion201,
+	})
+	require.NoError(t, err)
+	createChargeStationWithEvses(t, engine, "00188", "DE*GCE*E00188*001")
+	reference := "NL/ABC/res001"
+	err = engine.SetReservation(context.Background(), &store.Reservation{
+		ChargeStationId: "00188",
+		ReservationId:   42,
+		Reference:       &reference,
+		IdToken:         "CAFEBABE",
+		TokenType:       "ISO14443",
+		ExpiryDate:      time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+		Status:          store.ReservationStatusAccepted,
+	})
+	require.NoError(t, err)
+
+	result := postCommand(t, handler, "RESERVE_NOW", reserveNowCommand)
+	assert.Equal(t, ocpi.CommandResponseResultACCEPTED, result)
+
+	// the other party's reservation is not replaced
+	reservations, err := engine.ListReservations(context.Background(), "00188")
+	require.NoError(t, err)
+	require.Len(t, reservations, 2)
+	other, err := engine.LookupReservation(context.Background(), "00188", 42)
+	require.NoError(t, err)
+	assert.Equal(t, "CAFEBABE", other.IdToken)
+}
+
+func TestPostReserveNowWithUnknownEvse(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "00188", &store.ChargeStationRuntimeDetails{
+		OcppVersion: store.OcppVersion201,
+	})
+	require.NoError(t, err)
+	createChargeStationWithEvses(t, engine, "00188", "DE*GCE*E00188*002")
+
+	req := httptest.NewRequest(http.MethodPost, "/ocpi/receiver/2.2/commands/RESERVE_NOW", strings.NewReader(reserveNowCommand))
+	req.Header.Set("Authorization", "Token 123")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "123")
+	req.Header.Set("X-Correlation-ID", "123")
+	req.Header.Set("OCPI-from-country-code", "GB")
+	req.Header.Set("OCPI-from-party-id", "TWK")
+	req.Header.Set("OCPI-to-country-code", "GB")
+	req.Header.Set("OCPI-to-party-id", "TWK")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+	reservations, err := engine.ListReservations(context.Background(), "00188")
+	require.NoError(t, err)
+	assert.Empty(t, reservations)
+}
+
+func TestPostCancelReservation(t *testing.T) {
+	handler, engine, now := setupHandler(t)
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "00188", &store.ChargeStationRuntimeDetails{
+		OcppVersion: store.OcppVersion201,
+	})
+	require.NoError(t, err)
+	reference := "GB/TWK/res001"
+	err = engine.SetReservation(context.Background(), &store.Reservation{
+		ChargeStationId: "00188",
+		ReservationId:   42,
+		Reference:       &reference,
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ExpiryDate:      now.Add(time.Hour),
+		Status:          store.ReservationStatusAccepted,
+	})
+	require.NoError(t, err)
+
+	result := postCommand(t, handler, "CANCEL_RESERVATION", `{
+		"response_url": "https://example.com/ocpi/receiver/2.2/commands/CANCEL_RESERVATION/12345",
+		"reservation_id": "res001"
+	}`)
+	assert.Equal(t, ocpi.CommandResponseResultACCEPTED, result)
+
+	result = postCommand(t, handler, "CANCEL_RESERVATION", `{
+		"response_url": "https://example.com/ocpi/receiver/2.2/commands/CANCEL_RESERVATION/12345",
+		"reservation_id": "unknown"
+	}`)
+	assert.Equal(t, ocpi.CommandResponseResultREJECTED, result)
+
+	// another party can't cancel the reservation
+	otherReference := "NL/ABC/res002"
+	err = engine.SetReservation(context.Background(), &store.Reservation{
+		ChargeStationId: "00188",
+		ReservationId:   43,
+		Reference:       &otherReference,
+		IdToken:         "CAFEBABE",
+		TokenType:       "ISO14443",
+		ExpiryDate:      now.Add(time.Hour),
+		Status:          store.ReservationStatusAccepted,
+	})
+	require.NoError(t, err)
+	result = postCommand(t, handler, "CANCEL_RESERVATION", `{
+		"response_url": "https://example.com/ocpi/receiver/2.2/commands/CANCEL_RESERVATION/12345",
+		"reservation_id": "res002"
+	}`)
+	assert.Equal(t, ocpi.CommandResponseResultREJECTED, result)
+}
+
 func newNoopV16CallMaker() *handlers.OcppCallMaker {
 	emitter := transport.EmitterFunc(func(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
 		return nil
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slog"
+)
+
+// HandlerV211 returns the OCPI 2.1.1 routes. These are not part of the
+// generated OCPI 2.2 handler, so should be mounted at /ocpi/2.1.1 with the
+// token authentication middleware.
+func HandlerV211(s *Server) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/", s.GetVersionV211)
+	r.Post("/credentials", s.PostCredentialsV211)
+	r.Get("/tokens/{country_code}/{party_id}/{token_uid}", s.GetClientOwnedTokenV211)
+	r.Put("/tokens/{country_code}/{party_id}/{token_uid}", s.PutClientOwnedTokenV211)
+	r.Patch("/tokens/{country_code}/{party_id}/{token_uid}", s.PatchClientOwnedTokenV211)
+	return r
+}
+
+// VERSIONS
+
+func (s *Server) GetVersionV211(w http.ResponseWriter, r *http.Request) {
+	version, err := s.ocpi.GetVersionV211(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, OcpiResponseVersionDetailV211{
+			StatusCode: StatusGenericServerFailure,
+			Timestamp:  s.clock.Now().Format(time.RFC3339),
+		})
+		return
+	}
+	_ = render.Render(w, r, OcpiResponseVersionDetailV211{
+		StatusCode:    StatusSuccess,
+		Data:          &version,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		StatusMessage: &StatusSuccessMessage,
+	})
+}
+
+// CREDENTIALS
+
+func (s *Server) PostCredentialsV211(w http.ResponseWriter, r *http.Request) {
+	creds := new(CredentialsV211)
+	if err := render.Bind(r, creds); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	matches := authzHeaderRegexp.FindStringSubmatch(r.Header.Get("Authorization"))
+	if len(matches) != 2 {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid authorization header")))
+		return
+	}
+
+	err := s.ocpi.SetCredentialsV211(r.Context(), matches[1], *creds)
+	if err != nil {
+		slog.Error("Error setting credentials", "err", err)
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// TOKEN RECEIVER
+
+func (s *Server) GetClientOwnedTokenV211(w http.ResponseWriter, r *http.Request) {
+	token, err := s.ocpi.GetToken(r.Context(), chi.URLParam(r, "country_code"), chi.URLParam(r, "party_id"), chi.URLParam(r, "token_uid"))
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	var tokV211 *TokenV211
+	if token != nil {
+		tok := NewTokenV211(*token)
+		tokV211 = &tok
+	}
+
+	_ = render.Render(w, r, OcpiResponseTokenV211{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          tokV211,
+	})
+}
+
+func (s *Server) PutClientOwnedTokenV211(w http.ResponseWriter, r *http.Request) {
+	tok := new(TokenV211)
+	if err := render.Bind(r, tok); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	if tok.Uid != chi.URLParam(r, "token_uid") {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("token uid mismatch")))
+		return
+	}
+
+	err := s.ocpi.SetToken(r.Context(), tok.ToToken(chi.URLParam(r, "country_code"), chi.URLParam(r, "party_id")))
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+}
+
+func (s *Server) PatchClientOwnedTokenV211(w http.ResponseWriter, r *http.Request) {
+	var patch map[string]any
+	err := json.NewDecoder(r.Body).Decode(&patch)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	tok, err := s.ocpi.GetToken(r.Context(), chi.URLParam(r, "country_code"), chi.URLParam(r, "party_id"), chi.URLParam(r, "token_uid"))
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if tok == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	// the 2.1.1 auth_id is the 2.2 contract_id
+	if authId, ok := patch["auth_id"]; ok {
+		patch["contract_id"] = authId
+		delete(patch, "auth_id")
+	}
+
+	err = applyTokenPatch(tok, patch)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	err = s.ocpi.SetToken(r.Context(), *tok)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func setupV211Handler(t *testing.T) (http.Handler, store.Engine, time.Time) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	now := time.Now().UTC()
+	evseUidSvc := services.NewEvseUIDService(`^([A-Z]{2})\*([A-Z0-9]{3})\*E([0-9]+)\*?(.*)$`)
+	server, err := ocpi.NewServer(ocpiApi, fakeclock.NewFakePassiveClock(now), newNoopV16CallMaker(), newNoopV201CallMaker(), evseUidSvc)
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Mount("/ocpi/2.1.1", ocpi.HandlerV211(server))
+	return r, engine, now
+}
+
+func TestServerGetVersionV211(t *testing.T) {
+	handler, _, now := setupV211Handler(t)
+	req := httptest.NewRequest(http.MethodGet, "/ocpi/2.1.1", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	want := ocpi.OcpiResponseVersionDetailV211{
+		Data: &ocpi.VersionDetailV211{
+			Version: "2.1.1",
+			Endpoints: []ocpi.EndpointV211{
+				{
+					Identifier: "credentials",
+					Url:        "/ocpi/2.1.1/credentials",
+				},
+				{
+					Identifier: "tokens",
+					Url:        "/ocpi/2.1.1/tokens/",
+				},
+			},
+		},
+		StatusCode:    ocpi.StatusSuccess,
+		StatusMessage: &ocpi.StatusSuccessMessage,
+		Timestamp:     now.Format(time.RFC3339),
+	}
+
+	var got ocpi.OcpiResponseVersionDetailV211
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, want, got)
+}
+
+func TestServerPutAndGetTokenV211(t *testing.T) {
+	handler, engine, _ := setupV211Handler(t)
+
+	tok := `{"uid":"DEADBEEF","type":"RFID","auth_id":"GBTWKTWTW000018","issuer":"Thoughtworks","valid":true,"whitelist":"ALWAYS","last_updated":"2024-01-01T00:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPut, "/ocpi/2.1.1/tokens/GB/TWK/DEADBEEF", strings.NewReader(tok))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	stored, err := engine.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "GB", stored.CountryCode)
+	assert.Equal(t, "TWK", stored.PartyId)
+	assert.Equal(t, "GBTWKTWTW000018", stored.ContractId)
+
+	req = httptest.NewRequest(http.MethodPatch, "/ocpi/2.1.1/tokens/GB/TWK/DEADBEEF", strings.NewReader(`{"valid":false}`))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/ocpi/2.1.1/tokens/GB/TWK/DEADBEEF", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var got ocpi.OcpiResponseTokenV211
+	err = json.NewDecoder(resp.Body).Decode(&got)
+	require.NoError(t, err)
+	require.NotNil(t, got.Data)
+	assert.Equal(t, "DEADBEEF", got.Data.Uid)
+	assert.Equal(t, "GBTWKTWTW000018", got.Data.AuthId)
+	assert.False(t, got.Data.Valid)
+}
@@ -0,0 +1,438 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import "strconv"
+
+// The types in this file describe the OCPI 2.1.1 shapes of the objects that
+// are exchanged with parties that do not yet support OCPI 2.2. The rest of the
+// package works with the 2.2 types: these are only used at the edges.
+
+type EndpointV211 struct {
+	Identifier string `json:"identifier"`
+	Url        string `json:"url"`
+}
+
+type VersionDetailV211 struct {
+	Endpoints []EndpointV211 `json:"endpoints"`
+	Version   string         `json:"version"`
+}
+
+type OcpiResponseVersionDetailV211 struct {
+	Data          *VersionDetailV211 `json:"data,omitempty"`
+	StatusCode    int32              `json:"status_code"`
+	StatusMessage *string            `json:"status_message,omitempty"`
+	Timestamp     string             `json:"timestamp"`
+}
+
+type OcpiResponseTokenV211 struct {
+	Data          *TokenV211 `json:"data,omitempty"`
+	StatusCode    int32      `json:"status_code"`
+	StatusMessage *string    `json:"status_message,omitempty"`
+	Timestamp     string     `json:"timestamp"`
+}
+
+type OcpiResponseCredentialsV211 struct {
+	Data          *CredentialsV211 `json:"data,omitempty"`
+	StatusCode    int32            `json:"status_code"`
+	StatusMessage *string          `json:"status_message,omitempty"`
+	Timestamp     string           `json:"timestamp"`
+}
+
+type CredentialsV211 struct {
+	BusinessDetails BusinessDetails `json:"business_details"`
+	CountryCode     string          `json:"country_code"`
+	PartyId         string          `json:"party_id"`
+	Token           string          `json:"token"`
+	Url             string          `json:"url"`
+}
+
+type ConnectorV211 struct {
+	Amperage           int32              `json:"amperage"`
+	Format             ConnectorFormat    `json:"format"`
+	Id                 string             `json:"id"`
+	LastUpdated        string             `json:"last_updated"`
+	PowerType          ConnectorPowerType `json:"power_type"`
+	Standard           ConnectorStandard  `json:"standard"`
+	TariffId           *string            `json:"tariff_id,omitempty"`
+	TermsAndConditions *string            `json:"terms_and_conditions,omitempty"`
+	Voltage            int32              `json:"voltage"`
+}
+
+type EvseV211 struct {
+	Capabilities        *[]EvseCapabilities        `json:"capabilities,omitempty"`
+	Connectors          []ConnectorV211            `json:"connectors"`
+	Coordinates         *GeoLocation               `json:"coordinates,omitempty"`
+	Directions          *[]DisplayText             `json:"directions,omitempty"`
+	EvseId              *string                    `json:"evse_id,omitempty"`
+	FloorLevel          *string                    `json:"floor_level,omitempty"`
+	Images              *[]Image                   `json:"images,omitempty"`
+	LastUpdated         string                     `json:"last_updated"`
+	ParkingRestrictions *[]EvseParkingRestrictions `json:"parking_restrictions,omitempty"`
+	PhysicalReference   *string                    `json:"physical_reference,omitempty"`
+	Status              EvseStatus                 `json:"status"`
+	StatusSchedule      *[]StatusSchedule          `json:"status_schedule,omitempty"`
+	Uid                 string                     `json:"uid"`
+}
+
+type LocationV211 struct {
+	Address            string                   `json:"address"`
+	ChargingWhenClosed *bool                    `json:"charging_when_closed,omitempty"`
+	City               string                   `json:"city"`
+	Coordinates        GeoLocation              `json:"coordinates"`
+	Country            string                   `json:"country"`
+	Directions         *[]DisplayText           `json:"directions,omitempty"`
+	EnergyMix          *EnergyMix               `json:"energy_mix,omitempty"`
+	Evses              *[]EvseV211              `json:"evses,omitempty"`
+	Facilities         *[]LocationFacilities    `json:"facilities,omitempty"`
+	Id                 string                   `json:"id"`
+	Images             *[]Image                 `json:"images,omitempty"`
+	LastUpdated        string                   `json:"last_updated"`
+	Name               *string                  `json:"name,omitempty"`
+	OpeningTimes       *Hours                   `json:"opening_times,omitempty"`
+	Operator           *BusinessDetails         `json:"operator,omitempty"`
+	Owner              *BusinessDetails         `json:"owner,omitempty"`
+	PostalCode         string                   `json:"postal_code"`
+	RelatedLocations   *[]AdditionalGeoLocation `json:"related_locations,omitempty"`
+	Suboperator        *BusinessDetails         `json:"suboperator,omitempty"`
+	TimeZone           *string                  `json:"time_zone,omitempty"`
+	Type               string                   `json:"type"`
+}
+
+type TokenV211 struct {
+	AuthId       string         `json:"auth_id"`
+	Issuer       string         `json:"issuer"`
+	Language     *string        `json:"language,omitempty"`
+	LastUpdated  string         `json:"last_updated"`
+	Type         TokenType      `json:"type"`
+	Uid          string         `json:"uid"`
+	Valid        bool           `json:"valid"`
+	VisualNumber *string        `json:"visual_number,omitempty"`
+	Whitelist    TokenWhitelist `json:"whitelist"`
+}
+
+type ChargingPeriodV211 struct {
+	Dimensions    []CdrDimension `json:"dimensions"`
+	StartDateTime string         `json:"start_date_time"`
+}
+
+type SessionV211 struct {
+	AuthId          string                `json:"auth_id"`
+	AuthMethod      string                `json:"auth_method"`
+	ChargingPeriods *[]ChargingPeriodV211 `json:"charging_periods,omitempty"`
+	Currency        string                `json:"currency"`
+	EndDatetime     *string               `json:"end_datetime,omitempty"`
+	Id              string                `json:"id"`
+	Kwh             float32               `json:"kwh"`
+	LastUpdated     string                `json:"last_updated"`
+	Location        LocationV211          `json:"location"`
+	MeterId         *string               `json:"meter_id,omitempty"`
+	StartDatetime   string                `json:"start_datetime"`
+	Status          string                `json:"status"`
+	TotalCost       *float32              `json:"total_cost,omitempty"`
+}
+
+type CdrV211 struct {
+	AuthId           string               `json:"auth_id"`
+	AuthMethod       string               `json:"auth_method"`
+	ChargingPeriods  []ChargingPeriodV211 `json:"charging_periods"`
+	Currency         string               `json:"currency"`
+	Id               string               `json:"id"`
+	LastUpdated      string               `json:"last_updated"`
+	Location         LocationV211         `json:"location"`
+	MeterId          *string              `json:"meter_id,omitempty"`
+	Remark           *string              `json:"remark,omitempty"`
+	StartDateTime    string               `json:"start_date_time"`
+	StopDateTime     string               `json:"stop_date_time"`
+	TotalCost        float32              `json:"total_cost"`
+	TotalEnergy      float32              `json:"total_energy"`
+	TotalParkingTime *float32             `json:"total_parking_time,omitempty"`
+	TotalTime        float32              `json:"total_time"`
+}
+
+// ToCredentials converts 2.1.1 credentials, which only describe a single
+// party, into 2.2 credentials with the given role.
+func (c CredentialsV211) ToCredentials(role CredentialsRoleRole) Credentials {
+	return Credentials{
+		Roles: []CredentialsRole{
+			{
+				BusinessDetails: c.BusinessDetails,
+				CountryCode:     c.CountryCode,
+				PartyId:         c.PartyId,
+				Role:            role,
+			},
+		},
+		Token: c.Token,
+		Url:   c.Url,
+	}
+}
+
+// NewCredentialsV211 converts 2.2 credentials into 2.1.1 credentials using
+// the first role.
+func NewCredentialsV211(credentials Credentials) CredentialsV211 {
+	creds := CredentialsV211{
+		Token: credentials.Token,
+		Url:   credentials.Url,
+	}
+	if len(credentials.Roles) > 0 {
+		creds.BusinessDetails = credentials.Roles[0].BusinessDetails
+		creds.CountryCode = credentials.Roles[0].CountryCode
+		creds.PartyId = credentials.Roles[0].PartyId
+	}
+	return creds
+}
+
+// ToToken converts a 2.1.1 token into a 2.2 token: 2.1.1 tokens do not carry
+// the country code and party id of the issuing party, these are taken from
+// the request.
+func (t TokenV211) ToToken(countryCode, partyId string) Token {
+	return Token{
+		ContractId:   t.AuthId,
+		CountryCode:  countryCode,
+		Issuer:       t.Issuer,
+		Language:     t.Language,
+		LastUpdated:  t.LastUpdated,
+		PartyId:      partyId,
+		Type:         t.Type,
+		Uid:          t.Uid,
+		Valid:        t.Valid,
+		VisualNumber: t.VisualNumber,
+		Whitelist:    t.Whitelist,
+	}
+}
+
+func NewTokenV211(token Token) TokenV211 {
+	return TokenV211{
+		AuthId:       token.ContractId,
+		Issuer:       token.Issuer,
+		Language:     token.Language,
+		LastUpdated:  token.LastUpdated,
+		Type:         token.Type,
+		Uid:          token.Uid,
+		Valid:        token.Valid,
+		VisualNumber: token.VisualNumber,
+		Whitelist:    token.Whitelist,
+	}
+}
+
+func NewLocationV211(location Location) LocationV211 {
+	loc := LocationV211{
+		Address:          location.Address,
+		City:             location.City,
+		Coordinates:      location.Coordinates,
+		Country:          location.Country,
+		Directions:       location.Directions,
+		EnergyMix:        location.EnergyMix,
+		Facilities:       location.Facilities,
+		Id:               location.Id,
+		Images:           location.Images,
+		LastUpdated:      location.LastUpdated,
+		Name:             location.Name,
+		OpeningTimes:     location.OpeningTimes,
+		Operator:         location.Operator,
+		Owner:            location.Owner,
+		RelatedLocations: location.RelatedLocations,
+		Suboperator:      location.Suboperator,
+		TimeZone:         location.TimeZone,
+		Type:             locationTypeV211(location.ParkingType),
+	}
+	if location.PostalCode != nil {
+		loc.PostalCode = *location.PostalCode
+	}
+	if location.ChargingWhenClosed != nil {
+		if chargingWhenClosed, err := strconv.ParseBool(*location.ChargingWhenClosed); err == nil {
+			loc.ChargingWhenClosed = &chargingWhenClosed
+		}
+	}
+	if location.Evses != nil {
+		evses := make([]EvseV211, len(*location.Evses))
+		for i, evse := range *location.Evses {
+			evses[i] = newEvseV211(evse)
+		}
+		loc.Evses = &evses
+	}
+	return loc
+}
+
+func locationTypeV211(parkingType *LocationParkingType) string {
+	if parkingType == nil {
+		return "UNKNOWN"
+	}
+	switch *parkingType {
+	case LocationParkingTypeONSTREET, LocationParkingTypePARKINGGARAGE,
+		LocationParkingTypePARKINGLOT, LocationParkingTypeUNDERGROUNDGARAGE:
+		return string(*parkingType)
+	default:
+		return "OTHER"
+	}
+}
+
+func newEvseV211(evse Evse) EvseV211 {
+	connectors := make([]ConnectorV211, len(evse.Connectors))
+	for i, connector := range evse.Connectors {
+		connectors[i] = newConnectorV211(connector)
+	}
+	return EvseV211{
+		Capabilities:        evse.Capabilities,
+		Connectors:          connectors,
+		Coordinates:         evse.Coordinates,
+		Directions:          evse.Directions,
+		EvseId:              evse.EvseId,
+		FloorLevel:          evse.FloorLevel,
+		Images:              evse.Images,
+		LastUpdated:         evse.LastUpdated,
+		ParkingRestrictions: evse.ParkingRestrictions,
+		PhysicalReference:   evse.PhysicalReference,
+		Status:              evse.Status,
+		StatusSchedule:      evse.StatusSchedule,
+		Uid:                 evse.Uid,
+	}
+}
+
+func newConnectorV211(connector Connector) ConnectorV211 {
+	conn := ConnectorV211{
+		Amperage:           connector.MaxAmperage,
+		Format:             connector.Format,
+		Id:                 connector.Id,
+		LastUpdated:        connector.LastUpdated,
+		PowerType:          connector.PowerType,
+		Standard:           connector.Standard,
+		TermsAndConditions: connector.TermsAndConditions,
+		Voltage:            connector.MaxVoltage,
+	}
+	// 2.1.1 only supports a single tariff per connector
+	if connector.TariffIds != nil && len(*connector.TariffIds) > 0 {
+		conn.TariffId = &(*connector.TariffIds)[0]
+	}
+	return conn
+}
+
+// NewSessionV211 converts a 2.2 session into a 2.1.1 session. In 2.1.1 the
+// session embeds the location restricted to the EVSE and connector in use,
+// so the location referenced by the session must be provided.
+func NewSessionV211(session Session, location Location) SessionV211 {
+	loc := NewLocationV211(location)
+	if loc.Evses != nil {
+		var evses []EvseV211
+		for _, evse := range *loc.Evses {
+			if evse.Uid != session.EvseUid {
+				continue
+			}
+			var connectors []ConnectorV211
+			for _, connector := range evse.Connectors {
+				if connector.Id == session.ConnectorId {
+					connectors = append(connectors, connector)
+				}
+			}
+			evse.Connectors = connectors
+			evses = append(evses, evse)
+		}
+		loc.Evses = &evses
+	}
+
+	sess := SessionV211{
+		AuthId:        session.CdrToken.ContractId,
+		AuthMethod:    authMethodV211(string(session.AuthMethod)),
+		Currency:      session.Currency,
+		EndDatetime:   session.EndDateTime,
+		Id:            session.Id,
+		Kwh:           session.Kwh,
+		LastUpdated:   session.LastUpdated,
+		Location:      loc,
+		MeterId:       session.MeterId,
+		StartDatetime: session.StartDateTime,
+		Status:        sessionStatusV211(session.Status),
+	}
+	if session.ChargingPeriods != nil {
+		chargingPeriods := newChargingPeriodsV211(*session.ChargingPeriods)
+		sess.ChargingPeriods = &chargingPeriods
+	}
+	if session.TotalCost != nil {
+		sess.TotalCost = &session.TotalCost.ExclVat
+	}
+	return sess
+}
+
+// NewCdrV211 converts a 2.2 CDR into a 2.1.1 CDR. Tariffs are not included as
+// the 2.1.1 tariff model is not compatible with 2.2.
+func NewCdrV211(cdr CDR) CdrV211 {
+	connectorId := cdr.CdrLocation.ConnectorId
+	evseId := cdr.CdrLocation.EvseId
+	postalCode := cdr.CdrLocation.PostalCode
+	loc := NewLocationV211(Location{
+		Address:     cdr.CdrLocation.Address,
+		City:        cdr.CdrLocation.City,
+		Coordinates: cdr.CdrLocation.Coordinates,
+		Country:     cdr.CdrLocation.Country,
+		Evses: &[]Evse{
+			{
+				Connectors: []Connector{
+					{
+						Format:      ConnectorFormat(cdr.CdrLocation.ConnectorFormat),
+						Id:          connectorId,
+						LastUpdated: cdr.LastUpdated,
+						PowerType:   ConnectorPowerType(cdr.CdrLocation.ConnectorPowerType),
+						Standard:    ConnectorStandard(cdr.CdrLocation.ConnectorStandard),
+					},
+				},
+				EvseId:      &evseId,
+				LastUpdated: cdr.LastUpdated,
+				Status:      EvseStatusAVAILABLE,
+				Uid:         cdr.CdrLocation.EvseUid,
+			},
+		},
+		Id:          cdr.CdrLocation.Id,
+		LastUpdated: cdr.LastUpdated,
+		Name:        cdr.CdrLocation.Name,
+		PostalCode:  &postalCode,
+	})
+
+	return CdrV211{
+		AuthId:           cdr.CdrToken.ContractId,
+		AuthMethod:       authMethodV211(string(cdr.AuthMethod)),
+		ChargingPeriods:  newChargingPeriodsV211(cdr.ChargingPeriods),
+		Currency:         cdr.Currency,
+		Id:               cdr.Id,
+		LastUpdated:      cdr.LastUpdated,
+		Location:         loc,
+		MeterId:          cdr.MeterId,
+		Remark:           cdr.Remark,
+		StartDateTime:    cdr.StartDateTime,
+		StopDateTime:     cdr.EndDateTime,
+		TotalCost:        cdr.TotalCost.ExclVat,
+		TotalEnergy:      cdr.TotalEnergy,
+		TotalParkingTime: cdr.TotalParkingTime,
+		TotalTime:        cdr.TotalTime,
+	}
+}
+
+func newChargingPeriodsV211(chargingPeriods []ChargingPeriod) []ChargingPeriodV211 {
+	periods := make([]ChargingPeriodV211, len(chargingPeriods))
+	for i, period := range chargingPeriods {
+		periods[i] = ChargingPeriodV211{
+			Dimensions:    period.Dimensions,
+			StartDateTime: period.StartDateTime,
+		}
+	}
+	return periods
+}
+
+// authMethodV211 maps the 2.2 auth method to 2.1.1: 2.1.1 has no COMMAND
+// method, remote starts were reported as AUTH_REQUEST.
+func authMethodV211(authMethod string) string {
+	if authMethod == string(SessionAuthMethodCOMMAND) {
+		return string(SessionAuthMethodAUTHREQUEST)
+	}
+	return authMethod
+}
+
+// sessionStatusV211 maps the 2.2 session status to 2.1.1: 2.1.1 has no
+// RESERVATION status.
+func sessionStatusV211(status SessionStatus) string {
+	switch status {
+	case SessionStatusACTIVE, SessionStatusCOMPLETED, SessionStatusINVALID:
+		return string(status)
+	default:
+		return "PENDING"
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+)
+
+func TestNewLocationV211(t *testing.T) {
+	parkingType := ocpi.LocationParkingTypeONDRIVEWAY
+	postalCode := "AB1 2CD"
+	chargingWhenClosed := "true"
+	tariffIds := []string{"tariff001", "tariff002"}
+
+	got := ocpi.NewLocationV211(ocpi.Location{
+		Id:                 "loc001",
+		Address:            "1 High Street",
+		ChargingWhenClosed: &chargingWhenClosed,
+		ParkingType:        &parkingType,
+		PostalCode:         &postalCode,
+		Evses: &[]ocpi.Evse{
+			{
+				Uid:    "GB*TWK*E001",
+				Status: ocpi.EvseStatusAVAILABLE,
+				Connectors: []ocpi.Connector{
+					{
+						Id:          "1",
+						MaxAmperage: 32,
+						MaxVoltage:  230,
+						TariffIds:   &tariffIds,
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, "loc001", got.Id)
+	assert.Equal(t, "OTHER", got.Type)
+	assert.Equal(t, "AB1 2CD", got.PostalCode)
+	assert.Equal(t, true, *got.ChargingWhenClosed)
+	connector := (*got.Evses)[0].Connectors[0]
+	assert.Equal(t, int32(32), connector.Amperage)
+	assert.Equal(t, int32(230), connector.Voltage)
+	assert.Equal(t, "tariff001", *connector.TariffId)
+}
+
+func TestNewSessionV211(t *testing.T) {
+	totalCost := ocpi.Price{ExclVat: 10.0, InclVat: 12.0}
+	location := ocpi.Location{
+		Id: "loc001",
+		Evses: &[]ocpi.Evse{
+			{Uid: "evse1", Connectors: []ocpi.Connector{{Id: "1"}, {Id: "2"}}},
+			{Uid: "evse2", Connectors: []ocpi.Connector{{Id: "1"}}},
+		},
+	}
+
+	got := ocpi.NewSessionV211(ocpi.Session{
+		Id:          "s001",
+		AuthMethod:  ocpi.SessionAuthMethodCOMMAND,
+		CdrToken:    ocpi.CdrToken{ContractId: "GBTWKTWTW000018"},
+		EvseUid:     "evse1",
+		ConnectorId: "2",
+		Status:      ocpi.SessionStatusRESERVATION,
+		TotalCost:   &totalCost,
+	}, location)
+
+	assert.Equal(t, "GBTWKTWTW000018", got.AuthId)
+	assert.Equal(t, "AUTH_REQUEST", got.AuthMethod)
+	assert.Equal(t, "PENDING", got.Status)
+	assert.Equal(t, float32(10.0), *got.TotalCost)
+	assert.Len(t, *got.Location.Evses, 1)
+	assert.Equal(t, "evse1", (*got.Location.Evses)[0].Uid)
+	assert.Equal(t, []ocpi.ConnectorV211{{Id: "2"}}, (*got.Location.Evses)[0].Connectors)
+}
+
+func TestNewCdrV211(t *testing.T) {
+	got := ocpi.NewCdrV211(ocpi.CDR{
+		Id:            "cdr001",
+		AuthMethod:    ocpi.CDRAuthMethodWHITELIST,
+		CdrToken:      ocpi.CdrToken{ContractId: "GBTWKTWTW000018"},
+		StartDateTime: "2024-01-01T10:00:00Z",
+		EndDateTime:   "2024-01-01T11:00:00Z",
+		CdrLocation: ocpi.CdrLocation{
+			Id:          "loc001",
+			EvseUid:     "evse1",
+			EvseId:      "GB*TWK*E001",
+			ConnectorId: "1",
+			PostalCode:  "AB1 2CD",
+		},
+		ChargingPeriods: []ocpi.ChargingPeriod{
+			{StartDateTime: "2024-01-01T10:00:00Z", Dimensions: []ocpi.CdrDimension{{Type: "ENERGY", Volume: 7.5}}},
+		},
+		TotalCost:   ocpi.Price{ExclVat: 3.0, InclVat: 3.6},
+		TotalEnergy: 7.5,
+		TotalTime:   1.0,
+	})
+
+	assert.Equal(t, "cdr001", got.Id)
+	assert.Equal(t, "WHITELIST", got.AuthMethod)
+	assert.Equal(t, "2024-01-01T11:00:00Z", got.StopDateTime)
+	assert.Equal(t, float32(3.0), got.TotalCost)
+	assert.Equal(t, "loc001", got.Location.Id)
+	assert.Equal(t, "AB1 2CD", got.Location.PostalCode)
+	assert.Equal(t, "GB*TWK*E001", *(*got.Location.Evses)[0].EvseId)
+	assert.Len(t, got.ChargingPeriods, 1)
+}
+
+func TestCredentialsV211RoundTrip(t *testing.T) {
+	creds := ocpi.CredentialsV211{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Token:       "abc",
+		Url:         "https://example.com/ocpi/versions",
+	}
+
+	got := ocpi.NewCredentialsV211(creds.ToCredentials(ocpi.CredentialsRoleRoleEMSP))
+	assert.Equal(t, creds, got)
+}
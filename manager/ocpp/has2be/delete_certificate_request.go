@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+type DeleteCertificateRequestJson struct {
+	// CertificateHashData corresponds to the JSON schema field "certificateHashData".
+	CertificateHashData CertificateHashDataType `json:"certificateHashData" yaml:"certificateHashData" mapstructure:"certificateHashData"`
+}
+
+func (*DeleteCertificateRequestJson) IsRequest() {}
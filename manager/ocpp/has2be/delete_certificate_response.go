@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+type DeleteCertificateStatusEnumType string
+
+const DeleteCertificateStatusEnumTypeAccepted DeleteCertificateStatusEnumType = "Accepted"
+const DeleteCertificateStatusEnumTypeFailed DeleteCertificateStatusEnumType = "Failed"
+const DeleteCertificateStatusEnumTypeNotFound DeleteCertificateStatusEnumType = "NotFound"
+
+type DeleteCertificateResponseJson struct {
+	// Status corresponds to the JSON schema field "status".
+	Status DeleteCertificateStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+}
+
+func (*DeleteCertificateResponseJson) IsResponse() {}
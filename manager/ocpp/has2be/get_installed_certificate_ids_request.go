@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+type GetCertificateIdUseEnumType string
+
+const GetCertificateIdUseEnumTypeCSMSRootCertificate GetCertificateIdUseEnumType = "CSMSRootCertificate"
+const GetCertificateIdUseEnumTypeMORootCertificate GetCertificateIdUseEnumType = "MORootCertificate"
+const GetCertificateIdUseEnumTypeManufacturerRootCertificate GetCertificateIdUseEnumType = "ManufacturerRootCertificate"
+const GetCertificateIdUseEnumTypeV2GCertificateChain GetCertificateIdUseEnumType = "V2GCertificateChain"
+const GetCertificateIdUseEnumTypeV2GRootCertificate GetCertificateIdUseEnumType = "V2GRootCertificate"
+
+type GetInstalledCertificateIdsRequestJson struct {
+	// Indicates the type of certificates requested. When omitted, all certificate
+	// types are requested.
+	TypeOfCertificate []GetCertificateIdUseEnumType `json:"typeOfCertificate,omitempty" yaml:"typeOfCertificate,omitempty" mapstructure:"typeOfCertificate,omitempty"`
+}
+
+func (*GetInstalledCertificateIdsRequestJson) IsRequest() {}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+type GetInstalledCertificateStatusEnumType string
+
+const GetInstalledCertificateStatusEnumTypeAccepted GetInstalledCertificateStatusEnumType = "Accepted"
+const GetInstalledCertificateStatusEnumTypeNotFound GetInstalledCertificateStatusEnumType = "NotFound"
+
+type GetInstalledCertificateIdsResponseJson struct {
+	// CertificateHashDataChain corresponds to the JSON schema field
+	// "certificateHashDataChain".
+	CertificateHashDataChain []CertificateHashDataType `json:"certificateHashDataChain,omitempty" yaml:"certificateHashDataChain,omitempty" mapstructure:"certificateHashDataChain,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status GetInstalledCertificateStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+}
+
+func (*GetInstalledCertificateIdsResponseJson) IsResponse() {}
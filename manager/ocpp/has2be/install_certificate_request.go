@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+type InstallCertificateUseEnumType string
+
+const InstallCertificateUseEnumTypeCSMSRootCertificate InstallCertificateUseEnumType = "CSMSRootCertificate"
+const InstallCertificateUseEnumTypeMORootCertificate InstallCertificateUseEnumType = "MORootCertificate"
+const InstallCertificateUseEnumTypeManufacturerRootCertificate InstallCertificateUseEnumType = "ManufacturerRootCertificate"
+const InstallCertificateUseEnumTypeV2GRootCertificate InstallCertificateUseEnumType = "V2GRootCertificate"
+
+type InstallCertificateRequestJson struct {
+	// An X.509 certificate, first DER encoded into binary, and then hex encoded into
+	// a case insensitive string.
+	Certificate string `json:"certificate" yaml:"certificate" mapstructure:"certificate"`
+
+	// CertificateType corresponds to the JSON schema field "certificateType".
+	CertificateType InstallCertificateUseEnumType `json:"certificateType" yaml:"certificateType" mapstructure:"certificateType"`
+}
+
+func (*InstallCertificateRequestJson) IsRequest() {}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package has2be
+
+type InstallCertificateStatusEnumType string
+
+const InstallCertificateStatusEnumTypeAccepted InstallCertificateStatusEnumType = "Accepted"
+const InstallCertificateStatusEnumTypeFailed InstallCertificateStatusEnumType = "Failed"
+const InstallCertificateStatusEnumTypeRejected InstallCertificateStatusEnumType = "Rejected"
+
+type InstallCertificateResponseJson struct {
+	// Status corresponds to the JSON schema field "status".
+	Status InstallCertificateStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+}
+
+func (*InstallCertificateResponseJson) IsResponse() {}
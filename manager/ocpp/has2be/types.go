@@ -29,3 +29,17 @@ type OCSPRequestDataType struct {
 	// The serial number of the certificate.
 	SerialNumber string `json:"serialNumber" yaml:"serialNumber" mapstructure:"serialNumber"`
 }
+
+type CertificateHashDataType struct {
+	// HashAlgorithm corresponds to the JSON schema field "hashAlgorithm".
+	HashAlgorithm HashAlgorithmEnumType `json:"hashAlgorithm" yaml:"hashAlgorithm" mapstructure:"hashAlgorithm"`
+
+	// Hashed value of the issuers public key
+	IssuerKeyHash string `json:"issuerKeyHash" yaml:"issuerKeyHash" mapstructure:"issuerKeyHash"`
+
+	// Hashed value of the Issuer DN (Distinguished Name).
+	IssuerNameHash string `json:"issuerNameHash" yaml:"issuerNameHash" mapstructure:"issuerNameHash"`
+
+	// The serial number of the certificate.
+	SerialNumber string `json:"serialNumber" yaml:"serialNumber" mapstructure:"serialNumber"`
+}
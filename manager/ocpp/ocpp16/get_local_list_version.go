@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+type GetLocalListVersionJson map[string]interface{}
+
+func (*GetLocalListVersionJson) IsRequest() {}
@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+type GetLocalListVersionResponseJson struct {
+	// ListVersion corresponds to the JSON schema field "listVersion".
+	ListVersion int `json:"listVersion" yaml:"listVersion" mapstructure:"listVersion"`
+}
+
+func (*GetLocalListVersionResponseJson) IsResponse() {}
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+// MaxIdTagLength is the maximum length of an IdToken (used for idTag and parentIdTag),
+// which is a CiString20Type
+const MaxIdTagLength = 20
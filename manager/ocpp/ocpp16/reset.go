@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+type ResetJson struct {
+	// Type corresponds to the JSON schema field "type".
+	Type ResetJsonType `json:"type" yaml:"type" mapstructure:"type"`
+}
+
+func (*ResetJson) IsRequest() {}
+
+type ResetJsonType string
+
+const ResetJsonTypeHard ResetJsonType = "Hard"
+const ResetJsonTypeSoft ResetJsonType = "Soft"
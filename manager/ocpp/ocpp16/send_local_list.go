@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+type SendLocalListJson struct {
+	// ListVersion corresponds to the JSON schema field "listVersion".
+	ListVersion int `json:"listVersion" yaml:"listVersion" mapstructure:"listVersion"`
+
+	// LocalAuthorizationList corresponds to the JSON schema field
+	// "localAuthorizationList".
+	LocalAuthorizationList []SendLocalListJsonLocalAuthorizationListElem `json:"localAuthorizationList,omitempty" yaml:"localAuthorizationList,omitempty" mapstructure:"localAuthorizationList,omitempty"`
+
+	// UpdateType corresponds to the JSON schema field "updateType".
+	UpdateType SendLocalListJsonUpdateType `json:"updateType" yaml:"updateType" mapstructure:"updateType"`
+}
+
+func (*SendLocalListJson) IsRequest() {}
+
+type SendLocalListJsonLocalAuthorizationListElem struct {
+	// IdTag corresponds to the JSON schema field "idTag".
+	IdTag string `json:"idTag" yaml:"idTag" mapstructure:"idTag"`
+
+	// IdTagInfo corresponds to the JSON schema field "idTagInfo".
+	IdTagInfo *SendLocalListJsonLocalAuthorizationListElemIdTagInfo `json:"idTagInfo,omitempty" yaml:"idTagInfo,omitempty" mapstructure:"idTagInfo,omitempty"`
+}
+
+type SendLocalListJsonLocalAuthorizationListElemIdTagInfo struct {
+	// ExpiryDate corresponds to the JSON schema field "expiryDate".
+	ExpiryDate *string `json:"expiryDate,omitempty" yaml:"expiryDate,omitempty" mapstructure:"expiryDate,omitempty"`
+
+	// ParentIdTag corresponds to the JSON schema field "parentIdTag".
+	ParentIdTag *string `json:"parentIdTag,omitempty" yaml:"parentIdTag,omitempty" mapstructure:"parentIdTag,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatus `json:"status" yaml:"status" mapstructure:"status"`
+}
+
+type SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatus string
+
+const SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatusAccepted SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatus = "Accepted"
+const SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatusBlocked SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatus = "Blocked"
+const SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatusConcurrentTx SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatus = "ConcurrentTx"
+const SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatusExpired SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatus = "Expired"
+const SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatusInvalid SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatus = "Invalid"
+
+type SendLocalListJsonUpdateType string
+
+const SendLocalListJsonUpdateTypeDifferential SendLocalListJsonUpdateType = "Differential"
+const SendLocalListJsonUpdateTypeFull SendLocalListJsonUpdateType = "Full"
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+type SendLocalListResponseJsonStatus string
+
+const SendLocalListResponseJsonStatusAccepted SendLocalListResponseJsonStatus = "Accepted"
+const SendLocalListResponseJsonStatusFailed SendLocalListResponseJsonStatus = "Failed"
+const SendLocalListResponseJsonStatusNotSupported SendLocalListResponseJsonStatus = "NotSupported"
+const SendLocalListResponseJsonStatusVersionMismatch SendLocalListResponseJsonStatus = "VersionMismatch"
+
+type SendLocalListResponseJson struct {
+	// Status corresponds to the JSON schema field "status".
+	Status SendLocalListResponseJsonStatus `json:"status" yaml:"status" mapstructure:"status"`
+}
+
+func (*SendLocalListResponseJson) IsResponse() {}
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+type CostUpdatedRequestJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Current total cost, based on the information known by the CSMS, of the
+	// transaction including taxes. In the currency configured with the configuration
+	// Variable: [&lt;&lt;configkey-currency, Currency&gt;&gt;]
+	//
+	//
+	TotalCost float64 `json:"totalCost" yaml:"totalCost" mapstructure:"totalCost"`
+
+	// Transaction Id of the transaction the current cost are asked for.
+	//
+	//
+	TransactionId string `json:"transactionId" yaml:"transactionId" mapstructure:"transactionId"`
+}
+
+func (*CostUpdatedRequestJson) IsRequest() {}
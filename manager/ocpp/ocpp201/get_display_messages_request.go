@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+type GetDisplayMessagesRequestJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// If provided the Charging Station shall return Display Messages of the given
+	// ids. This field SHALL NOT contain more ids than set in
+	// &lt;&lt;configkey-number-of-display-messages,NumberOfDisplayMessages.maxLimit&gt;&gt;
+	//
+	//
+	Id []int `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id,omitempty"`
+
+	// Priority corresponds to the JSON schema field "priority".
+	Priority *MessagePriorityEnumType `json:"priority,omitempty" yaml:"priority,omitempty" mapstructure:"priority,omitempty"`
+
+	// The Id of this request.
+	//
+	RequestId int `json:"requestId" yaml:"requestId" mapstructure:"requestId"`
+
+	// State corresponds to the JSON schema field "state".
+	State *MessageStateEnumType `json:"state,omitempty" yaml:"state,omitempty" mapstructure:"state,omitempty"`
+}
+
+func (*GetDisplayMessagesRequestJson) IsRequest() {}
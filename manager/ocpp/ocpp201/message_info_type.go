@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+// Contains message details, for a message to be displayed on a Charging Station.
+type MessageInfoType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Display corresponds to the JSON schema field "display".
+	Display *ComponentType `json:"display,omitempty" yaml:"display,omitempty" mapstructure:"display,omitempty"`
+
+	// Message_ Info. End. Date_ Time
+	// urn:x-enexis:ecdm:uid:1:569257
+	// Until what date-time should this message be shown, after this date/time this
+	// message SHALL be removed.
+	//
+	EndDateTime *string `json:"endDateTime,omitempty" yaml:"endDateTime,omitempty" mapstructure:"endDateTime,omitempty"`
+
+	// Identified_ Object. MRID. Numeric_ Identifier
+	// urn:x-enexis:ecdm:uid:1:569198
+	// Master resource identifier, unique within an exchange context. It is defined
+	// within the OCPP context as a positive Integer value (greater or equal to zero).
+	//
+	Id int `json:"id" yaml:"id" mapstructure:"id"`
+
+	// Message corresponds to the JSON schema field "message".
+	Message MessageContentType `json:"message" yaml:"message" mapstructure:"message"`
+
+	// Priority corresponds to the JSON schema field "priority".
+	Priority MessagePriorityEnumType `json:"priority" yaml:"priority" mapstructure:"priority"`
+
+	// Message_ Info. Start. Date_ Time
+	// urn:x-enexis:ecdm:uid:1:569256
+	// From what date-time should this message be shown. If omitted: directly.
+	//
+	StartDateTime *string `json:"startDateTime,omitempty" yaml:"startDateTime,omitempty" mapstructure:"startDateTime,omitempty"`
+
+	// State corresponds to the JSON schema field "state".
+	State *MessageStateEnumType `json:"state,omitempty" yaml:"state,omitempty" mapstructure:"state,omitempty"`
+
+	// During which transaction shall this message be shown.
+	// Message SHALL be removed by the Charging Station after transaction has
+	// ended.
+	//
+	TransactionId *string `json:"transactionId,omitempty" yaml:"transactionId,omitempty" mapstructure:"transactionId,omitempty"`
+}
+
+type MessagePriorityEnumType string
+
+const MessagePriorityEnumTypeAlwaysFront MessagePriorityEnumType = "AlwaysFront"
+const MessagePriorityEnumTypeInFront MessagePriorityEnumType = "InFront"
+const MessagePriorityEnumTypeNormalCycle MessagePriorityEnumType = "NormalCycle"
+
+type MessageStateEnumType string
+
+const MessageStateEnumTypeCharging MessageStateEnumType = "Charging"
+const MessageStateEnumTypeFaulted MessageStateEnumType = "Faulted"
+const MessageStateEnumTypeIdle MessageStateEnumType = "Idle"
+const MessageStateEnumTypeUnavailable MessageStateEnumType = "Unavailable"
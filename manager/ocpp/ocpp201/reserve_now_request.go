@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+type ConnectorEnumType string
+
+const ConnectorEnumTypeCCCS1 ConnectorEnumType = "cCCS1"
+const ConnectorEnumTypeCCCS2 ConnectorEnumType = "cCCS2"
+const ConnectorEnumTypeCG105 ConnectorEnumType = "cG105"
+const ConnectorEnumTypeCTesla ConnectorEnumType = "cTesla"
+const ConnectorEnumTypeCType1 ConnectorEnumType = "cType1"
+const ConnectorEnumTypeCType2 ConnectorEnumType = "cType2"
+const ConnectorEnumTypeOther1PhMax16A ConnectorEnumType = "Other1PhMax16A"
+const ConnectorEnumTypeOther1PhOver16A ConnectorEnumType = "Other1PhOver16A"
+const ConnectorEnumTypeOther3Ph ConnectorEnumType = "Other3Ph"
+const ConnectorEnumTypePan ConnectorEnumType = "Pan"
+const ConnectorEnumTypeS3091P16A ConnectorEnumType = "s309-1P-16A"
+const ConnectorEnumTypeS3091P32A ConnectorEnumType = "s309-1P-32A"
+const ConnectorEnumTypeS3093P16A ConnectorEnumType = "s309-3P-16A"
+const ConnectorEnumTypeS3093P32A ConnectorEnumType = "s309-3P-32A"
+const ConnectorEnumTypeSBS1361 ConnectorEnumType = "sBS1361"
+const ConnectorEnumTypeSCEE77 ConnectorEnumType = "sCEE-7-7"
+const ConnectorEnumTypeSType2 ConnectorEnumType = "sType2"
+const ConnectorEnumTypeSType3 ConnectorEnumType = "sType3"
+const ConnectorEnumTypeUndetermined ConnectorEnumType = "Undetermined"
+const ConnectorEnumTypeUnknown ConnectorEnumType = "Unknown"
+const ConnectorEnumTypeWInductive ConnectorEnumType = "wInductive"
+const ConnectorEnumTypeWResonant ConnectorEnumType = "wResonant"
+
+type ReserveNowRequestJson struct {
+	// ConnectorType corresponds to the JSON schema field "connectorType".
+	ConnectorType *ConnectorEnumType `json:"connectorType,omitempty" yaml:"connectorType,omitempty" mapstructure:"connectorType,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// This contains ID of the evse to be reserved.
+	//
+	EvseId *int `json:"evseId,omitempty" yaml:"evseId,omitempty" mapstructure:"evseId,omitempty"`
+
+	// Date and time at which the reservation expires.
+	//
+	ExpiryDateTime string `json:"expiryDateTime" yaml:"expiryDateTime" mapstructure:"expiryDateTime"`
+
+	// GroupIdToken corresponds to the JSON schema field "groupIdToken".
+	GroupIdToken *IdTokenType `json:"groupIdToken,omitempty" yaml:"groupIdToken,omitempty" mapstructure:"groupIdToken,omitempty"`
+
+	// Id of reservation.
+	//
+	Id int `json:"id" yaml:"id" mapstructure:"id"`
+
+	// IdToken corresponds to the JSON schema field "idToken".
+	IdToken IdTokenType `json:"idToken" yaml:"idToken" mapstructure:"idToken"`
+}
+
+func (*ReserveNowRequestJson) IsRequest() {}
@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+type SetDisplayMessageRequestJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Message corresponds to the JSON schema field "message".
+	Message MessageInfoType `json:"message" yaml:"message" mapstructure:"message"`
+}
+
+func (*SetDisplayMessageRequestJson) IsRequest() {}
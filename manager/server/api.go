@@ -10,9 +10,11 @@ import (
 	"github.com/rs/cors"
 	"github.com/thoughtworks/maeve-csms/manager/api"
 	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
 	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"github.com/unrolled/secure"
 	"k8s.io/utils/clock"
 
@@ -21,8 +23,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func NewApiHandler(settings config.ApiSettings, engine store.Engine, ocpi ocpi.Api, csCertProvider services.ChargeStationCertificateProvider) http.Handler {
-	apiServer, err := api.NewServer(engine, clock.RealClock{}, ocpi)
+func NewApiHandler(settings config.ApiSettings, engine store.Engine, ocpi ocpi.Api, csCertProvider services.ChargeStationCertificateProvider, v16CallMaker, v201CallMaker handlers.SyncCallMaker, controller transport.ConnectionController) http.Handler {
+	apiServer, err := api.NewServer(engine, clock.RealClock{}, ocpi, v16CallMaker, v201CallMaker,
+		services.GetCertificateRevocationListProvider(csCertProvider), controller)
 	if err != nil {
 		panic(err)
 	}
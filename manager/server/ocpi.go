@@ -53,6 +53,7 @@ func NewOcpiHandler(engine store.Engine, clock clock.PassiveClock, ocpiApi ocpi.
 	swagger.Servers = nil
 	r.Use(middleware.Recoverer, secureMiddleware.Handler, cors.Default().Handler, logger)
 	r.Get("/openapi.json", getOcpiSwaggerJson)
+	r.With(ocpi.NewTokenAuthenticationMiddleware(engine)).Mount("/ocpi/2.1.1", ocpi.HandlerV211(ocpiServer))
 	r.With(oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapimiddleware.Options{
 		Options: openapi3filter.Options{
 			AuthenticationFunc: ocpi.NewTokenAuthenticationFunc(engine),
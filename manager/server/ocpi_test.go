@@ -80,7 +80,7 @@ func TestAPIRequestWithValidToken(t *testing.T) {
 		t.Errorf("status code: want %d, got %d", http.StatusOK, res.StatusCode)
 	}
 
-	assert.JSONEq(t, `{"status_code":1000,"status_message":"Success","timestamp":"2023-06-15T15:05:00Z","data":[{"url":"/ocpi/2.2","version":"2.2"}]}`, string(b))
+	assert.JSONEq(t, `{"status_code":1000,"status_message":"Success","timestamp":"2023-06-15T15:05:00Z","data":[{"url":"/ocpi/2.2","version":"2.2"},{"url":"/ocpi/2.1.1","version":"2.1.1"}]}`, string(b))
 }
 
 func TestAPIRequestWithInvalidToken(t *testing.T) {
@@ -105,3 +105,51 @@ func TestAPIRequestWithInvalidToken(t *testing.T) {
 		t.Errorf("status code: want %d, got %d", http.StatusOK, res.StatusCode)
 	}
 }
+
+func TestV211RequestWithInvalidToken(t *testing.T) {
+	token := "abcdef123456"
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	handler := NewOcpiHandler(engine, clock.RealClock{}, ocpiApi, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ocpi/2.1.1", nil)
+	req.Header.Add("Authorization", fmt.Sprintf("Token %s", token))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	res := w.Result()
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			t.Errorf("closing body: %v", err)
+		}
+	}()
+
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status code: want %d, got %d", http.StatusUnauthorized, res.StatusCode)
+	}
+}
+
+func TestV211RequestWithPendingToken(t *testing.T) {
+	token := "abcdef123456"
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetRegistrationDetails(context.Background(), token, &store.OcpiRegistration{Status: store.OcpiRegistrationStatusPending})
+	require.NoError(t, err)
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	handler := NewOcpiHandler(engine, clock.RealClock{}, ocpiApi, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ocpi/2.1.1", nil)
+	req.Header.Add("Authorization", fmt.Sprintf("Token %s", token))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	res := w.Result()
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			t.Errorf("closing body: %v", err)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("status code: want %d, got %d", http.StatusOK, res.StatusCode)
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// CertificateRevocationListProvider provides a DER encoded CRL for the certificates that have
+// been issued by the CSMS and subsequently revoked
+type CertificateRevocationListProvider interface {
+	ProvideCertificateRevocationList(ctx context.Context) ([]byte, error)
+}
+
+// crlValidity is how long a CRL is valid for: the CRL is regenerated for each request so
+// this only determines how often relying parties must fetch it
+const crlValidity = 24 * time.Hour
+
+// revocationReasonCodes maps the reasons that a certificate can be revoked for to the
+// RFC 5280 CRL reason codes
+var revocationReasonCodes = map[string]int{
+	"unspecified":          0,
+	"keyCompromise":        1,
+	"cACompromise":         2,
+	"affiliationChanged":   3,
+	"superseded":           4,
+	"cessationOfOperation": 5,
+	"privilegeWithdrawn":   9,
+}
+
+// RevocationReasonCode returns the CRL reason code for a revocation reason
+func RevocationReasonCode(reason string) (int, error) {
+	code, ok := revocationReasonCodes[reason]
+	if !ok {
+		return 0, fmt.Errorf("unknown revocation reason: %s", reason)
+	}
+	return code, nil
+}
+
+// GetCertificateRevocationListProvider returns the provider of the CRL for the CSO certificates
+// issued by the charge station certificate provider or nil if it does not issue CRLs
+func GetCertificateRevocationListProvider(provider ChargeStationCertificateProvider) CertificateRevocationListProvider {
+	switch p := provider.(type) {
+	case *DelegatingChargeStationCertificateProvider:
+		return GetCertificateRevocationListProvider(p.CSOChargeStationCertificateProvider)
+	case CertificateRevocationListProvider:
+		return p
+	default:
+		return nil
+	}
+}
+
+// ProvideCertificateRevocationList returns a CRL, signed by the local CA, that lists the revoked
+// certificates that were issued by the local CA
+func (l *LocalChargeStationCertificateProvider) ProvideCertificateRevocationList(ctx context.Context) ([]byte, error) {
+	certificate, err := readSigningCertificate(ctx, l.CertificateReader)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := getSigner(ctx, l.Signer, l.PrivateKeyReader)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("private key cannot be used for signing")
+	}
+
+	issuer := certificate.Subject.String()
+	var entries []x509.RevocationListEntry
+	var previousCertificateHash string
+	for {
+		revokedCertificates, err := l.RevocationStore.ListRevokedCertificates(ctx, 50, previousCertificateHash)
+		if err != nil {
+			return nil, fmt.Errorf("listing revoked certificates: %w", err)
+		}
+		for _, revokedCertificate := range revokedCertificates {
+			if revokedCertificate.Issuer != issuer || revokedCertificate.SerialNumber == "" {
+				continue
+			}
+			entry, err := getRevocationListEntry(revokedCertificate)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+		if len(revokedCertificates) < 50 {
+			break
+		}
+		previousCertificateHash = revokedCertificates[len(revokedCertificates)-1].CertificateHash
+	}
+
+	now := time.Now()
+	template := &x509.RevocationList{
+		RevokedCertificateEntries: entries,
+		Number:                    big.NewInt(now.Unix()),
+		ThisUpdate:                now,
+		NextUpdate:                now.Add(crlValidity),
+	}
+
+	crl, err := x509.CreateRevocationList(rand.Reader, template, certificate, signer)
+	if err != nil {
+		return nil, fmt.Errorf("creating crl: %w", err)
+	}
+	return crl, nil
+}
+
+func getRevocationListEntry(revokedCertificate *store.RevokedCertificate) (x509.RevocationListEntry, error) {
+	serialNumber, ok := new(big.Int).SetString(revokedCertificate.SerialNumber, 16)
+	if !ok {
+		return x509.RevocationListEntry{}, fmt.Errorf("invalid serial number for revoked certificate %s: %s",
+			revokedCertificate.CertificateHash, revokedCertificate.SerialNumber)
+	}
+	reasonCode := 0
+	if revokedCertificate.Reason != "" {
+		var err error
+		reasonCode, err = RevocationReasonCode(revokedCertificate.Reason)
+		if err != nil {
+			return x509.RevocationListEntry{}, err
+		}
+	}
+	return x509.RevocationListEntry{
+		SerialNumber:   serialNumber,
+		RevocationTime: revokedCertificate.RevokedAt,
+		ReasonCode:     reasonCode,
+	}, nil
+}
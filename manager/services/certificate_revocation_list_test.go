@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+)
+
+func TestLocalChargeStationCertificateProviderCertificateRevocationList(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.TODO()
+
+	caCert, caKey := createRootCACertificate(t, "test")
+	intCert, intKey := createIntermediateCACertificate(t, "int", "", caCert, caKey)
+
+	privateKey, err := x509.MarshalPKCS8PrivateKey(intKey)
+	require.NoError(t, err)
+
+	certificateProvider := &services.LocalChargeStationCertificateProvider{
+		Store:             engine,
+		RevocationStore:   engine,
+		CertificateReader: services.StringSource{Data: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intCert.Raw}))},
+		PrivateKeyReader:  services.StringSource{Data: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey}))},
+	}
+
+	chain, err := certificateProvider.ProvideCertificate(ctx, services.CertificateTypeCSO, string(createCertificateSigningRequest(t)), "cs001")
+	require.NoError(t, err)
+	block, _ := pem.Decode([]byte(chain))
+	require.NotNil(t, block)
+	leafCert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	revokedAt := time.Now().UTC().Truncate(time.Second)
+	err = engine.SetRevokedCertificate(ctx, &store.RevokedCertificate{
+		CertificateHash: getCertificateHash(leafCert),
+		ChargeStationId: "cs001",
+		SerialNumber:    leafCert.SerialNumber.Text(16),
+		Issuer:          leafCert.Issuer.String(),
+		Reason:          "keyCompromise",
+		RevokedAt:       revokedAt,
+	})
+	require.NoError(t, err)
+	// certificates issued by another CA and certificates that were unknown when they were revoked are not included
+	err = engine.SetRevokedCertificate(ctx, &store.RevokedCertificate{
+		CertificateHash: "other",
+		SerialNumber:    "1234",
+		Issuer:          "CN=other",
+		RevokedAt:       revokedAt,
+	})
+	require.NoError(t, err)
+	err = engine.SetRevokedCertificate(ctx, &store.RevokedCertificate{
+		CertificateHash: "unknown",
+		RevokedAt:       revokedAt,
+	})
+	require.NoError(t, err)
+
+	der, err := certificateProvider.ProvideCertificateRevocationList(ctx)
+	require.NoError(t, err)
+
+	crl, err := x509.ParseRevocationList(der)
+	require.NoError(t, err)
+	assert.NoError(t, crl.CheckSignatureFrom(intCert))
+	require.Len(t, crl.RevokedCertificateEntries, 1)
+	assert.Equal(t, leafCert.SerialNumber, crl.RevokedCertificateEntries[0].SerialNumber)
+	assert.Equal(t, revokedAt, crl.RevokedCertificateEntries[0].RevocationTime)
+	assert.Equal(t, 1, crl.RevokedCertificateEntries[0].ReasonCode)
+	assert.True(t, crl.NextUpdate.After(crl.ThisUpdate))
+}
+
+func TestGetCertificateRevocationListProvider(t *testing.T) {
+	localProvider := &services.LocalChargeStationCertificateProvider{}
+
+	assert.Equal(t, localProvider, services.GetCertificateRevocationListProvider(localProvider))
+	assert.Equal(t, localProvider, services.GetCertificateRevocationListProvider(&services.DelegatingChargeStationCertificateProvider{
+		V2GChargeStationCertificateProvider: &services.DefaultChargeStationCertificateProvider{},
+		CSOChargeStationCertificateProvider: localProvider,
+	}))
+	assert.Nil(t, services.GetCertificateRevocationListProvider(&services.DefaultChargeStationCertificateProvider{}))
+}
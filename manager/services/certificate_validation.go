@@ -6,17 +6,23 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/crypto/ocsp"
 	"golang.org/x/exp/slog"
 	"io"
+	"k8s.io/utils/clock"
 	"math/big"
 	"net/http"
+	"time"
 )
 
 // OCSPError is an error returned by the OCSP server in response to a check
@@ -55,10 +61,50 @@ type CertificateValidationService interface {
 	ValidateHashedCertificateChain(ctx context.Context, ocspRequestData []ocpp201.OCSPRequestDataType) (*string, error)
 }
 
+// CompositeCertificateValidationService tries each validator in turn until one
+// gives a conclusive result: either the chain is valid or it has failed validation.
+// Other errors, such as being unable to reach an OCSP responder, move on to the next
+// validator.
+type CompositeCertificateValidationService struct {
+	Validators []CertificateValidationService
+}
+
+func (c CompositeCertificateValidationService) ValidatePEMCertificateChain(ctx context.Context, pemChain []byte, eMAID string) (*string, error) {
+	return c.validate(func(validator CertificateValidationService) (*string, error) {
+		return validator.ValidatePEMCertificateChain(ctx, pemChain, eMAID)
+	})
+}
+
+func (c CompositeCertificateValidationService) ValidateHashedCertificateChain(ctx context.Context, ocspRequestData []ocpp201.OCSPRequestDataType) (*string, error) {
+	return c.validate(func(validator CertificateValidationService) (*string, error) {
+		return validator.ValidateHashedCertificateChain(ctx, ocspRequestData)
+	})
+}
+
+func (c CompositeCertificateValidationService) validate(fn func(CertificateValidationService) (*string, error)) (ocspResponse *string, err error) {
+	if len(c.Validators) == 0 {
+		return nil, errors.New("no certificate validators configured")
+	}
+	for index, validator := range c.Validators {
+		ocspResponse, err = fn(validator)
+		var validationError ValidationError
+		if err == nil || errors.As(err, &validationError) {
+			return
+		}
+		slog.Warn("certificate validation inconclusive", slog.Int("validator", index), "error", err)
+	}
+	return
+}
+
 type OnlineCertificateValidationService struct {
 	RootCertificateProvider RootCertificateProviderService
 	MaxOCSPAttempts         int
 	HttpClient              *http.Client
+	// OcspResponseCache is optional: if set, good OCSP responses are shared through the
+	// store until the earlier of the response's nextUpdate and OcspResponseCacheTTL
+	OcspResponseCache    store.OcspResponseStore
+	OcspResponseCacheTTL time.Duration
+	Clock                clock.PassiveClock
 }
 
 func (o *OnlineCertificateValidationService) ValidatePEMCertificateChain(ctx context.Context, pemChain []byte, eMAID string) (*string, error) {
@@ -71,7 +117,7 @@ func (o *OnlineCertificateValidationService) ValidatePEMCertificateChain(ctx con
 		return nil, fmt.Errorf("empty certificate chain")
 	}
 
-	err = o.validateEMAID(certificateChain[0], eMAID)
+	err = validateEMAID(certificateChain[0], eMAID)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +127,7 @@ func (o *OnlineCertificateValidationService) ValidatePEMCertificateChain(ctx con
 		return nil, err
 	}
 
-	err = o.validatePEMCertificateChain(certificateChain, rootCerts)
+	err = validatePEMCertificateChain(certificateChain, rootCerts)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +163,7 @@ func (o *OnlineCertificateValidationService) ValidateHashedCertificateChain(ctx
 	return ocspResponse, err
 }
 
-func (o *OnlineCertificateValidationService) validatePEMCertificateChain(certificateChain, rootCertificates []*x509.Certificate) error {
+func validatePEMCertificateChain(certificateChain, rootCertificates []*x509.Certificate) error {
 	if len(certificateChain) < 1 {
 		return errors.New("no certificates in chain")
 	}
@@ -151,7 +197,7 @@ func (o *OnlineCertificateValidationService) validatePEMCertificateChain(certifi
 	return nil
 }
 
-func (o *OnlineCertificateValidationService) validateEMAID(certificate *x509.Certificate, eMAID string) error {
+func validateEMAID(certificate *x509.Certificate, eMAID string) error {
 	if certificate.Subject.CommonName != eMAID {
 		return fmt.Errorf("leaf certificate CN: %s, not %s: %w", certificate.Subject.CommonName, eMAID, ValidationErrorWrongEmaid)
 	}
@@ -220,10 +266,16 @@ func (o *OnlineCertificateValidationService) performOCSPCheck(ctx context.Contex
 		return nil, nil
 	}
 
+	cacheKey := ocspResponseCacheKey(ocspRequest)
+	if ocspResponse := o.lookupCachedOCSPResponse(ctx, cacheKey); ocspResponse != nil {
+		return ocspResponse, nil
+	}
+
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		responderUrl := ocspResponderUrls[attempt%ocspResponderUrlCount]
-		ocspResponse, err := o.attemptOCSPCheck(ctx, responderUrl, ocspRequest, issuerCert)
+		ocspResponse, parsedResponse, err := o.attemptOCSPCheck(ctx, responderUrl, ocspRequest, issuerCert)
 		if err == nil {
+			o.cacheOCSPResponse(ctx, cacheKey, ocspResponse, parsedResponse)
 			return ocspResponse, nil
 		}
 		var ocspError *OCSPError
@@ -236,6 +288,52 @@ func (o *OnlineCertificateValidationService) performOCSPCheck(ctx context.Contex
 	return nil, fmt.Errorf("failed to perform ocsp check after %d attempts", maxAttempts)
 }
 
+// ocspResponseCacheKey identifies the certificate that an OCSP request is for. The request
+// is DER encoded, so requests for the same certificate have the same bytes.
+func ocspResponseCacheKey(ocspRequest []byte) string {
+	hash := sha256.Sum256(ocspRequest)
+	return hex.EncodeToString(hash[:])
+}
+
+func (o *OnlineCertificateValidationService) lookupCachedOCSPResponse(ctx context.Context, key string) *string {
+	if o.OcspResponseCache == nil {
+		return nil
+	}
+	cached, err := o.OcspResponseCache.LookupOcspResponse(ctx, key)
+	if err != nil {
+		slog.Warn("lookup cached ocsp response", "key", key, "error", err)
+		return nil
+	}
+	if cached == nil || !o.Clock.Now().Before(cached.ExpiresAt) {
+		return nil
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("ocsp.cached_response", true))
+	return &cached.Response
+}
+
+func (o *OnlineCertificateValidationService) cacheOCSPResponse(ctx context.Context, key string, ocspResponse *string, parsedResponse *ocsp.Response) {
+	if o.OcspResponseCache == nil {
+		return
+	}
+	now := o.Clock.Now()
+	expiresAt := now.Add(o.OcspResponseCacheTTL)
+	if !parsedResponse.NextUpdate.IsZero() && parsedResponse.NextUpdate.Before(expiresAt) {
+		expiresAt = parsedResponse.NextUpdate
+	}
+	if !now.Before(expiresAt) {
+		return
+	}
+	err := o.OcspResponseCache.SetOcspResponse(ctx, &store.OcspResponse{
+		Key:       key,
+		Response:  *ocspResponse,
+		FetchedAt: now,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		slog.Warn("cache ocsp response", "key", key, "error", err)
+	}
+}
+
 func (o *OnlineCertificateValidationService) createOCSPRequestFromCertificate(subjectCert, issuerCert *x509.Certificate) ([]byte, error) {
 	return ocsp.CreateRequest(subjectCert, issuerCert, nil)
 }
@@ -267,11 +365,11 @@ func (o *OnlineCertificateValidationService) createOCSPRequestFromHashData(issue
 	return req.Marshal()
 }
 
-func (o *OnlineCertificateValidationService) attemptOCSPCheck(ctx context.Context, ocspResponderUrl string, ocspRequest []byte, issuerCert *x509.Certificate) (*string, error) {
+func (o *OnlineCertificateValidationService) attemptOCSPCheck(ctx context.Context, ocspResponderUrl string, ocspRequest []byte, issuerCert *x509.Certificate) (*string, *ocsp.Response, error) {
 	//#nosec G107 - need to use OCSP URL specified in the certificate
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ocspResponderUrl, bytes.NewReader(ocspRequest))
 	if err != nil {
-		return nil, fmt.Errorf("new request: %w", err)
+		return nil, nil, fmt.Errorf("new request: %w", err)
 	}
 
 	req.Header.Add("Content-Type", "application/ocsp-request")
@@ -279,28 +377,28 @@ func (o *OnlineCertificateValidationService) attemptOCSPCheck(ctx context.Contex
 
 	resp, err := o.HttpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("post %s: %w", ocspResponderUrl, err)
+		return nil, nil, fmt.Errorf("post %s: %w", ocspResponderUrl, err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("post %s status %s", ocspResponderUrl, resp.Status)
+		return nil, nil, fmt.Errorf("post %s status %s", ocspResponderUrl, resp.Status)
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 	respBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading body: %w", err)
+		return nil, nil, fmt.Errorf("reading body: %w", err)
 	}
 	base64Response := base64.StdEncoding.EncodeToString(respBytes)
 	ocspResp, err := ocsp.ParseResponse(respBytes, issuerCert)
 	if err != nil {
-		return &base64Response, fmt.Errorf("parsing ocsp response: %w", err)
+		return &base64Response, nil, fmt.Errorf("parsing ocsp response: %w", err)
 	}
 	if ocspResp.Status != ocsp.Good {
-		return &base64Response, OCSPError(ocspResp.Status)
+		return &base64Response, ocspResp, OCSPError(ocspResp.Status)
 	}
 
-	return &base64Response, nil
+	return &base64Response, ocspResp, nil
 }
 
 func ParseCertificates(pemData []byte) ([]*x509.Certificate, error) {
@@ -19,8 +19,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"golang.org/x/crypto/ocsp"
 	"io"
+	clockTest "k8s.io/utils/clock/testing"
 	"k8s.io/utils/strings/slices"
 	"math"
 	"math/big"
@@ -35,9 +37,12 @@ type OCSPResponder struct {
 	Certificates         []*x509.Certificate
 	Keys                 []*ecdsa.PrivateKey
 	RevokedSerialNumbers []string
+	NextUpdate           time.Duration
+	RequestCount         int
 }
 
 func (o *OCSPResponder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.RequestCount++
 	reqBytes, err := io.ReadAll(r.Body)
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -99,7 +104,7 @@ func (o *OCSPResponder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			Status:       ocsp.Good,
 			SerialNumber: ocspReq.SerialNumber,
 			ThisUpdate:   now,
-			NextUpdate:   now,
+			NextUpdate:   now.Add(o.NextUpdate),
 		}
 	}
 
@@ -396,6 +401,114 @@ func TestValidatingHashedCertificateChain(t *testing.T) {
 	validateOCSPResponse(t, ocspResp)
 }
 
+func TestValidatingHashedCertificateChainWithCachedOCSPResponses(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:          t,
+		NextUpdate: 10 * time.Minute,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	validationService := services.OnlineCertificateValidationService{
+		RootCertificateProvider: services.X509RootCertificateProviderService{Certificates: rootCACerts},
+		MaxOCSPAttempts:         3,
+		HttpClient:              http.DefaultClient,
+		OcspResponseCache:       inmemory.NewStore(clock),
+		OcspResponseCacheTTL:    time.Hour,
+		Clock:                   clock,
+	}
+
+	intCAPublicKeyBytes, err := getPublicKeyBytes(intCACert.RawSubjectPublicKeyInfo)
+	require.NoError(t, err)
+	ca2PublicKeyBytes, err := getPublicKeyBytes(rootCACerts[1].RawSubjectPublicKeyInfo)
+	require.NoError(t, err)
+
+	ocspRequestData := []ocpp201.OCSPRequestDataType{
+		{
+			HashAlgorithm:  "SHA256",
+			IssuerNameHash: hashBytes(leafCert.RawIssuer),
+			IssuerKeyHash:  hashBytes(intCAPublicKeyBytes),
+			ResponderURL:   leafCert.OCSPServer[0],
+			SerialNumber:   leafCert.SerialNumber.Text(16),
+		},
+		{
+			HashAlgorithm:  "SHA256",
+			IssuerNameHash: hashBytes(intCACert.RawIssuer),
+			IssuerKeyHash:  hashBytes(ca2PublicKeyBytes),
+			ResponderURL:   intCACert.OCSPServer[0],
+			SerialNumber:   intCACert.SerialNumber.Text(16),
+		},
+	}
+
+	ocspResp, err := validationService.ValidateHashedCertificateChain(context.TODO(), ocspRequestData)
+	require.NoError(t, err)
+	validateOCSPResponse(t, ocspResp)
+	assert.Equal(t, 2, ocspResponder.RequestCount)
+
+	// served from the cache
+	cachedOcspResp, err := validationService.ValidateHashedCertificateChain(context.TODO(), ocspRequestData)
+	require.NoError(t, err)
+	assert.Equal(t, *ocspResp, *cachedOcspResp)
+	assert.Equal(t, 2, ocspResponder.RequestCount)
+
+	// the responses have passed their nextUpdate time
+	clock.SetTime(clock.Now().Add(11 * time.Minute))
+	ocspResp, err = validationService.ValidateHashedCertificateChain(context.TODO(), ocspRequestData)
+	require.NoError(t, err)
+	validateOCSPResponse(t, ocspResp)
+	assert.Equal(t, 4, ocspResponder.RequestCount)
+}
+
+func TestValidatingPEMCertificateChainWithCachedOCSPResponseExpiredByTTL(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:          t,
+		NextUpdate: 24 * time.Hour,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	validationService := services.OnlineCertificateValidationService{
+		RootCertificateProvider: services.X509RootCertificateProviderService{Certificates: rootCACerts},
+		MaxOCSPAttempts:         3,
+		HttpClient:              http.DefaultClient,
+		OcspResponseCache:       inmemory.NewStore(clock),
+		OcspResponseCacheTTL:    time.Hour,
+		Clock:                   clock,
+	}
+
+	pemChain := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: leafCert.Raw,
+	})
+	pemChain = append(pemChain, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: intCACert.Raw,
+	})...)
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	require.NoError(t, err)
+	requestCount := ocspResponder.RequestCount
+
+	clock.SetTime(clock.Now().Add(30 * time.Minute))
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	require.NoError(t, err)
+	assert.Equal(t, requestCount, ocspResponder.RequestCount)
+
+	clock.SetTime(clock.Now().Add(31 * time.Minute))
+	ocspResp, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	require.NoError(t, err)
+	validateOCSPResponse(t, ocspResp)
+	assert.Equal(t, 2*requestCount, ocspResponder.RequestCount)
+}
+
 func TestValidatingHashedCertificateChainWithRevokedCertificate(t *testing.T) {
 	ocspResponder := &OCSPResponder{
 		T: t,
@@ -250,9 +250,13 @@ func (n DefaultChargeStationCertificateProvider) ProvideCertificate(context.Cont
 
 // LocalChargeStationCertificateProvider issues CSO certificates using local data
 type LocalChargeStationCertificateProvider struct {
-	Store             store.CertificateStore
+	Store store.CertificateStore
+	// RevocationStore provides the revoked certificates to include in the CRL
+	RevocationStore   store.CertificateRevocationStore
 	CertificateReader LocalSource
 	PrivateKeyReader  LocalSource
+	// Signer is used instead of PrivateKeyReader when the private key is held outside the CSMS
+	Signer crypto.Signer
 }
 
 func (l *LocalChargeStationCertificateProvider) ProvideCertificate(ctx context.Context, typ CertificateType, pemEncodedCSR string, csId string) (pemEncodedCertificateChain string, err error) {
@@ -274,7 +278,7 @@ func (l *LocalChargeStationCertificateProvider) ProvideCertificate(ctx context.C
 		return "", err
 	}
 
-	privateKey, err := readPrivateKey(ctx, l.PrivateKeyReader)
+	privateKey, err := getSigner(ctx, l.Signer, l.PrivateKeyReader)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,320 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// CRLCertificateValidationService checks the revocation status of contract certificates
+// using certificate revocation lists (CRLs) rather than OCSP. CRLs are downloaded from the
+// distribution points of the root certificates and of the certificates in the chains being
+// validated. They are kept until they are refreshed, so validation can continue when the
+// distribution points cannot be reached.
+type CRLCertificateValidationService struct {
+	sync.Mutex
+	rootCertificateProvider RootCertificateProviderService
+	httpClient              *http.Client
+	refreshInterval         time.Duration
+	clock                   clock.PassiveClock
+	// keyed by distribution point URL
+	crls map[string]*cachedCRL
+}
+
+type cachedCRL struct {
+	crl       *x509.RevocationList
+	issuer    *x509.Certificate
+	refreshAt time.Time
+}
+
+func NewCRLCertificateValidationService(rootCertificateProvider RootCertificateProviderService, httpClient *http.Client, refreshInterval time.Duration, clock clock.PassiveClock) *CRLCertificateValidationService {
+	return &CRLCertificateValidationService{
+		rootCertificateProvider: rootCertificateProvider,
+		httpClient:              httpClient,
+		refreshInterval:         refreshInterval,
+		clock:                   clock,
+		crls:                    make(map[string]*cachedCRL),
+	}
+}
+
+// ValidatePEMCertificateChain validates the chain and checks each certificate against the CRLs
+// published by its issuer. No OCSP response is available, so the response is always nil.
+func (c *CRLCertificateValidationService) ValidatePEMCertificateChain(ctx context.Context, pemChain []byte, eMAID string) (*string, error) {
+	certificateChain, err := ParseCertificates(pemChain)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(certificateChain) == 0 {
+		return nil, fmt.Errorf("empty certificate chain")
+	}
+
+	err = validateEMAID(certificateChain[0], eMAID)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCerts, err := c.rootCertificateProvider.ProvideCertificates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validatePEMCertificateChain(certificateChain, rootCerts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, subjectCert := range certificateChain {
+		var issuerCert *x509.Certificate
+		if i+1 < len(certificateChain) {
+			issuerCert = certificateChain[i+1]
+		} else {
+			for _, rootCert := range rootCerts {
+				if bytes.Equal(subjectCert.AuthorityKeyId, rootCert.SubjectKeyId) {
+					issuerCert = rootCert
+					break
+				}
+			}
+		}
+		if issuerCert == nil || bytes.Equal(subjectCert.Raw, issuerCert.Raw) {
+			continue
+		}
+
+		err = c.checkCertificate(ctx, subjectCert, issuerCert)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// ValidateHashedCertificateChain checks each certificate against the CRLs already downloaded for
+// its issuer. The CRLs for the root certificates are always available; CRLs for intermediate
+// certificates are only available once a chain including the intermediate has been validated.
+func (c *CRLCertificateValidationService) ValidateHashedCertificateChain(ctx context.Context, ocspRequestData []ocpp201.OCSPRequestDataType) (*string, error) {
+	c.RefreshCRLs(ctx)
+
+	for _, requestData := range ocspRequestData {
+		serialNumber, ok := new(big.Int).SetString(requestData.SerialNumber, 16)
+		if !ok {
+			return nil, fmt.Errorf("unable to parse serial number %s as base 16 string", requestData.SerialNumber)
+		}
+
+		crl, err := c.findCRLForIssuer(string(requestData.HashAlgorithm), requestData.IssuerNameHash, requestData.IssuerKeyHash)
+		if err != nil {
+			return nil, err
+		}
+		if crl == nil {
+			return nil, fmt.Errorf("no crl available for issuer with name hash %s", requestData.IssuerNameHash)
+		}
+		if isRevoked(crl, serialNumber) {
+			return nil, fmt.Errorf("certificate %s revoked: %w", requestData.SerialNumber, ValidationErrorCertRevoked)
+		}
+	}
+
+	return nil, nil
+}
+
+// RefreshCRLs downloads the CRLs for the root certificates, along with any other CRLs
+// that are due to be refreshed. Failures are logged and the existing CRLs are kept.
+func (c *CRLCertificateValidationService) RefreshCRLs(ctx context.Context) {
+	refreshed := make(map[string]bool)
+	rootCerts, err := c.rootCertificateProvider.ProvideCertificates(ctx)
+	if err != nil {
+		slog.Warn("provide root certificates for crl refresh", "error", err)
+	} else {
+		for _, rootCert := range rootCerts {
+			for _, url := range rootCert.CRLDistributionPoints {
+				refreshed[url] = true
+				if _, err := c.getCRL(ctx, url, rootCert); err != nil {
+					slog.Warn("refresh crl", "url", url, "error", err)
+				}
+			}
+		}
+	}
+
+	c.Lock()
+	var due []string
+	for url, cached := range c.crls {
+		if !refreshed[url] && !c.clock.Now().Before(cached.refreshAt) {
+			due = append(due, url)
+		}
+	}
+	c.Unlock()
+
+	for _, url := range due {
+		if _, err := c.getCRL(ctx, url, nil); err != nil {
+			slog.Warn("refresh crl", "url", url, "error", err)
+		}
+	}
+}
+
+func (c *CRLCertificateValidationService) checkCertificate(ctx context.Context, subjectCert, issuerCert *x509.Certificate) error {
+	if len(subjectCert.CRLDistributionPoints) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, url := range subjectCert.CRLDistributionPoints {
+		crl, err := c.getCRL(ctx, url, issuerCert)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if isRevoked(crl, subjectCert.SerialNumber) {
+			return fmt.Errorf("certificate %s revoked: %w", subjectCert.Subject, ValidationErrorCertRevoked)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no crl available for %s: %w", subjectCert.Subject, errors.Join(errs...))
+}
+
+// getCRL returns the CRL for the distribution point, downloading it if it has not been
+// downloaded before or is due to be refreshed. If a refresh fails then the previously
+// downloaded CRL is returned. The issuer may be nil when refreshing a CRL that has already
+// been downloaded.
+func (c *CRLCertificateValidationService) getCRL(ctx context.Context, url string, issuerCert *x509.Certificate) (*x509.RevocationList, error) {
+	c.Lock()
+	cached := c.crls[url]
+	c.Unlock()
+
+	now := c.clock.Now()
+	if cached != nil && now.Before(cached.refreshAt) {
+		return cached.crl, nil
+	}
+	if issuerCert == nil {
+		if cached == nil {
+			return nil, fmt.Errorf("no issuer for crl %s", url)
+		}
+		issuerCert = cached.issuer
+	}
+
+	crl, err := c.downloadCRL(ctx, url, issuerCert)
+	if err != nil {
+		if cached != nil {
+			slog.Warn("using previously downloaded crl", "url", url, "error", err)
+			return cached.crl, nil
+		}
+		return nil, err
+	}
+
+	refreshAt := now.Add(c.refreshInterval)
+	if !crl.NextUpdate.IsZero() && crl.NextUpdate.Before(refreshAt) {
+		refreshAt = crl.NextUpdate
+	}
+
+	c.Lock()
+	c.crls[url] = &cachedCRL{
+		crl:       crl,
+		issuer:    issuerCert,
+		refreshAt: refreshAt,
+	}
+	c.Unlock()
+
+	return crl, nil
+}
+
+func (c *CRLCertificateValidationService) downloadCRL(ctx context.Context, url string, issuerCert *x509.Certificate) (*x509.RevocationList, error) {
+	//#nosec G107 - need to use CRL distribution point specified in the certificate
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", url, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s status %s", url, resp.Status)
+	}
+	crlBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading body: %w", err)
+	}
+	if block, _ := pem.Decode(crlBytes); block != nil && block.Type == "X509 CRL" {
+		crlBytes = block.Bytes
+	}
+
+	crl, err := x509.ParseRevocationList(crlBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing crl from %s: %w", url, err)
+	}
+	err = crl.CheckSignatureFrom(issuerCert)
+	if err != nil {
+		return nil, fmt.Errorf("checking signature of crl from %s: %w", url, err)
+	}
+
+	return crl, nil
+}
+
+func (c *CRLCertificateValidationService) findCRLForIssuer(hashAlg, issuerNameHash, issuerKeyHash string) (*x509.RevocationList, error) {
+	hash := hashAlgorithm(hashAlg)
+
+	c.Lock()
+	defer c.Unlock()
+
+	for _, cached := range c.crls {
+		publicKey, err := getSubjectPublicKey(cached.issuer)
+		if err != nil {
+			return nil, err
+		}
+
+		h := hash.New()
+		h.Write(cached.issuer.RawSubject)
+		nameHash := hex.EncodeToString(h.Sum(nil))
+
+		h = hash.New()
+		h.Write(publicKey)
+		keyHash := hex.EncodeToString(h.Sum(nil))
+
+		if strings.EqualFold(nameHash, issuerNameHash) && strings.EqualFold(keyHash, issuerKeyHash) {
+			return cached.crl, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func isRevoked(crl *x509.RevocationList, serialNumber *big.Int) bool {
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(serialNumber) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func getSubjectPublicKey(cert *x509.Certificate) ([]byte, error) {
+	var publicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(cert.RawSubjectPublicKeyInfo, &publicKeyInfo); err != nil {
+		return nil, fmt.Errorf("parsing public key of %s: %w", cert.Subject, err)
+	}
+	return publicKeyInfo.PublicKey.RightAlign(), nil
+}
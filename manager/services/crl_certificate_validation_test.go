@@ -0,0 +1,380 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+type CRLDistributionPoint struct {
+	CRLs         map[string][]byte
+	Unavailable  bool
+	RequestCount int
+}
+
+func (d *CRLDistributionPoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.RequestCount++
+	if d.Unavailable {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	crl, ok := d.CRLs[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	_, _ = w.Write(crl)
+}
+
+type crlTestPKI struct {
+	rootCert *x509.Certificate
+	rootKey  *ecdsa.PrivateKey
+	intCert  *x509.Certificate
+	intKey   *ecdsa.PrivateKey
+	leafCert *x509.Certificate
+}
+
+func setupCRLDistributionPoint(t *testing.T, serverUrl string, distributionPoint *CRLDistributionPoint) *crlTestPKI {
+	rootCert, rootKey := createCertificateWithCRLDistributionPoint(t, "crl-root", serverUrl+"/root.crl", true, nil, nil)
+	intCert, intKey := createCertificateWithCRLDistributionPoint(t, "crl-int", serverUrl+"/root.crl", true, rootCert, rootKey)
+	leafCert, _ := createCertificateWithCRLDistributionPoint(t, "MYEMAID", serverUrl+"/int.crl", false, intCert, intKey)
+
+	pki := &crlTestPKI{
+		rootCert: rootCert,
+		rootKey:  rootKey,
+		intCert:  intCert,
+		intKey:   intKey,
+		leafCert: leafCert,
+	}
+
+	distributionPoint.CRLs = map[string][]byte{
+		"/root.crl": createCRL(t, rootCert, rootKey),
+		"/int.crl":  createCRL(t, intCert, intKey),
+	}
+
+	return pki
+}
+
+func (p *crlTestPKI) pemChain() []byte {
+	pemChain := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: p.leafCert.Raw,
+	})
+	return append(pemChain, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: p.intCert.Raw,
+	})...)
+}
+
+func (p *crlTestPKI) hashedChain(t *testing.T) []ocpp201.OCSPRequestDataType {
+	intPublicKeyBytes, err := getPublicKeyBytes(p.intCert.RawSubjectPublicKeyInfo)
+	require.NoError(t, err)
+	rootPublicKeyBytes, err := getPublicKeyBytes(p.rootCert.RawSubjectPublicKeyInfo)
+	require.NoError(t, err)
+
+	return []ocpp201.OCSPRequestDataType{
+		{
+			HashAlgorithm:  "SHA256",
+			IssuerNameHash: hashBytes(p.leafCert.RawIssuer),
+			IssuerKeyHash:  hashBytes(intPublicKeyBytes),
+			SerialNumber:   p.leafCert.SerialNumber.Text(16),
+		},
+		{
+			HashAlgorithm:  "SHA256",
+			IssuerNameHash: hashBytes(p.intCert.RawIssuer),
+			IssuerKeyHash:  hashBytes(rootPublicKeyBytes),
+			SerialNumber:   p.intCert.SerialNumber.Text(16),
+		},
+	}
+}
+
+func TestCRLValidatingPEMCertificateChain(t *testing.T) {
+	distributionPoint := &CRLDistributionPoint{}
+	server := httptest.NewServer(distributionPoint)
+	defer server.Close()
+
+	pki := setupCRLDistributionPoint(t, server.URL, distributionPoint)
+
+	validationService := services.NewCRLCertificateValidationService(
+		services.X509RootCertificateProviderService{Certificates: []*x509.Certificate{pki.rootCert}},
+		http.DefaultClient, time.Hour, clockTest.NewFakePassiveClock(time.Now()))
+
+	ocspResp, err := validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	require.NoError(t, err)
+	assert.Nil(t, ocspResp)
+	assert.Equal(t, 2, distributionPoint.RequestCount)
+}
+
+func TestCRLValidatingPEMCertificateChainWithRevokedCertificate(t *testing.T) {
+	distributionPoint := &CRLDistributionPoint{}
+	server := httptest.NewServer(distributionPoint)
+	defer server.Close()
+
+	pki := setupCRLDistributionPoint(t, server.URL, distributionPoint)
+	distributionPoint.CRLs["/int.crl"] = createCRL(t, pki.intCert, pki.intKey, pki.leafCert.SerialNumber)
+
+	validationService := services.NewCRLCertificateValidationService(
+		services.X509RootCertificateProviderService{Certificates: []*x509.Certificate{pki.rootCert}},
+		http.DefaultClient, time.Hour, clockTest.NewFakePassiveClock(time.Now()))
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	assert.ErrorIs(t, err, services.ValidationErrorCertRevoked)
+}
+
+func TestCRLValidatingPEMCertificateChainWithCRLSignedByWrongIssuer(t *testing.T) {
+	distributionPoint := &CRLDistributionPoint{}
+	server := httptest.NewServer(distributionPoint)
+	defer server.Close()
+
+	pki := setupCRLDistributionPoint(t, server.URL, distributionPoint)
+	distributionPoint.CRLs["/int.crl"] = createCRL(t, pki.rootCert, pki.rootKey)
+
+	validationService := services.NewCRLCertificateValidationService(
+		services.X509RootCertificateProviderService{Certificates: []*x509.Certificate{pki.rootCert}},
+		http.DefaultClient, time.Hour, clockTest.NewFakePassiveClock(time.Now()))
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	require.Error(t, err)
+	var validationError services.ValidationError
+	assert.False(t, errors.As(err, &validationError))
+}
+
+func TestCRLValidatingPEMCertificateChainWhenDistributionPointUnavailable(t *testing.T) {
+	distributionPoint := &CRLDistributionPoint{}
+	server := httptest.NewServer(distributionPoint)
+	defer server.Close()
+
+	pki := setupCRLDistributionPoint(t, server.URL, distributionPoint)
+
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	validationService := services.NewCRLCertificateValidationService(
+		services.X509RootCertificateProviderService{Certificates: []*x509.Certificate{pki.rootCert}},
+		http.DefaultClient, time.Hour, clock)
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	require.NoError(t, err)
+
+	// the previously downloaded CRLs continue to be used
+	distributionPoint.Unavailable = true
+	clock.SetTime(clock.Now().Add(2 * time.Hour))
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	require.NoError(t, err)
+	assert.Equal(t, 4, distributionPoint.RequestCount)
+
+	// no CRLs have been downloaded
+	validationService = services.NewCRLCertificateValidationService(
+		services.X509RootCertificateProviderService{Certificates: []*x509.Certificate{pki.rootCert}},
+		http.DefaultClient, time.Hour, clock)
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	require.Error(t, err)
+	var validationError services.ValidationError
+	assert.False(t, errors.As(err, &validationError))
+}
+
+func TestCRLRefreshesCRLsAfterRefreshInterval(t *testing.T) {
+	distributionPoint := &CRLDistributionPoint{}
+	server := httptest.NewServer(distributionPoint)
+	defer server.Close()
+
+	pki := setupCRLDistributionPoint(t, server.URL, distributionPoint)
+
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	validationService := services.NewCRLCertificateValidationService(
+		services.X509RootCertificateProviderService{Certificates: []*x509.Certificate{pki.rootCert}},
+		http.DefaultClient, time.Hour, clock)
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	require.NoError(t, err)
+
+	distributionPoint.CRLs["/int.crl"] = createCRL(t, pki.intCert, pki.intKey, pki.leafCert.SerialNumber)
+
+	clock.SetTime(clock.Now().Add(30 * time.Minute))
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	require.NoError(t, err)
+	assert.Equal(t, 2, distributionPoint.RequestCount)
+
+	clock.SetTime(clock.Now().Add(31 * time.Minute))
+	validationService.RefreshCRLs(context.TODO())
+	assert.Equal(t, 4, distributionPoint.RequestCount)
+
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	assert.ErrorIs(t, err, services.ValidationErrorCertRevoked)
+	assert.Equal(t, 4, distributionPoint.RequestCount)
+}
+
+func TestCRLValidatingHashedCertificateChain(t *testing.T) {
+	distributionPoint := &CRLDistributionPoint{}
+	server := httptest.NewServer(distributionPoint)
+	defer server.Close()
+
+	pki := setupCRLDistributionPoint(t, server.URL, distributionPoint)
+
+	validationService := services.NewCRLCertificateValidationService(
+		services.X509RootCertificateProviderService{Certificates: []*x509.Certificate{pki.rootCert}},
+		http.DefaultClient, time.Hour, clockTest.NewFakePassiveClock(time.Now()))
+
+	// only the CRL for the root certificate is available
+	hashedChain := pki.hashedChain(t)
+	ocspResp, err := validationService.ValidateHashedCertificateChain(context.TODO(), hashedChain[1:])
+	require.NoError(t, err)
+	assert.Nil(t, ocspResp)
+
+	_, err = validationService.ValidateHashedCertificateChain(context.TODO(), hashedChain)
+	require.Error(t, err)
+	var validationError services.ValidationError
+	assert.False(t, errors.As(err, &validationError))
+
+	// the CRL for the intermediate certificate is downloaded when validating the PEM chain
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pki.pemChain(), "MYEMAID")
+	require.NoError(t, err)
+
+	_, err = validationService.ValidateHashedCertificateChain(context.TODO(), hashedChain)
+	require.NoError(t, err)
+}
+
+func TestCRLValidatingHashedCertificateChainWithRevokedCertificate(t *testing.T) {
+	distributionPoint := &CRLDistributionPoint{}
+	server := httptest.NewServer(distributionPoint)
+	defer server.Close()
+
+	pki := setupCRLDistributionPoint(t, server.URL, distributionPoint)
+	distributionPoint.CRLs["/root.crl"] = createCRL(t, pki.rootCert, pki.rootKey, pki.intCert.SerialNumber)
+
+	validationService := services.NewCRLCertificateValidationService(
+		services.X509RootCertificateProviderService{Certificates: []*x509.Certificate{pki.rootCert}},
+		http.DefaultClient, time.Hour, clockTest.NewFakePassiveClock(time.Now()))
+
+	_, err := validationService.ValidateHashedCertificateChain(context.TODO(), pki.hashedChain(t)[1:])
+	assert.ErrorIs(t, err, services.ValidationErrorCertRevoked)
+}
+
+type stubCertificateValidationService struct {
+	err   error
+	calls int
+}
+
+func (s *stubCertificateValidationService) ValidatePEMCertificateChain(context.Context, []byte, string) (*string, error) {
+	s.calls++
+	return nil, s.err
+}
+
+func (s *stubCertificateValidationService) ValidateHashedCertificateChain(context.Context, []ocpp201.OCSPRequestDataType) (*string, error) {
+	s.calls++
+	return nil, s.err
+}
+
+func TestCompositeCertificateValidationFallsBackWhenInconclusive(t *testing.T) {
+	unavailable := &stubCertificateValidationService{err: errors.New("failed to perform ocsp check after 3 attempts")}
+	valid := &stubCertificateValidationService{}
+	validationService := services.CompositeCertificateValidationService{
+		Validators: []services.CertificateValidationService{unavailable, valid},
+	}
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), []byte{}, "MYEMAID")
+	require.NoError(t, err)
+	_, err = validationService.ValidateHashedCertificateChain(context.TODO(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, unavailable.calls)
+	assert.Equal(t, 2, valid.calls)
+}
+
+func TestCompositeCertificateValidationStopsWhenConclusive(t *testing.T) {
+	revoked := &stubCertificateValidationService{err: services.ValidationErrorCertRevoked}
+	valid := &stubCertificateValidationService{}
+	validationService := services.CompositeCertificateValidationService{
+		Validators: []services.CertificateValidationService{revoked, valid},
+	}
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), []byte{}, "MYEMAID")
+	assert.ErrorIs(t, err, services.ValidationErrorCertRevoked)
+	assert.Equal(t, 0, valid.calls)
+}
+
+func TestCompositeCertificateValidationReturnsLastErrorWhenAllInconclusive(t *testing.T) {
+	validationService := services.CompositeCertificateValidationService{
+		Validators: []services.CertificateValidationService{
+			&stubCertificateValidationService{err: errors.New("ocsp unavailable")},
+			&stubCertificateValidationService{err: errors.New("crl unavailable")},
+		},
+	}
+
+	_, err := validationService.ValidateHashedCertificateChain(context.TODO(), nil)
+	assert.EqualError(t, err, "crl unavailable")
+}
+
+func createCertificateWithCRLDistributionPoint(t *testing.T, commonName, crlUrl string, isCA bool, issuerCert *x509.Certificate, issuerKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	keyPair, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{"Thoughtworks"},
+		},
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		CRLDistributionPoints: []string{crlUrl},
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	parent, signer := &template, keyPair
+	if issuerCert != nil {
+		parent, signer = issuerCert, issuerKey
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, &template, parent, &keyPair.PublicKey, signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+
+	return cert, keyPair
+}
+
+func createCRL(t *testing.T, issuerCert *x509.Certificate, issuerKey *ecdsa.PrivateKey, revokedSerialNumbers ...*big.Int) []byte {
+	now := time.Now()
+	var entries []x509.RevocationListEntry
+	for _, serialNumber := range revokedSerialNumbers {
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serialNumber,
+			RevocationTime: now,
+		})
+	}
+
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(now.UnixNano()),
+		ThisUpdate:                now,
+		NextUpdate:                now.Add(24 * time.Hour),
+		RevokedCertificateEntries: entries,
+	}, issuerCert, issuerKey)
+	require.NoError(t, err)
+
+	return crl
+}
@@ -0,0 +1,352 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/iso15118"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+// LocalContractCertificateProvider acts as a contract certificate pool for ISO 15118-2 certificate
+// installation. It issues contract certificates from a locally configured MO sub-CA and signs the
+// response using a locally configured certificate provisioning service (CPS) certificate. It is
+// intended for test environments that do not have access to a real contract certificate pool.
+type LocalContractCertificateProvider struct {
+	// CertificateReader provides the MO sub-CA certificate used to issue contract certificates,
+	// followed by any other sub-CA certificates in its chain
+	CertificateReader LocalSource
+	PrivateKeyReader  LocalSource
+	// ProvisioningCertificateReader provides the CPS leaf certificate used to sign the response,
+	// followed by any sub-CA certificates in its chain
+	ProvisioningCertificateReader LocalSource
+	ProvisioningPrivateKeyReader  LocalSource
+	// Signer and ProvisioningSigner are used instead of PrivateKeyReader and ProvisioningPrivateKeyReader
+	// when the private keys are held outside the CSMS
+	Signer             crypto.Signer
+	ProvisioningSigner crypto.Signer
+	// EmaidPrefix is the country code and provider id used for the issued eMAIDs, e.g. GBTWK
+	EmaidPrefix string
+	// Store records the issued contract certificates
+	Store store.CertificateStore
+	// TokenStore registers the issued eMAIDs as valid tokens, so the EV can be authorized
+	TokenStore store.TokenStore
+	Clock      clock.PassiveClock
+}
+
+// Ids of the elements of the response that are referenced from the signature
+const (
+	contractCertChainId   = "id1"
+	contractPrivateKeyId  = "id2"
+	contractDHPublicKeyId = "id3"
+	contractEmaidId       = "id4"
+)
+
+// The eMAID instance is a 'C' followed by random characters
+const (
+	emaidInstanceCharacters  = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	emaidInstanceRandomChars = 8
+)
+
+func (l *LocalContractCertificateProvider) ProvideCertificate(ctx context.Context, exiRequest string) (EvCertificate15118Response, error) {
+	failed := EvCertificate15118Response{
+		Status: ocpp201.Iso15118EVCertificateStatusEnumTypeFailed,
+	}
+
+	exiData, err := base64.StdEncoding.DecodeString(exiRequest)
+	if err != nil {
+		return failed, fmt.Errorf("decoding exi request: %w", err)
+	}
+	msg, err := iso15118.DecodeV2GMessage(exiData)
+	if err != nil {
+		return failed, fmt.Errorf("decoding exi request: %w", err)
+	}
+	req := msg.Body.CertificateInstallationReq
+	if req == nil {
+		return failed, errors.New("exi request is not a certificate installation request")
+	}
+
+	oemCertificate, err := x509.ParseCertificate(req.OEMProvisioningCert)
+	if err != nil {
+		return failed, fmt.Errorf("parsing oem provisioning certificate: %w", err)
+	}
+	oemPublicKey, ok := oemCertificate.PublicKey.(*ecdsa.PublicKey)
+	if !ok || oemPublicKey.Curve != elliptic.P256() {
+		return failed, errors.New("oem provisioning certificate must have a P-256 public key")
+	}
+
+	moChain, err := readCertificateChain(ctx, l.CertificateReader)
+	if err != nil {
+		return failed, err
+	}
+	moPrivateKey, err := getSigner(ctx, l.Signer, l.PrivateKeyReader)
+	if err != nil {
+		return failed, err
+	}
+	cpsChain, err := readCertificateChain(ctx, l.ProvisioningCertificateReader)
+	if err != nil {
+		return failed, err
+	}
+	cpsPrivateKey, err := getSigner(ctx, l.ProvisioningSigner, l.ProvisioningPrivateKeyReader)
+	if err != nil {
+		return failed, err
+	}
+	cpsSigner, ok := cpsPrivateKey.(crypto.Signer)
+	if !ok {
+		return failed, errors.New("provisioning private key cannot be used for signing")
+	}
+
+	eMAID, err := l.generateEmaid()
+	if err != nil {
+		return failed, err
+	}
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("contract_cert.emaid", eMAID))
+
+	contractPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return failed, fmt.Errorf("generating contract private key: %w", err)
+	}
+	contractCertificate, err := l.issueContractCertificate(eMAID, &contractPrivateKey.PublicKey, moChain[0], moPrivateKey)
+	if err != nil {
+		return failed, err
+	}
+	encryptedPrivateKey, dhPublicKey, err := encryptContractPrivateKey(contractPrivateKey, oemPublicKey)
+	if err != nil {
+		return failed, err
+	}
+
+	certChainId := contractCertChainId
+	res := &iso15118.V2GMessage{
+		Header: iso15118.MessageHeader{
+			SessionID: msg.Header.SessionID,
+		},
+		Body: iso15118.Body{
+			CertificateInstallationRes: &iso15118.CertificateInstallationRes{
+				ResponseCode: iso15118.ResponseCodeOK,
+				SAProvisioningCertificateChain: iso15118.CertificateChain{
+					Certificate:     cpsChain[0].Raw,
+					SubCertificates: subCertificates(cpsChain[1:]),
+				},
+				ContractSignatureCertChain: iso15118.CertificateChain{
+					Id:              &certChainId,
+					Certificate:     contractCertificate,
+					SubCertificates: subCertificates(moChain),
+				},
+				ContractSignatureEncryptedPrivateKey: iso15118.IdentifiedValue[[]byte]{
+					Id:    contractPrivateKeyId,
+					Value: encryptedPrivateKey,
+				},
+				DHpublickey: iso15118.IdentifiedValue[[]byte]{
+					Id:    contractDHPublicKeyId,
+					Value: dhPublicKey,
+				},
+				EMAID: iso15118.IdentifiedValue[string]{
+					Id:    contractEmaidId,
+					Value: eMAID,
+				},
+			},
+		},
+	}
+
+	err = iso15118.SignCertificateInstallationRes(res, cpsSigner)
+	if err != nil {
+		return failed, err
+	}
+	exiResponse, err := iso15118.EncodeV2GMessage(res)
+	if err != nil {
+		return failed, fmt.Errorf("encoding exi response: %w", err)
+	}
+
+	if l.Store != nil {
+		err = l.Store.SetCertificate(ctx, string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: contractCertificate,
+		})))
+		if err != nil {
+			return failed, fmt.Errorf("adding certificate to store: %w", err)
+		}
+	}
+
+	if l.TokenStore != nil {
+		err = l.TokenStore.SetToken(ctx, &store.Token{
+			CountryCode: eMAID[:2],
+			PartyId:     eMAID[2:5],
+			Type:        "OTHER",
+			Uid:         eMAID,
+			ContractId:  eMAID,
+			Issuer:      "Local Contract Certificate Pool",
+			Valid:       true,
+			CacheMode:   "ALWAYS",
+			LastUpdated: l.Clock.Now().Format(time.RFC3339),
+		})
+		if err != nil {
+			return failed, fmt.Errorf("adding token to store: %w", err)
+		}
+	}
+
+	return EvCertificate15118Response{
+		Status:                     ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted,
+		CertificateInstallationRes: base64.StdEncoding.EncodeToString(exiResponse),
+	}, nil
+}
+
+// generateEmaid creates a random eMAID with the configured prefix and a check digit
+func (l *LocalContractCertificateProvider) generateEmaid() (string, error) {
+	instance := make([]byte, emaidInstanceRandomChars)
+	for i := range instance {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(emaidInstanceCharacters))))
+		if err != nil {
+			return "", fmt.Errorf("generating emaid: %w", err)
+		}
+		instance[i] = emaidInstanceCharacters[n.Int64()]
+	}
+
+	eMAID, err := ocpp.NormalizeEmaid(fmt.Sprintf("%sC%s", l.EmaidPrefix, instance))
+	if err != nil {
+		return "", fmt.Errorf("generating emaid: %w", err)
+	}
+	return eMAID, nil
+}
+
+func (l *LocalContractCertificateProvider) issueContractCertificate(eMAID string, publicKey crypto.PublicKey, issuer *x509.Certificate, issuerKey crypto.PrivateKey) ([]byte, error) {
+	serial, err := rand.Int(rand.Reader, (&big.Int{}).Exp(big.NewInt(2), big.NewInt(159), nil))
+	if err != nil {
+		return nil, fmt.Errorf("creating serial number: %w", err)
+	}
+
+	now := l.Clock.Now()
+	notAfter := now.AddDate(1, 0, 0)
+	if issuer.NotAfter.Before(notAfter) {
+		notAfter = issuer.NotAfter
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: eMAID},
+		NotBefore:             now,
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		IsCA:                  false,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
+	}
+
+	contractCertificate, err := x509.CreateCertificate(rand.Reader, &template, issuer, publicKey, issuerKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate: %w", err)
+	}
+	return contractCertificate, nil
+}
+
+// encryptContractPrivateKey encrypts the contract private key for the EV as described in
+// ISO 15118-2: an AES-128-CBC key is derived from an ECDH shared secret between an ephemeral
+// key and the OEM provisioning certificate's key. It returns the IV followed by the encrypted
+// private key, and the ephemeral public key.
+func encryptContractPrivateKey(contractPrivateKey *ecdsa.PrivateKey, oemPublicKey *ecdsa.PublicKey) ([]byte, []byte, error) {
+	ephemeralKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating ephemeral key: %w", err)
+	}
+
+	sessionKey, err := deriveContractSessionKey(ephemeralKey, oemPublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	encrypted := make([]byte, aes.BlockSize+32)
+	iv := encrypted[:aes.BlockSize]
+	if _, err = rand.Read(iv); err != nil {
+		return nil, nil, fmt.Errorf("generating iv: %w", err)
+	}
+	privateKey := make([]byte, 32)
+	contractPrivateKey.D.FillBytes(privateKey)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted[aes.BlockSize:], privateKey)
+
+	ephemeralPublicKey, err := ephemeralKey.PublicKey.ECDH()
+	if err != nil {
+		return nil, nil, fmt.Errorf("converting ephemeral public key: %w", err)
+	}
+
+	return encrypted, ephemeralPublicKey.Bytes(), nil
+}
+
+// deriveContractSessionKey derives the key used to encrypt the contract private key using the
+// concatenation KDF from NIST SP 800-56A with SHA-256 and the other info defined by ISO 15118-2
+func deriveContractSessionKey(privateKey *ecdsa.PrivateKey, publicKey *ecdsa.PublicKey) ([]byte, error) {
+	ecdhPrivateKey, err := privateKey.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("converting private key: %w", err)
+	}
+	ecdhPublicKey, err := publicKey.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("converting public key: %w", err)
+	}
+	sharedSecret, err := ecdhPrivateKey.ECDH(ecdhPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("computing shared secret: %w", err)
+	}
+
+	var kdfInput bytes.Buffer
+	kdfInput.Write([]byte{0x00, 0x00, 0x00, 0x01})
+	kdfInput.Write(sharedSecret)
+	// algorithm id, party u info and party v info
+	kdfInput.Write([]byte{0x01, 0x55, 0x56})
+	digest := sha256.Sum256(kdfInput.Bytes())
+
+	return digest[:16], nil
+}
+
+func readCertificateChain(ctx context.Context, certificateSource LocalSource) ([]*x509.Certificate, error) {
+	pemCertificates, err := certificateSource.GetData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificate: %v", err)
+	}
+
+	certificates, err := ParseCertificates([]byte(pemCertificates))
+	if err != nil {
+		return nil, err
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no signing certificate")
+	}
+
+	return certificates, nil
+}
+
+// subCertificates returns the DER encoded sub-CA certificates, omitting any self-signed root certificate
+func subCertificates(certs []*x509.Certificate) [][]byte {
+	var subCerts [][]byte
+	for _, cert := range certs {
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil {
+			continue
+		}
+		subCerts = append(subCerts, cert.Raw)
+	}
+	return subCerts
+}
@@ -0,0 +1,260 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/iso15118"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+type localContractCertificatePool struct {
+	moSubCA2       *x509.Certificate
+	moSubCA1       *x509.Certificate
+	cpsLeaf        *x509.Certificate
+	cpsSubCA       *x509.Certificate
+	oemProvCert    *x509.Certificate
+	oemProvKey     *ecdsa.PrivateKey
+	pemMOChain     []byte
+	pemMOKey       []byte
+	pemCPSChain    []byte
+	pemCPSKey      []byte
+	v2gRootCert    *x509.Certificate
+	moRootCertName string
+}
+
+func createLocalContractCertificatePool(t *testing.T) *localContractCertificatePool {
+	moRoot, moRootKey := createRootCACertificate(t, "MO Root CA")
+	moSubCA1, moSubCA1Key := createIntermediateCACertificate(t, "MO Sub-CA 1", "", moRoot, moRootKey)
+	moSubCA2, moSubCA2Key := createIntermediateCACertificate(t, "MO Sub-CA 2", "", moSubCA1, moSubCA1Key)
+
+	v2gRoot, v2gRootKey := createRootCACertificate(t, "V2G Root CA")
+	cpsSubCA, cpsSubCAKey := createIntermediateCACertificate(t, "CPS Sub-CA", "", v2gRoot, v2gRootKey)
+	cpsLeaf, cpsLeafKey := createLeafCertificate(t, "CPS Leaf", "", cpsSubCA, cpsSubCAKey)
+
+	oemRoot, oemRootKey := createRootCACertificate(t, "OEM Root CA")
+	oemProvCert, oemProvKey := createLeafCertificate(t, "OEM Prov", "", oemRoot, oemRootKey)
+
+	return &localContractCertificatePool{
+		moSubCA2:       moSubCA2,
+		moSubCA1:       moSubCA1,
+		cpsLeaf:        cpsLeaf,
+		cpsSubCA:       cpsSubCA,
+		oemProvCert:    oemProvCert,
+		oemProvKey:     oemProvKey,
+		pemMOChain:     pemEncodeCertificates(moSubCA2, moSubCA1, moRoot),
+		pemMOKey:       pemEncodePrivateKey(t, moSubCA2Key),
+		pemCPSChain:    pemEncodeCertificates(cpsLeaf, cpsSubCA),
+		pemCPSKey:      pemEncodePrivateKey(t, cpsLeafKey),
+		v2gRootCert:    v2gRoot,
+		moRootCertName: moRoot.Subject.String(),
+	}
+}
+
+func pemEncodeCertificates(certs ...*x509.Certificate) []byte {
+	var pemData []byte
+	for _, cert := range certs {
+		pemData = append(pemData, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: cert.Raw,
+		})...)
+	}
+	return pemData
+}
+
+func pemEncodePrivateKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: privateKey,
+	})
+}
+
+func createCertificateInstallationReq(t *testing.T, pool *localContractCertificatePool) string {
+	req := &iso15118.V2GMessage{
+		Header: iso15118.MessageHeader{
+			SessionID: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		},
+		Body: iso15118.Body{
+			CertificateInstallationReq: &iso15118.CertificateInstallationReq{
+				Id:                  "id1",
+				OEMProvisioningCert: pool.oemProvCert.Raw,
+				ListOfRootCertificateIDs: []iso15118.X509IssuerSerial{
+					{
+						X509IssuerName:   pool.v2gRootCert.Subject.String(),
+						X509SerialNumber: pool.v2gRootCert.SerialNumber,
+					},
+				},
+			},
+		},
+	}
+
+	exiRequest, err := iso15118.EncodeV2GMessage(req)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(exiRequest)
+}
+
+// decryptContractPrivateKey performs the EV side of the contract private key encryption
+func decryptContractPrivateKey(t *testing.T, oemProvKey *ecdsa.PrivateKey, dhPublicKey, encryptedPrivateKey []byte) *big.Int {
+	oemECDHKey, err := oemProvKey.ECDH()
+	require.NoError(t, err)
+	ephemeralPublicKey, err := ecdh.P256().NewPublicKey(dhPublicKey)
+	require.NoError(t, err)
+	sharedSecret, err := oemECDHKey.ECDH(ephemeralPublicKey)
+	require.NoError(t, err)
+
+	kdfInput := append([]byte{0x00, 0x00, 0x00, 0x01}, sharedSecret...)
+	kdfInput = append(kdfInput, 0x01, 0x55, 0x56)
+	sessionKey := sha256.Sum256(kdfInput)
+
+	require.Len(t, encryptedPrivateKey, 48)
+	block, err := aes.NewCipher(sessionKey[:16])
+	require.NoError(t, err)
+	privateKey := make([]byte, 32)
+	cipher.NewCBCDecrypter(block, encryptedPrivateKey[:16]).CryptBlocks(privateKey, encryptedPrivateKey[16:])
+
+	return new(big.Int).SetBytes(privateKey)
+}
+
+func TestLocalContractCertificateProvider(t *testing.T) {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	engine := inmemory.NewStore(clock)
+	pool := createLocalContractCertificatePool(t)
+
+	provider := &services.LocalContractCertificateProvider{
+		CertificateReader:             services.StringSource{Data: string(pool.pemMOChain)},
+		PrivateKeyReader:              services.StringSource{Data: string(pool.pemMOKey)},
+		ProvisioningCertificateReader: services.StringSource{Data: string(pool.pemCPSChain)},
+		ProvisioningPrivateKeyReader:  services.StringSource{Data: string(pool.pemCPSKey)},
+		EmaidPrefix:                   "GBTWK",
+		Store:                         engine,
+		TokenStore:                    engine,
+		Clock:                         clock,
+	}
+
+	ctx := context.TODO()
+	got, err := provider.ProvideCertificate(ctx, createCertificateInstallationReq(t, pool))
+	require.NoError(t, err)
+	assert.Equal(t, ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted, got.Status)
+
+	exiResponse, err := base64.StdEncoding.DecodeString(got.CertificateInstallationRes)
+	require.NoError(t, err)
+	msg, err := iso15118.DecodeV2GMessage(exiResponse)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}, msg.Header.SessionID)
+
+	res := msg.Body.CertificateInstallationRes
+	require.NotNil(t, res)
+	assert.Equal(t, iso15118.ResponseCodeOK, res.ResponseCode)
+
+	// the response is signed by the CPS leaf certificate
+	assert.Equal(t, pool.cpsLeaf.Raw, res.SAProvisioningCertificateChain.Certificate)
+	assert.Equal(t, [][]byte{pool.cpsSubCA.Raw}, res.SAProvisioningCertificateChain.SubCertificates)
+	err = iso15118.VerifyCertificateInstallationRes(msg, pool.cpsLeaf.PublicKey.(*ecdsa.PublicKey))
+	assert.NoError(t, err)
+
+	// the eMAID has the configured prefix and a valid check digit
+	eMAID := res.EMAID.Value
+	assert.Len(t, eMAID, 15)
+	assert.Equal(t, "GBTWKC", eMAID[:6])
+	normalizedEmaid, err := ocpp.NormalizeEmaid(eMAID)
+	require.NoError(t, err)
+	assert.Equal(t, eMAID, normalizedEmaid)
+
+	// the contract certificate is issued by the MO sub-CA for the eMAID
+	contractCert, err := x509.ParseCertificate(res.ContractSignatureCertChain.Certificate)
+	require.NoError(t, err)
+	assert.Equal(t, eMAID, contractCert.Subject.CommonName)
+	assert.NoError(t, contractCert.CheckSignatureFrom(pool.moSubCA2))
+	assert.Equal(t, [][]byte{pool.moSubCA2.Raw, pool.moSubCA1.Raw}, res.ContractSignatureCertChain.SubCertificates)
+
+	// the EV can decrypt the contract private key using its OEM provisioning key
+	privateKey := decryptContractPrivateKey(t, pool.oemProvKey, res.DHpublickey.Value, res.ContractSignatureEncryptedPrivateKey.Value)
+	contractPublicKey := contractCert.PublicKey.(*ecdsa.PublicKey)
+	x, y := contractPublicKey.Curve.ScalarBaseMult(privateKey.Bytes())
+	assert.Equal(t, 0, x.Cmp(contractPublicKey.X))
+	assert.Equal(t, 0, y.Cmp(contractPublicKey.Y))
+
+	// the contract certificate and the eMAID token are stored
+	cert, err := engine.LookupCertificate(ctx, getCertificateHash(contractCert))
+	require.NoError(t, err)
+	assert.NotEqual(t, "", cert)
+
+	token, err := engine.LookupToken(ctx, eMAID)
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.True(t, token.Valid)
+	assert.Equal(t, eMAID, token.ContractId)
+	assert.Equal(t, "GB", token.CountryCode)
+	assert.Equal(t, "TWK", token.PartyId)
+}
+
+func TestLocalContractCertificateProviderIssuesDifferentEmaids(t *testing.T) {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	pool := createLocalContractCertificatePool(t)
+
+	provider := &services.LocalContractCertificateProvider{
+		CertificateReader:             services.StringSource{Data: string(pool.pemMOChain)},
+		PrivateKeyReader:              services.StringSource{Data: string(pool.pemMOKey)},
+		ProvisioningCertificateReader: services.StringSource{Data: string(pool.pemCPSChain)},
+		ProvisioningPrivateKeyReader:  services.StringSource{Data: string(pool.pemCPSKey)},
+		EmaidPrefix:                   "GBTWK",
+		Clock:                         clock,
+	}
+
+	ctx := context.TODO()
+	exiRequest := createCertificateInstallationReq(t, pool)
+
+	var eMAIDs []string
+	for i := 0; i < 2; i++ {
+		got, err := provider.ProvideCertificate(ctx, exiRequest)
+		require.NoError(t, err)
+		exiResponse, err := base64.StdEncoding.DecodeString(got.CertificateInstallationRes)
+		require.NoError(t, err)
+		msg, err := iso15118.DecodeV2GMessage(exiResponse)
+		require.NoError(t, err)
+		eMAIDs = append(eMAIDs, msg.Body.CertificateInstallationRes.EMAID.Value)
+	}
+
+	assert.NotEqual(t, eMAIDs[0], eMAIDs[1])
+}
+
+func TestLocalContractCertificateProviderWithInvalidRequest(t *testing.T) {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	pool := createLocalContractCertificatePool(t)
+
+	provider := &services.LocalContractCertificateProvider{
+		CertificateReader:             services.StringSource{Data: string(pool.pemMOChain)},
+		PrivateKeyReader:              services.StringSource{Data: string(pool.pemMOKey)},
+		ProvisioningCertificateReader: services.StringSource{Data: string(pool.pemCPSChain)},
+		ProvisioningPrivateKeyReader:  services.StringSource{Data: string(pool.pemCPSKey)},
+		EmaidPrefix:                   "GBTWK",
+		Clock:                         clock,
+	}
+
+	got, err := provider.ProvideCertificate(context.TODO(), base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0xff}, 16)))
+	assert.ErrorContains(t, err, "decoding exi request")
+	assert.Equal(t, ocpp201.Iso15118EVCertificateStatusEnumTypeFailed, got.Status)
+	assert.Equal(t, "", got.CertificateInstallationRes)
+}
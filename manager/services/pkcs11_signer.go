@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build pkcs11 && cgo
+
+package services
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+var (
+	oidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+	oidNamedCurveP521 = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
+)
+
+// PKCS #1 v1.5 DigestInfo prefixes for the hashes used to sign certificates
+var pkcs11DigestInfoPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// pkcs11Signer signs using a private key held in a PKCS #11 token. The public key is read from
+// the public key object with the same label. A single session is used, so signing is serialized.
+type pkcs11Signer struct {
+	sync.Mutex
+	ctx       *pkcs11.Ctx
+	session   pkcs11.SessionHandle
+	key       pkcs11.ObjectHandle
+	publicKey crypto.PublicKey
+}
+
+// NewPKCS11Signer loads the PKCS #11 module, logs in to the token with the label and finds
+// the private key with the label
+func NewPKCS11Signer(modulePath, tokenLabel, pin, keyLabel string) (crypto.Signer, error) {
+	ctx := pkcs11.New(modulePath)
+	if ctx == nil {
+		return nil, fmt.Errorf("loading pkcs11 module %s", modulePath)
+	}
+	if err := ctx.Initialize(); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+		return nil, fmt.Errorf("initializing pkcs11 module: %w", err)
+	}
+
+	slot, found, err := findPKCS11Slot(ctx, tokenLabel)
+	if err != nil {
+		return nil, fmt.Errorf("finding pkcs11 token: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("no pkcs11 token with label %s", tokenLabel)
+	}
+
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, fmt.Errorf("opening pkcs11 session: %w", err)
+	}
+	if err := ctx.Login(session, pkcs11.CKU_USER, pin); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		_ = ctx.CloseSession(session)
+		return nil, fmt.Errorf("opening pkcs11 session: %w", err)
+	}
+
+	signer := &pkcs11Signer{
+		ctx:     ctx,
+		session: session,
+	}
+
+	var ok bool
+	signer.key, ok, err = signer.findObject(pkcs11.CKO_PRIVATE_KEY, keyLabel)
+	if err != nil {
+		return nil, fmt.Errorf("finding pkcs11 private key: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("no pkcs11 private key with label %s", keyLabel)
+	}
+	publicKeyObject, ok, err := signer.findObject(pkcs11.CKO_PUBLIC_KEY, keyLabel)
+	if err != nil {
+		return nil, fmt.Errorf("finding pkcs11 public key: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("no pkcs11 public key with label %s", keyLabel)
+	}
+
+	signer.publicKey, err = signer.readPublicKey(publicKeyObject)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// token labels are padded with spaces to 32 bytes
+func findPKCS11Slot(ctx *pkcs11.Ctx, tokenLabel string) (uint, bool, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+		if strings.TrimRight(info.Label, " \x00") == tokenLabel {
+			return slot, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func (p *pkcs11Signer) Public() crypto.PublicKey {
+	return p.publicKey
+}
+
+// Sign signs the digest. ECDSA signatures are ASN.1 encoded and RSA signatures use PKCS #1 v1.5
+// padding; RSA-PSS is not supported.
+func (p *pkcs11Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	switch p.publicKey.(type) {
+	case *ecdsa.PublicKey:
+		signature, err := p.sign(pkcs11.CKM_ECDSA, digest)
+		if err != nil {
+			return nil, err
+		}
+		return asn1.Marshal(struct {
+			R, S *big.Int
+		}{
+			R: new(big.Int).SetBytes(signature[:len(signature)/2]),
+			S: new(big.Int).SetBytes(signature[len(signature)/2:]),
+		})
+	case *rsa.PublicKey:
+		if _, ok := opts.(*rsa.PSSOptions); ok {
+			return nil, errors.New("pkcs11 signer does not support rsa-pss")
+		}
+		prefix, ok := pkcs11DigestInfoPrefixes[opts.HashFunc()]
+		if !ok {
+			return nil, fmt.Errorf("pkcs11 signer does not support hash %s", opts.HashFunc())
+		}
+		return p.sign(pkcs11.CKM_RSA_PKCS, append(append([]byte{}, prefix...), digest...))
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", p.publicKey)
+	}
+}
+
+func (p *pkcs11Signer) sign(mechanism uint, data []byte) ([]byte, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	err := p.ctx.SignInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(mechanism, nil)}, p.key)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11 sign: %w", err)
+	}
+	signature, err := p.ctx.Sign(p.session, data)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11 sign: %w", err)
+	}
+	return signature, nil
+}
+
+func (p *pkcs11Signer) findObject(class uint, label string) (pkcs11.ObjectHandle, bool, error) {
+	err := p.ctx.FindObjectsInit(p.session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	objects, _, err := p.ctx.FindObjects(p.session, 1)
+	finalErr := p.ctx.FindObjectsFinal(p.session)
+	if err != nil {
+		return 0, false, err
+	}
+	if finalErr != nil {
+		return 0, false, finalErr
+	}
+	if len(objects) == 0 {
+		return 0, false, nil
+	}
+	return objects[0], true, nil
+}
+
+func (p *pkcs11Signer) getAttribute(object pkcs11.ObjectHandle, attributeType uint) ([]byte, error) {
+	attributes, err := p.ctx.GetAttributeValue(p.session, object, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(attributeType, nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(attributes) == 0 || len(attributes[0].Value) == 0 {
+		return nil, nil
+	}
+	return attributes[0].Value, nil
+}
+
+func (p *pkcs11Signer) readPublicKey(object pkcs11.ObjectHandle) (crypto.PublicKey, error) {
+	ecParams, err := p.getAttribute(object, pkcs11.CKA_EC_PARAMS)
+	if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_ATTRIBUTE_TYPE_INVALID)) {
+		return nil, fmt.Errorf("reading pkcs11 ec params: %w", err)
+	}
+	if ecParams != nil {
+		return p.readECPublicKey(object, ecParams)
+	}
+
+	modulus, err := p.getAttribute(object, pkcs11.CKA_MODULUS)
+	if err != nil {
+		return nil, fmt.Errorf("reading pkcs11 modulus: %w", err)
+	}
+	exponent, err := p.getAttribute(object, pkcs11.CKA_PUBLIC_EXPONENT)
+	if err != nil {
+		return nil, fmt.Errorf("reading pkcs11 public exponent: %w", err)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
+
+func (p *pkcs11Signer) readECPublicKey(object pkcs11.ObjectHandle, ecParams []byte) (crypto.PublicKey, error) {
+	var curveOid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(ecParams, &curveOid); err != nil {
+		return nil, fmt.Errorf("parsing pkcs11 ec params: %w", err)
+	}
+	var curve elliptic.Curve
+	switch {
+	case curveOid.Equal(oidNamedCurveP256):
+		curve = elliptic.P256()
+	case curveOid.Equal(oidNamedCurveP384):
+		curve = elliptic.P384()
+	case curveOid.Equal(oidNamedCurveP521):
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported pkcs11 ec curve %s", curveOid)
+	}
+
+	ecPoint, err := p.getAttribute(object, pkcs11.CKA_EC_POINT)
+	if err != nil {
+		return nil, fmt.Errorf("reading pkcs11 ec point: %w", err)
+	}
+	// the point should be a DER encoded octet string, but some modules return the raw point
+	var point []byte
+	if rest, err := asn1.Unmarshal(ecPoint, &point); err != nil || len(rest) != 0 {
+		point = ecPoint
+	}
+
+	size := (curve.Params().BitSize + 7) / 8
+	if len(point) != 1+2*size || point[0] != 4 {
+		return nil, errors.New("pkcs11 ec point is not an uncompressed point")
+	}
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(point[1 : 1+size]),
+		Y:     new(big.Int).SetBytes(point[1+size:]),
+	}, nil
+}
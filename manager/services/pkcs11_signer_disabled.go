@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !pkcs11 || !cgo
+
+package services
+
+import (
+	"crypto"
+	"errors"
+)
+
+// NewPKCS11Signer is not available unless the manager is built with cgo and the pkcs11 build tag
+func NewPKCS11Signer(string, string, string, string) (crypto.Signer, error) {
+	return nil, errors.New("pkcs11 support is not included in this build, build with CGO_ENABLED=1 and -tags pkcs11")
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build pkcs11 && cgo
+
+package services_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+)
+
+// newTestPKCS11Signer creates a signer for an EC key in a PKCS #11 token, such as a SoftHSM
+// token initialized with:
+//
+//	softhsm2-util --init-token --free --label test --pin 1234 --so-pin 1234
+//	pkcs11-tool --module $PKCS11_MODULE --token-label test --login --pin 1234 --keypairgen --key-type EC:prime256v1 --label key
+func newTestPKCS11Signer(t *testing.T) crypto.Signer {
+	module := os.Getenv("PKCS11_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_MODULE is not set")
+	}
+
+	signer, err := services.NewPKCS11Signer(module, os.Getenv("PKCS11_TOKEN_LABEL"), os.Getenv("PKCS11_PIN"), os.Getenv("PKCS11_KEY_LABEL"))
+	require.NoError(t, err)
+	return signer
+}
+
+func TestPKCS11SignerSignsDigest(t *testing.T) {
+	signer := newTestPKCS11Signer(t)
+
+	digest := sha256.Sum256([]byte("data"))
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+
+	publicKey, ok := signer.Public().(*ecdsa.PublicKey)
+	require.True(t, ok)
+	assert.True(t, ecdsa.VerifyASN1(publicKey, digest[:], signature))
+}
+
+func TestPKCS11SignerIssuesCertificate(t *testing.T) {
+	signer := newTestPKCS11Signer(t)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "PKCS11 CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(cert))
+}
+
+func TestPKCS11SignerWithWrongPin(t *testing.T) {
+	module := os.Getenv("PKCS11_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_MODULE is not set")
+	}
+
+	_, err := services.NewPKCS11Signer(module, os.Getenv("PKCS11_TOKEN_LABEL"), "wrong-pin", os.Getenv("PKCS11_KEY_LABEL"))
+	assert.ErrorContains(t, err, "opening pkcs11 session")
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// RemoteSigner is a crypto.Signer for a private key that is held by a remote signing service.
+// The service provides the public key with GET {baseURL}/keys/{keyId} and signs digests with
+// POST {baseURL}/keys/{keyId}/sign.
+type RemoteSigner struct {
+	baseURL          string
+	keyId            string
+	httpTokenService HttpTokenService
+	httpClient       *http.Client
+	publicKey        crypto.PublicKey
+}
+
+type RemotePublicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+type RemoteSignRequest struct {
+	Digest        string `json:"digest"`
+	HashAlgorithm string `json:"hash_algorithm"`
+}
+
+type RemoteSignResponse struct {
+	Signature string `json:"signature"`
+}
+
+// NewRemoteSigner creates a signer for the key held by the remote signing service. The public key
+// is retrieved when the signer is created. The token service is optional.
+func NewRemoteSigner(ctx context.Context, baseURL, keyId string, httpTokenService HttpTokenService, httpClient *http.Client) (*RemoteSigner, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	r := &RemoteSigner{
+		baseURL:          baseURL,
+		keyId:            keyId,
+		httpTokenService: httpTokenService,
+		httpClient:       httpClient,
+	}
+
+	var publicKeyResponse RemotePublicKeyResponse
+	err := r.do(ctx, http.MethodGet, r.keyURL(""), nil, &publicKeyResponse)
+	if err != nil {
+		return nil, fmt.Errorf("getting public key: %w", err)
+	}
+	block, _ := pem.Decode([]byte(publicKeyResponse.PublicKey))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("public key is not a PEM encoded public key")
+	}
+	r.publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+
+	return r, nil
+}
+
+func (r *RemoteSigner) Public() crypto.PublicKey {
+	return r.publicKey
+}
+
+// Sign asks the remote signing service to sign the digest. ECDSA signatures are ASN.1 encoded
+// and RSA signatures use PKCS #1 v1.5 padding; RSA-PSS is not supported.
+func (r *RemoteSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if _, ok := opts.(*rsa.PSSOptions); ok {
+		return nil, errors.New("remote signer does not support rsa-pss")
+	}
+	if opts.HashFunc() == 0 {
+		return nil, errors.New("remote signer requires a hashed digest")
+	}
+
+	signRequest := RemoteSignRequest{
+		Digest:        base64.StdEncoding.EncodeToString(digest),
+		HashAlgorithm: opts.HashFunc().String(),
+	}
+	var signResponse RemoteSignResponse
+	err := r.do(context.Background(), http.MethodPost, r.keyURL("/sign"), signRequest, &signResponse)
+	if err != nil {
+		return nil, fmt.Errorf("signing digest: %w", err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signResponse.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+	return signature, nil
+}
+
+func (r *RemoteSigner) keyURL(suffix string) string {
+	return fmt.Sprintf("%s/keys/%s%s", r.baseURL, url.PathEscape(r.keyId), suffix)
+}
+
+func (r *RemoteSigner) do(ctx context.Context, method, requestUrl string, requestBody, responseBody any) error {
+	var body io.Reader
+	if requestBody != nil {
+		marshalledBody, err := json.Marshal(requestBody)
+		if err != nil {
+			return fmt.Errorf("marshalling body: %w", err)
+		}
+		body = bytes.NewReader(marshalledBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, body)
+	if err != nil {
+		return err
+	}
+	if requestBody != nil {
+		req.Header.Add("content-type", "application/json")
+	}
+	if r.httpTokenService != nil {
+		token, err := r.httpTokenService.GetToken(ctx, false)
+		if err != nil {
+			return err
+		}
+		req.Header.Add("authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return HttpError(resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(responseBody)
+}
+
+// getSigner returns the signer if one is configured, otherwise it reads the private key from the source
+func getSigner(ctx context.Context, signer crypto.Signer, privateKeySource LocalSource) (crypto.PrivateKey, error) {
+	if signer != nil {
+		return signer, nil
+	}
+	return readPrivateKey(ctx, privateKeySource)
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+)
+
+type remoteSignerHandler struct {
+	t     *testing.T
+	keyId string
+	key   *ecdsa.PrivateKey
+	token string
+}
+
+func (h remoteSignerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("authorization") != "Bearer "+h.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/keys/"+h.keyId:
+		publicKey, err := x509.MarshalPKIXPublicKey(&h.key.PublicKey)
+		require.NoError(h.t, err)
+		_ = json.NewEncoder(w).Encode(services.RemotePublicKeyResponse{
+			PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+		})
+	case r.Method == http.MethodPost && r.URL.Path == "/keys/"+h.keyId+"/sign":
+		var req services.RemoteSignRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		require.NoError(h.t, err)
+		assert.Equal(h.t, "SHA-256", req.HashAlgorithm)
+		digest, err := base64.StdEncoding.DecodeString(req.Digest)
+		require.NoError(h.t, err)
+		signature, err := ecdsa.SignASN1(rand.Reader, h.key, digest)
+		require.NoError(h.t, err)
+		_ = json.NewEncoder(w).Encode(services.RemoteSignResponse{
+			Signature: base64.StdEncoding.EncodeToString(signature),
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newRemoteSignerServer(t *testing.T) (*httptest.Server, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(remoteSignerHandler{
+		t:     t,
+		keyId: "ca-key",
+		key:   key,
+		token: "test-token",
+	})
+	t.Cleanup(server.Close)
+
+	return server, key
+}
+
+func TestRemoteSignerSignsDigest(t *testing.T) {
+	server, key := newRemoteSignerServer(t)
+
+	signer, err := services.NewRemoteSigner(context.TODO(), server.URL, "ca-key", services.NewFixedHttpTokenService("test-token"), http.DefaultClient)
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(signer.Public()))
+
+	digest := sha256.Sum256([]byte("data"))
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature))
+}
+
+func TestRemoteSignerWithUnknownKey(t *testing.T) {
+	server, _ := newRemoteSignerServer(t)
+
+	_, err := services.NewRemoteSigner(context.TODO(), server.URL, "other-key", services.NewFixedHttpTokenService("test-token"), http.DefaultClient)
+	assert.ErrorIs(t, err, services.HttpError(http.StatusNotFound))
+}
+
+func TestRemoteSignerWithInvalidToken(t *testing.T) {
+	server, _ := newRemoteSignerServer(t)
+
+	_, err := services.NewRemoteSigner(context.TODO(), server.URL, "ca-key", services.NewFixedHttpTokenService("wrong-token"), http.DefaultClient)
+	assert.ErrorIs(t, err, services.HttpError(http.StatusUnauthorized))
+}
+
+func TestRemoteSignerDoesNotSupportRSAPSS(t *testing.T) {
+	server, _ := newRemoteSignerServer(t)
+
+	signer, err := services.NewRemoteSigner(context.TODO(), server.URL, "ca-key", services.NewFixedHttpTokenService("test-token"), http.DefaultClient)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("data"))
+	_, err = signer.Sign(rand.Reader, digest[:], &rsa.PSSOptions{Hash: crypto.SHA256})
+	assert.ErrorContains(t, err, "rsa-pss")
+}
+
+func TestLocalChargeStationCertificateProviderWithRemoteSigner(t *testing.T) {
+	server, key := newRemoteSignerServer(t)
+
+	caCert, caKey := createRootCACertificate(t, "test")
+	intTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "int", Organization: []string{"Thoughtworks"}},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+	}
+	intCertBytes, err := x509.CreateCertificate(rand.Reader, intTemplate, caCert, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	signer, err := services.NewRemoteSigner(context.TODO(), server.URL, "ca-key", services.NewFixedHttpTokenService("test-token"), http.DefaultClient)
+	require.NoError(t, err)
+
+	certificateProvider := &services.LocalChargeStationCertificateProvider{
+		Store:             inmemory.NewStore(clock.RealClock{}),
+		CertificateReader: services.StringSource{Data: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intCertBytes}))},
+		Signer:            signer,
+	}
+
+	chain, err := certificateProvider.ProvideCertificate(context.TODO(), services.CertificateTypeCSO, string(createCertificateSigningRequest(t)), "cs001")
+	require.NoError(t, err)
+
+	block, _ := pem.Decode([]byte(chain))
+	require.NotNil(t, block)
+	leafCert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	intCert, err := x509.ParseCertificate(intCertBytes)
+	require.NoError(t, err)
+	assert.NoError(t, leafCert.CheckSignatureFrom(intCert))
+}
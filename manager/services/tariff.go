@@ -12,20 +12,43 @@ import (
 )
 
 type TariffService interface {
+	// CalculateCost returns the total cost of a transaction that has ended
 	CalculateCost(transaction *store.Transaction) (float64, error)
+	// CalculateRunningCost returns the cost so far of a transaction that is in progress
+	CalculateRunningCost(transaction *store.Transaction) (float64, error)
+	// TariffText returns a description of the tariff that applies to a session started by the
+	// token at the charge station, or an empty string if there is no description
+	TariffText(chargeStationId, idToken string) string
 }
 
 type BasicKwhTariffService struct{}
 
+const (
+	basicKwhTariffCostPerKwh = 0.55
+	basicKwhTariffCurrency   = "EUR"
+)
+
 func (BasicKwhTariffService) CalculateCost(transaction *store.Transaction) (float64, error) {
+	return calculateBasicKwhCost(transaction, true)
+}
+
+func (BasicKwhTariffService) CalculateRunningCost(transaction *store.Transaction) (float64, error) {
+	return calculateBasicKwhCost(transaction, false)
+}
+
+func (BasicKwhTariffService) TariffText(string, string) string {
+	return fmt.Sprintf("%.2f %s per kWh", basicKwhTariffCostPerKwh, basicKwhTariffCurrency)
+}
+
+func calculateBasicKwhCost(transaction *store.Transaction, ended bool) (float64, error) {
 	var cost float64
 
 	if transaction == nil {
 		return cost, errors.New("no transaction provided")
 	}
 
-	costPerWh := 0.55 / 1000
-	Wh, found := findMostRecentOutletEnergyReading(transaction)
+	costPerWh := basicKwhTariffCostPerKwh / 1000
+	Wh, found := findMostRecentOutletEnergyReading(transaction, ended)
 	if !found {
 		return cost, fmt.Errorf("no output energy reading found in transaction")
 	}
@@ -34,7 +57,9 @@ func (BasicKwhTariffService) CalculateCost(transaction *store.Transaction) (floa
 	return cost, nil
 }
 
-func findMostRecentOutletEnergyReading(transaction *store.Transaction) (float64, bool) {
+// findMostRecentOutletEnergyReading returns the most recent outlet energy reading: if ended is
+// true then only the reading taken at the end of the transaction is considered
+func findMostRecentOutletEnergyReading(transaction *store.Transaction, ended bool) (float64, bool) {
 	sort.Slice(transaction.MeterValues, func(i, j int) bool {
 		ts1, err := time.Parse(time.RFC3339, transaction.MeterValues[i].Timestamp)
 		if err != nil {
@@ -53,7 +78,7 @@ func findMostRecentOutletEnergyReading(transaction *store.Transaction) (float64,
 
 	for _, mv := range transaction.MeterValues {
 		for _, sv := range mv.SampledValues {
-			if sv.Context != nil && *sv.Context == "Transaction.End" &&
+			if (!ended || (sv.Context != nil && *sv.Context == "Transaction.End")) &&
 				sv.Measurand != nil && *sv.Measurand == "Energy.Active.Import.Register" &&
 				sv.Location != nil && *sv.Location == "Outlet" {
 				totalWh = float64(sv.Value)
@@ -54,3 +54,44 @@ func TestBasicKwhTariffServiceErrorsWhenNoKwhReading(t *testing.T) {
 	var zero float64
 	assert.Equal(t, zero, cost)
 }
+
+func TestBasicKwhTariffServiceCanCalculateRunningCost(t *testing.T) {
+	transaction := &store.Transaction{
+		MeterValues: []store.MeterValue{
+			{
+				Timestamp: time.Now().Add(-time.Minute).Format(time.RFC3339),
+				SampledValues: []store.SampledValue{
+					{
+						Context:   makePtr("Transaction.Begin"),
+						Measurand: makePtr("Energy.Active.Import.Register"),
+						Location:  makePtr("Outlet"),
+						Value:     0,
+					},
+				},
+			},
+			{
+				Timestamp: time.Now().Format(time.RFC3339),
+				SampledValues: []store.SampledValue{
+					{
+						Context:   makePtr("Sample.Periodic"),
+						Measurand: makePtr("Energy.Active.Import.Register"),
+						Location:  makePtr("Outlet"),
+						Value:     200,
+					},
+				},
+			},
+		},
+	}
+	tariffService := services.BasicKwhTariffService{}
+	cost, err := tariffService.CalculateRunningCost(transaction)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.11, cost)
+
+	_, err = tariffService.CalculateCost(transaction)
+	assert.ErrorContains(t, err, "no output energy reading found in transaction")
+}
+
+func TestBasicKwhTariffServiceTariffText(t *testing.T) {
+	tariffService := services.BasicKwhTariffService{}
+	assert.Equal(t, "0.55 EUR per kWh", tariffService.TariffText("cs001", "ABCD1234"))
+}
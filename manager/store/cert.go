@@ -2,10 +2,35 @@
 
 package store
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type CertificateStore interface {
 	SetCertificate(ctx context.Context, pemCertificate string) error
 	LookupCertificate(ctx context.Context, certificateHash string) (string, error)
 	DeleteCertificate(ctx context.Context, certificateHash string) error
 }
+
+// RevokedCertificate is a client certificate that must no longer be accepted. The certificate
+// hash is the base64 (URL encoded, without padding) SHA-256 hash of the DER bytes, as used by the
+// CertificateStore.
+type RevokedCertificate struct {
+	CertificateHash string
+	// ChargeStationId is set when the certificate was issued to a known charge station
+	ChargeStationId string
+	// SerialNumber and Issuer are only known if the certificate is available when it is revoked:
+	// they are required to include the certificate in a CRL
+	SerialNumber string
+	Issuer       string
+	// Reason is the CRL reason, e.g. keyCompromise
+	Reason    string
+	RevokedAt time.Time
+}
+
+type CertificateRevocationStore interface {
+	SetRevokedCertificate(ctx context.Context, certificate *RevokedCertificate) error
+	LookupRevokedCertificate(ctx context.Context, certificateHash string) (*RevokedCertificate, error)
+	ListRevokedCertificates(ctx context.Context, pageSize int, previousCertificateHash string) ([]*RevokedCertificate, error)
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// InventoryCertificate identifies a certificate installed on a charge station using the hash data
+// that OCPP uses to refer to certificates
+type InventoryCertificate struct {
+	CertificateType CertificateType
+	HashAlgorithm   string
+	IssuerNameHash  string
+	IssuerKeyHash   string
+	SerialNumber    string
+	// Subject is only known for certificates that are expected but missing
+	Subject string
+}
+
+// Matches returns true if both refer to the same certificate. Charge stations differ in the case
+// of the hex encoded values and whether the serial number has leading zeros.
+func (c *InventoryCertificate) Matches(other *InventoryCertificate) bool {
+	return strings.EqualFold(c.HashAlgorithm, other.HashAlgorithm) &&
+		strings.EqualFold(c.IssuerNameHash, other.IssuerNameHash) &&
+		strings.EqualFold(c.IssuerKeyHash, other.IssuerKeyHash) &&
+		strings.EqualFold(strings.TrimLeft(c.SerialNumber, "0"), strings.TrimLeft(other.SerialNumber, "0"))
+}
+
+// ChargeStationCertificateInventory are the certificates that a charge station has reported as
+// installed, updated as certificates are installed and deleted, together with the result of the
+// most recent audit against the expected root certificates
+type ChargeStationCertificateInventory struct {
+	ChargeStationId string
+	Certificates    []*InventoryCertificate
+	// ReportedAt is when the charge station last reported its installed certificates
+	ReportedAt *time.Time
+	// RequestedAt is when the installed certificates were last requested from the charge station
+	RequestedAt *time.Time
+	// MissingCertificates are the expected root certificates that are not installed
+	MissingCertificates []*InventoryCertificate
+	AuditedAt           *time.Time
+	UpdatedAt           time.Time
+}
+
+type CertificateInventoryStore interface {
+	SetChargeStationCertificateInventory(ctx context.Context, csId string, inventory *ChargeStationCertificateInventory) error
+	LookupChargeStationCertificateInventory(ctx context.Context, csId string) (*ChargeStationCertificateInventory, error)
+	ListChargeStationCertificateInventories(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationCertificateInventory, error)
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"golang.org/x/exp/slices"
+)
+
+// ConfigurationTemplate is a named set of settings that is applied to every charge station
+// that matches the template's selectors. The settings are keyed by name using the same syntax
+// as ChargeStationSettings: the OCPP 1.6 configuration key or the OCPP 2.0.1
+// <component>/<variable> name.
+type ConfigurationTemplate struct {
+	Id         string
+	LocationId *string
+	Vendor     *string
+	Model      *string
+	Tag        *string
+	Settings   map[string]string
+}
+
+// Matches returns true if the charge station satisfies all the selectors that are set on the
+// template: a template with no selectors matches every charge station. The vendor and model are
+// reported by the charge station when it boots so will not match until the charge station has
+// connected at least once.
+func (t *ConfigurationTemplate) Matches(cs *ChargeStation, details *ChargeStationRuntimeDetails) bool {
+	if t.LocationId != nil && *t.LocationId != cs.LocationId {
+		return false
+	}
+	if t.Vendor != nil && (details == nil || *t.Vendor != details.Vendor) {
+		return false
+	}
+	if t.Model != nil && (details == nil || *t.Model != details.Model) {
+		return false
+	}
+	if t.Tag != nil && !slices.Contains(cs.Tags, *t.Tag) {
+		return false
+	}
+	return true
+}
+
+type ConfigurationTemplateStore interface {
+	SetConfigurationTemplate(ctx context.Context, template *ConfigurationTemplate) error
+	LookupConfigurationTemplate(ctx context.Context, templateId string) (*ConfigurationTemplate, error)
+	// ListConfigurationTemplates returns all the configuration templates ordered by id
+	ListConfigurationTemplates(ctx context.Context) ([]*ConfigurationTemplate, error)
+	DeleteConfigurationTemplate(ctx context.Context, templateId string) error
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// ChargeStationConnection records the gateway that a charge station is connected to. It is
+// maintained from the connection events published by the gateways.
+type ChargeStationConnection struct {
+	ChargeStationId string
+	ConnectionId    string
+	GatewayId       string
+	RemoteAddress   string
+	Subprotocol     string
+	SecurityProfile SecurityProfile
+	ConnectedAt     time.Time
+}
+
+type ChargeStationConnectionStore interface {
+	SetChargeStationConnection(ctx context.Context, csId string, connection *ChargeStationConnection) error
+	LookupChargeStationConnection(ctx context.Context, csId string) (*ChargeStationConnection, error)
+	DeleteChargeStationConnection(ctx context.Context, csId string) error
+	ListChargeStationConnections(ctx context.Context, pageSize int, previousCsId string) ([]*ChargeStationConnection, error)
+}
@@ -40,6 +40,22 @@ type ChargeStation struct {
 	SecurityProfile        SecurityProfile `json:"security_profile"`
 	Base64SHA256Password   string          `json:"base64_sha256_password"`
 	InvalidUsernameAllowed bool            `json:"invalid_username_allowed"`
+	Tags                   []string        `json:"tags,omitempty"`
+	ClientCertificateHash  string          `json:"client_certificate_hash,omitempty"`
+	// PendingBase64SHA256Password is set when the password has been rotated and is cleared
+	// once the charge station connects using the new password
+	PendingBase64SHA256Password string `json:"pending_base64_sha256_password,omitempty"`
+	// PendingPasswordExpiresAt is the time after which the pending password is no longer accepted
+	PendingPasswordExpiresAt *time.Time `json:"pending_password_expires_at,omitempty"`
+}
+
+// PendingPassword returns the hash of the pending password or an empty string if there is no
+// pending password or it has expired
+func (cs *ChargeStation) PendingPassword(now time.Time) string {
+	if cs.PendingPasswordExpiresAt != nil && !now.Before(*cs.PendingPasswordExpiresAt) {
+		return ""
+	}
+	return cs.PendingBase64SHA256Password
 }
 
 type ChargeStationStore interface {
@@ -58,12 +74,47 @@ var (
 	ChargeStationSettingStatusRejected       ChargeStationSettingStatus = "Rejected"
 	ChargeStationSettingStatusRebootRequired ChargeStationSettingStatus = "RebootRequired"
 	ChargeStationSettingStatusNotSupported   ChargeStationSettingStatus = "NotSupported"
+	// ChargeStationSettingStatusDrifted is used for a setting that was accepted by the charge
+	// station but has since been read back from the charge station with a different value
+	ChargeStationSettingStatusDrifted ChargeStationSettingStatus = "Drifted"
+)
+
+// The settings used to set the basic auth password that a charge station uses to connect
+const (
+	Ocpp16BasicAuthPasswordKey  = "AuthorizationKey"
+	Ocpp201BasicAuthPasswordKey = "SecurityCtrlr/BasicAuthPassword"
 )
 
+// IsWriteOnlySetting returns true for settings that hold a secret: the value of a write-only setting
+// is only kept until the charge station has responded to it and is never read back
+func IsWriteOnlySetting(name string) bool {
+	return name == Ocpp16BasicAuthPasswordKey || name == Ocpp201BasicAuthPasswordKey
+}
+
+// RetainedSettingValue returns the value that is kept (or logged) for a setting once it has been
+// sent to the charge station
+func RetainedSettingValue(name, value string) string {
+	if IsWriteOnlySetting(name) {
+		return ""
+	}
+	return value
+}
+
+// BasicAuthPasswordKey returns the setting used to set the basic auth password for the OCPP version
+func BasicAuthPasswordKey(ocppVersion OcppVersion) string {
+	if ocppVersion == OcppVersion16 {
+		return Ocpp16BasicAuthPasswordKey
+	}
+	return Ocpp201BasicAuthPasswordKey
+}
+
 type ChargeStationSetting struct {
 	Value     string
 	Status    ChargeStationSettingStatus
 	SendAfter time.Time
+	// ErrorCode and ErrorDescription are set if the charge station responded with a CallError
+	ErrorCode        string
+	ErrorDescription string
 }
 
 type ChargeStationSettings struct {
@@ -78,6 +129,41 @@ type ChargeStationSettingsStore interface {
 	DeleteChargeStationSettings(ctx context.Context, csId string) error
 }
 
+type ChargeStationVariableStatus string
+
+var (
+	ChargeStationVariableStatusPending                   ChargeStationVariableStatus = "Pending"
+	ChargeStationVariableStatusAccepted                  ChargeStationVariableStatus = "Accepted"
+	ChargeStationVariableStatusRejected                  ChargeStationVariableStatus = "Rejected"
+	ChargeStationVariableStatusUnknownComponent          ChargeStationVariableStatus = "UnknownComponent"
+	ChargeStationVariableStatusUnknownVariable           ChargeStationVariableStatus = "UnknownVariable"
+	ChargeStationVariableStatusNotSupportedAttributeType ChargeStationVariableStatus = "NotSupportedAttributeType"
+	ChargeStationVariableStatusUnknownKey                ChargeStationVariableStatus = "UnknownKey"
+)
+
+type ChargeStationVariable struct {
+	Value     *string
+	Status    ChargeStationVariableStatus
+	SendAfter time.Time
+	// ErrorCode and ErrorDescription are set if the charge station responded with a CallError
+	ErrorCode        string
+	ErrorDescription string
+}
+
+// ChargeStationVariables are the variables that have been read (or are waiting to
+// be read) from a charge station. The variables are keyed by name: the OCPP 1.6
+// configuration key or the OCPP 2.0.1 <component>/<variable> name.
+type ChargeStationVariables struct {
+	ChargeStationId string
+	Variables       map[string]*ChargeStationVariable
+}
+
+type ChargeStationVariablesStore interface {
+	UpdateChargeStationVariables(ctx context.Context, csId string, variables *ChargeStationVariables) error
+	LookupChargeStationVariables(ctx context.Context, csId string) (*ChargeStationVariables, error)
+	ListChargeStationVariables(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationVariables, error)
+}
+
 type OcppVersion string
 
 const (
@@ -87,6 +173,8 @@ const (
 
 type ChargeStationRuntimeDetails struct {
 	OcppVersion OcppVersion `json:"ocpp_version"`
+	Vendor      string      `json:"vendor"`
+	Model       string      `json:"model"`
 }
 
 type ChargeStationRuntimeDetailsStore interface {
@@ -111,6 +199,8 @@ var (
 	CertificateInstallationPending  CertificateInstallationStatus = "Pending"
 	CertificateInstallationAccepted CertificateInstallationStatus = "Accepted"
 	CertificateInstallationRejected CertificateInstallationStatus = "Rejected"
+	// CertificateInstallationErrored is used when the charge station responded with a CallError
+	CertificateInstallationErrored CertificateInstallationStatus = "Errored"
 )
 
 type ChargeStationInstallCertificate struct {
@@ -119,6 +209,8 @@ type ChargeStationInstallCertificate struct {
 	CertificateData               string
 	CertificateInstallationStatus CertificateInstallationStatus
 	SendAfter                     time.Time
+	ErrorCode                     string
+	ErrorDescription              string
 }
 
 type ChargeStationInstallCertificates struct {
@@ -139,6 +231,8 @@ var (
 	TriggerStatusAccepted       TriggerStatus = "Accepted"
 	TriggerStatusRejected       TriggerStatus = "Rejected"
 	TriggerStatusNotImplemented TriggerStatus = "NotImplemented"
+	// TriggerStatusFailed is used when the charge station responded with a CallError
+	TriggerStatusFailed TriggerStatus = "Failed"
 )
 
 type TriggerMessage string
@@ -161,6 +255,9 @@ type ChargeStationTriggerMessage struct {
 	TriggerMessage  TriggerMessage
 	TriggerStatus   TriggerStatus
 	SendAfter       time.Time
+	// ErrorCode and ErrorDescription are set if the charge station responded with a CallError
+	ErrorCode        string
+	ErrorDescription string
 }
 
 type ChargeStationTriggerMessageStore interface {
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type DeviceModelVariableAttribute struct {
+	Type       string  `json:"type"`
+	Value      *string `json:"value,omitempty"`
+	Mutability string  `json:"mutability"`
+	Persistent bool    `json:"persistent"`
+	Constant   bool    `json:"constant"`
+}
+
+type DeviceModelVariableCharacteristics struct {
+	DataType           string   `json:"data_type"`
+	Unit               *string  `json:"unit,omitempty"`
+	MinLimit           *float64 `json:"min_limit,omitempty"`
+	MaxLimit           *float64 `json:"max_limit,omitempty"`
+	ValuesList         *string  `json:"values_list,omitempty"`
+	SupportsMonitoring bool     `json:"supports_monitoring"`
+}
+
+type DeviceModelVariable struct {
+	ComponentName     string                              `json:"component_name"`
+	ComponentInstance *string                             `json:"component_instance,omitempty"`
+	EvseId            *int                                `json:"evse_id,omitempty"`
+	ConnectorId       *int                                `json:"connector_id,omitempty"`
+	VariableName      string                              `json:"variable_name"`
+	VariableInstance  *string                             `json:"variable_instance,omitempty"`
+	Attributes        []*DeviceModelVariableAttribute     `json:"attributes"`
+	Characteristics   *DeviceModelVariableCharacteristics `json:"characteristics,omitempty"`
+}
+
+// ComponentKey uniquely identifies the variable's component within a charge
+// station's device model. The format is <component>[<instance>]@<evse>.<connector>
+// with the optional parts omitted when not present.
+func (v *DeviceModelVariable) ComponentKey() string {
+	var b strings.Builder
+	b.WriteString(v.ComponentName)
+	if v.ComponentInstance != nil {
+		_, _ = fmt.Fprintf(&b, "[%s]", *v.ComponentInstance)
+	}
+	if v.EvseId != nil {
+		_, _ = fmt.Fprintf(&b, "@%d", *v.EvseId)
+		if v.ConnectorId != nil {
+			_, _ = fmt.Fprintf(&b, ".%d", *v.ConnectorId)
+		}
+	}
+	return b.String()
+}
+
+// Key uniquely identifies the component/variable combination within a charge
+// station's device model. The format is <component key>/<variable>[<instance>]
+// with the optional parts omitted when not present.
+func (v *DeviceModelVariable) Key() string {
+	var b strings.Builder
+	b.WriteString(v.ComponentKey())
+	b.WriteString("/")
+	b.WriteString(v.VariableName)
+	if v.VariableInstance != nil {
+		_, _ = fmt.Fprintf(&b, "[%s]", *v.VariableInstance)
+	}
+	return b.String()
+}
+
+type ChargeStationDeviceModel struct {
+	ChargeStationId string
+	Variables       []*DeviceModelVariable
+	UpdatedAt       time.Time
+}
+
+// DeviceModelReportPart is a single NotifyReport message that forms part of a
+// (possibly multi-part) report.
+type DeviceModelReportPart struct {
+	SeqNo     int
+	Tbc       bool
+	Variables []*DeviceModelVariable
+}
+
+type ChargeStationDeviceModelStore interface {
+	SetDeviceModelReportPart(ctx context.Context, csId string, requestId int, part *DeviceModelReportPart) error
+	LookupDeviceModelReportParts(ctx context.Context, csId string, requestId int) ([]*DeviceModelReportPart, error)
+	DeleteDeviceModelReportParts(ctx context.Context, csId string, requestId int) error
+	// UpdateChargeStationDeviceModel merges the variables into the charge station's device model:
+	// existing variables with the same key are replaced
+	UpdateChargeStationDeviceModel(ctx context.Context, csId string, variables []*DeviceModelVariable, updatedAt time.Time) error
+	// LookupChargeStationDeviceModel returns the charge station's device model with the variables ordered by key
+	LookupChargeStationDeviceModel(ctx context.Context, csId string) (*ChargeStationDeviceModel, error)
+}
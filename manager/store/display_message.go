@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+type DisplayMessageStatus string
+
+var (
+	// DisplayMessageStatusPending is used until the charge station accepts the message
+	DisplayMessageStatusPending  DisplayMessageStatus = "Pending"
+	DisplayMessageStatusAccepted DisplayMessageStatus = "Accepted"
+	// DisplayMessageStatusNotSupportedMessageFormat, DisplayMessageStatusNotSupportedPriority,
+	// DisplayMessageStatusNotSupportedState, DisplayMessageStatusRejected and
+	// DisplayMessageStatusUnknownTransaction are returned by the charge station when it will not
+	// display the message
+	DisplayMessageStatusNotSupportedMessageFormat DisplayMessageStatus = "NotSupportedMessageFormat"
+	DisplayMessageStatusNotSupportedPriority      DisplayMessageStatus = "NotSupportedPriority"
+	DisplayMessageStatusNotSupportedState         DisplayMessageStatus = "NotSupportedState"
+	DisplayMessageStatusRejected                  DisplayMessageStatus = "Rejected"
+	DisplayMessageStatusUnknownTransaction        DisplayMessageStatus = "UnknownTransaction"
+	// DisplayMessageStatusErrored is used when the charge station responds with a CallError
+	DisplayMessageStatusErrored DisplayMessageStatus = "Errored"
+	// DisplayMessageStatusClearPending is used for a message that must be cleared from the charge station
+	DisplayMessageStatusClearPending DisplayMessageStatus = "ClearPending"
+	// DisplayMessageStatusUnmanaged is used for a message that the charge station reports that it
+	// holds but that was not scheduled by the CSMS
+	DisplayMessageStatusUnmanaged DisplayMessageStatus = "Unmanaged"
+)
+
+// DisplayMessage is a message shown on a charge station's display. The message id is unique for
+// the charge station.
+type DisplayMessage struct {
+	Id               int
+	Priority         string  // AlwaysFront, InFront or NormalCycle
+	State            *string // the charge station state in which the message is shown
+	StartDateTime    *time.Time
+	EndDateTime      *time.Time
+	TransactionId    *string
+	Format           string // ASCII, HTML, URI or UTF8
+	Language         *string
+	Content          string
+	Status           DisplayMessageStatus
+	ErrorCode        string
+	ErrorDescription string
+	SendAfter        time.Time
+	// ReportedRequestId is the id of the last GetDisplayMessages request for which the charge
+	// station reported that it holds the message
+	ReportedRequestId int
+}
+
+type DisplayMessageReconcileStatus string
+
+var (
+	// DisplayMessageReconcileStatusRequired is used when the charge station has rebooted and
+	// the messages that it holds must be checked
+	DisplayMessageReconcileStatusRequired DisplayMessageReconcileStatus = "Required"
+	// DisplayMessageReconcileStatusPending is used when the messages held by the charge station
+	// have been requested
+	DisplayMessageReconcileStatusPending DisplayMessageReconcileStatus = "Pending"
+)
+
+// ChargeStationDisplayMessages are the display messages managed for a charge station. The
+// messages held by the charge station are reconciled with the managed messages (using
+// GetDisplayMessages and NotifyDisplayMessages) after the charge station boots.
+type ChargeStationDisplayMessages struct {
+	ChargeStationId    string
+	Messages           map[int]*DisplayMessage
+	ReconcileStatus    DisplayMessageReconcileStatus // empty if the messages do not need to be reconciled
+	ReconcileRequestId int
+	ReconcileAfter     time.Time
+	UpdatedAt          time.Time
+}
+
+type DisplayMessageStore interface {
+	SetChargeStationDisplayMessages(ctx context.Context, csId string, messages *ChargeStationDisplayMessages) error
+	LookupChargeStationDisplayMessages(ctx context.Context, csId string) (*ChargeStationDisplayMessages, error)
+	ListChargeStationDisplayMessages(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationDisplayMessages, error)
+}
@@ -6,12 +6,25 @@ type Engine interface {
 	Store
 	ChargeStationStore
 	ChargeStationSettingsStore
+	ChargeStationVariablesStore
 	ChargeStationRuntimeDetailsStore
 	ChargeStationInstallCertificatesStore
+	IssuedCertificateStore
+	CertificateInventoryStore
+	OcspResponseStore
 	ChargeStationTriggerMessageStore
+	ChargeStationDeviceModelStore
+	ChargeStationOperationStore
+	ChargeStationSecurityProfileUpgradeStore
+	ChargeStationConnectionStore
+	LocalListStore
+	DisplayMessageStore
+	ReservationStore
+	ConfigurationTemplateStore
 	TokenStore
 	TransactionStore
 	CertificateStore
+	CertificateRevocationStore
 	OcpiStore
 	LocationStore
 }
@@ -9,6 +9,10 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -82,3 +86,77 @@ func (s *Store) DeleteCertificate(ctx context.Context, certificateHash string) e
 
 	return nil
 }
+
+type revokedCertificate struct {
+	ChargeStationId string    `firestore:"cs"`
+	SerialNumber    string    `firestore:"sn"`
+	Issuer          string    `firestore:"is"`
+	Reason          string    `firestore:"r"`
+	RevokedAt       time.Time `firestore:"ra"`
+}
+
+func (s *Store) SetRevokedCertificate(ctx context.Context, certificate *store.RevokedCertificate) error {
+	certRef := s.client.Doc(fmt.Sprintf("RevokedCertificate/%s", certificate.CertificateHash))
+	_, err := certRef.Set(ctx, &revokedCertificate{
+		ChargeStationId: certificate.ChargeStationId,
+		SerialNumber:    certificate.SerialNumber,
+		Issuer:          certificate.Issuer,
+		Reason:          certificate.Reason,
+		RevokedAt:       certificate.RevokedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting revoked certificate %s: %w", certificate.CertificateHash, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupRevokedCertificate(ctx context.Context, certificateHash string) (*store.RevokedCertificate, error) {
+	certRef := s.client.Doc(fmt.Sprintf("RevokedCertificate/%s", certificateHash))
+	snap, err := certRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup revoked certificate %s: %w", certificateHash, err)
+	}
+	var certificate revokedCertificate
+	if err = snap.DataTo(&certificate); err != nil {
+		return nil, fmt.Errorf("map revoked certificate %s: %w", certificateHash, err)
+	}
+	return mapRevokedCertificate(certificateHash, &certificate), nil
+}
+
+func (s *Store) ListRevokedCertificates(ctx context.Context, pageSize int, previousCertificateHash string) ([]*store.RevokedCertificate, error) {
+	var result []*store.RevokedCertificate
+	var docIt *firestore.DocumentIterator
+	if previousCertificateHash == "" {
+		docIt = s.client.Collection("RevokedCertificate").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("RevokedCertificate").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCertificateHash).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list revoked certificates: %w", err)
+	}
+	for _, snap := range snaps {
+		var certificate revokedCertificate
+		if err = snap.DataTo(&certificate); err != nil {
+			return nil, fmt.Errorf("map revoked certificate: %w", err)
+		}
+		result = append(result, mapRevokedCertificate(snap.Ref.ID, &certificate))
+	}
+	return result, nil
+}
+
+func mapRevokedCertificate(certificateHash string, certificate *revokedCertificate) *store.RevokedCertificate {
+	return &store.RevokedCertificate{
+		CertificateHash: certificateHash,
+		ChargeStationId: certificate.ChargeStationId,
+		SerialNumber:    certificate.SerialNumber,
+		Issuer:          certificate.Issuer,
+		Reason:          certificate.Reason,
+		RevokedAt:       certificate.RevokedAt,
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"encoding/pem"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
 	"k8s.io/utils/clock"
 	"math/big"
@@ -56,6 +57,63 @@ func TestSetAndLookupAndDeleteCertificate(t *testing.T) {
 	assert.Equal(t, "", got)
 }
 
+func TestSetAndLookupRevokedCertificate(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	certificate := &store.RevokedCertificate{
+		CertificateHash: "abc123",
+		ChargeStationId: "cs001",
+		SerialNumber:    "1234",
+		Issuer:          "CN=CA",
+		Reason:          "keyCompromise",
+		RevokedAt:       time.Now().UTC().Truncate(time.Millisecond),
+	}
+	err = engine.SetRevokedCertificate(ctx, certificate)
+	require.NoError(t, err)
+
+	got, err := engine.LookupRevokedCertificate(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, certificate, got)
+
+	got, err = engine.LookupRevokedCertificate(ctx, "def456")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListRevokedCertificates(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	for _, certificateHash := range []string{"abc123", "def456"} {
+		err = engine.SetRevokedCertificate(ctx, &store.RevokedCertificate{
+			CertificateHash: certificateHash,
+			RevokedAt:       time.Now().UTC().Truncate(time.Millisecond),
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListRevokedCertificates(ctx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "abc123", got[0].CertificateHash)
+
+	got, err = engine.ListRevokedCertificates(ctx, 1, "abc123")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "def456", got[0].CertificateHash)
+}
+
 func generateCertificate(t *testing.T) *x509.Certificate {
 	keyPair, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type inventoryCertificate struct {
+	CertificateType string `firestore:"t"`
+	HashAlgorithm   string `firestore:"ha"`
+	IssuerNameHash  string `firestore:"in"`
+	IssuerKeyHash   string `firestore:"ik"`
+	SerialNumber    string `firestore:"sn"`
+	Subject         string `firestore:"s,omitempty"`
+}
+
+type chargeStationCertificateInventory struct {
+	Certificates        []*inventoryCertificate `firestore:"c"`
+	ReportedAt          *time.Time              `firestore:"rp"`
+	RequestedAt         *time.Time              `firestore:"rq"`
+	MissingCertificates []*inventoryCertificate `firestore:"m"`
+	AuditedAt           *time.Time              `firestore:"aa"`
+	UpdatedAt           time.Time               `firestore:"ua"`
+}
+
+func (s *Store) SetChargeStationCertificateInventory(ctx context.Context, chargeStationId string, inventory *store.ChargeStationCertificateInventory) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationCertificateInventory/%s", chargeStationId))
+	_, err := csRef.Set(ctx, &chargeStationCertificateInventory{
+		Certificates:        toInventoryCertificates(inventory.Certificates),
+		ReportedAt:          inventory.ReportedAt,
+		RequestedAt:         inventory.RequestedAt,
+		MissingCertificates: toInventoryCertificates(inventory.MissingCertificates),
+		AuditedAt:           inventory.AuditedAt,
+		UpdatedAt:           inventory.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station certificate inventory %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationCertificateInventory(ctx context.Context, chargeStationId string) (*store.ChargeStationCertificateInventory, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationCertificateInventory/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station certificate inventory %s: %w", chargeStationId, err)
+	}
+	var inventory chargeStationCertificateInventory
+	if err = snap.DataTo(&inventory); err != nil {
+		return nil, fmt.Errorf("map charge station certificate inventory %s: %w", chargeStationId, err)
+	}
+	return mapChargeStationCertificateInventory(chargeStationId, &inventory), nil
+}
+
+func (s *Store) ListChargeStationCertificateInventories(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationCertificateInventory, error) {
+	var result []*store.ChargeStationCertificateInventory
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationCertificateInventory").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationCertificateInventory").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station certificate inventories: %w", err)
+	}
+	for _, snap := range snaps {
+		var inventory chargeStationCertificateInventory
+		if err = snap.DataTo(&inventory); err != nil {
+			return nil, fmt.Errorf("map charge station certificate inventory: %w", err)
+		}
+		result = append(result, mapChargeStationCertificateInventory(snap.Ref.ID, &inventory))
+	}
+	return result, nil
+}
+
+func toInventoryCertificates(certificates []*store.InventoryCertificate) []*inventoryCertificate {
+	if certificates == nil {
+		return nil
+	}
+	result := make([]*inventoryCertificate, len(certificates))
+	for i, cert := range certificates {
+		result[i] = &inventoryCertificate{
+			CertificateType: string(cert.CertificateType),
+			HashAlgorithm:   cert.HashAlgorithm,
+			IssuerNameHash:  cert.IssuerNameHash,
+			IssuerKeyHash:   cert.IssuerKeyHash,
+			SerialNumber:    cert.SerialNumber,
+			Subject:         cert.Subject,
+		}
+	}
+	return result
+}
+
+func fromInventoryCertificates(certificates []*inventoryCertificate) []*store.InventoryCertificate {
+	if certificates == nil {
+		return nil
+	}
+	result := make([]*store.InventoryCertificate, len(certificates))
+	for i, cert := range certificates {
+		result[i] = &store.InventoryCertificate{
+			CertificateType: store.CertificateType(cert.CertificateType),
+			HashAlgorithm:   cert.HashAlgorithm,
+			IssuerNameHash:  cert.IssuerNameHash,
+			IssuerKeyHash:   cert.IssuerKeyHash,
+			SerialNumber:    cert.SerialNumber,
+			Subject:         cert.Subject,
+		}
+	}
+	return result
+}
+
+func mapChargeStationCertificateInventory(chargeStationId string, inventory *chargeStationCertificateInventory) *store.ChargeStationCertificateInventory {
+	return &store.ChargeStationCertificateInventory{
+		ChargeStationId:     chargeStationId,
+		Certificates:        fromInventoryCertificates(inventory.Certificates),
+		ReportedAt:          inventory.ReportedAt,
+		RequestedAt:         inventory.RequestedAt,
+		MissingCertificates: fromInventoryCertificates(inventory.MissingCertificates),
+		AuditedAt:           inventory.AuditedAt,
+		UpdatedAt:           inventory.UpdatedAt,
+	}
+}
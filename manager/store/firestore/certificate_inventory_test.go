@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupChargeStationCertificateInventory(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	inventory := &store.ChargeStationCertificateInventory{
+		ChargeStationId: "cs001",
+		Certificates: []*store.InventoryCertificate{
+			{
+				CertificateType: store.CertificateTypeV2G,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "name",
+				IssuerKeyHash:   "key",
+				SerialNumber:    "1234",
+			},
+		},
+		ReportedAt: &now,
+		MissingCertificates: []*store.InventoryCertificate{
+			{
+				CertificateType: store.CertificateTypeMO,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "mo-name",
+				IssuerKeyHash:   "mo-key",
+				SerialNumber:    "5678",
+				Subject:         "CN=MO Root",
+			},
+		},
+		AuditedAt: &now,
+		UpdatedAt: now,
+	}
+	err = engine.SetChargeStationCertificateInventory(ctx, "cs001", inventory)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationCertificateInventory(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, inventory, got)
+
+	got, err = engine.LookupChargeStationCertificateInventory(ctx, "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeStationCertificateInventories(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	for _, csId := range []string{"cs001", "cs002"} {
+		err = engine.SetChargeStationCertificateInventory(ctx, csId, &store.ChargeStationCertificateInventory{
+			UpdatedAt: time.Now().UTC().Truncate(time.Millisecond),
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationCertificateInventories(ctx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs001", got[0].ChargeStationId)
+
+	got, err = engine.ListChargeStationCertificateInventories(ctx, 1, "cs001")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs002", got[0].ChargeStationId)
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type configurationTemplate struct {
+	LocationId *string           `firestore:"l"`
+	Vendor     *string           `firestore:"vn"`
+	Model      *string           `firestore:"m"`
+	Tag        *string           `firestore:"t"`
+	Settings   map[string]string `firestore:"s"`
+}
+
+func mapConfigurationTemplate(templateId string, t *configurationTemplate) *store.ConfigurationTemplate {
+	return &store.ConfigurationTemplate{
+		Id:         templateId,
+		LocationId: t.LocationId,
+		Vendor:     t.Vendor,
+		Model:      t.Model,
+		Tag:        t.Tag,
+		Settings:   t.Settings,
+	}
+}
+
+func (s *Store) SetConfigurationTemplate(ctx context.Context, template *store.ConfigurationTemplate) error {
+	templateRef := s.client.Doc(fmt.Sprintf("ConfigurationTemplate/%s", template.Id))
+	_, err := templateRef.Set(ctx, &configurationTemplate{
+		LocationId: template.LocationId,
+		Vendor:     template.Vendor,
+		Model:      template.Model,
+		Tag:        template.Tag,
+		Settings:   template.Settings,
+	})
+	if err != nil {
+		return fmt.Errorf("setting configuration template %s: %w", template.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupConfigurationTemplate(ctx context.Context, templateId string) (*store.ConfigurationTemplate, error) {
+	templateRef := s.client.Doc(fmt.Sprintf("ConfigurationTemplate/%s", templateId))
+	snap, err := templateRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup configuration template %s: %w", templateId, err)
+	}
+	var templateData configurationTemplate
+	if err = snap.DataTo(&templateData); err != nil {
+		return nil, fmt.Errorf("map configuration template %s: %w", templateId, err)
+	}
+	return mapConfigurationTemplate(templateId, &templateData), nil
+}
+
+func (s *Store) ListConfigurationTemplates(ctx context.Context) ([]*store.ConfigurationTemplate, error) {
+	templates := make([]*store.ConfigurationTemplate, 0)
+	iter := s.client.Collection("ConfigurationTemplate").OrderBy(firestore.DocumentID, firestore.Asc).Documents(ctx)
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("next configuration template: %w", err)
+		}
+		var templateData configurationTemplate
+		if err = snap.DataTo(&templateData); err != nil {
+			return nil, fmt.Errorf("map configuration template %s: %w", snap.Ref.ID, err)
+		}
+		templates = append(templates, mapConfigurationTemplate(snap.Ref.ID, &templateData))
+	}
+	return templates, nil
+}
+
+func (s *Store) DeleteConfigurationTemplate(ctx context.Context, templateId string) error {
+	templateRef := s.client.Doc(fmt.Sprintf("ConfigurationTemplate/%s", templateId))
+	_, err := templateRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting configuration template %s: %w", templateId, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupConfigurationTemplate(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	vendor := "VendorX"
+	want := &store.ConfigurationTemplate{
+		Id:     "tpl001",
+		Vendor: &vendor,
+		Settings: map[string]string{
+			"HeartbeatInterval":               "60",
+			"OCPPCommCtrlr/HeartbeatInterval": "60",
+		},
+	}
+	err = engine.SetConfigurationTemplate(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupConfigurationTemplate(ctx, "tpl001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	err = engine.DeleteConfigurationTemplate(ctx, "tpl001")
+	require.NoError(t, err)
+
+	got, err = engine.LookupConfigurationTemplate(ctx, "tpl001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListConfigurationTemplates(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	tag := "fast"
+	for _, id := range []string{"c", "a", "b"} {
+		err = engine.SetConfigurationTemplate(ctx, &store.ConfigurationTemplate{
+			Id:       id,
+			Tag:      &tag,
+			Settings: map[string]string{"HeartbeatInterval": "60"},
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListConfigurationTemplates(ctx)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, template := range got {
+		ids = append(ids, template.Id)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+}
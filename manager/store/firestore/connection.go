@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type chargeStationConnection struct {
+	ConnectionId    string    `firestore:"id"`
+	GatewayId       string    `firestore:"gw"`
+	RemoteAddress   string    `firestore:"ra"`
+	Subprotocol     string    `firestore:"sp"`
+	SecurityProfile int       `firestore:"pr"`
+	ConnectedAt     time.Time `firestore:"ca"`
+}
+
+func (s *Store) SetChargeStationConnection(ctx context.Context, chargeStationId string, connection *store.ChargeStationConnection) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationConnection/%s", chargeStationId))
+	_, err := csRef.Set(ctx, &chargeStationConnection{
+		ConnectionId:    connection.ConnectionId,
+		GatewayId:       connection.GatewayId,
+		RemoteAddress:   connection.RemoteAddress,
+		Subprotocol:     connection.Subprotocol,
+		SecurityProfile: int(connection.SecurityProfile),
+		ConnectedAt:     connection.ConnectedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station connection %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationConnection(ctx context.Context, chargeStationId string) (*store.ChargeStationConnection, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationConnection/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station connection %s: %w", chargeStationId, err)
+	}
+	var connection chargeStationConnection
+	if err = snap.DataTo(&connection); err != nil {
+		return nil, fmt.Errorf("map charge station connection %s: %w", chargeStationId, err)
+	}
+	return mapChargeStationConnection(chargeStationId, &connection), nil
+}
+
+func (s *Store) DeleteChargeStationConnection(ctx context.Context, chargeStationId string) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationConnection/%s", chargeStationId))
+	_, err := csRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("delete charge station connection %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) ListChargeStationConnections(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationConnection, error) {
+	var connections []*store.ChargeStationConnection
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationConnection").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationConnection").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station connections: %w", err)
+	}
+	for _, snap := range snaps {
+		var connection chargeStationConnection
+		if err = snap.DataTo(&connection); err != nil {
+			return nil, fmt.Errorf("map charge station connection: %w", err)
+		}
+		connections = append(connections, mapChargeStationConnection(snap.Ref.ID, &connection))
+	}
+	return connections, nil
+}
+
+func mapChargeStationConnection(chargeStationId string, connection *chargeStationConnection) *store.ChargeStationConnection {
+	return &store.ChargeStationConnection{
+		ChargeStationId: chargeStationId,
+		ConnectionId:    connection.ConnectionId,
+		GatewayId:       connection.GatewayId,
+		RemoteAddress:   connection.RemoteAddress,
+		Subprotocol:     connection.Subprotocol,
+		SecurityProfile: store.SecurityProfile(connection.SecurityProfile),
+		ConnectedAt:     connection.ConnectedAt,
+	}
+}
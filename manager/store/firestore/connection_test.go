@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetLookupAndDeleteChargeStationConnection(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	want := &store.ChargeStationConnection{
+		ChargeStationId: "cs001",
+		ConnectionId:    "conn-1",
+		GatewayId:       "gateway-1",
+		RemoteAddress:   "10.0.0.1:4321",
+		Subprotocol:     "ocpp2.0.1",
+		SecurityProfile: store.TLSWithBasicAuth,
+		ConnectedAt:     time.Now().UTC().Truncate(time.Millisecond),
+	}
+	err = engine.SetChargeStationConnection(ctx, "cs001", want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	err = engine.DeleteChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+
+	got, err = engine.LookupChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeStationConnections(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	for _, csId := range []string{"cs003", "cs001", "cs002"} {
+		err = engine.SetChargeStationConnection(ctx, csId, &store.ChargeStationConnection{
+			ConnectionId: "conn-" + csId,
+			GatewayId:    "gateway-1",
+		})
+		require.NoError(t, err)
+	}
+
+	list, err := engine.ListChargeStationConnections(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "cs001", list[0].ChargeStationId)
+	assert.Equal(t, "cs002", list[1].ChargeStationId)
+
+	list, err = engine.ListChargeStationConnections(ctx, 2, "cs002")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "cs003", list[0].ChargeStationId)
+}
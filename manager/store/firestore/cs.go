@@ -76,9 +76,11 @@ func (s *Store) ListChargeStations(context context.Context, offset, limit int) (
 }
 
 type chargeStationSetting struct {
-	Value     string    `firestore:"v"`
-	Status    string    `firestore:"s"`
-	SendAfter time.Time `firestore:"u"`
+	Value            string    `firestore:"v"`
+	Status           string    `firestore:"s"`
+	SendAfter        time.Time `firestore:"u"`
+	ErrorCode        string    `firestore:"ec"`
+	ErrorDescription string    `firestore:"ed"`
 }
 
 func (s *Store) UpdateChargeStationSettings(ctx context.Context, chargeStationId string, settings *store.ChargeStationSettings) error {
@@ -86,8 +88,10 @@ func (s *Store) UpdateChargeStationSettings(ctx context.Context, chargeStationId
 	var set = make(map[string]*chargeStationSetting)
 	for k, v := range settings.Settings {
 		set[k] = &chargeStationSetting{
-			Value:  v.Value,
-			Status: string(v.Status),
+			Value:            v.Value,
+			Status:           string(v.Status),
+			ErrorCode:        v.ErrorCode,
+			ErrorDescription: v.ErrorDescription,
 		}
 	}
 	_, err := csRef.Set(ctx, set, firestore.MergeAll)
@@ -130,9 +134,11 @@ func mapChargeStationSettings(csData map[string]*chargeStationSetting) map[strin
 	var settings = make(map[string]*store.ChargeStationSetting)
 	for k, v := range csData {
 		settings[k] = &store.ChargeStationSetting{
-			Value:     v.Value,
-			Status:    store.ChargeStationSettingStatus(v.Status),
-			SendAfter: v.SendAfter,
+			Value:            v.Value,
+			Status:           store.ChargeStationSettingStatus(v.Status),
+			SendAfter:        v.SendAfter,
+			ErrorCode:        v.ErrorCode,
+			ErrorDescription: v.ErrorDescription,
 		}
 	}
 	return settings
@@ -166,11 +172,100 @@ func (s *Store) ListChargeStationSettings(ctx context.Context, pageSize int, pre
 	return chargeStationSettings, nil
 }
 
+type chargeStationVariable struct {
+	Value            *string   `firestore:"v"`
+	Status           string    `firestore:"s"`
+	SendAfter        time.Time `firestore:"u"`
+	ErrorCode        string    `firestore:"ec"`
+	ErrorDescription string    `firestore:"ed"`
+}
+
+func (s *Store) UpdateChargeStationVariables(ctx context.Context, chargeStationId string, variables *store.ChargeStationVariables) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationVariables/%s", chargeStationId))
+	var vars = make(map[string]*chargeStationVariable)
+	for k, v := range variables.Variables {
+		vars[k] = &chargeStationVariable{
+			Value:            v.Value,
+			Status:           string(v.Status),
+			SendAfter:        v.SendAfter,
+			ErrorCode:        v.ErrorCode,
+			ErrorDescription: v.ErrorDescription,
+		}
+	}
+	_, err := csRef.Set(ctx, vars, firestore.MergeAll)
+	if err != nil {
+		return fmt.Errorf("setting charge station variables %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationVariables(ctx context.Context, chargeStationId string) (*store.ChargeStationVariables, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationVariables/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station variables %s: %w", chargeStationId, err)
+	}
+	var csData map[string]*chargeStationVariable
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map charge station variables %s: %w", chargeStationId, err)
+	}
+	return &store.ChargeStationVariables{
+		ChargeStationId: chargeStationId,
+		Variables:       mapChargeStationVariables(csData),
+	}, nil
+}
+
+func mapChargeStationVariables(csData map[string]*chargeStationVariable) map[string]*store.ChargeStationVariable {
+	var variables = make(map[string]*store.ChargeStationVariable)
+	for k, v := range csData {
+		variables[k] = &store.ChargeStationVariable{
+			Value:            v.Value,
+			Status:           store.ChargeStationVariableStatus(v.Status),
+			SendAfter:        v.SendAfter,
+			ErrorCode:        v.ErrorCode,
+			ErrorDescription: v.ErrorDescription,
+		}
+	}
+	return variables
+}
+
+func (s *Store) ListChargeStationVariables(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationVariables, error) {
+	var chargeStationVariables []*store.ChargeStationVariables
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationVariables").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationVariables").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station variables: %w", err)
+	}
+	for _, snap := range snaps {
+		var variables map[string]*chargeStationVariable
+		if err = snap.DataTo(&variables); err != nil {
+			return nil, fmt.Errorf("map charge station variables: %w", err)
+		}
+		chargeStationVariables = append(chargeStationVariables, &store.ChargeStationVariables{
+			ChargeStationId: snap.Ref.ID,
+			Variables:       mapChargeStationVariables(variables),
+		})
+	}
+	return chargeStationVariables, nil
+}
+
 type chargeStationInstallCertificate struct {
-	Type      string    `firestore:"t"`
-	Data      string    `firestore:"d"`
-	Status    string    `firestore:"s"`
-	SendAfter time.Time `firestore:"u"`
+	Type             string    `firestore:"t"`
+	Data             string    `firestore:"d"`
+	Status           string    `firestore:"s"`
+	SendAfter        time.Time `firestore:"u"`
+	ErrorCode        string    `firestore:"ec"`
+	ErrorDescription string    `firestore:"ed"`
 }
 
 func mapChargeStationInstallCertificates(certificates map[string]*chargeStationInstallCertificate) []*store.ChargeStationInstallCertificate {
@@ -182,6 +277,8 @@ func mapChargeStationInstallCertificates(certificates map[string]*chargeStationI
 			CertificateData:               c.Data,
 			CertificateInstallationStatus: store.CertificateInstallationStatus(c.Status),
 			SendAfter:                     c.SendAfter,
+			ErrorCode:                     c.ErrorCode,
+			ErrorDescription:              c.ErrorDescription,
 		})
 	}
 	return certs
@@ -192,10 +289,12 @@ func (s *Store) UpdateChargeStationInstallCertificates(ctx context.Context, char
 	var set = make(map[string]*chargeStationInstallCertificate)
 	for _, c := range certificates.Certificates {
 		set[c.CertificateId] = &chargeStationInstallCertificate{
-			Type:      string(c.CertificateType),
-			Data:      c.CertificateData,
-			Status:    string(c.CertificateInstallationStatus),
-			SendAfter: c.SendAfter,
+			Type:             string(c.CertificateType),
+			Data:             c.CertificateData,
+			Status:           string(c.CertificateInstallationStatus),
+			SendAfter:        c.SendAfter,
+			ErrorCode:        c.ErrorCode,
+			ErrorDescription: c.ErrorDescription,
 		}
 	}
 	_, err := csRef.Set(ctx, set, firestore.MergeAll)
@@ -255,12 +354,16 @@ func (s *Store) ListChargeStationInstallCertificates(ctx context.Context, pageSi
 
 type chargeStationRuntimeDetails struct {
 	OcppVersion string `firestore:"v"`
+	Vendor      string `firestore:"vn"`
+	Model       string `firestore:"m"`
 }
 
 func (s *Store) SetChargeStationRuntimeDetails(ctx context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
 	csRef := s.client.Doc(fmt.Sprintf("ChargeStationRuntimeDetails/%s", chargeStationId))
 	_, err := csRef.Set(ctx, &chargeStationRuntimeDetails{
 		OcppVersion: string(details.OcppVersion),
+		Vendor:      details.Vendor,
+		Model:       details.Model,
 	})
 	if err != nil {
 		return err
@@ -283,21 +386,27 @@ func (s *Store) LookupChargeStationRuntimeDetails(ctx context.Context, chargeSta
 	}
 	return &store.ChargeStationRuntimeDetails{
 		OcppVersion: store.OcppVersion(csData.OcppVersion),
+		Vendor:      csData.Vendor,
+		Model:       csData.Model,
 	}, nil
 }
 
 type chargeStationTriggerMessage struct {
-	Type      string    `firestore:"t"`
-	Status    string    `firestore:"s"`
-	SendAfter time.Time `firestore:"u"`
+	Type             string    `firestore:"t"`
+	Status           string    `firestore:"s"`
+	SendAfter        time.Time `firestore:"u"`
+	ErrorCode        string    `firestore:"ec"`
+	ErrorDescription string    `firestore:"ed"`
 }
 
 func (s *Store) SetChargeStationTriggerMessage(ctx context.Context, chargeStationId string, triggerMessage *store.ChargeStationTriggerMessage) error {
 	csRef := s.client.Doc(fmt.Sprintf("ChargeStationTriggerMessage/%s", chargeStationId))
 	_, err := csRef.Set(ctx, &chargeStationTriggerMessage{
-		Type:      string(triggerMessage.TriggerMessage),
-		Status:    string(triggerMessage.TriggerStatus),
-		SendAfter: triggerMessage.SendAfter,
+		Type:             string(triggerMessage.TriggerMessage),
+		Status:           string(triggerMessage.TriggerStatus),
+		SendAfter:        triggerMessage.SendAfter,
+		ErrorCode:        triggerMessage.ErrorCode,
+		ErrorDescription: triggerMessage.ErrorDescription,
 	})
 	if err != nil {
 		return err
@@ -328,10 +437,12 @@ func (s *Store) LookupChargeStationTriggerMessage(ctx context.Context, chargeSta
 		return nil, fmt.Errorf("map charge station trigger message %s: %w", chargeStationId, err)
 	}
 	return &store.ChargeStationTriggerMessage{
-		ChargeStationId: chargeStationId,
-		TriggerMessage:  store.TriggerMessage(csData.Type),
-		TriggerStatus:   store.TriggerStatus(csData.Status),
-		SendAfter:       csData.SendAfter,
+		ChargeStationId:  chargeStationId,
+		TriggerMessage:   store.TriggerMessage(csData.Type),
+		TriggerStatus:    store.TriggerStatus(csData.Status),
+		SendAfter:        csData.SendAfter,
+		ErrorCode:        csData.ErrorCode,
+		ErrorDescription: csData.ErrorDescription,
 	}, nil
 }
 
@@ -355,10 +466,12 @@ func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize i
 			return nil, fmt.Errorf("map charge station trigger message: %w", err)
 		}
 		triggerMessages = append(triggerMessages, &store.ChargeStationTriggerMessage{
-			ChargeStationId: snap.Ref.ID,
-			TriggerMessage:  store.TriggerMessage(triggerMessage.Type),
-			TriggerStatus:   store.TriggerStatus(triggerMessage.Status),
-			SendAfter:       triggerMessage.SendAfter,
+			ChargeStationId:  snap.Ref.ID,
+			TriggerMessage:   store.TriggerMessage(triggerMessage.Type),
+			TriggerStatus:    store.TriggerStatus(triggerMessage.Status),
+			SendAfter:        triggerMessage.SendAfter,
+			ErrorCode:        triggerMessage.ErrorCode,
+			ErrorDescription: triggerMessage.ErrorDescription,
 		})
 	}
 	return triggerMessages, nil
@@ -239,6 +239,42 @@ func TestUpdateAndLookupChargeStationSettingsWithUpdatedSettings(t *testing.T) {
 	assert.Equal(t, store.ChargeStationSettingStatusAccepted, got.Settings["baz"].Status)
 }
 
+func TestUpdateAndLookupChargeStationSettingsWithError(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	settingsStore, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	defer settingsStore.CloseConn()
+	require.NoError(t, err)
+
+	err = settingsStore.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"foo": {Value: "bar", Status: store.ChargeStationSettingStatusPending},
+		},
+	})
+	require.NoError(t, err)
+
+	err = settingsStore.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"foo": {
+				Value:            "bar",
+				Status:           store.ChargeStationSettingStatusRejected,
+				ErrorCode:        "FormatViolation",
+				ErrorDescription: "invalid value",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err := settingsStore.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+
+	assert.Equal(t, store.ChargeStationSettingStatusRejected, got.Settings["foo"].Status)
+	assert.Equal(t, "FormatViolation", got.Settings["foo"].ErrorCode)
+	assert.Equal(t, "invalid value", got.Settings["foo"].ErrorDescription)
+}
+
 func TestListChargeStationSettings(t *testing.T) {
 	defer cleanupAllCollections(t, "myproject")
 
@@ -290,6 +326,49 @@ func TestListChargeStationSettings(t *testing.T) {
 	assert.Len(t, csIds, 25)
 }
 
+func TestUpdateAndLookupChargeStationVariables(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	variablesStore, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	defer variablesStore.CloseConn()
+	require.NoError(t, err)
+
+	sendAfter := time.Date(2024, 3, 18, 17, 10, 0, 0, time.UTC)
+	err = variablesStore.UpdateChargeStationVariables(ctx, "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"OCPPCommCtrlr/HeartbeatInterval": {Status: store.ChargeStationVariableStatusPending, SendAfter: sendAfter},
+			"OCPPCommCtrlr/Unknown":           {Status: store.ChargeStationVariableStatusPending, SendAfter: sendAfter},
+		},
+	})
+	require.NoError(t, err)
+
+	value := "60"
+	err = variablesStore.UpdateChargeStationVariables(ctx, "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"OCPPCommCtrlr/HeartbeatInterval": {Value: &value, Status: store.ChargeStationVariableStatusAccepted, SendAfter: sendAfter},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err := variablesStore.LookupChargeStationVariables(ctx, "cs001")
+	require.NoError(t, err)
+
+	want := &store.ChargeStationVariables{
+		ChargeStationId: "cs001",
+		Variables: map[string]*store.ChargeStationVariable{
+			"OCPPCommCtrlr/HeartbeatInterval": {Value: &value, Status: store.ChargeStationVariableStatusAccepted, SendAfter: sendAfter},
+			"OCPPCommCtrlr/Unknown":           {Status: store.ChargeStationVariableStatusPending, SendAfter: sendAfter},
+		},
+	}
+	assert.Equal(t, want, got)
+
+	list, err := variablesStore.ListChargeStationVariables(ctx, 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationVariables{want}, list)
+}
+
 func TestUpdateAndLookupChargeStationInstallCertificates(t *testing.T) {
 	defer cleanupAllCollections(t, "myproject")
 
@@ -423,6 +502,8 @@ func TestSetAndLookupChargeStationRuntimeDetails(t *testing.T) {
 
 	want := &store.ChargeStationRuntimeDetails{
 		OcppVersion: "1.6",
+		Vendor:      "VendorX",
+		Model:       "ModelY",
 	}
 
 	err = detailsStore.SetChargeStationRuntimeDetails(ctx, "cs001", want)
@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type deviceModelVariableAttribute struct {
+	Type       string  `firestore:"t"`
+	Value      *string `firestore:"v"`
+	Mutability string  `firestore:"m"`
+	Persistent bool    `firestore:"p"`
+	Constant   bool    `firestore:"c"`
+}
+
+type deviceModelVariableCharacteristics struct {
+	DataType           string   `firestore:"d"`
+	Unit               *string  `firestore:"u"`
+	MinLimit           *float64 `firestore:"n"`
+	MaxLimit           *float64 `firestore:"x"`
+	ValuesList         *string  `firestore:"l"`
+	SupportsMonitoring bool     `firestore:"s"`
+}
+
+type deviceModelVariable struct {
+	ComponentName     string                              `firestore:"cn"`
+	ComponentInstance *string                             `firestore:"ci"`
+	EvseId            *int                                `firestore:"e"`
+	ConnectorId       *int                                `firestore:"c"`
+	VariableName      string                              `firestore:"vn"`
+	VariableInstance  *string                             `firestore:"vi"`
+	Attributes        []*deviceModelVariableAttribute     `firestore:"a"`
+	Characteristics   *deviceModelVariableCharacteristics `firestore:"ch"`
+}
+
+// deviceModelComponentPageSize is the number of component documents read in each query when
+// looking up a charge station's device model
+const deviceModelComponentPageSize = 50
+
+// deviceModel holds when the charge station's device model was last updated. The variables
+// are held in a subcollection with a document for each component, so that the size of the
+// device model is not limited by the maximum size of a document.
+type deviceModel struct {
+	UpdatedAt time.Time `firestore:"u"`
+}
+
+type deviceModelComponent struct {
+	Variables map[string]*deviceModelVariable `firestore:"v"`
+}
+
+func deviceModelComponentsPath(chargeStationId string) string {
+	return fmt.Sprintf("ChargeStationDeviceModel/%s/Component", chargeStationId)
+}
+
+// deviceModelComponentPath returns the path of the component's document: the component key is
+// escaped as it may contain characters that are not allowed in a document id
+func deviceModelComponentPath(chargeStationId, componentKey string) string {
+	return fmt.Sprintf("%s/%s", deviceModelComponentsPath(chargeStationId), url.PathEscape(componentKey))
+}
+
+type deviceModelReportPart struct {
+	Tbc       bool                   `firestore:"t"`
+	Variables []*deviceModelVariable `firestore:"v"`
+}
+
+func newDeviceModelVariable(v *store.DeviceModelVariable) *deviceModelVariable {
+	var attrs []*deviceModelVariableAttribute
+	for _, a := range v.Attributes {
+		attrs = append(attrs, &deviceModelVariableAttribute{
+			Type:       a.Type,
+			Value:      a.Value,
+			Mutability: a.Mutability,
+			Persistent: a.Persistent,
+			Constant:   a.Constant,
+		})
+	}
+	var chars *deviceModelVariableCharacteristics
+	if v.Characteristics != nil {
+		chars = &deviceModelVariableCharacteristics{
+			DataType:           v.Characteristics.DataType,
+			Unit:               v.Characteristics.Unit,
+			MinLimit:           v.Characteristics.MinLimit,
+			MaxLimit:           v.Characteristics.MaxLimit,
+			ValuesList:         v.Characteristics.ValuesList,
+			SupportsMonitoring: v.Characteristics.SupportsMonitoring,
+		}
+	}
+	return &deviceModelVariable{
+		ComponentName:     v.ComponentName,
+		ComponentInstance: v.ComponentInstance,
+		EvseId:            v.EvseId,
+		ConnectorId:       v.ConnectorId,
+		VariableName:      v.VariableName,
+		VariableInstance:  v.VariableInstance,
+		Attributes:        attrs,
+		Characteristics:   chars,
+	}
+}
+
+func mapDeviceModelVariable(v *deviceModelVariable) *store.DeviceModelVariable {
+	var attrs []*store.DeviceModelVariableAttribute
+	for _, a := range v.Attributes {
+		attrs = append(attrs, &store.DeviceModelVariableAttribute{
+			Type:       a.Type,
+			Value:      a.Value,
+			Mutability: a.Mutability,
+			Persistent: a.Persistent,
+			Constant:   a.Constant,
+		})
+	}
+	var chars *store.DeviceModelVariableCharacteristics
+	if v.Characteristics != nil {
+		chars = &store.DeviceModelVariableCharacteristics{
+			DataType:           v.Characteristics.DataType,
+			Unit:               v.Characteristics.Unit,
+			MinLimit:           v.Characteristics.MinLimit,
+			MaxLimit:           v.Characteristics.MaxLimit,
+			ValuesList:         v.Characteristics.ValuesList,
+			SupportsMonitoring: v.Characteristics.SupportsMonitoring,
+		}
+	}
+	return &store.DeviceModelVariable{
+		ComponentName:     v.ComponentName,
+		ComponentInstance: v.ComponentInstance,
+		EvseId:            v.EvseId,
+		ConnectorId:       v.ConnectorId,
+		VariableName:      v.VariableName,
+		VariableInstance:  v.VariableInstance,
+		Attributes:        attrs,
+		Characteristics:   chars,
+	}
+}
+
+func (s *Store) SetDeviceModelReportPart(ctx context.Context, chargeStationId string, requestId int, part *store.DeviceModelReportPart) error {
+	reportRef := s.client.Doc(fmt.Sprintf("ChargeStationDeviceModelReport/%s:%d", chargeStationId, requestId))
+	var variables []*deviceModelVariable
+	for _, v := range part.Variables {
+		variables = append(variables, newDeviceModelVariable(v))
+	}
+	_, err := reportRef.Set(ctx, map[string]*deviceModelReportPart{
+		strconv.Itoa(part.SeqNo): {
+			Tbc:       part.Tbc,
+			Variables: variables,
+		},
+	}, firestore.MergeAll)
+	if err != nil {
+		return fmt.Errorf("setting device model report part %s:%d/%d: %w", chargeStationId, requestId, part.SeqNo, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupDeviceModelReportParts(ctx context.Context, chargeStationId string, requestId int) ([]*store.DeviceModelReportPart, error) {
+	reportRef := s.client.Doc(fmt.Sprintf("ChargeStationDeviceModelReport/%s:%d", chargeStationId, requestId))
+	snap, err := reportRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup device model report %s:%d: %w", chargeStationId, requestId, err)
+	}
+	var reportData map[string]*deviceModelReportPart
+	if err = snap.DataTo(&reportData); err != nil {
+		return nil, fmt.Errorf("map device model report %s:%d: %w", chargeStationId, requestId, err)
+	}
+	var parts []*store.DeviceModelReportPart
+	for k, p := range reportData {
+		seqNo, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, fmt.Errorf("map device model report %s:%d: invalid seq no %s", chargeStationId, requestId, k)
+		}
+		var variables []*store.DeviceModelVariable
+		for _, v := range p.Variables {
+			variables = append(variables, mapDeviceModelVariable(v))
+		}
+		parts = append(parts, &store.DeviceModelReportPart{
+			SeqNo:     seqNo,
+			Tbc:       p.Tbc,
+			Variables: variables,
+		})
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].SeqNo < parts[j].SeqNo
+	})
+	return parts, nil
+}
+
+func (s *Store) DeleteDeviceModelReportParts(ctx context.Context, chargeStationId string, requestId int) error {
+	reportRef := s.client.Doc(fmt.Sprintf("ChargeStationDeviceModelReport/%s:%d", chargeStationId, requestId))
+	_, err := reportRef.Delete(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) UpdateChargeStationDeviceModel(ctx context.Context, chargeStationId string, variables []*store.DeviceModelVariable, updatedAt time.Time) error {
+	components := make(map[string]map[string]*deviceModelVariable)
+	for _, v := range variables {
+		componentKey := v.ComponentKey()
+		if components[componentKey] == nil {
+			components[componentKey] = make(map[string]*deviceModelVariable)
+		}
+		components[componentKey][v.Key()] = newDeviceModelVariable(v)
+	}
+
+	bulkWriter := s.client.BulkWriter(ctx)
+	jobs := make(map[string]*firestore.BulkWriterJob)
+	for componentKey, vars := range components {
+		job, err := bulkWriter.Set(s.client.Doc(deviceModelComponentPath(chargeStationId, componentKey)), map[string]any{
+			"v": vars,
+		}, firestore.MergeAll)
+		if err != nil {
+			bulkWriter.End()
+			return fmt.Errorf("setting device model %s component %s: %w", chargeStationId, componentKey, err)
+		}
+		jobs[componentKey] = job
+	}
+	bulkWriter.End()
+	for componentKey, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return fmt.Errorf("setting device model %s component %s: %w", chargeStationId, componentKey, err)
+		}
+	}
+
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDeviceModel/%s", chargeStationId))
+	_, err := csRef.Set(ctx, map[string]any{
+		"u": updatedAt,
+		// the variables used to be held in this document
+		"v": firestore.Delete,
+	}, firestore.MergeAll)
+	if err != nil {
+		return fmt.Errorf("setting device model %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationDeviceModel(ctx context.Context, chargeStationId string) (*store.ChargeStationDeviceModel, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDeviceModel/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup device model %s: %w", chargeStationId, err)
+	}
+	var csData deviceModel
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map device model %s: %w", chargeStationId, err)
+	}
+
+	variables := make([]*store.DeviceModelVariable, 0)
+	var previous *firestore.DocumentSnapshot
+	for {
+		query := s.client.Collection(deviceModelComponentsPath(chargeStationId)).OrderBy(firestore.DocumentID, firestore.Asc)
+		if previous != nil {
+			query = query.StartAfter(previous)
+		}
+		snaps, err := query.Limit(deviceModelComponentPageSize).Documents(ctx).GetAll()
+		if err != nil {
+			return nil, fmt.Errorf("lookup device model %s components: %w", chargeStationId, err)
+		}
+		for _, componentSnap := range snaps {
+			var component deviceModelComponent
+			if err = componentSnap.DataTo(&component); err != nil {
+				return nil, fmt.Errorf("map device model %s component %s: %w", chargeStationId, componentSnap.Ref.ID, err)
+			}
+			for _, v := range component.Variables {
+				variables = append(variables, mapDeviceModelVariable(v))
+			}
+		}
+		if len(snaps) < deviceModelComponentPageSize {
+			break
+		}
+		previous = snaps[len(snaps)-1]
+	}
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Key() < variables[j].Key()
+	})
+
+	return &store.ChargeStationDeviceModel{
+		ChargeStationId: chargeStationId,
+		Variables:       variables,
+		UpdatedAt:       csData.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupDeviceModelReportParts(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	part1 := &store.DeviceModelReportPart{
+		SeqNo: 1,
+		Tbc:   false,
+		Variables: []*store.DeviceModelVariable{
+			{ComponentName: "OCPPCommCtrlr", VariableName: "HeartbeatInterval"},
+		},
+	}
+	part0 := &store.DeviceModelReportPart{
+		SeqNo: 0,
+		Tbc:   true,
+		Variables: []*store.DeviceModelVariable{
+			{ComponentName: "SecurityCtrlr", VariableName: "SecurityProfile"},
+		},
+	}
+
+	err = engine.SetDeviceModelReportPart(ctx, "cs001", 42, part1)
+	require.NoError(t, err)
+	err = engine.SetDeviceModelReportPart(ctx, "cs001", 42, part0)
+	require.NoError(t, err)
+
+	got, err := engine.LookupDeviceModelReportParts(ctx, "cs001", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.DeviceModelReportPart{part0, part1}, got)
+
+	err = engine.DeleteDeviceModelReportParts(ctx, "cs001", 42)
+	require.NoError(t, err)
+
+	got, err = engine.LookupDeviceModelReportParts(ctx, "cs001", 42)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestUpdateAndLookupChargeStationDeviceModel(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	evseId := 1
+	heartbeat := &store.DeviceModelVariable{
+		ComponentName: "OCPPCommCtrlr",
+		VariableName:  "HeartbeatInterval",
+		Attributes: []*store.DeviceModelVariableAttribute{
+			{Type: "Actual", Value: makePtr("60"), Mutability: "ReadWrite"},
+		},
+		Characteristics: &store.DeviceModelVariableCharacteristics{
+			DataType: "integer",
+		},
+	}
+	evse := &store.DeviceModelVariable{
+		ComponentName: "EVSE",
+		EvseId:        &evseId,
+		VariableName:  "Power",
+	}
+
+	err = engine.UpdateChargeStationDeviceModel(ctx, "cs001", []*store.DeviceModelVariable{heartbeat}, now)
+	require.NoError(t, err)
+	err = engine.UpdateChargeStationDeviceModel(ctx, "cs001", []*store.DeviceModelVariable{evse}, now)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationDeviceModel(ctx, "cs001")
+	require.NoError(t, err)
+
+	want := &store.ChargeStationDeviceModel{
+		ChargeStationId: "cs001",
+		Variables:       []*store.DeviceModelVariable{evse, heartbeat},
+		UpdatedAt:       now,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestUpdateAndLookupChargeStationDeviceModelWithManyComponents(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	var want []*store.DeviceModelVariable
+	for i := 0; i < 120; i++ {
+		want = append(want, &store.DeviceModelVariable{
+			ComponentName:     "Controller",
+			ComponentInstance: makePtr(fmt.Sprintf("%03d/a", i)),
+			VariableName:      "Enabled",
+		})
+	}
+	err = engine.UpdateChargeStationDeviceModel(ctx, "cs001", want, now)
+	require.NoError(t, err)
+
+	// a variable is added to an existing component
+	available := &store.DeviceModelVariable{
+		ComponentName:     "Controller",
+		ComponentInstance: makePtr("000/a"),
+		VariableName:      "Available",
+	}
+	err = engine.UpdateChargeStationDeviceModel(ctx, "cs001", []*store.DeviceModelVariable{available}, now)
+	require.NoError(t, err)
+	want = append([]*store.DeviceModelVariable{available}, want...)
+
+	got, err := engine.LookupChargeStationDeviceModel(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, want, got.Variables)
+	assert.Equal(t, now, got.UpdatedAt)
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type displayMessage struct {
+	Priority          string     `firestore:"p"`
+	State             *string    `firestore:"st"`
+	StartDateTime     *time.Time `firestore:"sd"`
+	EndDateTime       *time.Time `firestore:"ed"`
+	TransactionId     *string    `firestore:"tx"`
+	Format            string     `firestore:"f"`
+	Language          *string    `firestore:"l"`
+	Content           string     `firestore:"c"`
+	Status            string     `firestore:"s"`
+	ErrorCode         string     `firestore:"ec"`
+	ErrorDescription  string     `firestore:"er"`
+	SendAfter         time.Time  `firestore:"sa"`
+	ReportedRequestId int        `firestore:"rr"`
+}
+
+type chargeStationDisplayMessages struct {
+	// firestore map keys must be strings so the messages are keyed by the string form of the id
+	Messages           map[string]*displayMessage `firestore:"m"`
+	ReconcileStatus    string                     `firestore:"rs"`
+	ReconcileRequestId int                        `firestore:"ri"`
+	ReconcileAfter     time.Time                  `firestore:"ra"`
+	UpdatedAt          time.Time                  `firestore:"ua"`
+}
+
+func (s *Store) SetChargeStationDisplayMessages(ctx context.Context, chargeStationId string, messages *store.ChargeStationDisplayMessages) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDisplayMessages/%s", chargeStationId))
+	msgs := make(map[string]*displayMessage, len(messages.Messages))
+	for id, message := range messages.Messages {
+		msgs[strconv.Itoa(id)] = &displayMessage{
+			Priority:          message.Priority,
+			State:             message.State,
+			StartDateTime:     message.StartDateTime,
+			EndDateTime:       message.EndDateTime,
+			TransactionId:     message.TransactionId,
+			Format:            message.Format,
+			Language:          message.Language,
+			Content:           message.Content,
+			Status:            string(message.Status),
+			ErrorCode:         message.ErrorCode,
+			ErrorDescription:  message.ErrorDescription,
+			SendAfter:         message.SendAfter,
+			ReportedRequestId: message.ReportedRequestId,
+		}
+	}
+	// the whole document is replaced so that messages that have been removed are deleted
+	_, err := csRef.Set(ctx, &chargeStationDisplayMessages{
+		Messages:           msgs,
+		ReconcileStatus:    string(messages.ReconcileStatus),
+		ReconcileRequestId: messages.ReconcileRequestId,
+		ReconcileAfter:     messages.ReconcileAfter,
+		UpdatedAt:          messages.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station display messages %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationDisplayMessages(ctx context.Context, chargeStationId string) (*store.ChargeStationDisplayMessages, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDisplayMessages/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station display messages %s: %w", chargeStationId, err)
+	}
+	var messages chargeStationDisplayMessages
+	if err = snap.DataTo(&messages); err != nil {
+		return nil, fmt.Errorf("map charge station display messages %s: %w", chargeStationId, err)
+	}
+	return mapChargeStationDisplayMessages(chargeStationId, &messages)
+}
+
+func (s *Store) ListChargeStationDisplayMessages(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationDisplayMessages, error) {
+	var result []*store.ChargeStationDisplayMessages
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationDisplayMessages").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationDisplayMessages").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station display messages: %w", err)
+	}
+	for _, snap := range snaps {
+		var messages chargeStationDisplayMessages
+		if err = snap.DataTo(&messages); err != nil {
+			return nil, fmt.Errorf("map charge station display messages: %w", err)
+		}
+		mapped, err := mapChargeStationDisplayMessages(snap.Ref.ID, &messages)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, mapped)
+	}
+	return result, nil
+}
+
+func mapChargeStationDisplayMessages(chargeStationId string, messages *chargeStationDisplayMessages) (*store.ChargeStationDisplayMessages, error) {
+	msgs := make(map[int]*store.DisplayMessage, len(messages.Messages))
+	for key, message := range messages.Messages {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("map charge station display message %s id %s: %w", chargeStationId, key, err)
+		}
+		msgs[id] = &store.DisplayMessage{
+			Id:                id,
+			Priority:          message.Priority,
+			State:             message.State,
+			StartDateTime:     message.StartDateTime,
+			EndDateTime:       message.EndDateTime,
+			TransactionId:     message.TransactionId,
+			Format:            message.Format,
+			Language:          message.Language,
+			Content:           message.Content,
+			Status:            store.DisplayMessageStatus(message.Status),
+			ErrorCode:         message.ErrorCode,
+			ErrorDescription:  message.ErrorDescription,
+			SendAfter:         message.SendAfter,
+			ReportedRequestId: message.ReportedRequestId,
+		}
+	}
+	return &store.ChargeStationDisplayMessages{
+		ChargeStationId:    chargeStationId,
+		Messages:           msgs,
+		ReconcileStatus:    store.DisplayMessageReconcileStatus(messages.ReconcileStatus),
+		ReconcileRequestId: messages.ReconcileRequestId,
+		ReconcileAfter:     messages.ReconcileAfter,
+		UpdatedAt:          messages.UpdatedAt,
+	}, nil
+}
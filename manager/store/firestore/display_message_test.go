@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupChargeStationDisplayMessages(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	endDateTime := now.Add(time.Hour)
+	state := "Idle"
+	messages := &store.ChargeStationDisplayMessages{
+		ChargeStationId: "cs001",
+		Messages: map[int]*store.DisplayMessage{
+			1: {
+				Id:          1,
+				Priority:    "NormalCycle",
+				State:       &state,
+				EndDateTime: &endDateTime,
+				Format:      "UTF8",
+				Content:     "Welcome",
+				Status:      store.DisplayMessageStatusPending,
+				SendAfter:   now,
+			},
+			2: {
+				Id:       2,
+				Priority: "InFront",
+				Format:   "ASCII",
+				Content:  "Out of order",
+				Status:   store.DisplayMessageStatusAccepted,
+			},
+		},
+		UpdatedAt: now,
+	}
+	err = engine.SetChargeStationDisplayMessages(ctx, "cs001", messages)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, messages, got)
+
+	// messages that are no longer managed are removed
+	delete(messages.Messages, 2)
+	messages.ReconcileStatus = store.DisplayMessageReconcileStatusPending
+	messages.ReconcileRequestId = 7
+	messages.ReconcileAfter = now
+	err = engine.SetChargeStationDisplayMessages(ctx, "cs001", messages)
+	require.NoError(t, err)
+
+	got, err = engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, messages, got)
+}
+
+func TestListChargeStationDisplayMessages(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	for _, csId := range []string{"cs001", "cs002"} {
+		err = engine.SetChargeStationDisplayMessages(ctx, csId, &store.ChargeStationDisplayMessages{
+			ReconcileStatus: store.DisplayMessageReconcileStatusRequired,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationDisplayMessages(ctx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs001", got[0].ChargeStationId)
+
+	got, err = engine.ListChargeStationDisplayMessages(ctx, 1, "cs001")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs002", got[0].ChargeStationId)
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type issuedCertificate struct {
+	// CertificateType is only set for superseded certificates
+	CertificateType    string     `firestore:"ty,omitempty"`
+	CertificateId      string     `firestore:"id"`
+	SerialNumber       string     `firestore:"sn"`
+	NotAfter           time.Time  `firestore:"na"`
+	IssuedAt           time.Time  `firestore:"ia"`
+	RenewalRequestedAt *time.Time `firestore:"rr"`
+}
+
+type chargeStationIssuedCertificates struct {
+	// keyed by certificate type
+	Certificates map[string]*issuedCertificate `firestore:"c"`
+	Superseded   []*issuedCertificate          `firestore:"s"`
+	UpdatedAt    time.Time                     `firestore:"ua"`
+}
+
+func (s *Store) SetChargeStationIssuedCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationIssuedCertificates) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationIssuedCertificates/%s", chargeStationId))
+	certs := make(map[string]*issuedCertificate, len(certificates.Certificates))
+	for certificateType, certificate := range certificates.Certificates {
+		certs[string(certificateType)] = &issuedCertificate{
+			CertificateId:      certificate.CertificateId,
+			SerialNumber:       certificate.SerialNumber,
+			NotAfter:           certificate.NotAfter,
+			IssuedAt:           certificate.IssuedAt,
+			RenewalRequestedAt: certificate.RenewalRequestedAt,
+		}
+	}
+	superseded := make([]*issuedCertificate, len(certificates.Superseded))
+	for i, certificate := range certificates.Superseded {
+		superseded[i] = &issuedCertificate{
+			CertificateType:    string(certificate.CertificateType),
+			CertificateId:      certificate.CertificateId,
+			SerialNumber:       certificate.SerialNumber,
+			NotAfter:           certificate.NotAfter,
+			IssuedAt:           certificate.IssuedAt,
+			RenewalRequestedAt: certificate.RenewalRequestedAt,
+		}
+	}
+	_, err := csRef.Set(ctx, &chargeStationIssuedCertificates{
+		Certificates: certs,
+		Superseded:   superseded,
+		UpdatedAt:    certificates.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station issued certificates %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationIssuedCertificates(ctx context.Context, chargeStationId string) (*store.ChargeStationIssuedCertificates, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationIssuedCertificates/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station issued certificates %s: %w", chargeStationId, err)
+	}
+	var certificates chargeStationIssuedCertificates
+	if err = snap.DataTo(&certificates); err != nil {
+		return nil, fmt.Errorf("map charge station issued certificates %s: %w", chargeStationId, err)
+	}
+	return mapChargeStationIssuedCertificates(chargeStationId, &certificates), nil
+}
+
+func (s *Store) ListChargeStationIssuedCertificates(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationIssuedCertificates, error) {
+	var result []*store.ChargeStationIssuedCertificates
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationIssuedCertificates").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationIssuedCertificates").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station issued certificates: %w", err)
+	}
+	for _, snap := range snaps {
+		var certificates chargeStationIssuedCertificates
+		if err = snap.DataTo(&certificates); err != nil {
+			return nil, fmt.Errorf("map charge station issued certificates: %w", err)
+		}
+		result = append(result, mapChargeStationIssuedCertificates(snap.Ref.ID, &certificates))
+	}
+	return result, nil
+}
+
+func mapChargeStationIssuedCertificates(chargeStationId string, certificates *chargeStationIssuedCertificates) *store.ChargeStationIssuedCertificates {
+	certs := make(map[store.CertificateType]*store.IssuedCertificate, len(certificates.Certificates))
+	for key, certificate := range certificates.Certificates {
+		certificateType := store.CertificateType(key)
+		certs[certificateType] = &store.IssuedCertificate{
+			CertificateType:    certificateType,
+			CertificateId:      certificate.CertificateId,
+			SerialNumber:       certificate.SerialNumber,
+			NotAfter:           certificate.NotAfter,
+			IssuedAt:           certificate.IssuedAt,
+			RenewalRequestedAt: certificate.RenewalRequestedAt,
+		}
+	}
+	var superseded []*store.IssuedCertificate
+	for _, certificate := range certificates.Superseded {
+		superseded = append(superseded, &store.IssuedCertificate{
+			CertificateType:    store.CertificateType(certificate.CertificateType),
+			CertificateId:      certificate.CertificateId,
+			SerialNumber:       certificate.SerialNumber,
+			NotAfter:           certificate.NotAfter,
+			IssuedAt:           certificate.IssuedAt,
+			RenewalRequestedAt: certificate.RenewalRequestedAt,
+		})
+	}
+	return &store.ChargeStationIssuedCertificates{
+		ChargeStationId: chargeStationId,
+		Certificates:    certs,
+		Superseded:      superseded,
+		UpdatedAt:       certificates.UpdatedAt,
+	}
+}
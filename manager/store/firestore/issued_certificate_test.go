@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupChargeStationIssuedCertificates(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	certificates := &store.ChargeStationIssuedCertificates{
+		ChargeStationId: "cs001",
+		Certificates: map[store.CertificateType]*store.IssuedCertificate{
+			store.CertificateTypeChargeStation: {
+				CertificateType:    store.CertificateTypeChargeStation,
+				CertificateId:      "abc123",
+				SerialNumber:       "1234",
+				NotAfter:           now.Add(365 * 24 * time.Hour),
+				IssuedAt:           now,
+				RenewalRequestedAt: &now,
+			},
+			store.CertificateTypeEVCC: {
+				CertificateType: store.CertificateTypeEVCC,
+				CertificateId:   "def456",
+				SerialNumber:    "5678",
+				NotAfter:        now.Add(30 * 24 * time.Hour),
+				IssuedAt:        now,
+			},
+		},
+		Superseded: []*store.IssuedCertificate{
+			{
+				CertificateType: store.CertificateTypeChargeStation,
+				CertificateId:   "fed987",
+				SerialNumber:    "1233",
+				NotAfter:        now.Add(24 * time.Hour),
+				IssuedAt:        now.Add(-364 * 24 * time.Hour),
+			},
+		},
+		UpdatedAt: now,
+	}
+	err = engine.SetChargeStationIssuedCertificates(ctx, "cs001", certificates)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationIssuedCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, certificates, got)
+
+	got, err = engine.LookupChargeStationIssuedCertificates(ctx, "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeStationIssuedCertificates(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	for _, csId := range []string{"cs001", "cs002"} {
+		err = engine.SetChargeStationIssuedCertificates(ctx, csId, &store.ChargeStationIssuedCertificates{
+			UpdatedAt: time.Now().UTC().Truncate(time.Millisecond),
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationIssuedCertificates(ctx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs001", got[0].ChargeStationId)
+
+	got, err = engine.ListChargeStationIssuedCertificates(ctx, 1, "cs001")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs002", got[0].ChargeStationId)
+}
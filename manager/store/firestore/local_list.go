@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type localListEntry struct {
+	IdTokenType string  `firestore:"t,omitempty"`
+	Status      string  `firestore:"s"`
+	GroupId     *string `firestore:"g"`
+}
+
+type chargeStationLocalList struct {
+	GroupId          *string                    `firestore:"g"`
+	Version          int                        `firestore:"v"`
+	Entries          map[string]*localListEntry `firestore:"e"`
+	Status           string                     `firestore:"s"`
+	ErrorCode        string                     `firestore:"ec"`
+	ErrorDescription string                     `firestore:"ed"`
+	SendAfter        time.Time                  `firestore:"sa"`
+	UpdatedAt        time.Time                  `firestore:"ua"`
+}
+
+func (s *Store) SetChargeStationLocalList(ctx context.Context, chargeStationId string, localList *store.ChargeStationLocalList) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationLocalLists/%s", chargeStationId))
+	entries := make(map[string]*localListEntry, len(localList.Entries))
+	for idToken, entry := range localList.Entries {
+		entries[idToken] = &localListEntry{
+			IdTokenType: entry.IdTokenType,
+			Status:      entry.Status,
+			GroupId:     entry.GroupId,
+		}
+	}
+	// the whole document is replaced so that entries removed from the list are deleted
+	_, err := csRef.Set(ctx, &chargeStationLocalList{
+		GroupId:          localList.GroupId,
+		Version:          localList.Version,
+		Entries:          entries,
+		Status:           string(localList.Status),
+		ErrorCode:        localList.ErrorCode,
+		ErrorDescription: localList.ErrorDescription,
+		SendAfter:        localList.SendAfter,
+		UpdatedAt:        localList.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station local list %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationLocalList(ctx context.Context, chargeStationId string) (*store.ChargeStationLocalList, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationLocalLists/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station local list %s: %w", chargeStationId, err)
+	}
+	var localList chargeStationLocalList
+	if err = snap.DataTo(&localList); err != nil {
+		return nil, fmt.Errorf("map charge station local list %s: %w", chargeStationId, err)
+	}
+	return mapChargeStationLocalList(chargeStationId, &localList), nil
+}
+
+func (s *Store) ListChargeStationLocalLists(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationLocalList, error) {
+	var localLists []*store.ChargeStationLocalList
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationLocalLists").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationLocalLists").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station local lists: %w", err)
+	}
+	for _, snap := range snaps {
+		var localList chargeStationLocalList
+		if err = snap.DataTo(&localList); err != nil {
+			return nil, fmt.Errorf("map charge station local list: %w", err)
+		}
+		localLists = append(localLists, mapChargeStationLocalList(snap.Ref.ID, &localList))
+	}
+	return localLists, nil
+}
+
+func mapChargeStationLocalList(chargeStationId string, localList *chargeStationLocalList) *store.ChargeStationLocalList {
+	entries := make(map[string]*store.LocalListEntry, len(localList.Entries))
+	for idToken, entry := range localList.Entries {
+		entries[idToken] = &store.LocalListEntry{
+			IdToken:     idToken,
+			IdTokenType: entry.IdTokenType,
+			Status:      entry.Status,
+			GroupId:     entry.GroupId,
+		}
+	}
+	return &store.ChargeStationLocalList{
+		ChargeStationId:  chargeStationId,
+		GroupId:          localList.GroupId,
+		Version:          localList.Version,
+		Entries:          entries,
+		Status:           store.LocalListStatus(localList.Status),
+		ErrorCode:        localList.ErrorCode,
+		ErrorDescription: localList.ErrorDescription,
+		SendAfter:        localList.SendAfter,
+		UpdatedAt:        localList.UpdatedAt,
+	}
+}
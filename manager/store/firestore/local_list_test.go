@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupChargeStationLocalList(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	groupId := "group001"
+	localList := &store.ChargeStationLocalList{
+		ChargeStationId: "cs001",
+		GroupId:         &groupId,
+		Version:         2,
+		Entries: map[string]*store.LocalListEntry{
+			"ABCD1234": {IdToken: "ABCD1234", IdTokenType: "ISO14443", Status: "Accepted", GroupId: &groupId},
+			"EFGH5678": {IdToken: "EFGH5678", Status: "Invalid"},
+		},
+		Status:    store.LocalListStatusPending,
+		SendAfter: now,
+		UpdatedAt: now,
+	}
+	err = engine.SetChargeStationLocalList(ctx, "cs001", localList)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, localList, got)
+
+	// entries that are no longer in the list are removed
+	delete(localList.Entries, "EFGH5678")
+	localList.Version = 3
+	localList.Status = store.LocalListStatusAccepted
+	err = engine.SetChargeStationLocalList(ctx, "cs001", localList)
+	require.NoError(t, err)
+
+	got, err = engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, localList, got)
+}
+
+func TestLookupChargeStationLocalListWithUnknownChargeStation(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeStationLocalLists(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	for _, csId := range []string{"cs001", "cs002", "cs003"} {
+		err = engine.SetChargeStationLocalList(ctx, csId, &store.ChargeStationLocalList{
+			Entries: map[string]*store.LocalListEntry{},
+			Status:  store.LocalListStatusAccepted,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationLocalLists(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs001", got[0].ChargeStationId)
+	assert.Equal(t, "cs002", got[1].ChargeStationId)
+
+	got, err = engine.ListChargeStationLocalLists(ctx, 2, "cs002")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs003", got[0].ChargeStationId)
+}
@@ -77,11 +77,23 @@ func cleanupAllCollections(t *testing.T, gcloudProject string) {
 	cleanupCollection(t, gcloudProject, "Certificate")
 	cleanupCollection(t, gcloudProject, "ChargeStation")
 	cleanupCollection(t, gcloudProject, "ChargeStationSettings")
+	cleanupCollection(t, gcloudProject, "ChargeStationVariables")
 	cleanupCollection(t, gcloudProject, "ChargeStationInstallCertificates")
 	cleanupCollection(t, gcloudProject, "ChargeStationRuntimeDetails")
+	cleanupCollection(t, gcloudProject, "ChargeStationDeviceModel")
+	cleanupCollection(t, gcloudProject, "Component")
+	cleanupCollection(t, gcloudProject, "ChargeStationDeviceModelReport")
+	cleanupCollection(t, gcloudProject, "ChargeStationOperations")
+	cleanupCollection(t, gcloudProject, "ChargeStationLocalLists")
+	cleanupCollection(t, gcloudProject, "ChargeStationDisplayMessages")
+	cleanupCollection(t, gcloudProject, "ChargeStationIssuedCertificates")
+	cleanupCollection(t, gcloudProject, "ChargeStationCertificateInventory")
+	cleanupCollection(t, gcloudProject, "Reservation")
+	cleanupCollection(t, gcloudProject, "ConfigurationTemplate")
 	cleanupCollection(t, gcloudProject, "Location")
 	cleanupCollection(t, gcloudProject, "OcpiParty")
 	cleanupCollection(t, gcloudProject, "OcpiRegistration")
+	cleanupCollection(t, gcloudProject, "OcspResponse")
 	cleanupCollection(t, gcloudProject, "Token")
 	cleanupCollection(t, gcloudProject, "Transaction")
 }
@@ -93,7 +105,8 @@ func cleanupCollection(t *testing.T, gcloudProject, collection string) {
 	defer client.Close()
 	assert.NoError(t, err)
 
-	col := client.Collection(collection)
+	// a collection group includes the subcollections with the id
+	col := client.CollectionGroup(collection)
 	bulkwriter := client.BulkWriter(ctx)
 
 	numDeleted := 0
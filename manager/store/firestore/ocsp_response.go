@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ocspResponse struct {
+	Response  string    `firestore:"r"`
+	FetchedAt time.Time `firestore:"fa"`
+	ExpiresAt time.Time `firestore:"ea"`
+}
+
+func (s *Store) SetOcspResponse(ctx context.Context, response *store.OcspResponse) error {
+	respRef := s.client.Doc(fmt.Sprintf("OcspResponse/%s", response.Key))
+	_, err := respRef.Set(ctx, &ocspResponse{
+		Response:  response.Response,
+		FetchedAt: response.FetchedAt,
+		ExpiresAt: response.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting ocsp response %s: %w", response.Key, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupOcspResponse(ctx context.Context, key string) (*store.OcspResponse, error) {
+	respRef := s.client.Doc(fmt.Sprintf("OcspResponse/%s", key))
+	snap, err := respRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup ocsp response %s: %w", key, err)
+	}
+	var response ocspResponse
+	if err = snap.DataTo(&response); err != nil {
+		return nil, fmt.Errorf("map ocsp response %s: %w", key, err)
+	}
+	return &store.OcspResponse{
+		Key:       key,
+		Response:  response.Response,
+		FetchedAt: response.FetchedAt,
+		ExpiresAt: response.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupOcspResponse(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	response := &store.OcspResponse{
+		Key:       "abc123",
+		Response:  "MIIB",
+		FetchedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	err = engine.SetOcspResponse(ctx, response)
+	require.NoError(t, err)
+
+	got, err := engine.LookupOcspResponse(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, response, got)
+}
+
+func TestLookupOcspResponseWithUnregisteredKey(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	got, err := engine.LookupOcspResponse(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
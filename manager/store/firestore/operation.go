@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/maps"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type chargeStationOperation struct {
+	Request          string    `firestore:"q"`
+	Response         string    `firestore:"r"`
+	Status           string    `firestore:"s"`
+	ErrorCode        string    `firestore:"ec"`
+	ErrorDescription string    `firestore:"ed"`
+	RequestedAt      time.Time `firestore:"ra"`
+	UpdatedAt        time.Time `firestore:"ua"`
+}
+
+func (s *Store) SetChargeStationOperation(ctx context.Context, chargeStationId string, operation *store.ChargeStationOperation) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationOperations/%s", chargeStationId))
+	set := map[string]*chargeStationOperation{
+		operation.Operation: {
+			Request:          operation.Request,
+			Response:         operation.Response,
+			Status:           string(operation.Status),
+			ErrorCode:        operation.ErrorCode,
+			ErrorDescription: operation.ErrorDescription,
+			RequestedAt:      operation.RequestedAt,
+			UpdatedAt:        operation.UpdatedAt,
+		},
+	}
+	_, err := csRef.Set(ctx, set, firestore.MergeAll)
+	if err != nil {
+		return fmt.Errorf("setting charge station operation %s/%s: %w", chargeStationId, operation.Operation, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationOperation(ctx context.Context, chargeStationId, operation string) (*store.ChargeStationOperation, error) {
+	operations, err := s.lookupChargeStationOperations(ctx, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+	op, ok := operations[operation]
+	if !ok {
+		return nil, nil
+	}
+	return mapChargeStationOperation(chargeStationId, operation, op), nil
+}
+
+func (s *Store) ListChargeStationOperations(ctx context.Context, chargeStationId string) ([]*store.ChargeStationOperation, error) {
+	operations, err := s.lookupChargeStationOperations(ctx, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+	names := maps.Keys(operations)
+	sort.Strings(names)
+	result := make([]*store.ChargeStationOperation, 0, len(names))
+	for _, name := range names {
+		result = append(result, mapChargeStationOperation(chargeStationId, name, operations[name]))
+	}
+	return result, nil
+}
+
+func (s *Store) lookupChargeStationOperations(ctx context.Context, chargeStationId string) (map[string]*chargeStationOperation, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationOperations/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station operations %s: %w", chargeStationId, err)
+	}
+	var operations map[string]*chargeStationOperation
+	if err = snap.DataTo(&operations); err != nil {
+		return nil, fmt.Errorf("map charge station operations %s: %w", chargeStationId, err)
+	}
+	return operations, nil
+}
+
+func mapChargeStationOperation(chargeStationId, operation string, op *chargeStationOperation) *store.ChargeStationOperation {
+	return &store.ChargeStationOperation{
+		ChargeStationId:  chargeStationId,
+		Operation:        operation,
+		Request:          op.Request,
+		Response:         op.Response,
+		Status:           store.ChargeStationOperationStatus(op.Status),
+		ErrorCode:        op.ErrorCode,
+		ErrorDescription: op.ErrorDescription,
+		RequestedAt:      op.RequestedAt,
+		UpdatedAt:        op.UpdatedAt,
+	}
+}
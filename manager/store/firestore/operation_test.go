@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupChargeStationOperation(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	reset := &store.ChargeStationOperation{
+		ChargeStationId: "cs001",
+		Operation:       "Reset",
+		Request:         `{"type":"Immediate"}`,
+		Status:          store.ChargeStationOperationStatusPending,
+		RequestedAt:     now,
+		UpdatedAt:       now,
+	}
+	err = engine.SetChargeStationOperation(ctx, "cs001", reset)
+	require.NoError(t, err)
+
+	clearCache := &store.ChargeStationOperation{
+		ChargeStationId:  "cs001",
+		Operation:        "ClearCache",
+		Request:          `{}`,
+		Status:           store.ChargeStationOperationStatusErrored,
+		ErrorCode:        "NotSupported",
+		ErrorDescription: "not supported",
+		RequestedAt:      now,
+		UpdatedAt:        now,
+	}
+	err = engine.SetChargeStationOperation(ctx, "cs001", clearCache)
+	require.NoError(t, err)
+
+	reset.Status = "Accepted"
+	reset.Response = `{"status":"Accepted"}`
+	err = engine.SetChargeStationOperation(ctx, "cs001", reset)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationOperation(ctx, "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Equal(t, reset, got)
+
+	all, err := engine.ListChargeStationOperations(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationOperation{clearCache, reset}, all)
+}
+
+func TestLookupChargeStationOperationThatDoesNotExist(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	got, err := engine.LookupChargeStationOperation(ctx, "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	all, err := engine.ListChargeStationOperations(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slices"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type reservation struct {
+	ChargeStationId  string    `firestore:"cs"`
+	ReservationId    int       `firestore:"id"`
+	Reference        *string   `firestore:"ref"`
+	EvseId           *int      `firestore:"evse"`
+	IdToken          string    `firestore:"tok"`
+	TokenType        string    `firestore:"tt"`
+	GroupId          *string   `firestore:"g"`
+	ExpiryDate       time.Time `firestore:"x"`
+	Status           string    `firestore:"s"`
+	ErrorCode        string    `firestore:"ec"`
+	ErrorDescription string    `firestore:"ed"`
+	TransactionId    *string   `firestore:"tx"`
+	CreatedAt        time.Time `firestore:"ca"`
+	UpdatedAt        time.Time `firestore:"ua"`
+	// ActiveUntil is only set while the reservation is active so that expired reservations can
+	// be found without a composite index
+	ActiveUntil *time.Time `firestore:"au,omitempty"`
+}
+
+func reservationPath(chargeStationId string, reservationId int) string {
+	return fmt.Sprintf("Reservation/%s:%d", chargeStationId, reservationId)
+}
+
+func (s *Store) SetReservation(ctx context.Context, r *store.Reservation) error {
+	ref := s.client.Doc(reservationPath(r.ChargeStationId, r.ReservationId))
+	doc := &reservation{
+		ChargeStationId:  r.ChargeStationId,
+		ReservationId:    r.ReservationId,
+		Reference:        r.Reference,
+		EvseId:           r.EvseId,
+		IdToken:          r.IdToken,
+		TokenType:        r.TokenType,
+		GroupId:          r.GroupId,
+		ExpiryDate:       r.ExpiryDate,
+		Status:           string(r.Status),
+		ErrorCode:        r.ErrorCode,
+		ErrorDescription: r.ErrorDescription,
+		TransactionId:    r.TransactionId,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+	if r.Status.IsActive() {
+		doc.ActiveUntil = &r.ExpiryDate
+	}
+	_, err := ref.Set(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("setting reservation %s/%d: %w", r.ChargeStationId, r.ReservationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupReservation(ctx context.Context, chargeStationId string, reservationId int) (*store.Reservation, error) {
+	ref := s.client.Doc(reservationPath(chargeStationId, reservationId))
+	snap, err := ref.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup reservation %s/%d: %w", chargeStationId, reservationId, err)
+	}
+	return mapReservation(snap)
+}
+
+func (s *Store) LookupReservationByReference(ctx context.Context, reference string) (*store.Reservation, error) {
+	reservations, err := s.queryReservations(ctx, s.client.Collection("Reservation").Where("ref", "==", reference).Limit(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(reservations) == 0 {
+		return nil, nil
+	}
+	return reservations[0], nil
+}
+
+func (s *Store) ListReservations(ctx context.Context, chargeStationId string) ([]*store.Reservation, error) {
+	reservations, err := s.queryReservations(ctx, s.client.Collection("Reservation").Where("cs", "==", chargeStationId))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(reservations, func(a, b *store.Reservation) int {
+		return a.ReservationId - b.ReservationId
+	})
+	return reservations, nil
+}
+
+func (s *Store) ListExpiredReservations(ctx context.Context, before time.Time, pageSize int) ([]*store.Reservation, error) {
+	return s.queryReservations(ctx, s.client.Collection("Reservation").Where("au", "<", before).
+		OrderBy("au", firestore.Asc).Limit(pageSize))
+}
+
+func (s *Store) queryReservations(ctx context.Context, query firestore.Query) ([]*store.Reservation, error) {
+	var reservations []*store.Reservation
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("query reservations: %w", err)
+		}
+		r, err := mapReservation(snap)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, r)
+	}
+	return reservations, nil
+}
+
+func mapReservation(snap *firestore.DocumentSnapshot) (*store.Reservation, error) {
+	var r reservation
+	if err := snap.DataTo(&r); err != nil {
+		return nil, fmt.Errorf("map reservation %s: %w", snap.Ref.ID, err)
+	}
+	return &store.Reservation{
+		ChargeStationId:  r.ChargeStationId,
+		ReservationId:    r.ReservationId,
+		Reference:        r.Reference,
+		EvseId:           r.EvseId,
+		IdToken:          r.IdToken,
+		TokenType:        r.TokenType,
+		GroupId:          r.GroupId,
+		ExpiryDate:       r.ExpiryDate,
+		Status:           store.ReservationStatus(r.Status),
+		ErrorCode:        r.ErrorCode,
+		ErrorDescription: r.ErrorDescription,
+		TransactionId:    r.TransactionId,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}, nil
+}
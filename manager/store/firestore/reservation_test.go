@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupReservation(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	reference := "RES001"
+	evseId := 1
+	reservation := &store.Reservation{
+		ChargeStationId: "cs001",
+		ReservationId:   2,
+		Reference:       &reference,
+		EvseId:          &evseId,
+		IdToken:         "ABCD1234",
+		TokenType:       "ISO14443",
+		ExpiryDate:      now.Add(time.Hour),
+		Status:          store.ReservationStatusAccepted,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	err = engine.SetReservation(ctx, reservation)
+	require.NoError(t, err)
+
+	got, err := engine.LookupReservation(ctx, "cs001", 2)
+	require.NoError(t, err)
+	assert.Equal(t, reservation, got)
+
+	got, err = engine.LookupReservationByReference(ctx, "RES001")
+	require.NoError(t, err)
+	assert.Equal(t, reservation, got)
+
+	got, err = engine.LookupReservation(ctx, "cs001", 3)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListReservations(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	reservations := []*store.Reservation{
+		{ChargeStationId: "cs001", ReservationId: 2, IdToken: "ABCD1234", ExpiryDate: now.Add(-time.Minute), Status: store.ReservationStatusAccepted},
+		{ChargeStationId: "cs001", ReservationId: 1, IdToken: "ABCD1234", ExpiryDate: now.Add(-time.Hour), Status: store.ReservationStatusUsed},
+		{ChargeStationId: "cs002", ReservationId: 1, IdToken: "EFGH5678", ExpiryDate: now.Add(time.Hour), Status: store.ReservationStatusPending},
+	}
+	for _, reservation := range reservations {
+		require.NoError(t, engine.SetReservation(ctx, reservation))
+	}
+
+	got, err := engine.ListReservations(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.Reservation{reservations[1], reservations[0]}, got)
+
+	expired, err := engine.ListExpiredReservations(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.Reservation{reservations[0]}, expired)
+
+	// a reservation that is no longer active is not expired
+	reservations[0].Status = store.ReservationStatusExpired
+	require.NoError(t, engine.SetReservation(ctx, reservations[0]))
+
+	expired, err = engine.ListExpiredReservations(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+}
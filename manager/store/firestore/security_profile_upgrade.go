@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type securityProfileUpgrade struct {
+	FromSecurityProfile   int       `firestore:"fp"`
+	ToSecurityProfile     int       `firestore:"tp"`
+	Step                  string    `firestore:"st"`
+	CsmsRootCertificate   string    `firestore:"rc"`
+	Base64SHA256Password  string    `firestore:"ph"`
+	ClientCertificateHash string    `firestore:"ch"`
+	OcppCsmsUrl           string    `firestore:"cu"`
+	ConfigurationSlot     int       `firestore:"cs"`
+	OcppInterface         string    `firestore:"oi"`
+	MessageTimeout        int       `firestore:"mt"`
+	SendAfter             time.Time `firestore:"u"`
+	ErrorDescription      string    `firestore:"ed"`
+	RequestedAt           time.Time `firestore:"ra"`
+	UpdatedAt             time.Time `firestore:"ua"`
+}
+
+func (s *Store) SetChargeStationSecurityProfileUpgrade(ctx context.Context, chargeStationId string, upgrade *store.ChargeStationSecurityProfileUpgrade) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationSecurityProfileUpgrade/%s", chargeStationId))
+	_, err := csRef.Set(ctx, &securityProfileUpgrade{
+		FromSecurityProfile:   int(upgrade.FromSecurityProfile),
+		ToSecurityProfile:     int(upgrade.ToSecurityProfile),
+		Step:                  string(upgrade.Step),
+		CsmsRootCertificate:   upgrade.CsmsRootCertificate,
+		Base64SHA256Password:  upgrade.Base64SHA256Password,
+		ClientCertificateHash: upgrade.ClientCertificateHash,
+		OcppCsmsUrl:           upgrade.OcppCsmsUrl,
+		ConfigurationSlot:     upgrade.ConfigurationSlot,
+		OcppInterface:         upgrade.OcppInterface,
+		MessageTimeout:        upgrade.MessageTimeout,
+		SendAfter:             upgrade.SendAfter,
+		ErrorDescription:      upgrade.ErrorDescription,
+		RequestedAt:           upgrade.RequestedAt,
+		UpdatedAt:             upgrade.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station security profile upgrade %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationSecurityProfileUpgrade(ctx context.Context, chargeStationId string) (*store.ChargeStationSecurityProfileUpgrade, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationSecurityProfileUpgrade/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station security profile upgrade %s: %w", chargeStationId, err)
+	}
+	var upgrade securityProfileUpgrade
+	if err = snap.DataTo(&upgrade); err != nil {
+		return nil, fmt.Errorf("map charge station security profile upgrade %s: %w", chargeStationId, err)
+	}
+	return mapSecurityProfileUpgrade(chargeStationId, &upgrade), nil
+}
+
+func (s *Store) ListChargeStationSecurityProfileUpgrades(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationSecurityProfileUpgrade, error) {
+	var upgrades []*store.ChargeStationSecurityProfileUpgrade
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationSecurityProfileUpgrade").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationSecurityProfileUpgrade").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station security profile upgrades: %w", err)
+	}
+	for _, snap := range snaps {
+		var upgrade securityProfileUpgrade
+		if err = snap.DataTo(&upgrade); err != nil {
+			return nil, fmt.Errorf("map charge station security profile upgrade: %w", err)
+		}
+		upgrades = append(upgrades, mapSecurityProfileUpgrade(snap.Ref.ID, &upgrade))
+	}
+	return upgrades, nil
+}
+
+func mapSecurityProfileUpgrade(chargeStationId string, upgrade *securityProfileUpgrade) *store.ChargeStationSecurityProfileUpgrade {
+	return &store.ChargeStationSecurityProfileUpgrade{
+		ChargeStationId:       chargeStationId,
+		FromSecurityProfile:   store.SecurityProfile(upgrade.FromSecurityProfile),
+		ToSecurityProfile:     store.SecurityProfile(upgrade.ToSecurityProfile),
+		Step:                  store.SecurityProfileUpgradeStep(upgrade.Step),
+		CsmsRootCertificate:   upgrade.CsmsRootCertificate,
+		Base64SHA256Password:  upgrade.Base64SHA256Password,
+		ClientCertificateHash: upgrade.ClientCertificateHash,
+		OcppCsmsUrl:           upgrade.OcppCsmsUrl,
+		ConfigurationSlot:     upgrade.ConfigurationSlot,
+		OcppInterface:         upgrade.OcppInterface,
+		MessageTimeout:        upgrade.MessageTimeout,
+		SendAfter:             upgrade.SendAfter,
+		ErrorDescription:      upgrade.ErrorDescription,
+		RequestedAt:           upgrade.RequestedAt,
+		UpdatedAt:             upgrade.UpdatedAt,
+	}
+}
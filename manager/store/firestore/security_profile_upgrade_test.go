@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+)
+
+func TestSetAndLookupChargeStationSecurityProfileUpgrade(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	want := &store.ChargeStationSecurityProfileUpgrade{
+		ChargeStationId:      "cs001",
+		FromSecurityProfile:  store.UnsecuredTransportWithBasicAuth,
+		ToSecurityProfile:    store.TLSWithBasicAuth,
+		Step:                 store.SecurityProfileUpgradeStepSetBasicAuthPassword,
+		CsmsRootCertificate:  "-----BEGIN CERTIFICATE-----",
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=",
+		OcppCsmsUrl:          "wss://csms.example.com/ws",
+		ConfigurationSlot:    2,
+		OcppInterface:        "Wireless0",
+		MessageTimeout:       30,
+		SendAfter:            now,
+		RequestedAt:          now,
+		UpdatedAt:            now,
+	}
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeStationSecurityProfileUpgrades(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	defer engine.CloseConn()
+
+	for _, csId := range []string{"cs003", "cs001", "cs002"} {
+		err = engine.SetChargeStationSecurityProfileUpgrade(ctx, csId, &store.ChargeStationSecurityProfileUpgrade{
+			FromSecurityProfile: store.TLSWithBasicAuth,
+			ToSecurityProfile:   store.TLSWithClientSideCertificates,
+			Step:                store.SecurityProfileUpgradeStepSignCertificate,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationSecurityProfileUpgrades(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs001", got[0].ChargeStationId)
+	assert.Equal(t, "cs002", got[1].ChargeStationId)
+
+	got, err = engine.ListChargeStationSecurityProfileUpgrades(ctx, 2, "cs002")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs003", got[0].ChargeStationId)
+}
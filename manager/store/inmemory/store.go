@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -29,12 +30,26 @@ type Store struct {
 	clock                            clock.PassiveClock
 	chargeStation                    map[string]*store.ChargeStation
 	chargeStationSettings            map[string]*store.ChargeStationSettings
+	chargeStationVariables           map[string]*store.ChargeStationVariables
 	chargeStationInstallCertificates map[string]*store.ChargeStationInstallCertificates
+	chargeStationIssuedCertificates  map[string]*store.ChargeStationIssuedCertificates
+	chargeStationCertInventories     map[string]*store.ChargeStationCertificateInventory
 	chargeStationRuntimeDetails      map[string]*store.ChargeStationRuntimeDetails
 	chargeStationTriggerMessage      map[string]*store.ChargeStationTriggerMessage
+	chargeStationDeviceModel         map[string]*store.ChargeStationDeviceModel
+	chargeStationOperations          map[string]map[string]*store.ChargeStationOperation
+	securityProfileUpgrades          map[string]*store.ChargeStationSecurityProfileUpgrade
+	connections                      map[string]*store.ChargeStationConnection
+	chargeStationLocalLists          map[string]*store.ChargeStationLocalList
+	chargeStationDisplayMessages     map[string]*store.ChargeStationDisplayMessages
+	reservations                     map[string]map[int]*store.Reservation
+	deviceModelReportParts           map[string][]*store.DeviceModelReportPart
+	configurationTemplates           map[string]*store.ConfigurationTemplate
 	tokens                           map[string]*store.Token
 	transactions                     map[string]*store.Transaction
 	certificates                     map[string]string
+	revokedCertificates              map[string]*store.RevokedCertificate
+	ocspResponses                    map[string]*store.OcspResponse
 	registrations                    map[string]*store.OcpiRegistration
 	partyDetails                     map[string]*store.OcpiParty
 	locations                        map[string]*store.Location
@@ -45,12 +60,26 @@ func NewStore(clock clock.PassiveClock) *Store {
 		clock:                            clock,
 		chargeStation:                    make(map[string]*store.ChargeStation),
 		chargeStationSettings:            make(map[string]*store.ChargeStationSettings),
+		chargeStationVariables:           make(map[string]*store.ChargeStationVariables),
 		chargeStationInstallCertificates: make(map[string]*store.ChargeStationInstallCertificates),
+		chargeStationIssuedCertificates:  make(map[string]*store.ChargeStationIssuedCertificates),
+		chargeStationCertInventories:     make(map[string]*store.ChargeStationCertificateInventory),
 		chargeStationRuntimeDetails:      make(map[string]*store.ChargeStationRuntimeDetails),
 		chargeStationTriggerMessage:      make(map[string]*store.ChargeStationTriggerMessage),
+		chargeStationDeviceModel:         make(map[string]*store.ChargeStationDeviceModel),
+		chargeStationOperations:          make(map[string]map[string]*store.ChargeStationOperation),
+		securityProfileUpgrades:          make(map[string]*store.ChargeStationSecurityProfileUpgrade),
+		connections:                      make(map[string]*store.ChargeStationConnection),
+		chargeStationLocalLists:          make(map[string]*store.ChargeStationLocalList),
+		chargeStationDisplayMessages:     make(map[string]*store.ChargeStationDisplayMessages),
+		reservations:                     make(map[string]map[int]*store.Reservation),
+		deviceModelReportParts:           make(map[string][]*store.DeviceModelReportPart),
+		configurationTemplates:           make(map[string]*store.ConfigurationTemplate),
 		tokens:                           make(map[string]*store.Token),
 		transactions:                     make(map[string]*store.Transaction),
 		certificates:                     make(map[string]string),
+		revokedCertificates:              make(map[string]*store.RevokedCertificate),
+		ocspResponses:                    make(map[string]*store.OcspResponse),
 		registrations:                    make(map[string]*store.OcpiRegistration),
 		partyDetails:                     make(map[string]*store.OcpiParty),
 		locations:                        make(map[string]*store.Location),
@@ -88,12 +117,13 @@ func (s *Store) ListChargeStations(_ context.Context, offset int, limit int) ([]
 	s.Lock()
 	defer s.Unlock()
 	var chargeStations []*store.ChargeStation
-	count := 0
-	for _, cs := range s.chargeStation {
+	// order by id (as the firestore store does) so that paging is stable
+	csIds := maps.Keys(s.chargeStation)
+	slices.Sort(csIds)
+	for count, csId := range csIds {
 		if count >= offset && count < offset+limit {
-			chargeStations = append(chargeStations, cs)
+			chargeStations = append(chargeStations, s.chargeStation[csId])
 		}
-		count++
 	}
 	if chargeStations == nil {
 		chargeStations = make([]*store.ChargeStation, 0)
@@ -155,6 +185,49 @@ func (s *Store) ListChargeStationSettings(_ context.Context, pageSize int, previ
 	return settings, nil
 }
 
+func (s *Store) UpdateChargeStationVariables(_ context.Context, chargeStationId string, variables *store.ChargeStationVariables) error {
+	s.Lock()
+	defer s.Unlock()
+	vars := s.chargeStationVariables[chargeStationId]
+	if vars == nil {
+		vars = &store.ChargeStationVariables{
+			ChargeStationId: chargeStationId,
+			Variables:       make(map[string]*store.ChargeStationVariable, len(variables.Variables)),
+		}
+	}
+	maps.Copy(vars.Variables, variables.Variables)
+	s.chargeStationVariables[chargeStationId] = vars
+	return nil
+}
+
+func (s *Store) LookupChargeStationVariables(_ context.Context, chargeStationId string) (*store.ChargeStationVariables, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.chargeStationVariables[chargeStationId], nil
+}
+
+func (s *Store) ListChargeStationVariables(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationVariables, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.chargeStationVariables)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var variables []*store.ChargeStationVariables
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		variables = append(variables, s.chargeStationVariables[k])
+	}
+	return variables, nil
+}
+
 func (s *Store) UpdateChargeStationInstallCertificates(_ context.Context, chargeStationId string, certificates *store.ChargeStationInstallCertificates) error {
 	s.Lock()
 	defer s.Unlock()
@@ -173,6 +246,8 @@ func (s *Store) UpdateChargeStationInstallCertificates(_ context.Context, charge
 					c.CertificateData = v.CertificateData
 					c.CertificateInstallationStatus = v.CertificateInstallationStatus
 					c.CertificateType = v.CertificateType
+					c.ErrorCode = v.ErrorCode
+					c.ErrorDescription = v.ErrorDescription
 					matched = true
 					break
 				}
@@ -215,6 +290,140 @@ func (s *Store) ListChargeStationInstallCertificates(_ context.Context, pageSize
 	return installCertificates, nil
 }
 
+func (s *Store) SetChargeStationIssuedCertificates(_ context.Context, chargeStationId string, certificates *store.ChargeStationIssuedCertificates) error {
+	s.Lock()
+	defer s.Unlock()
+	c := copyIssuedCertificates(certificates)
+	c.ChargeStationId = chargeStationId
+	s.chargeStationIssuedCertificates[chargeStationId] = c
+	return nil
+}
+
+func (s *Store) LookupChargeStationIssuedCertificates(_ context.Context, chargeStationId string) (*store.ChargeStationIssuedCertificates, error) {
+	s.Lock()
+	defer s.Unlock()
+	certificates, ok := s.chargeStationIssuedCertificates[chargeStationId]
+	if !ok {
+		return nil, nil
+	}
+	return copyIssuedCertificates(certificates), nil
+}
+
+func (s *Store) ListChargeStationIssuedCertificates(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationIssuedCertificates, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.chargeStationIssuedCertificates)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var result []*store.ChargeStationIssuedCertificates
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		result = append(result, copyIssuedCertificates(s.chargeStationIssuedCertificates[k]))
+	}
+	return result, nil
+}
+
+// copyIssuedCertificates copies the certificates so that changes made by the caller do not affect the store
+func copyIssuedCertificates(certificates *store.ChargeStationIssuedCertificates) *store.ChargeStationIssuedCertificates {
+	c := *certificates
+	c.Certificates = make(map[store.CertificateType]*store.IssuedCertificate, len(certificates.Certificates))
+	for certificateType, certificate := range certificates.Certificates {
+		c.Certificates[certificateType] = copyIssuedCertificate(certificate)
+	}
+	c.Superseded = nil
+	for _, certificate := range certificates.Superseded {
+		c.Superseded = append(c.Superseded, copyIssuedCertificate(certificate))
+	}
+	return &c
+}
+
+func copyIssuedCertificate(certificate *store.IssuedCertificate) *store.IssuedCertificate {
+	cert := *certificate
+	if certificate.RenewalRequestedAt != nil {
+		renewalRequestedAt := *certificate.RenewalRequestedAt
+		cert.RenewalRequestedAt = &renewalRequestedAt
+	}
+	return &cert
+}
+
+func (s *Store) SetChargeStationCertificateInventory(_ context.Context, chargeStationId string, inventory *store.ChargeStationCertificateInventory) error {
+	s.Lock()
+	defer s.Unlock()
+	i := copyCertificateInventory(inventory)
+	i.ChargeStationId = chargeStationId
+	s.chargeStationCertInventories[chargeStationId] = i
+	return nil
+}
+
+func (s *Store) LookupChargeStationCertificateInventory(_ context.Context, chargeStationId string) (*store.ChargeStationCertificateInventory, error) {
+	s.Lock()
+	defer s.Unlock()
+	inventory, ok := s.chargeStationCertInventories[chargeStationId]
+	if !ok {
+		return nil, nil
+	}
+	return copyCertificateInventory(inventory), nil
+}
+
+func (s *Store) ListChargeStationCertificateInventories(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationCertificateInventory, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.chargeStationCertInventories)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var result []*store.ChargeStationCertificateInventory
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		result = append(result, copyCertificateInventory(s.chargeStationCertInventories[k]))
+	}
+	return result, nil
+}
+
+// copyCertificateInventory copies the inventory so that changes made by the caller do not affect the store
+func copyCertificateInventory(inventory *store.ChargeStationCertificateInventory) *store.ChargeStationCertificateInventory {
+	copyCerts := func(certs []*store.InventoryCertificate) []*store.InventoryCertificate {
+		if certs == nil {
+			return nil
+		}
+		result := make([]*store.InventoryCertificate, len(certs))
+		for i, cert := range certs {
+			c := *cert
+			result[i] = &c
+		}
+		return result
+	}
+	copyTime := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		c := *t
+		return &c
+	}
+	i := *inventory
+	i.Certificates = copyCerts(inventory.Certificates)
+	i.MissingCertificates = copyCerts(inventory.MissingCertificates)
+	i.ReportedAt = copyTime(inventory.ReportedAt)
+	i.RequestedAt = copyTime(inventory.RequestedAt)
+	i.AuditedAt = copyTime(inventory.AuditedAt)
+	return &i
+}
+
 func (s *Store) SetChargeStationRuntimeDetails(_ context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
 	s.Lock()
 	defer s.Unlock()
@@ -270,6 +479,420 @@ func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize i
 	return triggerMessages, nil
 }
 
+func (s *Store) SetChargeStationSecurityProfileUpgrade(_ context.Context, chargeStationId string, upgrade *store.ChargeStationSecurityProfileUpgrade) error {
+	s.Lock()
+	defer s.Unlock()
+	u := *upgrade
+	u.ChargeStationId = chargeStationId
+	s.securityProfileUpgrades[chargeStationId] = &u
+	return nil
+}
+
+func (s *Store) LookupChargeStationSecurityProfileUpgrade(_ context.Context, chargeStationId string) (*store.ChargeStationSecurityProfileUpgrade, error) {
+	s.Lock()
+	defer s.Unlock()
+	upgrade, ok := s.securityProfileUpgrades[chargeStationId]
+	if !ok {
+		return nil, nil
+	}
+	u := *upgrade
+	return &u, nil
+}
+
+func (s *Store) ListChargeStationSecurityProfileUpgrades(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationSecurityProfileUpgrade, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.securityProfileUpgrades)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var upgrades []*store.ChargeStationSecurityProfileUpgrade
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		u := *s.securityProfileUpgrades[k]
+		upgrades = append(upgrades, &u)
+	}
+	return upgrades, nil
+}
+
+func (s *Store) SetChargeStationConnection(_ context.Context, chargeStationId string, connection *store.ChargeStationConnection) error {
+	s.Lock()
+	defer s.Unlock()
+	c := *connection
+	c.ChargeStationId = chargeStationId
+	s.connections[chargeStationId] = &c
+	return nil
+}
+
+func (s *Store) LookupChargeStationConnection(_ context.Context, chargeStationId string) (*store.ChargeStationConnection, error) {
+	s.Lock()
+	defer s.Unlock()
+	connection, ok := s.connections[chargeStationId]
+	if !ok {
+		return nil, nil
+	}
+	c := *connection
+	return &c, nil
+}
+
+func (s *Store) DeleteChargeStationConnection(_ context.Context, chargeStationId string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.connections, chargeStationId)
+	return nil
+}
+
+func (s *Store) ListChargeStationConnections(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationConnection, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.connections)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var connections []*store.ChargeStationConnection
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		c := *s.connections[k]
+		connections = append(connections, &c)
+	}
+	return connections, nil
+}
+
+func getDeviceModelReportKey(chargeStationId string, requestId int) string {
+	return fmt.Sprintf("%s:%d", chargeStationId, requestId)
+}
+
+func (s *Store) SetDeviceModelReportPart(_ context.Context, chargeStationId string, requestId int, part *store.DeviceModelReportPart) error {
+	s.Lock()
+	defer s.Unlock()
+	key := getDeviceModelReportKey(chargeStationId, requestId)
+	parts := slices.DeleteFunc(s.deviceModelReportParts[key], func(p *store.DeviceModelReportPart) bool {
+		return p.SeqNo == part.SeqNo
+	})
+	parts = append(parts, part)
+	slices.SortFunc(parts, func(a, b *store.DeviceModelReportPart) int {
+		return a.SeqNo - b.SeqNo
+	})
+	s.deviceModelReportParts[key] = parts
+	return nil
+}
+
+func (s *Store) LookupDeviceModelReportParts(_ context.Context, chargeStationId string, requestId int) ([]*store.DeviceModelReportPart, error) {
+	s.Lock()
+	defer s.Unlock()
+	return slices.Clone(s.deviceModelReportParts[getDeviceModelReportKey(chargeStationId, requestId)]), nil
+}
+
+func (s *Store) DeleteDeviceModelReportParts(_ context.Context, chargeStationId string, requestId int) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.deviceModelReportParts, getDeviceModelReportKey(chargeStationId, requestId))
+	return nil
+}
+
+func (s *Store) UpdateChargeStationDeviceModel(_ context.Context, chargeStationId string, variables []*store.DeviceModelVariable, updatedAt time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	merged := make(map[string]*store.DeviceModelVariable)
+	if deviceModel, ok := s.chargeStationDeviceModel[chargeStationId]; ok {
+		for _, v := range deviceModel.Variables {
+			merged[v.Key()] = v
+		}
+	}
+	for _, v := range variables {
+		merged[v.Key()] = v
+	}
+
+	keys := maps.Keys(merged)
+	sort.Strings(keys)
+	deviceModelVariables := make([]*store.DeviceModelVariable, len(keys))
+	for i, k := range keys {
+		deviceModelVariables[i] = merged[k]
+	}
+
+	s.chargeStationDeviceModel[chargeStationId] = &store.ChargeStationDeviceModel{
+		ChargeStationId: chargeStationId,
+		Variables:       deviceModelVariables,
+		UpdatedAt:       updatedAt,
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationDeviceModel(_ context.Context, chargeStationId string) (*store.ChargeStationDeviceModel, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.chargeStationDeviceModel[chargeStationId], nil
+}
+
+func (s *Store) SetChargeStationOperation(_ context.Context, chargeStationId string, operation *store.ChargeStationOperation) error {
+	s.Lock()
+	defer s.Unlock()
+	operations, ok := s.chargeStationOperations[chargeStationId]
+	if !ok {
+		operations = make(map[string]*store.ChargeStationOperation)
+		s.chargeStationOperations[chargeStationId] = operations
+	}
+	op := *operation
+	op.ChargeStationId = chargeStationId
+	operations[operation.Operation] = &op
+	return nil
+}
+
+func (s *Store) LookupChargeStationOperation(_ context.Context, chargeStationId, operation string) (*store.ChargeStationOperation, error) {
+	s.Lock()
+	defer s.Unlock()
+	op, ok := s.chargeStationOperations[chargeStationId][operation]
+	if !ok {
+		return nil, nil
+	}
+	result := *op
+	return &result, nil
+}
+
+func (s *Store) ListChargeStationOperations(_ context.Context, chargeStationId string) ([]*store.ChargeStationOperation, error) {
+	s.Lock()
+	defer s.Unlock()
+	operations := s.chargeStationOperations[chargeStationId]
+	names := maps.Keys(operations)
+	sort.Strings(names)
+	result := make([]*store.ChargeStationOperation, 0, len(names))
+	for _, name := range names {
+		op := *operations[name]
+		result = append(result, &op)
+	}
+	return result, nil
+}
+
+func (s *Store) SetChargeStationLocalList(_ context.Context, chargeStationId string, localList *store.ChargeStationLocalList) error {
+	s.Lock()
+	defer s.Unlock()
+	list := copyLocalList(localList)
+	list.ChargeStationId = chargeStationId
+	s.chargeStationLocalLists[chargeStationId] = list
+	return nil
+}
+
+func (s *Store) LookupChargeStationLocalList(_ context.Context, chargeStationId string) (*store.ChargeStationLocalList, error) {
+	s.Lock()
+	defer s.Unlock()
+	list, ok := s.chargeStationLocalLists[chargeStationId]
+	if !ok {
+		return nil, nil
+	}
+	return copyLocalList(list), nil
+}
+
+func (s *Store) ListChargeStationLocalLists(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationLocalList, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.chargeStationLocalLists)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var lists []*store.ChargeStationLocalList
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		lists = append(lists, copyLocalList(s.chargeStationLocalLists[k]))
+	}
+	return lists, nil
+}
+
+// copyLocalList copies the list so that changes made by the caller do not affect the store
+func copyLocalList(localList *store.ChargeStationLocalList) *store.ChargeStationLocalList {
+	list := *localList
+	list.Entries = make(map[string]*store.LocalListEntry, len(localList.Entries))
+	for idToken, entry := range localList.Entries {
+		e := *entry
+		list.Entries[idToken] = &e
+	}
+	return &list
+}
+
+func (s *Store) SetChargeStationDisplayMessages(_ context.Context, chargeStationId string, messages *store.ChargeStationDisplayMessages) error {
+	s.Lock()
+	defer s.Unlock()
+	m := copyDisplayMessages(messages)
+	m.ChargeStationId = chargeStationId
+	s.chargeStationDisplayMessages[chargeStationId] = m
+	return nil
+}
+
+func (s *Store) LookupChargeStationDisplayMessages(_ context.Context, chargeStationId string) (*store.ChargeStationDisplayMessages, error) {
+	s.Lock()
+	defer s.Unlock()
+	messages, ok := s.chargeStationDisplayMessages[chargeStationId]
+	if !ok {
+		return nil, nil
+	}
+	return copyDisplayMessages(messages), nil
+}
+
+func (s *Store) ListChargeStationDisplayMessages(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationDisplayMessages, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.chargeStationDisplayMessages)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var result []*store.ChargeStationDisplayMessages
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		result = append(result, copyDisplayMessages(s.chargeStationDisplayMessages[k]))
+	}
+	return result, nil
+}
+
+// copyDisplayMessages copies the messages so that changes made by the caller do not affect the store
+func copyDisplayMessages(messages *store.ChargeStationDisplayMessages) *store.ChargeStationDisplayMessages {
+	m := *messages
+	m.Messages = make(map[int]*store.DisplayMessage, len(messages.Messages))
+	for id, message := range messages.Messages {
+		msg := *message
+		m.Messages[id] = &msg
+	}
+	return &m
+}
+
+func (s *Store) SetReservation(_ context.Context, reservation *store.Reservation) error {
+	s.Lock()
+	defer s.Unlock()
+	reservations, ok := s.reservations[reservation.ChargeStationId]
+	if !ok {
+		reservations = make(map[int]*store.Reservation)
+		s.reservations[reservation.ChargeStationId] = reservations
+	}
+	r := *reservation
+	reservations[reservation.ReservationId] = &r
+	return nil
+}
+
+func (s *Store) LookupReservation(_ context.Context, chargeStationId string, reservationId int) (*store.Reservation, error) {
+	s.Lock()
+	defer s.Unlock()
+	reservation, ok := s.reservations[chargeStationId][reservationId]
+	if !ok {
+		return nil, nil
+	}
+	r := *reservation
+	return &r, nil
+}
+
+func (s *Store) LookupReservationByReference(_ context.Context, reference string) (*store.Reservation, error) {
+	s.Lock()
+	defer s.Unlock()
+	for _, reservations := range s.reservations {
+		for _, reservation := range reservations {
+			if reservation.Reference != nil && *reservation.Reference == reference {
+				r := *reservation
+				return &r, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Store) ListReservations(_ context.Context, chargeStationId string) ([]*store.Reservation, error) {
+	s.Lock()
+	defer s.Unlock()
+	result := make([]*store.Reservation, 0, len(s.reservations[chargeStationId]))
+	for _, reservation := range s.reservations[chargeStationId] {
+		r := *reservation
+		result = append(result, &r)
+	}
+	sortReservations(result)
+	return result, nil
+}
+
+func (s *Store) ListExpiredReservations(_ context.Context, before time.Time, pageSize int) ([]*store.Reservation, error) {
+	s.Lock()
+	defer s.Unlock()
+	var result []*store.Reservation
+	for _, reservations := range s.reservations {
+		for _, reservation := range reservations {
+			if reservation.Status.IsActive() && reservation.ExpiryDate.Before(before) {
+				r := *reservation
+				result = append(result, &r)
+			}
+		}
+	}
+	sortReservations(result)
+	if len(result) > pageSize {
+		result = result[:pageSize]
+	}
+	return result, nil
+}
+
+func sortReservations(reservations []*store.Reservation) {
+	slices.SortFunc(reservations, func(a, b *store.Reservation) int {
+		if a.ChargeStationId != b.ChargeStationId {
+			return strings.Compare(a.ChargeStationId, b.ChargeStationId)
+		}
+		return a.ReservationId - b.ReservationId
+	})
+}
+
+func (s *Store) SetConfigurationTemplate(_ context.Context, template *store.ConfigurationTemplate) error {
+	s.Lock()
+	defer s.Unlock()
+	s.configurationTemplates[template.Id] = template
+	return nil
+}
+
+func (s *Store) LookupConfigurationTemplate(_ context.Context, templateId string) (*store.ConfigurationTemplate, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.configurationTemplates[templateId], nil
+}
+
+func (s *Store) ListConfigurationTemplates(_ context.Context) ([]*store.ConfigurationTemplate, error) {
+	s.Lock()
+	defer s.Unlock()
+	templates := make([]*store.ConfigurationTemplate, 0, len(s.configurationTemplates))
+	for _, template := range s.configurationTemplates {
+		templates = append(templates, template)
+	}
+	slices.SortFunc(templates, func(a, b *store.ConfigurationTemplate) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return templates, nil
+}
+
+func (s *Store) DeleteConfigurationTemplate(_ context.Context, templateId string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.configurationTemplates, templateId)
+	return nil
+}
+
 func (s *Store) SetToken(_ context.Context, token *store.Token) error {
 	s.Lock()
 	defer s.Unlock()
@@ -456,6 +1079,73 @@ func (s *Store) DeleteCertificate(_ context.Context, certificateHash string) err
 	return nil
 }
 
+func (s *Store) SetRevokedCertificate(_ context.Context, certificate *store.RevokedCertificate) error {
+	s.Lock()
+	defer s.Unlock()
+
+	c := *certificate
+	s.revokedCertificates[certificate.CertificateHash] = &c
+
+	return nil
+}
+
+func (s *Store) LookupRevokedCertificate(_ context.Context, certificateHash string) (*store.RevokedCertificate, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	certificate, ok := s.revokedCertificates[certificateHash]
+	if !ok {
+		return nil, nil
+	}
+	c := *certificate
+	return &c, nil
+}
+
+func (s *Store) ListRevokedCertificates(_ context.Context, pageSize int, previousCertificateHash string) ([]*store.RevokedCertificate, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.revokedCertificates)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousCertificateHash)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var result []*store.RevokedCertificate
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		c := *s.revokedCertificates[k]
+		result = append(result, &c)
+	}
+	return result, nil
+}
+
+func (s *Store) SetOcspResponse(_ context.Context, response *store.OcspResponse) error {
+	s.Lock()
+	defer s.Unlock()
+
+	r := *response
+	s.ocspResponses[response.Key] = &r
+
+	return nil
+}
+
+func (s *Store) LookupOcspResponse(_ context.Context, key string) (*store.OcspResponse, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	response, ok := s.ocspResponses[key]
+	if !ok {
+		return nil, nil
+	}
+	r := *response
+	return &r, nil
+}
+
 func (s *Store) SetRegistrationDetails(_ context.Context, token string, registration *store.OcpiRegistration) error {
 	s.Lock()
 	defer s.Unlock()
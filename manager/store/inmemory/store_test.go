@@ -117,6 +117,42 @@ func TestListChargeStationSettingsReturnsDataInPages(t *testing.T) {
 	assert.Len(t, csIds, 25)
 }
 
+func TestUpdateChargeStationVariablesMergesVariables(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	value := "60"
+	err := engine.UpdateChargeStationVariables(context.Background(), "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"HeartbeatInterval":        {Status: store.ChargeStationVariableStatusPending},
+			"MeterValueSampleInterval": {Status: store.ChargeStationVariableStatusPending},
+		},
+	})
+	require.NoError(t, err)
+
+	err = engine.UpdateChargeStationVariables(context.Background(), "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"HeartbeatInterval": {Value: &value, Status: store.ChargeStationVariableStatusAccepted},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationVariables(context.Background(), "cs001")
+	require.NoError(t, err)
+
+	want := &store.ChargeStationVariables{
+		ChargeStationId: "cs001",
+		Variables: map[string]*store.ChargeStationVariable{
+			"HeartbeatInterval":        {Value: &value, Status: store.ChargeStationVariableStatusAccepted},
+			"MeterValueSampleInterval": {Status: store.ChargeStationVariableStatusPending},
+		},
+	}
+	assert.Equal(t, want, got)
+
+	list, err := engine.ListChargeStationVariables(context.Background(), 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationVariables{want}, list)
+}
+
 func TestUpdateChargeStationInstallCertificates(t *testing.T) {
 	now := time.Now()
 	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(now))
@@ -214,3 +250,536 @@ func TestUpdateChargeStationCertificateWithNewCertificate(t *testing.T) {
 	assert.Equal(t, "evcc-pem-data", got.Certificates[1].CertificateData)
 	assert.Equal(t, store.CertificateInstallationPending, got.Certificates[1].CertificateInstallationStatus)
 }
+
+func TestDeviceModelReportParts(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	part1 := &store.DeviceModelReportPart{SeqNo: 1, Tbc: false}
+	part0 := &store.DeviceModelReportPart{SeqNo: 0, Tbc: true}
+
+	require.NoError(t, engine.SetDeviceModelReportPart(ctx, "cs001", 42, part1))
+	require.NoError(t, engine.SetDeviceModelReportPart(ctx, "cs001", 42, part0))
+	require.NoError(t, engine.SetDeviceModelReportPart(ctx, "cs001", 42, part1))
+
+	got, err := engine.LookupDeviceModelReportParts(ctx, "cs001", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.DeviceModelReportPart{part0, part1}, got)
+
+	require.NoError(t, engine.DeleteDeviceModelReportParts(ctx, "cs001", 42))
+
+	got, err = engine.LookupDeviceModelReportParts(ctx, "cs001", 42)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestUpdateChargeStationDeviceModelMergesVariables(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+	now := time.Now()
+
+	oldValue, newValue := "30", "60"
+	heartbeat := func(value *string) *store.DeviceModelVariable {
+		return &store.DeviceModelVariable{
+			ComponentName: "OCPPCommCtrlr",
+			VariableName:  "HeartbeatInterval",
+			Attributes: []*store.DeviceModelVariableAttribute{
+				{Type: "Actual", Value: value, Mutability: "ReadWrite"},
+			},
+		}
+	}
+	profile := &store.DeviceModelVariable{ComponentName: "SecurityCtrlr", VariableName: "SecurityProfile"}
+
+	require.NoError(t, engine.UpdateChargeStationDeviceModel(ctx, "cs001",
+		[]*store.DeviceModelVariable{profile, heartbeat(&oldValue)}, now))
+	require.NoError(t, engine.UpdateChargeStationDeviceModel(ctx, "cs001",
+		[]*store.DeviceModelVariable{heartbeat(&newValue)}, now.Add(time.Minute)))
+
+	got, err := engine.LookupChargeStationDeviceModel(ctx, "cs001")
+	require.NoError(t, err)
+
+	want := &store.ChargeStationDeviceModel{
+		ChargeStationId: "cs001",
+		Variables:       []*store.DeviceModelVariable{heartbeat(&newValue), profile},
+		UpdatedAt:       now.Add(time.Minute),
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestConfigurationTemplates(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	location := "loc001"
+	model := "ModelY"
+	templateB := &store.ConfigurationTemplate{
+		Id:         "b",
+		LocationId: &location,
+		Settings:   map[string]string{"HeartbeatInterval": "60"},
+	}
+	templateA := &store.ConfigurationTemplate{
+		Id:       "a",
+		Model:    &model,
+		Settings: map[string]string{"OCPPCommCtrlr/HeartbeatInterval": "30"},
+	}
+	require.NoError(t, engine.SetConfigurationTemplate(ctx, templateB))
+	require.NoError(t, engine.SetConfigurationTemplate(ctx, templateA))
+
+	got, err := engine.LookupConfigurationTemplate(ctx, "b")
+	require.NoError(t, err)
+	assert.Equal(t, templateB, got)
+
+	templates, err := engine.ListConfigurationTemplates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ConfigurationTemplate{templateA, templateB}, templates)
+
+	require.NoError(t, engine.DeleteConfigurationTemplate(ctx, "b"))
+
+	got, err = engine.LookupConfigurationTemplate(ctx, "b")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestChargeStationOperations(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	reset := &store.ChargeStationOperation{
+		ChargeStationId: "cs001",
+		Operation:       "Reset",
+		Request:         `{"type":"Immediate"}`,
+		Status:          store.ChargeStationOperationStatusPending,
+	}
+	clearCache := &store.ChargeStationOperation{
+		ChargeStationId: "cs001",
+		Operation:       "ClearCache",
+		Request:         `{}`,
+		Status:          "Accepted",
+		Response:        `{"status":"Accepted"}`,
+	}
+	require.NoError(t, engine.SetChargeStationOperation(ctx, "cs001", reset))
+	require.NoError(t, engine.SetChargeStationOperation(ctx, "cs001", clearCache))
+
+	got, err := engine.LookupChargeStationOperation(ctx, "cs001", "Reset")
+	require.NoError(t, err)
+	assert.Equal(t, reset, got)
+
+	got, err = engine.LookupChargeStationOperation(ctx, "cs002", "Reset")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	operations, err := engine.ListChargeStationOperations(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationOperation{clearCache, reset}, operations)
+}
+
+func TestChargeStationLocalLists(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	groupId := "group001"
+	localList := &store.ChargeStationLocalList{
+		ChargeStationId: "cs001",
+		GroupId:         &groupId,
+		Version:         3,
+		Entries: map[string]*store.LocalListEntry{
+			"ABCD1234": {IdToken: "ABCD1234", Status: "Accepted", GroupId: &groupId},
+		},
+		Status: store.LocalListStatusAccepted,
+	}
+	require.NoError(t, engine.SetChargeStationLocalList(ctx, "cs001", localList))
+	require.NoError(t, engine.SetChargeStationLocalList(ctx, "cs002", &store.ChargeStationLocalList{
+		Status: store.LocalListStatusPending,
+	}))
+
+	got, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, localList, got)
+
+	// changes to the returned list do not affect the store
+	got.Entries["ABCD1234"].Status = "Invalid"
+	got, err = engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "Accepted", got.Entries["ABCD1234"].Status)
+
+	got, err = engine.LookupChargeStationLocalList(ctx, "cs003")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	lists, err := engine.ListChargeStationLocalLists(ctx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	assert.Equal(t, "cs001", lists[0].ChargeStationId)
+
+	lists, err = engine.ListChargeStationLocalLists(ctx, 1, "cs001")
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	assert.Equal(t, "cs002", lists[0].ChargeStationId)
+}
+
+func TestChargeStationDisplayMessages(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	endDateTime := time.Now().UTC().Add(time.Hour)
+	messages := &store.ChargeStationDisplayMessages{
+		ChargeStationId: "cs001",
+		Messages: map[int]*store.DisplayMessage{
+			1: {
+				Id:          1,
+				Priority:    "NormalCycle",
+				EndDateTime: &endDateTime,
+				Format:      "UTF8",
+				Content:     "Welcome",
+				Status:      store.DisplayMessageStatusAccepted,
+			},
+		},
+	}
+	require.NoError(t, engine.SetChargeStationDisplayMessages(ctx, "cs001", messages))
+	require.NoError(t, engine.SetChargeStationDisplayMessages(ctx, "cs002", &store.ChargeStationDisplayMessages{
+		ReconcileStatus: store.DisplayMessageReconcileStatusRequired,
+	}))
+
+	got, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, messages, got)
+
+	// changes to the returned messages do not affect the store
+	got.Messages[1].Status = store.DisplayMessageStatusClearPending
+	got, err = engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageStatusAccepted, got.Messages[1].Status)
+
+	got, err = engine.LookupChargeStationDisplayMessages(ctx, "cs003")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	all, err := engine.ListChargeStationDisplayMessages(ctx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "cs001", all[0].ChargeStationId)
+
+	all, err = engine.ListChargeStationDisplayMessages(ctx, 1, "cs001")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "cs002", all[0].ChargeStationId)
+}
+
+func TestChargeStationIssuedCertificates(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	certificates := &store.ChargeStationIssuedCertificates{
+		ChargeStationId: "cs001",
+		Certificates: map[store.CertificateType]*store.IssuedCertificate{
+			store.CertificateTypeChargeStation: {
+				CertificateType: store.CertificateTypeChargeStation,
+				CertificateId:   "abc123",
+				SerialNumber:    "1234",
+				NotAfter:        now.Add(365 * 24 * time.Hour),
+				IssuedAt:        now,
+			},
+		},
+		Superseded: []*store.IssuedCertificate{
+			{
+				CertificateType: store.CertificateTypeChargeStation,
+				CertificateId:   "fed987",
+				SerialNumber:    "1233",
+				NotAfter:        now.Add(24 * time.Hour),
+				IssuedAt:        now.Add(-364 * 24 * time.Hour),
+			},
+		},
+		UpdatedAt: now,
+	}
+	require.NoError(t, engine.SetChargeStationIssuedCertificates(ctx, "cs001", certificates))
+	require.NoError(t, engine.SetChargeStationIssuedCertificates(ctx, "cs002", &store.ChargeStationIssuedCertificates{}))
+
+	got, err := engine.LookupChargeStationIssuedCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, certificates, got)
+
+	// changes to the returned certificates do not affect the store
+	got.Certificates[store.CertificateTypeChargeStation].RenewalRequestedAt = &now
+	got.Superseded[0].SerialNumber = "changed"
+	got, err = engine.LookupChargeStationIssuedCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got.Certificates[store.CertificateTypeChargeStation].RenewalRequestedAt)
+	assert.Equal(t, "1233", got.Superseded[0].SerialNumber)
+
+	got, err = engine.LookupChargeStationIssuedCertificates(ctx, "cs003")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	all, err := engine.ListChargeStationIssuedCertificates(ctx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "cs001", all[0].ChargeStationId)
+
+	all, err = engine.ListChargeStationIssuedCertificates(ctx, 1, "cs001")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "cs002", all[0].ChargeStationId)
+}
+
+func TestChargeStationCertificateInventory(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	inventory := &store.ChargeStationCertificateInventory{
+		ChargeStationId: "cs001",
+		Certificates: []*store.InventoryCertificate{
+			{
+				CertificateType: store.CertificateTypeV2G,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "name",
+				IssuerKeyHash:   "key",
+				SerialNumber:    "1234",
+			},
+		},
+		ReportedAt: &now,
+		UpdatedAt:  now,
+	}
+	require.NoError(t, engine.SetChargeStationCertificateInventory(ctx, "cs001", inventory))
+	require.NoError(t, engine.SetChargeStationCertificateInventory(ctx, "cs002", &store.ChargeStationCertificateInventory{
+		RequestedAt: &now,
+	}))
+
+	got, err := engine.LookupChargeStationCertificateInventory(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, inventory, got)
+
+	// changes to the returned inventory do not affect the store
+	got.Certificates[0].SerialNumber = "5678"
+	got, err = engine.LookupChargeStationCertificateInventory(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "1234", got.Certificates[0].SerialNumber)
+
+	got, err = engine.LookupChargeStationCertificateInventory(ctx, "cs003")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	all, err := engine.ListChargeStationCertificateInventories(ctx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "cs001", all[0].ChargeStationId)
+
+	all, err = engine.ListChargeStationCertificateInventories(ctx, 1, "cs001")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "cs002", all[0].ChargeStationId)
+}
+
+func TestReservations(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	reference := "RES001"
+	evseId := 1
+	reservations := []*store.Reservation{
+		{
+			ChargeStationId: "cs001",
+			ReservationId:   2,
+			Reference:       &reference,
+			EvseId:          &evseId,
+			IdToken:         "ABCD1234",
+			TokenType:       "ISO14443",
+			ExpiryDate:      now.Add(-time.Minute),
+			Status:          store.ReservationStatusAccepted,
+		},
+		{
+			ChargeStationId: "cs001",
+			ReservationId:   1,
+			IdToken:         "ABCD1234",
+			TokenType:       "ISO14443",
+			ExpiryDate:      now.Add(-time.Hour),
+			Status:          store.ReservationStatusCancelled,
+		},
+		{
+			ChargeStationId: "cs002",
+			ReservationId:   1,
+			IdToken:         "EFGH5678",
+			TokenType:       "ISO14443",
+			ExpiryDate:      now.Add(time.Hour),
+			Status:          store.ReservationStatusPending,
+		},
+	}
+	for _, reservation := range reservations {
+		require.NoError(t, engine.SetReservation(ctx, reservation))
+	}
+
+	got, err := engine.LookupReservation(ctx, "cs001", 2)
+	require.NoError(t, err)
+	assert.Equal(t, reservations[0], got)
+
+	got, err = engine.LookupReservation(ctx, "cs001", 3)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = engine.LookupReservationByReference(ctx, "RES001")
+	require.NoError(t, err)
+	assert.Equal(t, reservations[0], got)
+
+	got, err = engine.LookupReservationByReference(ctx, "RES002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	list, err := engine.ListReservations(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.Reservation{reservations[1], reservations[0]}, list)
+
+	expired, err := engine.ListExpiredReservations(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.Reservation{reservations[0]}, expired)
+}
+
+func TestOcspResponse(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	response := &store.OcspResponse{
+		Key:       "abc123",
+		Response:  "MIIB",
+		FetchedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	require.NoError(t, engine.SetOcspResponse(ctx, response))
+
+	got, err := engine.LookupOcspResponse(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, response, got)
+
+	got, err = engine.LookupOcspResponse(ctx, "def456")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestRevokedCertificates(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	certificate := &store.RevokedCertificate{
+		CertificateHash: "abc123",
+		ChargeStationId: "cs001",
+		SerialNumber:    "1234",
+		Issuer:          "CN=CA",
+		Reason:          "keyCompromise",
+		RevokedAt:       now,
+	}
+	require.NoError(t, engine.SetRevokedCertificate(ctx, certificate))
+	require.NoError(t, engine.SetRevokedCertificate(ctx, &store.RevokedCertificate{CertificateHash: "def456", RevokedAt: now}))
+
+	got, err := engine.LookupRevokedCertificate(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, certificate, got)
+
+	got, err = engine.LookupRevokedCertificate(ctx, "ghi789")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	all, err := engine.ListRevokedCertificates(ctx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "abc123", all[0].CertificateHash)
+
+	all, err = engine.ListRevokedCertificates(ctx, 1, "abc123")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "def456", all[0].CertificateHash)
+}
+
+func TestChargeStationSecurityProfileUpgrades(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	for _, csId := range []string{"cs003", "cs001", "cs002"} {
+		err := engine.SetChargeStationSecurityProfileUpgrade(ctx, csId, &store.ChargeStationSecurityProfileUpgrade{
+			FromSecurityProfile: store.TLSWithBasicAuth,
+			ToSecurityProfile:   store.TLSWithClientSideCertificates,
+			Step:                store.SecurityProfileUpgradeStepSignCertificate,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationSecurityProfileUpgrade{
+		ChargeStationId:     "cs001",
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepSignCertificate,
+	}, got)
+
+	// the stored upgrade is not changed by changing a value that has been looked up
+	got.Step = store.SecurityProfileUpgradeStepFailed
+	got, err = engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepSignCertificate, got.Step)
+
+	got, err = engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs004")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	list, err := engine.ListChargeStationSecurityProfileUpgrades(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "cs001", list[0].ChargeStationId)
+	assert.Equal(t, "cs002", list[1].ChargeStationId)
+
+	list, err = engine.ListChargeStationSecurityProfileUpgrades(ctx, 2, "cs002")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "cs003", list[0].ChargeStationId)
+}
+
+func TestChargeStationConnections(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+
+	connectedAt := time.Now().UTC()
+	for _, csId := range []string{"cs003", "cs001", "cs002"} {
+		err := engine.SetChargeStationConnection(ctx, csId, &store.ChargeStationConnection{
+			ConnectionId:    "conn-" + csId,
+			GatewayId:       "gateway-1",
+			RemoteAddress:   "10.0.0.1:4321",
+			Subprotocol:     "ocpp2.0.1",
+			SecurityProfile: store.TLSWithBasicAuth,
+			ConnectedAt:     connectedAt,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.LookupChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationConnection{
+		ChargeStationId: "cs001",
+		ConnectionId:    "conn-cs001",
+		GatewayId:       "gateway-1",
+		RemoteAddress:   "10.0.0.1:4321",
+		Subprotocol:     "ocpp2.0.1",
+		SecurityProfile: store.TLSWithBasicAuth,
+		ConnectedAt:     connectedAt,
+	}, got)
+
+	list, err := engine.ListChargeStationConnections(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "cs001", list[0].ChargeStationId)
+	assert.Equal(t, "cs002", list[1].ChargeStationId)
+
+	list, err = engine.ListChargeStationConnections(ctx, 2, "cs002")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "cs003", list[0].ChargeStationId)
+
+	err = engine.DeleteChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+
+	got, err = engine.LookupChargeStationConnection(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// IssuedCertificate is a certificate issued to a charge station for a certificate type: either
+// CertificateTypeChargeStation or CertificateTypeEVCC (the V2G certificate)
+type IssuedCertificate struct {
+	CertificateType CertificateType
+	CertificateId   string
+	SerialNumber    string
+	NotAfter        time.Time
+	IssuedAt        time.Time
+	// RenewalRequestedAt is set when the charge station has been asked to renew the certificate
+	RenewalRequestedAt *time.Time
+}
+
+// ChargeStationIssuedCertificates are the certificates issued to a charge station, recorded so
+// that they can be renewed before they expire and revoked if the charge station is compromised
+type ChargeStationIssuedCertificates struct {
+	ChargeStationId string
+	// Certificates are the most recent certificates issued to the charge station by type
+	Certificates map[CertificateType]*IssuedCertificate
+	// Superseded are the earlier certificates that have not yet expired: they are still valid
+	// so must be revoked along with the current certificates
+	Superseded []*IssuedCertificate
+	UpdatedAt  time.Time
+}
+
+type IssuedCertificateStore interface {
+	SetChargeStationIssuedCertificates(ctx context.Context, csId string, certificates *ChargeStationIssuedCertificates) error
+	LookupChargeStationIssuedCertificates(ctx context.Context, csId string) (*ChargeStationIssuedCertificates, error)
+	ListChargeStationIssuedCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationIssuedCertificates, error)
+}
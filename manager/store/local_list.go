@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+type LocalListStatus string
+
+var (
+	// LocalListStatusPending is used when an update has been sent to the charge station
+	LocalListStatusPending         LocalListStatus = "Pending"
+	LocalListStatusAccepted        LocalListStatus = "Accepted"
+	LocalListStatusFailed          LocalListStatus = "Failed"
+	LocalListStatusVersionMismatch LocalListStatus = "VersionMismatch"
+	LocalListStatusNotSupported    LocalListStatus = "NotSupported"
+	// LocalListStatusErrored is used when the charge station responds with a CallError
+	LocalListStatusErrored LocalListStatus = "Errored"
+	// LocalListStatusVersionUnknown is used when the charge station has rebooted and the version
+	// of the list that it holds must be checked
+	LocalListStatusVersionUnknown LocalListStatus = "VersionUnknown"
+	// LocalListStatusVersionCheckPending is used when the version of the list has been requested
+	// from the charge station
+	LocalListStatusVersionCheckPending LocalListStatus = "VersionCheckPending"
+)
+
+// LocalListEntry is an entry in a charge station's local authorization list
+type LocalListEntry struct {
+	IdToken string
+	// IdTokenType is the OCPP 2.0.1 IdTokenEnumType, e.g. ISO14443: it is not used for OCPP 1.6
+	IdTokenType string
+	Status      string // the OCPP authorization status, e.g. Accepted or Invalid
+	GroupId     *string
+}
+
+// ChargeStationLocalList is the local authorization list managed for a charge station. The list
+// contains the RFID tokens (optionally restricted to a token group) that can be cached by the
+// charge station. Version and Entries record the list held by the charge station: they are only
+// updated when the charge station accepts an update.
+type ChargeStationLocalList struct {
+	ChargeStationId  string
+	GroupId          *string
+	Version          int
+	Entries          map[string]*LocalListEntry
+	Status           LocalListStatus
+	ErrorCode        string
+	ErrorDescription string
+	SendAfter        time.Time
+	UpdatedAt        time.Time
+}
+
+type LocalListStore interface {
+	SetChargeStationLocalList(ctx context.Context, csId string, localList *ChargeStationLocalList) error
+	LookupChargeStationLocalList(ctx context.Context, csId string) (*ChargeStationLocalList, error)
+	ListChargeStationLocalLists(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationLocalList, error)
+}
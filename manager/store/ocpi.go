@@ -23,6 +23,8 @@ type OcpiParty struct {
 	Role        string
 	Url         string
 	Token       string
+	// Version is the OCPI version negotiated with the party, e.g. "2.2" or "2.1.1"
+	Version string
 }
 
 type OcpiStore interface {
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// OcspResponse is a cached response from an OCSP responder. The key identifies
+// the certificate that the response is for, the response is the base64 encoded
+// DER response as it was received from the responder.
+type OcspResponse struct {
+	Key       string
+	Response  string
+	FetchedAt time.Time
+	// ExpiresAt is the time after which the response must be fetched again: the
+	// earlier of the response's nextUpdate and the configured cache TTL
+	ExpiresAt time.Time
+}
+
+type OcspResponseStore interface {
+	SetOcspResponse(ctx context.Context, response *OcspResponse) error
+	LookupOcspResponse(ctx context.Context, key string) (*OcspResponse, error)
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+type ChargeStationOperationStatus string
+
+var (
+	ChargeStationOperationStatusPending ChargeStationOperationStatus = "Pending"
+	// ChargeStationOperationStatusErrored is used when the charge station responds with a CallError
+	ChargeStationOperationStatusErrored ChargeStationOperationStatus = "Errored"
+)
+
+// ChargeStationOperation records the most recent request for a remote operation, identified by
+// the OCPP action (e.g. Reset), that has been sent to a charge station. The status is Pending
+// until the charge station responds: it is then set to the status returned by the charge station
+// (e.g. Accepted, Rejected or Scheduled) or to Errored.
+type ChargeStationOperation struct {
+	ChargeStationId  string
+	Operation        string
+	Request          string // the JSON encoded OCPP request
+	Response         string // the JSON encoded OCPP response (once received)
+	Status           ChargeStationOperationStatus
+	ErrorCode        string
+	ErrorDescription string
+	RequestedAt      time.Time
+	UpdatedAt        time.Time
+}
+
+type ChargeStationOperationStore interface {
+	SetChargeStationOperation(ctx context.Context, csId string, operation *ChargeStationOperation) error
+	LookupChargeStationOperation(ctx context.Context, csId, operation string) (*ChargeStationOperation, error)
+	// ListChargeStationOperations returns the most recent request for each operation ordered by operation
+	ListChargeStationOperations(ctx context.Context, csId string) ([]*ChargeStationOperation, error)
+}
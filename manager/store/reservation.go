@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+type ReservationStatus string
+
+var (
+	// ReservationStatusPending is used until the charge station responds to the ReserveNow request
+	ReservationStatusPending  ReservationStatus = "Pending"
+	ReservationStatusAccepted ReservationStatus = "Accepted"
+	// ReservationStatusFaulted, ReservationStatusOccupied, ReservationStatusRejected and
+	// ReservationStatusUnavailable are returned by the charge station when it will not make the reservation
+	ReservationStatusFaulted     ReservationStatus = "Faulted"
+	ReservationStatusOccupied    ReservationStatus = "Occupied"
+	ReservationStatusRejected    ReservationStatus = "Rejected"
+	ReservationStatusUnavailable ReservationStatus = "Unavailable"
+	// ReservationStatusErrored is used when the charge station responds with a CallError
+	ReservationStatusErrored ReservationStatus = "Errored"
+	// ReservationStatusCancelled is used when the charge station accepts a CancelReservation request
+	ReservationStatusCancelled ReservationStatus = "Cancelled"
+	// ReservationStatusExpired is used when the reservation expires without being used
+	ReservationStatusExpired ReservationStatus = "Expired"
+	// ReservationStatusRemoved is used when the charge station reports that it removed the reservation
+	ReservationStatusRemoved ReservationStatus = "Removed"
+	// ReservationStatusUsed is used when a transaction is started using the reservation
+	ReservationStatusUsed ReservationStatus = "Used"
+)
+
+// IsActive reports whether the reservation may still be used to start a transaction
+func (s ReservationStatus) IsActive() bool {
+	return s == ReservationStatusPending || s == ReservationStatusAccepted
+}
+
+// Reservation is a reservation of an EVSE (or any EVSE if EvseId is nil) at a charge station
+// for a token. The reservation id is unique for the charge station. The reference is an
+// optional identifier supplied by another system (e.g. the OCPI reservation id).
+type Reservation struct {
+	ChargeStationId  string
+	ReservationId    int
+	Reference        *string
+	EvseId           *int
+	IdToken          string
+	TokenType        string
+	GroupId          *string
+	ExpiryDate       time.Time
+	Status           ReservationStatus
+	ErrorCode        string
+	ErrorDescription string
+	TransactionId    *string // the transaction that used the reservation
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type ReservationStore interface {
+	SetReservation(ctx context.Context, reservation *Reservation) error
+	LookupReservation(ctx context.Context, chargeStationId string, reservationId int) (*Reservation, error)
+	LookupReservationByReference(ctx context.Context, reference string) (*Reservation, error)
+	// ListReservations returns the reservations for the charge station ordered by reservation id
+	ListReservations(ctx context.Context, chargeStationId string) ([]*Reservation, error)
+	// ListExpiredReservations returns up to pageSize reservations that are still active but have
+	// an expiry date before the given time
+	ListExpiredReservations(ctx context.Context, before time.Time, pageSize int) ([]*Reservation, error)
+}
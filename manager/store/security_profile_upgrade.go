@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+type SecurityProfileUpgradeStep string
+
+var (
+	SecurityProfileUpgradeStepInstallRootCertificate          SecurityProfileUpgradeStep = "InstallRootCertificate"
+	SecurityProfileUpgradeStepSetBasicAuthPassword            SecurityProfileUpgradeStep = "SetBasicAuthPassword"
+	SecurityProfileUpgradeStepSignCertificate                 SecurityProfileUpgradeStep = "SignCertificate"
+	SecurityProfileUpgradeStepSetNetworkProfile               SecurityProfileUpgradeStep = "SetNetworkProfile"
+	SecurityProfileUpgradeStepSetNetworkConfigurationPriority SecurityProfileUpgradeStep = "SetNetworkConfigurationPriority"
+	SecurityProfileUpgradeStepAwaitReconnect                  SecurityProfileUpgradeStep = "AwaitReconnect"
+	SecurityProfileUpgradeStepCompleted                       SecurityProfileUpgradeStep = "Completed"
+	SecurityProfileUpgradeStepFailed                          SecurityProfileUpgradeStep = "Failed"
+)
+
+// ChargeStationSecurityProfileUpgrade records the progress of moving a charge station to a
+// higher security profile. The steps are performed in order (skipping those that are not required
+// for the upgrade) and the charge station's security profile is only changed once the charge
+// station has reconnected using the new security profile.
+type ChargeStationSecurityProfileUpgrade struct {
+	ChargeStationId     string
+	FromSecurityProfile SecurityProfile
+	ToSecurityProfile   SecurityProfile
+	Step                SecurityProfileUpgradeStep
+	// CsmsRootCertificate is the PEM encoded certificate that is installed when upgrading from
+	// unsecured transport
+	CsmsRootCertificate string
+	// Base64SHA256Password is the hash of the new basic auth password: it is set once the password
+	// has been generated and queued for the charge station (the password itself is not kept)
+	Base64SHA256Password string
+	// ClientCertificateHash is the charge station's client certificate hash when the upgrade
+	// was requested: the certificate has been signed once the hash changes
+	ClientCertificateHash string
+	// OcppCsmsUrl, ConfigurationSlot, OcppInterface and MessageTimeout make up the network
+	// connection profile that is sent to OCPP 2.0.1 charge stations
+	OcppCsmsUrl       string
+	ConfigurationSlot int
+	OcppInterface     string
+	MessageTimeout    int
+	SendAfter         time.Time
+	ErrorDescription  string
+	RequestedAt       time.Time
+	UpdatedAt         time.Time
+}
+
+type ChargeStationSecurityProfileUpgradeStore interface {
+	SetChargeStationSecurityProfileUpgrade(ctx context.Context, csId string, upgrade *ChargeStationSecurityProfileUpgrade) error
+	LookupChargeStationSecurityProfileUpgrade(ctx context.Context, csId string) (*ChargeStationSecurityProfileUpgrade, error)
+	ListChargeStationSecurityProfileUpgrades(ctx context.Context, pageSize int, previousCsId string) ([]*ChargeStationSecurityProfileUpgrade, error)
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// SyncCertificateAudit checks that each charge station has the expected root certificates installed.
+// The certificates installed on each charge station are requested with GetInstalledCertificateIds if
+// they have not been reported since the previous audit. Any expected root certificates that the charge
+// station has not reported are recorded as missing in the charge station's certificate inventory.
+func SyncCertificateAudit(ctx context.Context, engine store.Engine, clock clock.PassiveClock, dataTransferCallMaker, v201CallMaker handlers.CallMaker, expectedRootCertificates map[store.CertificateType]services.RootCertificateProviderService, runEvery time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync certificate audit")
+			return
+		case <-time.After(runEvery):
+			slog.Info("auditing charge station certificates")
+			expected, err := getExpectedRootCertificates(ctx, expectedRootCertificates)
+			if err != nil {
+				slog.Error("get expected root certificates", slog.String("err", err.Error()))
+				continue
+			}
+			err = forEachChargeStation(ctx, engine, func(csId string) {
+				err := auditChargeStationCertificates(ctx, engine, clock, dataTransferCallMaker, v201CallMaker, csId, expected, runEvery)
+				if err != nil {
+					slog.Error("audit charge station certificates", slog.String("err", err.Error()),
+						slog.String("chargeStationId", csId))
+				}
+			})
+			if err != nil {
+				slog.Error("list charge stations", slog.String("err", err.Error()))
+			}
+		}
+	}
+}
+
+func getExpectedRootCertificates(ctx context.Context, providers map[store.CertificateType]services.RootCertificateProviderService) ([]*store.InventoryCertificate, error) {
+	var expected []*store.InventoryCertificate
+	certificateTypes := maps.Keys(providers)
+	slices.Sort(certificateTypes)
+	for _, certificateType := range certificateTypes {
+		certs, err := providers[certificateType].ProvideCertificates(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("provide %s root certificates: %w", certificateType, err)
+		}
+		for _, cert := range certs {
+			certificate, err := handlers.NewInventoryCertificate(certificateType, cert)
+			if err != nil {
+				return nil, fmt.Errorf("%s root certificate %s: %w", certificateType, cert.Subject, err)
+			}
+			certificate.Subject = cert.Subject.String()
+			expected = append(expected, certificate)
+		}
+	}
+	return expected, nil
+}
+
+func forEachChargeStation(ctx context.Context, engine store.Engine, fn func(csId string)) error {
+	const pageSize = 50
+	for offset := 0; ; offset += pageSize {
+		chargeStations, err := engine.ListChargeStations(ctx, offset, pageSize)
+		if err != nil {
+			return err
+		}
+		for _, cs := range chargeStations {
+			fn(cs.Id)
+		}
+		if len(chargeStations) < pageSize {
+			return nil
+		}
+	}
+}
+
+func auditChargeStationCertificates(ctx context.Context, engine store.Engine, clock clock.PassiveClock, dataTransferCallMaker, v201CallMaker handlers.CallMaker, csId string, expected []*store.InventoryCertificate, runEvery time.Duration) error {
+	details, err := engine.LookupChargeStationRuntimeDetails(ctx, csId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station runtime details: %w", err)
+	}
+	if details == nil {
+		// the charge station has never connected
+		return nil
+	}
+
+	inventory, err := engine.LookupChargeStationCertificateInventory(ctx, csId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station certificate inventory: %w", err)
+	}
+	if inventory == nil {
+		inventory = &store.ChargeStationCertificateInventory{}
+	}
+
+	now := clock.Now()
+	if inventory.ReportedAt != nil {
+		var missing []*store.InventoryCertificate
+		for _, want := range expected {
+			if !slices.ContainsFunc(inventory.Certificates, func(got *store.InventoryCertificate) bool {
+				return got.CertificateType == want.CertificateType && got.Matches(want)
+			}) {
+				missing = append(missing, want)
+			}
+		}
+		if len(missing) > 0 {
+			slog.Warn("charge station is missing root certificates", slog.String("chargeStationId", csId),
+				slog.Int("missing", len(missing)))
+		}
+		inventory.MissingCertificates = missing
+		inventory.AuditedAt = &now
+	}
+
+	var req ocpp.Request
+	if inventory.ReportedAt == nil || now.Sub(*inventory.ReportedAt) >= runEvery {
+		if inventory.RequestedAt == nil || now.Sub(*inventory.RequestedAt) >= runEvery {
+			req = &ocpp201.GetInstalledCertificateIdsRequestJson{}
+			inventory.RequestedAt = &now
+		}
+	}
+
+	inventory.UpdatedAt = now
+	err = engine.SetChargeStationCertificateInventory(ctx, csId, inventory)
+	if err != nil {
+		return fmt.Errorf("set charge station certificate inventory: %w", err)
+	}
+
+	if req != nil {
+		slog.Info("requesting installed certificates", slog.String("chargeStationId", csId))
+		if details.OcppVersion == store.OcppVersion16 {
+			err = dataTransferCallMaker.Send(ctx, csId, req)
+		} else {
+			err = v201CallMaker.Send(ctx, csId, req)
+		}
+		if err != nil {
+			return fmt.Errorf("send get installed certificate ids request: %w", err)
+		}
+	}
+	return nil
+}
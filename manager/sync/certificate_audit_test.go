@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"k8s.io/utils/clock"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type fixedRootCertificateProvider struct {
+	certs []*x509.Certificate
+}
+
+func (f fixedRootCertificateProvider) ProvideCertificates(context.Context) ([]*x509.Certificate, error) {
+	return f.certs, nil
+}
+
+func newRootCertificate(t *testing.T, commonName string) *x509.Certificate {
+	keyPair, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &keyPair.PublicKey, keyPair)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(derBytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestSyncCertificateAudit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for _, csId := range []string{"cs001", "cs002", "cs003"} {
+		require.NoError(t, engine.CreateChargeStation(ctx, &store.ChargeStation{Id: csId}))
+	}
+	require.NoError(t, engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{OcppVersion: store.OcppVersion201}))
+	require.NoError(t, engine.SetChargeStationRuntimeDetails(ctx, "cs002", &store.ChargeStationRuntimeDetails{OcppVersion: store.OcppVersion16}))
+
+	v2gRoot := newRootCertificate(t, "V2G Root CA")
+	moRoot := newRootCertificate(t, "MO Root CA")
+	expected := map[store.CertificateType]services.RootCertificateProviderService{
+		store.CertificateTypeV2G: fixedRootCertificateProvider{certs: []*x509.Certificate{v2gRoot}},
+		store.CertificateTypeMO:  fixedRootCertificateProvider{certs: []*x509.Certificate{moRoot}},
+	}
+
+	// cs001 only has the V2G root installed
+	installed, err := handlers.NewInventoryCertificate(store.CertificateTypeV2G, v2gRoot)
+	require.NoError(t, err)
+	v201CallMaker := &mockCallMaker{engine: engine, updateFn: func(ctx context.Context, engine store.Engine, chargeStationId string, req ocpp.Request) error {
+		return handlers.ReplaceInventoryCertificates(ctx, engine, clock.RealClock{}, chargeStationId, nil, []*store.InventoryCertificate{installed})
+	}}
+	dataTransferCallMaker := &mockCallMaker{engine: engine}
+
+	sync.SyncCertificateAudit(ctx, engine, clock.RealClock{}, dataTransferCallMaker, v201CallMaker, expected, 100*time.Millisecond)
+
+	require.NotEmpty(t, v201CallMaker.callEvents)
+	for _, event := range v201CallMaker.callEvents {
+		assert.Equal(t, "cs001", event.chargeStationId)
+		assert.Equal(t, &ocpp201.GetInstalledCertificateIdsRequestJson{}, event.request)
+	}
+	require.NotEmpty(t, dataTransferCallMaker.callEvents)
+	assert.Equal(t, "cs002", dataTransferCallMaker.callEvents[0].chargeStationId)
+
+	inventory, err := engine.LookupChargeStationCertificateInventory(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, inventory.AuditedAt)
+	require.Len(t, inventory.MissingCertificates, 1)
+	assert.Equal(t, store.CertificateTypeMO, inventory.MissingCertificates[0].CertificateType)
+	assert.Equal(t, "CN=MO Root CA", inventory.MissingCertificates[0].Subject)
+
+	// cs002 has not reported its certificates so it cannot be audited
+	inventory, err = engine.LookupChargeStationCertificateInventory(ctx, "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, inventory.AuditedAt)
+	assert.NotNil(t, inventory.RequestedAt)
+
+	inventory, err = engine.LookupChargeStationCertificateInventory(ctx, "cs003")
+	require.NoError(t, err)
+	assert.Nil(t, inventory)
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// renewalTriggers are the trigger messages that ask a charge station to renew a certificate of
+// each type that the CSMS issues
+var renewalTriggers = map[store.CertificateType]store.TriggerMessage{
+	store.CertificateTypeChargeStation: store.TriggerMessageSignChargingStationCertificate,
+	store.CertificateTypeEVCC:          store.TriggerMessageSignV2GCertificate,
+}
+
+// SyncCertificateRenewals asks charge stations to renew the certificates that they have been issued
+// once the certificate is within renewalPeriod of its expiry. The renewal is requested by queueing
+// a trigger message which is then sent by SyncTriggers. If the charge station has not renewed the
+// certificate after retryAfter then the renewal is requested again.
+func SyncCertificateRenewals(ctx context.Context, engine store.Engine, clock clock.PassiveClock, renewalPeriod time.Duration, runEvery time.Duration, retryAfter time.Duration) {
+	var previousChargeStationId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync certificate renewals")
+			return
+		case <-time.After(runEvery):
+			slog.Info("checking for certificates that need renewing")
+			issuedCertificates, err := engine.ListChargeStationIssuedCertificates(ctx, 50, previousChargeStationId)
+			if err != nil {
+				slog.Error("list charge station issued certificates", slog.String("err", err.Error()))
+				continue
+			}
+			if len(issuedCertificates) > 0 {
+				previousChargeStationId = issuedCertificates[len(issuedCertificates)-1].ChargeStationId
+			} else {
+				previousChargeStationId = ""
+			}
+			for _, certificates := range issuedCertificates {
+				err = syncCertificateRenewal(ctx, engine, clock, certificates, renewalPeriod, retryAfter)
+				if err != nil {
+					slog.Error("sync certificate renewal", slog.String("err", err.Error()),
+						slog.String("chargeStationId", certificates.ChargeStationId))
+				}
+			}
+		}
+	}
+}
+
+func syncCertificateRenewal(ctx context.Context, engine store.Engine, clock clock.PassiveClock, certificates *store.ChargeStationIssuedCertificates, renewalPeriod time.Duration, retryAfter time.Duration) error {
+	csId := certificates.ChargeStationId
+	now := clock.Now()
+
+	// the charge station certificate is renewed first: only one trigger can be queued at a time
+	var certificate *store.IssuedCertificate
+	for _, certificateType := range []store.CertificateType{store.CertificateTypeChargeStation, store.CertificateTypeEVCC} {
+		cert := certificates.Certificates[certificateType]
+		if cert == nil || now.Before(cert.NotAfter.Add(-renewalPeriod)) {
+			continue
+		}
+		if cert.RenewalRequestedAt != nil && now.Before(cert.RenewalRequestedAt.Add(retryAfter)) {
+			continue
+		}
+		certificate = cert
+		break
+	}
+	if certificate == nil {
+		return nil
+	}
+
+	// a security profile upgrade uses the trigger message to have the charge station sign a new
+	// certificate and reads the charge station's response to it: the trigger is left to the upgrade
+	upgrade, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, csId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station security profile upgrade: %w", err)
+	}
+	if upgrade != nil && upgrade.Step != store.SecurityProfileUpgradeStepCompleted && upgrade.Step != store.SecurityProfileUpgradeStepFailed {
+		return nil
+	}
+
+	trigger, err := engine.LookupChargeStationTriggerMessage(ctx, csId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station trigger message: %w", err)
+	}
+	if trigger != nil && trigger.TriggerStatus == store.TriggerStatusPending {
+		// wait for the pending trigger to be sent rather than replacing it
+		return nil
+	}
+
+	slog.Info("requesting certificate renewal", slog.String("chargeStationId", csId),
+		slog.String("certificateType", string(certificate.CertificateType)),
+		slog.String("notAfter", certificate.NotAfter.Format(time.RFC3339)))
+	err = engine.SetChargeStationTriggerMessage(ctx, csId, &store.ChargeStationTriggerMessage{
+		TriggerMessage: renewalTriggers[certificate.CertificateType],
+		TriggerStatus:  store.TriggerStatusPending,
+		SendAfter:      now,
+	})
+	if err != nil {
+		return fmt.Errorf("set charge station trigger message: %w", err)
+	}
+
+	certificate.RenewalRequestedAt = &now
+	certificates.UpdatedAt = now
+	err = engine.SetChargeStationIssuedCertificates(ctx, csId, certificates)
+	if err != nil {
+		return fmt.Errorf("set charge station issued certificates: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSyncCertificateRenewals(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	now := time.Now().UTC()
+	for csId, notAfter := range map[string]time.Time{
+		"cs001": now.Add(10 * 24 * time.Hour),
+		"cs002": now.Add(60 * 24 * time.Hour),
+	} {
+		err := engine.SetChargeStationIssuedCertificates(ctx, csId, &store.ChargeStationIssuedCertificates{
+			Certificates: map[store.CertificateType]*store.IssuedCertificate{
+				store.CertificateTypeChargeStation: {
+					CertificateType: store.CertificateTypeChargeStation,
+					CertificateId:   "abc123",
+					NotAfter:        notAfter,
+				},
+				store.CertificateTypeEVCC: {
+					CertificateType: store.CertificateTypeEVCC,
+					CertificateId:   "def456",
+					NotAfter:        notAfter,
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	sync.SyncCertificateRenewals(ctx, engine, clock.RealClock{}, 30*24*time.Hour, 100*time.Millisecond, time.Hour)
+
+	// only the charge station certificate renewal is queued as the trigger is still pending
+	trigger, err := engine.LookupChargeStationTriggerMessage(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, trigger)
+	assert.Equal(t, store.TriggerMessageSignChargingStationCertificate, trigger.TriggerMessage)
+	assert.Equal(t, store.TriggerStatusPending, trigger.TriggerStatus)
+	assert.False(t, trigger.SendAfter.IsZero())
+
+	issued, err := engine.LookupChargeStationIssuedCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	assert.NotNil(t, issued.Certificates[store.CertificateTypeChargeStation].RenewalRequestedAt)
+	assert.Nil(t, issued.Certificates[store.CertificateTypeEVCC].RenewalRequestedAt)
+
+	trigger, err = engine.LookupChargeStationTriggerMessage(ctx, "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, trigger)
+}
+
+func TestSyncCertificateRenewalsQueuesV2GRenewalAfterChargeStationRenewal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	now := time.Now().UTC()
+	err := engine.SetChargeStationIssuedCertificates(ctx, "cs001", &store.ChargeStationIssuedCertificates{
+		Certificates: map[store.CertificateType]*store.IssuedCertificate{
+			store.CertificateTypeChargeStation: {
+				CertificateType:    store.CertificateTypeChargeStation,
+				NotAfter:           now.Add(24 * time.Hour),
+				RenewalRequestedAt: &now,
+			},
+			store.CertificateTypeEVCC: {
+				CertificateType: store.CertificateTypeEVCC,
+				NotAfter:        now.Add(24 * time.Hour),
+			},
+		},
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationTriggerMessage(ctx, "cs001", &store.ChargeStationTriggerMessage{
+		TriggerMessage: store.TriggerMessageSignChargingStationCertificate,
+		TriggerStatus:  store.TriggerStatusAccepted,
+	})
+	require.NoError(t, err)
+
+	sync.SyncCertificateRenewals(ctx, engine, clock.RealClock{}, 30*24*time.Hour, 100*time.Millisecond, time.Hour)
+
+	trigger, err := engine.LookupChargeStationTriggerMessage(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.TriggerMessageSignV2GCertificate, trigger.TriggerMessage)
+	assert.Equal(t, store.TriggerStatusPending, trigger.TriggerStatus)
+}
+
+func TestSyncCertificateRenewalsSkipsChargeStationWithSecurityProfileUpgradeInProgress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	now := time.Now().UTC()
+	err := engine.SetChargeStationIssuedCertificates(ctx, "cs001", &store.ChargeStationIssuedCertificates{
+		Certificates: map[store.CertificateType]*store.IssuedCertificate{
+			store.CertificateTypeChargeStation: {
+				CertificateType: store.CertificateTypeChargeStation,
+				NotAfter:        now.Add(24 * time.Hour),
+			},
+		},
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", &store.ChargeStationSecurityProfileUpgrade{
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepSignCertificate,
+	})
+	require.NoError(t, err)
+	// the charge station rejected the trigger sent for the upgrade
+	err = engine.SetChargeStationTriggerMessage(ctx, "cs001", &store.ChargeStationTriggerMessage{
+		TriggerMessage: store.TriggerMessageSignChargingStationCertificate,
+		TriggerStatus:  store.TriggerStatusRejected,
+	})
+	require.NoError(t, err)
+
+	sync.SyncCertificateRenewals(ctx, engine, clock.RealClock{}, 30*24*time.Hour, 100*time.Millisecond, time.Hour)
+
+	trigger, err := engine.LookupChargeStationTriggerMessage(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.TriggerStatusRejected, trigger.TriggerStatus)
+
+	issued, err := engine.LookupChargeStationIssuedCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, issued.Certificates[store.CertificateTypeChargeStation].RenewalRequestedAt)
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// SyncDisplayMessages keeps the display messages held by OCPP 2.0.1 charge stations in step with
+// the managed display messages. Pending messages are sent with SetDisplayMessage and messages that
+// must be cleared are sent with ClearDisplayMessage. After a charge station boots the messages that
+// it holds are requested with GetDisplayMessages before anything else is sent. Messages that have
+// passed their end date are forgotten: the charge station removes them itself.
+func SyncDisplayMessages(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v201CallMaker handlers.CallMaker, runEvery time.Duration, retryAfter time.Duration) {
+	var previousChargeStationId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync display messages")
+			return
+		case <-time.After(runEvery):
+			slog.Info("checking for charge station display message updates")
+			displayMessages, err := engine.ListChargeStationDisplayMessages(ctx, 50, previousChargeStationId)
+			if err != nil {
+				slog.Error("list charge station display messages", slog.String("err", err.Error()))
+				continue
+			}
+			if len(displayMessages) > 0 {
+				previousChargeStationId = displayMessages[len(displayMessages)-1].ChargeStationId
+			} else {
+				previousChargeStationId = ""
+			}
+			for _, messages := range displayMessages {
+				err = syncDisplayMessages(ctx, engine, clock, v201CallMaker, messages, retryAfter)
+				if err != nil {
+					slog.Error("sync display messages", slog.String("err", err.Error()),
+						slog.String("chargeStationId", messages.ChargeStationId))
+				}
+			}
+		}
+	}
+}
+
+func syncDisplayMessages(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v201CallMaker handlers.CallMaker, messages *store.ChargeStationDisplayMessages, retryAfter time.Duration) error {
+	csId := messages.ChargeStationId
+	details, err := engine.LookupChargeStationRuntimeDetails(ctx, csId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station runtime details: %w", err)
+	}
+	if details == nil {
+		slog.Warn("no runtime details for charge station", slog.String("chargeStationId", csId))
+		return nil
+	}
+	if details.OcppVersion != store.OcppVersion201 {
+		slog.Warn("display messages are not supported by charge station", slog.String("chargeStationId", csId),
+			slog.String("ocppVersion", string(details.OcppVersion)))
+		return nil
+	}
+
+	now := clock.Now()
+	changed := false
+	for id, message := range messages.Messages {
+		if message.EndDateTime != nil && !message.EndDateTime.After(now) {
+			delete(messages.Messages, id)
+			changed = true
+		}
+	}
+
+	var reqs []ocpp.Request
+	switch {
+	case messages.ReconcileStatus == store.DisplayMessageReconcileStatusRequired ||
+		(messages.ReconcileStatus == store.DisplayMessageReconcileStatusPending && !now.Before(messages.ReconcileAfter)):
+		slog.Info("getting charge station display messages", slog.String("chargeStationId", csId))
+		messages.ReconcileStatus = store.DisplayMessageReconcileStatusPending
+		messages.ReconcileRequestId = int(rand.Int31()) //#nosec G404 - request id does not require secure random number generator
+		messages.ReconcileAfter = now.Add(retryAfter)
+		reqs = append(reqs, &ocpp201.GetDisplayMessagesRequestJson{RequestId: messages.ReconcileRequestId})
+	case messages.ReconcileStatus == store.DisplayMessageReconcileStatusPending:
+		// wait for the charge station to report the messages that it holds
+	default:
+		ids := maps.Keys(messages.Messages)
+		sort.Ints(ids)
+		for _, id := range ids {
+			message := messages.Messages[id]
+			if now.Before(message.SendAfter) {
+				continue
+			}
+			switch message.Status {
+			case store.DisplayMessageStatusPending:
+				slog.Info("setting charge station display message", slog.String("chargeStationId", csId), slog.Int("id", id))
+				reqs = append(reqs, &ocpp201.SetDisplayMessageRequestJson{Message: newMessageInfo(message)})
+			case store.DisplayMessageStatusClearPending:
+				slog.Info("clearing charge station display message", slog.String("chargeStationId", csId), slog.Int("id", id))
+				reqs = append(reqs, &ocpp201.ClearDisplayMessageRequestJson{Id: id})
+			default:
+				continue
+			}
+			message.SendAfter = now.Add(retryAfter)
+		}
+	}
+
+	if len(reqs) == 0 && !changed {
+		return nil
+	}
+
+	messages.UpdatedAt = now
+	err = engine.SetChargeStationDisplayMessages(ctx, csId, messages)
+	if err != nil {
+		return fmt.Errorf("set charge station display messages: %w", err)
+	}
+
+	for _, req := range reqs {
+		err = v201CallMaker.Send(ctx, csId, req)
+		if err != nil {
+			return fmt.Errorf("send display message request: %w", err)
+		}
+	}
+	return nil
+}
+
+func newMessageInfo(message *store.DisplayMessage) ocpp201.MessageInfoType {
+	info := ocpp201.MessageInfoType{
+		Id:            message.Id,
+		Priority:      ocpp201.MessagePriorityEnumType(message.Priority),
+		TransactionId: message.TransactionId,
+		Message: ocpp201.MessageContentType{
+			Content:  message.Content,
+			Format:   ocpp201.MessageFormatEnumType(message.Format),
+			Language: message.Language,
+		},
+	}
+	if message.State != nil {
+		state := ocpp201.MessageStateEnumType(*message.State)
+		info.State = &state
+	}
+	if message.StartDateTime != nil {
+		startDateTime := message.StartDateTime.UTC().Format(time.RFC3339)
+		info.StartDateTime = &startDateTime
+	}
+	if message.EndDateTime != nil {
+		endDateTime := message.EndDateTime.UTC().Format(time.RFC3339)
+		info.EndDateTime = &endDateTime
+	}
+	return info
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+// acceptV201DisplayMessageRequests responds to the display message requests as a charge station
+// that accepts every message would
+func acceptV201DisplayMessageRequests(ctx context.Context, engine store.Engine, chargeStationId string, request ocpp.Request) error {
+	messages, err := engine.LookupChargeStationDisplayMessages(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	switch req := request.(type) {
+	case *ocpp201.SetDisplayMessageRequestJson:
+		messages.Messages[req.Message.Id].Status = store.DisplayMessageStatusAccepted
+	case *ocpp201.ClearDisplayMessageRequestJson:
+		delete(messages.Messages, req.Id)
+	default:
+		return nil
+	}
+	return engine.SetChargeStationDisplayMessages(ctx, chargeStationId, messages)
+}
+
+func TestSyncDisplayMessages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	state := "Idle"
+	endDateTime := time.Now().Add(1 * time.Hour).UTC().Truncate(time.Second)
+	expired := time.Now().Add(-1 * time.Minute)
+	err = engine.SetChargeStationDisplayMessages(ctx, "cs001", &store.ChargeStationDisplayMessages{
+		Messages: map[int]*store.DisplayMessage{
+			1: {Id: 1, Priority: "NormalCycle", State: &state, EndDateTime: &endDateTime, Format: "UTF8", Content: "Welcome", Status: store.DisplayMessageStatusPending},
+			2: {Id: 2, Priority: "InFront", Format: "ASCII", Content: "Old news", Status: store.DisplayMessageStatusClearPending},
+			3: {Id: 3, Priority: "InFront", Format: "ASCII", Content: "Shown", Status: store.DisplayMessageStatusAccepted},
+			4: {Id: 4, Priority: "InFront", EndDateTime: &expired, Format: "ASCII", Content: "Expired", Status: store.DisplayMessageStatusPending},
+		},
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{engine: engine, updateFn: acceptV201DisplayMessageRequests}
+	sync.SyncDisplayMessages(ctx, engine, clock.RealClock{}, v201CallMaker, 100*time.Millisecond, 500*time.Millisecond)
+
+	require.Len(t, v201CallMaker.callEvents, 2)
+	idle := ocpp201.MessageStateEnumTypeIdle
+	formattedEndDateTime := endDateTime.Format(time.RFC3339)
+	assert.Equal(t, &ocpp201.SetDisplayMessageRequestJson{
+		Message: ocpp201.MessageInfoType{
+			Id:          1,
+			Priority:    ocpp201.MessagePriorityEnumTypeNormalCycle,
+			State:       &idle,
+			EndDateTime: &formattedEndDateTime,
+			Message: ocpp201.MessageContentType{
+				Content: "Welcome",
+				Format:  ocpp201.MessageFormatEnumTypeUTF8,
+			},
+		},
+	}, v201CallMaker.callEvents[0].request)
+	assert.Equal(t, &ocpp201.ClearDisplayMessageRequestJson{Id: 2}, v201CallMaker.callEvents[1].request)
+
+	messages, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	require.Len(t, messages.Messages, 2)
+	assert.Equal(t, store.DisplayMessageStatusAccepted, messages.Messages[1].Status)
+	assert.Equal(t, store.DisplayMessageStatusAccepted, messages.Messages[3].Status)
+}
+
+func TestSyncDisplayMessagesReconcilesBeforeSending(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationDisplayMessages(ctx, "cs001", &store.ChargeStationDisplayMessages{
+		Messages: map[int]*store.DisplayMessage{
+			1: {Id: 1, Priority: "NormalCycle", Format: "UTF8", Content: "Welcome", Status: store.DisplayMessageStatusPending},
+		},
+		ReconcileStatus: store.DisplayMessageReconcileStatusRequired,
+	})
+	require.NoError(t, err)
+
+	// the charge station does not report its messages so they are requested again
+	v201CallMaker := &mockCallMaker{engine: engine}
+	sync.SyncDisplayMessages(ctx, engine, clock.RealClock{}, v201CallMaker, 100*time.Millisecond, 500*time.Millisecond)
+
+	require.Len(t, v201CallMaker.callEvents, 2)
+	for _, event := range v201CallMaker.callEvents {
+		assert.IsType(t, &ocpp201.GetDisplayMessagesRequestJson{}, event.request)
+	}
+
+	messages, err := engine.LookupChargeStationDisplayMessages(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.DisplayMessageReconcileStatusPending, messages.ReconcileStatus)
+	assert.Equal(t, v201CallMaker.callEvents[1].request.(*ocpp201.GetDisplayMessagesRequestJson).RequestId, messages.ReconcileRequestId)
+	assert.Equal(t, store.DisplayMessageStatusPending, messages.Messages[1].Status)
+}
+
+func TestSyncDisplayMessagesIgnoresV16ChargeStations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationDisplayMessages(ctx, "cs001", &store.ChargeStationDisplayMessages{
+		Messages: map[int]*store.DisplayMessage{
+			1: {Id: 1, Priority: "NormalCycle", Format: "UTF8", Content: "Welcome", Status: store.DisplayMessageStatusPending},
+		},
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{engine: engine}
+	sync.SyncDisplayMessages(ctx, engine, clock.RealClock{}, v201CallMaker, 100*time.Millisecond, 500*time.Millisecond)
+
+	assert.Len(t, v201CallMaker.callEvents, 0)
+}
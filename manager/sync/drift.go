@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// SyncSettingsDrift periodically reads back the settings that charge stations have accepted so
+// they can be compared with the values actually in use. The comparison happens when the values
+// are returned by the charge station (see handlers.DetectSettingsDrift) with any settings that
+// no longer match marked as Drifted. If reapply is set, drifted settings are returned to Pending
+// so that they will be sent to the charge station again by SyncSettings.
+func SyncSettingsDrift(ctx context.Context, engine store.Engine, checkEvery time.Duration, reapply bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync settings drift")
+			return
+		case <-time.After(checkEvery):
+			slog.Info("checking charge station settings for drift")
+			var previousChargeStationId string
+			for {
+				settings, err := engine.ListChargeStationSettings(ctx, 50, previousChargeStationId)
+				if err != nil {
+					slog.Error("list charge station settings", slog.String("err", err.Error()))
+					break
+				}
+				for _, csSettings := range settings {
+					checkSettingsDrift(ctx, engine, csSettings, reapply)
+				}
+				if len(settings) < 50 {
+					break
+				}
+				previousChargeStationId = settings[len(settings)-1].ChargeStationId
+			}
+		}
+	}
+}
+
+func checkSettingsDrift(ctx context.Context, engine store.Engine, csSettings *store.ChargeStationSettings, reapply bool) {
+	csId := csSettings.ChargeStationId
+	reapplySettings := make(map[string]*store.ChargeStationSetting)
+	readBackVariables := make(map[string]*store.ChargeStationVariable)
+	for name, setting := range csSettings.Settings {
+		if store.IsWriteOnlySetting(name) {
+			// the value is not kept and cannot be read back
+			continue
+		}
+		switch setting.Status {
+		case store.ChargeStationSettingStatusDrifted:
+			if reapply {
+				reapplySettings[name] = &store.ChargeStationSetting{Value: setting.Value, Status: store.ChargeStationSettingStatusPending}
+			} else {
+				readBackVariables[name] = &store.ChargeStationVariable{Status: store.ChargeStationVariableStatusPending}
+			}
+		case store.ChargeStationSettingStatusAccepted:
+			readBackVariables[name] = &store.ChargeStationVariable{Status: store.ChargeStationVariableStatusPending}
+		}
+	}
+
+	if len(reapplySettings) > 0 {
+		slog.Info("reapplying drifted charge station settings", slog.String("chargeStationId", csId),
+			slog.Int("count", len(reapplySettings)))
+		err := engine.UpdateChargeStationSettings(ctx, csId, &store.ChargeStationSettings{
+			Settings: reapplySettings,
+		})
+		if err != nil {
+			slog.Error("update charge station settings", slog.String("err", err.Error()),
+				slog.String("chargeStationId", csId))
+		}
+	}
+
+	if len(readBackVariables) > 0 {
+		err := engine.UpdateChargeStationVariables(ctx, csId, &store.ChargeStationVariables{
+			Variables: readBackVariables,
+		})
+		if err != nil {
+			slog.Error("update charge station variables", slog.String("err", err.Error()),
+				slog.String("chargeStationId", csId))
+		}
+	}
+}
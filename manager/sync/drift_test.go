@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func setupDriftSettings(t *testing.T, ctx context.Context, engine store.Engine) {
+	err := engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"HeartbeatInterval":        {Value: "60", Status: store.ChargeStationSettingStatusAccepted},
+			"MeterValueSampleInterval": {Value: "30", Status: store.ChargeStationSettingStatusDrifted},
+			"ConnectionTimeOut":        {Value: "90", Status: store.ChargeStationSettingStatusPending},
+			"WebSocketPingInterval":    {Value: "10", Status: store.ChargeStationSettingStatusRejected},
+			"AuthorizationKey":         {Status: store.ChargeStationSettingStatusAccepted},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestSyncSettingsDriftReadsBackAcceptedSettings(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	setupDriftSettings(t, ctx, engine)
+
+	sync.SyncSettingsDrift(ctx, engine, 100*time.Millisecond, false)
+
+	variables, err := engine.LookupChargeStationVariables(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, variables)
+	assert.Equal(t, map[string]*store.ChargeStationVariable{
+		"HeartbeatInterval":        {Status: store.ChargeStationVariableStatusPending},
+		"MeterValueSampleInterval": {Status: store.ChargeStationVariableStatusPending},
+	}, variables.Variables)
+
+	settings, err := engine.LookupChargeStationSettings(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationSettingStatusDrifted, settings.Settings["MeterValueSampleInterval"].Status)
+}
+
+func TestSyncSettingsDriftReappliesDriftedSettings(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	setupDriftSettings(t, ctx, engine)
+
+	sync.SyncSettingsDrift(ctx, engine, 100*time.Millisecond, true)
+
+	settings, err := engine.LookupChargeStationSettings(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationSettingStatusPending, settings.Settings["MeterValueSampleInterval"].Status)
+	assert.Equal(t, "30", settings.Settings["MeterValueSampleInterval"].Value)
+	assert.Equal(t, store.ChargeStationSettingStatusAccepted, settings.Settings["HeartbeatInterval"].Status)
+
+	variables, err := engine.LookupChargeStationVariables(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, variables)
+	assert.Equal(t, map[string]*store.ChargeStationVariable{
+		"HeartbeatInterval": {Status: store.ChargeStationVariableStatusPending},
+	}, variables.Variables)
+}
@@ -0,0 +1,407 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultLocalListItemsPerMessage is the number of entries sent in each SendLocalList request
+// when the charge station has not reported its limit
+const defaultLocalListItemsPerMessage = 20
+
+// The variables that limit the size of the local list: the number of entries in each
+// SendLocalList request and (for OCPP 1.6) the number of entries in the list
+const (
+	ocpp16SendLocalListMaxLengthKey = "SendLocalListMaxLength"
+	ocpp16LocalAuthListMaxLengthKey = "LocalAuthListMaxLength"
+	ocpp201LocalListItemsPerMessage = "LocalAuthListCtrlr/ItemsPerMessage"
+)
+
+// SyncLocalLists keeps the local authorization lists held by charge stations in step with the
+// tokens in the token store. The version of the list held by a charge station is checked after
+// it boots; then differential updates are sent as tokens change (or a full update if the charge
+// station reports a different version). Changes that do not fit in a single SendLocalList
+// request are sent in further differential updates once each update is accepted.
+func SyncLocalLists(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v16CallMaker, v201CallMaker handlers.CallMaker, runEvery time.Duration, retryAfter time.Duration) {
+	var previousChargeStationId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync local lists")
+			return
+		case <-time.After(runEvery):
+			slog.Info("checking for charge station local list updates")
+			localLists, err := engine.ListChargeStationLocalLists(ctx, 50, previousChargeStationId)
+			if err != nil {
+				slog.Error("list charge station local lists", slog.String("err", err.Error()))
+				continue
+			}
+			if len(localLists) > 0 {
+				previousChargeStationId = localLists[len(localLists)-1].ChargeStationId
+			} else {
+				previousChargeStationId = ""
+			}
+			// the tokens are only read once for all the charge stations
+			tokens := &localListTokens{tokenStore: engine}
+			for _, localList := range localLists {
+				if localList.Status == store.LocalListStatusNotSupported || clock.Now().Before(localList.SendAfter) {
+					continue
+				}
+				err = syncLocalList(ctx, engine, clock, v16CallMaker, v201CallMaker, tokens, localList, retryAfter)
+				if err != nil {
+					slog.Error("sync local list", slog.String("err", err.Error()),
+						slog.String("chargeStationId", localList.ChargeStationId))
+				}
+			}
+		}
+	}
+}
+
+func syncLocalList(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v16CallMaker, v201CallMaker handlers.CallMaker, tokens *localListTokens, localList *store.ChargeStationLocalList, retryAfter time.Duration) error {
+	csId := localList.ChargeStationId
+	details, err := engine.LookupChargeStationRuntimeDetails(ctx, csId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station runtime details: %w", err)
+	}
+	if details == nil {
+		slog.Warn("no runtime details for charge station", slog.String("chargeStationId", csId))
+		return nil
+	}
+
+	var callMaker handlers.CallMaker
+	switch details.OcppVersion {
+	case "1.6":
+		callMaker = v16CallMaker
+	case "2.0.1":
+		callMaker = v201CallMaker
+	default:
+		return fmt.Errorf("unsupported ocpp version: %s", details.OcppVersion)
+	}
+
+	limits, err := lookupLocalListLimits(ctx, engine, csId, details.OcppVersion)
+	if err != nil {
+		return err
+	}
+
+	var req ocpp.Request
+	switch localList.Status {
+	case store.LocalListStatusVersionUnknown, store.LocalListStatusVersionCheckPending, store.LocalListStatusPending:
+		// an update that has not been answered may or may not have been applied so the
+		// version held by the charge station is checked before anything else is sent
+		slog.Info("getting charge station local list version", slog.String("chargeStationId", csId))
+		err = limits.readMissing(ctx, engine, csId)
+		if err != nil {
+			return err
+		}
+		localList.Status = store.LocalListStatusVersionCheckPending
+		if details.OcppVersion == "1.6" {
+			req = &ocpp16.GetLocalListVersionJson{}
+		} else {
+			req = &ocpp201.GetLocalListVersionRequestJson{}
+		}
+	default:
+		allTokens, err := tokens.list(ctx)
+		if err != nil {
+			return err
+		}
+		entries := localListEntries(allTokens, localList.GroupId, details.OcppVersion == "1.6")
+		if limits.maxEntries > 0 && len(entries) > limits.maxEntries {
+			slog.Warn("local list is larger than the charge station supports", slog.String("chargeStationId", csId),
+				slog.Int("entries", len(entries)), slog.Int("maxEntries", limits.maxEntries))
+			entries = truncateLocalListEntries(entries, limits.maxEntries)
+		}
+
+		// the full list is sent if the charge station holds a different version
+		full := localList.Status == store.LocalListStatusVersionMismatch
+		var changes []*store.LocalListEntry
+		if full {
+			changes = maps.Values(entries)
+		} else {
+			changes = localListChanges(localList.Entries, entries)
+			if len(changes) == 0 {
+				return nil
+			}
+		}
+		// removals are sent first to make space for the new entries
+		sort.Slice(changes, func(i, j int) bool {
+			if (changes[i].Status == "") != (changes[j].Status == "") {
+				return changes[i].Status == ""
+			}
+			return changes[i].IdToken < changes[j].IdToken
+		})
+		if len(changes) > limits.itemsPerMessage {
+			changes = changes[:limits.itemsPerMessage]
+		}
+
+		version := localList.Version + 1
+		slog.Info("sending charge station local list", slog.String("chargeStationId", csId),
+			slog.Bool("full", full), slog.Int("version", version), slog.Int("entries", len(changes)))
+		localList.Status = store.LocalListStatusPending
+		if details.OcppVersion == "1.6" {
+			req = newOcpp16SendLocalList(full, version, changes)
+		} else {
+			req = newOcpp201SendLocalList(full, version, changes)
+		}
+	}
+
+	localList.SendAfter = clock.Now().Add(retryAfter)
+	localList.UpdatedAt = clock.Now()
+	err = engine.SetChargeStationLocalList(ctx, csId, localList)
+	if err != nil {
+		return fmt.Errorf("set charge station local list: %w", err)
+	}
+
+	err = callMaker.Send(ctx, csId, req)
+	if err != nil {
+		return fmt.Errorf("send local list request: %w", err)
+	}
+	return nil
+}
+
+// localListLimits are the limits reported by a charge station for its local list
+type localListLimits struct {
+	itemsPerMessage int
+	// maxEntries is zero if the number of entries is not limited (or not known)
+	maxEntries int
+	// missing are the names of the variables that have not been read from the charge station
+	missing []string
+}
+
+func lookupLocalListLimits(ctx context.Context, variablesStore store.ChargeStationVariablesStore, csId string, ocppVersion store.OcppVersion) (*localListLimits, error) {
+	variables, err := variablesStore.LookupChargeStationVariables(ctx, csId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station variables: %w", err)
+	}
+	limits := &localListLimits{itemsPerMessage: defaultLocalListItemsPerMessage}
+	intValue := func(name string) int {
+		var variable *store.ChargeStationVariable
+		if variables != nil {
+			variable = variables.Variables[name]
+		}
+		if variable == nil {
+			limits.missing = append(limits.missing, name)
+			return 0
+		}
+		if variable.Status != store.ChargeStationVariableStatusAccepted || variable.Value == nil {
+			return 0
+		}
+		value, err := strconv.Atoi(*variable.Value)
+		if err != nil || value < 0 {
+			slog.Warn("invalid local list limit", slog.String("chargeStationId", csId),
+				slog.String("name", name), slog.String("value", *variable.Value))
+			return 0
+		}
+		return value
+	}
+
+	var itemsPerMessage int
+	if ocppVersion == store.OcppVersion16 {
+		itemsPerMessage = intValue(ocpp16SendLocalListMaxLengthKey)
+		limits.maxEntries = intValue(ocpp16LocalAuthListMaxLengthKey)
+	} else {
+		itemsPerMessage = intValue(ocpp201LocalListItemsPerMessage)
+	}
+	if itemsPerMessage > 0 {
+		limits.itemsPerMessage = itemsPerMessage
+	}
+	return limits, nil
+}
+
+// readMissing requests the limits that have not been read from the charge station: they are
+// read by SyncVariables
+func (l *localListLimits) readMissing(ctx context.Context, variablesStore store.ChargeStationVariablesStore, csId string) error {
+	if len(l.missing) == 0 {
+		return nil
+	}
+	variables := make(map[string]*store.ChargeStationVariable, len(l.missing))
+	for _, name := range l.missing {
+		variables[name] = &store.ChargeStationVariable{Status: store.ChargeStationVariableStatusPending}
+	}
+	err := variablesStore.UpdateChargeStationVariables(ctx, csId, &store.ChargeStationVariables{
+		Variables: variables,
+	})
+	if err != nil {
+		return fmt.Errorf("update charge station variables: %w", err)
+	}
+	return nil
+}
+
+// localListTokens reads the tokens that can be held in a local list the first time they are needed
+type localListTokens struct {
+	tokenStore store.TokenStore
+	tokens     []*store.Token
+	read       bool
+}
+
+// list returns the RFID tokens that can be cached by the charge station: other types of token
+// (e.g. APP_USER) are authorized by the CSMS when they are used
+func (l *localListTokens) list(ctx context.Context) ([]*store.Token, error) {
+	if l.read {
+		return l.tokens, nil
+	}
+	pageSize := 100
+	for offset := 0; ; offset += pageSize {
+		tokens, err := l.tokenStore.ListTokens(ctx, offset, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("list tokens: %w", err)
+		}
+		for _, token := range tokens {
+			if token.Type == "RFID" && token.CacheMode != store.CacheModeNever {
+				l.tokens = append(l.tokens, token)
+			}
+		}
+		if len(tokens) < pageSize {
+			l.read = true
+			return l.tokens, nil
+		}
+	}
+}
+
+// localListEntries returns the entries that should be in the local list, optionally restricted
+// to a single token group
+func localListEntries(tokens []*store.Token, groupId *string, v16 bool) map[string]*store.LocalListEntry {
+	entries := make(map[string]*store.LocalListEntry)
+	for _, token := range tokens {
+		if groupId != nil && (token.GroupId == nil || *token.GroupId != *groupId) {
+			continue
+		}
+		if v16 && len(token.Uid) > ocpp16.MaxIdTagLength {
+			continue
+		}
+		entry := &store.LocalListEntry{
+			IdToken: token.Uid,
+			Status:  "Invalid",
+			GroupId: token.GroupId,
+		}
+		if !v16 {
+			entry.IdTokenType = string(rfidIdTokenType(token.Uid))
+		}
+		if token.Valid {
+			entry.Status = "Accepted"
+		}
+		if v16 && token.GroupId != nil && len(*token.GroupId) > ocpp16.MaxIdTagLength {
+			entry.GroupId = nil
+		}
+		entries[entry.IdToken] = entry
+	}
+	return entries
+}
+
+// rfidIdTokenType returns the type of card with the UID: ISO 15693 cards have an 8 byte UID
+// starting with E0, ISO 14443 cards have a 4, 7 or 10 byte UID
+func rfidIdTokenType(uid string) ocpp201.IdTokenEnumType {
+	if len(uid) == 16 && strings.HasPrefix(strings.ToUpper(uid), "E0") {
+		return ocpp201.IdTokenEnumTypeISO15693
+	}
+	return ocpp201.IdTokenEnumTypeISO14443
+}
+
+// truncateLocalListEntries keeps the first max entries ordered by id token
+func truncateLocalListEntries(entries map[string]*store.LocalListEntry, max int) map[string]*store.LocalListEntry {
+	idTokens := maps.Keys(entries)
+	sort.Strings(idTokens)
+	truncated := make(map[string]*store.LocalListEntry, max)
+	for _, idToken := range idTokens[:max] {
+		truncated[idToken] = entries[idToken]
+	}
+	return truncated
+}
+
+// localListChanges returns the entries that must be sent in a differential update to change
+// the current entries to the required entries: removed entries do not have a status
+func localListChanges(current, required map[string]*store.LocalListEntry) []*store.LocalListEntry {
+	var changes []*store.LocalListEntry
+	for idToken, entry := range required {
+		if existing, ok := current[idToken]; !ok || !sameLocalListEntry(existing, entry) {
+			changes = append(changes, entry)
+		}
+	}
+	for idToken := range current {
+		if _, ok := required[idToken]; !ok {
+			changes = append(changes, &store.LocalListEntry{IdToken: idToken, IdTokenType: current[idToken].IdTokenType})
+		}
+	}
+	return changes
+}
+
+func sameLocalListEntry(a, b *store.LocalListEntry) bool {
+	if a.Status != b.Status || a.IdTokenType != b.IdTokenType {
+		return false
+	}
+	if a.GroupId == nil || b.GroupId == nil {
+		return a.GroupId == nil && b.GroupId == nil
+	}
+	return *a.GroupId == *b.GroupId
+}
+
+func newOcpp16SendLocalList(full bool, version int, entries []*store.LocalListEntry) *ocpp16.SendLocalListJson {
+	req := &ocpp16.SendLocalListJson{
+		ListVersion: version,
+		UpdateType:  ocpp16.SendLocalListJsonUpdateTypeDifferential,
+	}
+	if full {
+		req.UpdateType = ocpp16.SendLocalListJsonUpdateTypeFull
+	}
+	for _, entry := range entries {
+		data := ocpp16.SendLocalListJsonLocalAuthorizationListElem{
+			IdTag: entry.IdToken,
+		}
+		if entry.Status != "" {
+			data.IdTagInfo = &ocpp16.SendLocalListJsonLocalAuthorizationListElemIdTagInfo{
+				ParentIdTag: entry.GroupId,
+				Status:      ocpp16.SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatus(entry.Status),
+			}
+		}
+		req.LocalAuthorizationList = append(req.LocalAuthorizationList, data)
+	}
+	return req
+}
+
+func newOcpp201SendLocalList(full bool, version int, entries []*store.LocalListEntry) *ocpp201.SendLocalListRequestJson {
+	req := &ocpp201.SendLocalListRequestJson{
+		VersionNumber: version,
+		UpdateType:    ocpp201.UpdateEnumTypeDifferential,
+	}
+	if full {
+		req.UpdateType = ocpp201.UpdateEnumTypeFull
+	}
+	for _, entry := range entries {
+		data := ocpp201.AuthorizationData{
+			IdToken: ocpp201.IdTokenType{
+				Type:    ocpp201.IdTokenEnumType(entry.IdTokenType),
+				IdToken: entry.IdToken,
+			},
+		}
+		if data.IdToken.Type == "" {
+			// entries recorded before the type was kept were all sent as ISO14443
+			data.IdToken.Type = ocpp201.IdTokenEnumTypeISO14443
+		}
+		if entry.Status != "" {
+			data.IdTokenInfo = &ocpp201.IdTokenInfoType{
+				Status: ocpp201.AuthorizationStatusEnumType(entry.Status),
+			}
+			if entry.GroupId != nil {
+				data.IdTokenInfo.GroupIdToken = &ocpp201.IdTokenType{
+					Type:    ocpp201.IdTokenEnumTypeCentral,
+					IdToken: *entry.GroupId,
+				}
+			}
+		}
+		req.LocalAuthorizationList = append(req.LocalAuthorizationList, data)
+	}
+	return req
+}
@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func setLocalListTokens(t *testing.T, engine store.Engine) {
+	groupId := "group001"
+	for _, token := range []*store.Token{
+		{Uid: "ABC", Type: "RFID", Valid: true, CacheMode: store.CacheModeAllowed, GroupId: &groupId},
+		{Uid: "DEF", Type: "RFID", Valid: false, CacheMode: store.CacheModeAlways},
+		{Uid: "GHI", Type: "APP_USER", Valid: true, CacheMode: store.CacheModeAlways},
+		{Uid: "JKL", Type: "RFID", Valid: true, CacheMode: store.CacheModeNever},
+	} {
+		require.NoError(t, engine.SetToken(context.Background(), token))
+	}
+}
+
+// acceptV201LocalListRequests responds to the local list requests as a charge station with an
+// empty list would
+func acceptV201LocalListRequests(ctx context.Context, engine store.Engine, chargeStationId string, request ocpp.Request) error {
+	switch req := request.(type) {
+	case *ocpp201.GetLocalListVersionRequestJson:
+		return handlers.RecordLocalListVersion(ctx, engine, clock.RealClock{}, chargeStationId, 0)
+	case *ocpp201.SendLocalListRequestJson:
+		update := handlers.LocalListUpdate{
+			Full:    req.UpdateType == ocpp201.UpdateEnumTypeFull,
+			Version: req.VersionNumber,
+		}
+		for _, data := range req.LocalAuthorizationList {
+			entry := &store.LocalListEntry{IdToken: data.IdToken.IdToken, IdTokenType: string(data.IdToken.Type)}
+			if data.IdTokenInfo != nil {
+				entry.Status = string(data.IdTokenInfo.Status)
+				if data.IdTokenInfo.GroupIdToken != nil {
+					entry.GroupId = &data.IdTokenInfo.GroupIdToken.IdToken
+				}
+			}
+			update.Entries = append(update.Entries, entry)
+		}
+		return handlers.RecordLocalListUpdateResult(ctx, engine, clock.RealClock{}, chargeStationId, update, store.LocalListStatusAccepted)
+	}
+	return nil
+}
+
+func TestSyncV201LocalList(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	setLocalListTokens(t, engine)
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Entries: map[string]*store.LocalListEntry{},
+		Status:  store.LocalListStatusVersionUnknown,
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{engine: engine, updateFn: acceptV201LocalListRequests}
+	sync.SyncLocalLists(ctx, engine, clock.RealClock{}, nil, v201CallMaker, 100*time.Millisecond, 500*time.Millisecond)
+
+	require.Len(t, v201CallMaker.callEvents, 2)
+	assert.Equal(t, &ocpp201.GetLocalListVersionRequestJson{}, v201CallMaker.callEvents[0].request)
+	assert.Equal(t, &ocpp201.SendLocalListRequestJson{
+		VersionNumber: 1,
+		UpdateType:    ocpp201.UpdateEnumTypeDifferential,
+		LocalAuthorizationList: []ocpp201.AuthorizationData{
+			{
+				IdToken: ocpp201.IdTokenType{Type: ocpp201.IdTokenEnumTypeISO14443, IdToken: "ABC"},
+				IdTokenInfo: &ocpp201.IdTokenInfoType{
+					Status:       ocpp201.AuthorizationStatusEnumTypeAccepted,
+					GroupIdToken: &ocpp201.IdTokenType{Type: ocpp201.IdTokenEnumTypeCentral, IdToken: "group001"},
+				},
+			},
+			{
+				IdToken:     ocpp201.IdTokenType{Type: ocpp201.IdTokenEnumTypeISO14443, IdToken: "DEF"},
+				IdTokenInfo: &ocpp201.IdTokenInfoType{Status: ocpp201.AuthorizationStatusEnumTypeInvalid},
+			},
+		},
+	}, v201CallMaker.callEvents[1].request)
+
+	localList, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.LocalListStatusAccepted, localList.Status)
+	assert.Equal(t, 1, localList.Version)
+	assert.Len(t, localList.Entries, 2)
+
+	// the number of entries the charge station accepts in each request is read
+	variables, err := engine.LookupChargeStationVariables(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, variables)
+	assert.Equal(t, store.ChargeStationVariableStatusPending, variables.Variables["LocalAuthListCtrlr/ItemsPerMessage"].Status)
+}
+
+func TestSyncV201LocalListSplitsUpdateByItemsPerMessage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	setLocalListTokens(t, engine)
+	err := engine.SetToken(ctx, &store.Token{Uid: "E004010012345678", Type: "RFID", Valid: true, CacheMode: store.CacheModeAlways})
+	require.NoError(t, err)
+
+	err = engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	itemsPerMessage := "2"
+	err = engine.UpdateChargeStationVariables(ctx, "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"LocalAuthListCtrlr/ItemsPerMessage": {Value: &itemsPerMessage, Status: store.ChargeStationVariableStatusAccepted},
+		},
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Entries: map[string]*store.LocalListEntry{
+			"OLD": {IdToken: "OLD", IdTokenType: "ISO14443", Status: "Accepted"},
+		},
+		Status: store.LocalListStatusAccepted,
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{engine: engine, updateFn: acceptV201LocalListRequests}
+	sync.SyncLocalLists(ctx, engine, clock.RealClock{}, nil, v201CallMaker, 100*time.Millisecond, 500*time.Millisecond)
+
+	// the removal is sent first, then the remaining entries once the first update is accepted
+	require.Len(t, v201CallMaker.callEvents, 2)
+	assert.Equal(t, &ocpp201.SendLocalListRequestJson{
+		VersionNumber: 1,
+		UpdateType:    ocpp201.UpdateEnumTypeDifferential,
+		LocalAuthorizationList: []ocpp201.AuthorizationData{
+			{
+				IdToken: ocpp201.IdTokenType{Type: ocpp201.IdTokenEnumTypeISO14443, IdToken: "OLD"},
+			},
+			{
+				IdToken: ocpp201.IdTokenType{Type: ocpp201.IdTokenEnumTypeISO14443, IdToken: "ABC"},
+				IdTokenInfo: &ocpp201.IdTokenInfoType{
+					Status:       ocpp201.AuthorizationStatusEnumTypeAccepted,
+					GroupIdToken: &ocpp201.IdTokenType{Type: ocpp201.IdTokenEnumTypeCentral, IdToken: "group001"},
+				},
+			},
+		},
+	}, v201CallMaker.callEvents[0].request)
+	assert.Equal(t, &ocpp201.SendLocalListRequestJson{
+		VersionNumber: 2,
+		UpdateType:    ocpp201.UpdateEnumTypeDifferential,
+		LocalAuthorizationList: []ocpp201.AuthorizationData{
+			{
+				IdToken:     ocpp201.IdTokenType{Type: ocpp201.IdTokenEnumTypeISO14443, IdToken: "DEF"},
+				IdTokenInfo: &ocpp201.IdTokenInfoType{Status: ocpp201.AuthorizationStatusEnumTypeInvalid},
+			},
+			{
+				IdToken:     ocpp201.IdTokenType{Type: ocpp201.IdTokenEnumTypeISO15693, IdToken: "E004010012345678"},
+				IdTokenInfo: &ocpp201.IdTokenInfoType{Status: ocpp201.AuthorizationStatusEnumTypeAccepted},
+			},
+		},
+	}, v201CallMaker.callEvents[1].request)
+
+	localList, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, 2, localList.Version)
+	assert.Len(t, localList.Entries, 3)
+}
+
+func TestSyncV201LocalListSendsDifferentialUpdate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	setLocalListTokens(t, engine)
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	groupId := "group001"
+	err = engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		GroupId: &groupId,
+		Version: 4,
+		Entries: map[string]*store.LocalListEntry{
+			"ABC": {IdToken: "ABC", IdTokenType: "ISO14443", Status: "Accepted", GroupId: &groupId},
+			"OLD": {IdToken: "OLD", IdTokenType: "ISO15693", Status: "Accepted", GroupId: &groupId},
+		},
+		Status: store.LocalListStatusAccepted,
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{engine: engine, updateFn: acceptV201LocalListRequests}
+	sync.SyncLocalLists(ctx, engine, clock.RealClock{}, nil, v201CallMaker, 100*time.Millisecond, 500*time.Millisecond)
+
+	require.Len(t, v201CallMaker.callEvents, 1)
+	assert.Equal(t, &ocpp201.SendLocalListRequestJson{
+		VersionNumber: 5,
+		UpdateType:    ocpp201.UpdateEnumTypeDifferential,
+		LocalAuthorizationList: []ocpp201.AuthorizationData{
+			{
+				IdToken: ocpp201.IdTokenType{Type: ocpp201.IdTokenEnumTypeISO15693, IdToken: "OLD"},
+			},
+		},
+	}, v201CallMaker.callEvents[0].request)
+
+	localList, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, 5, localList.Version)
+	assert.Equal(t, map[string]*store.LocalListEntry{
+		"ABC": {IdToken: "ABC", IdTokenType: "ISO14443", Status: "Accepted", GroupId: &groupId},
+	}, localList.Entries)
+}
+
+func TestSyncV16LocalListSendsFullUpdateAfterVersionMismatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	setLocalListTokens(t, engine)
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationLocalList(ctx, "cs001", &store.ChargeStationLocalList{
+		Version: 2,
+		Entries: map[string]*store.LocalListEntry{
+			"DEF": {IdToken: "DEF", Status: "Invalid"},
+		},
+		Status: store.LocalListStatusVersionMismatch,
+	})
+	require.NoError(t, err)
+
+	// the charge station does not respond so its version is checked again
+	v16CallMaker := &mockCallMaker{engine: engine}
+	sync.SyncLocalLists(ctx, engine, clock.RealClock{}, v16CallMaker, nil, 100*time.Millisecond, 500*time.Millisecond)
+
+	require.Len(t, v16CallMaker.callEvents, 2)
+	groupId := "group001"
+	want := &ocpp16.SendLocalListJson{
+		ListVersion: 3,
+		UpdateType:  ocpp16.SendLocalListJsonUpdateTypeFull,
+		LocalAuthorizationList: []ocpp16.SendLocalListJsonLocalAuthorizationListElem{
+			{
+				IdTag: "ABC",
+				IdTagInfo: &ocpp16.SendLocalListJsonLocalAuthorizationListElemIdTagInfo{
+					ParentIdTag: &groupId,
+					Status:      ocpp16.SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatusAccepted,
+				},
+			},
+			{
+				IdTag: "DEF",
+				IdTagInfo: &ocpp16.SendLocalListJsonLocalAuthorizationListElemIdTagInfo{
+					Status: ocpp16.SendLocalListJsonLocalAuthorizationListElemIdTagInfoStatusInvalid,
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, v16CallMaker.callEvents[0].request)
+	assert.Equal(t, &ocpp16.GetLocalListVersionJson{}, v16CallMaker.callEvents[1].request)
+
+	localList, err := engine.LookupChargeStationLocalList(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.LocalListStatusVersionCheckPending, localList.Status)
+	assert.Equal(t, 2, localList.Version)
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// SyncReservations marks reservations that have passed their expiry date as expired. OCPP 2.0.1
+// charge stations report when a reservation expires, but OCPP 1.6 charge stations do not and a
+// reservation that was never answered would otherwise remain pending.
+func SyncReservations(ctx context.Context, engine store.Engine, clock clock.PassiveClock, runEvery time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync reservations")
+			return
+		case <-time.After(runEvery):
+			slog.Info("checking for expired reservations")
+			reservations, err := engine.ListExpiredReservations(ctx, clock.Now(), 50)
+			if err != nil {
+				slog.Error("list expired reservations", slog.String("err", err.Error()))
+				continue
+			}
+			for _, reservation := range reservations {
+				slog.Info("reservation expired", slog.String("chargeStationId", reservation.ChargeStationId),
+					slog.Int("reservationId", reservation.ReservationId))
+				err = handlers.EndReservation(ctx, engine, clock, reservation.ChargeStationId, reservation.ReservationId, store.ReservationStatusExpired)
+				if err != nil {
+					slog.Error("expire reservation", slog.String("err", err.Error()),
+						slog.String("chargeStationId", reservation.ChargeStationId),
+						slog.Int("reservationId", reservation.ReservationId))
+				}
+			}
+		}
+	}
+}
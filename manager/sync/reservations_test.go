@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestSyncReservations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now().UTC())
+
+	reservations := []*store.Reservation{
+		{ChargeStationId: "cs001", ReservationId: 1, ExpiryDate: clk.Now().Add(-time.Minute), Status: store.ReservationStatusAccepted},
+		{ChargeStationId: "cs001", ReservationId: 2, ExpiryDate: clk.Now().Add(-time.Minute), Status: store.ReservationStatusPending},
+		{ChargeStationId: "cs001", ReservationId: 3, ExpiryDate: clk.Now().Add(time.Minute), Status: store.ReservationStatusAccepted},
+		{ChargeStationId: "cs002", ReservationId: 1, ExpiryDate: clk.Now().Add(-time.Minute), Status: store.ReservationStatusUsed},
+	}
+	for _, reservation := range reservations {
+		require.NoError(t, engine.SetReservation(ctx, reservation))
+	}
+
+	sync.SyncReservations(ctx, engine, clk, 100*time.Millisecond)
+
+	for _, want := range []struct {
+		chargeStationId string
+		reservationId   int
+		status          store.ReservationStatus
+	}{
+		{"cs001", 1, store.ReservationStatusExpired},
+		{"cs001", 2, store.ReservationStatusExpired},
+		{"cs001", 3, store.ReservationStatusAccepted},
+		{"cs002", 1, store.ReservationStatusUsed},
+	} {
+		got, err := engine.LookupReservation(context.Background(), want.chargeStationId, want.reservationId)
+		require.NoError(t, err)
+		assert.Equal(t, want.status, got.Status, "%s/%d", want.chargeStationId, want.reservationId)
+	}
+}
@@ -0,0 +1,541 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// the settings used to change the security profile (OCPP 1.6 only) or network configuration
+// priority (OCPP 2.0.1 only)
+const (
+	ocpp16SecurityProfileKey               = "SecurityProfile"
+	ocpp201NetworkConfigurationPriorityKey = "OCPPCommCtrlr/NetworkConfigurationPriority"
+)
+
+// SyncSecurityProfileUpgrades moves charge stations through the steps required to upgrade them to a
+// higher security profile. Most steps are performed by queueing a certificate, setting or trigger
+// message that is sent to the charge station by the other sync processes: the step is complete once
+// the charge station has accepted the change. The upgrade is complete when the charge station
+// reconnects to the gateway using the new security profile: the upgrade fails if the charge station
+// has not reconnected within reconnectTimeout of being sent the new security profile.
+func SyncSecurityProfileUpgrades(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v16CallMaker, v201CallMaker handlers.CallMaker, runEvery time.Duration, retryAfter time.Duration, reconnectTimeout time.Duration) {
+	var previousChargeStationId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync security profile upgrades")
+			return
+		case <-time.After(runEvery):
+			slog.Info("checking for charge station security profile upgrades")
+			upgrades, err := engine.ListChargeStationSecurityProfileUpgrades(ctx, 50, previousChargeStationId)
+			if err != nil {
+				slog.Error("list charge station security profile upgrades", slog.String("err", err.Error()))
+				continue
+			}
+			if len(upgrades) > 0 {
+				previousChargeStationId = upgrades[len(upgrades)-1].ChargeStationId
+			} else {
+				previousChargeStationId = ""
+			}
+			for _, upgrade := range upgrades {
+				if upgrade.Step == store.SecurityProfileUpgradeStepCompleted ||
+					upgrade.Step == store.SecurityProfileUpgradeStepFailed {
+					continue
+				}
+				if upgrade.Step == store.SecurityProfileUpgradeStepAwaitReconnect {
+					err = expireSecurityProfileUpgradeReconnect(ctx, engine, clock, upgrade, reconnectTimeout)
+				} else {
+					err = syncSecurityProfileUpgrade(ctx, engine, clock, v16CallMaker, v201CallMaker, upgrade, retryAfter)
+				}
+				if err != nil {
+					slog.Error("sync security profile upgrade", slog.String("err", err.Error()),
+						slog.String("chargeStationId", upgrade.ChargeStationId))
+				}
+			}
+		}
+	}
+}
+
+func syncSecurityProfileUpgrade(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v16CallMaker, v201CallMaker handlers.CallMaker, upgrade *store.ChargeStationSecurityProfileUpgrade, retryAfter time.Duration) error {
+	csId := upgrade.ChargeStationId
+	details, err := engine.LookupChargeStationRuntimeDetails(ctx, csId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station runtime details: %w", err)
+	}
+	if details == nil {
+		slog.Warn("no runtime details for charge station", slog.String("chargeStationId", csId))
+		return nil
+	}
+	if details.OcppVersion != store.OcppVersion16 && details.OcppVersion != store.OcppVersion201 {
+		return fmt.Errorf("unsupported ocpp version: %s", details.OcppVersion)
+	}
+
+	callMaker := v201CallMaker
+	if details.OcppVersion == store.OcppVersion16 {
+		callMaker = v16CallMaker
+	}
+
+	var stepComplete, rebootRequired bool
+	var errorDescription string
+	switch upgrade.Step {
+	case store.SecurityProfileUpgradeStepInstallRootCertificate:
+		stepComplete, errorDescription, err = syncSecurityProfileUpgradeRootCertificate(ctx, engine, upgrade)
+	case store.SecurityProfileUpgradeStepSetBasicAuthPassword:
+		stepComplete, rebootRequired, errorDescription, err = syncSecurityProfileUpgradePassword(ctx, engine, clock, upgrade, details.OcppVersion)
+	case store.SecurityProfileUpgradeStepSignCertificate:
+		stepComplete, errorDescription, err = syncSecurityProfileUpgradeSignCertificate(ctx, engine, clock, upgrade, retryAfter)
+	case store.SecurityProfileUpgradeStepSetNetworkProfile:
+		if details.OcppVersion == store.OcppVersion16 {
+			// OCPP 1.6 charge stations reconnect using the new security profile once it has been accepted
+			stepComplete, rebootRequired, errorDescription, err = syncSecurityProfileUpgradeSetting(ctx, engine, clock, csId,
+				ocpp16SecurityProfileKey, strconv.Itoa(ocppSecurityProfile(upgrade.ToSecurityProfile)))
+		} else if clock.Now().After(upgrade.SendAfter) {
+			// the step is completed by the SetNetworkProfile result handler
+			err = sendSecurityProfileUpgradeNetworkProfile(ctx, engine, clock, callMaker, upgrade, details.OcppVersion, retryAfter)
+		}
+	case store.SecurityProfileUpgradeStepSetNetworkConfigurationPriority:
+		var priority string
+		priority, errorDescription, err = securityProfileUpgradeNetworkConfigurationPriority(ctx, engine, clock, upgrade)
+		if err == nil && priority != "" {
+			stepComplete, _, errorDescription, err = syncSecurityProfileUpgradeSetting(ctx, engine, clock, csId,
+				ocpp201NetworkConfigurationPriorityKey, priority)
+			// the new network configuration priority is only used once the charge station has been reset
+			rebootRequired = true
+		}
+	default:
+		return fmt.Errorf("unknown security profile upgrade step: %s", upgrade.Step)
+	}
+	if err != nil {
+		return err
+	}
+
+	if stepComplete && rebootRequired {
+		var resetSent bool
+		resetSent, err = sendSecurityProfileUpgradeReset(ctx, engine, clock, callMaker, csId, details.OcppVersion)
+		if err != nil {
+			return err
+		}
+		if !resetSent {
+			// the step is completed again (and the reset sent) once the pending reset has completed
+			return nil
+		}
+	}
+
+	if errorDescription != "" {
+		slog.Warn("security profile upgrade failed", slog.String("chargeStationId", csId),
+			slog.String("step", string(upgrade.Step)), slog.String("reason", errorDescription))
+		upgrade.Step = store.SecurityProfileUpgradeStepFailed
+		upgrade.ErrorDescription = errorDescription
+	} else if stepComplete {
+		upgrade.Step = nextSecurityProfileUpgradeStep(upgrade, details.OcppVersion)
+		upgrade.SendAfter = clock.Now()
+		slog.Info("security profile upgrade step complete", slog.String("chargeStationId", csId),
+			slog.String("nextStep", string(upgrade.Step)))
+	} else {
+		return nil
+	}
+
+	upgrade.UpdatedAt = clock.Now()
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, csId, upgrade)
+	if err != nil {
+		return fmt.Errorf("set charge station security profile upgrade: %w", err)
+	}
+	return nil
+}
+
+// expireSecurityProfileUpgradeReconnect fails the upgrade if the charge station has not reconnected
+// using the new security profile within reconnectTimeout of reaching the AwaitReconnect step
+func expireSecurityProfileUpgradeReconnect(ctx context.Context, engine store.Engine, clock clock.PassiveClock, upgrade *store.ChargeStationSecurityProfileUpgrade, reconnectTimeout time.Duration) error {
+	if !clock.Now().After(upgrade.UpdatedAt.Add(reconnectTimeout)) {
+		return nil
+	}
+
+	csId := upgrade.ChargeStationId
+	errorDescription := fmt.Sprintf("charge station did not reconnect using security profile %d within %s",
+		ocppSecurityProfile(upgrade.ToSecurityProfile), reconnectTimeout)
+	slog.Warn("security profile upgrade failed", slog.String("chargeStationId", csId),
+		slog.String("step", string(upgrade.Step)), slog.String("reason", errorDescription))
+	upgrade.Step = store.SecurityProfileUpgradeStepFailed
+	upgrade.ErrorDescription = errorDescription
+	upgrade.UpdatedAt = clock.Now()
+	err := engine.SetChargeStationSecurityProfileUpgrade(ctx, csId, upgrade)
+	if err != nil {
+		return fmt.Errorf("set charge station security profile upgrade: %w", err)
+	}
+	return nil
+}
+
+// nextSecurityProfileUpgradeStep returns the step that follows the current step, skipping those
+// that are not required to upgrade the charge station to the new security profile
+func nextSecurityProfileUpgradeStep(upgrade *store.ChargeStationSecurityProfileUpgrade, ocppVersion store.OcppVersion) store.SecurityProfileUpgradeStep {
+	switch upgrade.Step {
+	case store.SecurityProfileUpgradeStepInstallRootCertificate:
+		if upgrade.ToSecurityProfile == store.TLSWithBasicAuth {
+			return store.SecurityProfileUpgradeStepSetBasicAuthPassword
+		}
+		return store.SecurityProfileUpgradeStepSignCertificate
+	case store.SecurityProfileUpgradeStepSetBasicAuthPassword, store.SecurityProfileUpgradeStepSignCertificate:
+		return store.SecurityProfileUpgradeStepSetNetworkProfile
+	case store.SecurityProfileUpgradeStepSetNetworkProfile:
+		if ocppVersion == store.OcppVersion201 {
+			return store.SecurityProfileUpgradeStepSetNetworkConfigurationPriority
+		}
+		return store.SecurityProfileUpgradeStepAwaitReconnect
+	default:
+		return store.SecurityProfileUpgradeStepAwaitReconnect
+	}
+}
+
+// ocppSecurityProfile returns the security profile number used by OCPP (which starts at 1)
+func ocppSecurityProfile(securityProfile store.SecurityProfile) int {
+	return int(securityProfile) + 1
+}
+
+func syncSecurityProfileUpgradeRootCertificate(ctx context.Context, engine store.Engine, upgrade *store.ChargeStationSecurityProfileUpgrade) (bool, string, error) {
+	csId := upgrade.ChargeStationId
+	certId, err := handlers201.GetCertificateId(upgrade.CsmsRootCertificate)
+	if err != nil {
+		return false, "", fmt.Errorf("get csms root certificate id: %w", err)
+	}
+
+	certs, err := engine.LookupChargeStationInstallCertificates(ctx, csId)
+	if err != nil {
+		return false, "", fmt.Errorf("lookup charge station install certificates: %w", err)
+	}
+	if certs != nil {
+		for _, cert := range certs.Certificates {
+			if cert.CertificateId != certId {
+				continue
+			}
+			switch cert.CertificateInstallationStatus {
+			case store.CertificateInstallationAccepted:
+				return true, "", nil
+			case store.CertificateInstallationRejected:
+				return false, "charge station rejected the csms root certificate", nil
+			case store.CertificateInstallationErrored:
+				return false, fmt.Sprintf("charge station failed to install the csms root certificate: %s", cert.ErrorDescription), nil
+			}
+			return false, "", nil
+		}
+	}
+
+	slog.Info("queueing csms root certificate for security profile upgrade", slog.String("chargeStationId", csId))
+	err = engine.UpdateChargeStationInstallCertificates(ctx, csId, &store.ChargeStationInstallCertificates{
+		ChargeStationId: csId,
+		Certificates: []*store.ChargeStationInstallCertificate{
+			{
+				CertificateType:               store.CertificateTypeCSMS,
+				CertificateId:                 certId,
+				CertificateData:               upgrade.CsmsRootCertificate,
+				CertificateInstallationStatus: store.CertificateInstallationPending,
+			},
+		},
+	})
+	if err != nil {
+		return false, "", fmt.Errorf("update charge station install certificates: %w", err)
+	}
+	return false, "", nil
+}
+
+// syncSecurityProfileUpgradeSetting queues the setting if the charge station has not been sent the value
+// and reports whether the charge station has accepted it (and whether it must be reset to use it)
+func syncSecurityProfileUpgradeSetting(ctx context.Context, engine store.Engine, clock clock.PassiveClock, csId, key, value string) (bool, bool, string, error) {
+	settings, err := engine.LookupChargeStationSettings(ctx, csId)
+	if err != nil {
+		return false, false, "", fmt.Errorf("lookup charge station settings: %w", err)
+	}
+	var setting *store.ChargeStationSetting
+	if settings != nil {
+		setting = settings.Settings[key]
+	}
+
+	if setting == nil || setting.Value != value {
+		slog.Info("queueing setting for security profile upgrade", slog.String("chargeStationId", csId),
+			slog.String("key", key))
+		err = engine.UpdateChargeStationSettings(ctx, csId, &store.ChargeStationSettings{
+			Settings: map[string]*store.ChargeStationSetting{
+				key: {Value: value, Status: store.ChargeStationSettingStatusPending, SendAfter: clock.Now()},
+			},
+		})
+		if err != nil {
+			return false, false, "", fmt.Errorf("update charge station settings: %w", err)
+		}
+		return false, false, "", nil
+	}
+
+	switch setting.Status {
+	case store.ChargeStationSettingStatusAccepted:
+		return true, false, "", nil
+	case store.ChargeStationSettingStatusRebootRequired:
+		return true, true, "", nil
+	case store.ChargeStationSettingStatusRejected, store.ChargeStationSettingStatusNotSupported:
+		description := fmt.Sprintf("charge station responded to setting %s with %s", key, setting.Status)
+		if setting.ErrorCode != "" {
+			description = fmt.Sprintf("charge station responded to setting %s with error %s: %s", key, setting.ErrorCode, setting.ErrorDescription)
+		}
+		return false, false, description, nil
+	}
+	return false, false, "", nil
+}
+
+// syncSecurityProfileUpgradePassword rotates the charge station's basic auth password using the
+// pending password mechanism: the charge station may continue to connect using its current password
+// until it reconnects using the new password. It reports whether the charge station has accepted
+// the new password (and whether it must be reset to use it).
+func syncSecurityProfileUpgradePassword(ctx context.Context, engine store.Engine, clock clock.PassiveClock, upgrade *store.ChargeStationSecurityProfileUpgrade, ocppVersion store.OcppVersion) (bool, bool, string, error) {
+	csId := upgrade.ChargeStationId
+	if upgrade.Base64SHA256Password == "" {
+		slog.Info("rotating password for security profile upgrade", slog.String("chargeStationId", csId))
+		hash, err := handlers.RotatePassword(ctx, engine, clock, csId, ocppVersion)
+		if err != nil {
+			return false, false, "", fmt.Errorf("rotate password: %w", err)
+		}
+		upgrade.Base64SHA256Password = hash
+		upgrade.UpdatedAt = clock.Now()
+		err = engine.SetChargeStationSecurityProfileUpgrade(ctx, csId, upgrade)
+		if err != nil {
+			return false, false, "", fmt.Errorf("set charge station security profile upgrade: %w", err)
+		}
+		return false, false, "", nil
+	}
+
+	key := store.BasicAuthPasswordKey(ocppVersion)
+	settings, err := engine.LookupChargeStationSettings(ctx, csId)
+	if err != nil {
+		return false, false, "", fmt.Errorf("lookup charge station settings: %w", err)
+	}
+	var setting *store.ChargeStationSetting
+	if settings != nil {
+		setting = settings.Settings[key]
+	}
+	if setting == nil {
+		return false, false, "basic auth password setting has been removed", nil
+	}
+
+	switch setting.Status {
+	case store.ChargeStationSettingStatusAccepted:
+		return true, false, "", nil
+	case store.ChargeStationSettingStatusRebootRequired:
+		return true, true, "", nil
+	case store.ChargeStationSettingStatusRejected, store.ChargeStationSettingStatusNotSupported:
+		description := fmt.Sprintf("charge station responded to setting %s with %s", key, setting.Status)
+		if setting.ErrorCode != "" {
+			description = fmt.Sprintf("charge station responded to setting %s with error %s: %s", key, setting.ErrorCode, setting.ErrorDescription)
+		}
+		return false, false, description, nil
+	}
+
+	cs, err := engine.LookupChargeStation(ctx, csId)
+	if err != nil {
+		return false, false, "", fmt.Errorf("lookup charge station: %w", err)
+	}
+	if cs == nil {
+		return false, false, "charge station has been deleted", nil
+	}
+	if cs.PendingBase64SHA256Password != upgrade.Base64SHA256Password && cs.Base64SHA256Password != upgrade.Base64SHA256Password {
+		return false, false, "basic auth password was rotated during the upgrade", nil
+	}
+	return false, false, "", nil
+}
+
+func syncSecurityProfileUpgradeSignCertificate(ctx context.Context, engine store.Engine, clock clock.PassiveClock, upgrade *store.ChargeStationSecurityProfileUpgrade, retryAfter time.Duration) (bool, string, error) {
+	csId := upgrade.ChargeStationId
+	cs, err := engine.LookupChargeStation(ctx, csId)
+	if err != nil {
+		return false, "", fmt.Errorf("lookup charge station: %w", err)
+	}
+	if cs == nil {
+		return false, "charge station has been deleted", nil
+	}
+	// the certificate signed result handler records the hash of the new certificate
+	if cs.ClientCertificateHash != "" && cs.ClientCertificateHash != upgrade.ClientCertificateHash {
+		return true, "", nil
+	}
+
+	trigger, err := engine.LookupChargeStationTriggerMessage(ctx, csId)
+	if err != nil {
+		return false, "", fmt.Errorf("lookup charge station trigger message: %w", err)
+	}
+	if trigger != nil {
+		if trigger.TriggerMessage == store.TriggerMessageSignChargingStationCertificate {
+			switch trigger.TriggerStatus {
+			case store.TriggerStatusRejected, store.TriggerStatusNotImplemented:
+				return false, fmt.Sprintf("charge station responded to trigger %s with %s", trigger.TriggerMessage, trigger.TriggerStatus), nil
+			case store.TriggerStatusFailed:
+				return false, fmt.Sprintf("charge station responded to trigger %s with error %s: %s", trigger.TriggerMessage, trigger.ErrorCode, trigger.ErrorDescription), nil
+			}
+		}
+		// wait for the pending trigger to be sent
+		return false, "", nil
+	}
+
+	// an accepted trigger is deleted, so the trigger is only queued again if the charge station
+	// has not sent a certificate signing request in the time allowed
+	if clock.Now().After(upgrade.SendAfter) {
+		slog.Info("queueing sign certificate trigger for security profile upgrade", slog.String("chargeStationId", csId))
+		err = engine.SetChargeStationTriggerMessage(ctx, csId, &store.ChargeStationTriggerMessage{
+			TriggerMessage: store.TriggerMessageSignChargingStationCertificate,
+			TriggerStatus:  store.TriggerStatusPending,
+			SendAfter:      clock.Now(),
+		})
+		if err != nil {
+			return false, "", fmt.Errorf("set charge station trigger message: %w", err)
+		}
+		upgrade.SendAfter = clock.Now().Add(retryAfter)
+		upgrade.UpdatedAt = clock.Now()
+		err = engine.SetChargeStationSecurityProfileUpgrade(ctx, csId, upgrade)
+		if err != nil {
+			return false, "", fmt.Errorf("set charge station security profile upgrade: %w", err)
+		}
+	}
+	return false, "", nil
+}
+
+// securityProfileUpgradeNetworkConfigurationPriority returns the network configuration priority that
+// puts the new configuration slot first: the charge station falls back to the slots that it currently
+// uses if it can't connect using the new network connection profile. The current priority is read
+// when the network connection profile is sent, so no priority is returned until it has been read.
+func securityProfileUpgradeNetworkConfigurationPriority(ctx context.Context, engine store.Engine, clock clock.PassiveClock, upgrade *store.ChargeStationSecurityProfileUpgrade) (string, string, error) {
+	csId := upgrade.ChargeStationId
+	variables, err := engine.LookupChargeStationVariables(ctx, csId)
+	if err != nil {
+		return "", "", fmt.Errorf("lookup charge station variables: %w", err)
+	}
+	var variable *store.ChargeStationVariable
+	if variables != nil {
+		variable = variables.Variables[ocpp201NetworkConfigurationPriorityKey]
+	}
+
+	switch {
+	case variable == nil:
+		return "", "", readNetworkConfigurationPriority(ctx, engine, clock, csId)
+	case variable.Status == store.ChargeStationVariableStatusPending:
+		return "", "", nil
+	case variable.Status != store.ChargeStationVariableStatusAccepted || variable.Value == nil:
+		description := fmt.Sprintf("charge station responded to reading %s with %s", ocpp201NetworkConfigurationPriorityKey, variable.Status)
+		if variable.ErrorCode != "" {
+			description = fmt.Sprintf("charge station responded to reading %s with error %s: %s", ocpp201NetworkConfigurationPriorityKey, variable.ErrorCode, variable.ErrorDescription)
+		}
+		return "", description, nil
+	}
+
+	newSlot := strconv.Itoa(upgrade.ConfigurationSlot)
+	priority := []string{newSlot}
+	for _, slot := range strings.Split(*variable.Value, ",") {
+		slot = strings.TrimSpace(slot)
+		if slot != "" && slot != newSlot {
+			priority = append(priority, slot)
+		}
+	}
+	return strings.Join(priority, ","), "", nil
+}
+
+// readNetworkConfigurationPriority requests the charge station's current network configuration
+// priority: it is read by SyncVariables
+func readNetworkConfigurationPriority(ctx context.Context, engine store.Engine, clock clock.PassiveClock, csId string) error {
+	err := engine.UpdateChargeStationVariables(ctx, csId, &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			ocpp201NetworkConfigurationPriorityKey: {Status: store.ChargeStationVariableStatusPending, SendAfter: clock.Now()},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("update charge station variables: %w", err)
+	}
+	return nil
+}
+
+func sendSecurityProfileUpgradeNetworkProfile(ctx context.Context, engine store.Engine, clock clock.PassiveClock, callMaker handlers.CallMaker, upgrade *store.ChargeStationSecurityProfileUpgrade, ocppVersion store.OcppVersion, retryAfter time.Duration) error {
+	csId := upgrade.ChargeStationId
+	networkProfileOcppVersion, err := ocppNetworkProfileVersion(ocppVersion)
+	if err != nil {
+		return err
+	}
+
+	// the current network configuration priority is read so that it is up to date when the new
+	// configuration slot is added to it
+	err = readNetworkConfigurationPriority(ctx, engine, clock, csId)
+	if err != nil {
+		return err
+	}
+
+	upgrade.SendAfter = clock.Now().Add(retryAfter)
+	upgrade.UpdatedAt = clock.Now()
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, csId, upgrade)
+	if err != nil {
+		return fmt.Errorf("set charge station security profile upgrade: %w", err)
+	}
+
+	slog.Info("sending network profile for security profile upgrade", slog.String("chargeStationId", csId),
+		slog.Int("configurationSlot", upgrade.ConfigurationSlot))
+	err = callMaker.Send(ctx, csId, &ocpp201.SetNetworkProfileRequestJson{
+		ConfigurationSlot: upgrade.ConfigurationSlot,
+		ConnectionData: ocpp201.NetworkConnectionProfileType{
+			MessageTimeout:  upgrade.MessageTimeout,
+			OcppCsmsUrl:     upgrade.OcppCsmsUrl,
+			OcppInterface:   ocpp201.OCPPInterfaceEnumType(upgrade.OcppInterface),
+			OcppTransport:   ocpp201.OCPPTransportEnumTypeJSON,
+			OcppVersion:     networkProfileOcppVersion,
+			SecurityProfile: ocppSecurityProfile(upgrade.ToSecurityProfile),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("send set network profile request: %w", err)
+	}
+	return nil
+}
+
+// ocppNetworkProfileVersion returns the OCPP version for the new network connection profile: the
+// charge station carries on using the OCPP version that it is currently connected with
+func ocppNetworkProfileVersion(ocppVersion store.OcppVersion) (ocpp201.OCPPVersionEnumType, error) {
+	switch ocppVersion {
+	case store.OcppVersion201:
+		return ocpp201.OCPPVersionEnumTypeOCPP20, nil
+	default:
+		return "", fmt.Errorf("no network profile ocpp version for ocpp version: %s", ocppVersion)
+	}
+}
+
+// sendSecurityProfileUpgradeReset resets the charge station (once it is idle) so that it reconnects:
+// the reset is recorded as an operation in the same way as one requested through the API. No reset
+// is sent (and false is returned) while another reset is pending.
+func sendSecurityProfileUpgradeReset(ctx context.Context, engine store.Engine, clock clock.PassiveClock, callMaker handlers.CallMaker, csId string, ocppVersion store.OcppVersion) (bool, error) {
+	var req ocpp.Request
+	if ocppVersion == store.OcppVersion16 {
+		req = &ocpp16.ResetJson{Type: ocpp16.ResetJsonTypeSoft}
+	} else {
+		req = &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeOnIdle}
+	}
+	_, err := handlers.StartOperation(ctx, engine, clock, csId, "Reset", req)
+	if err != nil {
+		if errors.Is(err, handlers.ErrOperationPending) {
+			slog.Info("waiting for pending reset before resetting charge station for security profile upgrade",
+				slog.String("chargeStationId", csId))
+			return false, nil
+		}
+		return false, err
+	}
+
+	slog.Info("resetting charge station for security profile upgrade", slog.String("chargeStationId", csId))
+	err = callMaker.Send(ctx, csId, req)
+	if err != nil {
+		// mark the reset as errored so that it is retried on the next sync
+		err = errors.Join(err, handlers.RecordOperationFailure(ctx, engine, clock, csId, "Reset", err))
+		return false, fmt.Errorf("send reset request: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,383 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"k8s.io/utils/clock"
+)
+
+func runSecurityProfileUpgrades(engine store.Engine, v16CallMaker, v201CallMaker *mockCallMaker) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	sync.SyncSecurityProfileUpgrades(ctx, engine, clock.RealClock{}, v16CallMaker, v201CallMaker, 50*time.Millisecond, time.Minute, time.Hour)
+}
+
+func setSecurityProfileUpgradeSettingStatus(t *testing.T, engine store.Engine, csId, key string, status store.ChargeStationSettingStatus) {
+	settings, err := engine.LookupChargeStationSettings(context.Background(), csId)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Settings[key])
+	err = engine.UpdateChargeStationSettings(context.Background(), csId, &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			key: {Value: settings.Settings[key].Value, Status: status},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func acceptV201NetworkProfile(ctx context.Context, engine store.Engine, chargeStationId string, request ocpp.Request) error {
+	if req, ok := request.(*ocpp201.SetNetworkProfileRequestJson); ok {
+		handler := handlers201.SetNetworkProfileResultHandler{Store: engine, Clock: clock.RealClock{}}
+		return handler.HandleCallResult(ctx, chargeStationId, req, &ocpp201.SetNetworkProfileResponseJson{
+			Status: ocpp201.SetNetworkProfileStatusEnumTypeAccepted,
+		}, nil)
+	}
+	return nil
+}
+
+func TestSyncV201SecurityProfileUpgradeToClientCertificates(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{OcppVersion: "2.0.1"})
+	require.NoError(t, err)
+	err = engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:              "cs001",
+		SecurityProfile: store.TLSWithBasicAuth,
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", &store.ChargeStationSecurityProfileUpgrade{
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepSignCertificate,
+		OcppCsmsUrl:         "wss://csms.example.com/ws",
+		ConfigurationSlot:   2,
+		OcppInterface:       "Wireless1",
+		MessageTimeout:      45,
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{engine: engine, updateFn: acceptV201NetworkProfile}
+
+	// the charge station is asked to sign a new certificate
+	runSecurityProfileUpgrades(engine, nil, v201CallMaker)
+
+	trigger, err := engine.LookupChargeStationTriggerMessage(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, trigger)
+	assert.Equal(t, store.TriggerMessageSignChargingStationCertificate, trigger.TriggerMessage)
+	assert.Equal(t, store.TriggerStatusPending, trigger.TriggerStatus)
+
+	// the charge station accepts the trigger and installs the signed certificate
+	err = engine.DeleteChargeStationTriggerMessage(ctx, "cs001")
+	require.NoError(t, err)
+	err = engine.UpdateChargeStation(ctx, "cs001", &store.ChargeStation{
+		SecurityProfile:       store.TLSWithBasicAuth,
+		ClientCertificateHash: "new-certificate-hash",
+	})
+	require.NoError(t, err)
+
+	runSecurityProfileUpgrades(engine, nil, v201CallMaker)
+
+	require.Len(t, v201CallMaker.callEvents, 1)
+	assert.Equal(t, &ocpp201.SetNetworkProfileRequestJson{
+		ConfigurationSlot: 2,
+		ConnectionData: ocpp201.NetworkConnectionProfileType{
+			MessageTimeout:  45,
+			OcppCsmsUrl:     "wss://csms.example.com/ws",
+			OcppInterface:   ocpp201.OCPPInterfaceEnumTypeWireless1,
+			OcppTransport:   ocpp201.OCPPTransportEnumTypeJSON,
+			OcppVersion:     ocpp201.OCPPVersionEnumTypeOCPP20,
+			SecurityProfile: 3,
+		},
+	}, v201CallMaker.callEvents[0].request)
+
+	upgrade, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepSetNetworkConfigurationPriority, upgrade.Step)
+
+	// the current network configuration priority is read when the network profile is sent
+	variables, err := engine.LookupChargeStationVariables(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, variables.Variables["OCPPCommCtrlr/NetworkConfigurationPriority"])
+	assert.Equal(t, store.ChargeStationVariableStatusPending, variables.Variables["OCPPCommCtrlr/NetworkConfigurationPriority"].Status)
+	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, settings)
+
+	currentPriority := "1,0"
+	err = engine.UpdateChargeStationVariables(ctx, "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"OCPPCommCtrlr/NetworkConfigurationPriority": {Value: &currentPriority, Status: store.ChargeStationVariableStatusAccepted},
+		},
+	})
+	require.NoError(t, err)
+
+	runSecurityProfileUpgrades(engine, nil, v201CallMaker)
+
+	// the new configuration slot is used first, falling back to the current slots
+	settings, err = engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "2,1,0", settings.Settings["OCPPCommCtrlr/NetworkConfigurationPriority"].Value)
+	assert.Equal(t, store.ChargeStationSettingStatusPending, settings.Settings["OCPPCommCtrlr/NetworkConfigurationPriority"].Status)
+
+	// the charge station is reset once it accepts the new network configuration priority
+	setSecurityProfileUpgradeSettingStatus(t, engine, "cs001", "OCPPCommCtrlr/NetworkConfigurationPriority", store.ChargeStationSettingStatusAccepted)
+
+	runSecurityProfileUpgrades(engine, nil, v201CallMaker)
+
+	require.Len(t, v201CallMaker.callEvents, 2)
+	assert.Equal(t, &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeOnIdle}, v201CallMaker.callEvents[1].request)
+
+	operation, err := engine.LookupChargeStationOperation(ctx, "cs001", "Reset")
+	require.NoError(t, err)
+	require.NotNil(t, operation)
+	assert.Equal(t, store.ChargeStationOperationStatusPending, operation.Status)
+
+	upgrade, err = engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepAwaitReconnect, upgrade.Step)
+
+	// the security profile is only changed once the charge station reconnects
+	cs, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.TLSWithBasicAuth, cs.SecurityProfile)
+}
+
+func TestSyncV201SecurityProfileUpgradeFailsWhenNetworkConfigurationPriorityCannotBeRead(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{OcppVersion: "2.0.1"})
+	require.NoError(t, err)
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", &store.ChargeStationSecurityProfileUpgrade{
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepSetNetworkConfigurationPriority,
+		ConfigurationSlot:   2,
+	})
+	require.NoError(t, err)
+	err = engine.UpdateChargeStationVariables(ctx, "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"OCPPCommCtrlr/NetworkConfigurationPriority": {Status: store.ChargeStationVariableStatusRejected},
+		},
+	})
+	require.NoError(t, err)
+
+	runSecurityProfileUpgrades(engine, nil, &mockCallMaker{engine: engine})
+
+	upgrade, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepFailed, upgrade.Step)
+	assert.Equal(t, "charge station responded to reading OCPPCommCtrlr/NetworkConfigurationPriority with Rejected", upgrade.ErrorDescription)
+
+	// the network configuration priority is left unchanged
+	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, settings)
+}
+
+func TestSyncV16SecurityProfileUpgradeToTLSWithBasicAuth(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{OcppVersion: "1.6"})
+	require.NoError(t, err)
+	err = engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:                   "cs001",
+		SecurityProfile:      store.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "old-password-hash",
+	})
+	require.NoError(t, err)
+
+	rootCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("root")}))
+	rootCertificateId, err := handlers201.GetCertificateId(rootCertificate)
+	require.NoError(t, err)
+
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", &store.ChargeStationSecurityProfileUpgrade{
+		FromSecurityProfile: store.UnsecuredTransportWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithBasicAuth,
+		Step:                store.SecurityProfileUpgradeStepInstallRootCertificate,
+		CsmsRootCertificate: rootCertificate,
+	})
+	require.NoError(t, err)
+
+	v16CallMaker := &mockCallMaker{engine: engine}
+
+	// the csms root certificate is queued for installation
+	runSecurityProfileUpgrades(engine, v16CallMaker, nil)
+
+	certs, err := engine.LookupChargeStationInstallCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	require.Len(t, certs.Certificates, 1)
+	assert.Equal(t, store.CertificateTypeCSMS, certs.Certificates[0].CertificateType)
+	assert.Equal(t, rootCertificateId, certs.Certificates[0].CertificateId)
+	assert.Equal(t, store.CertificateInstallationPending, certs.Certificates[0].CertificateInstallationStatus)
+
+	// then the new password once the certificate has been installed
+	err = engine.UpdateChargeStationInstallCertificates(ctx, "cs001", &store.ChargeStationInstallCertificates{
+		Certificates: []*store.ChargeStationInstallCertificate{
+			{
+				CertificateType:               store.CertificateTypeCSMS,
+				CertificateId:                 rootCertificateId,
+				CertificateData:               rootCertificate,
+				CertificateInstallationStatus: store.CertificateInstallationAccepted,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	runSecurityProfileUpgrades(engine, v16CallMaker, nil)
+
+	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Len(t, settings.Settings["AuthorizationKey"].Value, 40)
+	assert.Equal(t, store.ChargeStationSettingStatusPending, settings.Settings["AuthorizationKey"].Status)
+
+	upgrade, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.NotEmpty(t, upgrade.Base64SHA256Password)
+
+	cs, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "old-password-hash", cs.Base64SHA256Password)
+	assert.Equal(t, upgrade.Base64SHA256Password, cs.PendingBase64SHA256Password)
+
+	// the upgrade moves on when the charge station accepts the new password: the current
+	// password is only replaced once the charge station connects with the new one
+	setSecurityProfileUpgradeSettingStatus(t, engine, "cs001", "AuthorizationKey", store.ChargeStationSettingStatusAccepted)
+
+	runSecurityProfileUpgrades(engine, v16CallMaker, nil)
+
+	cs, err = engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "old-password-hash", cs.Base64SHA256Password)
+	assert.Equal(t, upgrade.Base64SHA256Password, cs.PendingBase64SHA256Password)
+
+	upgrade, err = engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepSetNetworkProfile, upgrade.Step)
+
+	settings, err = engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "2", settings.Settings["SecurityProfile"].Value)
+
+	// the charge station is reset if it requires a reboot to use the new security profile: but not
+	// while another reset is pending
+	setSecurityProfileUpgradeSettingStatus(t, engine, "cs001", "SecurityProfile", store.ChargeStationSettingStatusRebootRequired)
+	err = engine.SetChargeStationOperation(ctx, "cs001", &store.ChargeStationOperation{
+		Operation:   "Reset",
+		Request:     `{"type":"Hard"}`,
+		Status:      store.ChargeStationOperationStatusPending,
+		RequestedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	runSecurityProfileUpgrades(engine, v16CallMaker, nil)
+
+	require.Empty(t, v16CallMaker.callEvents)
+	upgrade, err = engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepSetNetworkProfile, upgrade.Step)
+
+	err = engine.SetChargeStationOperation(ctx, "cs001", &store.ChargeStationOperation{
+		Operation:   "Reset",
+		Request:     `{"type":"Hard"}`,
+		Response:    `{"status":"Rejected"}`,
+		Status:      "Rejected",
+		RequestedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	runSecurityProfileUpgrades(engine, v16CallMaker, nil)
+
+	require.Len(t, v16CallMaker.callEvents, 1)
+	assert.Equal(t, &ocpp16.ResetJson{Type: ocpp16.ResetJsonTypeSoft}, v16CallMaker.callEvents[0].request)
+
+	upgrade, err = engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepAwaitReconnect, upgrade.Step)
+
+	cs, err = engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.UnsecuredTransportWithBasicAuth, cs.SecurityProfile)
+}
+
+func TestSyncSecurityProfileUpgradeFailsWhenSignCertificateTriggerIsRejected(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{OcppVersion: "2.0.1"})
+	require.NoError(t, err)
+	err = engine.CreateChargeStation(ctx, &store.ChargeStation{
+		Id:              "cs001",
+		SecurityProfile: store.TLSWithBasicAuth,
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", &store.ChargeStationSecurityProfileUpgrade{
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepSignCertificate,
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationTriggerMessage(ctx, "cs001", &store.ChargeStationTriggerMessage{
+		TriggerMessage: store.TriggerMessageSignChargingStationCertificate,
+		TriggerStatus:  store.TriggerStatusRejected,
+	})
+	require.NoError(t, err)
+
+	runSecurityProfileUpgrades(engine, nil, &mockCallMaker{engine: engine})
+
+	upgrade, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepFailed, upgrade.Step)
+	assert.Equal(t, "charge station responded to trigger SignChargingStationCertificate with Rejected", upgrade.ErrorDescription)
+}
+
+func TestSyncSecurityProfileUpgradeFailsWhenChargeStationDoesNotReconnect(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for _, csId := range []string{"cs001", "cs002"} {
+		err := engine.SetChargeStationRuntimeDetails(ctx, csId, &store.ChargeStationRuntimeDetails{OcppVersion: "2.0.1"})
+		require.NoError(t, err)
+	}
+	err := engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs001", &store.ChargeStationSecurityProfileUpgrade{
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepAwaitReconnect,
+		UpdatedAt:           time.Now().Add(-2 * time.Hour),
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationSecurityProfileUpgrade(ctx, "cs002", &store.ChargeStationSecurityProfileUpgrade{
+		FromSecurityProfile: store.TLSWithBasicAuth,
+		ToSecurityProfile:   store.TLSWithClientSideCertificates,
+		Step:                store.SecurityProfileUpgradeStepAwaitReconnect,
+		UpdatedAt:           time.Now(),
+	})
+	require.NoError(t, err)
+
+	runSecurityProfileUpgrades(engine, nil, &mockCallMaker{engine: engine})
+
+	upgrade, err := engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepFailed, upgrade.Step)
+	assert.Equal(t, "charge station did not reconnect using security profile 3 within 1h0m0s", upgrade.ErrorDescription)
+
+	upgrade, err = engine.LookupChargeStationSecurityProfileUpgrade(ctx, "cs002")
+	require.NoError(t, err)
+	assert.Equal(t, store.SecurityProfileUpgradeStepAwaitReconnect, upgrade.Step)
+}
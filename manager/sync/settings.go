@@ -4,15 +4,13 @@ package sync
 
 import (
 	"context"
-	"fmt"
 	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"golang.org/x/exp/slog"
 	"k8s.io/utils/clock"
-	"regexp"
-	"strconv"
 	"time"
 )
 
@@ -49,7 +47,7 @@ func SyncSettings(ctx context.Context, engine store.Engine, clock clock.PassiveC
 						if setting.Status == store.ChargeStationSettingStatusPending && clock.Now().After(setting.SendAfter) {
 							slog.Info("updating charge station settings", slog.String("chargeStationId", csId),
 								slog.String("key", name),
-								slog.String("value", setting.Value),
+								slog.String("value", store.RetainedSettingValue(name, setting.Value)),
 								slog.String("OcppVersion", string(details.OcppVersion)))
 							err = engine.UpdateChargeStationSettings(ctx, csId, &store.ChargeStationSettings{
 								Settings: map[string]*store.ChargeStationSetting{
@@ -67,7 +65,7 @@ func SyncSettings(ctx context.Context, engine store.Engine, clock clock.PassiveC
 							err := v16CallMaker.Send(ctx, csId, req)
 							if err != nil {
 								slog.Error("send change configuration request", slog.String("err", err.Error()),
-									slog.String("chargeStationId", csId), slog.String("key", name), slog.String("value", setting.Value))
+									slog.String("chargeStationId", csId), slog.String("key", name), slog.String("value", store.RetainedSettingValue(name, setting.Value)))
 							}
 						}
 					}
@@ -76,7 +74,7 @@ func SyncSettings(ctx context.Context, engine store.Engine, clock clock.PassiveC
 					for name, setting := range pendingSetting.Settings {
 						slog.Info("updating charge station settings", slog.String("chargeStationId", csId),
 							slog.String("key", name),
-							slog.String("value", setting.Value),
+							slog.String("value", store.RetainedSettingValue(name, setting.Value)),
 							slog.String("OcppVersion", string(details.OcppVersion)))
 						if setting.Status == store.ChargeStationSettingStatusPending && clock.Now().After(setting.SendAfter) {
 							err = engine.UpdateChargeStationSettings(ctx, csId, &store.ChargeStationSettings{
@@ -114,46 +112,14 @@ func SyncSettings(ctx context.Context, engine store.Engine, clock clock.PassiveC
 	}
 }
 
-// ocpp201NamePattern is a regexp that matches the following:
-// - Component name - mandatory (first component)
-// - Component instance - optional (first component following a ';')
-// - EVSE id - optional (second component following a ';')
-// - Variable name - mandatory (first component following a '/')
-// - Variable instance - optional (first component following a ';')
-// - Attribute type - optional (second component following a ';')
-var ocpp201NamePattern = regexp.MustCompile(`^([A-Za-z0-9*\-_=:+|@.]+)(?:;([A-Za-z0-9*\-_=:+|@.]+))?(?:;(\d+))?/([A-Za-z0-9*\-_=:+|@.]+)(?:;([A-Za-z0-9*\-_=:+|@.]+))?(?:;(Actual|Target|MinSet|MaxSet))?$`)
-
 func parseOcpp201Name(name string, set *ocpp201.SetVariableDataType) error {
-	matches := ocpp201NamePattern.FindStringSubmatch(name)
-	if len(matches) != 7 {
-		return fmt.Errorf("invalid ocpp 2.0.1 name: %s", name)
-	}
-	set.Component = ocpp201.ComponentType{
-		Name: matches[1],
-	}
-	if matches[2] != "" {
-		set.Component.Instance = &matches[2]
-	}
-	if matches[3] != "" {
-		evseId, err := strconv.Atoi(matches[3])
-		if err != nil {
-			return fmt.Errorf("invalid ocpp 2.0.1 name (EVSE id is not an integer): %s", name)
-		}
-		set.Component.Evse = &ocpp201.EVSEType{
-			Id: evseId,
-		}
+	component, variable, attributeType, err := handlers201.ParseVariableName(name)
+	if err != nil {
+		return err
 	}
-
-	set.Variable = ocpp201.VariableType{
-		Name: matches[4],
-	}
-	if matches[5] != "" {
-		set.Variable.Instance = &matches[5]
-	}
-	if matches[6] != "" {
-		set.AttributeType = (*ocpp201.AttributeEnumType)(&matches[6])
-	}
-
+	set.Component = component
+	set.Variable = variable
+	set.AttributeType = attributeType
 	return nil
 }
 
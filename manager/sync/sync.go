@@ -6,6 +6,7 @@ import (
 	"context"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"go.opentelemetry.io/otel/trace"
@@ -13,7 +14,8 @@ import (
 	"time"
 )
 
-func Sync(storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tracer, emitter transport.Emitter) {
+func Sync(storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tracer, emitter transport.Emitter, driftCheckInterval time.Duration, reapplyDriftedSettings bool, certRenewalPeriod time.Duration,
+	certificateAuditInterval time.Duration, expectedRootCertificates map[store.CertificateType]services.RootCertificateProviderService) {
 	v16SyncCallMaker := ocpp16.NewCallMaker(emitter)
 	dataTransferCallMaker := ocpp16.NewDataTransferCallMaker(emitter)
 	v201SyncCallMaker := ocpp201.NewCallMaker(emitter)
@@ -25,6 +27,19 @@ func Sync(storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tra
 		v201SyncCallMaker,
 		1*time.Minute,
 		2*time.Minute)
+	go SyncVariables(context.Background(),
+		storageEngine,
+		clock,
+		v16SyncCallMaker,
+		v201SyncCallMaker,
+		1*time.Minute,
+		2*time.Minute)
+	if driftCheckInterval > 0 {
+		go SyncSettingsDrift(context.Background(),
+			storageEngine,
+			driftCheckInterval,
+			reapplyDriftedSettings)
+	}
 	go SyncCertificates(context.Background(),
 		storageEngine,
 		clock,
@@ -32,6 +47,48 @@ func Sync(storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tra
 		v201SyncCallMaker,
 		1*time.Minute,
 		2*time.Minute)
+	if certRenewalPeriod > 0 {
+		go SyncCertificateRenewals(context.Background(),
+			storageEngine,
+			clock,
+			certRenewalPeriod,
+			1*time.Hour,
+			24*time.Hour)
+	}
+	if certificateAuditInterval > 0 {
+		go SyncCertificateAudit(context.Background(),
+			storageEngine,
+			clock,
+			dataTransferCallMaker,
+			v201SyncCallMaker,
+			expectedRootCertificates,
+			certificateAuditInterval)
+	}
+	go SyncLocalLists(context.Background(),
+		storageEngine,
+		clock,
+		v16SyncCallMaker,
+		v201SyncCallMaker,
+		1*time.Minute,
+		2*time.Minute)
+	go SyncDisplayMessages(context.Background(),
+		storageEngine,
+		clock,
+		v201SyncCallMaker,
+		1*time.Minute,
+		2*time.Minute)
+	go SyncSecurityProfileUpgrades(context.Background(),
+		storageEngine,
+		clock,
+		v16SyncCallMaker,
+		v201SyncCallMaker,
+		1*time.Minute,
+		10*time.Minute,
+		24*time.Hour)
+	go SyncReservations(context.Background(),
+		storageEngine,
+		clock,
+		1*time.Minute)
 	go SyncTriggers(context.Background(),
 		tracer,
 		storageEngine,
@@ -68,7 +68,8 @@ func SyncTriggers(ctx context.Context,
 						}
 
 						csId := pendingTriggerMessage.ChargeStationId
-						if clock.Now().After(pendingTriggerMessage.SendAfter) {
+						// a trigger that failed with a CallError is not retried: it has to be requested again
+						if pendingTriggerMessage.TriggerStatus != store.TriggerStatusFailed && clock.Now().After(pendingTriggerMessage.SendAfter) {
 							span.SetAttributes(attribute.String("sync.trigger.ocpp_version", string(details.OcppVersion)))
 							err = engine.SetChargeStationTriggerMessage(ctx, csId, &store.ChargeStationTriggerMessage{
 								TriggerMessage: pendingTriggerMessage.TriggerMessage,
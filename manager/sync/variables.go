@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"sort"
+	"time"
+)
+
+func SyncVariables(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v16CallMaker, v201CallMaker handlers.CallMaker, runEvery time.Duration, retryAfter time.Duration) {
+	var previousChargeStationId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync variables")
+			return
+		case <-time.After(runEvery):
+			slog.Info("checking for pending charge station variable requests")
+			variables, err := engine.ListChargeStationVariables(ctx, 50, previousChargeStationId)
+			if err != nil {
+				slog.Error("list charge station variables", slog.String("err", err.Error()))
+				continue
+			}
+			if len(variables) > 0 {
+				previousChargeStationId = variables[len(variables)-1].ChargeStationId
+			} else {
+				previousChargeStationId = ""
+			}
+			for _, pendingVariables := range filterPendingVariables(variables) {
+				csId := pendingVariables.ChargeStationId
+				details, err := engine.LookupChargeStationRuntimeDetails(ctx, csId)
+				if err != nil {
+					slog.Error("lookup charge station runtime details", slog.String("err", err.Error()),
+						slog.String("chargeStationId", csId))
+					continue
+				}
+				if details == nil {
+					slog.Warn("no runtime details for charge station", slog.String("chargeStationId", csId))
+					continue
+				}
+
+				names := readyToSendVariableNames(pendingVariables, clock.Now())
+				if len(names) == 0 {
+					continue
+				}
+
+				updated := make(map[string]*store.ChargeStationVariable, len(names))
+				for _, name := range names {
+					updated[name] = &store.ChargeStationVariable{
+						Status:    store.ChargeStationVariableStatusPending,
+						SendAfter: clock.Now().Add(retryAfter),
+					}
+				}
+
+				switch details.OcppVersion {
+				case "1.6":
+					slog.Info("getting charge station configuration", slog.String("chargeStationId", csId),
+						slog.Any("keys", names))
+					err = engine.UpdateChargeStationVariables(ctx, csId, &store.ChargeStationVariables{
+						Variables: updated,
+					})
+					if err != nil {
+						slog.Error("update charge station variables", slog.String("err", err.Error()))
+						continue
+					}
+					err = v16CallMaker.Send(ctx, csId, &ocpp16.GetConfigurationJson{
+						Key: names,
+					})
+					if err != nil {
+						slog.Error("send get configuration request", slog.String("err", err.Error()),
+							slog.String("chargeStationId", csId))
+					}
+				case "2.0.1":
+					slog.Info("getting charge station variables", slog.String("chargeStationId", csId),
+						slog.Any("names", names))
+					var data []ocpp201.GetVariableDataType
+					for _, name := range names {
+						var variable ocpp201.GetVariableDataType
+						err = parseOcpp201GetName(name, &variable)
+						if err != nil {
+							// the charge station can never return a value so stop asking for it
+							slog.Error("parse ocpp 2.0.1 name", slog.String("err", err.Error()))
+							updated[name].Status = store.ChargeStationVariableStatusRejected
+							continue
+						}
+						data = append(data, variable)
+					}
+					err = engine.UpdateChargeStationVariables(ctx, csId, &store.ChargeStationVariables{
+						Variables: updated,
+					})
+					if err != nil {
+						slog.Error("update charge station variables", slog.String("err", err.Error()))
+						continue
+					}
+					if len(data) > 0 {
+						err = v201CallMaker.Send(ctx, csId, &ocpp201.GetVariablesRequestJson{
+							GetVariableData: data,
+						})
+						if err != nil {
+							slog.Error("send get variables request", slog.String("err", err.Error()),
+								slog.String("chargeStationId", csId))
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func parseOcpp201GetName(name string, get *ocpp201.GetVariableDataType) error {
+	var set ocpp201.SetVariableDataType
+	if err := parseOcpp201Name(name, &set); err != nil {
+		return err
+	}
+	get.Component = set.Component
+	get.Variable = set.Variable
+	get.AttributeType = set.AttributeType
+	return nil
+}
+
+// readyToSendVariableNames returns the (sorted) names of the pending variables
+// that have not been requested within the retry period
+func readyToSendVariableNames(variables *store.ChargeStationVariables, now time.Time) []string {
+	var names []string
+	for _, name := range maps.Keys(variables.Variables) {
+		variable := variables.Variables[name]
+		if variable.Status == store.ChargeStationVariableStatusPending && now.After(variable.SendAfter) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func filterPendingVariables(variables []*store.ChargeStationVariables) []*store.ChargeStationVariables {
+	var pendingVariables []*store.ChargeStationVariables
+	for _, csVariables := range variables {
+		for _, v := range csVariables.Variables {
+			if v.Status == store.ChargeStationVariableStatusPending {
+				pendingVariables = append(pendingVariables, csVariables)
+				break
+			}
+		}
+	}
+	return pendingVariables
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func updateV16VariablesToAccepted(ctx context.Context, engine store.Engine, chargeStationId string, request ocpp.Request) error {
+	req := request.(*ocpp16.GetConfigurationJson)
+	value := "value"
+	variables := make(map[string]*store.ChargeStationVariable)
+	for _, key := range req.Key {
+		variables[key] = &store.ChargeStationVariable{Value: &value, Status: store.ChargeStationVariableStatusAccepted}
+	}
+	return engine.UpdateChargeStationVariables(ctx, chargeStationId, &store.ChargeStationVariables{
+		Variables: variables,
+	})
+}
+
+func TestSyncV16GetVariables(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+	})
+	require.NoError(t, err)
+	err = engine.UpdateChargeStationVariables(ctx, "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"HeartbeatInterval":        {Status: store.ChargeStationVariableStatusPending},
+			"MeterValueSampleInterval": {Status: store.ChargeStationVariableStatusPending},
+		},
+	})
+	require.NoError(t, err)
+
+	v16CallMaker := &mockCallMaker{engine: engine, updateFn: updateV16VariablesToAccepted}
+	sync.SyncVariables(ctx, engine, clock.RealClock{}, v16CallMaker, nil, 100*time.Millisecond, 500*time.Millisecond)
+
+	require.Len(t, v16CallMaker.callEvents, 1)
+	assert.Equal(t, &ocpp16.GetConfigurationJson{
+		Key: []string{"HeartbeatInterval", "MeterValueSampleInterval"},
+	}, v16CallMaker.callEvents[0].request)
+
+	variables, err := engine.LookupChargeStationVariables(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Len(t, variables.Variables, 2)
+	for _, v := range variables.Variables {
+		assert.Equal(t, store.ChargeStationVariableStatusAccepted, v.Status)
+	}
+}
+
+func TestSyncV201GetVariables(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	err = engine.UpdateChargeStationVariables(ctx, "cs001", &store.ChargeStationVariables{
+		Variables: map[string]*store.ChargeStationVariable{
+			"OCPPCommCtrlr/HeartbeatInterval;;Target": {Status: store.ChargeStationVariableStatusPending},
+			"EVSE;;1/Power": {Status: store.ChargeStationVariableStatusPending},
+			"NotAValidName": {Status: store.ChargeStationVariableStatusPending},
+		},
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{engine: engine}
+	sync.SyncVariables(ctx, engine, clock.RealClock{}, nil, v201CallMaker, 100*time.Millisecond, 2*time.Second)
+
+	target := ocpp201.AttributeEnumTypeTarget
+	require.Len(t, v201CallMaker.callEvents, 1)
+	assert.Equal(t, &ocpp201.GetVariablesRequestJson{
+		GetVariableData: []ocpp201.GetVariableDataType{
+			{
+				Component: ocpp201.ComponentType{Name: "EVSE", Evse: &ocpp201.EVSEType{Id: 1}},
+				Variable:  ocpp201.VariableType{Name: "Power"},
+			},
+			{
+				Component:     ocpp201.ComponentType{Name: "OCPPCommCtrlr"},
+				Variable:      ocpp201.VariableType{Name: "HeartbeatInterval"},
+				AttributeType: &target,
+			},
+		},
+	}, v201CallMaker.callEvents[0].request)
+
+	variables, err := engine.LookupChargeStationVariables(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ChargeStationVariableStatusPending, variables.Variables["EVSE;;1/Power"].Status)
+	assert.Equal(t, store.ChargeStationVariableStatusRejected, variables.Variables["NotAValidName"].Status)
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+// ConnectionEventType identifies whether a charge station has connected to or
+// disconnected from a gateway.
+type ConnectionEventType string
+
+const (
+	ConnectionEventConnected    ConnectionEventType = "Connected"
+	ConnectionEventDisconnected ConnectionEventType = "Disconnected"
+)
+
+// ConnectionEvent is published by the gateway when a charge station connects or disconnects.
+// The ConnectionId identifies a single websocket connection: it is used to ignore a disconnect
+// event for a connection that has already been replaced.
+type ConnectionEvent struct {
+	Event           ConnectionEventType `json:"event"`
+	ConnectionId    string              `json:"connection_id"`
+	GatewayId       string              `json:"gateway_id"`
+	RemoteAddress   string              `json:"remote_address,omitempty"`
+	Subprotocol     string              `json:"subprotocol,omitempty"`
+	SecurityProfile int                 `json:"security_profile"`
+	ConnectedAt     *time.Time          `json:"connected_at,omitempty"`
+}
+
+type ConnectionEventHandler interface {
+	// Handle a ConnectionEvent published by a gateway for the charge station identified by
+	// the chargeStationId.
+	Handle(ctx context.Context, chargeStationId string, event *ConnectionEvent)
+}
+
+type ConnectionEventHandlerFunc func(ctx context.Context, chargeStationId string, event *ConnectionEvent)
+
+func (h ConnectionEventHandlerFunc) Handle(ctx context.Context, chargeStationId string, event *ConnectionEvent) {
+	h(ctx, chargeStationId, event)
+}
+
+type ConnectionEventListener interface {
+	// ConnectConnectionEvents establishes a connection to the broker and subscribes to receive the
+	// connection events for all charge stations. The events are delivered to the provided
+	// ConnectionEventHandler.
+	ConnectConnectionEvents(ctx context.Context, handler ConnectionEventHandler) (Connection, error)
+}
+
+// ConnectionController is used to ask the gateway that owns a charge station's connection to
+// close it.
+type ConnectionController interface {
+	// Disconnect asks the gateway to close the connection identified by connectionId. If the
+	// connectionId is empty then every connection for the charge station is closed.
+	Disconnect(ctx context.Context, chargeStationId, connectionId string) error
+}
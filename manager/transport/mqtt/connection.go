@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// ConnectConnectionEvents subscribes to the connection events published by the gateways on
+// <prefix>/connection/<cs-id>. The subscription is shared by the listener's group.
+func (l *Listener) ConnectConnectionEvents(ctx context.Context, handler transport.ConnectionEventHandler) (transport.Connection, error) {
+	var err error
+
+	ctx, cancel := context.WithTimeout(ctx, l.mqttConnectTimeout)
+	defer cancel()
+
+	clientId := fmt.Sprintf("%s-%s", l.mqttGroup, randSeq(5))
+
+	readyCh := make(chan struct{})
+
+	topic := fmt.Sprintf("$share/%s/%s/connection/#", l.mqttGroup, l.mqttPrefix)
+
+	conn := new(connection)
+	mqttRouter := paho.NewStandardRouter()
+	conn.mqttConn, err = autopaho.NewConnection(context.Background(), autopaho.ClientConfig{
+		BrokerUrls:        l.mqttBrokerUrls,
+		KeepAlive:         l.mqttKeepAliveInterval,
+		ConnectRetryDelay: l.mqttConnectRetryDelay,
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
+			_, err := manager.Subscribe(ctx, &paho.Subscribe{
+				Subscriptions: []paho.SubscribeOptions{{Topic: topic}},
+			})
+			if err != nil {
+				slog.Error("failed to subscribe to topic", "topic", topic)
+				return
+			}
+			mqttRouter.UnregisterHandler(topic)
+			mqttRouter.RegisterHandler(topic, func(mqttMsg *paho.Publish) {
+				newCtx, span := l.tracer.Start(context.Background(),
+					fmt.Sprintf("%s receive", getTopicPattern(mqttMsg.Topic)),
+					trace.WithSpanKind(trace.SpanKindConsumer),
+					trace.WithAttributes(
+						semconv.MessagingSystem("mqtt"),
+						semconv.MessagingConsumerID(clientId),
+						semconv.MessagingMessagePayloadSizeBytes(len(mqttMsg.Payload)),
+						semconv.MessagingOperationKey.String("receive"),
+					))
+				defer span.End()
+
+				topicParts := strings.Split(mqttMsg.Topic, "/")
+				var chargeStationId = topicParts[len(topicParts)-1]
+
+				var event transport.ConnectionEvent
+				err := json.Unmarshal(mqttMsg.Payload, &event)
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, "unable to unmarshal connection event")
+					slog.Warn("unable to unmarshal connection event", "err", err)
+					return
+				}
+
+				span.SetAttributes(
+					attribute.String("csId", chargeStationId),
+					attribute.String("connection.event", string(event.Event)),
+					attribute.String("connection.id", event.ConnectionId),
+					attribute.String("connection.gateway_id", event.GatewayId))
+
+				handler.Handle(newCtx, chargeStationId, &event)
+			})
+			readyCh <- struct{}{}
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: clientId,
+			Router:   mqttRouter,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, errors.New("timeout waiting for mqtt connectionDetails setup")
+	case <-readyCh:
+		return conn, nil
+	}
+}
+
+type connectionControl struct {
+	Action       string `json:"action"`
+	ConnectionId string `json:"connection_id,omitempty"`
+}
+
+// Disconnect publishes a disconnect request on <prefix>/control/<cs-id>: the request is
+// honoured by the gateway that holds the charge station's connection.
+func (e *Emitter) Disconnect(ctx context.Context, chargeStationId, connectionId string) error {
+	topic := fmt.Sprintf("%s/control/%s", e.mqttPrefix, chargeStationId)
+	payload, err := json.Marshal(connectionControl{
+		Action:       "Disconnect",
+		ConnectionId: connectionId,
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling disconnect request: %v", err)
+	}
+
+	newCtx, span := e.tracer.Start(ctx,
+		fmt.Sprintf("%s/control/# publish", e.mqttPrefix),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("mqtt"),
+			semconv.MessagingMessagePayloadSizeBytes(len(payload)),
+			semconv.MessagingOperationKey.String("publish"),
+			attribute.String("csId", chargeStationId),
+			attribute.String("connection.id", connectionId),
+		))
+	defer span.End()
+
+	err = e.ensureConnection(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to MQTT: %v", err)
+	}
+
+	_, err = e.conn.Publish(newCtx, &paho.Publish{
+		Topic:   topic,
+		Payload: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("publishing to %s: %v", topic, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"context"
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/mochi-co/mqtt/v2/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/mqtt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestListenerProcessesConnectionEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, clientUrl := mqtt.NewBroker(t)
+	defer func() {
+		err := broker.Close()
+		assert.NoError(t, err)
+	}()
+	err := broker.Serve()
+	require.NoError(t, err)
+
+	receivedEventCh := make(chan struct{})
+	handler := func(ctx context.Context, chargeStationId string, event *transport.ConnectionEvent) {
+		assert.Equal(t, "cs001", chargeStationId)
+		assert.Equal(t, transport.ConnectionEventConnected, event.Event)
+		assert.Equal(t, "conn-1", event.ConnectionId)
+		assert.Equal(t, "gateway-1", event.GatewayId)
+		assert.Equal(t, "ocpp2.0.1", event.Subprotocol)
+		assert.Equal(t, 1, event.SecurityProfile)
+		receivedEventCh <- struct{}{}
+	}
+
+	listener := mqtt.NewListener(mqtt.WithMqttBrokerUrl[mqtt.Listener](clientUrl))
+	conn, err := listener.ConnectConnectionEvents(ctx, transport.ConnectionEventHandlerFunc(handler))
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		require.NoError(t, err)
+	}()
+
+	cl := broker.NewClient(nil, "local", "inline", true)
+	err = broker.InjectPacket(cl, packets.Packet{
+		FixedHeader: packets.FixedHeader{
+			Type: packets.Publish,
+		},
+		TopicName: "cs/connection/cs001",
+		Payload:   []byte(`{"event":"Connected","connection_id":"conn-1","gateway_id":"gateway-1","subprotocol":"ocpp2.0.1","security_profile":1}`),
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for test to complete")
+	case <-receivedEventCh:
+		// do nothing
+	}
+}
+
+func TestEmitterPublishesDisconnectRequest(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, clientUrl := mqtt.NewBroker(t)
+	defer func() {
+		err := broker.Close()
+		assert.NoError(t, err)
+	}()
+	err := broker.Serve()
+	require.NoError(t, err)
+
+	emitter := mqtt.NewEmitter(
+		mqtt.WithMqttBrokerUrl[mqtt.Emitter](clientUrl),
+		mqtt.WithMqttPrefix[mqtt.Emitter]("cs"))
+
+	rcvdCh := make(chan struct{})
+	router := paho.NewSingleHandlerRouter(func(publish *paho.Publish) {
+		assert.Equal(t, "cs/control/cs001", publish.Topic)
+		assert.JSONEq(t, `{"action":"Disconnect","connection_id":"conn-1"}`, string(publish.Payload))
+		rcvdCh <- struct{}{}
+	})
+	mqttClient, err := autopaho.NewConnection(context.Background(), autopaho.ClientConfig{
+		BrokerUrls:        []*url.URL{clientUrl},
+		KeepAlive:         10,
+		ConnectRetryDelay: 10,
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
+			_, err := manager.Subscribe(context.Background(), &paho.Subscribe{
+				Subscriptions: []paho.SubscribeOptions{{Topic: "cs/control/cs001"}},
+			})
+			require.NoError(t, err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: "test",
+			Router:   router,
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = mqttClient.Disconnect(ctx)
+	}()
+	err = mqttClient.AwaitConnection(ctx)
+	require.NoError(t, err)
+
+	controller, ok := emitter.(transport.ConnectionController)
+	require.True(t, ok)
+	err = controller.Disconnect(ctx, "cs001", "conn-1")
+	require.NoError(t, err)
+
+	select {
+	case <-rcvdCh:
+		// success
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for test")
+	}
+}